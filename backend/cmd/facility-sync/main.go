@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/config"
+	"github.com/besart951/go_infra_link/backend/internal/db"
+	"github.com/besart951/go_infra_link/backend/internal/wire"
+	"github.com/google/uuid"
+)
+
+const usage = `usage: facility-sync [flags] <command>
+
+commands:
+  export  write the local project's bundle to -out
+  plan    report what importing -in into the local project would change
+  apply   import -in into the local project
+  push    send the local project to -remote-project on -remote (plan with -dry-run)
+  pull    import -remote-project from -remote into the local project (plan with -dry-run)`
+
+type options struct {
+	project       string
+	remote        string
+	remoteProject string
+	token         string
+	in            string
+	out           string
+	dryRun        bool
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.project, "project", "", "local project ID")
+	flag.StringVar(&opts.remote, "remote", "", "base URL of the other instance, e.g. https://staging.example.com")
+	flag.StringVar(&opts.remoteProject, "remote-project", "", "project ID on the other instance (defaults to -project)")
+	flag.StringVar(&opts.token, "token", os.Getenv("FACILITY_SYNC_TOKEN"), "access token for the other instance")
+	flag.StringVar(&opts.in, "in", "-", "bundle file to read, - for stdin")
+	flag.StringVar(&opts.out, "out", "-", "file to write, - for stdout")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "only report the plan for push and pull")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, flag.Arg(0), opts); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, opts options) error {
+	projectID, err := uuid.Parse(opts.project)
+	if err != nil {
+		return fmt.Errorf("-project: %w", err)
+	}
+	if opts.remoteProject == "" {
+		opts.remoteProject = opts.project
+	}
+	switch command {
+	case "export", "plan", "apply", "push", "pull":
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+	service, err := localService()
+	if err != nil {
+		return err
+	}
+	switch command {
+	case "export":
+		bundle, err := service.Export(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		return writeJSON(opts.out, bundle)
+	case "plan", "apply":
+		bundle, err := readBundle(opts.in)
+		if err != nil {
+			return err
+		}
+		plan, err := importLocal(ctx, service, projectID, bundle, command == "plan")
+		return report(opts.out, plan, err)
+	case "push":
+		return push(ctx, service, projectID, opts)
+	default:
+		return pull(ctx, service, projectID, opts)
+	}
+}
+
+func localService() (*facilitysync.Service, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	database, err := db.Connect(cfg.DBConfig)
+	if err != nil {
+		return nil, fmt.Errorf("connect db: %w", err)
+	}
+	return wire.NewFacilitySyncService(database), nil
+}
+
+func push(ctx context.Context, service *facilitysync.Service, projectID uuid.UUID, opts options) error {
+	client, err := newRemoteClient(opts.remote, opts.token)
+	if err != nil {
+		return err
+	}
+	bundle, err := service.Export(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	plan, err := client.send(ctx, opts.remoteProject, bundle, opts.dryRun)
+	return report(opts.out, plan, err)
+}
+
+func pull(ctx context.Context, service *facilitysync.Service, projectID uuid.UUID, opts options) error {
+	client, err := newRemoteClient(opts.remote, opts.token)
+	if err != nil {
+		return err
+	}
+	bundle, err := client.bundle(ctx, opts.remoteProject)
+	if err != nil {
+		return err
+	}
+	plan, err := importLocal(ctx, service, projectID, bundle, opts.dryRun)
+	return report(opts.out, plan, err)
+}
+
+func importLocal(ctx context.Context, service *facilitysync.Service, projectID uuid.UUID, bundle facilitysync.Bundle, dryRun bool) (facilitysync.Plan, error) {
+	if dryRun {
+		return service.Plan(ctx, projectID, bundle)
+	}
+	return service.Apply(ctx, projectID, bundle)
+}
+
+// report writes the plan even when the import was refused, so unresolved
+// references can be inspected.
+func report(path string, plan facilitysync.Plan, err error) error {
+	if plan.Summary != nil {
+		if writeErr := writeJSON(path, plan); writeErr != nil {
+			return errors.Join(err, writeErr)
+		}
+	}
+	return err
+}
+
+func readBundle(path string) (facilitysync.Bundle, error) {
+	var bundle facilitysync.Bundle
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return bundle, err
+		}
+		defer file.Close()
+		reader = file
+	}
+	if err := json.NewDecoder(reader).Decode(&bundle); err != nil {
+		return bundle, fmt.Errorf("read bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+func writeJSON(path string, payload any) error {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+)
+
+// remoteClient talks to the facility sync endpoints of another instance. It
+// authenticates like the browser: the access token travels as a cookie and
+// the CSRF double submit uses a token generated per client.
+type remoteClient struct {
+	base  *url.URL
+	token string
+	csrf  string
+	http  *http.Client
+}
+
+func newRemoteClient(rawURL, token string) (*remoteClient, error) {
+	if rawURL == "" || token == "" {
+		return nil, fmt.Errorf("-remote and -token are required")
+	}
+	base, err := url.Parse(strings.TrimRight(rawURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("-remote: %w", err)
+	}
+	csrf := make([]byte, 16)
+	if _, err := rand.Read(csrf); err != nil {
+		return nil, err
+	}
+	return &remoteClient{base: base, token: token, csrf: hex.EncodeToString(csrf), http: &http.Client{Timeout: 5 * time.Minute}}, nil
+}
+
+func (c *remoteClient) bundle(ctx context.Context, projectID string) (facilitysync.Bundle, error) {
+	var bundle facilitysync.Bundle
+	_, err := c.do(ctx, http.MethodGet, projectID, "bundle", nil, &bundle)
+	return bundle, err
+}
+
+// send plans or applies bundle on the remote project. A refused apply still
+// returns the remote plan.
+func (c *remoteClient) send(ctx context.Context, projectID string, bundle facilitysync.Bundle, dryRun bool) (facilitysync.Plan, error) {
+	action := "apply"
+	if dryRun {
+		action = "plan"
+	}
+	var plan facilitysync.Plan
+	_, err := c.do(ctx, http.MethodPost, projectID, action, bundle, &plan)
+	return plan, err
+}
+
+// remoteError is the error body of the remote endpoints; only its code and
+// message matter here.
+type remoteError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// conflictError tells the two 409 answers apart. Unresolved reference data
+// comes back as the plan, rows changed since the plan as an error body with
+// the conflict code.
+func conflictError(data []byte, target any) error {
+	var failure remoteError
+	if err := json.Unmarshal(data, &failure); err != nil {
+		return fmt.Errorf("decode conflict response: %w", err)
+	}
+	switch failure.Code {
+	case "":
+		if err := json.Unmarshal(data, target); err != nil {
+			return fmt.Errorf("decode conflict response: %w", err)
+		}
+		return fmt.Errorf("remote refused the bundle: %w", facilitysync.ErrUnresolvedReferences)
+	case "conflict":
+		return fmt.Errorf("remote refused the bundle: %s: %w", failure.Message, domain.ErrConflict)
+	default:
+		return fmt.Errorf("remote refused the bundle: %s: %s", failure.Code, failure.Message)
+	}
+}
+
+func (c *remoteClient) do(ctx context.Context, method, projectID, action string, body, target any) (int, error) {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		payload = bytes.NewReader(data)
+	}
+	endpoint := c.base.JoinPath("api", "v1", "projects", projectID, "facility-sync", action)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), payload)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CSRF-Token", c.csrf)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: c.token})
+	req.AddCookie(&http.Cookie{Name: "csrf_token", Value: c.csrf})
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return resp.StatusCode, fmt.Errorf("%s %s: %s: %s", method, endpoint.Path, resp.Status, strings.TrimSpace(string(message)))
+	}
+	if resp.StatusCode == http.StatusConflict {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return resp.StatusCode, err
+		}
+		return resp.StatusCode, conflictError(data, target)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return resp.StatusCode, fmt.Errorf("decode %s response: %w", action, err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+)
+
+func TestSendTellsConflictAnswersApart(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		want error
+	}{
+		{name: "unresolved references", body: `{"project_id":"00000000-0000-0000-0000-000000000000","applied":false,"summary":{}}`, want: facilitysync.ErrUnresolvedReferences},
+		{name: "changed since the plan", body: `{"error":"conflict","code":"conflict","message":"Conflict"}`, want: domain.ErrConflict},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+			client, err := newRemoteClient(server.URL, "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			plan, err := client.send(t.Context(), "project", facilitysync.Bundle{}, false)
+
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("send() = %v, want %v", err, tc.want)
+			}
+			if (plan.Summary != nil) != (tc.want == facilitysync.ErrUnresolvedReferences) {
+				t.Fatalf("plan = %+v; only a refused apply carries the plan", plan)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	notificationservice "github.com/besart951/go_infra_link/backend/internal/service/notification"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
+)
+
+const usage = `usage: i18n-coverage [flags]
+
+Reports per translation catalog which keys used by the backend are missing
+and which catalog keys are unused. Used keys are the string literals passed
+to the localized error helpers (handlerutil.LocalizedError,
+RespondLocalizedError, localizedNotFound, ...) and the notification event
+templates.`
+
+type options struct {
+	translations string
+	source       string
+	reference    string
+	scopes       string
+	json         bool
+	strict       bool
+}
+
+// catalogKeyPattern matches dotted catalog keys such as "errors.not_found"
+// and skips bare error codes such as "not_found".
+var catalogKeyPattern = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)+$`)
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.translations, "translations", "./translations", "directory of the translation catalogs")
+	flag.StringVar(&opts.source, "source", "./internal", "Go source tree to scan for used keys")
+	flag.StringVar(&opts.reference, "reference", "de_CH", "catalog the other catalogs are translated from")
+	flag.StringVar(&opts.scopes, "scope", "notifications.events.", "comma-separated key prefixes owned by the backend; unreferenced keys below them are unused")
+	flag.BoolVar(&opts.json, "json", false, "write the report as JSON")
+	flag.BoolVar(&opts.strict, "strict", false, "exit with status 1 when a catalog misses a used key")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	complete, err := run(os.Stdout, opts)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if opts.strict && !complete {
+		os.Exit(1)
+	}
+}
+
+func run(out io.Writer, opts options) (bool, error) {
+	translator := i18n.NewTranslator(opts.reference)
+	if _, err := i18n.NewLoader(opts.translations).LoadInto(translator); err != nil {
+		return false, err
+	}
+	used, err := usedKeys(opts.source)
+	if err != nil {
+		return false, err
+	}
+	used = append(used, notificationservice.TemplateCatalogKeys()...)
+
+	report := i18n.Coverage(translator, opts.reference, used, strings.Split(opts.scopes, ","))
+	complete := true
+	for _, coverage := range report {
+		complete = complete && coverage.Complete()
+	}
+
+	if opts.json {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return complete, encoder.Encode(report)
+	}
+	for _, coverage := range report {
+		_, _ = fmt.Fprintf(out, "%s: %d missing, %d untranslated, %d unused\n",
+			coverage.Locale, len(coverage.Missing), len(coverage.Untranslated), len(coverage.Unused))
+		writeKeys(out, "missing", coverage.Missing)
+		writeKeys(out, "untranslated", coverage.Untranslated)
+		writeKeys(out, "unused", coverage.Unused)
+	}
+	return complete, nil
+}
+
+func writeKeys(out io.Writer, label string, keys []string) {
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(out, "  %-12s %s\n", label, key)
+	}
+}
+
+// usedKeys collects the catalog keys passed as string literals to functions
+// whose name contains "Localized" or "localized". Keys built at runtime are
+// not found.
+func usedKeys(root string) ([]string, error) {
+	keys := make(map[string]struct{})
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || !isLocalizedCall(call) {
+				return true
+			}
+			for _, arg := range call.Args {
+				literal, ok := arg.(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(literal.Value)
+				if err == nil && catalogKeyPattern.MatchString(value) {
+					keys[value] = struct{}{}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func isLocalizedCall(call *ast.CallExpr) bool {
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	default:
+		return false
+	}
+	return strings.Contains(name, "Localized") || strings.Contains(name, "localized")
+}
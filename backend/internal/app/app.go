@@ -21,10 +21,14 @@ func Run() error {
 	defer cleanup()
 	stopNotificationWorker := runtimeDeps.services.Notification.StartEmailOutboxWorker(time.Minute, 100)
 	defer stopNotificationWorker()
+	stopWebhookWorker := runtimeDeps.services.Webhook.StartDeliveryWorker(15*time.Second, 100)
+	defer stopWebhookWorker()
 	stopRegistrationCleanup := runtimeDeps.services.UserRegistration.StartCleanupWorker(24 * time.Hour)
 	defer stopRegistrationCleanup()
 	stopDeletedUserPurge := runtimeDeps.services.User.StartDeletedUserPurgeWorker(time.Hour, 100)
 	defer stopDeletedUserPurge()
+	stopChangeCompaction := runtimeDeps.services.Project.Changes.StartCompactionWorker(time.Hour)
+	defer stopChangeCompaction()
 
 	router := newRouter(runtimeDeps)
 	return serveHTTP(runtimeDeps.cfg, runtimeDeps.log, router)
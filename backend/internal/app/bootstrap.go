@@ -57,13 +57,16 @@ func bootstrapRuntime(cfg config.Config, log applogger.Logger) (*runtime, func()
 		cleanup()
 	}
 
+	translator, loader := initializeTranslator(log)
 	services, err := wire.NewServices(gormDB, repos, wire.ServiceConfig{
 		JWTSecret:       cfg.JWTSecret,
+		WebhookSecret:   cfg.WebhookSecretKey,
 		Issuer:          config.DefaultIssuer,
 		AccessTokenTTL:  cfg.AccessTokenTTL,
 		RefreshTokenTTL: cfg.RefreshTokenTTL,
 		AppPublicURL:    cfg.AppPublicURL,
 		Runtime:         runtimeAdapters,
+		Translator:      translator,
 	})
 	if err != nil {
 		log.Error("Failed to initialize services", "err", err)
@@ -82,7 +85,6 @@ func bootstrapRuntime(cfg config.Config, log applogger.Logger) (*runtime, func()
 		return nil, func() {}, fmt.Errorf("seed notifications: %w", err)
 	}
 
-	translator, loader := initializeTranslator(log)
 	handlers := wire.NewHandlers(
 		services,
 		runtimeAdapters,
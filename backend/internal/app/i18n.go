@@ -1,6 +1,8 @@
 package app
 
 import (
+	"slices"
+
 	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	applogger "github.com/besart951/go_infra_link/backend/pkg/logger"
 )
@@ -11,21 +13,16 @@ func initializeTranslator(log applogger.Logger) (*i18n.Translator, *i18n.Loader)
 	translator := i18n.NewTranslator(defaultLocale)
 	loader := i18n.NewLoader("./translations")
 
-	allTranslations, err := loader.LoadAll()
+	locales, err := loader.LoadInto(translator)
 	if err != nil {
 		log.Error("Failed to load translations", "err", err)
 		return translator, loader
 	}
 
-	for lang, translations := range allTranslations {
-		if err := translator.LoadLanguage(lang, translations); err != nil {
-			log.Error("Failed to load language translations", "lang", lang, "err", err)
-		}
-	}
-
-	if _, ok := allTranslations[defaultLocale]; !ok {
+	if !slices.Contains(locales, defaultLocale) {
 		log.Info("Default language translations may not be loaded", "locale", defaultLocale)
 	}
+	log.Info("Loaded translation catalogs", "locales", locales)
 
 	return translator, loader
 }
@@ -0,0 +1,122 @@
+package facilitysync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Fingerprints describes every reference row of one instance. Natural keys:
+// apparats and system parts by short name, system types by name, state texts
+// by reference number, notification classes by NC, alarm types and units by
+// code, alarm type fields by alarm type code and field key, and alarm
+// definitions by alarm type code and name.
+func Fingerprints(references References) []Fingerprint {
+	prints := make([]Fingerprint, 0)
+	for _, item := range references.Apparats {
+		prints = append(prints, fingerprint(ReferenceApparat, item.ID, strings.ToUpper(item.ShortName), []any{item.Name, item.Description}))
+	}
+	for _, item := range references.SystemParts {
+		prints = append(prints, fingerprint(ReferenceSystemPart, item.ID, strings.ToUpper(item.ShortName), []any{item.Name, item.Description}))
+	}
+	for _, item := range references.SystemTypes {
+		prints = append(prints, fingerprint(ReferenceSystemType, item.ID, item.Name, []any{item.NumberMin, item.NumberMax}))
+	}
+	// State texts and notification classes hash their whole row; the base
+	// is cleared so identity and timestamps do not count as drift.
+	for _, item := range references.StateTexts {
+		content := item
+		content.Base = domain.Base{}
+		prints = append(prints, fingerprint(ReferenceStateText, item.ID, strconv.Itoa(item.RefNumber), content))
+	}
+	for _, item := range references.NotificationClasses {
+		content := item
+		content.Base = domain.Base{}
+		prints = append(prints, fingerprint(ReferenceNotificationClass, item.ID, strconv.Itoa(item.Nc), content))
+	}
+	for _, item := range references.Units {
+		prints = append(prints, fingerprint(ReferenceUnit, item.ID, item.Code, []any{item.Symbol, item.Name, item.Dimension, item.Factor, item.Offset}))
+	}
+	prints = append(prints, alarmFingerprints(references)...)
+	sortFingerprints(prints)
+	return prints
+}
+
+func alarmFingerprints(references References) []Fingerprint {
+	prints := make([]Fingerprint, 0)
+	codes := make(map[uuid.UUID]string, len(references.AlarmTypes))
+	for _, alarmType := range references.AlarmTypes {
+		codes[alarmType.ID] = alarmType.Code
+		prints = append(prints, fingerprint(ReferenceAlarmType, alarmType.ID, alarmType.Code, []any{alarmType.Name}))
+		for _, field := range alarmType.Fields {
+			if field.AlarmField == nil {
+				continue
+			}
+			prints = append(prints, fingerprint(ReferenceAlarmTypeField, field.ID, alarmType.Code+"/"+field.AlarmField.Key, []any{
+				field.DisplayOrder, field.IsRequired, field.IsUserEditable, field.DefaultValueJSON, field.ValidationJSON, field.UIGroup,
+			}))
+		}
+	}
+	for _, definition := range references.AlarmDefinitions {
+		code := ""
+		if definition.AlarmTypeID != nil {
+			code = codes[*definition.AlarmTypeID]
+		}
+		prints = append(prints, fingerprint(ReferenceAlarmDefinition, definition.ID, code+"/"+definition.Name, []any{definition.AlarmNote, definition.Scope}))
+	}
+	return prints
+}
+
+func fingerprint(kind ReferenceKind, id uuid.UUID, naturalKey string, content any) Fingerprint {
+	data, _ := json.Marshal(content)
+	sum := sha256.Sum256(data)
+	return Fingerprint{Kind: kind, SourceID: id, NaturalKey: naturalKey, Hash: hex.EncodeToString(sum[:])}
+}
+
+func sortFingerprints(prints []Fingerprint) {
+	sort.Slice(prints, func(i, j int) bool {
+		if prints[i].Kind != prints[j].Kind {
+			return prints[i].Kind < prints[j].Kind
+		}
+		if prints[i].NaturalKey != prints[j].NaturalKey {
+			return prints[i].NaturalKey < prints[j].NaturalKey
+		}
+		return prints[i].SourceID.String() < prints[j].SourceID.String()
+	})
+}
+
+// usedReferenceIDs collects every reference row the bundle points at, so an
+// export only carries the fingerprints it needs and an import can tell which
+// references lack one.
+func usedReferenceIDs(bundle Bundle) map[uuid.UUID]ReferenceKind {
+	used := make(map[uuid.UUID]ReferenceKind)
+	add := func(kind ReferenceKind, id *uuid.UUID) {
+		if id != nil && *id != uuid.Nil {
+			used[*id] = kind
+		}
+	}
+	for _, systemType := range bundle.SystemTypes {
+		add(ReferenceSystemType, &systemType.SystemTypeID)
+	}
+	for _, device := range bundle.FieldDevices {
+		add(ReferenceSystemPart, &device.SystemPartID)
+		add(ReferenceApparat, &device.ApparatID)
+	}
+	for _, object := range bundle.BacnetObjects {
+		add(ReferenceStateText, object.StateTextID)
+		add(ReferenceNotificationClass, object.NotificationClassID)
+		add(ReferenceAlarmType, object.AlarmTypeID)
+		add(ReferenceAlarmDefinition, object.AlarmDefinitionID)
+	}
+	for _, value := range bundle.AlarmValues {
+		add(ReferenceAlarmTypeField, &value.AlarmTypeFieldID)
+		add(ReferenceUnit, value.UnitID)
+	}
+	return used
+}
@@ -0,0 +1,200 @@
+package facilitysync
+
+import (
+	"context"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// BundleFormat identifies sync bundles. BundleVersion changes whenever the
+// bundle layout changes incompatibly.
+const (
+	BundleFormat  = "go_infra_link.facility_sync"
+	BundleVersion = 1
+)
+
+// Bundle is a project's facility subtree as exported by one instance. Rows
+// keep their IDs; reference data is described by Fingerprints so the
+// receiving instance can map it onto its own rows by natural key.
+type Bundle struct {
+	Format          string                               `json:"format"`
+	FormatVersion   int                                  `json:"format_version"`
+	ExportedAt      time.Time                            `json:"exported_at"`
+	ProjectID       uuid.UUID                            `json:"project_id"`
+	ProjectName     string                               `json:"project_name"`
+	References      []Fingerprint                        `json:"references"`
+	Buildings       []Building                           `json:"buildings"`
+	ControlCabinets []ControlCabinet                     `json:"control_cabinets"`
+	SPSControllers  []SPSController                      `json:"sps_controllers"`
+	SystemTypes     []SPSControllerSystemType            `json:"sps_controller_system_types"`
+	FieldDevices    []domainExport.DocumentFieldDevice   `json:"field_devices"`
+	Specifications  []domainExport.DocumentSpecification `json:"specifications"`
+	BacnetObjects   []domainExport.DocumentBacnetObject  `json:"bacnet_objects"`
+	AlarmValues     []domainExport.DocumentAlarmValue    `json:"alarm_values"`
+	Links           ProjectLinks                         `json:"links"`
+}
+
+// ProjectLinks lists the rows the project references directly; everything
+// else in the bundle is reachable through them.
+type ProjectLinks struct {
+	ControlCabinetIDs []uuid.UUID `json:"control_cabinet_ids"`
+	SPSControllerIDs  []uuid.UUID `json:"sps_controller_ids"`
+	FieldDeviceIDs    []uuid.UUID `json:"field_device_ids"`
+}
+
+type Building struct {
+	ID            uuid.UUID `json:"id"`
+	Version       uint64    `json:"version"`
+	IWSCode       string    `json:"iws_code"`
+	BuildingGroup int       `json:"building_group"`
+}
+
+type ControlCabinet struct {
+	ID               uuid.UUID `json:"id"`
+	Version          uint64    `json:"version"`
+	BuildingID       uuid.UUID `json:"building_id"`
+	ControlCabinetNr *string   `json:"control_cabinet_nr"`
+}
+
+type SPSController struct {
+	ID                uuid.UUID `json:"id"`
+	Version           uint64    `json:"version"`
+	ControlCabinetID  uuid.UUID `json:"control_cabinet_id"`
+	GADevice          *string   `json:"ga_device"`
+	DeviceName        string    `json:"device_name"`
+	DeviceDescription *string   `json:"device_description"`
+	DeviceLocation    *string   `json:"device_location"`
+	IPAddress         *string   `json:"ip_address"`
+	Subnet            *string   `json:"subnet"`
+	Gateway           *string   `json:"gateway"`
+	Vlan              *string   `json:"vlan"`
+	MACAddress        *string   `json:"mac_address"`
+}
+
+type SPSControllerSystemType struct {
+	ID              uuid.UUID `json:"id"`
+	Version         uint64    `json:"version"`
+	SPSControllerID uuid.UUID `json:"sps_controller_id"`
+	SystemTypeID    uuid.UUID `json:"system_type_id"`
+	Number          *int      `json:"number"`
+	DocumentName    *string   `json:"document_name"`
+}
+
+type ReferenceKind string
+
+const (
+	ReferenceApparat           ReferenceKind = "apparat"
+	ReferenceSystemPart        ReferenceKind = "system_part"
+	ReferenceSystemType        ReferenceKind = "system_type"
+	ReferenceStateText         ReferenceKind = "state_text"
+	ReferenceNotificationClass ReferenceKind = "notification_class"
+	ReferenceAlarmType         ReferenceKind = "alarm_type"
+	ReferenceAlarmTypeField    ReferenceKind = "alarm_type_field"
+	ReferenceAlarmDefinition   ReferenceKind = "alarm_definition"
+	ReferenceUnit              ReferenceKind = "unit"
+)
+
+// Fingerprint identifies one reference-data row across instances. NaturalKey
+// is what both instances agree on (short name, reference number, code);
+// Hash covers the remaining content so drift between instances shows up.
+type Fingerprint struct {
+	Kind       ReferenceKind `json:"kind"`
+	SourceID   uuid.UUID     `json:"source_id"`
+	NaturalKey string        `json:"natural_key"`
+	Hash       string        `json:"hash"`
+}
+
+// References is the reference data of one instance.
+type References struct {
+	Apparats            []domainFacility.Apparat
+	SystemParts         []domainFacility.SystemPart
+	SystemTypes         []domainFacility.SystemType
+	StateTexts          []domainFacility.StateText
+	NotificationClasses []domainFacility.NotificationClass
+	AlarmTypes          []domainFacility.AlarmType
+	AlarmDefinitions    []domainFacility.AlarmDefinition
+	Units               []domainFacility.Unit
+}
+
+type ReferenceStatus string
+
+const (
+	ReferenceMatched ReferenceStatus = "matched"
+	// ReferenceChanged rows share the natural key but differ in content; the
+	// bundle is mapped onto the local row anyway.
+	ReferenceChanged ReferenceStatus = "changed"
+	ReferenceMissing ReferenceStatus = "missing"
+)
+
+type ReferenceMatch struct {
+	Kind       ReferenceKind   `json:"kind"`
+	NaturalKey string          `json:"natural_key"`
+	SourceID   uuid.UUID       `json:"source_id"`
+	TargetID   *uuid.UUID      `json:"target_id,omitempty"`
+	Status     ReferenceStatus `json:"status"`
+}
+
+type ChangeAction string
+
+const (
+	ChangeCreate    ChangeAction = "create"
+	ChangeUpdate    ChangeAction = "update"
+	ChangeUnchanged ChangeAction = "unchanged"
+)
+
+type Change struct {
+	Entity   string       `json:"entity"`
+	SourceID uuid.UUID    `json:"source_id"`
+	TargetID uuid.UUID    `json:"target_id"`
+	Action   ChangeAction `json:"action"`
+}
+
+type Summary struct {
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Plan reports what importing a bundle would do. Changes lists created and
+// updated rows only; Summary also counts unchanged ones per entity.
+type Plan struct {
+	ProjectID  uuid.UUID          `json:"project_id"`
+	Applied    bool               `json:"applied"`
+	References []ReferenceMatch   `json:"references"`
+	Summary    map[string]Summary `json:"summary"`
+	Changes    []Change           `json:"changes"`
+}
+
+// Missing lists the references the receiving instance cannot resolve. A plan
+// with missing references cannot be applied.
+func (p Plan) Missing() []ReferenceMatch {
+	missing := make([]ReferenceMatch, 0)
+	for _, match := range p.References {
+		if match.Status == ReferenceMissing {
+			missing = append(missing, match)
+		}
+	}
+	return missing
+}
+
+// Store reads and writes the facility rows of one instance.
+type Store interface {
+	LoadSubtree(ctx context.Context, projectID uuid.UUID) (Bundle, error)
+	LoadReferences(ctx context.Context) (References, error)
+	// LoadExisting returns the local rows sharing an ID with the bundle, plus
+	// the buildings, cabinets and controllers sharing their natural keys.
+	LoadExisting(ctx context.Context, bundle Bundle) (Bundle, error)
+	// Apply writes the rows of writes and links the project to every row in
+	// links, all in one transaction. It returns domain.ErrConflict when a
+	// row's local version no longer matches the plan.
+	Apply(ctx context.Context, projectID uuid.UUID, writes Bundle, links ProjectLinks) error
+}
+
+// ChangeRecorder feeds applied rows into the project change feed. aggregate
+// and action follow the feed's naming, e.g. "field_device" and "updated".
+type ChangeRecorder interface {
+	RecordChanges(ctx context.Context, projectID uuid.UUID, aggregate, action string, ids []uuid.UUID) error
+}
@@ -0,0 +1,238 @@
+package facilitysync
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// matchReferences maps every reference the bundle uses onto the local row of
+// the same kind and natural key. The returned mapping is keyed by source ID.
+func matchReferences(bundle Bundle, local []Fingerprint) ([]ReferenceMatch, map[uuid.UUID]uuid.UUID) {
+	byKey := make(map[string]Fingerprint, len(local))
+	for _, print := range local {
+		byKey[string(print.Kind)+"\x00"+print.NaturalKey] = print
+	}
+	sent := make(map[uuid.UUID]Fingerprint, len(bundle.References))
+	for _, print := range bundle.References {
+		sent[print.SourceID] = print
+	}
+	used := usedReferenceIDs(bundle)
+	matches := make([]ReferenceMatch, 0, len(used))
+	mapping := make(map[uuid.UUID]uuid.UUID, len(used))
+	for id, kind := range used {
+		match := ReferenceMatch{Kind: kind, SourceID: id, Status: ReferenceMissing}
+		print, ok := sent[id]
+		if ok {
+			match.NaturalKey = print.NaturalKey
+		}
+		if target, found := byKey[string(kind)+"\x00"+print.NaturalKey]; ok && found {
+			match.TargetID, match.Status = &target.SourceID, ReferenceMatched
+			if target.Hash != print.Hash {
+				match.Status = ReferenceChanged
+			}
+			mapping[id] = target.SourceID
+		}
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Kind != matches[j].Kind {
+			return matches[i].Kind < matches[j].Kind
+		}
+		if matches[i].NaturalKey != matches[j].NaturalKey {
+			return matches[i].NaturalKey < matches[j].NaturalKey
+		}
+		return matches[i].SourceID.String() < matches[j].SourceID.String()
+	})
+	return matches, mapping
+}
+
+// cloneRows copies every row slice so remapping never writes through to the
+// caller's bundle.
+func cloneRows(bundle Bundle) Bundle {
+	bundle.Buildings = slices.Clone(bundle.Buildings)
+	bundle.ControlCabinets = slices.Clone(bundle.ControlCabinets)
+	bundle.SPSControllers = slices.Clone(bundle.SPSControllers)
+	bundle.SystemTypes = slices.Clone(bundle.SystemTypes)
+	bundle.FieldDevices = slices.Clone(bundle.FieldDevices)
+	bundle.Specifications = slices.Clone(bundle.Specifications)
+	bundle.BacnetObjects = slices.Clone(bundle.BacnetObjects)
+	bundle.AlarmValues = slices.Clone(bundle.AlarmValues)
+	bundle.Links = ProjectLinks{
+		ControlCabinetIDs: slices.Clone(bundle.Links.ControlCabinetIDs),
+		SPSControllerIDs:  slices.Clone(bundle.Links.SPSControllerIDs),
+		FieldDeviceIDs:    slices.Clone(bundle.Links.FieldDeviceIDs),
+	}
+	return bundle
+}
+
+func remapReferences(bundle *Bundle, mapping map[uuid.UUID]uuid.UUID) {
+	for index := range bundle.SystemTypes {
+		row := &bundle.SystemTypes[index]
+		row.SystemTypeID = mapID(mapping, row.SystemTypeID)
+	}
+	for index := range bundle.FieldDevices {
+		row := &bundle.FieldDevices[index]
+		row.SystemPartID, row.ApparatID = mapID(mapping, row.SystemPartID), mapID(mapping, row.ApparatID)
+	}
+	for index := range bundle.BacnetObjects {
+		row := &bundle.BacnetObjects[index]
+		row.StateTextID, row.NotificationClassID = mapOptionalID(mapping, row.StateTextID), mapOptionalID(mapping, row.NotificationClassID)
+		row.AlarmTypeID, row.AlarmDefinitionID = mapOptionalID(mapping, row.AlarmTypeID), mapOptionalID(mapping, row.AlarmDefinitionID)
+	}
+	for index := range bundle.AlarmValues {
+		row := &bundle.AlarmValues[index]
+		row.AlarmTypeFieldID, row.UnitID = mapID(mapping, row.AlarmTypeFieldID), mapOptionalID(mapping, row.UnitID)
+	}
+}
+
+// remapHierarchy moves buildings, cabinets and controllers that are new by ID
+// but already exist locally under the same natural key onto the local rows.
+// It returns the moves as local ID to source ID.
+func remapHierarchy(bundle *Bundle, existing Bundle) map[uuid.UUID]uuid.UUID {
+	origins := make(map[uuid.UUID]uuid.UUID)
+	buildings := naturalKeyRemap(bundle.Buildings, existing.Buildings, func(row Building) (uuid.UUID, string) {
+		return row.ID, row.IWSCode + "/" + strconv.Itoa(row.BuildingGroup)
+	})
+	for index := range bundle.Buildings {
+		bundle.Buildings[index].ID = moveID(origins, buildings, bundle.Buildings[index].ID)
+	}
+	for index := range bundle.ControlCabinets {
+		bundle.ControlCabinets[index].BuildingID = mapID(buildings, bundle.ControlCabinets[index].BuildingID)
+	}
+	cabinets := naturalKeyRemap(bundle.ControlCabinets, existing.ControlCabinets, func(row ControlCabinet) (uuid.UUID, string) {
+		if row.ControlCabinetNr == nil {
+			return row.ID, ""
+		}
+		return row.ID, row.BuildingID.String() + "/" + *row.ControlCabinetNr
+	})
+	for index := range bundle.ControlCabinets {
+		bundle.ControlCabinets[index].ID = moveID(origins, cabinets, bundle.ControlCabinets[index].ID)
+	}
+	for index := range bundle.SPSControllers {
+		bundle.SPSControllers[index].ControlCabinetID = mapID(cabinets, bundle.SPSControllers[index].ControlCabinetID)
+	}
+	controllers := naturalKeyRemap(bundle.SPSControllers, existing.SPSControllers, func(row SPSController) (uuid.UUID, string) {
+		return row.ID, row.ControlCabinetID.String() + "/" + row.DeviceName
+	})
+	for index := range bundle.SPSControllers {
+		bundle.SPSControllers[index].ID = moveID(origins, controllers, bundle.SPSControllers[index].ID)
+	}
+	for index := range bundle.SystemTypes {
+		bundle.SystemTypes[index].SPSControllerID = mapID(controllers, bundle.SystemTypes[index].SPSControllerID)
+	}
+	bundle.Links.ControlCabinetIDs = mapIDs(cabinets, bundle.Links.ControlCabinetIDs)
+	bundle.Links.SPSControllerIDs = mapIDs(controllers, bundle.Links.SPSControllerIDs)
+	return origins
+}
+
+func naturalKeyRemap[T any](rows, existing []T, identify func(T) (uuid.UUID, string)) map[uuid.UUID]uuid.UUID {
+	ids := make(map[uuid.UUID]struct{}, len(existing))
+	keys := make(map[string]uuid.UUID, len(existing))
+	for _, row := range existing {
+		id, key := identify(row)
+		ids[id] = struct{}{}
+		if key != "" {
+			keys[key] = id
+		}
+	}
+	remap := make(map[uuid.UUID]uuid.UUID)
+	for _, row := range rows {
+		id, key := identify(row)
+		if _, ok := ids[id]; ok || key == "" {
+			continue
+		}
+		if target, ok := keys[key]; ok {
+			remap[id] = target
+		}
+	}
+	return remap
+}
+
+func moveID(origins, remap map[uuid.UUID]uuid.UUID, id uuid.UUID) uuid.UUID {
+	target, ok := remap[id]
+	if !ok {
+		return id
+	}
+	origins[target] = id
+	return target
+}
+
+func mapID(mapping map[uuid.UUID]uuid.UUID, id uuid.UUID) uuid.UUID {
+	if target, ok := mapping[id]; ok {
+		return target
+	}
+	return id
+}
+
+func mapOptionalID(mapping map[uuid.UUID]uuid.UUID, id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	target := mapID(mapping, *id)
+	return &target
+}
+
+func mapIDs(mapping map[uuid.UUID]uuid.UUID, ids []uuid.UUID) []uuid.UUID {
+	for index := range ids {
+		ids[index] = mapID(mapping, ids[index])
+	}
+	return ids
+}
+
+// rowSpec adapts one row type to planRows. content returns the compared
+// columns, so it clears versions, timestamps and derived columns.
+type rowSpec[T any] struct {
+	entity  string
+	id      func(T) uuid.UUID
+	version func(T) uint64
+	content func(T) T
+	stamp   func(*T, uint64)
+}
+
+type planner struct {
+	plan    *Plan
+	origins map[uuid.UUID]uuid.UUID
+}
+
+// planRows classifies rows against the local ones and returns the rows to
+// write, stamped with the version they will have after the write.
+func planRows[T any](p planner, rows, existing []T, spec rowSpec[T]) []T {
+	current := make(map[uuid.UUID]T, len(existing))
+	for _, row := range existing {
+		current[spec.id(row)] = row
+	}
+	summary := p.plan.Summary[spec.entity]
+	writes := make([]T, 0)
+	for _, row := range rows {
+		id := spec.id(row)
+		before, ok := current[id]
+		action := ChangeCreate
+		switch {
+		case !ok:
+			spec.stamp(&row, 1)
+			summary.Create++
+		case sameContent(spec.content(before), spec.content(row)):
+			summary.Unchanged++
+			continue
+		default:
+			spec.stamp(&row, spec.version(before)+1)
+			summary.Update++
+			action = ChangeUpdate
+		}
+		writes = append(writes, row)
+		p.plan.Changes = append(p.plan.Changes, Change{Entity: spec.entity, SourceID: mapID(p.origins, id), TargetID: id, Action: action})
+	}
+	p.plan.Summary[spec.entity] = summary
+	return writes
+}
+
+func sameContent(left, right any) bool {
+	leftJSON, leftErr := json.Marshal(left)
+	rightJSON, rightErr := json.Marshal(right)
+	return leftErr == nil && rightErr == nil && bytes.Equal(leftJSON, rightJSON)
+}
@@ -0,0 +1,262 @@
+package facilitysync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidBundle        = errors.New("invalid facility sync bundle")
+	ErrUnresolvedReferences = errors.New("facility sync bundle references unknown reference data")
+)
+
+type Service struct {
+	store   Store
+	changes ChangeRecorder
+	now     func() time.Time
+}
+
+func NewService(store Store) *Service {
+	return &Service{store: store, now: time.Now}
+}
+
+// SetChangeRecorder registers the project change feed applied bundles are
+// reported to.
+func (s *Service) SetChangeRecorder(changes ChangeRecorder) {
+	s.changes = changes
+}
+
+// Export bundles the project's facility subtree with the fingerprints of the
+// reference rows it uses.
+func (s *Service) Export(ctx context.Context, projectID uuid.UUID) (Bundle, error) {
+	bundle, err := s.store.LoadSubtree(ctx, projectID)
+	if err != nil {
+		return Bundle{}, err
+	}
+	references, err := s.store.LoadReferences(ctx)
+	if err != nil {
+		return Bundle{}, err
+	}
+	used := usedReferenceIDs(bundle)
+	bundle.References = make([]Fingerprint, 0, len(used))
+	for _, print := range Fingerprints(references) {
+		if _, ok := used[print.SourceID]; ok {
+			bundle.References = append(bundle.References, print)
+		}
+	}
+	bundle.Format, bundle.FormatVersion = BundleFormat, BundleVersion
+	bundle.ExportedAt = s.now().UTC()
+	return bundle, nil
+}
+
+// Plan reports what Apply would create and update in projectID without
+// writing anything.
+func (s *Service) Plan(ctx context.Context, projectID uuid.UUID, bundle Bundle) (Plan, error) {
+	plan, _, err := s.plan(ctx, projectID, bundle)
+	return plan, err
+}
+
+// Apply imports the bundle into projectID. Bundles whose references cannot
+// all be resolved are refused with ErrUnresolvedReferences and the plan.
+func (s *Service) Apply(ctx context.Context, projectID uuid.UUID, bundle Bundle) (Plan, error) {
+	plan, writes, err := s.plan(ctx, projectID, bundle)
+	if err != nil {
+		return plan, err
+	}
+	if missing := plan.Missing(); len(missing) > 0 {
+		return plan, fmt.Errorf("%w: %d missing", ErrUnresolvedReferences, len(missing))
+	}
+	if err := s.store.Apply(ctx, projectID, writes, writes.Links); err != nil {
+		return plan, err
+	}
+	plan.Applied = true
+	s.recordChanges(ctx, projectID, bundle, writes)
+	return plan, nil
+}
+
+// recordChanges reports the written rows as feed aggregates. Specifications,
+// objects and alarm values count as updates of their field device. The rows
+// are committed, so failures are only logged.
+func (s *Service) recordChanges(ctx context.Context, projectID uuid.UUID, bundle, writes Bundle) {
+	if s.changes == nil {
+		return
+	}
+	feed := newFeedChanges()
+	for _, row := range writes.ControlCabinets {
+		feed.add("control_cabinet", row.ID, row.Version)
+	}
+	for _, row := range writes.SPSControllers {
+		feed.add("sps_controller", row.ID, row.Version)
+	}
+	for _, row := range writes.SystemTypes {
+		feed.add("sps_controller_system_type", row.ID, row.Version)
+	}
+	for _, row := range writes.FieldDevices {
+		feed.add("field_device", row.ID, row.Version)
+	}
+	deviceByObject := make(map[uuid.UUID]uuid.UUID, len(bundle.BacnetObjects))
+	for _, row := range bundle.BacnetObjects {
+		deviceByObject[row.ID] = row.FieldDeviceID
+	}
+	for _, row := range writes.Specifications {
+		feed.touch("field_device", row.FieldDeviceID)
+	}
+	for _, row := range writes.BacnetObjects {
+		feed.touch("field_device", row.FieldDeviceID)
+	}
+	for _, row := range writes.AlarmValues {
+		feed.touch("field_device", deviceByObject[row.BacnetObjectID])
+	}
+	for _, group := range feed.groups {
+		if err := s.changes.RecordChanges(ctx, projectID, group.aggregate, group.action, group.ids); err != nil {
+			slog.Warn("facility sync changes could not be recorded", "project_id", projectID, "aggregate", group.aggregate, "err", err)
+		}
+	}
+}
+
+type feedGroup struct {
+	aggregate, action string
+	ids               []uuid.UUID
+}
+
+// feedChanges groups IDs by aggregate and action in first-seen order and
+// reports every ID once.
+type feedChanges struct {
+	groups []*feedGroup
+	seen   map[uuid.UUID]struct{}
+}
+
+func newFeedChanges() *feedChanges {
+	return &feedChanges{seen: map[uuid.UUID]struct{}{}}
+}
+
+func (f *feedChanges) add(aggregate string, id uuid.UUID, version uint64) {
+	action := "updated"
+	if version == 1 {
+		action = "created"
+	}
+	f.append(aggregate, action, id)
+}
+
+func (f *feedChanges) touch(aggregate string, id uuid.UUID) {
+	f.append(aggregate, "updated", id)
+}
+
+func (f *feedChanges) append(aggregate, action string, id uuid.UUID) {
+	if _, ok := f.seen[id]; ok || id == uuid.Nil {
+		return
+	}
+	f.seen[id] = struct{}{}
+	for _, group := range f.groups {
+		if group.aggregate == aggregate && group.action == action {
+			group.ids = append(group.ids, id)
+			return
+		}
+	}
+	f.groups = append(f.groups, &feedGroup{aggregate: aggregate, action: action, ids: []uuid.UUID{id}})
+}
+
+func (s *Service) plan(ctx context.Context, projectID uuid.UUID, bundle Bundle) (Plan, Bundle, error) {
+	plan := Plan{ProjectID: projectID, References: []ReferenceMatch{}, Summary: map[string]Summary{}, Changes: []Change{}}
+	if bundle.Format != BundleFormat || bundle.FormatVersion != BundleVersion {
+		return plan, Bundle{}, fmt.Errorf("%w: format %q version %d", ErrInvalidBundle, bundle.Format, bundle.FormatVersion)
+	}
+	references, err := s.store.LoadReferences(ctx)
+	if err != nil {
+		return plan, Bundle{}, err
+	}
+	var mapping map[uuid.UUID]uuid.UUID
+	plan.References, mapping = matchReferences(bundle, Fingerprints(references))
+	incoming := cloneRows(bundle)
+	remapReferences(&incoming, mapping)
+	existing, err := s.store.LoadExisting(ctx, incoming)
+	if err != nil {
+		return plan, Bundle{}, err
+	}
+	origins := remapHierarchy(&incoming, existing)
+	return plan, diffBundle(planner{plan: &plan, origins: origins}, incoming, existing), nil
+}
+
+// diffBundle returns the rows of incoming that differ from existing, in
+// hierarchy order, and records each decision in the plan.
+func diffBundle(p planner, incoming, existing Bundle) Bundle {
+	writes := Bundle{ProjectID: incoming.ProjectID, Links: incoming.Links}
+	writes.Buildings = planRows(p, incoming.Buildings, existing.Buildings, rowSpec[Building]{
+		entity:  "building",
+		id:      func(row Building) uuid.UUID { return row.ID },
+		version: func(row Building) uint64 { return row.Version },
+		content: func(row Building) Building { row.Version = 0; return row },
+		stamp:   func(row *Building, version uint64) { row.Version = version },
+	})
+	writes.ControlCabinets = planRows(p, incoming.ControlCabinets, existing.ControlCabinets, rowSpec[ControlCabinet]{
+		entity:  "control_cabinet",
+		id:      func(row ControlCabinet) uuid.UUID { return row.ID },
+		version: func(row ControlCabinet) uint64 { return row.Version },
+		content: func(row ControlCabinet) ControlCabinet { row.Version = 0; return row },
+		stamp:   func(row *ControlCabinet, version uint64) { row.Version = version },
+	})
+	writes.SPSControllers = planRows(p, incoming.SPSControllers, existing.SPSControllers, rowSpec[SPSController]{
+		entity:  "sps_controller",
+		id:      func(row SPSController) uuid.UUID { return row.ID },
+		version: func(row SPSController) uint64 { return row.Version },
+		content: func(row SPSController) SPSController { row.Version = 0; return row },
+		stamp:   func(row *SPSController, version uint64) { row.Version = version },
+	})
+	writes.SystemTypes = planRows(p, incoming.SystemTypes, existing.SystemTypes, rowSpec[SPSControllerSystemType]{
+		entity:  "sps_controller_system_type",
+		id:      func(row SPSControllerSystemType) uuid.UUID { return row.ID },
+		version: func(row SPSControllerSystemType) uint64 { return row.Version },
+		content: func(row SPSControllerSystemType) SPSControllerSystemType { row.Version = 0; return row },
+		stamp:   func(row *SPSControllerSystemType, version uint64) { row.Version = version },
+	})
+	diffDevices(p, incoming, existing, &writes)
+	return writes
+}
+
+func diffDevices(p planner, incoming, existing Bundle, writes *Bundle) {
+	writes.FieldDevices = planRows(p, incoming.FieldDevices, existing.FieldDevices, rowSpec[domainExport.DocumentFieldDevice]{
+		entity:  "field_device",
+		id:      func(row domainExport.DocumentFieldDevice) uuid.UUID { return row.ID },
+		version: func(row domainExport.DocumentFieldDevice) uint64 { return row.Version },
+		content: func(row domainExport.DocumentFieldDevice) domainExport.DocumentFieldDevice {
+			// The controller ID is derived from the system type; timestamps
+			// are local to each instance.
+			row.Version, row.CreatedAt, row.UpdatedAt, row.SPSControllerID = 0, time.Time{}, time.Time{}, uuid.Nil
+			return row
+		},
+		stamp: func(row *domainExport.DocumentFieldDevice, version uint64) { row.Version = version },
+	})
+	writes.Specifications = planRows(p, incoming.Specifications, existing.Specifications, rowSpec[domainExport.DocumentSpecification]{
+		entity:  "specification",
+		id:      func(row domainExport.DocumentSpecification) uuid.UUID { return row.ID },
+		version: func(row domainExport.DocumentSpecification) uint64 { return row.Version },
+		content: func(row domainExport.DocumentSpecification) domainExport.DocumentSpecification {
+			row.Version = 0
+			return row
+		},
+		stamp: func(row *domainExport.DocumentSpecification, version uint64) { row.Version = version },
+	})
+	writes.BacnetObjects = planRows(p, incoming.BacnetObjects, existing.BacnetObjects, rowSpec[domainExport.DocumentBacnetObject]{
+		entity:  "bacnet_object",
+		id:      func(row domainExport.DocumentBacnetObject) uuid.UUID { return row.ID },
+		version: func(row domainExport.DocumentBacnetObject) uint64 { return row.Version },
+		content: func(row domainExport.DocumentBacnetObject) domainExport.DocumentBacnetObject {
+			row.Version = 0
+			return row
+		},
+		stamp: func(row *domainExport.DocumentBacnetObject, version uint64) { row.Version = version },
+	})
+	writes.AlarmValues = planRows(p, incoming.AlarmValues, existing.AlarmValues, rowSpec[domainExport.DocumentAlarmValue]{
+		entity:  "alarm_value",
+		id:      func(row domainExport.DocumentAlarmValue) uuid.UUID { return row.ID },
+		version: func(row domainExport.DocumentAlarmValue) uint64 { return row.Version },
+		content: func(row domainExport.DocumentAlarmValue) domainExport.DocumentAlarmValue { row.Version = 0; return row },
+		stamp:   func(row *domainExport.DocumentAlarmValue, version uint64) { row.Version = version },
+	})
+}
@@ -0,0 +1,185 @@
+package facilitysync
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	facility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type storeStub struct {
+	subtree    Bundle
+	references References
+	existing   Bundle
+	applied    *Bundle
+	links      ProjectLinks
+}
+
+func (s *storeStub) LoadSubtree(context.Context, uuid.UUID) (Bundle, error) { return s.subtree, nil }
+func (s *storeStub) LoadReferences(context.Context) (References, error)     { return s.references, nil }
+func (s *storeStub) LoadExisting(context.Context, Bundle) (Bundle, error)   { return s.existing, nil }
+func (s *storeStub) Apply(_ context.Context, _ uuid.UUID, writes Bundle, links ProjectLinks) error {
+	s.applied, s.links = &writes, links
+	return nil
+}
+
+type recorderStub struct{ recorded []string }
+
+func (r *recorderStub) RecordChanges(_ context.Context, _ uuid.UUID, aggregate, action string, ids []uuid.UUID) error {
+	for _, id := range ids {
+		r.recorded = append(r.recorded, aggregate+"."+action+":"+id.String())
+	}
+	return nil
+}
+
+type syncFixture struct {
+	source, target References
+	bundle         Bundle
+}
+
+func newSyncFixture() syncFixture {
+	var fixture syncFixture
+	fixture.source.Apparats = []facility.Apparat{{ShortName: "ven", Name: "Ventilator"}}
+	fixture.source.SystemParts = []facility.SystemPart{{ShortName: "ab", Name: "Abluft"}}
+	fixture.source.SystemTypes = []facility.SystemType{{Name: "Lüftung", NumberMax: 9}}
+	fixture.target.Apparats = []facility.Apparat{{ShortName: "VEN", Name: "Ventilator"}}
+	fixture.target.SystemParts = []facility.SystemPart{{ShortName: "AB", Name: "Exhaust"}}
+	fixture.target.SystemTypes = []facility.SystemType{{Name: "Lüftung", NumberMax: 9}}
+	for _, refs := range []*References{&fixture.source, &fixture.target} {
+		refs.Apparats[0].ID, refs.SystemParts[0].ID, refs.SystemTypes[0].ID = uuid.New(), uuid.New(), uuid.New()
+	}
+
+	nr := "01"
+	bundle := Bundle{Format: BundleFormat, FormatVersion: BundleVersion, References: Fingerprints(fixture.source)}
+	bundle.Buildings = []Building{{ID: uuid.New(), IWSCode: "ABCD", BuildingGroup: 1}}
+	bundle.ControlCabinets = []ControlCabinet{{ID: uuid.New(), Version: 4, BuildingID: bundle.Buildings[0].ID, ControlCabinetNr: &nr}}
+	bundle.SPSControllers = []SPSController{{ID: uuid.New(), Version: 2, ControlCabinetID: bundle.ControlCabinets[0].ID, DeviceName: "AS01"}}
+	bundle.SystemTypes = []SPSControllerSystemType{{ID: uuid.New(), Version: 1, SPSControllerID: bundle.SPSControllers[0].ID, SystemTypeID: fixture.source.SystemTypes[0].ID}}
+	bundle.FieldDevices = []domainExport.DocumentFieldDevice{{
+		ID: uuid.New(), Version: 7, SPSControllerSystemTypeID: bundle.SystemTypes[0].ID,
+		SystemPartID: fixture.source.SystemParts[0].ID, ApparatID: fixture.source.Apparats[0].ID, ApparatNr: 3,
+	}}
+	bundle.Links = ProjectLinks{ControlCabinetIDs: []uuid.UUID{bundle.ControlCabinets[0].ID}, FieldDeviceIDs: []uuid.UUID{bundle.FieldDevices[0].ID}}
+	fixture.bundle = bundle
+	return fixture
+}
+
+func TestExportCarriesOnlyUsedReferenceFingerprints(t *testing.T) {
+	fixture := newSyncFixture()
+	subtree := fixture.bundle
+	subtree.References = nil
+	fixture.source.Units = []facility.Unit{{Code: "degC"}}
+	service := NewService(&storeStub{subtree: subtree, references: fixture.source})
+	service.now = func() time.Time { return time.Unix(1_700_000_000, 0) }
+
+	bundle, err := service.Export(context.Background(), uuid.New())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Format != BundleFormat || !bundle.ExportedAt.Equal(time.Unix(1_700_000_000, 0)) || len(bundle.References) != 3 {
+		t.Fatalf("bundle header = %q %v references=%+v", bundle.Format, bundle.ExportedAt, bundle.References)
+	}
+	for _, print := range bundle.References {
+		if print.Kind == ReferenceUnit {
+			t.Fatalf("unused unit fingerprint exported: %+v", print)
+		}
+	}
+}
+
+func TestApplyMapsReferencesAndHierarchyByNaturalKey(t *testing.T) {
+	fixture := newSyncFixture()
+	localBuilding := Building{ID: uuid.New(), IWSCode: "ABCD", BuildingGroup: 1}
+	store := &storeStub{references: fixture.target, existing: Bundle{Buildings: []Building{localBuilding}}}
+
+	recorder := &recorderStub{}
+	service := NewService(store)
+	service.SetChangeRecorder(recorder)
+	plan, err := service.Apply(context.Background(), uuid.New(), fixture.bundle)
+
+	if err != nil || !plan.Applied || store.applied == nil {
+		t.Fatalf("Apply() applied=%v error=%v", plan.Applied, err)
+	}
+	statuses := map[ReferenceKind]ReferenceStatus{}
+	for _, match := range plan.References {
+		statuses[match.Kind] = match.Status
+	}
+	if statuses[ReferenceApparat] != ReferenceMatched || statuses[ReferenceSystemPart] != ReferenceChanged || statuses[ReferenceSystemType] != ReferenceMatched {
+		t.Fatalf("reference statuses = %v", statuses)
+	}
+	writes := *store.applied
+	if len(writes.Buildings) != 0 || writes.ControlCabinets[0].BuildingID != localBuilding.ID {
+		t.Fatalf("building was not mapped onto the local one: %+v", writes.ControlCabinets)
+	}
+	device := writes.FieldDevices[0]
+	if device.ApparatID != fixture.target.Apparats[0].ID || device.SystemPartID != fixture.target.SystemParts[0].ID || device.Version != 1 {
+		t.Fatalf("device references were not remapped: %+v", device)
+	}
+	if writes.SystemTypes[0].SystemTypeID != fixture.target.SystemTypes[0].ID {
+		t.Fatalf("system type was not remapped: %+v", writes.SystemTypes[0])
+	}
+	if plan.Summary["building"].Unchanged != 1 || plan.Summary["control_cabinet"].Create != 1 || plan.Summary["field_device"].Create != 1 {
+		t.Fatalf("summary = %+v", plan.Summary)
+	}
+	if fixture.bundle.FieldDevices[0].ApparatID != fixture.source.Apparats[0].ID {
+		t.Fatal("Apply modified the caller's bundle")
+	}
+	if !slices.Contains(recorder.recorded, "field_device.created:"+device.ID.String()) ||
+		!slices.Contains(recorder.recorded, "control_cabinet.created:"+writes.ControlCabinets[0].ID.String()) {
+		t.Fatalf("change feed = %v", recorder.recorded)
+	}
+}
+
+func TestApplyRefusesUnresolvedReferences(t *testing.T) {
+	fixture := newSyncFixture()
+	fixture.target.Apparats = nil
+	store := &storeStub{references: fixture.target}
+
+	plan, err := NewService(store).Apply(context.Background(), uuid.New(), fixture.bundle)
+
+	if !errors.Is(err, ErrUnresolvedReferences) || store.applied != nil || plan.Applied {
+		t.Fatalf("Apply() error=%v applied=%v", err, store.applied != nil)
+	}
+	if missing := plan.Missing(); len(missing) != 1 || missing[0].Kind != ReferenceApparat || missing[0].NaturalKey != "VEN" {
+		t.Fatalf("missing = %+v", missing)
+	}
+}
+
+func TestPlanSeparatesUpdatedFromUnchangedRows(t *testing.T) {
+	fixture := newSyncFixture()
+	bundle := fixture.bundle
+	existing := Bundle{
+		Buildings:       bundle.Buildings,
+		ControlCabinets: []ControlCabinet{bundle.ControlCabinets[0]},
+		SPSControllers:  []SPSController{bundle.SPSControllers[0]},
+	}
+	existing.ControlCabinets[0].Version = 9
+	existing.SPSControllers[0].Version, existing.SPSControllers[0].DeviceName = 5, "AS02"
+
+	plan, err := NewService(&storeStub{references: fixture.target, existing: existing}).Plan(context.Background(), uuid.New(), bundle)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Summary["control_cabinet"].Unchanged != 1 || plan.Summary["sps_controller"].Update != 1 {
+		t.Fatalf("summary = %+v", plan.Summary)
+	}
+	for _, change := range plan.Changes {
+		if change.Entity == "control_cabinet" {
+			t.Fatalf("unchanged cabinet listed as change: %+v", change)
+		}
+	}
+}
+
+func TestPlanRejectsForeignBundles(t *testing.T) {
+	_, err := NewService(&storeStub{}).Plan(context.Background(), uuid.New(), Bundle{Format: "other", FormatVersion: 1})
+
+	if !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("Plan() error = %v, want ErrInvalidBundle", err)
+	}
+}
@@ -0,0 +1,239 @@
+package fielddeviceimport
+
+import (
+	"reflect"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// changePlan compares one staged aggregate with its persisted state and
+// expresses the difference as a bulk update, so existing rows go through the
+// same versioned write path as interactive edits.
+type changePlan struct {
+	change AggregateChange
+	update domainFacility.BulkFieldDeviceUpdate
+	issues []Issue
+}
+
+func planChange(staged Aggregate, current *Aggregate) changePlan {
+	device := staged.FieldDevice
+	plan := changePlan{change: AggregateChange{FieldDeviceID: device.ID, Action: ChangeCreate}}
+	if current == nil {
+		return plan
+	}
+	plan.update = domainFacility.BulkFieldDeviceUpdate{ID: device.ID, BaseVersion: domain.AggregateVersion(device.Version)}
+	plan.fieldDevice(device, current.FieldDevice)
+	plan.specification(staged.Specification, current.Specification)
+	plan.bacnetObjects(staged.BacnetObjects, current.BacnetObjects)
+	plan.change.Action = ChangeUnchanged
+	if len(plan.change.Diffs) > 0 || len(plan.issues) > 0 {
+		plan.change.Action = ChangeUpdate
+	}
+	if plan.change.Action == ChangeUpdate && device.Version != current.FieldDevice.Version {
+		plan.issue("field_device", device.ID, "version", "stale_version", "field device changed since the workbook was exported")
+	}
+	return plan
+}
+
+func (p *changePlan) differs(entity string, id uuid.UUID, field string, before, after any) bool {
+	if reflect.DeepEqual(before, after) {
+		return false
+	}
+	p.change.Diffs = append(p.change.Diffs, FieldDiff{Entity: entity, SourceID: id, Field: field, Before: before, After: after})
+	return true
+}
+
+func (p *changePlan) issue(entity string, id uuid.UUID, field, code, message string) {
+	p.issues = append(p.issues, Issue{Code: code, Entity: entity, SourceID: id, Field: field, Message: message})
+}
+
+func (p *changePlan) fieldDevice(staged, current domainFacility.FieldDevice) {
+	diff := func(field string, before, after any) bool {
+		return p.differs("field_device", staged.ID, field, before, after)
+	}
+	if diff("bmk", value(current.BMK), value(staged.BMK)) {
+		p.update.BMK, p.update.HasBMK = staged.BMK, true
+	}
+	if diff("description", value(current.Description), value(staged.Description)) {
+		p.update.Description, p.update.HasDescription = staged.Description, true
+	}
+	if diff("text_individual", value(current.TextIndividuell), value(staged.TextIndividuell)) {
+		p.update.TextIndividuell, p.update.HasTextIndividuell = staged.TextIndividuell, true
+	}
+	if diff("apparat_nr", current.ApparatNr, staged.ApparatNr) {
+		p.update.ApparatNr = &staged.ApparatNr
+	}
+	if diff("apparat_id", current.ApparatID, staged.ApparatID) {
+		p.update.ApparatID = &staged.ApparatID
+	}
+	if diff("system_part_id", current.SystemPartID, staged.SystemPartID) {
+		p.update.SystemPartID = &staged.SystemPartID
+	}
+	if diff("sps_controller_system_type_id", current.SPSControllerSystemTypeID, staged.SPSControllerSystemTypeID) {
+		p.issue("field_device", staged.ID, "sps_controller_system_type_id", "unsupported_change", "field devices cannot be moved to another controller by import")
+	}
+}
+
+func (p *changePlan) specification(staged, current *domainFacility.Specification) {
+	if staged == nil {
+		return
+	}
+	if current == nil {
+		current = &domainFacility.Specification{}
+	}
+	patch := &domainFacility.SpecificationPatch{BaseVersion: current.Version}
+	diff := func(field string, before, after any) bool {
+		return p.differs("specification", staged.ID, field, before, after)
+	}
+	if diff("supplier", value(current.SpecificationSupplier), value(staged.SpecificationSupplier)) {
+		patch.SpecificationSupplier, patch.HasSpecificationSupplier = staged.SpecificationSupplier, true
+	}
+	if diff("brand", value(current.SpecificationBrand), value(staged.SpecificationBrand)) {
+		patch.SpecificationBrand, patch.HasSpecificationBrand = staged.SpecificationBrand, true
+	}
+	if diff("type", value(current.SpecificationType), value(staged.SpecificationType)) {
+		patch.SpecificationType, patch.HasSpecificationType = staged.SpecificationType, true
+	}
+	if diff("motor_valve", value(current.AdditionalInfoMotorValve), value(staged.AdditionalInfoMotorValve)) {
+		patch.AdditionalInfoMotorValve, patch.HasAdditionalInfoMotorValve = staged.AdditionalInfoMotorValve, true
+	}
+	if diff("size", value(current.AdditionalInfoSize), value(staged.AdditionalInfoSize)) {
+		patch.AdditionalInfoSize, patch.HasAdditionalInfoSize = staged.AdditionalInfoSize, true
+	}
+	if diff("installation_location", value(current.AdditionalInformationInstallationLocation), value(staged.AdditionalInformationInstallationLocation)) {
+		patch.AdditionalInformationInstallationLocation, patch.HasAdditionalInformationInstallationLocation = staged.AdditionalInformationInstallationLocation, true
+	}
+	if diff("ph", value(current.ElectricalConnectionPH), value(staged.ElectricalConnectionPH)) {
+		patch.ElectricalConnectionPH, patch.HasElectricalConnectionPH = staged.ElectricalConnectionPH, true
+	}
+	if diff("acdc", value(current.ElectricalConnectionACDC), value(staged.ElectricalConnectionACDC)) {
+		patch.ElectricalConnectionACDC, patch.HasElectricalConnectionACDC = staged.ElectricalConnectionACDC, true
+	}
+	if diff("amperage", value(current.ElectricalConnectionAmperage), value(staged.ElectricalConnectionAmperage)) {
+		patch.ElectricalConnectionAmperage, patch.HasElectricalConnectionAmperage = staged.ElectricalConnectionAmperage, true
+	}
+	if diff("power", value(current.ElectricalConnectionPower), value(staged.ElectricalConnectionPower)) {
+		patch.ElectricalConnectionPower, patch.HasElectricalConnectionPower = staged.ElectricalConnectionPower, true
+	}
+	if diff("rotation", value(current.ElectricalConnectionRotation), value(staged.ElectricalConnectionRotation)) {
+		patch.ElectricalConnectionRotation, patch.HasElectricalConnectionRotation = staged.ElectricalConnectionRotation, true
+	}
+	if patch.HasChanges() {
+		p.update.Specification = patch
+	}
+}
+
+// bacnetObjects patches objects by ID. The bulk update cannot add or remove
+// objects, so a changed object set is reported instead of applied.
+func (p *changePlan) bacnetObjects(staged, current []domainFacility.BacnetObject) {
+	existing := make(map[uuid.UUID]domainFacility.BacnetObject, len(current))
+	for _, object := range current {
+		existing[object.ID] = object
+	}
+	patches := make([]domainFacility.BacnetObjectPatch, 0)
+	for _, object := range staged {
+		persisted, ok := existing[object.ID]
+		if !ok {
+			p.issue("bacnet_object", object.ID, "source_id", "unsupported_change", "BACnet objects cannot be added to an existing field device by import")
+			continue
+		}
+		delete(existing, object.ID)
+		if patch, changed := p.bacnetObject(object, persisted); changed {
+			patches = append(patches, patch)
+		}
+		p.alarmValues(object, persisted)
+	}
+	for id := range existing {
+		p.issue("bacnet_object", id, "source_id", "unsupported_change", "BACnet object is missing from the import")
+	}
+	if len(patches) > 0 {
+		p.update.BacnetObjects = &patches
+	}
+}
+
+func (p *changePlan) bacnetObject(staged, current domainFacility.BacnetObject) (domainFacility.BacnetObjectPatch, bool) {
+	patch := domainFacility.BacnetObjectPatch{ID: staged.ID}
+	before := len(p.change.Diffs)
+	diff := func(field string, before, after any) bool {
+		return p.differs("bacnet_object", staged.ID, field, before, after)
+	}
+	if diff("text_fix", current.TextFix, staged.TextFix) {
+		patch.TextFix = &staged.TextFix
+	}
+	if diff("gms_visible", current.GMSVisible, staged.GMSVisible) {
+		patch.GMSVisible = &staged.GMSVisible
+	}
+	if diff("optional", current.Optional, staged.Optional) {
+		patch.Optional = &staged.Optional
+	}
+	if diff("software_type", current.SoftwareType, staged.SoftwareType) {
+		patch.SoftwareType = &staged.SoftwareType
+	}
+	if diff("software_number", current.SoftwareNumber, staged.SoftwareNumber) {
+		patch.SoftwareNumber = &staged.SoftwareNumber
+	}
+	if diff("hardware_type", current.HardwareType, staged.HardwareType) {
+		patch.HardwareType = &staged.HardwareType
+	}
+	if diff("hardware_quantity", current.HardwareQuantity, staged.HardwareQuantity) {
+		patch.HardwareQuantity = &staged.HardwareQuantity
+	}
+	patch.Description = optionalObjectField(p, staged.ID, "description", current.Description, staged.Description)
+	patch.TextIndividual = optionalObjectField(p, staged.ID, "text_individual", current.TextIndividual, staged.TextIndividual)
+	patch.SoftwareReferenceID = optionalObjectField(p, staged.ID, "software_reference_id", current.SoftwareReferenceID, staged.SoftwareReferenceID)
+	patch.StateTextID = optionalObjectField(p, staged.ID, "state_text_id", current.StateTextID, staged.StateTextID)
+	patch.NotificationClassID = optionalObjectField(p, staged.ID, "notification_class_id", current.NotificationClassID, staged.NotificationClassID)
+	patch.AlarmTypeID = optionalObjectField(p, staged.ID, "alarm_type_id", current.AlarmTypeID, staged.AlarmTypeID)
+	return patch, len(p.change.Diffs) > before
+}
+
+// alarmValues reports changed alarm values of an existing object. The bulk
+// update cannot write them, and dropping them silently would lose the edit.
+func (p *changePlan) alarmValues(staged, current domainFacility.BacnetObject) {
+	persisted := make(map[uuid.UUID]domainFacility.BacnetObjectAlarmValue, len(current.AlarmValues))
+	for _, value := range current.AlarmValues {
+		persisted[value.AlarmTypeFieldID] = value
+	}
+	changed := len(staged.AlarmValues) != len(current.AlarmValues)
+	for _, value := range staged.AlarmValues {
+		before, ok := persisted[value.AlarmTypeFieldID]
+		if !ok || !sameAlarmValue(before, value) {
+			changed = true
+			p.differs("alarm_value", staged.ID, value.AlarmTypeFieldID.String(), alarmValueContent(before), alarmValueContent(value))
+		}
+	}
+	if changed {
+		p.issue("bacnet_object", staged.ID, "alarm_values", "unsupported_change", "alarm values of an existing BACnet object cannot be changed by import")
+	}
+}
+
+func sameAlarmValue(left, right domainFacility.BacnetObjectAlarmValue) bool {
+	return reflect.DeepEqual(alarmValueContent(left), alarmValueContent(right))
+}
+
+func alarmValueContent(value domainFacility.BacnetObjectAlarmValue) []any {
+	return []any{
+		value.ValueNumber, value.ValueInteger, value.ValueBoolean, value.ValueString, value.ValueJSON, value.UnitID,
+	}
+}
+
+// optionalObjectField returns the staged pointer when it changed. Object
+// patches treat nil as "keep", so clearing a value cannot be expressed.
+func optionalObjectField[T any](p *changePlan, id uuid.UUID, field string, current, staged *T) *T {
+	if !p.differs("bacnet_object", id, field, value(current), value(staged)) {
+		return nil
+	}
+	if staged == nil {
+		p.issue("bacnet_object", id, field, "unsupported_change", "BACnet object values cannot be cleared by import")
+	}
+	return staged
+}
+
+func value[T any](pointer *T) any {
+	if pointer == nil {
+		return nil
+	}
+	return *pointer
+}
@@ -17,6 +17,8 @@ type Manifest struct {
 	Scope         string
 	DeviceCount   int64
 	Counts        Counts
+	// Issues lists source rows the reader could not map to staged rows.
+	Issues []Issue `json:",omitempty"`
 }
 
 type Counts struct {
@@ -48,11 +50,77 @@ type Issue struct {
 }
 
 type Result struct {
-	ImportID uuid.UUID `json:"import_id"`
-	Total    int64     `json:"total"`
-	Imported int64     `json:"imported"`
-	Failed   int64     `json:"failed"`
-	Issues   []Issue   `json:"issues,omitempty"`
+	ImportID  uuid.UUID `json:"import_id"`
+	Total     int64     `json:"total"`
+	Imported  int64     `json:"imported"`
+	Updated   int64     `json:"updated"`
+	Unchanged int64     `json:"unchanged"`
+	Failed    int64     `json:"failed"`
+	Issues    []Issue   `json:"issues,omitempty"`
+}
+
+type ChangeAction string
+
+const (
+	ChangeCreate    ChangeAction = "create"
+	ChangeUpdate    ChangeAction = "update"
+	ChangeUnchanged ChangeAction = "unchanged"
+)
+
+// FieldDiff is one staged value that differs from the persisted row.
+type FieldDiff struct {
+	Entity   string    `json:"entity"`
+	SourceID uuid.UUID `json:"source_id"`
+	Field    string    `json:"field"`
+	Before   any       `json:"before"`
+	After    any       `json:"after"`
+}
+
+type AggregateChange struct {
+	FieldDeviceID uuid.UUID    `json:"field_device_id"`
+	Action        ChangeAction `json:"action"`
+	Diffs         []FieldDiff  `json:"diffs,omitempty"`
+}
+
+// Preview describes what confirming a staged import would do. The staged
+// session stays open until it is confirmed, discarded or expires.
+type Preview struct {
+	ImportID  uuid.UUID         `json:"import_id"`
+	Total     int64             `json:"total"`
+	Created   int64             `json:"created"`
+	Updated   int64             `json:"updated"`
+	Unchanged int64             `json:"unchanged"`
+	Changes   []AggregateChange `json:"changes"`
+	Issues    []Issue           `json:"issues,omitempty"`
+}
+
+// SessionRef addresses a staged import owned by one user.
+type SessionRef struct {
+	OwnerID  uuid.UUID
+	ImportID uuid.UUID
+}
+
+// EditedAggregate pairs the state recorded in a workbook's machine sheets with
+// the values a planner left in its human-readable controller sheets.
+type EditedAggregate struct {
+	Exported Aggregate
+	Edited   Aggregate
+}
+
+type EditSet struct {
+	Manifest   Manifest
+	Aggregates []EditedAggregate
+	Issues     []Issue
+}
+
+// EditPlan lists the bulk updates derived from an edited export. Updates carry
+// the exported versions, so rows changed since the export fail as conflicts.
+type EditPlan struct {
+	Total     int                                    `json:"total"`
+	Unchanged int                                    `json:"unchanged"`
+	Changes   []AggregateChange                      `json:"changes"`
+	Updates   []domainFacility.BulkFieldDeviceUpdate `json:"-"`
+	Issues    []Issue                                `json:"issues,omitempty"`
 }
 
 type Command struct {
@@ -60,10 +128,26 @@ type Command struct {
 	Source  io.Reader
 }
 
+// EDECommand imports a BACnet EDE point list. EDE rows carry no owner IDs, so
+// the caller chooses the controller system type the field devices land under.
+type EDECommand struct {
+	OwnerID                   uuid.UUID
+	SPSControllerSystemTypeID uuid.UUID
+	Source                    io.Reader
+}
+
 type WorkbookReader interface {
 	Read(ctx context.Context, source io.Reader, sink Sink) (Manifest, error)
 }
 
+type EditReader interface {
+	ReadEdits(ctx context.Context, source io.Reader) (EditSet, error)
+}
+
+type EDEReaderFactory interface {
+	EDEReader(ctx context.Context, spsControllerSystemTypeID uuid.UUID) (WorkbookReader, error)
+}
+
 type Sink interface {
 	FieldDevices(ctx context.Context, values []domainFacility.FieldDevice) error
 	Specifications(ctx context.Context, values []domainFacility.Specification) error
@@ -88,8 +172,15 @@ type Session interface {
 
 type StagingStore interface {
 	Start(ctx context.Context, ownerID uuid.UUID) (uuid.UUID, Session, error)
+	// Open resumes a sealed session; it returns ErrSessionNotFound for unknown,
+	// foreign or already finished imports.
+	Open(ctx context.Context, ref SessionRef) (Session, error)
 }
 
+// AggregateWriter applies staged aggregates. Current returns nil when the
+// field device does not exist yet, so the aggregate is created.
 type AggregateWriter interface {
+	Current(ctx context.Context, fieldDeviceID uuid.UUID) (*Aggregate, error)
 	Import(ctx context.Context, aggregate Aggregate) error
+	Update(ctx context.Context, update domainFacility.BulkFieldDeviceUpdate) error
 }
@@ -4,45 +4,174 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
 )
 
-var ErrInvalidWorkbook = errors.New("invalid field device workbook")
+var (
+	ErrInvalidWorkbook  = errors.New("invalid field device workbook")
+	ErrEDEUnavailable   = errors.New("EDE import is unavailable")
+	ErrSessionNotFound  = errors.New("import session not found")
+	ErrEditsUnavailable = errors.New("edited workbook import is unavailable")
+)
 
 type Service struct {
 	reader WorkbookReader
 	store  StagingStore
 	writer AggregateWriter
+	ede    EDEReaderFactory
+	edits  EditReader
 }
 
 func NewService(reader WorkbookReader, store StagingStore, writer AggregateWriter) *Service {
 	return &Service{reader: reader, store: store, writer: writer}
 }
 
+// SetEDEReaders enables ImportEDE. Without a factory EDE uploads are refused.
+func (s *Service) SetEDEReaders(factory EDEReaderFactory) {
+	s.ede = factory
+}
+
+// SetEditReader enables PlanEdits for workbooks edited after an export.
+func (s *Service) SetEditReader(reader EditReader) {
+	s.edits = reader
+}
+
 func (s *Service) Import(ctx context.Context, command Command) (Result, error) {
-	result, session, err := s.stage(ctx, command)
+	return s.importWith(ctx, s.reader, command)
+}
+
+func (s *Service) ImportEDE(ctx context.Context, command EDECommand) (Result, error) {
+	if s.ede == nil {
+		return Result{}, ErrEDEUnavailable
+	}
+	reader, err := s.ede.EDEReader(ctx, command.SPSControllerSystemTypeID)
 	if err != nil {
+		return Result{}, err
+	}
+	return s.importWith(ctx, reader, Command{OwnerID: command.OwnerID, Source: command.Source})
+}
+
+func (s *Service) importWith(ctx context.Context, reader WorkbookReader, command Command) (Result, error) {
+	result, session, err := s.stage(ctx, reader, command)
+	if err != nil {
+		return result, err
+	}
+	if err := s.validate(ctx, session, &result.Issues); err != nil {
 		return result, err
 	}
-	if issues, validateErr := session.Validate(ctx); validateErr != nil || len(issues) > 0 {
-		result.Issues = issues
+	return s.write(ctx, session, result)
+}
+
+// Preview stages the upload and reports what Confirm would change without
+// writing. The staged session stays open under the returned ImportID.
+func (s *Service) Preview(ctx context.Context, command Command) (Preview, error) {
+	result, session, err := s.stage(ctx, s.reader, command)
+	preview := Preview{ImportID: result.ImportID, Total: result.Total, Changes: []AggregateChange{}, Issues: result.Issues}
+	if err != nil {
+		return preview, err
+	}
+	issues, err := session.Validate(ctx)
+	if err != nil {
+		_ = session.Discard(ctx)
+		return preview, err
+	}
+	preview.Issues = append(preview.Issues, issues...)
+	err = s.eachPlan(ctx, session, func(_ Aggregate, plan changePlan) error {
+		preview.add(plan)
+		return nil
+	})
+	if err != nil {
 		_ = session.Discard(ctx)
-		return result, errors.Join(ErrInvalidWorkbook, validateErr)
+	}
+	return preview, err
+}
+
+func (p *Preview) add(plan changePlan) {
+	p.Changes = append(p.Changes, plan.change)
+	p.Issues = append(p.Issues, plan.issues...)
+	switch plan.change.Action {
+	case ChangeCreate:
+		p.Created++
+	case ChangeUpdate:
+		p.Updated++
+	default:
+		p.Unchanged++
+	}
+}
+
+// Confirm writes a previewed session after validating it again, because the
+// persisted rows may have changed since the preview.
+func (s *Service) Confirm(ctx context.Context, ref SessionRef) (Result, error) {
+	session, err := s.store.Open(ctx, ref)
+	result := Result{ImportID: ref.ImportID}
+	if err != nil {
+		return result, err
+	}
+	if err := s.validate(ctx, session, &result.Issues); err != nil {
+		return result, err
 	}
 	return s.write(ctx, session, result)
 }
 
-func (s *Service) stage(ctx context.Context, command Command) (Result, Session, error) {
+func (s *Service) Discard(ctx context.Context, ref SessionRef) error {
+	session, err := s.store.Open(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return session.Discard(ctx)
+}
+
+// PlanEdits diffs an edited export against the state it was exported with.
+// Nothing is written; callers submit the updates to the bulk-update job.
+func (s *Service) PlanEdits(ctx context.Context, source io.Reader) (EditPlan, error) {
+	plan := EditPlan{Changes: []AggregateChange{}}
+	if s.edits == nil {
+		return plan, ErrEditsUnavailable
+	}
+	set, err := s.edits.ReadEdits(ctx, source)
+	if err != nil {
+		return plan, fmt.Errorf("%w: read edited workbook: %v", ErrInvalidWorkbook, err)
+	}
+	if set.Manifest.SchemaVersion != SchemaVersion {
+		return plan, fmt.Errorf("%w: schema version %d", ErrInvalidWorkbook, set.Manifest.SchemaVersion)
+	}
+	plan.Total, plan.Issues = len(set.Aggregates), set.Issues
+	for _, pair := range set.Aggregates {
+		plan.add(planChange(pair.Edited, &pair.Exported))
+	}
+	if len(plan.Issues) > 0 {
+		return plan, ErrInvalidWorkbook
+	}
+	return plan, nil
+}
+
+func (p *EditPlan) add(plan changePlan) {
+	p.Issues = append(p.Issues, plan.issues...)
+	if plan.change.Action == ChangeUnchanged {
+		p.Unchanged++
+		return
+	}
+	p.Changes = append(p.Changes, plan.change)
+	p.Updates = append(p.Updates, plan.update)
+}
+
+func (s *Service) stage(ctx context.Context, reader WorkbookReader, command Command) (Result, Session, error) {
 	id, session, err := s.store.Start(ctx, command.OwnerID)
 	result := Result{ImportID: id}
 	if err != nil {
 		return result, nil, err
 	}
-	manifest, err := s.reader.Read(ctx, command.Source, session)
+	manifest, err := reader.Read(ctx, command.Source, session)
 	if err != nil {
 		_ = session.Discard(ctx)
 		return result, nil, fmt.Errorf("%w: read import workbook: %v", ErrInvalidWorkbook, err)
 	}
 	result.Total = manifest.DeviceCount
+	result.Issues = manifest.Issues
 	if manifest.SchemaVersion != SchemaVersion {
 		_ = session.Discard(ctx)
 		return result, nil, fmt.Errorf("%w: schema version %d", ErrInvalidWorkbook, manifest.SchemaVersion)
@@ -54,31 +183,104 @@ func (s *Service) stage(ctx context.Context, command Command) (Result, Session,
 	return result, session, nil
 }
 
+// validate discards the session when the staged rows or the reader reported
+// issues, so nothing is written from an invalid upload.
+func (s *Service) validate(ctx context.Context, session Session, issues *[]Issue) error {
+	found, err := session.Validate(ctx)
+	if err == nil && len(*issues) == 0 && len(found) == 0 {
+		return nil
+	}
+	*issues = append(*issues, found...)
+	_ = session.Discard(ctx)
+	return errors.Join(ErrInvalidWorkbook, err)
+}
+
 func (s *Service) write(ctx context.Context, session Session, result Result) (Result, error) {
+	err := s.eachPlan(ctx, session, func(aggregate Aggregate, plan changePlan) error {
+		if len(plan.issues) > 0 {
+			result.Failed++
+			result.Issues = append(result.Issues, plan.issues...)
+			return nil
+		}
+		if err := s.apply(ctx, aggregate, plan); err != nil {
+			result.Failed++
+			result.Issues = append(result.Issues, applyIssues(aggregate.FieldDevice.ID, err)...)
+			return nil
+		}
+		result.count(plan.change.Action)
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, session.Complete(ctx)
+}
+
+// applyIssues reports each rejected field of a validation error on its own,
+// so a refused alarm value names its field and rule.
+func applyIssues(fieldDeviceID uuid.UUID, err error) []Issue {
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) || len(validation.Fields) == 0 {
+		return []Issue{{Code: "aggregate_import_failed", Entity: "field_device", SourceID: fieldDeviceID, Message: err.Error()}}
+	}
+	fields := make([]string, 0, len(validation.Fields))
+	for field := range validation.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	issues := make([]Issue, len(fields))
+	for index, field := range fields {
+		code := validation.Codes[field]
+		if code == "" {
+			code = "invalid"
+		}
+		issues[index] = Issue{Code: code, Entity: "field_device", SourceID: fieldDeviceID, Field: field, Message: validation.Fields[field]}
+	}
+	return issues
+}
+
+func (s *Service) apply(ctx context.Context, aggregate Aggregate, plan changePlan) error {
+	switch plan.change.Action {
+	case ChangeCreate:
+		return s.writer.Import(ctx, aggregate)
+	case ChangeUpdate:
+		return s.writer.Update(ctx, plan.update)
+	default:
+		return nil
+	}
+}
+
+func (r *Result) count(action ChangeAction) {
+	switch action {
+	case ChangeUpdate:
+		r.Imported++
+		r.Updated++
+	case ChangeUnchanged:
+		r.Unchanged++
+	default:
+		r.Imported++
+	}
+}
+
+func (s *Service) eachPlan(ctx context.Context, session Session, visit func(Aggregate, changePlan) error) error {
 	cursor := ""
 	for {
 		page, err := session.Aggregates(ctx, cursor)
 		if err != nil {
-			return result, err
+			return err
 		}
 		for index := range page.Items {
-			if err := s.writer.Import(ctx, page.Items[index]); err != nil {
-				result.Failed++
-				result.Issues = append(result.Issues, Issue{
-					Code: "aggregate_import_failed", Entity: "field_device",
-					SourceID: page.Items[index].FieldDevice.ID, Message: err.Error(),
-				})
-				continue
+			current, err := s.writer.Current(ctx, page.Items[index].FieldDevice.ID)
+			if err != nil {
+				return err
+			}
+			if err := visit(page.Items[index], planChange(page.Items[index], current)); err != nil {
+				return err
 			}
-			result.Imported++
 		}
 		if page.NextCursor == "" {
-			break
+			return nil
 		}
 		cursor = page.NextCursor
 	}
-	if err := session.Complete(ctx); err != nil {
-		return result, err
-	}
-	return result, nil
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/besart951/go_infra_link/backend/internal/domain"
 	facility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	"github.com/google/uuid"
 )
@@ -26,6 +27,10 @@ func (s storeStub) Start(context.Context, uuid.UUID) (uuid.UUID, Session, error)
 	return uuid.New(), s.session, nil
 }
 
+func (s storeStub) Open(context.Context, SessionRef) (Session, error) {
+	return s.session, nil
+}
+
 type sessionStub struct {
 	noopSink
 	issues    []Issue
@@ -45,9 +50,21 @@ func (s *sessionStub) Aggregates(context.Context, string) (AggregatePage, error)
 func (s *sessionStub) Complete(context.Context) error { s.completed = true; return nil }
 func (s *sessionStub) Discard(context.Context) error  { s.discarded = true; return nil }
 
-type writerStub struct{ calls int }
+type writerStub struct {
+	calls   int
+	updates []facility.BulkFieldDeviceUpdate
+	current map[uuid.UUID]*Aggregate
+	err     error
+}
 
+func (w *writerStub) Current(_ context.Context, id uuid.UUID) (*Aggregate, error) {
+	return w.current[id], w.err
+}
 func (w *writerStub) Import(context.Context, Aggregate) error { w.calls++; return nil }
+func (w *writerStub) Update(_ context.Context, update facility.BulkFieldDeviceUpdate) error {
+	w.updates = append(w.updates, update)
+	return nil
+}
 
 func TestServiceRejectsWorkbookBeforeMutation(t *testing.T) {
 	session := &sessionStub{issues: []Issue{{Code: "orphan", Message: "orphan object"}}}
@@ -64,6 +81,27 @@ func TestServiceRejectsWorkbookBeforeMutation(t *testing.T) {
 	}
 }
 
+func TestServiceImportEDERejectsUnresolvedReaderRows(t *testing.T) {
+	session := &sessionStub{}
+	writer := &writerStub{}
+	service := NewService(readerStub{}, storeStub{session}, writer)
+	service.SetEDEReaders(edeFactoryStub{reader: readerStub{manifest: Manifest{
+		SchemaVersion: SchemaVersion, Issues: []Issue{{Code: "unresolved_designation"}},
+	}}})
+
+	result, err := service.ImportEDE(context.Background(), EDECommand{SPSControllerSystemTypeID: uuid.New(), Source: strings.NewReader("data")})
+
+	if !errors.Is(err, ErrInvalidWorkbook) || len(result.Issues) != 1 || writer.calls != 0 || !session.discarded {
+		t.Fatalf("expected rejected EDE import, got result=%+v err=%v calls=%d", result, err, writer.calls)
+	}
+}
+
+type edeFactoryStub struct{ reader WorkbookReader }
+
+func (f edeFactoryStub) EDEReader(context.Context, uuid.UUID) (WorkbookReader, error) {
+	return f.reader, nil
+}
+
 func TestServiceImportsValidatedAggregatesPageByPage(t *testing.T) {
 	session := &sessionStub{pages: []AggregatePage{
 		{Items: []Aggregate{{}}, NextCursor: "next"},
@@ -79,6 +117,103 @@ func TestServiceImportsValidatedAggregatesPageByPage(t *testing.T) {
 	}
 }
 
+func TestServicePreviewClassifiesAggregatesWithoutWriting(t *testing.T) {
+	bmk := "B-1"
+	existing := Aggregate{FieldDevice: facility.FieldDevice{Base: domain.Base{ID: uuid.New(), Version: 3}, ApparatNr: 1}}
+	unchanged := Aggregate{FieldDevice: facility.FieldDevice{Base: domain.Base{ID: uuid.New(), Version: 1}, ApparatNr: 2}}
+	edited := existing
+	edited.FieldDevice.BMK = &bmk
+	session := &sessionStub{pages: []AggregatePage{{Items: []Aggregate{edited, unchanged, {FieldDevice: facility.FieldDevice{Base: domain.Base{ID: uuid.New()}}}}}}}
+	writer := &writerStub{current: map[uuid.UUID]*Aggregate{existing.FieldDevice.ID: &existing, unchanged.FieldDevice.ID: &unchanged}}
+	service := NewService(readerStub{manifest: Manifest{SchemaVersion: SchemaVersion, DeviceCount: 3}}, storeStub{session}, writer)
+
+	preview, err := service.Preview(context.Background(), Command{Source: strings.NewReader("data")})
+
+	if err != nil || preview.Created != 1 || preview.Updated != 1 || preview.Unchanged != 1 {
+		t.Fatalf("unexpected preview=%+v err=%v", preview, err)
+	}
+	diffs := preview.Changes[0].Diffs
+	if len(diffs) != 1 || diffs[0].Field != "bmk" || diffs[0].Before != nil || diffs[0].After != bmk {
+		t.Fatalf("unexpected diffs %+v", diffs)
+	}
+	if writer.calls != 0 || len(writer.updates) != 0 || session.discarded || session.completed {
+		t.Fatalf("preview mutated state: calls=%d updates=%d", writer.calls, len(writer.updates))
+	}
+}
+
+func TestServicePreviewDiscardsSessionWhenPlanningFails(t *testing.T) {
+	session := &sessionStub{pages: []AggregatePage{{Items: []Aggregate{{}}}}}
+	writer := &writerStub{err: errors.New("database unavailable")}
+	service := NewService(readerStub{manifest: Manifest{SchemaVersion: SchemaVersion, DeviceCount: 1}}, storeStub{session}, writer)
+
+	if _, err := service.Preview(context.Background(), Command{Source: strings.NewReader("data")}); err == nil || !session.discarded {
+		t.Fatalf("expected failed preview to discard its session: err=%v discarded=%v", err, session.discarded)
+	}
+}
+
+func TestServicePreviewReportsAlarmValueChangesOfExistingObjects(t *testing.T) {
+	fieldID, before, after := uuid.New(), 10.0, 12.0
+	object := facility.BacnetObject{Base: domain.Base{ID: uuid.New()}}
+	object.AlarmValues = []facility.BacnetObjectAlarmValue{{AlarmTypeFieldID: fieldID, ValueNumber: &before}}
+	existing := Aggregate{FieldDevice: facility.FieldDevice{Base: domain.Base{ID: uuid.New(), Version: 2}}, BacnetObjects: []facility.BacnetObject{object}}
+	edited := existing
+	edited.BacnetObjects = []facility.BacnetObject{object}
+	edited.BacnetObjects[0].AlarmValues = []facility.BacnetObjectAlarmValue{{AlarmTypeFieldID: fieldID, ValueNumber: &after}}
+	session := &sessionStub{pages: []AggregatePage{{Items: []Aggregate{edited}}}}
+	writer := &writerStub{current: map[uuid.UUID]*Aggregate{existing.FieldDevice.ID: &existing}}
+	service := NewService(readerStub{manifest: Manifest{SchemaVersion: SchemaVersion, DeviceCount: 1}}, storeStub{session}, writer)
+
+	preview, err := service.Preview(context.Background(), Command{Source: strings.NewReader("data")})
+
+	if err != nil || preview.Updated != 1 || len(preview.Issues) != 1 || preview.Issues[0].Field != "alarm_values" {
+		t.Fatalf("changed alarm values must be reported: preview=%+v err=%v", preview, err)
+	}
+}
+
+func TestServiceConfirmAppliesUpdatesWithExportedVersion(t *testing.T) {
+	bmk := "B-1"
+	existing := Aggregate{FieldDevice: facility.FieldDevice{Base: domain.Base{ID: uuid.New(), Version: 3}}}
+	edited := existing
+	edited.FieldDevice.BMK = &bmk
+	session := &sessionStub{pages: []AggregatePage{{Items: []Aggregate{edited}}}}
+	writer := &writerStub{current: map[uuid.UUID]*Aggregate{existing.FieldDevice.ID: &existing}}
+	service := NewService(readerStub{}, storeStub{session}, writer)
+
+	result, err := service.Confirm(context.Background(), SessionRef{ImportID: uuid.New()})
+
+	if err != nil || result.Updated != 1 || len(writer.updates) != 1 || !session.completed {
+		t.Fatalf("unexpected result=%+v err=%v", result, err)
+	}
+	if update := writer.updates[0]; update.BaseVersion != 3 || !update.HasBMK || *update.BMK != bmk {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+func TestServicePlanEditsUsesExportedVersions(t *testing.T) {
+	bmk := "B-2"
+	exported := Aggregate{FieldDevice: facility.FieldDevice{Base: domain.Base{ID: uuid.New(), Version: 7}}}
+	edited := exported
+	edited.FieldDevice.BMK = &bmk
+	service := NewService(readerStub{}, storeStub{}, &writerStub{})
+	service.SetEditReader(editReaderStub{set: EditSet{
+		Manifest:   Manifest{SchemaVersion: SchemaVersion},
+		Aggregates: []EditedAggregate{{Exported: exported, Edited: edited}, {Exported: exported, Edited: exported}},
+	}})
+
+	plan, err := service.PlanEdits(context.Background(), strings.NewReader("data"))
+
+	if err != nil || plan.Total != 2 || plan.Unchanged != 1 || len(plan.Updates) != 1 {
+		t.Fatalf("unexpected plan=%+v err=%v", plan, err)
+	}
+	if update := plan.Updates[0]; update.BaseVersion != 7 || !update.HasBMK || *update.BMK != bmk {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+type editReaderStub struct{ set EditSet }
+
+func (r editReaderStub) ReadEdits(context.Context, io.Reader) (EditSet, error) { return r.set, nil }
+
 type noopSink struct{}
 
 func (noopSink) FieldDevices(context.Context, []facility.FieldDevice) error           { return nil }
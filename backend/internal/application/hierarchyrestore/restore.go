@@ -14,6 +14,18 @@ const (
 	PhaseRestore Phase = "restore"
 )
 
+// Root names the aggregate whose subtree is restored. Its value doubles as
+// the change_event_scopes scope type and the facility lifecycle kind.
+type Root string
+
+const (
+	RootBuilding                Root = "building"
+	RootControlCabinet          Root = "control_cabinet"
+	RootSPSController           Root = "sps_controller"
+	RootSPSControllerSystemType Root = "sps_controller_system_type"
+	RootObjectData              Root = "object_data"
+)
+
 type Position struct {
 	PhaseIndex int       `json:"phase_index"`
 	TableIndex int       `json:"table_index"`
@@ -26,15 +38,16 @@ type Position struct {
 }
 
 type Command struct {
-	ControlCabinetID uuid.UUID
-	ProjectID        *uuid.UUID
-	AsOf             time.Time
-	Phase            Phase
-	Table            string
-	AfterID          uuid.UUID
-	Limit            int
-	ActorID          uuid.UUID
-	BatchID          uuid.UUID
+	Root      Root
+	RootID    uuid.UUID
+	ProjectID *uuid.UUID
+	AsOf      time.Time
+	Phase     Phase
+	Table     string
+	AfterID   uuid.UUID
+	Limit     int
+	ActorID   uuid.UUID
+	BatchID   uuid.UUID
 }
 
 type Result struct {
@@ -54,19 +67,70 @@ var phaseTables = map[Phase][]string{
 	PhaseDelete: {
 		"project_field_devices", "project_sps_controllers", "project_control_cabinets",
 		"bacnet_object_alarm_values", "bacnet_objects", "specifications", "field_devices",
-		"sps_controller_system_types", "sps_controllers", "control_cabinets",
+		"sps_controller_system_types", "sps_controllers", "control_cabinets", "object_data", "buildings",
 	},
 	PhaseRestore: {
-		"control_cabinets", "sps_controllers", "sps_controller_system_types", "field_devices",
+		"buildings", "object_data", "control_cabinets", "sps_controllers", "sps_controller_system_types", "field_devices",
 		"specifications", "bacnet_objects", "bacnet_object_alarm_values",
 		"project_control_cabinets", "project_sps_controllers", "project_field_devices",
 	},
 }
 
+// rootTables lists the tables that belong to the subtree of each root. The
+// phase order above is kept so parents are restored before their children.
+var rootTables = map[Root]map[string]struct{}{
+	RootBuilding: tableSet(
+		"buildings", "control_cabinets", "sps_controllers", "sps_controller_system_types", "field_devices",
+		"specifications", "bacnet_objects", "bacnet_object_alarm_values",
+		"project_control_cabinets", "project_sps_controllers", "project_field_devices",
+	),
+	RootControlCabinet: tableSet(
+		"control_cabinets", "sps_controllers", "sps_controller_system_types", "field_devices",
+		"specifications", "bacnet_objects", "bacnet_object_alarm_values",
+		"project_control_cabinets", "project_sps_controllers", "project_field_devices",
+	),
+	RootSPSController: tableSet(
+		"sps_controllers", "sps_controller_system_types", "field_devices",
+		"specifications", "bacnet_objects", "bacnet_object_alarm_values",
+		"project_sps_controllers", "project_field_devices",
+	),
+	RootSPSControllerSystemType: tableSet(
+		"sps_controller_system_types", "field_devices",
+		"specifications", "bacnet_objects", "bacnet_object_alarm_values", "project_field_devices",
+	),
+	RootObjectData: tableSet("object_data", "bacnet_objects", "bacnet_object_alarm_values"),
+}
+
 func Phases() []Phase {
 	return []Phase{PhaseDelete, PhaseRestore}
 }
 
-func Tables(phase Phase) []string {
-	return append([]string(nil), phaseTables[phase]...)
+func Roots() []Root {
+	return []Root{RootBuilding, RootControlCabinet, RootSPSController, RootSPSControllerSystemType, RootObjectData}
+}
+
+func IsRoot(root Root) bool {
+	_, ok := rootTables[root]
+	return ok
+}
+
+// Tables returns the tables of the root's subtree in the order the phase
+// visits them.
+func Tables(root Root, phase Phase) []string {
+	allowed := rootTables[root]
+	tables := make([]string, 0, len(allowed))
+	for _, table := range phaseTables[phase] {
+		if _, ok := allowed[table]; ok {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+func tableSet(tables ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(tables))
+	for _, table := range tables {
+		set[table] = struct{}{}
+	}
+	return set
 }
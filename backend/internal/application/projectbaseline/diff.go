@@ -0,0 +1,235 @@
+package projectbaseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// volatileFields change on every write without changing the content.
+var volatileFields = map[string]struct{}{
+	"id":         {},
+	"created_at": {},
+	"updated_at": {},
+	"deleted_at": {},
+	"version":    {},
+}
+
+// labelSpec tells the diff how to name an entity: the first non-empty label
+// field, prefixed with the label of its parent when the parent is known.
+type labelSpec struct {
+	fields []string
+	parent string
+}
+
+var labelSpecs = map[Category]labelSpec{
+	CategoryControlCabinets: {fields: []string{"control_cabinet_nr"}},
+	CategorySPSControllers:  {fields: []string{"ga_device", "device_name"}, parent: "control_cabinet_id"},
+	CategoryFieldDevices:    {fields: []string{"bmk", "apparat_nr"}},
+	CategoryBacnetObjects:   {fields: []string{"text_fix"}, parent: "field_device_id"},
+	CategoryAlarmValues:     {parent: "bacnet_object_id"},
+}
+
+type entityKey struct {
+	category Category
+	id       uuid.UUID
+}
+
+type decodedState map[entityKey]map[string]any
+
+// SameSnapshot reports whether two row snapshots hold the same values,
+// regardless of key order and formatting.
+func SameSnapshot(a, b json.RawMessage) bool {
+	var left, right any
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// diffStates compares two entity sets field by field. Changes come in
+// hierarchy order, then by label.
+func diffStates(from, to []Entity) (map[Category]Summary, []Change, error) {
+	before, err := decodeState(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := decodeState(to)
+	if err != nil {
+		return nil, nil, err
+	}
+	labels := newLabeler(before, after)
+	summary := make(map[Category]Summary, len(labelSpecs))
+	for _, category := range Categories() {
+		summary[category] = Summary{}
+	}
+	changes := []Change{}
+	for key, row := range after {
+		counts := summary[key.category]
+		previous, existed := before[key]
+		switch {
+		case !existed:
+			counts.Added++
+			changes = append(changes, Change{Category: key.category, EntityID: key.id, Label: labels.label(key), Action: ChangeAdded})
+		default:
+			fields := diffFields(previous, row)
+			if len(fields) == 0 {
+				counts.Unchanged++
+				break
+			}
+			counts.Changed++
+			changes = append(changes, Change{Category: key.category, EntityID: key.id, Label: labels.label(key), Action: ChangeChanged, Fields: fields})
+		}
+		summary[key.category] = counts
+	}
+	for key := range before {
+		if _, exists := after[key]; exists {
+			continue
+		}
+		counts := summary[key.category]
+		counts.Removed++
+		summary[key.category] = counts
+		changes = append(changes, Change{Category: key.category, EntityID: key.id, Label: labels.label(key), Action: ChangeRemoved})
+	}
+	sortChanges(changes)
+	return summary, changes, nil
+}
+
+func decodeState(entities []Entity) (decodedState, error) {
+	state := make(decodedState, len(entities))
+	for _, entity := range entities {
+		var row map[string]any
+		if err := json.Unmarshal(entity.Snapshot, &row); err != nil {
+			return nil, fmt.Errorf("decode %s %s: %w", entity.Category, entity.ID, err)
+		}
+		state[entityKey{category: entity.Category, id: entity.ID}] = row
+	}
+	return state, nil
+}
+
+func diffFields(before, after map[string]any) []FieldChange {
+	names := make(map[string]struct{}, len(before)+len(after))
+	for name := range before {
+		names[name] = struct{}{}
+	}
+	for name := range after {
+		names[name] = struct{}{}
+	}
+	var fields []FieldChange
+	for name := range names {
+		if _, volatile := volatileFields[name]; volatile {
+			continue
+		}
+		if !reflect.DeepEqual(before[name], after[name]) {
+			fields = append(fields, FieldChange{Field: name, Before: before[name], After: after[name]})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+	return fields
+}
+
+func sortChanges(changes []Change) {
+	order := make(map[Category]int, len(labelSpecs))
+	for index, category := range Categories() {
+		order[category] = index
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		left, right := changes[i], changes[j]
+		if left.Category != right.Category {
+			return order[left.Category] < order[right.Category]
+		}
+		if left.Label != right.Label {
+			return left.Label < right.Label
+		}
+		return left.EntityID.String() < right.EntityID.String()
+	})
+}
+
+// labeler names entities from either side, preferring the newer state so
+// renamed rows show their current name.
+type labeler struct {
+	states []decodedState
+}
+
+func newLabeler(before, after decodedState) labeler {
+	return labeler{states: []decodedState{after, before}}
+}
+
+func (l labeler) label(key entityKey) string {
+	row := l.row(key)
+	spec := labelSpecs[key.category]
+	own := ""
+	for _, field := range spec.fields {
+		if value := labelValue(row[field]); value != "" {
+			own = value
+			break
+		}
+	}
+	parent := l.parentLabel(key.category, row)
+	switch {
+	case parent != "" && own != "":
+		return parent + " / " + own
+	case parent != "":
+		return parent
+	case own != "":
+		return own
+	default:
+		return key.id.String()
+	}
+}
+
+func (l labeler) parentLabel(category Category, row map[string]any) string {
+	spec := labelSpecs[category]
+	if spec.parent == "" {
+		return ""
+	}
+	raw, _ := row[spec.parent].(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	parent := parentCategory(category)
+	if l.row(entityKey{category: parent, id: id}) == nil {
+		return ""
+	}
+	return l.label(entityKey{category: parent, id: id})
+}
+
+func (l labeler) row(key entityKey) map[string]any {
+	for _, state := range l.states {
+		if row, ok := state[key]; ok {
+			return row
+		}
+	}
+	return nil
+}
+
+func parentCategory(category Category) Category {
+	switch category {
+	case CategorySPSControllers:
+		return CategoryControlCabinets
+	case CategoryBacnetObjects:
+		return CategoryFieldDevices
+	case CategoryAlarmValues:
+		return CategoryBacnetObjects
+	default:
+		return ""
+	}
+}
+
+func labelValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(typed)
+	case float64:
+		return fmt.Sprintf("%g", typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
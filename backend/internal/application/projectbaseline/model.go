@@ -0,0 +1,130 @@
+package projectbaseline
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category names one of the facility tables a baseline covers. The values
+// are the table names, so baseline entries and history versions share them.
+type Category string
+
+const (
+	CategoryControlCabinets Category = "control_cabinets"
+	CategorySPSControllers  Category = "sps_controllers"
+	CategoryFieldDevices    Category = "field_devices"
+	CategoryBacnetObjects   Category = "bacnet_objects"
+	CategoryAlarmValues     Category = "bacnet_object_alarm_values"
+)
+
+// Categories lists the baseline categories in hierarchy order.
+func Categories() []Category {
+	return []Category{CategoryControlCabinets, CategorySPSControllers, CategoryFieldDevices, CategoryBacnetObjects, CategoryAlarmValues}
+}
+
+// Baseline is a named, frozen state of a project's facility data. Entries
+// point at the history version that matched each row at capture time.
+type Baseline struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	CapturedAt  time.Time  `json:"captured_at"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	EntityCount int        `json:"entity_count"`
+}
+
+// Entity is one row of a baseline or of the current state.
+type Entity struct {
+	Category Category
+	ID       uuid.UUID
+	Snapshot json.RawMessage
+}
+
+type CreateCommand struct {
+	ProjectID   uuid.UUID
+	Name        string
+	Description *string
+	CreatedBy   *uuid.UUID
+}
+
+// Target selects the right-hand side of a comparison. A nil BaselineID
+// compares against the project's current state.
+type Target struct {
+	BaselineID *uuid.UUID
+}
+
+type ChangeAction string
+
+const (
+	ChangeAdded   ChangeAction = "added"
+	ChangeRemoved ChangeAction = "removed"
+	ChangeChanged ChangeAction = "changed"
+)
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// Change describes one entity that differs between the two sides. Fields is
+// only set for changed entities.
+type Change struct {
+	Category Category      `json:"category"`
+	EntityID uuid.UUID     `json:"entity_id"`
+	Label    string        `json:"label"`
+	Action   ChangeAction  `json:"action"`
+	Fields   []FieldChange `json:"fields,omitempty"`
+}
+
+type Summary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Side names one state of a comparison. BaselineID is nil for the current
+// state.
+type Side struct {
+	BaselineID *uuid.UUID `json:"baseline_id,omitempty"`
+	Name       string     `json:"name"`
+	CapturedAt time.Time  `json:"captured_at"`
+}
+
+type Diff struct {
+	ProjectID uuid.UUID            `json:"project_id"`
+	From      Side                 `json:"from"`
+	To        Side                 `json:"to"`
+	Summary   map[Category]Summary `json:"summary"`
+	Changes   []Change             `json:"changes"`
+	Truncated bool                 `json:"truncated,omitempty"`
+}
+
+// Limit keeps the first n changes. The summary still counts all of them.
+func (d Diff) Limit(n int) Diff {
+	if n > 0 && len(d.Changes) > n {
+		d.Changes = d.Changes[:n]
+		d.Truncated = true
+	}
+	return d
+}
+
+// Store captures baselines and loads both sides of a comparison. Capture
+// assigns the baseline ID and entity count.
+type Store interface {
+	Capture(ctx context.Context, baseline Baseline) (Baseline, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]Baseline, error)
+	Get(ctx context.Context, projectID, baselineID uuid.UUID) (Baseline, error)
+	LoadBaseline(ctx context.Context, baselineID uuid.UUID) ([]Entity, error)
+	LoadCurrent(ctx context.Context, projectID uuid.UUID) ([]Entity, error)
+}
+
+type DiffWriter interface {
+	WriteDiff(ctx context.Context, target io.Writer, diff Diff) error
+}
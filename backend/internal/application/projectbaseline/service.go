@@ -0,0 +1,105 @@
+package projectbaseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidBaseline = errors.New("invalid project baseline")
+
+const (
+	maxNameLength = 120
+	// CurrentStateName labels the current project state in comparisons.
+	CurrentStateName = "current"
+)
+
+type Service struct {
+	store  Store
+	writer DiffWriter
+	now    func() time.Time
+}
+
+func NewService(store Store, writer DiffWriter) *Service {
+	return &Service{store: store, writer: writer, now: time.Now}
+}
+
+// Create freezes the project's current facility state under a name that is
+// unique within the project.
+func (s *Service) Create(ctx context.Context, command CreateCommand) (Baseline, error) {
+	name := strings.TrimSpace(command.Name)
+	if command.ProjectID == uuid.Nil || name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return Baseline{}, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidBaseline, maxNameLength)
+	}
+	return s.store.Capture(ctx, Baseline{
+		ProjectID:   command.ProjectID,
+		Name:        name,
+		Description: trimmedOrNil(command.Description),
+		CapturedAt:  s.now().UTC(),
+		CreatedBy:   command.CreatedBy,
+	})
+}
+
+func (s *Service) List(ctx context.Context, projectID uuid.UUID) ([]Baseline, error) {
+	return s.store.List(ctx, projectID)
+}
+
+// Compare diffs a baseline against another baseline of the same project or
+// against the current state.
+func (s *Service) Compare(ctx context.Context, projectID, baselineID uuid.UUID, target Target) (Diff, error) {
+	from, fromEntities, err := s.loadBaseline(ctx, projectID, baselineID)
+	if err != nil {
+		return Diff{}, err
+	}
+	diff := Diff{ProjectID: projectID, From: baselineSide(from)}
+	var toEntities []Entity
+	if target.BaselineID != nil {
+		var to Baseline
+		to, toEntities, err = s.loadBaseline(ctx, projectID, *target.BaselineID)
+		diff.To = baselineSide(to)
+	} else {
+		toEntities, err = s.store.LoadCurrent(ctx, projectID)
+		diff.To = Side{Name: CurrentStateName, CapturedAt: s.now().UTC()}
+	}
+	if err != nil {
+		return Diff{}, err
+	}
+	diff.Summary, diff.Changes, err = diffStates(fromEntities, toEntities)
+	return diff, err
+}
+
+// ExportDiff writes a comparison as a workbook.
+func (s *Service) ExportDiff(ctx context.Context, diff Diff, target io.Writer) error {
+	return s.writer.WriteDiff(ctx, target, diff)
+}
+
+func (s *Service) loadBaseline(ctx context.Context, projectID, baselineID uuid.UUID) (Baseline, []Entity, error) {
+	baseline, err := s.store.Get(ctx, projectID, baselineID)
+	if err != nil {
+		return Baseline{}, nil, err
+	}
+	entities, err := s.store.LoadBaseline(ctx, baseline.ID)
+	return baseline, entities, err
+}
+
+func baselineSide(baseline Baseline) Side {
+	id := baseline.ID
+	return Side{BaselineID: &id, Name: baseline.Name, CapturedAt: baseline.CapturedAt}
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,124 @@
+package projectbaseline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+type storeStub struct {
+	baselines map[uuid.UUID]Baseline
+	entities  map[uuid.UUID][]Entity
+	current   []Entity
+	captured  Baseline
+}
+
+func (s *storeStub) Capture(_ context.Context, baseline Baseline) (Baseline, error) {
+	baseline.ID = uuid.New()
+	s.captured = baseline
+	return baseline, nil
+}
+
+func (s *storeStub) List(context.Context, uuid.UUID) ([]Baseline, error) { return nil, nil }
+
+func (s *storeStub) Get(_ context.Context, projectID, baselineID uuid.UUID) (Baseline, error) {
+	baseline, ok := s.baselines[baselineID]
+	if !ok || baseline.ProjectID != projectID {
+		return Baseline{}, domain.ErrNotFound
+	}
+	return baseline, nil
+}
+
+func (s *storeStub) LoadBaseline(_ context.Context, baselineID uuid.UUID) ([]Entity, error) {
+	return s.entities[baselineID], nil
+}
+
+func (s *storeStub) LoadCurrent(context.Context, uuid.UUID) ([]Entity, error) { return s.current, nil }
+
+func entity(t *testing.T, category Category, id uuid.UUID, row map[string]any) Entity {
+	t.Helper()
+	snapshot, err := json.Marshal(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Entity{Category: category, ID: id, Snapshot: snapshot}
+}
+
+func TestCreateRejectsBlankName(t *testing.T) {
+	store := &storeStub{}
+	service := NewService(store, nil)
+	if _, err := service.Create(context.Background(), CreateCommand{ProjectID: uuid.New(), Name: "  "}); !errors.Is(err, ErrInvalidBaseline) {
+		t.Fatalf("Create() error = %v, want ErrInvalidBaseline", err)
+	}
+	description := "  "
+	baseline, err := service.Create(context.Background(), CreateCommand{ProjectID: uuid.New(), Name: " Baseline v3 ", Description: &description})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if baseline.Name != "Baseline v3" || baseline.Description != nil || baseline.CapturedAt.IsZero() {
+		t.Fatalf("captured baseline = %+v", baseline)
+	}
+}
+
+func TestCompareAgainstCurrentStateReportsAddedRemovedAndChangedRows(t *testing.T) {
+	projectID, baselineID := uuid.New(), uuid.New()
+	cabinetID, deviceID, removedID, objectID, valueID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	cabinet := map[string]any{"id": cabinetID.String(), "control_cabinet_nr": "SG01", "version": 1}
+	device := map[string]any{"id": deviceID.String(), "bmk": "T01", "apparat_nr": 1, "updated_at": "2026-01-01"}
+	object := map[string]any{"id": objectID.String(), "field_device_id": deviceID.String(), "text_fix": "AI01"}
+	store := &storeStub{
+		baselines: map[uuid.UUID]Baseline{baselineID: {ID: baselineID, ProjectID: projectID, Name: "Baseline v3"}},
+		entities: map[uuid.UUID][]Entity{baselineID: {
+			entity(t, CategoryControlCabinets, cabinetID, cabinet),
+			entity(t, CategoryFieldDevices, deviceID, device),
+			entity(t, CategoryFieldDevices, removedID, map[string]any{"bmk": "T02"}),
+			entity(t, CategoryBacnetObjects, objectID, object),
+		}},
+	}
+	cabinet["version"] = 4
+	device["apparat_nr"], device["updated_at"] = 2, "2026-02-01"
+	store.current = []Entity{
+		entity(t, CategoryControlCabinets, cabinetID, cabinet),
+		entity(t, CategoryFieldDevices, deviceID, device),
+		entity(t, CategoryBacnetObjects, objectID, object),
+		entity(t, CategoryAlarmValues, valueID, map[string]any{"bacnet_object_id": objectID.String(), "value_number": 21.5}),
+	}
+
+	diff, err := NewService(store, nil).Compare(context.Background(), projectID, baselineID, Target{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.To.Name != CurrentStateName || diff.From.Name != "Baseline v3" {
+		t.Fatalf("sides = %+v / %+v", diff.From, diff.To)
+	}
+	if got := diff.Summary[CategoryControlCabinets]; got != (Summary{Unchanged: 1}) {
+		t.Fatalf("cabinet summary = %+v, volatile fields must not count as changes", got)
+	}
+	if got := diff.Summary[CategoryFieldDevices]; got != (Summary{Removed: 1, Changed: 1}) {
+		t.Fatalf("field device summary = %+v", got)
+	}
+	if len(diff.Changes) != 3 {
+		t.Fatalf("changes = %+v", diff.Changes)
+	}
+	changed := diff.Changes[0]
+	if changed.Action != ChangeChanged || len(changed.Fields) != 1 || changed.Fields[0].Field != "apparat_nr" {
+		t.Fatalf("first change = %+v", changed)
+	}
+	added := diff.Changes[2]
+	if added.Category != CategoryAlarmValues || added.Action != ChangeAdded || added.Label != "T01 / AI01" {
+		t.Fatalf("alarm value change = %+v", added)
+	}
+}
+
+func TestCompareRejectsBaselineOfAnotherProject(t *testing.T) {
+	baselineID := uuid.New()
+	store := &storeStub{baselines: map[uuid.UUID]Baseline{baselineID: {ID: baselineID, ProjectID: uuid.New(), CapturedAt: time.Now()}}}
+	if _, err := NewService(store, nil).Compare(context.Background(), uuid.New(), baselineID, Target{}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Compare() error = %v, want ErrNotFound", err)
+	}
+}
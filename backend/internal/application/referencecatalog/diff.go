@@ -0,0 +1,117 @@
+package referencecatalog
+
+import (
+	"fmt"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+)
+
+type catalogField struct {
+	name  string
+	value any
+}
+
+// entrySpec tells diffEntries how to key and compare one catalog type.
+type entrySpec[T any] struct {
+	key    func(T) int
+	base   func(*T) *domain.Base
+	fields func(T) []catalogField
+}
+
+var stateTextSpec = entrySpec[domainFacility.StateText]{
+	key:  func(item domainFacility.StateText) int { return item.RefNumber },
+	base: func(item *domainFacility.StateText) *domain.Base { return &item.Base },
+	fields: func(item domainFacility.StateText) []catalogField {
+		fields := make([]catalogField, 0, domainFacility.StateTextSlots)
+		for index, state := range item.States() {
+			var value any
+			if state != nil {
+				value = *state
+			}
+			fields = append(fields, catalogField{name: domainFacility.StateTextField(index + 1), value: value})
+		}
+		return fields
+	},
+}
+
+var notificationClassSpec = entrySpec[domainFacility.NotificationClass]{
+	key:  func(item domainFacility.NotificationClass) int { return item.Nc },
+	base: func(item *domainFacility.NotificationClass) *domain.Base { return &item.Base },
+	fields: func(item domainFacility.NotificationClass) []catalogField {
+		return []catalogField{
+			{"event_category", item.EventCategory},
+			{"object_description", item.ObjectDescription},
+			{"internal_description", item.InternalDescription},
+			{"meaning", item.Meaning},
+			{"ack_required_not_normal", item.AckRequiredNotNormal},
+			{"ack_required_error", item.AckRequiredError},
+			{"ack_required_normal", item.AckRequiredNormal},
+			{"norm_not_normal", item.NormNotNormal},
+			{"norm_error", item.NormError},
+			{"norm_normal", item.NormNormal},
+		}
+	},
+}
+
+// diffEntries matches the imported rows to the stored ones by key. Updated
+// rows take over the stored ID and version; created rows have no ID yet.
+func diffEntries[T any](kind Kind, current, incoming []T, spec entrySpec[T]) (Plan, []T, []T) {
+	plan := Plan{Kind: kind, Total: len(incoming), Items: []Change{}}
+	stored := make(map[int]T, len(current))
+	ambiguous := make(map[int]bool)
+	for _, item := range current {
+		key := spec.key(item)
+		if _, exists := stored[key]; exists {
+			ambiguous[key] = true
+		}
+		stored[key] = item
+	}
+
+	var creates, updates []T
+	seen := make(map[int]bool, len(incoming))
+	for _, item := range incoming {
+		key := spec.key(item)
+		switch {
+		case seen[key]:
+			plan.Issues = append(plan.Issues, keyIssue("duplicate_key", key, fmt.Sprintf("key %d appears more than once in the file", key)))
+			continue
+		case ambiguous[key]:
+			plan.Issues = append(plan.Issues, keyIssue("ambiguous_key", key, fmt.Sprintf("key %d matches several stored entries", key)))
+			continue
+		}
+		seen[key] = true
+
+		existing, ok := stored[key]
+		if !ok {
+			plan.Created++
+			plan.Items = append(plan.Items, Change{Action: ChangeCreate, Key: key})
+			creates = append(creates, item)
+			continue
+		}
+		diffs := diffFields(spec.fields(existing), spec.fields(item))
+		if len(diffs) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		*spec.base(&item) = *spec.base(&existing)
+		plan.Updated++
+		plan.Items = append(plan.Items, Change{Action: ChangeUpdate, Key: key, ID: spec.base(&item).ID, Diffs: diffs})
+		updates = append(updates, item)
+	}
+	return plan, creates, updates
+}
+
+func diffFields(before, after []catalogField) []FieldDiff {
+	var diffs []FieldDiff
+	for index := range before {
+		if before[index].value != after[index].value {
+			diffs = append(diffs, FieldDiff{Field: before[index].name, Before: before[index].value, After: after[index].value})
+		}
+	}
+	return diffs
+}
+
+func keyIssue(code string, key int, message string) Issue {
+	return Issue{Code: code, Key: &key, Message: message}
+}
@@ -0,0 +1,130 @@
+package referencecatalog
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// Kind names one of the shared reference catalogs BACnet objects point at.
+type Kind string
+
+const (
+	KindStateTexts          Kind = "state_texts"
+	KindNotificationClasses Kind = "notification_classes"
+)
+
+func (k Kind) resource() domainFacility.BacnetReferenceResource {
+	if k == KindNotificationClasses {
+		return domainFacility.BacnetReferenceResourceNotificationClass
+	}
+	return domainFacility.BacnetReferenceResourceStateText
+}
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func ParseFormat(value string) (Format, bool) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case FormatCSV, FormatXLSX:
+		return format, true
+	default:
+		return "", false
+	}
+}
+
+// Catalog holds the rows of one kind. Readers report rows they could not map
+// as issues instead of failing the whole file.
+type Catalog struct {
+	Kind                Kind
+	StateTexts          []domainFacility.StateText
+	NotificationClasses []domainFacility.NotificationClass
+	Issues              []Issue
+}
+
+type Issue struct {
+	Code    string `json:"code"`
+	Row     int    `json:"row,omitempty"`
+	Key     *int   `json:"key,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ChangeAction string
+
+const (
+	ChangeCreate    ChangeAction = "create"
+	ChangeUpdate    ChangeAction = "update"
+	ChangeUnchanged ChangeAction = "unchanged"
+)
+
+// FieldDiff is one column whose imported value differs from the stored row.
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// Change describes what an import does to one catalog entry. ID is empty for
+// entries a preview would create. BacnetObjectCount tells how many objects an
+// update affects.
+type Change struct {
+	Action            ChangeAction `json:"action"`
+	Key               int          `json:"key"`
+	ID                uuid.UUID    `json:"id,omitempty"`
+	Diffs             []FieldDiff  `json:"diffs,omitempty"`
+	BacnetObjectCount int64        `json:"bacnet_object_count"`
+}
+
+// Plan is the upsert an import file resolves to. Items lists created and
+// updated entries only; unchanged ones are counted.
+type Plan struct {
+	Kind      Kind     `json:"kind"`
+	Total     int      `json:"total"`
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Items     []Change `json:"items"`
+	Issues    []Issue  `json:"issues,omitempty"`
+}
+
+// Coverage is the result of the multi-state state text consistency check.
+type Coverage struct {
+	Checked   int                                     `json:"checked"`
+	Truncated bool                                    `json:"truncated"`
+	Issues    []domainFacility.StateTextCoverageIssue `json:"issues"`
+}
+
+type Command struct {
+	Kind   Kind
+	Format Format
+	Source io.Reader
+}
+
+type Reader interface {
+	ReadCatalog(ctx context.Context, kind Kind, format Format, source io.Reader) (Catalog, error)
+}
+
+type Writer interface {
+	WriteCatalog(ctx context.Context, target io.Writer, format Format, catalog Catalog) error
+}
+
+// Store loads whole catalogs and saves one import atomically. Updates carry
+// the versions read by the plan, so concurrent edits fail the import.
+type Store interface {
+	StateTexts(ctx context.Context) ([]domainFacility.StateText, error)
+	NotificationClasses(ctx context.Context) ([]domainFacility.NotificationClass, error)
+	SaveStateTexts(ctx context.Context, create, update []domainFacility.StateText) error
+	SaveNotificationClasses(ctx context.Context, create, update []domainFacility.NotificationClass) error
+}
+
+type UsageCounter interface {
+	CountByResource(ctx context.Context, resource domainFacility.BacnetReferenceResource, ids []uuid.UUID) (map[uuid.UUID]int64, error)
+}
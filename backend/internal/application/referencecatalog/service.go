@@ -0,0 +1,218 @@
+package referencecatalog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCatalog      = errors.New("invalid reference catalog")
+	ErrCoverageUnavailable = errors.New("state text consistency check is unavailable")
+)
+
+const (
+	coveragePageSize     = 1000
+	DefaultCoverageLimit = 1000
+)
+
+type Service struct {
+	reader  Reader
+	writer  Writer
+	store   Store
+	usage   UsageCounter
+	objects domainFacility.MultiStateObjectRepository
+}
+
+func NewService(reader Reader, writer Writer, store Store, usage UsageCounter) *Service {
+	return &Service{reader: reader, writer: writer, store: store, usage: usage}
+}
+
+// SetMultiStateObjects enables CheckStateTextCoverage.
+func (s *Service) SetMultiStateObjects(objects domainFacility.MultiStateObjectRepository) {
+	s.objects = objects
+}
+
+// Preview reads an import file and reports the upsert it would perform.
+func (s *Service) Preview(ctx context.Context, command Command) (Plan, error) {
+	plan, _, err := s.plan(ctx, command)
+	return plan, err
+}
+
+// Import applies an import file as one upsert keyed by RefNumber or Nc. A
+// file with issues is refused as a whole.
+func (s *Service) Import(ctx context.Context, command Command) (Plan, error) {
+	plan, changes, err := s.plan(ctx, command)
+	if err != nil {
+		return plan, err
+	}
+	if len(plan.Issues) > 0 {
+		return plan, ErrInvalidCatalog
+	}
+	if err := changes.assignIDs(&plan); err != nil {
+		return plan, err
+	}
+	if command.Kind == KindNotificationClasses {
+		err = s.store.SaveNotificationClasses(ctx, changes.notificationClasses.create, changes.notificationClasses.update)
+	} else {
+		err = s.store.SaveStateTexts(ctx, changes.stateTexts.create, changes.stateTexts.update)
+	}
+	return plan, err
+}
+
+// Export writes the whole catalog ordered by its key.
+func (s *Service) Export(ctx context.Context, kind Kind, format Format, target io.Writer) error {
+	catalog := Catalog{Kind: kind}
+	var err error
+	if kind == KindNotificationClasses {
+		if catalog.NotificationClasses, err = s.store.NotificationClasses(ctx); err != nil {
+			return err
+		}
+		sort.SliceStable(catalog.NotificationClasses, func(i, j int) bool {
+			return catalog.NotificationClasses[i].Nc < catalog.NotificationClasses[j].Nc
+		})
+	} else {
+		if catalog.StateTexts, err = s.store.StateTexts(ctx); err != nil {
+			return err
+		}
+		sort.SliceStable(catalog.StateTexts, func(i, j int) bool {
+			return catalog.StateTexts[i].RefNumber < catalog.StateTexts[j].RefNumber
+		})
+	}
+	return s.writer.WriteCatalog(ctx, target, format, catalog)
+}
+
+// CheckStateTextCoverage flags multi-state objects whose state text is
+// missing or leaves a state of the object's state maps unnamed. At most limit
+// issues are returned; all objects are still counted.
+func (s *Service) CheckStateTextCoverage(ctx context.Context, limit int) (Coverage, error) {
+	if s.objects == nil {
+		return Coverage{}, ErrCoverageUnavailable
+	}
+	if limit <= 0 {
+		limit = DefaultCoverageLimit
+	}
+	texts, err := s.store.StateTexts(ctx)
+	if err != nil {
+		return Coverage{}, err
+	}
+	byID := make(map[uuid.UUID]*domainFacility.StateText, len(texts))
+	for index := range texts {
+		byID[texts[index].ID] = &texts[index]
+	}
+
+	coverage := Coverage{Issues: []domainFacility.StateTextCoverageIssue{}}
+	after := uuid.Nil
+	for {
+		objects, err := s.objects.ListMultiStateObjects(ctx, after, coveragePageSize)
+		if err != nil {
+			return Coverage{}, err
+		}
+		for _, object := range objects {
+			coverage.Checked++
+			var text *domainFacility.StateText
+			if object.StateTextID != nil {
+				text = byID[*object.StateTextID]
+			}
+			issue, ok := domainFacility.CheckStateTextCoverage(object, text)
+			if !ok {
+				continue
+			}
+			if len(coverage.Issues) == limit {
+				coverage.Truncated = true
+				continue
+			}
+			coverage.Issues = append(coverage.Issues, issue)
+		}
+		if len(objects) < coveragePageSize {
+			return coverage, nil
+		}
+		after = objects[len(objects)-1].ID
+	}
+}
+
+type upserts[T any] struct {
+	create []T
+	update []T
+}
+
+type catalogChanges struct {
+	stateTexts          upserts[domainFacility.StateText]
+	notificationClasses upserts[domainFacility.NotificationClass]
+}
+
+func (s *Service) plan(ctx context.Context, command Command) (Plan, catalogChanges, error) {
+	var changes catalogChanges
+	catalog, err := s.reader.ReadCatalog(ctx, command.Kind, command.Format, command.Source)
+	if err != nil {
+		return Plan{Kind: command.Kind, Items: []Change{}, Issues: catalog.Issues}, changes, err
+	}
+
+	var plan Plan
+	if command.Kind == KindNotificationClasses {
+		current, err := s.store.NotificationClasses(ctx)
+		if err != nil {
+			return Plan{}, changes, err
+		}
+		plan, changes.notificationClasses.create, changes.notificationClasses.update = diffEntries(command.Kind, current, catalog.NotificationClasses, notificationClassSpec)
+	} else {
+		current, err := s.store.StateTexts(ctx)
+		if err != nil {
+			return Plan{}, changes, err
+		}
+		plan, changes.stateTexts.create, changes.stateTexts.update = diffEntries(command.Kind, current, catalog.StateTexts, stateTextSpec)
+	}
+	plan.Issues = append(catalog.Issues, plan.Issues...)
+	return plan, changes, s.countUsage(ctx, &plan)
+}
+
+// countUsage fills in how many BACnet objects each updated entry affects.
+func (s *Service) countUsage(ctx context.Context, plan *Plan) error {
+	if s.usage == nil {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, plan.Updated)
+	for _, item := range plan.Items {
+		if item.Action == ChangeUpdate {
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	counts, err := s.usage.CountByResource(ctx, plan.Kind.resource(), ids)
+	if err != nil {
+		return err
+	}
+	for index := range plan.Items {
+		plan.Items[index].BacnetObjectCount = counts[plan.Items[index].ID]
+	}
+	return nil
+}
+
+// assignIDs gives created entries their IDs up front, so the plan returned
+// by Import names every row it wrote.
+func (c *catalogChanges) assignIDs(plan *Plan) error {
+	created := 0
+	for index := range plan.Items {
+		if plan.Items[index].Action != ChangeCreate {
+			continue
+		}
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		plan.Items[index].ID = id
+		if plan.Kind == KindNotificationClasses {
+			c.notificationClasses.create[created].ID = id
+		} else {
+			c.stateTexts.create[created].ID = id
+		}
+		created++
+	}
+	return nil
+}
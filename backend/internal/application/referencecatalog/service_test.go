@@ -0,0 +1,177 @@
+package referencecatalog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type readerStub struct{ catalog Catalog }
+
+func (r readerStub) ReadCatalog(_ context.Context, kind Kind, _ Format, _ io.Reader) (Catalog, error) {
+	catalog := r.catalog
+	catalog.Kind = kind
+	return catalog, nil
+}
+
+type storeStub struct {
+	stateTexts          []domainFacility.StateText
+	notificationClasses []domainFacility.NotificationClass
+	created, updated    []domainFacility.StateText
+	saves               int
+}
+
+func (s *storeStub) StateTexts(context.Context) ([]domainFacility.StateText, error) {
+	return s.stateTexts, nil
+}
+
+func (s *storeStub) NotificationClasses(context.Context) ([]domainFacility.NotificationClass, error) {
+	return s.notificationClasses, nil
+}
+
+func (s *storeStub) SaveStateTexts(_ context.Context, create, update []domainFacility.StateText) error {
+	s.saves++
+	s.created, s.updated = create, update
+	return nil
+}
+
+func (s *storeStub) SaveNotificationClasses(context.Context, []domainFacility.NotificationClass, []domainFacility.NotificationClass) error {
+	s.saves++
+	return nil
+}
+
+type usageStub map[uuid.UUID]int64
+
+func (u usageStub) CountByResource(_ context.Context, resource domainFacility.BacnetReferenceResource, ids []uuid.UUID) (map[uuid.UUID]int64, error) {
+	if resource != domainFacility.BacnetReferenceResourceStateText {
+		return nil, errors.New("unexpected resource")
+	}
+	counts := make(map[uuid.UUID]int64, len(ids))
+	for _, id := range ids {
+		counts[id] = u[id]
+	}
+	return counts, nil
+}
+
+type objectsStub []domainFacility.MultiStateObject
+
+func (o objectsStub) ListMultiStateObjects(_ context.Context, after uuid.UUID, limit int) ([]domainFacility.MultiStateObject, error) {
+	if after != uuid.Nil {
+		return nil, nil
+	}
+	return o, nil
+}
+
+func stateText(ref int, version uint64, texts ...string) domainFacility.StateText {
+	var states [domainFacility.StateTextSlots]*string
+	for index := range texts {
+		states[index] = &texts[index]
+	}
+	item := domainFacility.StateText{RefNumber: ref}
+	if version > 0 {
+		item.Base = domain.Base{ID: uuid.New(), Version: version}
+	}
+	item.SetStates(states)
+	return item
+}
+
+func TestServicePreviewReportsUpsertWithUsage(t *testing.T) {
+	changed, same := stateText(10, 3, "Off", "On"), stateText(11, 1, "Closed", "Open")
+	store := &storeStub{stateTexts: []domainFacility.StateText{changed, same}}
+	service := NewService(readerStub{catalog: Catalog{StateTexts: []domainFacility.StateText{
+		stateText(10, 0, "Off", "On", "Auto"),
+		stateText(11, 0, "Closed", "Open"),
+		stateText(12, 0, "Low", "High"),
+	}}}, nil, store, usageStub{changed.ID: 7})
+
+	plan, err := service.Preview(context.Background(), Command{Kind: KindStateTexts, Format: FormatCSV})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Total != 3 || plan.Created != 1 || plan.Updated != 1 || plan.Unchanged != 1 || len(plan.Items) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	update := plan.Items[0]
+	if update.Action != ChangeUpdate || update.ID != changed.ID || update.BacnetObjectCount != 7 {
+		t.Fatalf("update = %+v", update)
+	}
+	if len(update.Diffs) != 1 || update.Diffs[0].Field != "state_text_3" || update.Diffs[0].Before != nil || update.Diffs[0].After != "Auto" {
+		t.Fatalf("diffs = %+v", update.Diffs)
+	}
+	if create := plan.Items[1]; create.Action != ChangeCreate || create.Key != 12 || create.ID != uuid.Nil {
+		t.Fatalf("create = %+v", create)
+	}
+	if store.saves != 0 {
+		t.Fatal("preview saved the catalog")
+	}
+}
+
+func TestServiceImportKeepsVersionsAndAssignsIDs(t *testing.T) {
+	current := stateText(10, 3, "Off", "On")
+	store := &storeStub{stateTexts: []domainFacility.StateText{current}}
+	service := NewService(readerStub{catalog: Catalog{StateTexts: []domainFacility.StateText{
+		stateText(10, 0, "Aus", "Ein"),
+		stateText(12, 0, "Low", "High"),
+	}}}, nil, store, nil)
+
+	plan, err := service.Import(context.Background(), Command{Kind: KindStateTexts, Format: FormatCSV})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.updated) != 1 || store.updated[0].ID != current.ID || store.updated[0].Version != 3 || *store.updated[0].StateText1 != "Aus" {
+		t.Fatalf("updated = %+v", store.updated)
+	}
+	if len(store.created) != 1 || store.created[0].ID == uuid.Nil || store.created[0].ID != plan.Items[1].ID {
+		t.Fatalf("created = %+v, plan items = %+v", store.created, plan.Items)
+	}
+}
+
+func TestServiceImportRejectsDuplicateKeys(t *testing.T) {
+	store := &storeStub{}
+	service := NewService(readerStub{catalog: Catalog{StateTexts: []domainFacility.StateText{
+		stateText(10, 0, "Off", "On"),
+		stateText(10, 0, "Aus", "Ein"),
+	}}}, nil, store, nil)
+
+	plan, err := service.Import(context.Background(), Command{Kind: KindStateTexts, Format: FormatCSV})
+
+	if !errors.Is(err, ErrInvalidCatalog) {
+		t.Fatalf("Import() error = %v, want ErrInvalidCatalog", err)
+	}
+	if len(plan.Issues) != 1 || plan.Issues[0].Code != "duplicate_key" || store.saves != 0 {
+		t.Fatalf("issues = %+v, saves = %d", plan.Issues, store.saves)
+	}
+}
+
+func TestServiceCheckStateTextCoverage(t *testing.T) {
+	twoStates, threeStates := stateText(1, 1, "Off", "On"), stateText(2, 1, "Off", "Low", "High")
+	service := NewService(nil, nil, &storeStub{stateTexts: []domainFacility.StateText{twoStates, threeStates}}, nil)
+	if _, err := service.CheckStateTextCoverage(context.Background(), 0); !errors.Is(err, ErrCoverageUnavailable) {
+		t.Fatalf("error = %v, want ErrCoverageUnavailable", err)
+	}
+	service.SetMultiStateObjects(objectsStub{
+		{ID: uuid.New(), SoftwareType: domainFacility.BacnetSoftwareTypeMV, StateTextID: &threeStates.ID, StateMaps: []string{`{"1":"Off","3":"High"}`}},
+		{ID: uuid.New(), SoftwareType: domainFacility.BacnetSoftwareTypeMI, StateTextID: &twoStates.ID, StateMaps: []string{`{"1":"Off","3":"High"}`}},
+		{ID: uuid.New(), SoftwareType: domainFacility.BacnetSoftwareTypeMV, StateTextID: &twoStates.ID},
+		{ID: uuid.New(), SoftwareType: domainFacility.BacnetSoftwareTypeMO},
+	})
+
+	coverage, err := service.CheckStateTextCoverage(context.Background(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coverage.Checked != 4 || !coverage.Truncated || len(coverage.Issues) != 1 {
+		t.Fatalf("coverage = %+v", coverage)
+	}
+	if issue := coverage.Issues[0]; issue.Code != domainFacility.StateTextCoverageTooFew || issue.RequiredStates != 3 || issue.DefinedStates != 2 || len(issue.MissingStates) != 1 || issue.MissingStates[0] != 3 {
+		t.Fatalf("issue = %+v", issue)
+	}
+}
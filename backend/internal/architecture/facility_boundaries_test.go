@@ -16,6 +16,8 @@ var cleanDomainDirectories = []string{
 	"internal/application/hierarchydelete",
 	"internal/application/hierarchyrestore",
 	"internal/application/fielddeviceimport",
+	"internal/application/projectbaseline",
+	"internal/application/referencecatalog",
 	"internal/domain/facility/fielddevice",
 	"internal/domain/facility/hierarchy",
 	"internal/domain/facility/objectdata",
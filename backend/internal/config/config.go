@@ -20,6 +20,7 @@ type Config struct {
 	HTTPAddr                      string
 	SwaggerEnabled                bool
 	JWTSecret                     string
+	WebhookSecretKey              string
 	AccessTokenTTL                time.Duration
 	RefreshTokenTTL               time.Duration
 	CookieDomain                  string
@@ -58,6 +59,7 @@ type RealtimeConfig struct {
 
 const DefaultIssuer = "go_infra_link"
 const defaultJWTSecret = "change-me"
+const defaultWebhookSecretKey = "change-me-webhook-secret"
 
 func Load() (Config, error) {
 	loadEnvFiles()
@@ -71,6 +73,7 @@ func Load() (Config, error) {
 		HTTPAddr:           resolveHTTPAddr(env),
 		SwaggerEnabled:     env.Bool("SWAGGER_ENABLED", !IsProduction(appEnv)),
 		JWTSecret:          env.String("JWT_SECRET", defaultJWTSecret),
+		WebhookSecretKey:   env.String("WEBHOOK_SECRET_KEY", defaultWebhookSecretKey),
 		AccessTokenTTL:     env.Duration("ACCESS_TOKEN_TTL", 15*time.Minute),
 		RefreshTokenTTL:    env.Duration("REFRESH_TOKEN_TTL", 720*time.Hour),
 		CookieDomain:       env.String("COOKIE_DOMAIN", ""),
@@ -235,6 +238,9 @@ func validateConfig(cfg Config) error {
 		if isInsecureJWTSecret(cfg.JWTSecret) {
 			errs = append(errs, fmt.Errorf("JWT_SECRET must be set to a strong non-default value in production"))
 		}
+		if isInsecureJWTSecret(cfg.WebhookSecretKey) || cfg.WebhookSecretKey == cfg.JWTSecret {
+			errs = append(errs, fmt.Errorf("WEBHOOK_SECRET_KEY must be a strong non-default value different from JWT_SECRET in production"))
+		}
 		if !cfg.CookieSecure {
 			errs = append(errs, fmt.Errorf("COOKIE_SECURE must be true in production because auth cookies require HTTPS"))
 		}
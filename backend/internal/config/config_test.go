@@ -26,6 +26,9 @@ func TestLoadProductionValidation(t *testing.T) {
 	if !strings.Contains(message, "JWT_SECRET must be set to a strong non-default value in production") {
 		t.Fatalf("expected JWT validation error, got %q", message)
 	}
+	if !strings.Contains(message, "WEBHOOK_SECRET_KEY must be a strong non-default value different from JWT_SECRET in production") {
+		t.Fatalf("expected webhook secret key validation error, got %q", message)
+	}
 	if !strings.Contains(message, "COOKIE_SECURE must be true in production") {
 		t.Fatalf("expected cookie secure validation error, got %q", message)
 	}
@@ -197,6 +200,7 @@ func setValidProductionEnv(t *testing.T) {
 
 	t.Setenv("APP_ENV", "production")
 	t.Setenv("JWT_SECRET", "0123456789abcdefghijklmnopqrstuvwxyz0123456789")
+	t.Setenv("WEBHOOK_SECRET_KEY", "zyxwvutsrqponmlkjihgfedcba9876543210zyxwvutsrq")
 	t.Setenv("COOKIE_SECURE", "true")
 	t.Setenv("COOKIE_SAME_SITE", "strict")
 	t.Setenv("COOKIE_DOMAIN", "")
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	facilitysql "github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"gorm.io/gorm"
+)
+
+// migrateAlarmDefinitionBindings adds the nullable alarm_definition_id
+// columns on BACnet objects and templates; existing rows keep using the
+// plain alarm type schema.
+func migrateAlarmDefinitionBindings(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&facility.AlarmDefinitionFieldOverride{},
+		&facility.BacnetObject{},
+		&facilitysql.BacnetObjectTemplateRecord{},
+	)
+}
+
+// migrateUnitDimensions adds the conversion columns on units. Existing units
+// get factor 1 and no dimension, so they keep converting only to themselves.
+func migrateUnitDimensions(db *gorm.DB) error {
+	return db.AutoMigrate(&facility.Unit{})
+}
@@ -0,0 +1,12 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"gorm.io/gorm"
+)
+
+// migrateFacilityTranslations creates the per-locale texts of state texts,
+// BACnet objects, apparats and system parts. Base texts stay on the entities.
+func migrateFacilityTranslations(db *gorm.DB) error {
+	return db.AutoMigrate(&facility.Translation{})
+}
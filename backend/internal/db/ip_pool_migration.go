@@ -0,0 +1,10 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"gorm.io/gorm"
+)
+
+func migrateIPPools(db *gorm.DB) error {
+	return db.AutoMigrate(&facility.IPPool{})
+}
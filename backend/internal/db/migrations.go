@@ -229,6 +229,84 @@ var migrations = []migration{
 		blueGreenCompatible: true,
 		apply:               migrateFieldDeviceCombinedSearchIndex,
 	},
+	{
+		version:             "202610170001",
+		description:         "sps_controller_ip_pools",
+		blueGreenCompatible: true,
+		apply:               migrateIPPools,
+	},
+	{
+		version:             "202610170002",
+		description:         "alarm_definition_bindings",
+		blueGreenCompatible: true,
+		apply:               migrateAlarmDefinitionBindings,
+	},
+	{
+		version:             "202610170003",
+		description:         "unit_dimensions",
+		blueGreenCompatible: true,
+		apply:               migrateUnitDimensions,
+	},
+	{
+		version:             "202610170004",
+		description:         "facility_translations",
+		blueGreenCompatible: true,
+		apply:               migrateFacilityTranslations,
+	},
+	{
+		version:             "202610170005",
+		description:         "user_locale",
+		blueGreenCompatible: true,
+		apply:               migrateUserLocale,
+	},
+	{
+		version:             "202610170006",
+		description:         "object_data_revisions",
+		blueGreenCompatible: true,
+		apply:               migrateObjectDataRevisions,
+	},
+	{
+		version:             "202610170007",
+		description:         "object_data_lineage",
+		blueGreenCompatible: true,
+		apply:               migrateObjectDataLineage,
+	},
+	{
+		version:             "202610170008",
+		description:         "naming_schemes",
+		blueGreenCompatible: true,
+		apply:               migrateNamingSchemes,
+	},
+	{
+		version:             "202610170009",
+		description:         "project_baselines",
+		blueGreenCompatible: true,
+		apply:               migrateProjectBaselines,
+	},
+	{
+		version:             "202610170010",
+		description:         "project_change_compaction",
+		blueGreenCompatible: true,
+		apply:               migrateProjectChangeCompaction,
+	},
+	{
+		version:             "202610170011",
+		description:         "webhooks",
+		blueGreenCompatible: true,
+		apply:               migrateWebhooks,
+	},
+	{
+		version:             "202610170012",
+		description:         "sps_controller_mac_address",
+		blueGreenCompatible: true,
+		apply:               migrateSPSControllerMACAddress,
+	},
+	{
+		version:             "202610170013",
+		description:         "object_data_revision_backfill",
+		blueGreenCompatible: true,
+		apply:               backfillObjectDataRevisions,
+	},
 }
 
 type MigrationOptions struct {
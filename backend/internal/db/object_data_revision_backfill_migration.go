@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const objectDataRevisionBackfillBatch = 500
+
+type templateSlot struct {
+	softwareType   facility.BacnetSoftwareType
+	softwareNumber uint16
+}
+
+type objectDataBaseline struct {
+	objectDataID uuid.UUID
+	revision     uint64
+	templates    map[templateSlot]uuid.UUID
+}
+
+// backfillObjectDataRevisions freezes revision 1 of every ObjectData without
+// revisions and links the field devices built before revisions existed. A
+// device is linked when exactly one ObjectData of its apparat has templates
+// in the same software slots as the device's objects; its objects get the
+// template of their slot. Other devices stay unlinked, as before. The step
+// only fills nullable columns and rows older binaries ignore.
+func backfillObjectDataRevisions(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&facilitysql.ObjectDataRevisionRecord{}) || !db.Migrator().HasTable("object_data_apparats") {
+		return nil
+	}
+	baselines, err := objectDataBaselines(db)
+	if err != nil {
+		return err
+	}
+	var links []struct {
+		ObjectDataID uuid.UUID
+		ApparatID    uuid.UUID
+	}
+	if err := db.Table("object_data_apparats").Select("object_data_id, apparat_id").Scan(&links).Error; err != nil {
+		return err
+	}
+	byApparat := make(map[uuid.UUID][]objectDataBaseline)
+	for _, link := range links {
+		if baseline, ok := baselines[link.ObjectDataID]; ok {
+			byApparat[link.ApparatID] = append(byApparat[link.ApparatID], baseline)
+		}
+	}
+	if len(byApparat) == 0 {
+		return nil
+	}
+
+	afterID := uuid.Nil
+	for {
+		var devices []struct {
+			ID        uuid.UUID
+			ApparatID uuid.UUID
+		}
+		err := db.Model(&facilitysql.FieldDeviceRecord{}).Select("id, apparat_id").
+			Where("object_data_id IS NULL AND id > ?", afterID).
+			Order("id ASC").Limit(objectDataRevisionBackfillBatch).Scan(&devices).Error
+		if err != nil || len(devices) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, len(devices))
+		for index, device := range devices {
+			ids[index] = device.ID
+		}
+		var objects []struct {
+			ID             uuid.UUID
+			FieldDeviceID  uuid.UUID
+			SoftwareType   facility.BacnetSoftwareType
+			SoftwareNumber uint16
+		}
+		if err := db.Model(&facility.BacnetObject{}).Select("id, field_device_id, software_type, software_number").
+			Where("field_device_id IN ?", ids).Scan(&objects).Error; err != nil {
+			return err
+		}
+		slots := make(map[uuid.UUID]map[templateSlot]uuid.UUID, len(devices))
+		for _, object := range objects {
+			if slots[object.FieldDeviceID] == nil {
+				slots[object.FieldDeviceID] = map[templateSlot]uuid.UUID{}
+			}
+			slots[object.FieldDeviceID][templateSlot{object.SoftwareType, object.SoftwareNumber}] = object.ID
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, device := range devices {
+				baseline, ok := matchObjectDataBaseline(byApparat[device.ApparatID], slots[device.ID])
+				if !ok {
+					continue
+				}
+				if err := linkBackfilledFieldDevice(tx, device.ID, baseline, slots[device.ID]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(devices) < objectDataRevisionBackfillBatch {
+			return nil
+		}
+		afterID = devices[len(devices)-1].ID
+	}
+}
+
+// objectDataBaselines returns the latest revision of every ObjectData and
+// freezes revision 1 where none exists yet.
+func objectDataBaselines(db *gorm.DB) (map[uuid.UUID]objectDataBaseline, error) {
+	ctx := context.Background()
+	templateStore := facilitysql.NewBacnetObjectTemplateRepository(db)
+	revisionStore := facilitysql.NewObjectDataRevisionRepository(db)
+	var objectData []struct {
+		ID         uuid.UUID
+		ObjVersion string
+	}
+	if err := db.Table("object_data").Select("id, obj_version").Order("id ASC").Scan(&objectData).Error; err != nil {
+		return nil, err
+	}
+	baselines := make(map[uuid.UUID]objectDataBaseline, len(objectData))
+	for _, item := range objectData {
+		revision, err := revisionStore.Latest(ctx, item.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			templates, listErr := templateStore.ListByObjectDataID(ctx, item.ID)
+			if listErr != nil {
+				return nil, listErr
+			}
+			revision = &domainObjectData.TemplateRevision{
+				ObjectDataID: item.ID, Revision: 1, Label: item.ObjVersion,
+				Fingerprint: domainObjectData.TemplateFingerprint(templates), Templates: templates,
+			}
+			err = revisionStore.Create(ctx, revision)
+		}
+		if err != nil {
+			return nil, err
+		}
+		baseline := objectDataBaseline{objectDataID: item.ID, revision: revision.Revision, templates: make(map[templateSlot]uuid.UUID, len(revision.Templates))}
+		for _, template := range revision.Templates {
+			baseline.templates[templateSlot{template.SoftwareType, template.SoftwareNumber}] = template.ID
+		}
+		baselines[item.ID] = baseline
+	}
+	return baselines, nil
+}
+
+// matchObjectDataBaseline returns the only candidate whose template slots are
+// exactly the device's object slots.
+func matchObjectDataBaseline(candidates []objectDataBaseline, slots map[templateSlot]uuid.UUID) (objectDataBaseline, bool) {
+	var match objectDataBaseline
+	matches := 0
+	for _, candidate := range candidates {
+		if len(slots) == 0 || len(candidate.templates) != len(slots) {
+			continue
+		}
+		same := true
+		for slot := range slots {
+			if _, ok := candidate.templates[slot]; !ok {
+				same = false
+				break
+			}
+		}
+		if same {
+			match = candidate
+			matches++
+		}
+	}
+	return match, matches == 1
+}
+
+func linkBackfilledFieldDevice(tx *gorm.DB, fieldDeviceID uuid.UUID, baseline objectDataBaseline, slots map[templateSlot]uuid.UUID) error {
+	for slot, objectID := range slots {
+		if err := tx.Model(&facility.BacnetObject{}).Where("id = ?", objectID).
+			UpdateColumn("template_id", baseline.templates[slot]).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&facilitysql.FieldDeviceRecord{}).Where("id = ?", fieldDeviceID).UpdateColumns(map[string]any{
+		"object_data_id": baseline.objectDataID, "object_data_revision": baseline.revision,
+	}).Error
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	facilitysql "github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestObjectDataRevisionBackfillFreezesRevisionOneAndLinksMatchingDevices(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := autoMigrateCurrentSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := autoMigrateTablesOnly(db, &facilitysql.ObjectDataRevisionRecord{}, &facilitysql.BacnetObjectTemplateRecord{}, &facilitysql.BacnetObjectTemplateAlarmValueRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	apparatID := uuid.New()
+	pair, single := createMigrationObjectData(t, db, "Pair"), createMigrationObjectData(t, db, "Single")
+	templates := map[uuid.UUID][]uuid.UUID{}
+	for objectDataID, numbers := range map[uuid.UUID][]uint16{pair: {1, 2}, single: {1}} {
+		if err := db.Table("object_data_apparats").Create(map[string]any{"object_data_id": objectDataID, "apparat_id": apparatID}).Error; err != nil {
+			t.Fatal(err)
+		}
+		for _, number := range numbers {
+			template := &domainObjectData.BacnetObjectTemplate{ObjectDataID: objectDataID, TextFix: "AI", SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: number}
+			if err := facilitysql.NewBacnetObjectTemplateRepository(db).Create(context.Background(), template); err != nil {
+				t.Fatal(err)
+			}
+			templates[objectDataID] = append(templates[objectDataID], template.ID)
+		}
+	}
+	matching, unmatched := createBackfillFieldDevice(t, db, apparatID, 1, 2), createBackfillFieldDevice(t, db, apparatID, 1, 3)
+
+	if err := backfillObjectDataRevisions(db); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := facilitysql.NewObjectDataRevisionRepository(db).List(context.Background(), pair)
+	if err != nil || len(revisions) != 1 || revisions[0].Revision != 1 {
+		t.Fatalf("revisions = %+v, %v; want revision 1", revisions, err)
+	}
+	var device facilitysql.FieldDeviceRecord
+	if err := db.First(&device, "id = ?", matching).Error; err != nil {
+		t.Fatal(err)
+	}
+	if device.ObjectDataID == nil || *device.ObjectDataID != pair || device.ObjectDataRevision == nil || *device.ObjectDataRevision != 1 {
+		t.Fatalf("device = %+v, want revision 1 of the pair", device)
+	}
+	var linked []domainFacility.BacnetObject
+	if err := db.Where("field_device_id = ?", matching).Order("software_number").Find(&linked).Error; err != nil {
+		t.Fatal(err)
+	}
+	for index, object := range linked {
+		if object.TemplateID == nil || *object.TemplateID != templates[pair][index] {
+			t.Fatalf("object %d template = %v, want %s", object.SoftwareNumber, object.TemplateID, templates[pair][index])
+		}
+	}
+	var other facilitysql.FieldDeviceRecord
+	if err := db.First(&other, "id = ?", unmatched).Error; err != nil {
+		t.Fatal(err)
+	}
+	if other.ObjectDataID != nil {
+		t.Fatalf("device = %+v, want the device without matching templates unlinked", other)
+	}
+}
+
+func createBackfillFieldDevice(t *testing.T, db *gorm.DB, apparatID uuid.UUID, numbers ...uint16) uuid.UUID {
+	t.Helper()
+	now := time.Now().UTC()
+	device := facilitysql.FieldDeviceRecord{
+		Base:      domain.Base{ID: uuid.New(), CreatedAt: now, UpdatedAt: now, Version: 1},
+		ApparatID: apparatID, SystemPartID: uuid.New(), SPSControllerSystemTypeID: uuid.New(),
+	}
+	if err := db.Omit("SPSControllerSystemType", "SystemPart", "Apparat", "BacnetObjects").Create(&device).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, number := range numbers {
+		object := domainFacility.BacnetObject{
+			Base:    domain.Base{ID: uuid.New(), CreatedAt: now, UpdatedAt: now, Version: 1},
+			TextFix: "AI", SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: number, FieldDeviceID: &device.ID,
+		}
+		if err := db.Omit("AlarmValues").Create(&object).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return device.ID
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"gorm.io/gorm"
+)
+
+// migrateObjectDataRevisions creates the frozen template revisions and the
+// nullable links from field devices and BACnet objects back to them. Older
+// binaries ignore the new columns, so the step is blue-green compatible.
+func migrateObjectDataRevisions(db *gorm.DB) error {
+	return autoMigrateTablesOnly(db,
+		&facilitysql.ObjectDataRevisionRecord{},
+		&facilitysql.FieldDeviceRecord{},
+		&facility.BacnetObject{},
+	)
+}
+
+// migrateObjectDataLineage adds the nullable fork lineage of project
+// templates.
+func migrateObjectDataLineage(db *gorm.DB) error {
+	return autoMigrateTablesOnly(db, &facility.ObjectData{})
+}
@@ -0,0 +1,8 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/repository/baselinesql"
+	"gorm.io/gorm"
+)
+
+func migrateProjectBaselines(db *gorm.DB) error { return baselinesql.Migrate(db) }
@@ -0,0 +1,22 @@
+package db
+
+import (
+	projectchange "github.com/besart951/go_infra_link/backend/internal/repository/projectchange"
+	"gorm.io/gorm"
+)
+
+// migrateProjectChangeCompaction adds per-project retention to the change
+// feed. Events that earlier releases deleted cannot be compacted, so cursors
+// behind the oldest remaining event still need a reset.
+func migrateProjectChangeCompaction(db *gorm.DB) error {
+	if err := projectchange.AutoMigrate(db); err != nil {
+		return err
+	}
+	return db.Exec(`
+		UPDATE project_change_cursors
+		SET pruned_through = COALESCE(
+			(SELECT MIN(revision) - 1 FROM project_changes WHERE project_changes.project_id = project_change_cursors.project_id),
+			current_revision
+		)
+	`).Error
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/besart951/go_infra_link/backend/internal/domain/project"
 	"github.com/besart951/go_infra_link/backend/internal/domain/team"
 	"github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/internal/domain/webhook"
 	facilityrepo "github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
 	projectrepo "github.com/besart951/go_infra_link/backend/internal/repository/project"
 	projectsql "github.com/besart951/go_infra_link/backend/internal/repository/projectsql"
@@ -42,6 +43,8 @@ func currentSchemaModels() []any {
 		&notification.SystemNotification{},
 		&notification.EmailOutbox{},
 		&notification.NotificationRule{},
+		&webhook.Subscription{},
+		&webhook.Delivery{},
 
 		&team.Team{},
 		&team.TeamMember{},
@@ -52,6 +55,8 @@ func currentSchemaModels() []any {
 		&facility.Building{},
 		&facility.ControlCabinet{},
 		&facility.SPSController{},
+		&facility.IPPool{},
+		&facility.NamingScheme{},
 		&facility.SystemType{},
 		&facility.SPSControllerSystemType{},
 		&facility.SystemPart{},
@@ -68,6 +73,7 @@ func currentSchemaModels() []any {
 		&facility.AlarmTypeField{},
 		&facility.AlarmDefinitionFieldOverride{},
 		&facility.BacnetObjectAlarmValue{},
+		&facility.Translation{},
 		&history.ChangeEvent{},
 		&history.ChangeEventScope{},
 		&history.EntityVersion{},
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"gorm.io/gorm"
+)
+
+// migrateSPSControllerMACAddress adds the nullable hardware address network
+// exports use for DHCP reservations. Older binaries ignore the column, so the
+// step is blue-green compatible.
+func migrateSPSControllerMACAddress(db *gorm.DB) error {
+	if db.Migrator().HasColumn(&facility.SPSController{}, "MACAddress") {
+		return nil
+	}
+	return db.Migrator().AddColumn(&facility.SPSController{}, "MACAddress")
+}
@@ -0,0 +1,15 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// migrateUserLocale adds the nullable preferred locale of a user. Older
+// binaries ignore the column, so the step is blue-green compatible.
+func migrateUserLocale(db *gorm.DB) error {
+	if db.Migrator().HasColumn(&user.User{}, "Locale") {
+		return nil
+	}
+	return db.Migrator().AddColumn(&user.User{}, "Locale")
+}
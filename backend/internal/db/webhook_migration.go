@@ -0,0 +1,27 @@
+package db
+
+import (
+	"github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"gorm.io/gorm"
+)
+
+func migrateWebhooks(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&webhook.Subscription{}, &webhook.Delivery{}); err != nil {
+			return err
+		}
+		for _, definition := range user.CanonicalPermissionDefinitions() {
+			if definition.Name != user.PermissionWebhookManage {
+				continue
+			}
+			if err := ensureProjectPermissionDefinition(tx, projectPermissionDefinitionFromDomain(definition)); err != nil {
+				return err
+			}
+			if err := ensureProjectRolePermission(tx, user.RoleSuperAdmin, definition.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package exporting
+
+import (
+	"time"
+
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/google/uuid"
+)
+
+// Change reports render the history timeline as a change list for delivery.
+const (
+	OutputTypeChangeReportExcel OutputType = "change_report_excel"
+	OutputTypeChangeReportPDF   OutputType = "change_report_pdf"
+)
+
+// IsChangeReport reports whether the output type is a change report.
+func (o OutputType) IsChangeReport() bool {
+	return o == OutputTypeChangeReportExcel || o == OutputTypeChangeReportPDF
+}
+
+// ChangeReportRequest selects the change events of a change report.
+// ProjectIDs and AccessScope share their names with Request so the download
+// policy reads the scope of both job payloads alike.
+type ChangeReportRequest struct {
+	ProjectIDs  []uuid.UUID
+	AccessScope AccessScope
+	OutputType  OutputType
+	Locale      string
+	Filter      domainHistory.TimelineFilter
+}
+
+// ChangeReport is the localized change list. Groups are ordered by building,
+// control cabinet and controller; entries within a group by time.
+type ChangeReport struct {
+	Title        string
+	Locale       string
+	GeneratedAt  time.Time
+	OccurredFrom *time.Time
+	OccurredTo   *time.Time
+	Headings     ChangeReportHeadings
+	Groups       []ChangeReportGroup
+	EventCount   int
+	Truncated    bool
+}
+
+// ChangeReportHeadings holds the localized column and caption texts.
+type ChangeReportHeadings struct {
+	Period         string
+	GeneratedAt    string
+	Building       string
+	ControlCabinet string
+	SPSController  string
+	OccurredAt     string
+	Actor          string
+	Action         string
+	Entity         string
+	Field          string
+	Before         string
+	After          string
+	Unassigned     string
+	Truncated      string
+}
+
+type ChangeReportGroup struct {
+	Building       string
+	ControlCabinet string
+	SPSController  string
+	Entries        []ChangeReportEntry
+}
+
+type ChangeReportEntry struct {
+	OccurredAt time.Time
+	Actor      string
+	Action     string
+	Entity     string
+	Summary    string
+	Fields     []ChangeReportField
+}
+
+// ChangeReportField is one changed field with its formatted values.
+type ChangeReportField struct {
+	Name   string
+	Label  string
+	Before string
+	After  string
+}
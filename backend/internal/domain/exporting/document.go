@@ -0,0 +1,268 @@
+package exporting
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// DocumentFormat identifies JSON and NDJSON facility exports. DocumentVersion
+// changes whenever a record layout changes incompatibly.
+const (
+	DocumentFormat  = "go_infra_link.facility"
+	DocumentVersion = 1
+)
+
+type DocumentRecordType string
+
+const (
+	DocumentRecordManifest          DocumentRecordType = "manifest"
+	DocumentRecordBuilding          DocumentRecordType = "building"
+	DocumentRecordControlCabinet    DocumentRecordType = "control_cabinet"
+	DocumentRecordSPSController     DocumentRecordType = "sps_controller"
+	DocumentRecordSystemType        DocumentRecordType = "sps_controller_system_type"
+	DocumentRecordFieldDevice       DocumentRecordType = "field_device"
+	DocumentRecordSpecification     DocumentRecordType = "specification"
+	DocumentRecordBacnetObject      DocumentRecordType = "bacnet_object"
+	DocumentRecordSoftwareReference DocumentRecordType = "software_reference"
+	DocumentRecordAlarmValue        DocumentRecordType = "alarm_value"
+)
+
+// DocumentRecord is one NDJSON line or one element of the "records" array of
+// a JSON document. NDJSON files start with the manifest record; JSON
+// documents carry it in their top-level "manifest" member instead.
+type DocumentRecord struct {
+	Type DocumentRecordType `json:"type"`
+	Data json.RawMessage    `json:"data"`
+}
+
+type DocumentManifest struct {
+	Format            string            `json:"format"`
+	FormatVersion     int               `json:"format_version"`
+	SchemaVersion     int               `json:"schema_version"`
+	SnapshotAt        time.Time         `json:"snapshot_at"`
+	Scope             AccessScope       `json:"scope"`
+	DeviceCount       int64             `json:"device_count"`
+	Counts            DocumentCounts    `json:"counts"`
+	Filters           DocumentFilters   `json:"filters"`
+	Warnings          []string          `json:"warnings"`
+	SnapshotChecksums map[string]string `json:"snapshot_checksums"`
+}
+
+type DocumentCounts struct {
+	Buildings          int64 `json:"buildings"`
+	ControlCabinets    int64 `json:"control_cabinets"`
+	SPSControllers     int64 `json:"sps_controllers"`
+	SystemTypes        int64 `json:"sps_controller_system_types"`
+	FieldDevices       int64 `json:"field_devices"`
+	Specifications     int64 `json:"specifications"`
+	BacnetObjects      int64 `json:"bacnet_objects"`
+	SoftwareReferences int64 `json:"software_references"`
+	AlarmValues        int64 `json:"alarm_values"`
+}
+
+type DocumentFilters struct {
+	ProjectIDs                 []uuid.UUID `json:"project_ids"`
+	BuildingIDs                []uuid.UUID `json:"building_ids"`
+	ControlCabinetIDs          []uuid.UUID `json:"control_cabinet_ids"`
+	SPSControllerIDs           []uuid.UUID `json:"sps_controller_ids"`
+	SPSControllerSystemTypeIDs []uuid.UUID `json:"sps_controller_system_type_ids"`
+	Search                     string      `json:"search"`
+}
+
+type DocumentBuilding struct {
+	ID            uuid.UUID `json:"id"`
+	IWSCode       string    `json:"iws_code"`
+	BuildingGroup int       `json:"building_group"`
+}
+
+type DocumentControlCabinet struct {
+	ID               uuid.UUID `json:"id"`
+	BuildingID       uuid.UUID `json:"building_id"`
+	ControlCabinetNr string    `json:"control_cabinet_nr"`
+}
+
+type DocumentSPSController struct {
+	ID                uuid.UUID `json:"id"`
+	ControlCabinetID  uuid.UUID `json:"control_cabinet_id"`
+	GADevice          string    `json:"ga_device"`
+	DeviceName        string    `json:"device_name"`
+	DeviceInstance    string    `json:"device_instance"`
+	DeviceDescription string    `json:"device_description"`
+	DeviceLocation    string    `json:"device_location"`
+	IPAddress         string    `json:"ip_address"`
+	Subnet            string    `json:"subnet"`
+	Gateway           string    `json:"gateway"`
+	VLAN              string    `json:"vlan"`
+}
+
+type DocumentSystemType struct {
+	ID              uuid.UUID `json:"id"`
+	SPSControllerID uuid.UUID `json:"sps_controller_id"`
+	SystemTypeID    uuid.UUID `json:"system_type_id"`
+	Number          *int      `json:"number"`
+	DocumentName    *string   `json:"document_name"`
+}
+
+type DocumentFieldDevice struct {
+	ID                        uuid.UUID `json:"id"`
+	Version                   uint64    `json:"version"`
+	CreatedAt                 time.Time `json:"created_at"`
+	UpdatedAt                 time.Time `json:"updated_at"`
+	SPSControllerID           uuid.UUID `json:"sps_controller_id"`
+	SPSControllerSystemTypeID uuid.UUID `json:"sps_controller_system_type_id"`
+	SystemPartID              uuid.UUID `json:"system_part_id"`
+	ApparatID                 uuid.UUID `json:"apparat_id"`
+	ApparatNr                 int       `json:"apparat_nr"`
+	BMK                       *string   `json:"bmk"`
+	Description               *string   `json:"description"`
+	TextIndividual            *string   `json:"text_individual"`
+}
+
+type DocumentSpecification struct {
+	ID                   uuid.UUID `json:"id"`
+	FieldDeviceID        uuid.UUID `json:"field_device_id"`
+	Version              uint64    `json:"version"`
+	Supplier             *string   `json:"supplier"`
+	Brand                *string   `json:"brand"`
+	Type                 *string   `json:"type"`
+	MotorValve           *string   `json:"motor_valve"`
+	Size                 *int      `json:"size"`
+	InstallationLocation *string   `json:"installation_location"`
+	PH                   *int      `json:"ph"`
+	ACDC                 *string   `json:"acdc"`
+	Amperage             *float64  `json:"amperage"`
+	Power                *float64  `json:"power"`
+	Rotation             *int      `json:"rotation"`
+}
+
+type DocumentBacnetObject struct {
+	ID                  uuid.UUID                         `json:"id"`
+	FieldDeviceID       uuid.UUID                         `json:"field_device_id"`
+	Version             uint64                            `json:"version"`
+	TextFix             string                            `json:"text_fix"`
+	Description         *string                           `json:"description"`
+	GMSVisible          bool                              `json:"gms_visible"`
+	Optional            bool                              `json:"optional"`
+	TextIndividual      *string                           `json:"text_individual"`
+	SoftwareType        domainFacility.BacnetSoftwareType `json:"software_type"`
+	SoftwareNumber      uint16                            `json:"software_number"`
+	HardwareType        domainFacility.BacnetHardwareType `json:"hardware_type"`
+	HardwareQuantity    uint8                             `json:"hardware_quantity"`
+	SoftwareReferenceID *uuid.UUID                        `json:"software_reference_id"`
+	StateTextID         *uuid.UUID                        `json:"state_text_id"`
+	NotificationClassID *uuid.UUID                        `json:"notification_class_id"`
+	AlarmTypeID         *uuid.UUID                        `json:"alarm_type_id"`
+	AlarmDefinitionID   *uuid.UUID                        `json:"alarm_definition_id"`
+}
+
+type DocumentSoftwareReference struct {
+	SourceObjectID uuid.UUID `json:"source_object_id"`
+	TargetObjectID uuid.UUID `json:"target_object_id"`
+	FieldDeviceID  uuid.UUID `json:"field_device_id"`
+}
+
+type DocumentAlarmValue struct {
+	ID               uuid.UUID  `json:"id"`
+	BacnetObjectID   uuid.UUID  `json:"bacnet_object_id"`
+	Version          uint64     `json:"version"`
+	AlarmTypeFieldID uuid.UUID  `json:"alarm_type_field_id"`
+	ValueNumber      *float64   `json:"value_number"`
+	ValueInteger     *int64     `json:"value_integer"`
+	ValueBoolean     *bool      `json:"value_boolean"`
+	ValueString      *string    `json:"value_string"`
+	ValueJSON        *string    `json:"value_json"`
+	UnitID           *uuid.UUID `json:"unit_id"`
+	Source           string     `json:"source"`
+}
+
+func NewDocumentFieldDevice(controllerID uuid.UUID, device domainFacility.FieldDevice) DocumentFieldDevice {
+	return DocumentFieldDevice{
+		ID: device.ID, Version: device.Version, CreatedAt: device.CreatedAt.UTC(), UpdatedAt: device.UpdatedAt.UTC(),
+		SPSControllerID: controllerID, SPSControllerSystemTypeID: device.SPSControllerSystemTypeID,
+		SystemPartID: device.SystemPartID, ApparatID: device.ApparatID, ApparatNr: device.ApparatNr,
+		BMK: device.BMK, Description: device.Description, TextIndividual: device.TextIndividuell,
+	}
+}
+
+func (d DocumentFieldDevice) FieldDevice() domainFacility.FieldDevice {
+	return domainFacility.FieldDevice{
+		Base:                      domain.Base{ID: d.ID, Version: d.Version, CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt},
+		SPSControllerSystemTypeID: d.SPSControllerSystemTypeID, SystemPartID: d.SystemPartID,
+		ApparatID: d.ApparatID, ApparatNr: d.ApparatNr,
+		BMK: d.BMK, Description: d.Description, TextIndividuell: d.TextIndividual,
+	}
+}
+
+func NewDocumentSpecification(fieldDeviceID uuid.UUID, spec domainFacility.Specification) DocumentSpecification {
+	return DocumentSpecification{
+		ID: spec.ID, FieldDeviceID: fieldDeviceID, Version: spec.Version,
+		Supplier: spec.SpecificationSupplier, Brand: spec.SpecificationBrand, Type: spec.SpecificationType,
+		MotorValve: spec.AdditionalInfoMotorValve, Size: spec.AdditionalInfoSize,
+		InstallationLocation: spec.AdditionalInformationInstallationLocation,
+		PH:                   spec.ElectricalConnectionPH, ACDC: spec.ElectricalConnectionACDC,
+		Amperage: spec.ElectricalConnectionAmperage, Power: spec.ElectricalConnectionPower,
+		Rotation: spec.ElectricalConnectionRotation,
+	}
+}
+
+func (d DocumentSpecification) Specification() domainFacility.Specification {
+	fieldDeviceID := d.FieldDeviceID
+	return domainFacility.Specification{
+		Base: domain.Base{ID: d.ID, Version: d.Version}, FieldDeviceID: &fieldDeviceID,
+		SpecificationSupplier: d.Supplier, SpecificationBrand: d.Brand, SpecificationType: d.Type,
+		AdditionalInfoMotorValve: d.MotorValve, AdditionalInfoSize: d.Size,
+		AdditionalInformationInstallationLocation: d.InstallationLocation,
+		ElectricalConnectionPH:                    d.PH, ElectricalConnectionACDC: d.ACDC,
+		ElectricalConnectionAmperage: d.Amperage, ElectricalConnectionPower: d.Power,
+		ElectricalConnectionRotation: d.Rotation,
+	}
+}
+
+func NewDocumentBacnetObject(fieldDeviceID uuid.UUID, object domainFacility.BacnetObject) DocumentBacnetObject {
+	return DocumentBacnetObject{
+		ID: object.ID, FieldDeviceID: fieldDeviceID, Version: object.Version,
+		TextFix: object.TextFix, Description: object.Description, GMSVisible: object.GMSVisible,
+		Optional: object.Optional, TextIndividual: object.TextIndividual,
+		SoftwareType: object.SoftwareType, SoftwareNumber: object.SoftwareNumber,
+		HardwareType: object.HardwareType, HardwareQuantity: object.HardwareQuantity,
+		SoftwareReferenceID: object.SoftwareReferenceID, StateTextID: object.StateTextID,
+		NotificationClassID: object.NotificationClassID, AlarmTypeID: object.AlarmTypeID,
+		AlarmDefinitionID: object.AlarmDefinitionID,
+	}
+}
+
+func (d DocumentBacnetObject) BacnetObject() domainFacility.BacnetObject {
+	fieldDeviceID := d.FieldDeviceID
+	return domainFacility.BacnetObject{
+		Base: domain.Base{ID: d.ID, Version: d.Version}, FieldDeviceID: &fieldDeviceID,
+		TextFix: d.TextFix, Description: d.Description, GMSVisible: d.GMSVisible,
+		Optional: d.Optional, TextIndividual: d.TextIndividual,
+		SoftwareType: d.SoftwareType, SoftwareNumber: d.SoftwareNumber,
+		HardwareType: d.HardwareType, HardwareQuantity: d.HardwareQuantity,
+		SoftwareReferenceID: d.SoftwareReferenceID, StateTextID: d.StateTextID,
+		NotificationClassID: d.NotificationClassID, AlarmTypeID: d.AlarmTypeID,
+		AlarmDefinitionID: d.AlarmDefinitionID,
+	}
+}
+
+func NewDocumentAlarmValue(bacnetObjectID uuid.UUID, value domainFacility.BacnetObjectAlarmValue) DocumentAlarmValue {
+	return DocumentAlarmValue{
+		ID: value.ID, BacnetObjectID: bacnetObjectID, Version: value.Version,
+		AlarmTypeFieldID: value.AlarmTypeFieldID, ValueNumber: value.ValueNumber,
+		ValueInteger: value.ValueInteger, ValueBoolean: value.ValueBoolean,
+		ValueString: value.ValueString, ValueJSON: value.ValueJSON, UnitID: value.UnitID, Source: value.Source,
+	}
+}
+
+func (d DocumentAlarmValue) AlarmValue() domainFacility.BacnetObjectAlarmValue {
+	return domainFacility.BacnetObjectAlarmValue{
+		Base: domain.Base{ID: d.ID, Version: d.Version}, BacnetObjectID: d.BacnetObjectID,
+		AlarmTypeFieldID: d.AlarmTypeFieldID, ValueNumber: d.ValueNumber,
+		ValueInteger: d.ValueInteger, ValueBoolean: d.ValueBoolean,
+		ValueString: d.ValueString, ValueJSON: d.ValueJSON, UnitID: d.UnitID, Source: d.Source,
+	}
+}
@@ -2,8 +2,10 @@ package exporting
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	domainuser "github.com/besart951/go_infra_link/backend/internal/domain/user"
 	"github.com/google/uuid"
 )
@@ -24,13 +26,51 @@ type Request struct {
 	Search                     string
 	ExportAll                  bool
 	ForceAsync                 bool
+	OutputType                 OutputType
 	SnapshotAt                 time.Time
 	SchemaVersion              int
 	DeviceCount                int64
 	AccessScope                AccessScope
+	CollisionPolicy            CollisionPolicy
 	Manifest                   Manifest
+
+	// Locale selects the translations used for the human-readable point
+	// list, e.g. "fr_CH". Empty keeps the base texts.
+	Locale string
+
+	// ObjectNaming is the object name pattern of the naming scheme resolved
+	// for the export scope. Empty keeps the built-in names.
+	ObjectNaming domainFacility.NamingPattern
+}
+
+// ObjectNamingPattern returns the pattern BACnet object names are rendered
+// with.
+func (r Request) ObjectNamingPattern() domainFacility.NamingPattern {
+	if r.ObjectNaming.Pattern == "" {
+		return domainFacility.DefaultNamingScheme().ObjectName
+	}
+	return r.ObjectNaming
+}
+
+// ParseLocale normalizes a requested export locale. An empty value is valid
+// and means the base texts.
+func ParseLocale(value string) (string, bool) {
+	if value == "" {
+		return "", true
+	}
+	return domainFacility.NormalizeLocale(value)
 }
 
+// CollisionPolicy decides what an export job does when the BACnet instance
+// analysis finds collisions. The zero value behaves like CollisionPolicyWarn.
+type CollisionPolicy string
+
+const (
+	CollisionPolicyWarn   CollisionPolicy = "warn"
+	CollisionPolicyRefuse CollisionPolicy = "refuse"
+	CollisionPolicyIgnore CollisionPolicy = "ignore"
+)
+
 type Manifest struct {
 	Counts            Counts
 	Warnings          []string
@@ -68,6 +108,15 @@ type OutputType string
 const (
 	OutputTypeExcel OutputType = "excel"
 	OutputTypeZip   OutputType = "zip"
+	// OutputTypeEDE is a ZIP of BACnet EDE point lists, one set per controller.
+	OutputTypeEDE OutputType = "ede"
+	// OutputTypeNetwork is a ZIP of controller addressing as CSV, JSON and
+	// ISC-DHCP/Kea reservation snippets; it does not read field devices.
+	OutputTypeNetwork OutputType = "network"
+	// OutputTypeJSON and OutputTypeNDJSON carry the facility hierarchy from
+	// buildings down to alarm values as versioned documents for integrations.
+	OutputTypeJSON   OutputType = "json"
+	OutputTypeNDJSON OutputType = "ndjson"
 )
 
 type Job struct {
@@ -103,6 +152,7 @@ type DownloadAuthorizer interface {
 type Controller struct {
 	ID               uuid.UUID
 	ControlCabinetID uuid.UUID
+	BuildingID       uuid.UUID
 	GADevice         string
 
 	IWSCode             string
@@ -117,4 +167,59 @@ type Controller struct {
 	Subnet              string
 	Gateway             string
 	VLAN                string
+	MACAddress          string // EUI-48 in lower-case colon form; empty when unknown
+	SystemTypes         []ControllerSystemType
+}
+
+// ControllerSystemType is one system type assigned to a controller.
+type ControllerSystemType struct {
+	ID           uuid.UUID
+	SystemTypeID uuid.UUID
+	Number       *int
+	DocumentName *string
+}
+
+// NamingValues returns the controller tokens of naming scheme patterns.
+func (c Controller) NamingValues() domainFacility.NamingValues {
+	return domainFacility.NamingValues{
+		domainFacility.NamingTokenIWSCode:          c.IWSCode,
+		domainFacility.NamingTokenBuildingGroup:    fmt.Sprintf("%d", c.BuildingGroup),
+		domainFacility.NamingTokenControlCabinetNr: c.ControlCabinetNr,
+		domainFacility.NamingTokenGADevice:         c.GADevice,
+	}
+}
+
+type CollisionKind string
+
+const (
+	CollisionDuplicateDeviceInstance  CollisionKind = "duplicate_device_instance"
+	CollisionDuplicateObjectInstance  CollisionKind = "duplicate_object_instance"
+	CollisionDeviceInstanceOutOfRange CollisionKind = "device_instance_out_of_range"
+)
+
+// Collision is one BACnet addressing conflict. Device collisions list every
+// controller sharing the instance; object collisions stay within one
+// controller and list the objects sharing type and instance.
+type Collision struct {
+	Kind           CollisionKind
+	DeviceInstance string
+	ControllerIDs  []uuid.UUID
+	ObjectType     domainFacility.BacnetSoftwareType
+	ObjectInstance uint16
+	ObjectIDs      []uuid.UUID
+	Message        string
+}
+
+type CollisionReport struct {
+	Controllers int
+	Objects     int64
+	Collisions  []Collision
+}
+
+func (r CollisionReport) Warnings() []string {
+	warnings := make([]string, len(r.Collisions))
+	for index, collision := range r.Collisions {
+		warnings[index] = collision.Message
+	}
+	return warnings
 }
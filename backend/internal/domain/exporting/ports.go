@@ -4,6 +4,7 @@ import (
 	"context"
 
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
 	"github.com/google/uuid"
 )
 
@@ -25,6 +26,24 @@ type ZipGenerator interface {
 	GenerateZipByCabinet(ctx context.Context, outputPath string, controllers []Controller, source DataProvider, req Request, pageSize int) (int64, error)
 }
 
+// EDEGenerator writes Engineering Data Exchange CSV files (object list plus
+// the companion state-text and unit files) for every controller into one ZIP.
+type EDEGenerator interface {
+	GenerateEDEArchive(ctx context.Context, outputPath string, controllers []Controller, source DataProvider, req Request, pageSize int) (int64, error)
+}
+
+// NetworkConfigGenerator writes the addressing of every controller in scope
+// for network tooling (CSV, JSON, ISC-DHCP and Kea) into one ZIP.
+type NetworkConfigGenerator interface {
+	GenerateNetworkConfig(ctx context.Context, outputPath string, controllers []Controller) (int64, error)
+}
+
+// DocumentGenerator writes the facility hierarchy in scope as one JSON
+// document or as NDJSON records, depending on req.OutputType.
+type DocumentGenerator interface {
+	GenerateDocument(ctx context.Context, outputPath string, controllers []Controller, source DataProvider, req Request, pageSize int) (int64, error)
+}
+
 type FileStore interface {
 	BuildOutputPath(jobID uuid.UUID, outputType OutputType, downloadFileName string) (string, string)
 	BuildStagingPath(jobID uuid.UUID, outputType OutputType) string
@@ -32,3 +51,28 @@ type FileStore interface {
 	Remove(path string) error
 	SnapshotDirectory(jobID uuid.UUID) string
 }
+
+// ChangeEventSource pages through the history timeline of a change report.
+type ChangeEventSource interface {
+	ListTimelineCursor(ctx context.Context, filter domainHistory.TimelineFilter) (*domainHistory.TimelineCursorPage, error)
+}
+
+// ChangeReportGenerator writes a change report as a workbook or PDF,
+// depending on outputType, and returns the number of events written.
+type ChangeReportGenerator interface {
+	GenerateChangeReport(ctx context.Context, outputPath string, report ChangeReport, outputType OutputType) (int64, error)
+}
+
+// ChangeReportPDFChecker is an optional capability of change report
+// generators whose PDF fonts cover a limited character set. Reports it
+// cannot render are written as workbooks instead.
+type ChangeReportPDFChecker interface {
+	CanRenderPDF(report ChangeReport) bool
+}
+
+// LabelCatalog resolves report texts from the translation catalogs. Lookup
+// falls back to the default locale and reports false when no catalog has
+// the key.
+type LabelCatalog interface {
+	Lookup(locale, key string) (string, bool)
+}
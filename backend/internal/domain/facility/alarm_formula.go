@@ -0,0 +1,347 @@
+package facility
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidAlarmFormula  = errors.New("invalid alarm formula")
+	ErrAlarmFormulaVariable = errors.New("alarm formula references a value that is not set")
+)
+
+// AlarmFormula is a parsed arithmetic expression over alarm field keys, e.g.
+// "setpoint + 2" or "max(low_limit, setpoint * 0.9)". It supports + - * /,
+// parentheses, numeric literals and the functions min, max, abs and round.
+type AlarmFormula struct {
+	source string
+	root   alarmFormulaNode
+}
+
+// ParseAlarmAssignment splits "high_limit = setpoint + 2" into the target
+// field key and its parsed formula.
+func ParseAlarmAssignment(statement string) (string, AlarmFormula, error) {
+	key, expression, ok := strings.Cut(statement, "=")
+	key = strings.TrimSpace(key)
+	if !ok || !isAlarmFormulaIdentifier(key) {
+		return "", AlarmFormula{}, fmt.Errorf("%w: expected \"field = expression\"", ErrInvalidAlarmFormula)
+	}
+	formula, err := ParseAlarmFormula(expression)
+	return key, formula, err
+}
+
+// ParseAlarmFormula parses an arithmetic expression.
+func ParseAlarmFormula(expression string) (AlarmFormula, error) {
+	parser := alarmFormulaParser{input: expression}
+	if err := parser.tokenize(); err != nil {
+		return AlarmFormula{}, err
+	}
+	root, err := parser.expression()
+	if err != nil {
+		return AlarmFormula{}, err
+	}
+	if parser.position < len(parser.tokens) {
+		return AlarmFormula{}, fmt.Errorf("%w: unexpected %q", ErrInvalidAlarmFormula, parser.tokens[parser.position].text)
+	}
+	return AlarmFormula{source: strings.TrimSpace(expression), root: root}, nil
+}
+
+func (f AlarmFormula) String() string {
+	return f.source
+}
+
+// Variables returns the field keys the formula reads, in order of first use.
+func (f AlarmFormula) Variables() []string {
+	seen := make(map[string]struct{})
+	var out []string
+	var walk func(alarmFormulaNode)
+	walk = func(node alarmFormulaNode) {
+		switch typed := node.(type) {
+		case alarmFormulaVariable:
+			if _, ok := seen[string(typed)]; !ok {
+				seen[string(typed)] = struct{}{}
+				out = append(out, string(typed))
+			}
+		case alarmFormulaUnary:
+			walk(typed.operand)
+		case alarmFormulaBinary:
+			walk(typed.left)
+			walk(typed.right)
+		case alarmFormulaCall:
+			for _, arg := range typed.args {
+				walk(arg)
+			}
+		}
+	}
+	if f.root != nil {
+		walk(f.root)
+	}
+	return out
+}
+
+// Evaluate computes the formula with the given field values.
+func (f AlarmFormula) Evaluate(values map[string]float64) (float64, error) {
+	if f.root == nil {
+		return 0, ErrInvalidAlarmFormula
+	}
+	result, err := f.root.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("%w: result is not a finite number", ErrInvalidAlarmFormula)
+	}
+	return result, nil
+}
+
+type alarmFormulaNode interface {
+	evaluate(values map[string]float64) (float64, error)
+}
+
+type alarmFormulaNumber float64
+
+func (n alarmFormulaNumber) evaluate(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type alarmFormulaVariable string
+
+func (v alarmFormulaVariable) evaluate(values map[string]float64) (float64, error) {
+	value, ok := values[string(v)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrAlarmFormulaVariable, string(v))
+	}
+	return value, nil
+}
+
+type alarmFormulaUnary struct {
+	operator byte
+	operand  alarmFormulaNode
+}
+
+func (u alarmFormulaUnary) evaluate(values map[string]float64) (float64, error) {
+	value, err := u.operand.evaluate(values)
+	if u.operator == '-' {
+		value = -value
+	}
+	return value, err
+}
+
+type alarmFormulaBinary struct {
+	operator    byte
+	left, right alarmFormulaNode
+}
+
+func (b alarmFormulaBinary) evaluate(values map[string]float64) (float64, error) {
+	left, err := b.left.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	right, err := b.right.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	switch b.operator {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, fmt.Errorf("%w: division by zero", ErrInvalidAlarmFormula)
+		}
+		return left / right, nil
+	}
+}
+
+type alarmFormulaCall struct {
+	name string
+	args []alarmFormulaNode
+}
+
+var alarmFormulaArity = map[string][2]int{"min": {2, -1}, "max": {2, -1}, "abs": {1, 1}, "round": {1, 2}}
+
+func (c alarmFormulaCall) evaluate(values map[string]float64) (float64, error) {
+	args := make([]float64, len(c.args))
+	for index, arg := range c.args {
+		value, err := arg.evaluate(values)
+		if err != nil {
+			return 0, err
+		}
+		args[index] = value
+	}
+	switch c.name {
+	case "min", "max":
+		result := args[0]
+		for _, value := range args[1:] {
+			if (c.name == "min" && value < result) || (c.name == "max" && value > result) {
+				result = value
+			}
+		}
+		return result, nil
+	case "abs":
+		return math.Abs(args[0]), nil
+	default:
+		scale := 1.0
+		if len(args) == 2 {
+			scale = math.Pow(10, math.Round(args[1]))
+		}
+		return math.Round(args[0]*scale) / scale, nil
+	}
+}
+
+type alarmFormulaToken struct {
+	kind byte // 'n' number, 'i' identifier, or the operator/punctuation itself
+	text string
+}
+
+type alarmFormulaParser struct {
+	input    string
+	tokens   []alarmFormulaToken
+	position int
+}
+
+func (p *alarmFormulaParser) tokenize() error {
+	runes := []rune(p.input)
+	for index := 0; index < len(runes); {
+		r := runes[index]
+		switch {
+		case unicode.IsSpace(r):
+			index++
+		case unicode.IsDigit(r) || r == '.':
+			start := index
+			for index < len(runes) && (unicode.IsDigit(runes[index]) || runes[index] == '.') {
+				index++
+			}
+			p.tokens = append(p.tokens, alarmFormulaToken{kind: 'n', text: string(runes[start:index])})
+		case r == '_' || unicode.IsLetter(r):
+			start := index
+			for index < len(runes) && (runes[index] == '_' || unicode.IsLetter(runes[index]) || unicode.IsDigit(runes[index])) {
+				index++
+			}
+			p.tokens = append(p.tokens, alarmFormulaToken{kind: 'i', text: string(runes[start:index])})
+		case strings.ContainsRune("+-*/(),", r):
+			p.tokens = append(p.tokens, alarmFormulaToken{kind: byte(r), text: string(r)})
+			index++
+		default:
+			return fmt.Errorf("%w: unexpected %q", ErrInvalidAlarmFormula, string(r))
+		}
+	}
+	if len(p.tokens) == 0 {
+		return fmt.Errorf("%w: expression is empty", ErrInvalidAlarmFormula)
+	}
+	return nil
+}
+
+func (p *alarmFormulaParser) peek() byte {
+	if p.position >= len(p.tokens) {
+		return 0
+	}
+	return p.tokens[p.position].kind
+}
+
+func (p *alarmFormulaParser) expression() (alarmFormulaNode, error) {
+	left, err := p.term()
+	for err == nil && (p.peek() == '+' || p.peek() == '-') {
+		operator := p.tokens[p.position].kind
+		p.position++
+		var right alarmFormulaNode
+		if right, err = p.term(); err == nil {
+			left = alarmFormulaBinary{operator: operator, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *alarmFormulaParser) term() (alarmFormulaNode, error) {
+	left, err := p.factor()
+	for err == nil && (p.peek() == '*' || p.peek() == '/') {
+		operator := p.tokens[p.position].kind
+		p.position++
+		var right alarmFormulaNode
+		if right, err = p.factor(); err == nil {
+			left = alarmFormulaBinary{operator: operator, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *alarmFormulaParser) factor() (alarmFormulaNode, error) {
+	if p.position >= len(p.tokens) {
+		return nil, fmt.Errorf("%w: expression ends early", ErrInvalidAlarmFormula)
+	}
+	token := p.tokens[p.position]
+	p.position++
+	switch token.kind {
+	case '+', '-':
+		operand, err := p.factor()
+		return alarmFormulaUnary{operator: token.kind, operand: operand}, err
+	case 'n':
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad number %q", ErrInvalidAlarmFormula, token.text)
+		}
+		return alarmFormulaNumber(value), nil
+	case 'i':
+		if p.peek() == '(' {
+			return p.call(token.text)
+		}
+		return alarmFormulaVariable(token.text), nil
+	case '(':
+		inner, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("%w: missing )", ErrInvalidAlarmFormula)
+		}
+		p.position++
+		return inner, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidAlarmFormula, token.text)
+	}
+}
+
+func (p *alarmFormulaParser) call(name string) (alarmFormulaNode, error) {
+	arity, ok := alarmFormulaArity[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %s", ErrInvalidAlarmFormula, name)
+	}
+	p.position++ // (
+	var args []alarmFormulaNode
+	for p.peek() != ')' {
+		arg, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.peek() == ',' {
+			p.position++
+		} else if p.peek() != ')' {
+			return nil, fmt.Errorf("%w: missing ) after %s arguments", ErrInvalidAlarmFormula, name)
+		}
+	}
+	p.position++ // )
+	if len(args) < arity[0] || (arity[1] >= 0 && len(args) > arity[1]) {
+		return nil, fmt.Errorf("%w: wrong number of arguments for %s", ErrInvalidAlarmFormula, name)
+	}
+	return alarmFormulaCall{name: name, args: args}, nil
+}
+
+func isAlarmFormulaIdentifier(text string) bool {
+	if text == "" {
+		return false
+	}
+	for index, r := range text {
+		if r != '_' && !unicode.IsLetter(r) && (index == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
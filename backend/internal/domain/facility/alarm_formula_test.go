@@ -0,0 +1,55 @@
+package facility
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAlarmFormulaEvaluate(t *testing.T) {
+	values := map[string]float64{"setpoint": 21, "low_limit": 5}
+	cases := []struct {
+		expression string
+		want       float64
+	}{
+		{"setpoint + 2", 23},
+		{"-setpoint + 2 * 3", -15},
+		{"(setpoint - low_limit) / 2", 8},
+		{"max(low_limit, setpoint * 0.1)", 5},
+		{"round(setpoint / 3 + 0.123, 1)", 7.1},
+	}
+	for _, tt := range cases {
+		t.Run(tt.expression, func(t *testing.T) {
+			formula, err := ParseAlarmFormula(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseAlarmFormula() error = %v", err)
+			}
+			got, err := formula.Evaluate(values)
+			if err != nil || got != tt.want {
+				t.Fatalf("Evaluate() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAlarmAssignment(t *testing.T) {
+	key, formula, err := ParseAlarmAssignment("high_limit = setpoint + 2")
+	if err != nil || key != "high_limit" || formula.String() != "setpoint + 2" {
+		t.Fatalf("ParseAlarmAssignment() = %q, %q, %v", key, formula, err)
+	}
+	if variables := formula.Variables(); len(variables) != 1 || variables[0] != "setpoint" {
+		t.Fatalf("Variables() = %v, want [setpoint]", variables)
+	}
+	if _, err := formula.Evaluate(map[string]float64{}); !errors.Is(err, ErrAlarmFormulaVariable) {
+		t.Fatalf("missing variable error = %v", err)
+	}
+
+	for _, statement := range []string{"setpoint + 2", "high limit = 1", "x = (1 + 2", "x = 1 / 0", "x = pow(2, 3)", "x = 1 +"} {
+		key, formula, err := ParseAlarmAssignment(statement)
+		if err == nil {
+			_, err = formula.Evaluate(nil)
+		}
+		if !errors.Is(err, ErrInvalidAlarmFormula) {
+			t.Fatalf("%q: error = %v (key %q), want ErrInvalidAlarmFormula", statement, err, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package facility
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// EffectiveAlarmType returns a copy of alarmType whose fields carry the
+// definition-level overrides: required flag, default value, unit and the
+// validation rules merged key by key. alarmType itself is left untouched.
+func EffectiveAlarmType(alarmType *AlarmType, overrides []AlarmDefinitionFieldOverride) *AlarmType {
+	if alarmType == nil {
+		return nil
+	}
+	effective := *alarmType
+	effective.Fields = append([]AlarmTypeField(nil), alarmType.Fields...)
+	if len(overrides) == 0 {
+		return &effective
+	}
+	byField := make(map[uuid.UUID]AlarmDefinitionFieldOverride, len(overrides))
+	for _, override := range overrides {
+		byField[override.AlarmTypeFieldID] = override
+	}
+	for index := range effective.Fields {
+		field := &effective.Fields[index]
+		override, ok := byField[field.ID]
+		if !ok {
+			continue
+		}
+		if override.IsRequiredOverride != nil {
+			field.IsRequired = *override.IsRequiredOverride
+		}
+		if override.DefaultValueOverrideJSON != nil {
+			field.DefaultValueJSON = override.DefaultValueOverrideJSON
+		}
+		if override.ValidationOverrideJSON != nil {
+			field.ValidationJSON = mergeAlarmRulesJSON(field.ValidationJSON, override.ValidationOverrideJSON)
+		}
+		if override.UnitOverrideID != nil {
+			field.DefaultUnitID = override.UnitOverrideID
+			field.DefaultUnit = override.UnitOverride
+		}
+	}
+	return &effective
+}
+
+// mergeAlarmRulesJSON overlays the override's top-level keys on the base rule
+// set. A side that does not decode is returned as is, so the broken rules are
+// still reported as invalid_rules by ValidateAlarmValues.
+func mergeAlarmRulesJSON(base, override *string) *string {
+	if strings.TrimSpace(*override) == "" {
+		return base
+	}
+	merged := map[string]json.RawMessage{}
+	if base != nil && strings.TrimSpace(*base) != "" {
+		if err := json.Unmarshal([]byte(*base), &merged); err != nil {
+			return base
+		}
+	}
+	var overlay map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*override), &overlay); err != nil {
+		return override
+	}
+	for key, value := range overlay {
+		merged[key] = value
+	}
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		return override
+	}
+	result := string(encoded)
+	return &result
+}
@@ -0,0 +1,50 @@
+package facility
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEffectiveAlarmTypeAppliesDefinitionOverrides(t *testing.T) {
+	alarmType, fields := limitAlarmType()
+	required := true
+	defaultValue := `42`
+	rules := `{"max": 50}`
+	unitID := uuid.New()
+
+	effective := EffectiveAlarmType(alarmType, []AlarmDefinitionFieldOverride{{
+		AlarmTypeFieldID:         fields["high_limit"],
+		IsRequiredOverride:       &required,
+		DefaultValueOverrideJSON: &defaultValue,
+		ValidationOverrideJSON:   &rules,
+		UnitOverrideID:           &unitID,
+	}})
+
+	var field, original *AlarmTypeField
+	for index := range effective.Fields {
+		if effective.Fields[index].ID == fields["high_limit"] {
+			field, original = &effective.Fields[index], &alarmType.Fields[index]
+		}
+	}
+	if field == nil {
+		t.Fatal("high_limit missing from effective type")
+	}
+	if !field.IsRequired || field.DefaultValueJSON == nil || *field.DefaultValueJSON != defaultValue {
+		t.Fatalf("required/default not overridden: %+v", field)
+	}
+	if field.DefaultUnitID == nil || *field.DefaultUnitID != unitID {
+		t.Fatalf("unit = %v, want %v", field.DefaultUnitID, unitID)
+	}
+	var merged map[string]any
+	if err := json.Unmarshal([]byte(*field.ValidationJSON), &merged); err != nil {
+		t.Fatalf("merged rules: %v", err)
+	}
+	if merged["max"] != float64(50) || merged["min"] != float64(-50) || merged["greater_than"] != "low_limit" {
+		t.Fatalf("merged rules = %v", merged)
+	}
+	if original.IsRequired || original.DefaultValueJSON != nil {
+		t.Fatal("EffectiveAlarmType modified the source alarm type")
+	}
+}
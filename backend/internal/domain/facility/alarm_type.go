@@ -5,12 +5,17 @@ import (
 	"github.com/google/uuid"
 )
 
-// Unit represents a measurement unit (e.g. °C, %, Pa)
+// Unit represents a measurement unit (e.g. °C, %, Pa). Units of the same
+// Dimension convert through the dimension's base unit: base = value*Factor +
+// Offset. A unit without a dimension only converts to itself.
 type Unit struct {
 	domain.Base
-	Code   string `gorm:"uniqueIndex;not null;size:30"`
-	Symbol string `gorm:"not null;size:20"`
-	Name   string `gorm:"not null;size:100"`
+	Code      string  `gorm:"uniqueIndex;not null;size:30"`
+	Symbol    string  `gorm:"not null;size:20"`
+	Name      string  `gorm:"not null;size:100"`
+	Dimension string  `gorm:"not null;default:'';size:40;index"`
+	Factor    float64 `gorm:"not null;default:1"`
+	Offset    float64 `gorm:"not null;default:0"`
 }
 
 // AlarmType represents a technical alarm type (e.g. limit_high_low, io_monitoring)
@@ -0,0 +1,320 @@
+package facility
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AlarmFieldRules is the decoded form of AlarmTypeField.ValidationJSON, e.g.
+//
+//	{"min": 0, "max": 120, "step": 0.5, "precision": 1, "greater_than": "low_limit"}
+//
+// Cross-field rules name other fields of the same alarm type by key.
+type AlarmFieldRules struct {
+	Min         *float64       `json:"min,omitempty"`
+	Max         *float64       `json:"max,omitempty"`
+	Step        *float64       `json:"step,omitempty"`
+	Precision   *int           `json:"precision,omitempty"`
+	Regex       *string        `json:"regex,omitempty"`
+	Options     []AlarmOption  `json:"options,omitempty"`
+	GreaterThan alarmFieldKeys `json:"greater_than,omitempty"`
+	LessThan    alarmFieldKeys `json:"less_than,omitempty"`
+}
+
+// AlarmOption is one allowed enum value. Options may be plain JSON values or
+// objects with a value and a label.
+type AlarmOption struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+}
+
+func (o *AlarmOption) UnmarshalJSON(data []byte) error {
+	var object struct {
+		Value json.RawMessage `json:"value"`
+		Label string          `json:"label"`
+	}
+	if err := json.Unmarshal(data, &object); err == nil && object.Value != nil {
+		o.Value, o.Label = alarmOptionValue(object.Value), object.Label
+		return nil
+	}
+	o.Value = alarmOptionValue(data)
+	return nil
+}
+
+func alarmOptionValue(data json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return text
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// alarmFieldKeys accepts a single key or a list of keys.
+type alarmFieldKeys []string
+
+func (k *alarmFieldKeys) UnmarshalJSON(data []byte) error {
+	var key string
+	if err := json.Unmarshal(data, &key); err == nil {
+		*k = alarmFieldKeys{key}
+		return nil
+	}
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// ParseAlarmFieldRules decodes a rule set. Empty JSON means no rules.
+func ParseAlarmFieldRules(validationJSON *string) (AlarmFieldRules, error) {
+	var rules AlarmFieldRules
+	if validationJSON == nil || strings.TrimSpace(*validationJSON) == "" {
+		return rules, nil
+	}
+	err := json.Unmarshal([]byte(*validationJSON), &rules)
+	return rules, err
+}
+
+// ValidateAlarmValues checks values against the fields of alarmType, which
+// callers pass with the definition-level overrides applied (see
+// EffectiveAlarmType). Failures are reported per field under path, keyed by
+// the alarm field's key. Values left empty are open and pass unless their
+// field is required.
+func ValidateAlarmValues(path string, alarmType *AlarmType, values []BacnetObjectAlarmValue) error {
+	if path == "" {
+		path = "alarm_values"
+	}
+	validation := domain.NewValidationError()
+	schema := newAlarmSchema(alarmType)
+	numbers := make(map[string]float64, len(values))
+	seen := make(map[uuid.UUID]struct{}, len(values))
+	filled := make(map[uuid.UUID]struct{}, len(values))
+	checked := make([]alarmSchemaField, 0, len(values))
+	for index := range values {
+		value := &values[index]
+		field, ok := schema[value.AlarmTypeFieldID]
+		if !ok {
+			validation.AddCode(fmt.Sprintf("%s.%s", path, value.AlarmTypeFieldID), "unknown_field", "field does not belong to the alarm type")
+			continue
+		}
+		fieldPath := path + "." + field.key
+		if _, duplicate := seen[value.AlarmTypeFieldID]; duplicate {
+			validation.AddCode(fieldPath, "duplicate", field.key+" is set more than once")
+			continue
+		}
+		seen[value.AlarmTypeFieldID] = struct{}{}
+		if alarmValueSet(value) {
+			filled[value.AlarmTypeFieldID] = struct{}{}
+		}
+		if field.err != nil {
+			validation.AddCode(fieldPath, "invalid_rules", "validation rules of "+field.key+" are invalid")
+			continue
+		}
+		if code, message := field.check(value); code != "" {
+			validation.AddCode(fieldPath, code, message)
+			continue
+		}
+		if number, ok := alarmNumber(value); ok {
+			numbers[field.key] = number
+		}
+		checked = append(checked, field)
+	}
+	for _, field := range checked {
+		if code, message := field.compare(numbers); code != "" {
+			validation.AddCode(path+"."+field.key, code, message)
+		}
+	}
+	if alarmType != nil {
+		for _, typeField := range alarmType.Fields {
+			if _, ok := filled[typeField.ID]; typeField.IsRequired && !ok {
+				field := schema[typeField.ID]
+				validation.AddCode(path+"."+field.key, "required", field.key+" is required")
+			}
+		}
+	}
+	return validationResult(validation)
+}
+
+type alarmSchemaField struct {
+	key      string
+	dataType string
+	rules    AlarmFieldRules
+	pattern  *regexp.Regexp
+	err      error
+}
+
+func newAlarmSchema(alarmType *AlarmType) map[uuid.UUID]alarmSchemaField {
+	if alarmType == nil {
+		return nil
+	}
+	schema := make(map[uuid.UUID]alarmSchemaField, len(alarmType.Fields))
+	for _, typeField := range alarmType.Fields {
+		field := alarmSchemaField{key: typeField.ID.String()}
+		if typeField.AlarmField != nil {
+			field.key = typeField.AlarmField.Key
+			field.dataType = strings.ToLower(strings.TrimSpace(typeField.AlarmField.DataType))
+		}
+		field.rules, field.err = ParseAlarmFieldRules(typeField.ValidationJSON)
+		if field.err == nil && field.rules.Regex != nil {
+			field.pattern, field.err = regexp.Compile(*field.rules.Regex)
+		}
+		schema[typeField.ID] = field
+	}
+	return schema
+}
+
+// check validates a single value against its data type and rules and returns
+// the failing rule as code.
+func (f alarmSchemaField) check(value *BacnetObjectAlarmValue) (string, string) {
+	if code, message := f.checkType(value); code != "" {
+		return code, message
+	}
+	if number, ok := alarmNumber(value); ok {
+		return f.checkNumber(number)
+	}
+	if value.ValueString != nil {
+		return f.checkString(*value.ValueString)
+	}
+	return "", ""
+}
+
+func (f alarmSchemaField) checkType(value *BacnetObjectAlarmValue) (string, string) {
+	set := alarmValueCount(value)
+	if set > 1 {
+		return "type", f.key + " must hold exactly one value"
+	}
+	if set == 0 {
+		return "", ""
+	}
+	valid := true
+	switch f.dataType {
+	case "number", "duration":
+		valid = value.ValueNumber != nil || value.ValueInteger != nil
+	case "integer":
+		valid = value.ValueInteger != nil || (value.ValueNumber != nil && *value.ValueNumber == math.Trunc(*value.ValueNumber))
+	case "boolean":
+		valid = value.ValueBoolean != nil
+	case "string", "enum":
+		valid = value.ValueString != nil
+	case "state_map", "json":
+		valid = value.ValueJSON != nil && json.Valid([]byte(*value.ValueJSON))
+	}
+	if !valid {
+		return "type", f.key + " must be of type " + f.dataType
+	}
+	return "", ""
+}
+
+func (f alarmSchemaField) checkNumber(number float64) (string, string) {
+	rules := f.rules
+	if rules.Min != nil && number < *rules.Min {
+		return "min", fmt.Sprintf("%s must be at least %s", f.key, formatAlarmNumber(*rules.Min))
+	}
+	if rules.Max != nil && number > *rules.Max {
+		return "max", fmt.Sprintf("%s must be at most %s", f.key, formatAlarmNumber(*rules.Max))
+	}
+	if rules.Step != nil && *rules.Step > 0 {
+		base := 0.0
+		if rules.Min != nil {
+			base = *rules.Min
+		}
+		if !nearlyWhole((number - base) / *rules.Step) {
+			return "step", fmt.Sprintf("%s must be a multiple of %s", f.key, formatAlarmNumber(*rules.Step))
+		}
+	}
+	if rules.Precision != nil && *rules.Precision >= 0 && !nearlyWhole(number*math.Pow10(*rules.Precision)) {
+		return "precision", fmt.Sprintf("%s allows at most %d decimal places", f.key, *rules.Precision)
+	}
+	if len(rules.Options) > 0 && !f.hasOption(formatAlarmNumber(number)) {
+		return "oneof", f.key + " must be one of " + f.optionList()
+	}
+	return "", ""
+}
+
+func (f alarmSchemaField) checkString(text string) (string, string) {
+	if f.pattern != nil && !f.pattern.MatchString(text) {
+		return "format", f.key + " does not match the required format"
+	}
+	if len(f.rules.Options) > 0 && !f.hasOption(text) {
+		return "oneof", f.key + " must be one of " + f.optionList()
+	}
+	return "", ""
+}
+
+// compare applies the cross-field rules. Fields that are open or failed
+// their own checks are skipped.
+func (f alarmSchemaField) compare(numbers map[string]float64) (string, string) {
+	number, ok := numbers[f.key]
+	if !ok {
+		return "", ""
+	}
+	for _, other := range f.rules.GreaterThan {
+		if limit, ok := numbers[other]; ok && number <= limit {
+			return "greater_than", f.key + " must be greater than " + other
+		}
+	}
+	for _, other := range f.rules.LessThan {
+		if limit, ok := numbers[other]; ok && number >= limit {
+			return "less_than", f.key + " must be less than " + other
+		}
+	}
+	return "", ""
+}
+
+func (f alarmSchemaField) hasOption(value string) bool {
+	for _, option := range f.rules.Options {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (f alarmSchemaField) optionList() string {
+	values := make([]string, len(f.rules.Options))
+	for index, option := range f.rules.Options {
+		values[index] = option.Value
+	}
+	return strings.Join(values, ", ")
+}
+
+func alarmValueSet(value *BacnetObjectAlarmValue) bool {
+	return alarmValueCount(value) > 0
+}
+
+func alarmValueCount(value *BacnetObjectAlarmValue) int {
+	set := 0
+	for _, present := range []bool{value.ValueNumber != nil, value.ValueInteger != nil, value.ValueBoolean != nil, value.ValueString != nil, value.ValueJSON != nil} {
+		if present {
+			set++
+		}
+	}
+	return set
+}
+
+func alarmNumber(value *BacnetObjectAlarmValue) (float64, bool) {
+	switch {
+	case value.ValueNumber != nil:
+		return *value.ValueNumber, true
+	case value.ValueInteger != nil:
+		return float64(*value.ValueInteger), true
+	default:
+		return 0, false
+	}
+}
+
+func nearlyWhole(value float64) bool {
+	return math.Abs(value-math.Round(value)) < 1e-6
+}
+
+func formatAlarmNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,162 @@
+package facility
+
+import (
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestValidateAlarmValuesReportsFieldRules(t *testing.T) {
+	alarmType, fields := limitAlarmType()
+	tests := []struct {
+		name   string
+		values []BacnetObjectAlarmValue
+		want   map[string]string
+	}{
+		{
+			name: "high limit below low limit",
+			values: []BacnetObjectAlarmValue{
+				numberValue(fields["high_limit"], 10), numberValue(fields["low_limit"], 20),
+			},
+			want: map[string]string{"alarm_values.high_limit": "greater_than"},
+		},
+		{
+			name:   "above max",
+			values: []BacnetObjectAlarmValue{numberValue(fields["high_limit"], 150)},
+			want:   map[string]string{"alarm_values.high_limit": "max"},
+		},
+		{
+			name:   "off step",
+			values: []BacnetObjectAlarmValue{numberValue(fields["low_limit"], 2.25)},
+			want:   map[string]string{"alarm_values.low_limit": "step"},
+		},
+		{
+			name:   "string in enum field outside options",
+			values: []BacnetObjectAlarmValue{stringValue(fields["alarm_state"], "open")},
+			want:   map[string]string{"alarm_values.alarm_state": "oneof"},
+		},
+		{
+			name:   "number in enum field",
+			values: []BacnetObjectAlarmValue{numberValue(fields["alarm_state"], 1)},
+			want:   map[string]string{"alarm_values.alarm_state": "type"},
+		},
+		{
+			name:   "regex",
+			values: []BacnetObjectAlarmValue{stringValue(fields["nc_reference"], "nc-1")},
+			want:   map[string]string{"alarm_values.nc_reference": "format"},
+		},
+		{
+			name:   "field of another type",
+			values: []BacnetObjectAlarmValue{numberValue(uuid.Nil, 1)},
+			want:   map[string]string{"alarm_values." + uuid.Nil.String(): "unknown_field"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validationErr, ok := domain.AsValidationError(ValidateAlarmValues("", alarmType, tt.values))
+			if !ok {
+				t.Fatal("expected a validation error")
+			}
+			if len(validationErr.Codes) != len(tt.want) {
+				t.Fatalf("codes = %v, want %v", validationErr.Codes, tt.want)
+			}
+			for path, code := range tt.want {
+				if got := validationErr.Codes[path]; got != code {
+					t.Errorf("code for %q = %q, want %q", path, got, code)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAlarmValuesAcceptsValidAndOpenValues(t *testing.T) {
+	alarmType, fields := limitAlarmType()
+	values := []BacnetObjectAlarmValue{
+		numberValue(fields["high_limit"], 80.5), numberValue(fields["low_limit"], 20),
+		stringValue(fields["alarm_state"], "active"), stringValue(fields["nc_reference"], "NC12"),
+		{AlarmTypeFieldID: fields["alarm_delay"]},
+	}
+
+	if err := ValidateAlarmValues("", alarmType, values); err != nil {
+		t.Fatalf("ValidateAlarmValues() error = %v", err)
+	}
+}
+
+func TestValidateAlarmValuesAppliesOverridesOnTopOfTypeRules(t *testing.T) {
+	alarmType, fields := limitAlarmType()
+	override := `{"max": 50}`
+
+	effective := EffectiveAlarmType(alarmType, []AlarmDefinitionFieldOverride{
+		{AlarmTypeFieldID: fields["high_limit"], ValidationOverrideJSON: &override},
+	})
+	err := ValidateAlarmValues("", effective, []BacnetObjectAlarmValue{numberValue(fields["high_limit"], 80)})
+
+	validationErr, ok := domain.AsValidationError(err)
+	if !ok || validationErr.Codes["alarm_values.high_limit"] != "max" {
+		t.Fatalf("error = %v, want max on high_limit", err)
+	}
+}
+
+func TestValidateAlarmValuesRejectsMissingRequiredValues(t *testing.T) {
+	alarmType, fields := limitAlarmType()
+	alarmType.Fields[0].IsRequired = true
+	required := true
+	effective := EffectiveAlarmType(alarmType, []AlarmDefinitionFieldOverride{
+		{AlarmTypeFieldID: fields["alarm_delay"], IsRequiredOverride: &required},
+	})
+
+	err := ValidateAlarmValues("", effective, []BacnetObjectAlarmValue{
+		{AlarmTypeFieldID: fields["alarm_delay"]}, numberValue(fields["low_limit"], 20),
+	})
+
+	validationErr, ok := domain.AsValidationError(err)
+	if !ok {
+		t.Fatalf("error = %v, want a validation error", err)
+	}
+	want := map[string]string{"alarm_values.high_limit": "required", "alarm_values.alarm_delay": "required"}
+	if len(validationErr.Codes) != len(want) {
+		t.Fatalf("codes = %v, want %v", validationErr.Codes, want)
+	}
+	for path, code := range want {
+		if validationErr.Codes[path] != code {
+			t.Fatalf("codes = %v, want %v", validationErr.Codes, want)
+		}
+	}
+	if err := ValidateAlarmValues("", effective, []BacnetObjectAlarmValue{
+		numberValue(fields["high_limit"], 80), numberValue(fields["alarm_delay"], 30),
+	}); err != nil {
+		t.Fatalf("ValidateAlarmValues() error = %v, want the required values accepted", err)
+	}
+}
+
+func limitAlarmType() (*AlarmType, map[string]uuid.UUID) {
+	specs := []struct{ key, dataType, rules string }{
+		{"high_limit", "number", `{"min": -50, "max": 120, "precision": 1, "greater_than": "low_limit"}`},
+		{"low_limit", "number", `{"min": -50, "max": 120, "step": 0.5}`},
+		{"alarm_state", "enum", `{"options": ["active", {"value": "inactive", "label": "Inaktiv"}]}`},
+		{"nc_reference", "string", `{"regex": "^NC[0-9]+$"}`},
+		{"alarm_delay", "duration", ``},
+	}
+	alarmType := &AlarmType{Code: "limit_high_low"}
+	ids := make(map[string]uuid.UUID, len(specs))
+	for _, spec := range specs {
+		field := AlarmTypeField{Base: domain.Base{ID: uuid.New()}, AlarmField: &AlarmField{Key: spec.key, DataType: spec.dataType}}
+		if spec.rules != "" {
+			rules := spec.rules
+			field.ValidationJSON = &rules
+		}
+		alarmType.Fields = append(alarmType.Fields, field)
+		ids[spec.key] = field.ID
+	}
+	return alarmType, ids
+}
+
+func numberValue(fieldID uuid.UUID, value float64) BacnetObjectAlarmValue {
+	return BacnetObjectAlarmValue{AlarmTypeFieldID: fieldID, ValueNumber: &value}
+}
+
+func stringValue(fieldID uuid.UUID, value string) BacnetObjectAlarmValue {
+	return BacnetObjectAlarmValue{AlarmTypeFieldID: fieldID, ValueString: &value}
+}
@@ -0,0 +1,57 @@
+package facility
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// BacnetObjectSelection narrows the BACnet object instances of a bulk
+// operation. Criteria combine with AND; TextFix matches case-insensitively
+// and accepts * as a wildcard.
+type BacnetObjectSelection struct {
+	ProjectID       *uuid.UUID `json:"project_id,omitempty"`
+	SPSControllerID *uuid.UUID `json:"sps_controller_id,omitempty"`
+	SystemPartID    *uuid.UUID `json:"system_part_id,omitempty"`
+	ApparatID       *uuid.UUID `json:"apparat_id,omitempty"`
+	AlarmTypeID     *uuid.UUID `json:"alarm_type_id,omitempty"`
+	TextFix         string     `json:"text_fix,omitempty"`
+}
+
+// IsEmpty reports whether the selection would match every instance.
+func (s BacnetObjectSelection) IsEmpty() bool {
+	return s.ProjectID == nil && s.SPSControllerID == nil && s.SystemPartID == nil &&
+		s.ApparatID == nil && s.AlarmTypeID == nil && s.TextFix == ""
+}
+
+// SelectedBacnetObject is one selected instance with the revision the bulk
+// operation expects to write against.
+type SelectedBacnetObject struct {
+	ID      uuid.UUID `json:"id"`
+	Version uint64    `json:"version"`
+}
+
+type BacnetObjectSelectionRepository interface {
+	// Select returns matching instances ordered by ID, at most limit rows.
+	Select(ctx context.Context, selection BacnetObjectSelection, limit int) ([]SelectedBacnetObject, error)
+}
+
+// AlarmValueAssignment sets one alarm field either to a literal JSON Value
+// (null clears the field) or to the result of Formula. Formula may be a bare
+// expression or a full "field = expression" statement, in which case
+// FieldKey may be left empty.
+type AlarmValueAssignment struct {
+	FieldKey string          `json:"field_key,omitempty"`
+	Value    json.RawMessage `json:"value,omitempty"`
+	Formula  string          `json:"formula,omitempty"`
+	UnitID   *uuid.UUID      `json:"unit_id,omitempty"`
+}
+
+// AlarmValueBulkEdit applies the same assignments to every selected object.
+// Formulas read the values stored before the edit, so assignment order does
+// not matter.
+type AlarmValueBulkEdit struct {
+	Selection   BacnetObjectSelection  `json:"selection"`
+	Assignments []AlarmValueAssignment `json:"assignments"`
+}
@@ -0,0 +1,51 @@
+package facility
+
+import "github.com/google/uuid"
+
+// BacnetNumberingStrategy selects the instance range a BACnet object may be
+// numbered from. Instances are unique per SPS controller and object type.
+type BacnetNumberingStrategy string
+
+const (
+	// BacnetNumberingSequential uses the lowest free instance on the controller.
+	BacnetNumberingSequential BacnetNumberingStrategy = "sequential"
+	// BacnetNumberingSystemTypeBlock keeps instances inside the
+	// NumberMin..NumberMax range of the field device's system type.
+	BacnetNumberingSystemTypeBlock BacnetNumberingStrategy = "system_type_block"
+	// BacnetNumberingFieldDeviceBlock reserves one contiguous block of
+	// BlockSize instances for every field device.
+	BacnetNumberingFieldDeviceBlock BacnetNumberingStrategy = "field_device_block"
+)
+
+const DefaultBacnetNumberingBlockSize = 100
+
+// BacnetNumbering asks for instance numbers to be assigned automatically.
+// BlockSize only applies to BacnetNumberingFieldDeviceBlock.
+type BacnetNumbering struct {
+	Strategy  BacnetNumberingStrategy
+	BlockSize int
+}
+
+// BacnetNumberingRequest previews numbering for field devices on one
+// controller. An empty FieldDeviceIDs renumbers every device on it.
+type BacnetNumberingRequest struct {
+	SPSControllerID uuid.UUID
+	FieldDeviceIDs  []uuid.UUID
+	Numbering       BacnetNumbering
+}
+
+type BacnetNumberProposal struct {
+	BacnetObjectID uuid.UUID
+	FieldDeviceID  uuid.UUID
+	SoftwareType   BacnetSoftwareType
+	CurrentNumber  uint16
+	ProposedNumber uint16
+	Changed        bool
+}
+
+type BacnetNumberingPreview struct {
+	SPSControllerID uuid.UUID
+	Strategy        BacnetNumberingStrategy
+	Proposals       []BacnetNumberProposal
+	Changed         int
+}
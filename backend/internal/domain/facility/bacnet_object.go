@@ -29,8 +29,12 @@ type BacnetObject struct {
 	NotificationClass   *NotificationClass       `gorm:"foreignKey:NotificationClassID"`
 	AlarmTypeID         *uuid.UUID               `gorm:"type:uuid;index"`
 	AlarmType           *AlarmType               `gorm:"foreignKey:AlarmTypeID"`
-	AlarmDefinitionID   *uuid.UUID               `gorm:"-:all"`
+	AlarmDefinitionID   *uuid.UUID               `gorm:"type:uuid;index"`
 	AlarmValues         []BacnetObjectAlarmValue `gorm:"-:all"`
+
+	// TemplateID links the object to the ObjectData template it was cloned
+	// from, so template upgrades can find it again.
+	TemplateID *uuid.UUID `gorm:"type:uuid;index"`
 }
 
 // BacnetObjectPatch represents a partial update for a bacnet object.
@@ -23,6 +23,11 @@ type FieldDevice struct {
 	Apparat                   Apparat
 
 	BacnetObjects []BacnetObject
+
+	// ObjectDataID and ObjectDataRevision record the template revision the
+	// BACnet objects were built from. Both are nil for hand-built devices.
+	ObjectDataID       *uuid.UUID
+	ObjectDataRevision *uint64
 }
 
 // FieldDeviceOptions contains all metadata needed for creating/editing field devices
@@ -82,6 +87,8 @@ type FieldDeviceCreateItem struct {
 	FieldDevice   *FieldDevice
 	ObjectDataID  *uuid.UUID
 	BacnetObjects []BacnetObject
+	// Numbering replaces the template's instance numbers with free ones.
+	Numbering *BacnetNumbering
 }
 
 // FieldDeviceCreateResult represents the result of creating a single field device
@@ -116,6 +123,8 @@ type BulkFieldDeviceUpdate struct {
 	SystemPartID       *uuid.UUID
 	Specification      *SpecificationPatch
 	BacnetObjects      *[]BacnetObjectPatch
+	// Numbering renumbers the BACnet objects after BacnetObjects is applied.
+	Numbering *BacnetNumbering
 }
 
 func (u BulkFieldDeviceUpdate) HasBMKUpdate() bool {
@@ -0,0 +1,258 @@
+package facility
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// IPPool is the subnet of one VLAN that SPS controller addresses are
+// allocated from. A pool belongs to exactly one building or project.
+// RangeStart and RangeEnd narrow the allocatable part of Network; without
+// them every host address except the gateway is allocatable.
+type IPPool struct {
+	domain.Base
+	Name        string     `gorm:"not null"`
+	BuildingID  *uuid.UUID `gorm:"type:uuid;index"`
+	ProjectID   *uuid.UUID `gorm:"type:uuid;index"`
+	Vlan        string     `gorm:"not null;index"`
+	Network     string     `gorm:"not null"`
+	Gateway     *string
+	RangeStart  *string
+	RangeEnd    *string
+	Description *string
+}
+
+type IPPoolFilter struct {
+	BuildingID *uuid.UUID
+	ProjectID  *uuid.UUID
+	Vlan       string
+}
+
+// SPSControllerAddress is the addressing of one controller as stored on the
+// controller itself. BuildingID is resolved through its control cabinet.
+type SPSControllerAddress struct {
+	SPSControllerID uuid.UUID
+	BuildingID      uuid.UUID
+	DeviceName      string
+	IPAddress       *string
+	Subnet          *string
+	Gateway         *string
+	Vlan            *string
+}
+
+type IPPoolRepository interface {
+	domain.Repository[IPPool]
+	List(ctx context.Context, filter IPPoolFilter) ([]IPPool, error)
+	// ListControllerAddresses returns controllers on the filter's VLAN. An
+	// empty scope returns every controller on the VLAN.
+	ListControllerAddresses(ctx context.Context, filter IPPoolFilter) ([]SPSControllerAddress, error)
+}
+
+type IPAddressIssueKind string
+
+const (
+	IPAddressIssueOutsideSubnet        IPAddressIssueKind = "ip_outside_subnet"
+	IPAddressIssueGatewayOutsideSubnet IPAddressIssueKind = "gateway_outside_subnet"
+	IPAddressIssueSubnetMismatch       IPAddressIssueKind = "subnet_mismatch"
+)
+
+type IPAddressIssue struct {
+	SPSControllerID uuid.UUID
+	DeviceName      string
+	Kind            IPAddressIssueKind
+	Value           string
+}
+
+type IPPoolUtilization struct {
+	Pool        IPPool
+	Capacity    int
+	Used        int
+	Free        int
+	Utilization float64
+	Issues      []IPAddressIssue
+}
+
+type IPAllocation struct {
+	PoolID    uuid.UUID
+	IPAddress string
+	Subnet    string
+	Gateway   *string
+	Vlan      string
+}
+
+// Prefix returns the pool network in canonical form.
+func (p IPPool) Prefix() (netip.Prefix, bool) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(p.Network))
+	if err != nil || !prefix.Addr().Is4() {
+		return netip.Prefix{}, false
+	}
+	return prefix.Masked(), true
+}
+
+// SubnetMask renders the pool prefix as the dotted mask stored on controllers.
+func (p IPPool) SubnetMask() string {
+	prefix, ok := p.Prefix()
+	if !ok {
+		return ""
+	}
+	mask := uint32(0xffffffff) << (32 - prefix.Bits())
+	return netip.AddrFrom4([4]byte{byte(mask >> 24), byte(mask >> 16), byte(mask >> 8), byte(mask)}).String()
+}
+
+// Contains reports whether value is a host address of the pool network.
+func (p IPPool) Contains(value string) bool {
+	prefix, ok := p.Prefix()
+	if !ok {
+		return false
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(value))
+	return err == nil && prefix.Contains(addr)
+}
+
+// AllocatableRange returns the first and last address handed out by the pool.
+func (p IPPool) AllocatableRange() (netip.Addr, netip.Addr, bool) {
+	prefix, ok := p.Prefix()
+	if !ok || prefix.Bits() > 30 {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	first, last := prefix.Addr().Next(), broadcastAddr(prefix).Prev()
+	if p.RangeStart != nil && strings.TrimSpace(*p.RangeStart) != "" {
+		first, _ = netip.ParseAddr(strings.TrimSpace(*p.RangeStart))
+	}
+	if p.RangeEnd != nil && strings.TrimSpace(*p.RangeEnd) != "" {
+		last, _ = netip.ParseAddr(strings.TrimSpace(*p.RangeEnd))
+	}
+	return first, last, first.IsValid() && last.IsValid() && first.Compare(last) <= 0
+}
+
+// Capacity counts allocatable addresses, excluding a gateway inside the range.
+func (p IPPool) Capacity() int {
+	first, last, ok := p.AllocatableRange()
+	if !ok {
+		return 0
+	}
+	count := int(addrUint32(last)-addrUint32(first)) + 1
+	if gateway, ok := p.gatewayAddr(); ok && gateway.Compare(first) >= 0 && gateway.Compare(last) <= 0 {
+		count--
+	}
+	return count
+}
+
+// NextFree returns the lowest allocatable address that is neither the
+// gateway nor in used. used holds canonical address strings.
+func (p IPPool) NextFree(used map[string]struct{}) (string, bool) {
+	first, last, ok := p.AllocatableRange()
+	if !ok {
+		return "", false
+	}
+	gateway, hasGateway := p.gatewayAddr()
+	for addr := first; addr.IsValid() && addr.Compare(last) <= 0; addr = addr.Next() {
+		if hasGateway && addr == gateway {
+			continue
+		}
+		if _, taken := used[addr.String()]; !taken {
+			return addr.String(), true
+		}
+	}
+	return "", false
+}
+
+// InspectController lists the ways a controller's stored addressing
+// disagrees with the pool it belongs to.
+func (p IPPool) InspectController(controller SPSControllerAddress) []IPAddressIssue {
+	issue := func(kind IPAddressIssueKind, value string) IPAddressIssue {
+		return IPAddressIssue{SPSControllerID: controller.SPSControllerID, DeviceName: controller.DeviceName, Kind: kind, Value: value}
+	}
+	issues := make([]IPAddressIssue, 0)
+	if value, ok := trimmedAddress(controller.IPAddress); ok && !p.Contains(value) {
+		issues = append(issues, issue(IPAddressIssueOutsideSubnet, value))
+	}
+	if value, ok := trimmedAddress(controller.Gateway); ok && !p.Contains(value) {
+		issues = append(issues, issue(IPAddressIssueGatewayOutsideSubnet, value))
+	}
+	if value, ok := trimmedAddress(controller.Subnet); ok && value != p.SubnetMask() {
+		issues = append(issues, issue(IPAddressIssueSubnetMismatch, value))
+	}
+	return issues
+}
+
+func (p IPPool) Validate() error {
+	validation := domain.NewValidationError()
+	requireString(validation, "ippool.name", p.Name, 150)
+	if (p.BuildingID == nil) == (p.ProjectID == nil) {
+		validation.AddCode("ippool.building_id", "scope", "exactly one of building_id and project_id is required")
+	}
+	vlan := p.Vlan
+	validateVLAN(validation, "ippool.vlan", &vlan)
+	if strings.TrimSpace(p.Vlan) == "" {
+		validation.AddCode("ippool.vlan", "required", "vlan is required")
+	}
+	optionalStringMax(validation, "ippool.description", p.Description, 250)
+	prefix, ok := p.Prefix()
+	if !ok || prefix.Bits() > 30 {
+		validation.AddCode("ippool.network", "cidr", "network must be an IPv4 CIDR of at most /30")
+		return validationResult(validation)
+	}
+	p.validateHostAddress(validation, "ippool.gateway", p.Gateway)
+	p.validateHostAddress(validation, "ippool.range_start", p.RangeStart)
+	p.validateHostAddress(validation, "ippool.range_end", p.RangeEnd)
+	if len(validation.Fields) == 0 {
+		if _, _, ok := p.AllocatableRange(); !ok {
+			validation.AddCode("ippool.range_end", "range", "range_end must not be before range_start")
+		}
+	}
+	return validationResult(validation)
+}
+
+// Overlaps reports whether both pools share at least one address.
+func (p IPPool) Overlaps(other IPPool) bool {
+	left, ok := p.Prefix()
+	right, otherOK := other.Prefix()
+	return ok && otherOK && left.Overlaps(right)
+}
+
+func (p IPPool) validateHostAddress(validation *domain.ValidationError, path string, value *string) {
+	trimmed, ok := trimmedAddress(value)
+	if !ok {
+		return
+	}
+	prefix, _ := p.Prefix()
+	addr, err := netip.ParseAddr(trimmed)
+	if err != nil || !addr.Is4() {
+		validation.AddCode(path, "ipv4", fieldName(path)+" must be a valid IPv4 address")
+		return
+	}
+	if !prefix.Contains(addr) || addr == prefix.Addr() || addr == broadcastAddr(prefix) {
+		validation.AddCode(path, "subnet", fieldName(path)+" must be a host address inside network")
+	}
+}
+
+func (p IPPool) gatewayAddr() (netip.Addr, bool) {
+	value, ok := trimmedAddress(p.Gateway)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	addr, err := netip.ParseAddr(value)
+	return addr, err == nil
+}
+
+func trimmedAddress(value *string) (string, bool) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(*value), true
+}
+
+func broadcastAddr(prefix netip.Prefix) netip.Addr {
+	value := addrUint32(prefix.Addr()) | (uint32(0xffffffff) >> prefix.Bits())
+	return netip.AddrFrom4([4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+}
+
+func addrUint32(addr netip.Addr) uint32 {
+	bytes := addr.As4()
+	return uint32(bytes[0])<<24 | uint32(bytes[1])<<16 | uint32(bytes[2])<<8 | uint32(bytes[3])
+}
@@ -0,0 +1,61 @@
+package facility
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestIPPoolNextFreeSkipsGatewayAndUsedAddresses(t *testing.T) {
+	pool := IPPool{Network: "10.1.0.0/29", Gateway: stringPtr("10.1.0.1")}
+	used := map[string]struct{}{"10.1.0.2": {}, "10.1.0.3": {}}
+
+	address, ok := pool.NextFree(used)
+
+	if !ok || address != "10.1.0.4" {
+		t.Fatalf("next free = %q, %v; want 10.1.0.4", address, ok)
+	}
+	if capacity := pool.Capacity(); capacity != 5 {
+		t.Fatalf("capacity = %d, want 5", capacity)
+	}
+	if mask := pool.SubnetMask(); mask != "255.255.255.248" {
+		t.Fatalf("subnet mask = %q", mask)
+	}
+}
+
+func TestIPPoolNextFreeHonoursRangeAndReportsExhaustion(t *testing.T) {
+	pool := IPPool{Network: "10.1.0.0/24", RangeStart: stringPtr("10.1.0.100"), RangeEnd: stringPtr("10.1.0.101")}
+
+	_, ok := pool.NextFree(map[string]struct{}{"10.1.0.100": {}, "10.1.0.101": {}})
+
+	if ok {
+		t.Fatal("exhausted range must not yield an address")
+	}
+	if capacity := pool.Capacity(); capacity != 2 {
+		t.Fatalf("capacity = %d, want 2", capacity)
+	}
+}
+
+func TestIPPoolInspectControllerReportsAddressingIssues(t *testing.T) {
+	pool := IPPool{Network: "10.1.0.0/24"}
+	controller := SPSControllerAddress{
+		SPSControllerID: uuid.New(),
+		IPAddress:       stringPtr("10.1.1.5"),
+		Gateway:         stringPtr("10.2.0.1"),
+		Subnet:          stringPtr("255.255.0.0"),
+	}
+
+	issues := pool.InspectController(controller)
+
+	want := []IPAddressIssueKind{IPAddressIssueOutsideSubnet, IPAddressIssueGatewayOutsideSubnet, IPAddressIssueSubnetMismatch}
+	if len(issues) != len(want) {
+		t.Fatalf("issues = %+v", issues)
+	}
+	for index, kind := range want {
+		if issues[index].Kind != kind {
+			t.Errorf("issue %d kind = %q, want %q", index, issues[index].Kind, kind)
+		}
+	}
+}
+
+func stringPtr(value string) *string { return &value }
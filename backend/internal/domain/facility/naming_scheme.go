@@ -0,0 +1,408 @@
+package facility
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// NamingToken is a placeholder of a naming pattern, written as {token}.
+type NamingToken string
+
+const (
+	NamingTokenIWSCode          NamingToken = "iws_code"
+	NamingTokenBuildingGroup    NamingToken = "building_group"
+	NamingTokenControlCabinetNr NamingToken = "control_cabinet_nr"
+	NamingTokenGADevice         NamingToken = "ga_device"
+	NamingTokenSystemTypeNumber NamingToken = "system_type_number"
+	NamingTokenSystemPart       NamingToken = "system_part"
+	NamingTokenApparat          NamingToken = "apparat"
+	NamingTokenApparatNr        NamingToken = "apparat_nr"
+	NamingTokenDeviceCode       NamingToken = "device_code"
+	NamingTokenSoftwareAddress  NamingToken = "software_address"
+)
+
+// ControllerNamingTokens are the tokens known before any field device exists.
+var ControllerNamingTokens = []NamingToken{
+	NamingTokenIWSCode, NamingTokenBuildingGroup, NamingTokenControlCabinetNr, NamingTokenGADevice,
+}
+
+// ObjectNamingTokens are the tokens available to BACnet object names.
+var ObjectNamingTokens = []NamingToken{
+	NamingTokenIWSCode, NamingTokenBuildingGroup, NamingTokenControlCabinetNr, NamingTokenGADevice,
+	NamingTokenSystemTypeNumber, NamingTokenSystemPart, NamingTokenApparat, NamingTokenApparatNr,
+	NamingTokenDeviceCode, NamingTokenSoftwareAddress,
+}
+
+// MaxNamingLength caps the limit a pattern may declare; BACnet character
+// strings are not bounded, but controllers and GMS imports are.
+const MaxNamingLength = 255
+
+// NamingPattern renders a name from tokens. Text in [brackets] is kept only
+// when every token inside it has a value, which is how separators around
+// optional parts are written: "[{iws_code}_]{ga_device}". MaxLength 0 means
+// no limit.
+type NamingPattern struct {
+	Pattern   string `gorm:"type:varchar(500);not null"`
+	MaxLength int    `gorm:"not null;default:0"`
+	Uppercase bool   `gorm:"not null;default:false"`
+}
+
+// NamingScheme is a named pair of patterns for SPS controller device names and
+// BACnet object names. Schemes without project form the library, grouped by
+// Client; the default scheme of a scope is the one the scope uses.
+type NamingScheme struct {
+	domain.Base
+	Name      string     `gorm:"type:varchar(150);not null"`
+	Client    string     `gorm:"type:varchar(150);not null;default:'';index"`
+	ProjectID *uuid.UUID `gorm:"type:uuid;index"`
+	IsDefault bool       `gorm:"not null;default:false"`
+
+	ControllerName NamingPattern `gorm:"embedded;embeddedPrefix:controller_"`
+	ObjectName     NamingPattern `gorm:"embedded;embeddedPrefix:object_"`
+}
+
+// DefaultNamingScheme reproduces the names generated before naming schemes
+// existed; it applies wherever no scheme was selected.
+func DefaultNamingScheme() NamingScheme {
+	return NamingScheme{
+		Name: "Standard",
+		ControllerName: NamingPattern{
+			Pattern: "[{iws_code}_{control_cabinet_nr}_]{ga_device}", Uppercase: true,
+		},
+		ObjectName: NamingPattern{
+			Pattern: "[{iws_code}_]{building_group}[_{system_type_number}][_{ga_device}][_{device_code}][_{software_address}]",
+		},
+	}
+}
+
+type NamingSchemeFilter struct {
+	ProjectID *uuid.UUID
+	Client    string
+}
+
+type NamingSchemeRepository interface {
+	domain.Repository[NamingScheme]
+	List(ctx context.Context, filter NamingSchemeFilter) ([]NamingScheme, error)
+	// GetDefault returns the default scheme of a project, or of the library
+	// when projectID is nil, and ErrNotFound when the scope has none.
+	GetDefault(ctx context.Context, projectID *uuid.UUID) (*NamingScheme, error)
+	// ListDefaultsForControlCabinet returns the default schemes of the
+	// projects the cabinet is linked to.
+	ListDefaultsForControlCabinet(ctx context.Context, controlCabinetID uuid.UUID) ([]NamingScheme, error)
+	ClearDefault(ctx context.Context, projectID *uuid.UUID, exceptID uuid.UUID) error
+}
+
+// NamingValues holds the token values of one name. Missing tokens are empty.
+type NamingValues map[NamingToken]string
+
+// ControllerNamingValues collects the tokens of an SPS controller name.
+func ControllerNamingValues(building *Building, cabinet *ControlCabinet, gaDevice *string) NamingValues {
+	values := NamingValues{}
+	if building != nil {
+		values[NamingTokenIWSCode] = strings.TrimSpace(building.IWSCode)
+		values[NamingTokenBuildingGroup] = fmt.Sprintf("%d", building.BuildingGroup)
+	}
+	if cabinet != nil && cabinet.ControlCabinetNr != nil {
+		values[NamingTokenControlCabinetNr] = strings.TrimSpace(*cabinet.ControlCabinetNr)
+	}
+	if gaDevice != nil {
+		values[NamingTokenGADevice] = strings.ToUpper(strings.TrimSpace(*gaDevice))
+	}
+	return values
+}
+
+// ObjectNamingValues extends controller values with the tokens of a field
+// device and one of its BACnet objects. The apparat number only has a value
+// next to a system part or apparat short name; the device code joins the
+// three and is set whenever one of the short names is.
+func ObjectNamingValues(controller NamingValues, device FieldDevice, softwareAddress string) NamingValues {
+	values := make(NamingValues, len(controller)+6)
+	for token, value := range controller {
+		values[token] = value
+	}
+	if device.SPSControllerSystemType.Number != nil {
+		values[NamingTokenSystemTypeNumber] = fmt.Sprintf("%04d", *device.SPSControllerSystemType.Number)
+	}
+	values[NamingTokenSystemPart] = device.SystemPart.ShortName
+	values[NamingTokenApparat] = device.Apparat.ShortName
+	if device.SystemPart.ShortName != "" || device.Apparat.ShortName != "" {
+		values[NamingTokenApparatNr] = fmt.Sprintf("%02d", device.ApparatNr)
+		values[NamingTokenDeviceCode] = device.SystemPart.ShortName + device.Apparat.ShortName + values[NamingTokenApparatNr]
+	}
+	values[NamingTokenSoftwareAddress] = softwareAddress
+	return values
+}
+
+// SoftwareAddress is the object type and number as they appear in names,
+// e.g. "AI01".
+func (b BacnetObject) SoftwareAddress() string {
+	key := strings.ToUpper(string(b.SoftwareType))
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%02d", key, b.SoftwareNumber)
+}
+
+type namingSegment struct {
+	text     string
+	token    NamingToken
+	optional []namingSegment
+}
+
+// parseNamingPattern splits a pattern into literals, tokens and optional
+// sections. Only the given tokens are accepted; sections do not nest.
+func parseNamingPattern(pattern string, allowed []NamingToken) ([]namingSegment, error) {
+	known := make(map[NamingToken]struct{}, len(allowed))
+	for _, token := range allowed {
+		known[token] = struct{}{}
+	}
+	var (
+		segments []namingSegment
+		section  *[]namingSegment
+		literal  strings.Builder
+	)
+	target := func() *[]namingSegment {
+		if section != nil {
+			return section
+		}
+		return &segments
+	}
+	flush := func() {
+		if literal.Len() > 0 {
+			*target() = append(*target(), namingSegment{text: literal.String()})
+			literal.Reset()
+		}
+	}
+	for index := 0; index < len(pattern); index++ {
+		switch pattern[index] {
+		case '{':
+			end := strings.IndexByte(pattern[index:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed token at position %d", index+1)
+			}
+			token := NamingToken(strings.TrimSpace(pattern[index+1 : index+end]))
+			if _, ok := known[token]; !ok {
+				return nil, fmt.Errorf("unknown token {%s}", token)
+			}
+			flush()
+			*target() = append(*target(), namingSegment{token: token})
+			index += end
+		case '}':
+			return nil, fmt.Errorf("unexpected } at position %d", index+1)
+		case '[':
+			if section != nil {
+				return nil, fmt.Errorf("optional sections cannot be nested (position %d)", index+1)
+			}
+			flush()
+			section = &[]namingSegment{}
+		case ']':
+			if section == nil {
+				return nil, fmt.Errorf("unexpected ] at position %d", index+1)
+			}
+			flush()
+			if !containsToken(*section) {
+				return nil, fmt.Errorf("optional section ending at position %d has no token", index+1)
+			}
+			segments = append(segments, namingSegment{optional: *section})
+			section = nil
+		default:
+			literal.WriteByte(pattern[index])
+		}
+	}
+	if section != nil {
+		return nil, fmt.Errorf("unclosed optional section")
+	}
+	flush()
+	if !containsToken(segments) {
+		return nil, fmt.Errorf("pattern has no token")
+	}
+	return segments, nil
+}
+
+func containsToken(segments []namingSegment) bool {
+	for _, segment := range segments {
+		if segment.token != "" || containsToken(segment.optional) {
+			return true
+		}
+	}
+	return false
+}
+
+// Render builds the name for values. It fails when a token outside an
+// optional section has no value or the pattern itself is invalid.
+func (p NamingPattern) Render(values NamingValues) (string, bool) {
+	segments, err := parseNamingPattern(p.Pattern, ObjectNamingTokens)
+	if err != nil {
+		return "", false
+	}
+	var name strings.Builder
+	for _, segment := range segments {
+		switch {
+		case segment.token != "":
+			value := values[segment.token]
+			if value == "" {
+				return "", false
+			}
+			name.WriteString(value)
+		case segment.optional != nil:
+			if part, ok := renderNamingSection(segment.optional, values); ok {
+				name.WriteString(part)
+			}
+		default:
+			name.WriteString(segment.text)
+		}
+	}
+	if p.Uppercase {
+		return strings.ToUpper(name.String()), true
+	}
+	return name.String(), true
+}
+
+// RenderPartial builds the name from the tokens that have values. A token
+// without a value is left out with the literal that joins it to the name:
+// the one before it, or the one after it when it leads the name. Field device
+// lines use it, since they have no software address.
+func (p NamingPattern) RenderPartial(values NamingValues) string {
+	segments, err := parseNamingPattern(p.Pattern, ObjectNamingTokens)
+	if err != nil {
+		return ""
+	}
+	var name strings.Builder
+	pending, written, dropNext := "", false, false
+	for _, segment := range segments {
+		var part string
+		switch {
+		case segment.token != "":
+			if part = values[segment.token]; part == "" {
+				if written {
+					pending = ""
+				} else {
+					dropNext = true
+				}
+				continue
+			}
+		case segment.optional != nil:
+			if part, _ = renderNamingSection(segment.optional, values); part == "" {
+				continue
+			}
+		default:
+			if !dropNext {
+				pending += segment.text
+			}
+			dropNext = false
+			continue
+		}
+		name.WriteString(pending)
+		name.WriteString(part)
+		pending, written, dropNext = "", true, false
+	}
+	name.WriteString(pending)
+	if p.Uppercase {
+		return strings.ToUpper(name.String())
+	}
+	return name.String()
+}
+
+func renderNamingSection(segments []namingSegment, values NamingValues) (string, bool) {
+	var part strings.Builder
+	for _, segment := range segments {
+		if segment.token == "" {
+			part.WriteString(segment.text)
+			continue
+		}
+		value := values[segment.token]
+		if value == "" {
+			return "", false
+		}
+		part.WriteString(value)
+	}
+	return part.String(), true
+}
+
+// Fits reports whether name respects the length limit of the pattern.
+func (p NamingPattern) Fits(name string) bool {
+	return p.MaxLength <= 0 || utf8.RuneCountInString(name) <= p.MaxLength
+}
+
+func (p NamingPattern) validate(validation *domain.ValidationError, path string, allowed []NamingToken, required NamingToken) {
+	if p.MaxLength < 0 || p.MaxLength > MaxNamingLength {
+		validation.AddCode(path+".max_length", "range", fmt.Sprintf("max_length must be between 0 and %d", MaxNamingLength))
+	}
+	pattern := strings.TrimSpace(p.Pattern)
+	if pattern == "" {
+		validation.AddCode(path+".pattern", "required", "pattern is required")
+		return
+	}
+	if len(pattern) > 500 {
+		validation.AddCode(path+".pattern", "max", "pattern must be at most 500 characters")
+		return
+	}
+	segments, err := parseNamingPattern(pattern, allowed)
+	if err != nil {
+		validation.AddCode(path+".pattern", "pattern", err.Error())
+		return
+	}
+	if !usesNamingToken(segments, required) {
+		validation.AddCode(path+".pattern", "token", fmt.Sprintf("pattern must contain {%s} to keep names unique", required))
+	}
+}
+
+func usesNamingToken(segments []namingSegment, token NamingToken) bool {
+	for _, segment := range segments {
+		if segment.token == token || usesNamingToken(segment.optional, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks both patterns. Controller names must contain the GA
+// device and object names the software address, the parts that keep names
+// unique within a cabinet and a controller.
+func (s NamingScheme) Validate() error {
+	validation := domain.NewValidationError()
+	requireString(validation, "namingscheme.name", s.Name, 150)
+	if len(s.Client) > 150 {
+		validation.AddCode("namingscheme.client", "max", "client must be at most 150 characters")
+	}
+	s.ControllerName.validate(validation, "namingscheme.controller_name", ControllerNamingTokens, NamingTokenGADevice)
+	s.ObjectName.validate(validation, "namingscheme.object_name", ObjectNamingTokens, NamingTokenSoftwareAddress)
+	return validationResult(validation)
+}
+
+// NamingIssueKind classifies a generated name that breaks a scheme rule.
+type NamingIssueKind string
+
+const (
+	NamingIssueTooLong    NamingIssueKind = "too_long"
+	NamingIssueDuplicate  NamingIssueKind = "duplicate"
+	NamingIssueUnresolved NamingIssueKind = "unresolved"
+)
+
+type NamingIssue struct {
+	Kind     NamingIssueKind
+	Name     string
+	ObjectID *uuid.UUID
+}
+
+// NamingPreviewObject is the generated name of one BACnet object.
+type NamingPreviewObject struct {
+	BacnetObjectID uuid.UUID
+	FieldDeviceID  uuid.UUID
+	Name           string
+}
+
+// NamingPreview shows the names a scheme generates for one SPS controller
+// together with the names that break its length or uniqueness rules.
+type NamingPreview struct {
+	Scheme          NamingScheme
+	SPSControllerID uuid.UUID
+	ControllerName  string
+	Objects         []NamingPreviewObject
+	Issues          []NamingIssue
+}
@@ -0,0 +1,149 @@
+package facility
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+)
+
+func namingTestDevice() FieldDevice {
+	number := 12
+	return FieldDevice{
+		ApparatNr:               3,
+		SPSControllerSystemType: SPSControllerSystemType{Number: &number},
+		SystemPart:              SystemPart{ShortName: "ZUL"},
+		Apparat:                 Apparat{ShortName: "T"},
+	}
+}
+
+func TestDefaultNamingSchemeReproducesLegacyNames(t *testing.T) {
+	scheme := DefaultNamingScheme()
+	building := &Building{IWSCode: "abc1", BuildingGroup: 2}
+	ga := "aab"
+
+	controller, ok := scheme.ControllerName.Render(ControllerNamingValues(building, &ControlCabinet{ControlCabinetNr: stringPtr("01")}, &ga))
+	if !ok || controller != "ABC1_01_AAB" {
+		t.Fatalf("controller name = %q, %v; want ABC1_01_AAB", controller, ok)
+	}
+	values := ControllerNamingValues(building, &ControlCabinet{}, &ga)
+	controller, ok = scheme.ControllerName.Render(values)
+	if !ok || controller != "AAB" {
+		t.Fatalf("controller name without cabinet nr = %q, %v; want AAB", controller, ok)
+	}
+	if _, ok := scheme.ControllerName.Render(ControllerNamingValues(building, nil, nil)); ok {
+		t.Fatal("controller name without GA device must not resolve")
+	}
+
+	object := BacnetObject{SoftwareType: "ai", SoftwareNumber: 1}
+	name, ok := scheme.ObjectName.Render(ObjectNamingValues(values, namingTestDevice(), object.SoftwareAddress()))
+	if !ok || name != "abc1_2_0012_AAB_ZULT03_AI01" {
+		t.Fatalf("object name = %q, %v; want abc1_2_0012_AAB_ZULT03_AI01", name, ok)
+	}
+	name, ok = scheme.ObjectName.Render(ObjectNamingValues(NamingValues{NamingTokenBuildingGroup: "2"}, FieldDevice{}, ""))
+	if !ok || name != "2" {
+		t.Fatalf("object name without optional parts = %q, %v; want 2", name, ok)
+	}
+}
+
+// legacyBacnetObjectName is the object name builder of the exports before
+// naming schemes existed.
+func legacyBacnetObjectName(iwsCode string, buildingGroup int, gaDevice string, device FieldDevice, suffix string) string {
+	sysTypeNr := ""
+	if device.SPSControllerSystemType.Number != nil {
+		sysTypeNr = fmt.Sprintf("%04d", *device.SPSControllerSystemType.Number)
+	}
+	devicePart := ""
+	if device.SystemPart.ShortName != "" || device.Apparat.ShortName != "" {
+		devicePart = device.SystemPart.ShortName + device.Apparat.ShortName + fmt.Sprintf("%02d", device.ApparatNr)
+	}
+	var parts []string
+	for _, part := range []string{iwsCode, fmt.Sprintf("%d", buildingGroup), sysTypeNr, gaDevice, devicePart, suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+func TestDefaultObjectNameMatchesLegacyBuilder(t *testing.T) {
+	pattern := DefaultNamingScheme().ObjectName
+	number := 7
+	devices := map[string]FieldDevice{
+		"both short names": {ApparatNr: 4, SPSControllerSystemType: SPSControllerSystemType{Number: &number}, SystemPart: SystemPart{ShortName: "ZUL"}, Apparat: Apparat{ShortName: "T"}},
+		"system part only": {ApparatNr: 4, SystemPart: SystemPart{ShortName: "ZUL"}},
+		"apparat only":     {ApparatNr: 12, SPSControllerSystemType: SPSControllerSystemType{Number: &number}, Apparat: Apparat{ShortName: "T"}},
+		"no short names":   {ApparatNr: 5},
+	}
+	for name, device := range devices {
+		for _, iwsCode := range []string{"", "ABC1"} {
+			for _, suffix := range []string{"", "AI01"} {
+				building := &Building{IWSCode: iwsCode, BuildingGroup: 3}
+				ga := "AAB"
+				values := ObjectNamingValues(ControllerNamingValues(building, nil, &ga), device, suffix)
+				got, ok := pattern.Render(values)
+				want := legacyBacnetObjectName(iwsCode, 3, ga, device, suffix)
+				if !ok || got != want {
+					t.Errorf("%s (iws %q, suffix %q): name = %q, %v; want %q", name, iwsCode, suffix, got, ok, want)
+				}
+			}
+		}
+	}
+}
+
+func TestNamingPatternRendersClientTemplate(t *testing.T) {
+	pattern := NamingPattern{Pattern: "{building_group}-{ga_device}.{software_address}[/{apparat_nr}]", MaxLength: 10, Uppercase: true}
+	values := NamingValues{NamingTokenBuildingGroup: "7", NamingTokenGADevice: "aac", NamingTokenSoftwareAddress: "BI02"}
+
+	name, ok := pattern.Render(values)
+	if !ok || name != "7-AAC.BI02" {
+		t.Fatalf("name = %q, %v; want 7-AAC.BI02", name, ok)
+	}
+	if !pattern.Fits(name) {
+		t.Fatalf("%q must fit into %d characters", name, pattern.MaxLength)
+	}
+	values[NamingTokenApparatNr] = "01"
+	if name, _ := pattern.Render(values); pattern.Fits(name) {
+		t.Fatalf("%q must exceed %d characters", name, pattern.MaxLength)
+	}
+}
+
+func TestNamingPatternRenderPartialLeavesOutTokensWithoutValue(t *testing.T) {
+	values := NamingValues{NamingTokenBuildingGroup: "7", NamingTokenGADevice: "AAC"}
+	for _, tc := range []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "{building_group}-{ga_device}.{software_address}[/{apparat_nr}]", want: "7-AAC"},
+		{pattern: "{software_address}_{ga_device}", want: "AAC"},
+		{pattern: "X-{iws_code}_{ga_device}_{software_address}", want: "X-AAC"},
+		{pattern: "{ga_device}[_{iws_code}]_{building_group}", want: "AAC_7"},
+	} {
+		if name := (NamingPattern{Pattern: tc.pattern}).RenderPartial(values); name != tc.want {
+			t.Fatalf("%s renders %q, want %q", tc.pattern, name, tc.want)
+		}
+	}
+}
+
+func TestNamingSchemeValidateRejectsBrokenPatterns(t *testing.T) {
+	scheme := DefaultNamingScheme()
+	scheme.ControllerName.Pattern = "{iws_code}_{software_address}"
+	scheme.ObjectName.Pattern = "{building_group}_{unknown}"
+	scheme.ObjectName.MaxLength = MaxNamingLength + 1
+
+	err := scheme.Validate()
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("Validate() = %v, want validation error", err)
+	}
+	for _, field := range []string{"namingscheme.controller_name.pattern", "namingscheme.object_name.pattern", "namingscheme.object_name.max_length"} {
+		if ve.Fields[field] == "" {
+			t.Fatalf("missing validation error for %s in %v", field, ve.Fields)
+		}
+	}
+	if err := DefaultNamingScheme().Validate(); err != nil {
+		t.Fatalf("default scheme must be valid: %v", err)
+	}
+}
@@ -17,6 +17,11 @@ type ObjectData struct {
 	// many-to-many association.
 	BacnetObjects []*BacnetObject `gorm:"-:all"`
 	Apparats      []*Apparat      `gorm:"many2many:object_data_apparats;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	// ForkedFromID links a project template to the global template it was
+	// forked from; ForkedFromRevision is the origin revision it is based on.
+	ForkedFromID       *uuid.UUID `gorm:"type:uuid;index"`
+	ForkedFromRevision *uint64
 }
 
 type ObjectDataTemplateCreate struct {
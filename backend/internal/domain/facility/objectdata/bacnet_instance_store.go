@@ -0,0 +1,23 @@
+package objectdata
+
+import (
+	"context"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// BacnetInstanceSlot is one BACnet object instance in use on an SPS
+// controller together with the owners numbering strategies group by.
+type BacnetInstanceSlot struct {
+	BacnetObjectID uuid.UUID
+	FieldDeviceID  uuid.UUID
+	SystemTypeID   uuid.UUID
+	SoftwareType   domainFacility.BacnetSoftwareType
+	SoftwareNumber uint16
+}
+
+// BacnetInstanceStore lists the instances already taken on a controller.
+type BacnetInstanceStore interface {
+	ListBySPSControllerID(ctx context.Context, spsControllerID uuid.UUID) ([]BacnetInstanceSlot, error)
+}
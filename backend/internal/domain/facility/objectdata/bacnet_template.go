@@ -29,6 +29,7 @@ type BacnetObjectTemplate struct {
 	StateTextID         *uuid.UUID
 	NotificationClassID *uuid.UUID
 	AlarmTypeID         *uuid.UUID
+	AlarmDefinitionID   *uuid.UUID
 	AlarmValues         []BacnetObjectTemplateAlarmValue
 }
 
@@ -0,0 +1,165 @@
+package objectdata
+
+import (
+	"strconv"
+	"strings"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// TemplateDiff lists how the templates of two revisions differ.
+type TemplateDiff struct {
+	ObjectDataID uuid.UUID
+	FromRevision uint64
+	ToRevision   uint64
+	Added        []BacnetObjectTemplate
+	Removed      []BacnetObjectTemplate
+	Changed      []TemplateChange
+}
+
+// TemplateChange is one template present in both revisions whose inherited
+// fields differ.
+type TemplateChange struct {
+	Before BacnetObjectTemplate
+	After  BacnetObjectTemplate
+	Fields []string
+}
+
+// Empty reports whether both revisions have the same templates.
+func (d TemplateDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// TemplateField is one attribute a BACnet object inherits from its template.
+type TemplateField struct {
+	Name  string
+	Equal func(a, b BacnetObjectTemplate) bool
+	Copy  func(dst *BacnetObjectTemplate, src BacnetObjectTemplate)
+}
+
+// TemplateFieldTextIndividual is kept per device when templates are
+// propagated.
+const TemplateFieldTextIndividual = "text_individual"
+
+// TemplateFields lists the inherited attributes in display order. Software
+// references point at other templates and are resolved separately.
+var TemplateFields = []TemplateField{
+	valueField("text_fix", func(t *BacnetObjectTemplate) *string { return &t.TextFix }),
+	pointerField("description", func(t *BacnetObjectTemplate) **string { return &t.Description }),
+	valueField("gms_visible", func(t *BacnetObjectTemplate) *bool { return &t.GMSVisible }),
+	valueField("optional", func(t *BacnetObjectTemplate) *bool { return &t.Optional }),
+	pointerField(TemplateFieldTextIndividual, func(t *BacnetObjectTemplate) **string { return &t.TextIndividual }),
+	valueField("software_type", func(t *BacnetObjectTemplate) *domainFacility.BacnetSoftwareType { return &t.SoftwareType }),
+	valueField("software_number", func(t *BacnetObjectTemplate) *uint16 { return &t.SoftwareNumber }),
+	valueField("hardware_type", func(t *BacnetObjectTemplate) *domainFacility.BacnetHardwareType { return &t.HardwareType }),
+	valueField("hardware_quantity", func(t *BacnetObjectTemplate) *uint8 { return &t.HardwareQuantity }),
+	pointerField("state_text_id", func(t *BacnetObjectTemplate) **uuid.UUID { return &t.StateTextID }),
+	pointerField("notification_class_id", func(t *BacnetObjectTemplate) **uuid.UUID { return &t.NotificationClassID }),
+	pointerField("alarm_type_id", func(t *BacnetObjectTemplate) **uuid.UUID { return &t.AlarmTypeID }),
+	pointerField("alarm_definition_id", func(t *BacnetObjectTemplate) **uuid.UUID { return &t.AlarmDefinitionID }),
+}
+
+func valueField[T comparable](name string, field func(*BacnetObjectTemplate) *T) TemplateField {
+	return TemplateField{
+		Name:  name,
+		Equal: func(a, b BacnetObjectTemplate) bool { return *field(&a) == *field(&b) },
+		Copy:  func(dst *BacnetObjectTemplate, src BacnetObjectTemplate) { *field(dst) = *field(&src) },
+	}
+}
+
+func pointerField[T comparable](name string, field func(*BacnetObjectTemplate) **T) TemplateField {
+	return TemplateField{
+		Name: name,
+		Equal: func(a, b BacnetObjectTemplate) bool {
+			left, right := *field(&a), *field(&b)
+			if left == nil || right == nil {
+				return left == right
+			}
+			return *left == *right
+		},
+		Copy: func(dst *BacnetObjectTemplate, src BacnetObjectTemplate) { *field(dst) = *field(&src) },
+	}
+}
+
+// DiffTemplates compares two template sets, see MatchTemplates for how
+// templates are paired.
+func DiffTemplates(from, to []BacnetObjectTemplate) TemplateDiff {
+	matches := MatchTemplates(from, to)
+	matchedFrom := make(map[uuid.UUID]struct{}, len(matches))
+	diff := TemplateDiff{
+		Added: []BacnetObjectTemplate{}, Removed: []BacnetObjectTemplate{}, Changed: []TemplateChange{},
+	}
+	fromByID := make(map[uuid.UUID]BacnetObjectTemplate, len(from))
+	for _, template := range from {
+		fromByID[template.ID] = template
+	}
+	for _, template := range to {
+		fromID, ok := matches[template.ID]
+		if !ok {
+			diff.Added = append(diff.Added, template)
+			continue
+		}
+		matchedFrom[fromID] = struct{}{}
+		if fields := ChangedTemplateFields(fromByID[fromID], template); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, TemplateChange{Before: fromByID[fromID], After: template, Fields: fields})
+		}
+	}
+	for _, template := range from {
+		if _, ok := matchedFrom[template.ID]; !ok {
+			diff.Removed = append(diff.Removed, template)
+		}
+	}
+	return diff
+}
+
+// MatchTemplates pairs every template of to with one of from and returns
+// to.ID -> from.ID. Templates pair by ID first; templates rewritten with new
+// IDs pair by software type and number, which are unique per ObjectData.
+func MatchTemplates(from, to []BacnetObjectTemplate) map[uuid.UUID]uuid.UUID {
+	matches := make(map[uuid.UUID]uuid.UUID, len(to))
+	used := make(map[uuid.UUID]struct{}, len(from))
+	byID := make(map[uuid.UUID]struct{}, len(from))
+	for _, template := range from {
+		byID[template.ID] = struct{}{}
+	}
+	for _, template := range to {
+		if _, ok := byID[template.ID]; ok {
+			matches[template.ID] = template.ID
+			used[template.ID] = struct{}{}
+		}
+	}
+	bySoftware := make(map[string]uuid.UUID, len(from))
+	for _, template := range from {
+		if _, ok := used[template.ID]; !ok {
+			bySoftware[softwareKey(template.SoftwareType, template.SoftwareNumber)] = template.ID
+		}
+	}
+	for _, template := range to {
+		if _, ok := matches[template.ID]; ok {
+			continue
+		}
+		key := softwareKey(template.SoftwareType, template.SoftwareNumber)
+		if fromID, ok := bySoftware[key]; ok {
+			matches[template.ID] = fromID
+			delete(bySoftware, key)
+		}
+	}
+	return matches
+}
+
+// ChangedTemplateFields returns the names of the inherited fields that
+// differ between a and b.
+func ChangedTemplateFields(a, b BacnetObjectTemplate) []string {
+	var fields []string
+	for _, field := range TemplateFields {
+		if !field.Equal(a, b) {
+			fields = append(fields, field.Name)
+		}
+	}
+	return fields
+}
+
+func softwareKey(softwareType domainFacility.BacnetSoftwareType, number uint16) string {
+	return strings.ToLower(string(softwareType)) + ":" + strconv.FormatUint(uint64(number), 10)
+}
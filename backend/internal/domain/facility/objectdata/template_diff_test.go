@@ -0,0 +1,75 @@
+package objectdata
+
+import (
+	"testing"
+	"time"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func diffTemplate(textFix string, number uint16) BacnetObjectTemplate {
+	return BacnetObjectTemplate{
+		ID: uuid.New(), TextFix: textFix, SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: number,
+	}
+}
+
+func TestMatchTemplatesPairsByIDThenSoftware(t *testing.T) {
+	same, renumbered, dropped := diffTemplate("Temperature", 1), diffTemplate("Setpoint", 2), diffTemplate("Alarm", 3)
+	rewritten := renumbered
+	rewritten.ID = uuid.New()
+	added := diffTemplate("Humidity", 4)
+
+	matches := MatchTemplates([]BacnetObjectTemplate{same, renumbered, dropped}, []BacnetObjectTemplate{same, rewritten, added})
+	if matches[same.ID] != same.ID {
+		t.Fatalf("match for %s = %s, want same ID", same.ID, matches[same.ID])
+	}
+	if matches[rewritten.ID] != renumbered.ID {
+		t.Fatalf("match for rewritten = %s, want %s by software key", matches[rewritten.ID], renumbered.ID)
+	}
+	if _, ok := matches[added.ID]; ok || len(matches) != 2 {
+		t.Fatalf("matches = %v, want the added template unmatched", matches)
+	}
+}
+
+func TestDiffTemplates(t *testing.T) {
+	kept, removed := diffTemplate("Temperature", 1), diffTemplate("Alarm", 2)
+	changed := kept
+	changed.TextFix, changed.GMSVisible = "Temperature actual", true
+	changed.UpdatedAt = time.Now()
+	added := diffTemplate("Setpoint", 3)
+
+	diff := DiffTemplates([]BacnetObjectTemplate{kept, removed}, []BacnetObjectTemplate{changed, added})
+	if len(diff.Added) != 1 || diff.Added[0].ID != added.ID {
+		t.Fatalf("added = %v, want %s", diff.Added, added.ID)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != removed.ID {
+		t.Fatalf("removed = %v, want %s", diff.Removed, removed.ID)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("changed = %v, want one change", diff.Changed)
+	}
+	if fields := diff.Changed[0].Fields; len(fields) != 2 || fields[0] != "text_fix" || fields[1] != "gms_visible" {
+		t.Fatalf("changed fields = %v, want text_fix and gms_visible", fields)
+	}
+	if diff.Empty() {
+		t.Fatal("Empty() = true for a diff with changes")
+	}
+	if !DiffTemplates([]BacnetObjectTemplate{kept}, []BacnetObjectTemplate{kept}).Empty() {
+		t.Fatal("diff of identical templates is not empty")
+	}
+}
+
+func TestTemplateFingerprintIgnoresBookkeeping(t *testing.T) {
+	template := diffTemplate("Temperature", 1)
+	touched := template
+	touched.Version, touched.UpdatedAt = 7, time.Now()
+
+	if TemplateFingerprint([]BacnetObjectTemplate{template}) != TemplateFingerprint([]BacnetObjectTemplate{touched}) {
+		t.Fatal("fingerprint changed with version and timestamps only")
+	}
+	touched.TextFix = "Temperature actual"
+	if TemplateFingerprint([]BacnetObjectTemplate{template}) == TemplateFingerprint([]BacnetObjectTemplate{touched}) {
+		t.Fatal("fingerprint did not change with text_fix")
+	}
+}
@@ -0,0 +1,230 @@
+package objectdata
+
+import "github.com/google/uuid"
+
+// TemplateConflict is a template both sides changed in incompatible ways.
+// Ours or Theirs is nil when that side removed the template.
+type TemplateConflict struct {
+	Base   BacnetObjectTemplate
+	Ours   *BacnetObjectTemplate
+	Theirs *BacnetObjectTemplate
+	Fields []string
+}
+
+// TemplateThreeWayDiff compares a project fork and its global origin with the
+// origin revision the fork was taken from.
+type TemplateThreeWayDiff struct {
+	ObjectDataID   uuid.UUID
+	OriginID       uuid.UUID
+	BaseRevision   uint64
+	OriginRevision uint64
+	// Ours lists the fork changes, Theirs the origin changes since the fork.
+	Ours      TemplateDiff
+	Theirs    TemplateDiff
+	Conflicts []TemplateConflict
+}
+
+// OriginMoved reports whether the origin changed since the fork was taken.
+func (d TemplateThreeWayDiff) OriginMoved() bool {
+	return d.OriginRevision != d.BaseRevision
+}
+
+// DiffTemplatesThreeWay compares ours and theirs against their common base.
+func DiffTemplatesThreeWay(base, ours, theirs []BacnetObjectTemplate) TemplateThreeWayDiff {
+	_, conflicts := MergeTemplates(base, ours, theirs)
+	return TemplateThreeWayDiff{
+		Ours:      DiffTemplates(base, ours),
+		Theirs:    DiffTemplates(base, theirs),
+		Conflicts: conflicts,
+	}
+}
+
+// MergeTemplates replays the changes ours made to base on top of theirs.
+// Templates of theirs keep their IDs; templates only ours added keep the IDs
+// of ours. A field both sides changed to different values, a template one
+// side changed and the other removed, and a software slot both sides added
+// differently are conflicts and keep the value of theirs.
+//
+// Alarm values follow the alarm binding: they are taken from ours when ours
+// changed the alarm type or definition.
+func MergeTemplates(base, ours, theirs []BacnetObjectTemplate) ([]BacnetObjectTemplate, []TemplateConflict) {
+	merge := newTemplateMerge(base, ours, theirs)
+	merged := make([]BacnetObjectTemplate, 0, len(theirs)+len(ours))
+	conflicts := []TemplateConflict{}
+
+	for _, template := range theirs {
+		baseID, ok := merge.theirsToBase[template.ID]
+		if !ok {
+			merged = append(merged, template)
+			continue
+		}
+		ourTemplate, kept := merge.baseToOurs[baseID]
+		baseTemplate := merge.base[baseID]
+		if !kept {
+			if fields := ChangedTemplateFields(baseTemplate, template); len(fields) > 0 {
+				theirTemplate := template
+				conflicts = append(conflicts, TemplateConflict{Base: baseTemplate, Theirs: &theirTemplate, Fields: fields})
+				merged = append(merged, template)
+			}
+			continue
+		}
+		result, fields := mergeTemplateFields(baseTemplate, ourTemplate, template)
+		if len(fields) > 0 {
+			ourCopy, theirCopy := ourTemplate, template
+			conflicts = append(conflicts, TemplateConflict{Base: baseTemplate, Ours: &ourCopy, Theirs: &theirCopy, Fields: fields})
+		}
+		merged = append(merged, result)
+	}
+
+	for _, baseTemplate := range base {
+		if _, kept := merge.baseToTheirs[baseTemplate.ID]; kept {
+			continue
+		}
+		ourTemplate, kept := merge.baseToOurs[baseTemplate.ID]
+		if !kept {
+			continue
+		}
+		if fields := ChangedTemplateFields(baseTemplate, ourTemplate); len(fields) > 0 {
+			ourCopy := ourTemplate
+			conflicts = append(conflicts, TemplateConflict{Base: baseTemplate, Ours: &ourCopy, Fields: fields})
+		}
+	}
+
+	slots := make(map[string]int, len(merged))
+	for i, template := range merged {
+		slots[softwareKey(template.SoftwareType, template.SoftwareNumber)] = i
+	}
+	for _, template := range ours {
+		if _, matched := merge.oursToBase[template.ID]; matched {
+			continue
+		}
+		if index, taken := slots[softwareKey(template.SoftwareType, template.SoftwareNumber)]; taken {
+			if fields := ChangedTemplateFields(merged[index], template); len(fields) > 0 {
+				ourCopy, theirCopy := template, merged[index]
+				conflicts = append(conflicts, TemplateConflict{Ours: &ourCopy, Theirs: &theirCopy, Fields: fields})
+			}
+			continue
+		}
+		merged = append(merged, template)
+	}
+
+	merge.resolveReferences(merged)
+	return merged, conflicts
+}
+
+type templateMerge struct {
+	base         map[uuid.UUID]BacnetObjectTemplate
+	ours         map[uuid.UUID]BacnetObjectTemplate
+	oursToBase   map[uuid.UUID]uuid.UUID
+	theirsToBase map[uuid.UUID]uuid.UUID
+	baseToOurs   map[uuid.UUID]BacnetObjectTemplate
+	baseToTheirs map[uuid.UUID]uuid.UUID
+}
+
+func newTemplateMerge(base, ours, theirs []BacnetObjectTemplate) templateMerge {
+	merge := templateMerge{
+		base:         make(map[uuid.UUID]BacnetObjectTemplate, len(base)),
+		ours:         make(map[uuid.UUID]BacnetObjectTemplate, len(ours)),
+		oursToBase:   MatchTemplates(base, ours),
+		theirsToBase: MatchTemplates(base, theirs),
+		baseToOurs:   make(map[uuid.UUID]BacnetObjectTemplate, len(ours)),
+		baseToTheirs: make(map[uuid.UUID]uuid.UUID, len(theirs)),
+	}
+	for _, template := range base {
+		merge.base[template.ID] = template
+	}
+	for _, template := range ours {
+		merge.ours[template.ID] = template
+		if baseID, ok := merge.oursToBase[template.ID]; ok {
+			merge.baseToOurs[baseID] = template
+		}
+	}
+	for theirsID, baseID := range merge.theirsToBase {
+		merge.baseToTheirs[baseID] = theirsID
+	}
+	return merge
+}
+
+func mergeTemplateFields(base, ours, theirs BacnetObjectTemplate) (BacnetObjectTemplate, []string) {
+	result := theirs
+	var conflicts []string
+	alarmsFromOurs := false
+	for _, field := range TemplateFields {
+		if field.Equal(base, ours) {
+			continue
+		}
+		if !field.Equal(base, theirs) && !field.Equal(ours, theirs) {
+			conflicts = append(conflicts, field.Name)
+			continue
+		}
+		field.Copy(&result, ours)
+		if field.Name == "alarm_type_id" || field.Name == "alarm_definition_id" {
+			alarmsFromOurs = true
+		}
+	}
+	if alarmsFromOurs {
+		result.AlarmValues = ours.AlarmValues
+	}
+	return result, conflicts
+}
+
+// resolveReferences points software references at merged templates. A
+// matched template follows the reference of ours only when ours changed it.
+func (m templateMerge) resolveReferences(merged []BacnetObjectTemplate) {
+	present := make(map[uuid.UUID]struct{}, len(merged))
+	for _, template := range merged {
+		present[template.ID] = struct{}{}
+	}
+	for i := range merged {
+		template := &merged[i]
+		ourTemplate, fromOurs := m.ours[template.ID]
+		if baseID, matched := m.theirsToBase[template.ID]; matched {
+			ourTemplate, fromOurs = m.baseToOurs[baseID]
+			if fromOurs && sameTemplateRef(m.baseRef(ourTemplate.SoftwareReferenceID), m.base[baseID].SoftwareReferenceID) {
+				fromOurs = false
+			}
+		}
+		if fromOurs {
+			template.SoftwareReferenceID = m.mergedRef(ourTemplate.SoftwareReferenceID)
+		}
+		if template.SoftwareReferenceID != nil {
+			if _, ok := present[*template.SoftwareReferenceID]; !ok {
+				template.SoftwareReferenceID = nil
+			}
+		}
+	}
+}
+
+// baseRef translates a reference of ours into the ID space of base.
+func (m templateMerge) baseRef(ref *uuid.UUID) *uuid.UUID {
+	if ref == nil {
+		return nil
+	}
+	if baseID, ok := m.oursToBase[*ref]; ok {
+		return &baseID
+	}
+	return ref
+}
+
+// mergedRef translates a reference of ours into the ID space of the merge.
+func (m templateMerge) mergedRef(ref *uuid.UUID) *uuid.UUID {
+	if ref == nil {
+		return nil
+	}
+	baseID, ok := m.oursToBase[*ref]
+	if !ok {
+		return ref
+	}
+	theirsID, ok := m.baseToTheirs[baseID]
+	if !ok {
+		return nil
+	}
+	return &theirsID
+}
+
+func sameTemplateRef(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,79 @@
+package objectdata
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// forkTemplates copies templates under fresh IDs the way a project fork does.
+func forkTemplates(templates ...BacnetObjectTemplate) []BacnetObjectTemplate {
+	copies := make([]BacnetObjectTemplate, len(templates))
+	for i, template := range templates {
+		copies[i] = template
+		copies[i].ID = uuid.New()
+	}
+	return copies
+}
+
+func TestMergeTemplatesCombinesIndependentChanges(t *testing.T) {
+	temperature, setpoint := diffTemplate("Temperature", 1), diffTemplate("Setpoint", 2)
+	base := []BacnetObjectTemplate{temperature, setpoint}
+
+	ours := forkTemplates(temperature, setpoint)
+	ours[0].TextFix = "Temperature actual"
+	added := diffTemplate("Humidity", 3)
+	added.SoftwareReferenceID = &ours[0].ID
+	ours = append(ours, added)
+
+	theirs := []BacnetObjectTemplate{temperature, setpoint}
+	theirs[0].GMSVisible = true
+
+	merged, conflicts := MergeTemplates(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %v, want none", conflicts)
+	}
+	if len(merged) != 3 {
+		t.Fatalf("merged = %v, want three templates", merged)
+	}
+	if merged[0].ID != temperature.ID || merged[0].TextFix != "Temperature actual" || !merged[0].GMSVisible {
+		t.Fatalf("merged temperature = %+v, want both changes on the origin ID", merged[0])
+	}
+	if merged[2].ID != added.ID {
+		t.Fatalf("merged added ID = %s, want %s", merged[2].ID, added.ID)
+	}
+	if ref := merged[2].SoftwareReferenceID; ref == nil || *ref != temperature.ID {
+		t.Fatalf("added reference = %v, want origin template %s", ref, temperature.ID)
+	}
+}
+
+func TestMergeTemplatesReportsConflicts(t *testing.T) {
+	temperature, setpoint := diffTemplate("Temperature", 1), diffTemplate("Setpoint", 2)
+	base := []BacnetObjectTemplate{temperature, setpoint}
+
+	ours := forkTemplates(temperature, setpoint)
+	ours[0].TextFix = "Temperature ours"
+	ours[1].GMSVisible = true
+
+	theirs := []BacnetObjectTemplate{temperature}
+	theirs[0].TextFix = "Temperature theirs"
+
+	merged, conflicts := MergeTemplates(base, ours, theirs)
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %v, want a field and a removal conflict", conflicts)
+	}
+	if fields := conflicts[0].Fields; len(fields) != 1 || fields[0] != "text_fix" || conflicts[0].Ours == nil || conflicts[0].Theirs == nil {
+		t.Fatalf("field conflict = %+v, want text_fix on both sides", conflicts[0])
+	}
+	if conflicts[1].Base.ID != setpoint.ID || conflicts[1].Ours == nil || conflicts[1].Theirs != nil {
+		t.Fatalf("removal conflict = %+v, want setpoint removed by the origin", conflicts[1])
+	}
+	if len(merged) != 1 || merged[0].TextFix != "Temperature theirs" {
+		t.Fatalf("merged = %v, want the origin value kept", merged)
+	}
+
+	diff := DiffTemplatesThreeWay(base, ours, theirs)
+	if len(diff.Conflicts) != 2 || len(diff.Theirs.Removed) != 1 || len(diff.Ours.Changed) != 2 {
+		t.Fatalf("three-way diff = %+v, want both sides and their conflicts", diff)
+	}
+}
@@ -0,0 +1,76 @@
+package objectdata
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateRevision freezes the BACnet object templates of one ObjectData.
+// Field devices record the revision their BACnet objects were built from,
+// so later template changes can be diffed and propagated to them.
+type TemplateRevision struct {
+	ObjectDataID uuid.UUID
+	Revision     uint64
+	// Label is the free-text ObjectData version at the time of the revision.
+	Label       string
+	Fingerprint string
+	CreatedAt   time.Time
+	Templates   []BacnetObjectTemplate
+}
+
+// TemplateFieldDevice is a field device built from an ObjectData template.
+type TemplateFieldDevice struct {
+	FieldDeviceID uuid.UUID
+	Version       uint64
+	Revision      uint64
+}
+
+// TemplateRevisionStore persists template revisions. Create fails with
+// domain.ErrConflict when the revision number is already taken.
+type TemplateRevisionStore interface {
+	Create(ctx context.Context, revision *TemplateRevision) error
+	Get(ctx context.Context, objectDataID uuid.UUID, revision uint64) (*TemplateRevision, error)
+	Latest(ctx context.Context, objectDataID uuid.UUID) (*TemplateRevision, error)
+	// List returns the revisions newest first, without their templates.
+	List(ctx context.Context, objectDataID uuid.UUID) ([]TemplateRevision, error)
+	ListFieldDevices(ctx context.Context, objectDataID uuid.UUID) ([]TemplateFieldDevice, error)
+}
+
+// TemplateFingerprint hashes the content of templates. Timestamps and row
+// versions are ignored, so rewriting unchanged templates keeps the
+// fingerprint.
+func TemplateFingerprint(templates []BacnetObjectTemplate) string {
+	normalized := make([]BacnetObjectTemplate, len(templates))
+	for i, template := range templates {
+		template.CreatedAt, template.UpdatedAt, template.Version = time.Time{}, time.Time{}, 0
+		values := make([]BacnetObjectTemplateAlarmValue, len(template.AlarmValues))
+		for j, value := range template.AlarmValues {
+			value.ID, value.TemplateID = uuid.Nil, uuid.Nil
+			value.CreatedAt, value.UpdatedAt, value.Version = time.Time{}, time.Time{}, 0
+			values[j] = value
+		}
+		template.AlarmValues = values
+		normalized[i] = template
+	}
+	encoded, _ := json.Marshal(normalized)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// TemplateUpgrade summarises moving one field device to another revision.
+// Conflicts counts fields the device had overridden and therefore kept.
+type TemplateUpgrade struct {
+	FieldDeviceID uuid.UUID
+	Version       uint64
+	FromRevision  uint64
+	ToRevision    uint64
+	Added         int
+	Updated       int
+	Removed       int
+	Conflicts     int
+}
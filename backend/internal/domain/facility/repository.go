@@ -61,7 +61,10 @@ type BacnetReferenceUsageRepository interface {
 	CountByResource(ctx context.Context, resource BacnetReferenceResource, ids []uuid.UUID) (map[uuid.UUID]int64, error)
 }
 
-type UnitRepository = domain.Repository[Unit]
+type UnitRepository interface {
+	domain.Repository[Unit]
+	GetByCodes(ctx context.Context, codes []string) ([]Unit, error)
+}
 type AlarmFieldRepository = domain.Repository[AlarmField]
 type AlarmTypeRepository interface {
 	domain.Repository[AlarmType]
@@ -69,7 +72,11 @@ type AlarmTypeRepository interface {
 	ListWithFields(ctx context.Context, params domain.PaginationParams) (*domain.PaginatedList[AlarmType], error)
 }
 type AlarmTypeFieldRepository = domain.Repository[AlarmTypeField]
-type AlarmDefinitionFieldOverrideRepository = domain.Repository[AlarmDefinitionFieldOverride]
+type AlarmDefinitionFieldOverrideRepository interface {
+	domain.Repository[AlarmDefinitionFieldOverride]
+	ListByAlarmDefinitionIDs(ctx context.Context, alarmDefinitionIDs []uuid.UUID) ([]AlarmDefinitionFieldOverride, error)
+	DeleteAtVersion(ctx context.Context, id uuid.UUID, version uint64) error
+}
 type BacnetObjectAlarmValueRepository interface {
 	domain.Repository[BacnetObjectAlarmValue]
 	GetByBacnetObjectID(ctx context.Context, bacnetObjectID uuid.UUID) ([]BacnetObjectAlarmValue, error)
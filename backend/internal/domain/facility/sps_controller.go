@@ -17,6 +17,7 @@ type SPSController struct {
 	Subnet            *string
 	Gateway           *string
 	Vlan              *string `gorm:"uniqueIndex:idx_vlan_ip"`
+	MACAddress        *string
 
 	SPSControllerSystemTypes []SPSControllerSystemType `gorm:"foreignKey:SPSControllerID"`
 }
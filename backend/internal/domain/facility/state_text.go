@@ -1,9 +1,15 @@
 package facility
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 )
 
+// StateTextSlots is the number of state texts one StateText row holds.
+const StateTextSlots = 16
+
 type StateText struct {
 	domain.Base
 	RefNumber   int `gorm:"index;not null"`
@@ -24,3 +30,38 @@ type StateText struct {
 	StateText15 *string
 	StateText16 *string
 }
+
+// States returns the state texts in state order; index 0 is state 1.
+func (s StateText) States() [StateTextSlots]*string {
+	return [StateTextSlots]*string{
+		s.StateText1, s.StateText2, s.StateText3, s.StateText4,
+		s.StateText5, s.StateText6, s.StateText7, s.StateText8,
+		s.StateText9, s.StateText10, s.StateText11, s.StateText12,
+		s.StateText13, s.StateText14, s.StateText15, s.StateText16,
+	}
+}
+
+// StateTextField names state slot 1..16 in catalogs and translations.
+func StateTextField(state int) string {
+	return "state_text_" + strconv.Itoa(state)
+}
+
+// SetStates replaces all state texts; index 0 is state 1.
+func (s *StateText) SetStates(states [StateTextSlots]*string) {
+	s.StateText1, s.StateText2, s.StateText3, s.StateText4 = states[0], states[1], states[2], states[3]
+	s.StateText5, s.StateText6, s.StateText7, s.StateText8 = states[4], states[5], states[6], states[7]
+	s.StateText9, s.StateText10, s.StateText11, s.StateText12 = states[8], states[9], states[10], states[11]
+	s.StateText13, s.StateText14, s.StateText15, s.StateText16 = states[12], states[13], states[14], states[15]
+}
+
+// DefinedStates counts the non-blank texts from state 1 up to the first gap.
+// A multi-state object numbers its states from 1, so texts after a gap are
+// never shown.
+func (s StateText) DefinedStates() int {
+	for index, state := range s.States() {
+		if state == nil || strings.TrimSpace(*state) == "" {
+			return index
+		}
+	}
+	return StateTextSlots
+}
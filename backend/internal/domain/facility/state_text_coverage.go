@@ -0,0 +1,122 @@
+package facility
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MultiStateObject is the part of a multi-state BACnet object (mi, mo, mv)
+// the state text consistency check needs.
+type MultiStateObject struct {
+	ID             uuid.UUID
+	FieldDeviceID  *uuid.UUID
+	TextFix        string
+	SoftwareType   BacnetSoftwareType
+	SoftwareNumber uint16
+	StateTextID    *uuid.UUID
+	// StateMaps holds the JSON of the object's state_map alarm values.
+	StateMaps []string `gorm:"-"`
+}
+
+// MultiStateObjectRepository pages through the multi-state objects of active
+// field devices in ID order, starting after the given ID.
+type MultiStateObjectRepository interface {
+	ListMultiStateObjects(ctx context.Context, after uuid.UUID, limit int) ([]MultiStateObject, error)
+}
+
+const (
+	StateTextCoverageMissing = "missing_state_text"
+	StateTextCoverageTooFew  = "too_few_states"
+)
+
+// StateTextCoverageIssue flags a multi-state object whose state text cannot
+// name every state the object references.
+type StateTextCoverageIssue struct {
+	Code           string             `json:"code"`
+	BacnetObjectID uuid.UUID          `json:"bacnet_object_id"`
+	FieldDeviceID  *uuid.UUID         `json:"field_device_id,omitempty"`
+	TextFix        string             `json:"text_fix"`
+	SoftwareType   BacnetSoftwareType `json:"software_type"`
+	SoftwareNumber uint16             `json:"software_number"`
+	StateTextID    *uuid.UUID         `json:"state_text_id,omitempty"`
+	RefNumber      *int               `json:"ref_number,omitempty"`
+	RequiredStates int                `json:"required_states"`
+	DefinedStates  int                `json:"defined_states"`
+	MissingStates  []int              `json:"missing_states,omitempty"`
+}
+
+// IsMultiState reports whether objects of this type take their state names
+// from a state text.
+func (t BacnetSoftwareType) IsMultiState() bool {
+	return t == BacnetSoftwareTypeMI || t == BacnetSoftwareTypeMO || t == BacnetSoftwareTypeMV
+}
+
+// ReferencedStates returns the state numbers the object's state maps name, in
+// ascending order. A state map is a JSON object keyed by state number or a
+// JSON array whose entries stand for states 1, 2 and so on. Malformed maps
+// reference no states.
+func (o MultiStateObject) ReferencedStates() []int {
+	seen := make(map[int]bool)
+	for _, raw := range o.StateMaps {
+		var keyed map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(raw), &keyed); err == nil {
+			for key := range keyed {
+				if state, err := strconv.Atoi(key); err == nil && state > 0 {
+					seen[state] = true
+				}
+			}
+			continue
+		}
+		var listed []json.RawMessage
+		if err := json.Unmarshal([]byte(raw), &listed); err == nil {
+			for index, entry := range listed {
+				if string(entry) != "null" {
+					seen[index+1] = true
+				}
+			}
+		}
+	}
+	states := make([]int, 0, len(seen))
+	for state := range seen {
+		states = append(states, state)
+	}
+	slices.Sort(states)
+	return states
+}
+
+// CheckStateTextCoverage compares an object with its state text, which is nil
+// when the object has none or it no longer exists. The text has to name every
+// state the object's state maps reference.
+func CheckStateTextCoverage(object MultiStateObject, text *StateText) (StateTextCoverageIssue, bool) {
+	referenced := object.ReferencedStates()
+	issue := StateTextCoverageIssue{
+		Code: StateTextCoverageMissing, BacnetObjectID: object.ID, FieldDeviceID: object.FieldDeviceID,
+		TextFix: object.TextFix, SoftwareType: object.SoftwareType, SoftwareNumber: object.SoftwareNumber,
+		StateTextID: object.StateTextID,
+	}
+	if len(referenced) > 0 {
+		issue.RequiredStates = referenced[len(referenced)-1]
+	}
+	if text == nil {
+		return issue, true
+	}
+	refNumber := text.RefNumber
+	issue.RefNumber = &refNumber
+	issue.DefinedStates = text.DefinedStates()
+	states := text.States()
+	for _, state := range referenced {
+		if state > StateTextSlots || states[state-1] == nil || strings.TrimSpace(*states[state-1]) == "" {
+			issue.MissingStates = append(issue.MissingStates, state)
+		}
+	}
+	if len(issue.MissingStates) == 0 {
+		return StateTextCoverageIssue{}, false
+	}
+	issue.Code = StateTextCoverageTooFew
+	return issue, true
+}
@@ -0,0 +1,45 @@
+package facility
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiStateObjectReferencedStates(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		maps []string
+		want []int
+	}{
+		{name: "keyed by state number", maps: []string{`{"2":"Low","1":"Off","x":"?"}`}, want: []int{1, 2}},
+		{name: "listed in state order", maps: []string{`["Off",null,"High"]`}, want: []int{1, 3}},
+		{name: "merged across maps", maps: []string{`{"4":"Fault"}`, `["Off"]`}, want: []int{1, 4}},
+		{name: "malformed", maps: []string{`"Off"`}, want: []int{}},
+		{name: "none", want: []int{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MultiStateObject{StateMaps: tc.maps}.ReferencedStates()
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("ReferencedStates() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckStateTextCoverageComparesReferencedStates(t *testing.T) {
+	off, high := "Off", "High"
+	text := &StateText{StateText1: &off, StateText3: &high}
+	object := MultiStateObject{SoftwareType: BacnetSoftwareTypeMV, StateMaps: []string{`{"1":"Off","3":"High"}`}}
+	if issue, ok := CheckStateTextCoverage(object, text); ok {
+		t.Fatalf("issue = %+v; the text names every referenced state", issue)
+	}
+
+	object.StateMaps = append(object.StateMaps, `{"2":"Low","17":"Fault"}`)
+	issue, ok := CheckStateTextCoverage(object, text)
+	if !ok || issue.Code != StateTextCoverageTooFew || issue.RequiredStates != 17 || !slices.Equal(issue.MissingStates, []int{2, 17}) {
+		t.Fatalf("issue = %+v, %v; want states 2 and 17 missing", issue, ok)
+	}
+	if issue, ok := CheckStateTextCoverage(MultiStateObject{}, nil); !ok || issue.Code != StateTextCoverageMissing {
+		t.Fatalf("issue = %+v, %v; want a missing state text", issue, ok)
+	}
+}
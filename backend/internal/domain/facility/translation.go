@@ -0,0 +1,173 @@
+package facility
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// TranslationResource names the reference entities whose texts can be
+// translated.
+type TranslationResource string
+
+const (
+	TranslationResourceStateText    TranslationResource = "state_texts"
+	TranslationResourceBacnetObject TranslationResource = "bacnet_objects"
+	TranslationResourceApparat      TranslationResource = "apparats"
+	TranslationResourceSystemPart   TranslationResource = "system_parts"
+)
+
+// Translation is the text of one entity field in one locale. The stored
+// entity keeps its base text; translations never replace it.
+type Translation struct {
+	domain.Base
+	Resource TranslationResource `gorm:"size:32;not null;uniqueIndex:idx_facility_translation"`
+	EntityID uuid.UUID           `gorm:"type:uuid;not null;uniqueIndex:idx_facility_translation;index"`
+	Locale   string              `gorm:"size:16;not null;uniqueIndex:idx_facility_translation"`
+	Field    string              `gorm:"size:32;not null;uniqueIndex:idx_facility_translation"`
+	Text     string              `gorm:"type:text;not null"`
+}
+
+func (Translation) TableName() string { return "facility_translations" }
+
+type TranslationRepository interface {
+	// ListByEntities returns the translations of the given entities in any
+	// of the locales. Entity IDs are unique across resources.
+	ListByEntities(ctx context.Context, entityIDs []uuid.UUID, locales []string) ([]Translation, error)
+	// ReplaceLocale swaps all translations of one entity in one locale for
+	// items; an empty items removes the locale.
+	ReplaceLocale(ctx context.Context, resource TranslationResource, entityID uuid.UUID, locale string, items []Translation) error
+}
+
+const (
+	TranslationFieldName        = "name"
+	TranslationFieldDescription = "description"
+	TranslationFieldTextFix     = "text_fix"
+)
+
+// TranslatableFields lists the fields of a resource that accept
+// translations, or nil for an unknown resource.
+func TranslatableFields(resource TranslationResource) []string {
+	switch resource {
+	case TranslationResourceStateText:
+		fields := make([]string, StateTextSlots)
+		for index := range fields {
+			fields[index] = StateTextField(index + 1)
+		}
+		return fields
+	case TranslationResourceBacnetObject:
+		return []string{TranslationFieldTextFix}
+	case TranslationResourceApparat, TranslationResourceSystemPart:
+		return []string{TranslationFieldName, TranslationFieldDescription}
+	default:
+		return nil
+	}
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(?:_[A-Z]{2})?$`)
+
+// NormalizeLocale accepts "fr", "fr-CH", "fr_ch" and similar forms and
+// returns the "fr_CH" form used by the translation catalogs.
+func NormalizeLocale(value string) (string, bool) {
+	language, region, hasRegion := strings.Cut(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"), "_")
+	locale := strings.ToLower(language)
+	if hasRegion {
+		locale += "_" + strings.ToUpper(region)
+	}
+	return locale, localePattern.MatchString(locale)
+}
+
+// LocaleFallbacks is the lookup order for a locale: the locale itself, then
+// its language alone. "fr_CH" falls back to "fr".
+func LocaleFallbacks(locale string) []string {
+	locale, ok := NormalizeLocale(locale)
+	if !ok {
+		return nil
+	}
+	if language, _, hasRegion := strings.Cut(locale, "_"); hasRegion {
+		return []string{locale, language}
+	}
+	return []string{locale}
+}
+
+type translationKey struct {
+	resource TranslationResource
+	entityID uuid.UUID
+	field    string
+}
+
+// Translations resolves texts for one locale. Missing or blank translations
+// fall back from the regional locale to its language and then to the base
+// text, so a partial translation still yields a complete text set.
+type Translations struct {
+	texts map[translationKey]string
+}
+
+func NewTranslations(locale string, items []Translation) Translations {
+	fallbacks := LocaleFallbacks(locale)
+	rank := make(map[string]int, len(fallbacks))
+	for index, candidate := range fallbacks {
+		rank[candidate] = index
+	}
+	texts := make(map[translationKey]string, len(items))
+	ranks := make(map[translationKey]int, len(items))
+	for _, item := range items {
+		position, ok := rank[item.Locale]
+		if !ok || strings.TrimSpace(item.Text) == "" {
+			continue
+		}
+		key := translationKey{resource: item.Resource, entityID: item.EntityID, field: item.Field}
+		if current, exists := ranks[key]; exists && current <= position {
+			continue
+		}
+		texts[key] = item.Text
+		ranks[key] = position
+	}
+	return Translations{texts: texts}
+}
+
+// LoadTranslations reads what the given entities need to be shown in
+// locale. A nil repository or an empty locale yields no translations.
+func LoadTranslations(ctx context.Context, repo TranslationRepository, locale string, entityIDs []uuid.UUID) (Translations, error) {
+	fallbacks := LocaleFallbacks(locale)
+	if repo == nil || len(fallbacks) == 0 || len(entityIDs) == 0 {
+		return Translations{}, nil
+	}
+	items, err := repo.ListByEntities(ctx, entityIDs, fallbacks)
+	if err != nil {
+		return Translations{}, err
+	}
+	return NewTranslations(locale, items), nil
+}
+
+// Text returns the translation of one field or base when there is none.
+func (t Translations) Text(resource TranslationResource, entityID uuid.UUID, field, base string) string {
+	if text, ok := t.texts[translationKey{resource: resource, entityID: entityID, field: field}]; ok {
+		return text
+	}
+	return base
+}
+
+// Empty reports whether no translation applies, so callers can skip copying.
+func (t Translations) Empty() bool {
+	return len(t.texts) == 0
+}
+
+// LocalizeStateText returns a copy of text with its translated states. Slots
+// without a base text stay empty: a translation cannot add states the base
+// table does not define.
+func (t Translations) LocalizeStateText(text StateText) StateText {
+	states := text.States()
+	for index, state := range states {
+		if state == nil {
+			continue
+		}
+		translated := t.Text(TranslationResourceStateText, text.ID, StateTextField(index+1), *state)
+		states[index] = &translated
+	}
+	text.SetStates(states)
+	return text
+}
@@ -0,0 +1,58 @@
+package facility
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	cases := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"fr-ch", "fr_CH", true},
+		{" de_CH ", "de_CH", true},
+		{"FR", "fr", true},
+		{"french", "french", false},
+		{"fr_CHE", "fr_CHE", false},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := NormalizeLocale(tc.value)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("NormalizeLocale(%q) = %q, %v; want %q, %v", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+	if got := LocaleFallbacks("fr-CH"); !slices.Equal(got, []string{"fr_CH", "fr"}) {
+		t.Fatalf("LocaleFallbacks() = %v", got)
+	}
+}
+
+func TestTranslationsFallBackToLanguageAndBaseText(t *testing.T) {
+	off, on, auto := "Aus", "Ein", "Auto"
+	text := StateText{Base: domain.Base{ID: uuid.New()}, StateText1: &off, StateText2: &on, StateText3: &auto}
+	texts := NewTranslations("fr_CH", []Translation{
+		{Resource: TranslationResourceStateText, EntityID: text.ID, Locale: "fr", Field: "state_text_1", Text: "Arrêt"},
+		{Resource: TranslationResourceStateText, EntityID: text.ID, Locale: "fr_CH", Field: "state_text_1", Text: "Déclenché"},
+		{Resource: TranslationResourceStateText, EntityID: text.ID, Locale: "fr", Field: "state_text_2", Text: "Marche"},
+		{Resource: TranslationResourceStateText, EntityID: text.ID, Locale: "fr_CH", Field: "state_text_3", Text: " "},
+		{Resource: TranslationResourceStateText, EntityID: text.ID, Locale: "fr", Field: "state_text_4", Text: "Défaut"},
+		{Resource: TranslationResourceStateText, EntityID: text.ID, Locale: "it", Field: "state_text_3", Text: "Automatico"},
+	})
+
+	localized := texts.LocalizeStateText(text)
+
+	if *localized.StateText1 != "Déclenché" || *localized.StateText2 != "Marche" || *localized.StateText3 != "Auto" {
+		t.Fatalf("localized = %q, %q, %q", *localized.StateText1, *localized.StateText2, *localized.StateText3)
+	}
+	if localized.StateText4 != nil {
+		t.Fatalf("translation added state 4: %q", *localized.StateText4)
+	}
+	if *text.StateText1 != "Aus" {
+		t.Fatal("LocalizeStateText changed the base text")
+	}
+}
@@ -0,0 +1,134 @@
+package facility
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var (
+	ErrIncompatibleUnits = errors.New("units are not convertible")
+	ErrUnitValueNotWhole = errors.New("converted integer value is not whole")
+)
+
+// UnitDimension is one entry of the dimension catalog. BaseUnitCode names
+// the unit whose Factor is 1 and Offset 0; it is informational and does not
+// have to exist in the unit table.
+type UnitDimension struct {
+	Code         string
+	Name         string
+	BaseUnitCode string
+}
+
+// UnitDimensions is the catalog of dimensions a Unit may declare.
+var UnitDimensions = []UnitDimension{
+	{Code: "temperature", Name: "Temperature", BaseUnitCode: "K"},
+	{Code: "pressure", Name: "Pressure", BaseUnitCode: "Pa"},
+	{Code: "volume_flow", Name: "Volume flow", BaseUnitCode: "m3/s"},
+	{Code: "mass_flow", Name: "Mass flow", BaseUnitCode: "kg/s"},
+	{Code: "velocity", Name: "Velocity", BaseUnitCode: "m/s"},
+	{Code: "power", Name: "Power", BaseUnitCode: "W"},
+	{Code: "energy", Name: "Energy", BaseUnitCode: "J"},
+	{Code: "time", Name: "Time", BaseUnitCode: "s"},
+	{Code: "length", Name: "Length", BaseUnitCode: "m"},
+	{Code: "volume", Name: "Volume", BaseUnitCode: "m3"},
+	{Code: "ratio", Name: "Ratio", BaseUnitCode: "1"},
+	{Code: "electric_current", Name: "Electric current", BaseUnitCode: "A"},
+	{Code: "voltage", Name: "Voltage", BaseUnitCode: "V"},
+	{Code: "frequency", Name: "Frequency", BaseUnitCode: "Hz"},
+	{Code: "concentration", Name: "Concentration", BaseUnitCode: "ppm"},
+	{Code: "illuminance", Name: "Illuminance", BaseUnitCode: "lx"},
+}
+
+// LookupUnitDimension returns the catalog entry for code.
+func LookupUnitDimension(code string) (UnitDimension, bool) {
+	code = strings.TrimSpace(code)
+	for _, dimension := range UnitDimensions {
+		if dimension.Code == code {
+			return dimension, true
+		}
+	}
+	return UnitDimension{}, false
+}
+
+// ConvertibleTo reports whether values in u can be expressed in other.
+func (u Unit) ConvertibleTo(other Unit) bool {
+	if u.ID == other.ID {
+		return true
+	}
+	return u.Dimension != "" && u.Dimension == other.Dimension
+}
+
+// UnitValueKind tells how a value relates to the scale of its unit.
+type UnitValueKind int
+
+const (
+	// UnitValueAbsolute is a point on the scale, such as a limit.
+	UnitValueAbsolute UnitValueKind = iota
+	// UnitValueDifference is the span between two points, such as a
+	// deadband. It converts by the factor alone: 2 °C of hysteresis are
+	// 3.6 °F, not 35.6 °F.
+	UnitValueDifference
+)
+
+// differenceAlarmFields are the alarm fields whose values are spans.
+var differenceAlarmFields = map[string]bool{
+	"deadband":   true,
+	"hysteresis": true,
+}
+
+// AlarmFieldValueKind reports how the values of field convert between units.
+func AlarmFieldValueKind(field *AlarmTypeField) UnitValueKind {
+	if field != nil && field.AlarmField != nil && differenceAlarmFields[field.AlarmField.Key] {
+		return UnitValueDifference
+	}
+	return UnitValueAbsolute
+}
+
+// ConvertUnitValue converts value from one unit to another of the same
+// dimension.
+func ConvertUnitValue(value float64, from, to Unit, kind UnitValueKind) (float64, error) {
+	if from.ID == to.ID {
+		return value, nil
+	}
+	if !from.ConvertibleTo(to) || from.Factor == 0 || to.Factor == 0 {
+		return 0, ErrIncompatibleUnits
+	}
+	converted := value * from.Factor / to.Factor
+	if kind == UnitValueAbsolute {
+		converted = (value*from.Factor + from.Offset - to.Offset) / to.Factor
+	}
+	// Strip float noise such as 20.000000000000004 from round trips.
+	return math.Round(converted*1e9) / 1e9, nil
+}
+
+// ConvertAlarmValue rewrites the numeric payload of value from one unit into
+// another and points the value at the target unit. Integer values must stay
+// whole; non-numeric payloads only change their unit.
+func ConvertAlarmValue(value *BacnetObjectAlarmValue, from, to Unit, kind UnitValueKind) error {
+	if !from.ConvertibleTo(to) {
+		return ErrIncompatibleUnits
+	}
+	if value.ValueNumber != nil {
+		converted, err := ConvertUnitValue(*value.ValueNumber, from, to, kind)
+		if err != nil {
+			return err
+		}
+		value.ValueNumber = &converted
+	}
+	if value.ValueInteger != nil {
+		converted, err := ConvertUnitValue(float64(*value.ValueInteger), from, to, kind)
+		if err != nil {
+			return err
+		}
+		if !nearlyWhole(converted) {
+			return ErrUnitValueNotWhole
+		}
+		integer := int64(math.Round(converted))
+		value.ValueInteger = &integer
+	}
+	toID := to.ID
+	value.UnitID = &toID
+	value.Unit = nil
+	return nil
+}
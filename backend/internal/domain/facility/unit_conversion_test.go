@@ -0,0 +1,88 @@
+package facility
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+func testUnit(code, dimension string, factor, offset float64) Unit {
+	return Unit{Base: domain.Base{ID: uuid.New()}, Code: code, Dimension: dimension, Factor: factor, Offset: offset}
+}
+
+func TestConvertUnitValue(t *testing.T) {
+	celsius := testUnit("degC", "temperature", 1, 273.15)
+	fahrenheit := testUnit("degF", "temperature", 5.0/9.0, 273.15-32*5.0/9.0)
+	kilopascal := testUnit("kPa", "pressure", 1000, 0)
+	pascal := testUnit("Pa", "pressure", 1, 0)
+	cubicMetresPerHour := testUnit("m3/h", "volume_flow", 1.0/3600, 0)
+	litresPerSecond := testUnit("l/s", "volume_flow", 0.001, 0)
+
+	cases := []struct {
+		name     string
+		value    float64
+		from, to Unit
+		want     float64
+	}{
+		{"fahrenheit to celsius", 68, fahrenheit, celsius, 20},
+		{"celsius to fahrenheit", -40, celsius, fahrenheit, -40},
+		{"kilopascal to pascal", 1.5, kilopascal, pascal, 1500},
+		{"cubic metres per hour to litres per second", 36, cubicMetresPerHour, litresPerSecond, 10},
+		{"same unit", 7, pascal, pascal, 7},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertUnitValue(tt.value, tt.from, tt.to, UnitValueAbsolute)
+			if err != nil || got != tt.want {
+				t.Fatalf("ConvertUnitValue() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ConvertUnitValue(1, celsius, pascal, UnitValueAbsolute); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Fatalf("cross-dimension error = %v, want ErrIncompatibleUnits", err)
+	}
+	plain := testUnit("count", "", 1, 0)
+	if _, err := ConvertUnitValue(1, plain, testUnit("pcs", "", 1, 0), UnitValueAbsolute); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Fatalf("dimensionless error = %v, want ErrIncompatibleUnits", err)
+	}
+}
+
+func TestConvertUnitValueAppliesOnlyTheFactorToDifferences(t *testing.T) {
+	celsius := testUnit("degC", "temperature", 1, 273.15)
+	fahrenheit := testUnit("degF", "temperature", 5.0/9.0, 273.15-32*5.0/9.0)
+
+	if got, err := ConvertUnitValue(2, celsius, fahrenheit, UnitValueDifference); err != nil || got != 3.6 {
+		t.Fatalf("2 degC deadband = %v degF, %v, want 3.6", got, err)
+	}
+	if got, err := ConvertUnitValue(9, fahrenheit, celsius, UnitValueDifference); err != nil || got != 5 {
+		t.Fatalf("9 degF deadband = %v degC, %v, want 5", got, err)
+	}
+	deadband := &AlarmTypeField{AlarmField: &AlarmField{Key: "deadband"}}
+	limit := &AlarmTypeField{AlarmField: &AlarmField{Key: "high_limit"}}
+	if AlarmFieldValueKind(deadband) != UnitValueDifference || AlarmFieldValueKind(limit) != UnitValueAbsolute || AlarmFieldValueKind(nil) != UnitValueAbsolute {
+		t.Fatal("only deadband and hysteresis fields convert as differences")
+	}
+}
+
+func TestConvertAlarmValueKeepsIntegersWhole(t *testing.T) {
+	kilopascal := testUnit("kPa", "pressure", 1000, 0)
+	pascal := testUnit("Pa", "pressure", 1, 0)
+
+	integer := int64(3)
+	value := BacnetObjectAlarmValue{ValueInteger: &integer, UnitID: &kilopascal.ID}
+	if err := ConvertAlarmValue(&value, kilopascal, pascal, UnitValueAbsolute); err != nil {
+		t.Fatalf("ConvertAlarmValue() error = %v", err)
+	}
+	if *value.ValueInteger != 3000 || *value.UnitID != pascal.ID {
+		t.Fatalf("converted = %d in %s, want 3000 Pa", *value.ValueInteger, value.UnitID)
+	}
+
+	fraction := int64(1500)
+	value = BacnetObjectAlarmValue{ValueInteger: &fraction, UnitID: &pascal.ID}
+	if err := ConvertAlarmValue(&value, pascal, kilopascal, UnitValueAbsolute); !errors.Is(err, ErrUnitValueNotWhole) {
+		t.Fatalf("fractional integer error = %v, want ErrUnitValueNotWhole", err)
+	}
+}
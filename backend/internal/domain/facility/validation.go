@@ -32,10 +32,31 @@ func (c SPSController) Validate() error {
 	validateIPv4(validation, "spscontroller.ip_address", c.IPAddress)
 	validateIPv4(validation, "spscontroller.gateway", c.Gateway)
 	validateSubnet(validation, c.Subnet)
-	validateVLAN(validation, c.Vlan)
+	validateVLAN(validation, "spscontroller.vlan", c.Vlan)
+	validateMACAddress(validation, "spscontroller.mac_address", c.MACAddress)
 	return validationResult(validation)
 }
 
+// ValidateNetworkChange checks that the gateway lies in the subnet of the IP
+// address. Controllers saved before the rule existed may violate it, so on
+// updates it only applies when the IP address, subnet or gateway changes.
+// Pass nil as previous for new controllers. Call it after Validate.
+func (c SPSController) ValidateNetworkChange(previous *SPSController) error {
+	if previous != nil && sameAddress(c.IPAddress, previous.IPAddress) &&
+		sameAddress(c.Subnet, previous.Subnet) && sameAddress(c.Gateway, previous.Gateway) {
+		return nil
+	}
+	validation := domain.NewValidationError()
+	validateGatewayInSubnet(validation, c)
+	return validationResult(validation)
+}
+
+func sameAddress(left, right *string) bool {
+	leftValue, _ := trimmedAddress(left)
+	rightValue, _ := trimmedAddress(right)
+	return leftValue == rightValue
+}
+
 func (s SPSControllerSystemType) Validate(path string) error {
 	if path == "" {
 		path = "spscontroller.system_types"
@@ -183,12 +204,46 @@ func validateSubnet(validation *domain.ValidationError, value *string) {
 	}
 }
 
-func validateVLAN(validation *domain.ValidationError, value *string) {
+func validateVLAN(validation *domain.ValidationError, path string, value *string) {
 	if value == nil || strings.TrimSpace(*value) == "" {
 		return
 	}
 	vlan, err := strconv.Atoi(strings.TrimSpace(*value))
 	if err != nil || vlan < 1 || vlan > 4094 {
-		validation.AddCode("spscontroller.vlan", "range", "vlan must be a number between 1 and 4094")
+		validation.AddCode(path, "range", "vlan must be a number between 1 and 4094")
+	}
+}
+
+func validateMACAddress(validation *domain.ValidationError, path string, value *string) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return
+	}
+	if _, ok := NormalizeMACAddress(*value); !ok {
+		validation.AddCode(path, "mac", "mac_address must be a valid MAC address, e.g. 00:1a:2b:3c:4d:5e")
+	}
+}
+
+// NormalizeMACAddress returns an EUI-48 address in lower-case colon form, as
+// DHCP servers expect it.
+func NormalizeMACAddress(value string) (string, bool) {
+	hardware, err := net.ParseMAC(strings.TrimSpace(value))
+	if err != nil || len(hardware) != 6 {
+		return "", false
+	}
+	return hardware.String(), true
+}
+
+// validateGatewayInSubnet runs after the syntax checks of Validate, so every
+// non-empty address below is known to parse.
+func validateGatewayInSubnet(validation *domain.ValidationError, c SPSController) {
+	ip, hasIP := trimmedAddress(c.IPAddress)
+	gateway, hasGateway := trimmedAddress(c.Gateway)
+	subnet, hasSubnet := trimmedAddress(c.Subnet)
+	if !hasIP || !hasGateway || !hasSubnet {
+		return
+	}
+	mask := net.IPMask(net.ParseIP(subnet).To4())
+	if !net.ParseIP(ip).Mask(mask).Equal(net.ParseIP(gateway).Mask(mask)) {
+		validation.AddCode("spscontroller.gateway", "subnet", "gateway must be inside the subnet of ip_address")
 	}
 }
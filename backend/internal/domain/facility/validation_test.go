@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
 )
 
 func TestAggregateValidationReportsStableFieldCodes(t *testing.T) {
@@ -29,6 +30,31 @@ func TestAggregateValidationReportsStableFieldCodes(t *testing.T) {
 				"spscontroller.ga_device":          "required",
 			},
 		},
+		{
+			name: "SPS controller gateway outside subnet",
+			err: (SPSController{
+				ControlCabinetID: uuid.New(), DeviceName: "SPS-1", GADevice: stringPtr("ABC"),
+				IPAddress: stringPtr("10.1.0.10"), Subnet: stringPtr("255.255.255.0"), Gateway: stringPtr("10.1.1.1"),
+			}).ValidateNetworkChange(nil),
+			want: map[string]string{"spscontroller.gateway": "subnet"},
+		},
+		{
+			name: "SPS controller MAC address",
+			err: (SPSController{
+				ControlCabinetID: uuid.New(), DeviceName: "SPS-1", GADevice: stringPtr("ABC"), MACAddress: stringPtr("00:1a:2b:3c:4d"),
+			}).Validate(),
+			want: map[string]string{"spscontroller.mac_address": "mac"},
+		},
+		{
+			name: "IP pool",
+			err:  (IPPool{Network: "10.1.0.0/24", Gateway: stringPtr("10.2.0.1")}).Validate(),
+			want: map[string]string{
+				"ippool.name":        "required",
+				"ippool.building_id": "scope",
+				"ippool.vlan":        "required",
+				"ippool.gateway":     "subnet",
+			},
+		},
 		{
 			name: "SPS controller system type",
 			err:  (SPSControllerSystemType{}).Validate("spscontroller.system_types[0]"),
@@ -76,6 +102,31 @@ func TestAggregateValidationReportsStableFieldCodes(t *testing.T) {
 	}
 }
 
+func TestSPSControllerNetworkRuleOnlyAppliesToChangedAddressing(t *testing.T) {
+	legacy := SPSController{IPAddress: stringPtr("10.1.0.10"), Subnet: stringPtr("255.255.255.0"), Gateway: stringPtr("10.1.1.1")}
+	renamed := legacy
+	renamed.DeviceName = "SPS-2"
+	if err := renamed.ValidateNetworkChange(&legacy); err != nil {
+		t.Fatalf("unchanged addressing must not be revalidated: %v", err)
+	}
+	moved := legacy
+	moved.IPAddress = stringPtr(" 10.1.0.11 ")
+	if err := moved.ValidateNetworkChange(&legacy); err == nil {
+		t.Fatal("changed addressing must satisfy the gateway rule")
+	}
+}
+
+func TestNormalizeMACAddress(t *testing.T) {
+	for _, value := range []string{"00:1A:2B:3C:4D:5E", "00-1a-2b-3c-4d-5e", " 001a.2b3c.4d5e "} {
+		if got, ok := NormalizeMACAddress(value); !ok || got != "00:1a:2b:3c:4d:5e" {
+			t.Errorf("NormalizeMACAddress(%q) = %q, %v", value, got, ok)
+		}
+	}
+	if _, ok := NormalizeMACAddress("00:00:5e:10:00:00:00:01"); ok {
+		t.Error("EUI-64 addresses must be rejected")
+	}
+}
+
 func TestBacnetTypesValidateKnownValues(t *testing.T) {
 	if !BacnetSoftwareTypeAI.Valid() {
 		t.Fatal("AI software type should be valid")
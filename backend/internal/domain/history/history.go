@@ -120,19 +120,42 @@ type RestoreEntityRequest struct {
 	Mode    RestoreMode `json:"mode"`
 }
 
-type RestoreControlCabinetRequest struct {
+// RestoreHierarchyRequest selects the checkpoint of an as-of subtree restore.
+// EventID wins over AsOf; without either the current time is used. Force
+// skips the undo-conflict preflight and overwrites untracked changes.
+type RestoreHierarchyRequest struct {
 	AsOf      *time.Time `json:"as_of"`
 	EventID   *uuid.UUID `json:"event_id"`
 	ProjectID *uuid.UUID `json:"project_id"`
+	Force     bool       `json:"force"`
 }
 
-const TaskRestoreControlCabinet = "controlcabinet.restore.v1"
+type RestoreControlCabinetRequest = RestoreHierarchyRequest
+
+const (
+	TaskRestoreBuilding                = "building.restore.v1"
+	TaskRestoreControlCabinet          = "controlcabinet.restore.v1"
+	TaskRestoreSPSController           = "spscontroller.restore.v1"
+	TaskRestoreSPSControllerSystemType = "spscontrollersystemtype.restore.v1"
+	TaskRestoreObjectData              = "objectdata.restore.v1"
+)
+
+// RestoreHierarchyJobPayload is the durable payload of every subtree restore
+// task except the control cabinet one, which keeps its original shape.
+type RestoreHierarchyJobPayload struct {
+	RootID    uuid.UUID  `json:"root_id"`
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+	AsOf      *time.Time `json:"as_of,omitempty"`
+	EventID   *uuid.UUID `json:"event_id,omitempty"`
+	Force     bool       `json:"force,omitempty"`
+}
 
 type RestoreControlCabinetJobPayload struct {
 	ControlCabinetID uuid.UUID  `json:"control_cabinet_id"`
 	ProjectID        *uuid.UUID `json:"project_id,omitempty"`
 	AsOf             *time.Time `json:"as_of,omitempty"`
 	EventID          *uuid.UUID `json:"event_id,omitempty"`
+	Force            bool       `json:"force,omitempty"`
 }
 
 type RestoreResult struct {
@@ -142,6 +165,46 @@ type RestoreResult struct {
 	BatchID       uuid.UUID `json:"batch_id"`
 }
 
+type RestoreEffect string
+
+const (
+	RestoreEffectRecreate RestoreEffect = "recreate"
+	RestoreEffectUpdate   RestoreEffect = "update"
+	RestoreEffectDelete   RestoreEffect = "delete"
+	RestoreEffectSkip     RestoreEffect = "skip"
+)
+
+type RestorePreviewItem struct {
+	EntityTable string        `json:"entity_table"`
+	EntityID    uuid.UUID     `json:"entity_id"`
+	Effect      RestoreEffect `json:"effect"`
+}
+
+type RestorePreviewTable struct {
+	EntityTable   string `json:"entity_table"`
+	RecreateCount int    `json:"recreate_count"`
+	UpdateCount   int    `json:"update_count"`
+	DeleteCount   int    `json:"delete_count"`
+	SkipCount     int    `json:"skip_count"`
+}
+
+// RestorePreview describes what an as-of subtree restore would do without
+// mutating anything. Items is capped; the counts always cover the subtree.
+type RestorePreview struct {
+	Root          string                `json:"root"`
+	RootID        uuid.UUID             `json:"root_id"`
+	ProjectID     *uuid.UUID            `json:"project_id,omitempty"`
+	AsOf          time.Time             `json:"as_of"`
+	RecreateCount int                   `json:"recreate_count"`
+	UpdateCount   int                   `json:"update_count"`
+	DeleteCount   int                   `json:"delete_count"`
+	SkipCount     int                   `json:"skip_count"`
+	Tables        []RestorePreviewTable `json:"tables"`
+	Items         []RestorePreviewItem  `json:"items"`
+	Truncated     bool                  `json:"truncated"`
+	Conflicts     []UndoConflict        `json:"-"`
+}
+
 type UndoConflict struct {
 	Code            string
 	EntityTable     string
@@ -170,3 +170,10 @@ type ProjectMembershipReader interface {
 type TeamMemberReader interface {
 	ListByTeam(ctx context.Context, teamID uuid.UUID, params domain.PaginationParams) (*domain.PaginatedList[domainTeam.TeamMember], error)
 }
+
+// TemplateCatalog resolves notification texts from the translation catalogs.
+// Lookup falls back to the default locale and reports false when no catalog
+// has the key.
+type TemplateCatalog interface {
+	Lookup(locale, key string) (string, bool)
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -47,12 +48,47 @@ type NewChange struct {
 	OccurredAt    time.Time
 }
 
+// ChangePage is one page of the feed. Events up to CompactedThrough were
+// compacted: every aggregate keeps only its latest event, which carries the
+// changed fields of the events it replaced.
 type ChangePage struct {
-	ProjectID       uuid.UUID
-	CurrentRevision uint64
-	Events          []Change
-	HasMore         bool
-	ResetRequired   bool
+	ProjectID        uuid.UUID
+	CurrentRevision  uint64
+	CompactedThrough uint64
+	Events           []Change
+	HasMore          bool
+	ResetRequired    bool
+}
+
+const (
+	DefaultChangeRetentionDays = 30
+	MaxChangeRetentionDays     = 3650
+)
+
+// ChangeRetention is how long a project keeps every event before older
+// events are compacted. Custom is false while the project uses the default.
+type ChangeRetention struct {
+	ProjectID        uuid.UUID
+	Days             int
+	Custom           bool
+	CompactedThrough uint64
+}
+
+// ChangeSnapshot is the current state of the project's aggregates at
+// Revision. Consumers replace their copy with it and resume the feed after
+// Revision.
+type ChangeSnapshot struct {
+	ProjectID  uuid.UUID
+	Revision   uint64
+	TakenAt    time.Time
+	Aggregates []AggregateState
+}
+
+// AggregateState is the stored row of one aggregate as JSON.
+type AggregateState struct {
+	AggregateType string
+	AggregateID   uuid.UUID
+	State         json.RawMessage
 }
 
 // ChangeStore is defined in the consuming project domain. Implementations own
@@ -68,3 +104,27 @@ type ChangeStore interface {
 type BatchChangeStore interface {
 	AppendBatch(ctx context.Context, changes []NewChange) ([]Change, error)
 }
+
+// ChangeRetentionStore is an optional capability for stores that compact
+// events per project instead of dropping them after a fixed window. A nil
+// days value restores the default retention. CompactExpired compacts the
+// projects whose window moved on without a new append.
+type ChangeRetentionStore interface {
+	Retention(ctx context.Context, projectID uuid.UUID) (*ChangeRetention, error)
+	SetRetention(ctx context.Context, projectID uuid.UUID, days *int) (*ChangeRetention, error)
+	CompactExpired(ctx context.Context) error
+}
+
+// ChangeStateRow is the stored row of one aggregate as JSON.
+type ChangeStateRow struct {
+	ID    uuid.UUID
+	State json.RawMessage
+}
+
+// ChangeStateReader loads the stored rows a change snapshot is composed from.
+// ProjectRow fails with domain.ErrNotFound for unknown projects; Rows returns
+// the rows of the requested tables that belong to the project.
+type ChangeStateReader interface {
+	ProjectRow(ctx context.Context, projectID uuid.UUID) (json.RawMessage, error)
+	Rows(ctx context.Context, projectID uuid.UUID, tables []string) (map[string][]ChangeStateRow, error)
+}
@@ -144,6 +144,11 @@ func CanonicalPermissionDefinitions() []PermissionDefinition {
 		Resource:    "notification.smtp",
 		Action:      PermissionActionManage,
 		Description: "Manage SMTP notification settings",
+	}, PermissionDefinition{
+		Name:        PermissionWebhookManage,
+		Resource:    "webhook",
+		Action:      PermissionActionManage,
+		Description: "Manage webhook subscriptions and deliveries",
 	}, PermissionDefinition{
 		Name:        PermissionTimelineRead,
 		Resource:    "timeline",
@@ -150,6 +150,7 @@ const (
 	PermissionUnitDelete = "unit.delete"
 
 	PermissionNotificationSMTPManage = "notification.smtp.manage"
+	PermissionWebhookManage          = "webhook.manage"
 
 	PermissionTimelineRead    = "timeline.read"
 	PermissionTimelineRestore = "timeline.restore"
@@ -43,6 +43,10 @@ type User struct {
 	CreatedBy           *User            `gorm:"foreignKey:CreatedByID"`
 	BusinessDetails     *BusinessDetails `json:"business_details,omitempty" gorm:"foreignKey:UserID"`
 	Teams               []UserTeam       `gorm:"foreignKey:UserID"`
+
+	// Locale is the preferred language of the user, e.g. "fr_CH". It wins
+	// over Accept-Language; empty means the browser decides.
+	Locale string `gorm:"type:varchar(16)"`
 }
 
 type BusinessDetails struct {
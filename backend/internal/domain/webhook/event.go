@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	"github.com/google/uuid"
+)
+
+// JobStatus is the part of a facility job that receivers see. The owner and
+// the failure text stay internal; receivers see the outcome and the counters.
+type JobStatus struct {
+	JobID       uuid.UUID
+	Kind        string
+	Type        string
+	Class       string
+	Status      string
+	Stage       string
+	Processed   int64
+	Total       *int64
+	Succeeded   int64
+	Failed      int64
+	CompletedAt *time.Time
+	UpdatedAt   time.Time
+}
+
+type eventEnvelope struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       EventType  `json:"type"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	ProjectID  *uuid.UUID `json:"project_id,omitempty"`
+	Data       any        `json:"data"`
+}
+
+type changeData struct {
+	Revision      uint64               `json:"revision"`
+	AggregateType string               `json:"aggregate_type"`
+	AggregateID   *uuid.UUID           `json:"aggregate_id,omitempty"`
+	Action        string               `json:"action"`
+	ActorID       *uuid.UUID           `json:"actor_id,omitempty"`
+	ChangedFields []string             `json:"changed_fields"`
+	ParentRefs    map[string]uuid.UUID `json:"parent_refs,omitempty"`
+}
+
+type jobData struct {
+	JobID       uuid.UUID  `json:"job_id"`
+	Kind        string     `json:"kind"`
+	Type        string     `json:"type"`
+	Class       string     `json:"class"`
+	Status      string     `json:"status"`
+	Stage       string     `json:"stage"`
+	Processed   int64      `json:"processed"`
+	Total       *int64     `json:"total,omitempty"`
+	Succeeded   int64      `json:"succeeded"`
+	Failed      int64      `json:"failed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ChangeDeliveries builds one pending delivery per matching subscription and
+// change, due at now.
+func ChangeDeliveries(subscriptions []Subscription, changes []domainProject.Change, now time.Time) ([]Delivery, error) {
+	var deliveries []Delivery
+	for _, change := range changes {
+		projectID := change.ProjectID
+		envelope := eventEnvelope{
+			ID: change.EventID, Type: EventProjectChange, OccurredAt: change.OccurredAt, ProjectID: &projectID,
+			Data: changeData{
+				Revision: change.Revision, AggregateType: change.AggregateType, AggregateID: change.AggregateID,
+				Action: string(change.Action), ActorID: change.ActorID, ChangedFields: change.ChangedFields, ParentRefs: change.ParentRefs,
+			},
+		}
+		var err error
+		for _, subscription := range subscriptions {
+			if subscription.MatchesChange(change.ProjectID, change.AggregateType, string(change.Action)) {
+				if deliveries, err = appendDelivery(deliveries, subscription, envelope, now); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return deliveries, nil
+}
+
+// JobDeliveries builds one pending delivery per global subscription that
+// matches the job's status, due at now.
+func JobDeliveries(subscriptions []Subscription, job JobStatus, now time.Time) ([]Delivery, error) {
+	var deliveries []Delivery
+	var envelope *eventEnvelope
+	for _, subscription := range subscriptions {
+		if !subscription.MatchesJob(job.Status) {
+			continue
+		}
+		if envelope == nil {
+			eventID, err := uuid.NewV7()
+			if err != nil {
+				return nil, fmt.Errorf("allocate webhook event id: %w", err)
+			}
+			envelope = &eventEnvelope{
+				ID: eventID, Type: EventFacilityJobStatus, OccurredAt: job.UpdatedAt,
+				Data: jobData{
+					JobID: job.JobID, Kind: job.Kind, Type: job.Type, Class: job.Class,
+					Status: job.Status, Stage: job.Stage, Processed: job.Processed, Total: job.Total,
+					Succeeded: job.Succeeded, Failed: job.Failed, CompletedAt: job.CompletedAt,
+				},
+			}
+		}
+		var err error
+		if deliveries, err = appendDelivery(deliveries, subscription, *envelope, now); err != nil {
+			return nil, err
+		}
+	}
+	return deliveries, nil
+}
+
+func appendDelivery(deliveries []Delivery, subscription Subscription, envelope eventEnvelope, now time.Time) ([]Delivery, error) {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("encode webhook payload: %w", err)
+	}
+	return append(deliveries, Delivery{
+		SubscriptionID: subscription.ID,
+		EventType:      envelope.Type,
+		EventID:        envelope.ID,
+		ProjectID:      envelope.ProjectID,
+		Payload:        payload,
+		Status:         DeliveryStatusPending,
+		NextAttemptAt:  now,
+	}), nil
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// EventType names the event families a subscription can receive.
+type EventType string
+
+const (
+	EventProjectChange     EventType = "project.change"
+	EventFacilityJobStatus EventType = "facility_job.status"
+)
+
+func (t EventType) Valid() bool {
+	return t == EventProjectChange || t == EventFacilityJobStatus
+}
+
+// MaxDeliveryAttempts is the number of attempts before a delivery is marked
+// failed and waits for a manual replay.
+const MaxDeliveryAttempts = 8
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+func (s DeliveryStatus) Valid() bool {
+	switch s {
+	case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Subscription is an endpoint that receives signed event payloads. Global
+// subscriptions (ProjectID nil) see every project and the facility job
+// events; project subscriptions only see the changes of their project. Empty
+// filter lists match everything.
+type Subscription struct {
+	domain.Base
+	Name           string     `gorm:"not null"`
+	URL            string     `gorm:"type:varchar(2048);not null"`
+	ProjectID      *uuid.UUID `gorm:"type:uuid;index"`
+	Active         bool       `gorm:"not null;index"`
+	SecretCipher   string     `gorm:"type:text;not null"`
+	EventTypes     []string   `gorm:"serializer:json;type:text"`
+	AggregateTypes []string   `gorm:"serializer:json;type:text"`
+	Actions        []string   `gorm:"serializer:json;type:text"`
+	JobStatuses    []string   `gorm:"serializer:json;type:text"`
+	CreatedByID    *uuid.UUID `gorm:"type:uuid"`
+}
+
+func (s *Subscription) GetBase() *domain.Base {
+	return &s.Base
+}
+
+func (Subscription) TableName() string { return "webhook_subscriptions" }
+
+func (s Subscription) Receives(eventType EventType) bool {
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, string(eventType))
+}
+
+// MatchesChange reports whether a project change passes the subscription's
+// project, aggregate type and action filters.
+func (s Subscription) MatchesChange(projectID uuid.UUID, aggregateType, action string) bool {
+	if !s.Active || !s.Receives(EventProjectChange) || (s.ProjectID != nil && *s.ProjectID != projectID) {
+		return false
+	}
+	return matches(s.AggregateTypes, aggregateType) && matches(s.Actions, action)
+}
+
+// MatchesJob reports whether a facility job status passes the filter. Job
+// events carry no project, so only global subscriptions receive them.
+func (s Subscription) MatchesJob(status string) bool {
+	if !s.Active || !s.Receives(EventFacilityJobStatus) || s.ProjectID != nil {
+		return false
+	}
+	return matches(s.JobStatuses, status)
+}
+
+func matches(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
+
+// Delivery is one outbox entry: the payload for one subscription, retried
+// with backoff until the endpoint answers with a 2xx status or the attempts
+// run out.
+type Delivery struct {
+	domain.Base
+	SubscriptionID uuid.UUID      `gorm:"type:uuid;not null;index"`
+	EventType      EventType      `gorm:"type:varchar(64);not null"`
+	EventID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	ProjectID      *uuid.UUID     `gorm:"type:uuid;index"`
+	Payload        []byte         `gorm:"type:jsonb;not null"`
+	Status         DeliveryStatus `gorm:"type:varchar(16);not null;index:idx_webhook_deliveries_due"`
+	Attempts       int            `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time      `gorm:"not null;index:idx_webhook_deliveries_due"`
+	DeliveredAt    *time.Time
+	ResponseStatus *int
+	LastError      string `gorm:"type:text"`
+}
+
+func (d *Delivery) GetBase() *domain.Base {
+	return &d.Base
+}
+
+func (Delivery) TableName() string { return "webhook_deliveries" }
+
+// UpsertSubscriptionInput creates a subscription when ID is nil. An empty
+// Secret keeps the current signing secret, or generates one on create.
+type UpsertSubscriptionInput struct {
+	ID             uuid.UUID
+	ActorID        uuid.UUID
+	Name           string
+	URL            string
+	ProjectID      *uuid.UUID
+	Active         bool
+	Secret         string
+	EventTypes     []string
+	AggregateTypes []string
+	Actions        []string
+	JobStatuses    []string
+}
+
+type DeliveryFilter struct {
+	SubscriptionID *uuid.UUID
+	Status         DeliveryStatus
+	domain.PaginationParams
+}
+
+// ReplayFilter selects the failed deliveries to requeue: the listed IDs, or
+// every failure of the subscription, or every failure when both are empty.
+type ReplayFilter struct {
+	IDs            []uuid.UUID
+	SubscriptionID *uuid.UUID
+}
+
+// DeliveryAttempt records the outcome of one delivery attempt.
+type DeliveryAttempt struct {
+	Status         DeliveryStatus
+	Attempts       int
+	At             time.Time
+	NextAttemptAt  time.Time
+	ResponseStatus *int
+	Error          string
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *Subscription) error
+	Update(ctx context.Context, subscription *Subscription) error
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]Subscription, error)
+	List(ctx context.Context, projectID *uuid.UUID) ([]Subscription, error)
+	ListActive(ctx context.Context) ([]Subscription, error)
+}
+
+type DeliveryRepository interface {
+	CreateBatch(ctx context.Context, deliveries []Delivery) error
+	// Claim leases up to limit due deliveries until leaseUntil so concurrent
+	// workers do not send the same delivery twice.
+	Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]Delivery, error)
+	RecordAttempt(ctx context.Context, id uuid.UUID, attempt DeliveryAttempt) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	List(ctx context.Context, filter DeliveryFilter) (*domain.PaginatedList[Delivery], error)
+	// Replay requeues failed deliveries with a fresh attempt budget.
+	Replay(ctx context.Context, filter ReplayFilter, now time.Time) (int64, error)
+}
@@ -227,6 +227,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 		DisabledAt:             usr.DisabledAt,
 		LockedUntil:            usr.LockedUntil,
 		FailedLoginAttempts:    usr.FailedLoginAttempts,
+		Locale:                 usr.Locale,
 	})
 }
 
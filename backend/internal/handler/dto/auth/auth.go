@@ -26,6 +26,8 @@ type AuthUserResponse struct {
 	DisabledAt             *time.Time `json:"disabled_at,omitempty"`
 	LockedUntil            *time.Time `json:"locked_until,omitempty"`
 	FailedLoginAttempts    int        `json:"failed_login_attempts"`
+
+	Locale string `json:"locale,omitempty"`
 }
 
 type AuthResponse struct {
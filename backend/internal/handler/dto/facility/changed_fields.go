@@ -57,6 +57,9 @@ func (r UpdateSPSControllerRequest) ChangedFields() []string {
 	if r.Vlan != nil {
 		fields = append(fields, "vlan")
 	}
+	if r.MACAddress != nil {
+		fields = append(fields, "mac_address")
+	}
 	if r.SystemTypes != nil {
 		fields = append(fields, "system_types")
 	}
@@ -129,7 +132,7 @@ func (r BulkUpdateFieldDeviceItem) ChangedFields() []string {
 	if r.Specification != nil {
 		fields = append(fields, "specification")
 	}
-	if r.BacnetObjects != nil {
+	if r.BacnetObjects != nil || r.BacnetNumbering != nil {
 		fields = append(fields, "bacnet_objects")
 	}
 	return fields
@@ -397,7 +400,7 @@ func (r UpdateAlarmDefinitionRequest) ChangedFields() []string {
 }
 
 func (r UpdateUnitRequest) ChangedFields() []string {
-	fields := make([]string, 0, 3)
+	fields := make([]string, 0, 6)
 	if r.Code != nil {
 		fields = append(fields, "code")
 	}
@@ -407,6 +410,15 @@ func (r UpdateUnitRequest) ChangedFields() []string {
 	if r.Name != nil {
 		fields = append(fields, "name")
 	}
+	if r.Dimension != nil {
+		fields = append(fields, "dimension")
+	}
+	if r.Factor != nil {
+		fields = append(fields, "factor")
+	}
+	if r.Offset != nil {
+		fields = append(fields, "offset")
+	}
 	return fields
 }
 
@@ -453,6 +465,23 @@ func (r UpdateAlarmTypeFieldRequest) ChangedFields() []string {
 	return fields
 }
 
+func (r UpdateAlarmDefinitionFieldOverrideRequest) ChangedFields() []string {
+	fields := make([]string, 0, 4)
+	if r.IsRequiredOverride != nil {
+		fields = append(fields, "is_required_override")
+	}
+	if r.DefaultValueOverrideJSON != nil {
+		fields = append(fields, "default_value_override_json")
+	}
+	if r.ValidationOverrideJSON != nil {
+		fields = append(fields, "validation_override_json")
+	}
+	if r.UnitOverrideID != nil {
+		fields = append(fields, "unit_override_id")
+	}
+	return fields
+}
+
 func (r UpdateAlarmTypeRequest) ChangedFields() []string {
 	if r.Name == nil {
 		return []string{}
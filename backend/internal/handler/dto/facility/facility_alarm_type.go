@@ -9,24 +9,39 @@ import (
 // Unit DTOs
 
 type UnitResponse struct {
-	ID      uuid.UUID `json:"id"`
-	Version uint64    `json:"version"`
-	Code    string    `json:"code"`
-	Symbol  string    `json:"symbol"`
-	Name    string    `json:"name"`
+	ID        uuid.UUID `json:"id"`
+	Version   uint64    `json:"version"`
+	Code      string    `json:"code"`
+	Symbol    string    `json:"symbol"`
+	Name      string    `json:"name"`
+	Dimension string    `json:"dimension,omitempty"`
+	Factor    float64   `json:"factor"`
+	Offset    float64   `json:"offset"`
 }
 
 type CreateUnitRequest struct {
-	Code   string `json:"code" binding:"required,max=30"`
-	Symbol string `json:"symbol" binding:"required,max=20"`
-	Name   string `json:"name" binding:"required,max=100"`
+	Code      string   `json:"code" binding:"required,max=30"`
+	Symbol    string   `json:"symbol" binding:"required,max=20"`
+	Name      string   `json:"name" binding:"required,max=100"`
+	Dimension string   `json:"dimension" binding:"omitempty,max=40"`
+	Factor    *float64 `json:"factor"`
+	Offset    float64  `json:"offset"`
 }
 
 type UpdateUnitRequest struct {
-	BaseVersion uint64  `json:"base_version" binding:"required,min=1"`
-	Code        *string `json:"code" binding:"omitempty,max=30"`
-	Symbol      *string `json:"symbol" binding:"omitempty,max=20"`
-	Name        *string `json:"name" binding:"omitempty,max=100"`
+	BaseVersion uint64   `json:"base_version" binding:"required,min=1"`
+	Code        *string  `json:"code" binding:"omitempty,max=30"`
+	Symbol      *string  `json:"symbol" binding:"omitempty,max=20"`
+	Name        *string  `json:"name" binding:"omitempty,max=100"`
+	Dimension   *string  `json:"dimension" binding:"omitempty,max=40"`
+	Factor      *float64 `json:"factor"`
+	Offset      *float64 `json:"offset"`
+}
+
+type UnitDimensionResponse struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	BaseUnitCode string `json:"base_unit_code"`
 }
 
 func (r UpdateUnitRequest) ExpectedVersion() uint64 { return r.BaseVersion }
@@ -115,6 +130,41 @@ type UpdateAlarmTypeFieldRequest struct {
 	UIGroup          *string    `json:"ui_group" binding:"omitempty,max=80"`
 }
 
+// AlarmDefinitionFieldOverride DTOs
+
+type AlarmDefinitionFieldOverrideResponse struct {
+	ID                       uuid.UUID               `json:"id"`
+	Version                  uint64                  `json:"version"`
+	AlarmDefinitionID        uuid.UUID               `json:"alarm_definition_id"`
+	AlarmTypeFieldID         uuid.UUID               `json:"alarm_type_field_id"`
+	AlarmTypeField           *AlarmTypeFieldResponse `json:"alarm_type_field,omitempty"`
+	IsRequiredOverride       *bool                   `json:"is_required_override,omitempty"`
+	DefaultValueOverrideJSON *string                 `json:"default_value_override_json,omitempty"`
+	ValidationOverrideJSON   *string                 `json:"validation_override_json,omitempty"`
+	UnitOverrideID           *uuid.UUID              `json:"unit_override_id,omitempty"`
+	UnitOverride             *UnitResponse           `json:"unit_override,omitempty"`
+	CreatedAt                time.Time               `json:"created_at"`
+	UpdatedAt                time.Time               `json:"updated_at"`
+}
+
+type CreateAlarmDefinitionFieldOverrideRequest struct {
+	AlarmTypeFieldID         uuid.UUID  `json:"alarm_type_field_id" binding:"required"`
+	IsRequiredOverride       *bool      `json:"is_required_override"`
+	DefaultValueOverrideJSON *string    `json:"default_value_override_json"`
+	ValidationOverrideJSON   *string    `json:"validation_override_json"`
+	UnitOverrideID           *uuid.UUID `json:"unit_override_id"`
+}
+
+type UpdateAlarmDefinitionFieldOverrideRequest struct {
+	BaseVersion              uint64     `json:"base_version" binding:"required,min=1"`
+	IsRequiredOverride       *bool      `json:"is_required_override"`
+	DefaultValueOverrideJSON *string    `json:"default_value_override_json"`
+	ValidationOverrideJSON   *string    `json:"validation_override_json"`
+	UnitOverrideID           *uuid.UUID `json:"unit_override_id"`
+}
+
+func (r UpdateAlarmDefinitionFieldOverrideRequest) ExpectedVersion() uint64 { return r.BaseVersion }
+
 // AlarmType DTOs
 
 type CreateAlarmTypeRequest struct {
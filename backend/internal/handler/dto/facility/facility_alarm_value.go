@@ -1,6 +1,7 @@
 package facility
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -43,3 +44,28 @@ type AlarmValuesResponse struct {
 	Version uint64               `json:"version"`
 	Items   []AlarmValueResponse `json:"items"`
 }
+
+// BacnetObjectSelectionInput filters the BACnet objects of a bulk alarm value
+// edit. TextFix accepts * as a wildcard.
+type BacnetObjectSelectionInput struct {
+	ProjectID       *uuid.UUID `json:"project_id,omitempty"`
+	SPSControllerID *uuid.UUID `json:"sps_controller_id,omitempty"`
+	SystemPartID    *uuid.UUID `json:"system_part_id,omitempty"`
+	ApparatID       *uuid.UUID `json:"apparat_id,omitempty"`
+	AlarmTypeID     *uuid.UUID `json:"alarm_type_id,omitempty"`
+	TextFix         string     `json:"text_fix,omitempty"`
+}
+
+// AlarmValueAssignmentInput sets one alarm field either to a literal value or
+// to a formula such as "high_limit = setpoint + 2".
+type AlarmValueAssignmentInput struct {
+	FieldKey string          `json:"field_key,omitempty"`
+	Value    json.RawMessage `json:"value,omitempty" swaggertype:"object"`
+	Formula  string          `json:"formula,omitempty"`
+	UnitID   *uuid.UUID      `json:"unit_id,omitempty"`
+}
+
+type BulkEditAlarmValuesRequest struct {
+	Selection   BacnetObjectSelectionInput  `json:"selection"`
+	Assignments []AlarmValueAssignmentInput `json:"assignments" binding:"required,min=1"`
+}
@@ -0,0 +1,36 @@
+package facility
+
+import "github.com/google/uuid"
+
+type BacnetNumberingInput struct {
+	Strategy  string `json:"strategy" binding:"required,oneof=sequential system_type_block field_device_block"`
+	BlockSize int    `json:"block_size" binding:"omitempty,min=1,max=65535"`
+}
+
+// BacnetNumberingQuery opts a field-device copy into renumbering.
+type BacnetNumberingQuery struct {
+	Numbering string `form:"numbering" binding:"omitempty,oneof=sequential system_type_block field_device_block"`
+	BlockSize int    `form:"block_size" binding:"omitempty,min=1,max=65535"`
+}
+
+type BacnetNumberingPreviewRequest struct {
+	SPSControllerID uuid.UUID            `json:"sps_controller_id" binding:"required"`
+	FieldDeviceIDs  []uuid.UUID          `json:"field_device_ids"`
+	Numbering       BacnetNumberingInput `json:"numbering" binding:"required"`
+}
+
+type BacnetNumberProposalResponse struct {
+	BacnetObjectID uuid.UUID `json:"bacnet_object_id"`
+	FieldDeviceID  uuid.UUID `json:"field_device_id"`
+	SoftwareType   string    `json:"software_type"`
+	CurrentNumber  uint16    `json:"current_number"`
+	ProposedNumber uint16    `json:"proposed_number"`
+	Changed        bool      `json:"changed"`
+}
+
+type BacnetNumberingPreviewResponse struct {
+	SPSControllerID uuid.UUID                      `json:"sps_controller_id"`
+	Strategy        string                         `json:"strategy"`
+	Proposals       []BacnetNumberProposalResponse `json:"proposals"`
+	Changed         int                            `json:"changed"`
+}
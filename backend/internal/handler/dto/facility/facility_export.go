@@ -15,6 +15,33 @@ type CreateFieldDeviceExportRequest struct {
 	Search                     string      `json:"search" binding:"omitempty,max=200"`
 	ExportAll                  bool        `json:"export_all"`
 	ForceAsync                 bool        `json:"force_async"`
+	OutputType                 string      `json:"output_type" binding:"omitempty,oneof=excel zip ede network json ndjson"`
+	CollisionPolicy            string      `json:"collision_policy" binding:"omitempty,oneof=warn refuse ignore"`
+
+	Locale string `json:"locale" binding:"omitempty,max=16"`
+}
+
+// AnalyzeExportCollisionsRequest selects the controllers to check for BACnet
+// instance collisions; at least one project or building is required.
+type AnalyzeExportCollisionsRequest struct {
+	ProjectIDs  []uuid.UUID `json:"project_ids" binding:"omitempty,dive,uuid"`
+	BuildingIDs []uuid.UUID `json:"buildings_id" binding:"omitempty,dive,uuid"`
+}
+
+type ExportCollisionResponse struct {
+	Kind           string      `json:"kind"`
+	DeviceInstance string      `json:"device_instance,omitempty"`
+	ControllerIDs  []uuid.UUID `json:"controller_ids"`
+	ObjectType     string      `json:"object_type,omitempty"`
+	ObjectInstance *uint16     `json:"object_instance,omitempty"`
+	ObjectIDs      []uuid.UUID `json:"object_ids,omitempty"`
+	Message        string      `json:"message"`
+}
+
+type ExportCollisionReportResponse struct {
+	ControllerCount int                       `json:"controller_count"`
+	ObjectCount     int64                     `json:"object_count"`
+	Collisions      []ExportCollisionResponse `json:"collisions"`
 }
 
 type FieldDeviceExportJobResponse struct {
@@ -9,15 +9,16 @@ import (
 // Facility DTOs - FieldDevice
 
 type CreateFieldDeviceRequest struct {
-	BMK                       *string             `json:"bmk" binding:"omitempty,max=10"`
-	Description               *string             `json:"description" binding:"omitempty,max=250"`
-	TextIndividuell           *string             `json:"text_fix" binding:"omitempty,max=250"`
-	ApparatNr                 *int                `json:"apparat_nr" binding:"required,min=1,max=99"`
-	SPSControllerSystemTypeID uuid.UUID           `json:"sps_controller_system_type_id" binding:"required"`
-	SystemPartID              uuid.UUID           `json:"system_part_id" binding:"required"`
-	ApparatID                 uuid.UUID           `json:"apparat_id" binding:"required"`
-	ObjectDataID              *uuid.UUID          `json:"object_data_id"`
-	BacnetObjects             []BacnetObjectInput `json:"bacnet_objects" binding:"omitempty,dive"`
+	BMK                       *string               `json:"bmk" binding:"omitempty,max=10"`
+	Description               *string               `json:"description" binding:"omitempty,max=250"`
+	TextIndividuell           *string               `json:"text_fix" binding:"omitempty,max=250"`
+	ApparatNr                 *int                  `json:"apparat_nr" binding:"required,min=1,max=99"`
+	SPSControllerSystemTypeID uuid.UUID             `json:"sps_controller_system_type_id" binding:"required"`
+	SystemPartID              uuid.UUID             `json:"system_part_id" binding:"required"`
+	ApparatID                 uuid.UUID             `json:"apparat_id" binding:"required"`
+	ObjectDataID              *uuid.UUID            `json:"object_data_id"`
+	BacnetObjects             []BacnetObjectInput   `json:"bacnet_objects" binding:"omitempty,dive"`
+	BacnetNumbering           *BacnetNumberingInput `json:"bacnet_numbering" binding:"omitempty"`
 }
 
 type UpdateFieldDeviceRequest struct {
@@ -47,6 +48,9 @@ type FieldDeviceResponse struct {
 	CreatedAt                 time.Time  `json:"created_at"`
 	UpdatedAt                 time.Time  `json:"updated_at"`
 
+	ObjectDataID       *uuid.UUID `json:"object_data_id,omitempty"`
+	ObjectDataRevision *uint64    `json:"object_data_revision,omitempty"`
+
 	// Embedded related entities for display
 	SPSControllerSystemType *SPSControllerSystemTypeResponse `json:"sps_controller_system_type,omitempty"`
 	Apparat                 *ApparatResponse                 `json:"apparat,omitempty"`
@@ -131,6 +135,7 @@ type BulkUpdateFieldDeviceItem struct {
 	SystemPartID    *uuid.UUID                    `json:"system_part_id"`
 	Specification   *SpecificationInput           `json:"specification" binding:"omitempty"`
 	BacnetObjects   *[]BacnetObjectBulkPatchInput `json:"bacnet_objects" binding:"omitempty,dive"`
+	BacnetNumbering *BacnetNumberingInput         `json:"bacnet_numbering" binding:"omitempty"`
 }
 
 // BulkUpdateFieldDeviceRequest represents a request to update multiple field devices
@@ -0,0 +1,94 @@
+package facility
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Facility DTOs - IP pools
+
+type CreateIPPoolRequest struct {
+	Name        string     `json:"name" binding:"required,max=150"`
+	BuildingID  *uuid.UUID `json:"building_id"`
+	ProjectID   *uuid.UUID `json:"project_id"`
+	Vlan        string     `json:"vlan" binding:"required"`
+	Network     string     `json:"network" binding:"required"`
+	Gateway     *string    `json:"gateway"`
+	RangeStart  *string    `json:"range_start"`
+	RangeEnd    *string    `json:"range_end"`
+	Description *string    `json:"description" binding:"omitempty,max=250"`
+}
+
+type UpdateIPPoolRequest struct {
+	BaseVersion uint64  `json:"base_version" binding:"required,min=1"`
+	Name        *string `json:"name" binding:"omitempty,max=150"`
+	Vlan        *string `json:"vlan"`
+	Network     *string `json:"network"`
+	Gateway     *string `json:"gateway"`
+	RangeStart  *string `json:"range_start"`
+	RangeEnd    *string `json:"range_end"`
+	Description *string `json:"description" binding:"omitempty,max=250"`
+}
+
+func (r UpdateIPPoolRequest) ExpectedVersion() uint64 { return r.BaseVersion }
+
+type IPPoolResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Version     uint64     `json:"version"`
+	Name        string     `json:"name"`
+	BuildingID  *uuid.UUID `json:"building_id"`
+	ProjectID   *uuid.UUID `json:"project_id"`
+	Vlan        string     `json:"vlan"`
+	Network     string     `json:"network"`
+	SubnetMask  string     `json:"subnet_mask"`
+	Gateway     *string    `json:"gateway"`
+	RangeStart  *string    `json:"range_start"`
+	RangeEnd    *string    `json:"range_end"`
+	Description *string    `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type IPPoolListResponse struct {
+	Items      []IPPoolResponse `json:"items"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	TotalPages int              `json:"total_pages"`
+}
+
+type IPPoolUtilizationQuery struct {
+	Vlan string `form:"vlan"`
+}
+
+type NextIPAddressQuery struct {
+	Vlan string `form:"vlan" binding:"required"`
+}
+
+type IPAllocationResponse struct {
+	PoolID    uuid.UUID `json:"pool_id"`
+	IPAddress string    `json:"ip_address"`
+	Subnet    string    `json:"subnet"`
+	Gateway   *string   `json:"gateway"`
+	Vlan      string    `json:"vlan"`
+}
+
+type IPAddressIssueResponse struct {
+	SPSControllerID uuid.UUID `json:"sps_controller_id"`
+	DeviceName      string    `json:"device_name"`
+	Kind            string    `json:"kind"`
+	Value           string    `json:"value"`
+}
+
+type IPPoolUtilizationResponse struct {
+	Pool        IPPoolResponse           `json:"pool"`
+	Capacity    int                      `json:"capacity"`
+	Used        int                      `json:"used"`
+	Free        int                      `json:"free"`
+	Utilization float64                  `json:"utilization"`
+	Issues      []IPAddressIssueResponse `json:"issues"`
+}
+
+type IPPoolUtilizationReportResponse struct {
+	Items []IPPoolUtilizationResponse `json:"items"`
+}
@@ -0,0 +1,88 @@
+package facility
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Facility DTOs - BACnet naming schemes
+
+type NamingPatternRequest struct {
+	Pattern   string `json:"pattern" binding:"required,max=500"`
+	MaxLength int    `json:"max_length" binding:"min=0,max=255"`
+	Uppercase bool   `json:"uppercase"`
+}
+
+type CreateNamingSchemeRequest struct {
+	Name           string               `json:"name" binding:"required,max=150"`
+	Client         string               `json:"client" binding:"max=150"`
+	ProjectID      *uuid.UUID           `json:"project_id"`
+	IsDefault      bool                 `json:"is_default"`
+	ControllerName NamingPatternRequest `json:"controller_name" binding:"required"`
+	ObjectName     NamingPatternRequest `json:"object_name" binding:"required"`
+}
+
+type UpdateNamingSchemeRequest struct {
+	BaseVersion    uint64                `json:"base_version" binding:"required,min=1"`
+	Name           *string               `json:"name" binding:"omitempty,max=150"`
+	Client         *string               `json:"client" binding:"omitempty,max=150"`
+	IsDefault      *bool                 `json:"is_default"`
+	ControllerName *NamingPatternRequest `json:"controller_name"`
+	ObjectName     *NamingPatternRequest `json:"object_name"`
+}
+
+func (r UpdateNamingSchemeRequest) ExpectedVersion() uint64 { return r.BaseVersion }
+
+type NamingPatternResponse struct {
+	Pattern   string `json:"pattern"`
+	MaxLength int    `json:"max_length"`
+	Uppercase bool   `json:"uppercase"`
+}
+
+type NamingSchemeResponse struct {
+	ID             uuid.UUID             `json:"id"`
+	Version        uint64                `json:"version"`
+	Name           string                `json:"name"`
+	Client         string                `json:"client"`
+	ProjectID      *uuid.UUID            `json:"project_id"`
+	IsDefault      bool                  `json:"is_default"`
+	ControllerName NamingPatternResponse `json:"controller_name"`
+	ObjectName     NamingPatternResponse `json:"object_name"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+type NamingSchemeListResponse struct {
+	Items      []NamingSchemeResponse `json:"items"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+// NamingPreviewRequest renders an unsaved scheme against an existing
+// controller so the UI can show names before the scheme is stored.
+type NamingPreviewRequest struct {
+	SPSControllerID uuid.UUID            `json:"sps_controller_id" binding:"required"`
+	ControllerName  NamingPatternRequest `json:"controller_name" binding:"required"`
+	ObjectName      NamingPatternRequest `json:"object_name" binding:"required"`
+}
+
+type NamingPreviewObjectResponse struct {
+	BacnetObjectID uuid.UUID `json:"bacnet_object_id"`
+	FieldDeviceID  uuid.UUID `json:"field_device_id"`
+	Name           string    `json:"name"`
+}
+
+type NamingIssueResponse struct {
+	Kind     string     `json:"kind"`
+	Name     string     `json:"name"`
+	ObjectID *uuid.UUID `json:"object_id"`
+}
+
+type NamingPreviewResponse struct {
+	SPSControllerID uuid.UUID                     `json:"sps_controller_id"`
+	ControllerName  string                        `json:"controller_name"`
+	Objects         []NamingPreviewObjectResponse `json:"objects"`
+	Issues          []NamingIssueResponse         `json:"issues"`
+}
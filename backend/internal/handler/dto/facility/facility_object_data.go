@@ -38,6 +38,9 @@ type ObjectDataResponse struct {
 	BacnetObjects []BacnetObjectResponse `json:"bacnet_objects"`
 	CreatedAt     time.Time              `json:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at"`
+
+	ForkedFromID       *uuid.UUID `json:"forked_from_id,omitempty"`
+	ForkedFromRevision *uint64    `json:"forked_from_revision,omitempty"`
 }
 
 type ObjectDataListResponse struct {
@@ -46,3 +49,70 @@ type ObjectDataListResponse struct {
 	Page       int                  `json:"page"`
 	TotalPages int                  `json:"total_pages"`
 }
+
+type ObjectDataRevisionResponse struct {
+	ObjectDataID uuid.UUID `json:"object_data_id"`
+	Revision     uint64    `json:"revision"`
+	Label        string    `json:"label"`
+	Fingerprint  string    `json:"fingerprint"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ObjectDataTemplateResponse struct {
+	ID             uuid.UUID `json:"id"`
+	TextFix        string    `json:"text_fix"`
+	Description    *string   `json:"description"`
+	SoftwareType   string    `json:"software_type"`
+	SoftwareNumber uint16    `json:"software_number"`
+	HardwareType   string    `json:"hardware_type"`
+}
+
+type ObjectDataTemplateChangeResponse struct {
+	Before ObjectDataTemplateResponse `json:"before"`
+	After  ObjectDataTemplateResponse `json:"after"`
+	Fields []string                   `json:"fields"`
+}
+
+type ObjectDataDiffResponse struct {
+	ObjectDataID uuid.UUID                          `json:"object_data_id"`
+	FromRevision uint64                             `json:"from_revision"`
+	ToRevision   uint64                             `json:"to_revision"`
+	Added        []ObjectDataTemplateResponse       `json:"added"`
+	Removed      []ObjectDataTemplateResponse       `json:"removed"`
+	Changed      []ObjectDataTemplateChangeResponse `json:"changed"`
+}
+
+type ObjectDataTemplateConflictResponse struct {
+	Base   *ObjectDataTemplateResponse `json:"base,omitempty"`
+	Ours   *ObjectDataTemplateResponse `json:"ours"`
+	Theirs *ObjectDataTemplateResponse `json:"theirs"`
+	Fields []string                    `json:"fields"`
+}
+
+type ObjectDataForkDiffResponse struct {
+	ObjectDataID   uuid.UUID                            `json:"object_data_id"`
+	OriginID       uuid.UUID                            `json:"origin_id"`
+	BaseRevision   uint64                               `json:"base_revision"`
+	OriginRevision uint64                               `json:"origin_revision"`
+	OriginMoved    bool                                 `json:"origin_moved"`
+	Ours           ObjectDataDiffResponse               `json:"ours"`
+	Theirs         ObjectDataDiffResponse               `json:"theirs"`
+	Conflicts      []ObjectDataTemplateConflictResponse `json:"conflicts"`
+}
+
+type ForkObjectDataRequest struct {
+	ObjectDataID uuid.UUID `json:"object_data_id" binding:"required"`
+}
+
+type PromoteObjectDataRequest struct {
+	BaseVersion uint64 `json:"base_version" binding:"required,min=1"`
+}
+
+type UpgradeObjectDataDevicesRequest struct {
+	FieldDeviceIDs []uuid.UUID `json:"field_device_ids"`
+}
+
+type ObjectDataDiffQuery struct {
+	From uint64 `form:"from" binding:"required,min=1"`
+	To   uint64 `form:"to"`
+}
@@ -18,6 +18,7 @@ type CreateSPSControllerRequest struct {
 	Subnet            *string                        `json:"subnet" binding:"omitempty,max=50"`
 	Gateway           *string                        `json:"gateway" binding:"omitempty,max=50"`
 	Vlan              *string                        `json:"vlan" binding:"omitempty,max=50"`
+	MACAddress        *string                        `json:"mac_address" binding:"omitempty,max=50"`
 	SystemTypes       []SPSControllerSystemTypeInput `json:"system_types" binding:"omitempty,dive"`
 }
 
@@ -32,6 +33,7 @@ type UpdateSPSControllerRequest struct {
 	Subnet            *string                         `json:"subnet" binding:"omitempty,max=50"`
 	Gateway           *string                         `json:"gateway" binding:"omitempty,max=50"`
 	Vlan              *string                         `json:"vlan" binding:"omitempty,max=50"`
+	MACAddress        *string                         `json:"mac_address" binding:"omitempty,max=50"`
 	SystemTypes       *[]SPSControllerSystemTypeInput `json:"system_types" binding:"omitempty,dive"`
 }
 
@@ -54,6 +56,7 @@ type SPSControllerResponse struct {
 	Subnet            *string   `json:"subnet"`
 	Gateway           *string   `json:"gateway"`
 	Vlan              *string   `json:"vlan"`
+	MACAddress        *string   `json:"mac_address"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
@@ -0,0 +1,16 @@
+package facility
+
+import "github.com/google/uuid"
+
+// ReplaceTranslationsRequest carries the texts of one locale keyed by field,
+// e.g. "state_text_1" or "name". Omitted or blank fields use the base text.
+type ReplaceTranslationsRequest struct {
+	Fields map[string]string `json:"fields" binding:"required"`
+}
+
+// EntityTranslationsResponse lists the stored texts of one entity grouped by
+// locale and field.
+type EntityTranslationsResponse struct {
+	EntityID     uuid.UUID                    `json:"entity_id"`
+	Translations map[string]map[string]string `json:"translations"`
+}
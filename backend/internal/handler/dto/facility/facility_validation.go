@@ -25,4 +25,5 @@ type ValidateSPSControllerRequest struct {
 	Subnet           *string    `json:"subnet"`
 	Gateway          *string    `json:"gateway"`
 	Vlan             *string    `json:"vlan"`
+	MACAddress       *string    `json:"mac_address"`
 }
@@ -65,6 +65,19 @@ func UndoConflictResponseFrom(conflict domainHistory.UndoConflict) UndoConflictR
 	}
 }
 
+type RestorePreviewResponse struct {
+	domainHistory.RestorePreview
+	Conflicts []UndoConflictResponse `json:"conflicts"`
+}
+
+func RestorePreviewResponseFrom(preview *domainHistory.RestorePreview) RestorePreviewResponse {
+	conflicts := make([]UndoConflictResponse, len(preview.Conflicts))
+	for index, conflict := range preview.Conflicts {
+		conflicts[index] = UndoConflictResponseFrom(conflict)
+	}
+	return RestorePreviewResponse{RestorePreview: *preview, Conflicts: conflicts}
+}
+
 func TimelineResponseFrom(list *domain.PaginatedList[domainHistory.ChangeEvent]) TimelineResponse {
 	return TimelineResponse{Items: changeEventResponses(list.Items), Total: list.Total, Page: list.Page, TotalPages: list.TotalPages}
 }
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,9 +25,36 @@ type ProjectChangeResponse struct {
 }
 
 type ProjectChangesResponse struct {
-	ProjectID       uuid.UUID               `json:"project_id"`
-	CurrentRevision uint64                  `json:"current_revision"`
-	Events          []ProjectChangeResponse `json:"events"`
-	HasMore         bool                    `json:"has_more"`
-	ResetRequired   bool                    `json:"reset_required"`
+	ProjectID        uuid.UUID               `json:"project_id"`
+	CurrentRevision  uint64                  `json:"current_revision"`
+	CompactedThrough uint64                  `json:"compacted_through"`
+	Events           []ProjectChangeResponse `json:"events"`
+	HasMore          bool                    `json:"has_more"`
+	ResetRequired    bool                    `json:"reset_required"`
+}
+
+// UpdateProjectChangeRetentionRequest sets the retention in days; null
+// restores the default.
+type UpdateProjectChangeRetentionRequest struct {
+	RetentionDays *int `json:"retention_days" binding:"omitempty,min=1,max=3650"`
+}
+
+type ProjectChangeRetentionResponse struct {
+	ProjectID        uuid.UUID `json:"project_id"`
+	RetentionDays    int       `json:"retention_days"`
+	Custom           bool      `json:"custom"`
+	CompactedThrough uint64    `json:"compacted_through"`
+}
+
+type ProjectAggregateStateResponse struct {
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	State         json.RawMessage `json:"state" swaggertype:"object"`
+}
+
+type ProjectChangeSnapshotResponse struct {
+	ProjectID  uuid.UUID                       `json:"project_id"`
+	Revision   uint64                          `json:"revision"`
+	TakenAt    time.Time                       `json:"taken_at"`
+	Aggregates []ProjectAggregateStateResponse `json:"aggregates"`
 }
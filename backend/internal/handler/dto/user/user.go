@@ -32,6 +32,11 @@ type UpdateOwnPasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required,min=8"`
 }
 
+// UpdateOwnLocaleRequest sets the preferred locale; an empty locale clears it.
+type UpdateOwnLocaleRequest struct {
+	Locale string `json:"locale" binding:"max=16"`
+}
+
 type UserResponse struct {
 	ID                  uuid.UUID  `json:"id"`
 	FirstName           string     `json:"first_name"`
@@ -50,6 +55,8 @@ type UserResponse struct {
 	IsAnonymized        bool       `json:"is_anonymized"`
 	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
 	RestoreUntil        *time.Time `json:"restore_until,omitempty"`
+
+	Locale string `json:"locale,omitempty"`
 }
 
 type UserListResponse struct {
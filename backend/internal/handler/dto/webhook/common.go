@@ -0,0 +1,5 @@
+package webhook
+
+import commondto "github.com/besart951/go_infra_link/backend/internal/handler/dto/common"
+
+type ErrorResponse = commondto.ErrorResponse
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UpsertSubscriptionRequest struct {
+	Name           string     `json:"name" binding:"required"`
+	URL            string     `json:"url" binding:"required"`
+	ProjectID      *uuid.UUID `json:"project_id"`
+	Active         *bool      `json:"active" binding:"required"`
+	Secret         string     `json:"secret"`
+	EventTypes     []string   `json:"event_types"`
+	AggregateTypes []string   `json:"aggregate_types"`
+	Actions        []string   `json:"actions"`
+	JobStatuses    []string   `json:"job_statuses"`
+}
+
+type SubscriptionResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	URL            string     `json:"url"`
+	ProjectID      *uuid.UUID `json:"project_id,omitempty"`
+	Active         bool       `json:"active"`
+	EventTypes     []string   `json:"event_types"`
+	AggregateTypes []string   `json:"aggregate_types"`
+	Actions        []string   `json:"actions"`
+	JobStatuses    []string   `json:"job_statuses"`
+	CreatedByID    *uuid.UUID `json:"created_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	// Secret is only returned when it was generated or replaced.
+	Secret string `json:"secret,omitempty"`
+}
+
+type SubscriptionListResponse struct {
+	Items []SubscriptionResponse `json:"items"`
+}
+
+type DeliveryResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	EventType      string          `json:"event_type"`
+	EventID        uuid.UUID       `json:"event_id"`
+	ProjectID      *uuid.UUID      `json:"project_id,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type DeliveryListResponse struct {
+	Items      []DeliveryResponse `json:"items"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	TotalPages int                `json:"total_pages"`
+}
+
+type ReplayDeliveriesRequest struct {
+	SubscriptionID *uuid.UUID `json:"subscription_id"`
+}
+
+type ReplayDeliveriesResponse struct {
+	Replayed int64 `json:"replayed"`
+}
@@ -12,6 +12,10 @@ type Handlers struct {
 	CreateAlarmDefinition gin.HandlerFunc
 	UpdateAlarmDefinition gin.HandlerFunc
 	DeleteAlarmDefinition gin.HandlerFunc
+	ListFieldOverrides    gin.HandlerFunc
+	CreateFieldOverride   gin.HandlerFunc
+	UpdateFieldOverride   gin.HandlerFunc
+	DeleteFieldOverride   gin.HandlerFunc
 	ListAlarmTypes        gin.HandlerFunc
 	CreateAlarmType       gin.HandlerFunc
 	GetAlarmType          gin.HandlerFunc
@@ -26,6 +30,7 @@ type Handlers struct {
 	CreateUnit            gin.HandlerFunc
 	UpdateUnit            gin.HandlerFunc
 	DeleteUnit            gin.HandlerFunc
+	ListUnitDimensions    gin.HandlerFunc
 	ListAlarmFields       gin.HandlerFunc
 	GetAlarmField         gin.HandlerFunc
 	CreateAlarmField      gin.HandlerFunc
@@ -34,6 +39,7 @@ type Handlers struct {
 	GetAlarmSchema        gin.HandlerFunc
 	GetAlarmValues        gin.HandlerFunc
 	PutAlarmValues        gin.HandlerFunc
+	BulkEditAlarmValues   gin.HandlerFunc
 }
 
 func Routes(handlers Handlers) []routing.Definition {
@@ -43,6 +49,10 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Post("/alarm-definitions", domainUser.PermissionAlarmDefinitionCreate, handlers.CreateAlarmDefinition),
 		routing.Put("/alarm-definitions/:id", domainUser.PermissionAlarmDefinitionUpdate, handlers.UpdateAlarmDefinition),
 		routing.Delete("/alarm-definitions/:id", domainUser.PermissionAlarmDefinitionDelete, handlers.DeleteAlarmDefinition),
+		routing.Get("/alarm-definitions/:id/field-overrides", domainUser.PermissionAlarmDefinitionRead, handlers.ListFieldOverrides),
+		routing.Post("/alarm-definitions/:id/field-overrides", domainUser.PermissionAlarmDefinitionUpdate, handlers.CreateFieldOverride),
+		routing.Put("/alarm-definition-field-overrides/:id", domainUser.PermissionAlarmDefinitionUpdate, handlers.UpdateFieldOverride),
+		routing.Delete("/alarm-definition-field-overrides/:id", domainUser.PermissionAlarmDefinitionUpdate, handlers.DeleteFieldOverride),
 		routing.Get("/alarm-types", domainUser.PermissionAlarmTypeRead, handlers.ListAlarmTypes),
 		routing.Post("/alarm-types", domainUser.PermissionAlarmTypeCreate, handlers.CreateAlarmType),
 		routing.Get("/alarm-types/:id", domainUser.PermissionAlarmTypeRead, handlers.GetAlarmType),
@@ -57,6 +67,7 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Post("/alarm-units", domainUser.PermissionUnitCreate, handlers.CreateUnit),
 		routing.Put("/alarm-units/:id", domainUser.PermissionUnitUpdate, handlers.UpdateUnit),
 		routing.Delete("/alarm-units/:id", domainUser.PermissionUnitDelete, handlers.DeleteUnit),
+		routing.Get("/alarm-unit-dimensions", domainUser.PermissionUnitRead, handlers.ListUnitDimensions),
 		routing.Get("/alarm-fields", domainUser.PermissionAlarmFieldRead, handlers.ListAlarmFields),
 		routing.Get("/alarm-fields/:id", domainUser.PermissionAlarmFieldRead, handlers.GetAlarmField),
 		routing.Post("/alarm-fields", domainUser.PermissionAlarmFieldCreate, handlers.CreateAlarmField),
@@ -65,5 +76,6 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Get("/bacnet-objects/:id/alarm-schema", domainUser.PermissionBacnetObjectRead, handlers.GetAlarmSchema),
 		routing.Get("/bacnet-objects/:id/alarm-values", domainUser.PermissionBacnetObjectRead, handlers.GetAlarmValues),
 		routing.Put("/bacnet-objects/:id/alarm-values", domainUser.PermissionBacnetObjectUpdate, handlers.PutAlarmValues),
+		routing.Post("/bacnet-objects/alarm-values/bulk-edit", domainUser.PermissionBacnetObjectUpdate, handlers.BulkEditAlarmValues),
 	}
 }
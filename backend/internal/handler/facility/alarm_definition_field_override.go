@@ -0,0 +1,128 @@
+package facility
+
+import (
+	"net/http"
+
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	"github.com/gin-gonic/gin"
+)
+
+type AlarmDefinitionFieldOverrideHandler struct {
+	service AlarmDefinitionFieldOverrideService
+}
+
+func NewAlarmDefinitionFieldOverrideHandler(service AlarmDefinitionFieldOverrideService) *AlarmDefinitionFieldOverrideHandler {
+	return &AlarmDefinitionFieldOverrideHandler{service: service}
+}
+
+// ListAlarmDefinitionFieldOverrides godoc
+// @Summary List the field overrides of an alarm definition
+// @Tags facility-alarm-definitions
+// @Produce json
+// @Param id path string true "Alarm Definition ID"
+// @Success 200 {array} dto.AlarmDefinitionFieldOverrideResponse
+// @Router /api/v1/facility/alarm-definitions/{id}/field-overrides [get]
+func (h *AlarmDefinitionFieldOverrideHandler) ListAlarmDefinitionFieldOverrides(c *gin.Context) {
+	alarmDefinitionID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	items, err := h.service.ListByAlarmDefinition(c.Request.Context(), alarmDefinitionID)
+	if err != nil {
+		if respondLocalizedNotFoundIf(c, err, "facility.alarm_definition_not_found") {
+			return
+		}
+		respondLocalizedError(c, http.StatusInternalServerError, "fetch_failed", "facility.fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, mapItems(items, toAlarmDefinitionFieldOverrideResponse))
+}
+
+// CreateAlarmDefinitionFieldOverride godoc
+// @Summary Override an alarm type field for an alarm definition
+// @Tags facility-alarm-definitions
+// @Accept json
+// @Produce json
+// @Param id path string true "Alarm Definition ID"
+// @Param override body dto.CreateAlarmDefinitionFieldOverrideRequest true "Field override data"
+// @Success 201 {object} dto.AlarmDefinitionFieldOverrideResponse
+// @Router /api/v1/facility/alarm-definitions/{id}/field-overrides [post]
+func (h *AlarmDefinitionFieldOverrideHandler) CreateAlarmDefinitionFieldOverride(c *gin.Context) {
+	alarmDefinitionID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.CreateAlarmDefinitionFieldOverrideRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	item := toAlarmDefinitionFieldOverrideModel(alarmDefinitionID, req)
+	if err := h.service.Create(c.Request.Context(), item); err != nil {
+		if respondLocalizedNotFoundIf(c, err, "facility.alarm_definition_not_found") {
+			return
+		}
+		respondLocalizedValidationOrError(c, err, "facility.creation_failed")
+		return
+	}
+	c.JSON(http.StatusCreated, toAlarmDefinitionFieldOverrideResponse(*item))
+}
+
+// UpdateAlarmDefinitionFieldOverride godoc
+// @Summary Update an alarm definition field override
+// @Tags facility-alarm-definitions
+// @Accept json
+// @Produce json
+// @Param id path string true "Field Override ID"
+// @Param override body dto.UpdateAlarmDefinitionFieldOverrideRequest true "Field override data"
+// @Success 200 {object} dto.AlarmDefinitionFieldOverrideResponse
+// @Router /api/v1/facility/alarm-definition-field-overrides/{id} [put]
+func (h *AlarmDefinitionFieldOverrideHandler) UpdateAlarmDefinitionFieldOverride(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.UpdateAlarmDefinitionFieldOverrideRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	ctx := c.Request.Context()
+	item, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		if respondLocalizedNotFoundIf(c, err, "facility.not_found") {
+			return
+		}
+		respondLocalizedError(c, http.StatusInternalServerError, "fetch_failed", "facility.fetch_failed")
+		return
+	}
+	applyAlarmDefinitionFieldOverrideUpdate(item, req)
+	item.Version = req.BaseVersion
+	if err := h.service.Update(ctx, item); respondLocalizedValidationOrError(c, err, "facility.update_failed") {
+		return
+	}
+	c.JSON(http.StatusOK, toAlarmDefinitionFieldOverrideResponse(*item))
+}
+
+// DeleteAlarmDefinitionFieldOverride godoc
+// @Summary Delete an alarm definition field override
+// @Tags facility-alarm-definitions
+// @Param id path string true "Field Override ID"
+// @Param base_version query integer true "Expected aggregate version" minimum(1)
+// @Success 204
+// @Router /api/v1/facility/alarm-definition-field-overrides/{id} [delete]
+func (h *AlarmDefinitionFieldOverrideHandler) DeleteAlarmDefinitionFieldOverride(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	version, ok := parseRequiredBaseVersion(c)
+	if !ok {
+		return
+	}
+	if err := deleteAtVersion(c.Request.Context(), h.service, id, version); err != nil {
+		respondLocalizedDomainError(c, err, "deletion_failed", "facility.deletion_failed",
+			localizedNotFound("facility.not_found"),
+		)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,8 @@
 package facility
 
 import (
+	"net/http"
+
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
 	"github.com/gin-gonic/gin"
@@ -71,3 +73,13 @@ func (h *UnitHandler) UpdateUnit(c *gin.Context) { h.crud.handleUpdate(c) }
 // @Success 204
 // @Router /api/v1/facility/alarm-units/{id} [delete]
 func (h *UnitHandler) DeleteUnit(c *gin.Context) { h.crud.handleDelete(c) }
+
+// ListUnitDimensions godoc
+// @Summary List the unit dimensions units can be converted within
+// @Tags facility-alarm-units
+// @Produce json
+// @Success 200 {array} dto.UnitDimensionResponse
+// @Router /api/v1/facility/alarm-unit-dimensions [get]
+func (h *UnitHandler) ListUnitDimensions(c *gin.Context) {
+	c.JSON(http.StatusOK, mapItems(domainFacility.UnitDimensions, toUnitDimensionResponse))
+}
@@ -5,16 +5,22 @@ import (
 
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type BacnetAlarmHandler struct {
-	service BacnetAlarmValueService
+	service      BacnetAlarmValueService
+	facilityJobs *facilityservice.FacilityJobManager
 }
 
-func NewBacnetAlarmHandler(service BacnetAlarmValueService) *BacnetAlarmHandler {
-	return &BacnetAlarmHandler{service: service}
+func NewBacnetAlarmHandler(service BacnetAlarmValueService, facilityJobs ...*facilityservice.FacilityJobManager) *BacnetAlarmHandler {
+	handler := &BacnetAlarmHandler{service: service}
+	if len(facilityJobs) > 0 {
+		handler.facilityJobs = facilityJobs[0]
+	}
+	return handler
 }
 
 // GetAlarmSchema godoc
@@ -48,6 +54,7 @@ func (h *BacnetAlarmHandler) GetAlarmSchema(c *gin.Context) {
 // @Tags facility-bacnet-alarm
 // @Produce json
 // @Param id path string true "BacnetObject ID"
+// @Param units query string false "Comma separated unit codes to render values in, one per dimension"
 // @Success 200 {object} dto.AlarmValuesResponse
 // @Router /api/v1/facility/bacnet-objects/{id}/alarm-values [get]
 func (h *BacnetAlarmHandler) GetAlarmValues(c *gin.Context) {
@@ -56,11 +63,15 @@ func (h *BacnetAlarmHandler) GetAlarmValues(c *gin.Context) {
 		return
 	}
 
-	values, err := h.service.GetValues(c.Request.Context(), id)
+	ctx := c.Request.Context()
+	values, err := h.service.GetValues(ctx, id)
 	if err != nil {
 		respondLocalizedError(c, http.StatusInternalServerError, "fetch_failed", "facility.fetch_failed")
 		return
 	}
+	if err := h.service.RenderValues(ctx, values.Items, parseStringListQueryParam(c, "units")); respondLocalizedValidationOrError(c, err, "facility.fetch_failed") {
+		return
+	}
 
 	c.JSON(http.StatusOK, toAlarmValuesResponse(*values))
 }
@@ -103,6 +114,54 @@ func (h *BacnetAlarmHandler) PutAlarmValues(c *gin.Context) {
 	c.JSON(http.StatusOK, toAlarmValuesResponse(*updated))
 }
 
+// BulkEditAlarmValues godoc
+// @Summary Bulk edit alarm values of selected BACnet objects
+// @Description Queues a facility job that applies literal values or formulas such as "high_limit = setpoint + 2" to every BACnet object matching the selection. Objects changed while the job runs are reported as conflicts.
+// @Tags facility-bacnet-alarm
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkEditAlarmValuesRequest true "Selection and assignments"
+// @Success 202 {object} dto.FacilityJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/bacnet-objects/alarm-values/bulk-edit [post]
+func (h *BacnetAlarmHandler) BulkEditAlarmValues(c *gin.Context) {
+	var req dto.BulkEditAlarmValuesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	edit := toAlarmValueBulkEdit(req)
+	if respondLocalizedValidationOrError(c, h.service.ValidateBulkEdit(edit), "facility.update_failed") {
+		return
+	}
+	submitFacilityBulkJob(c, h.facilityJobs, facilityservice.FacilityJobKindBacnetObject,
+		facilityservice.FacilityJobTaskBulkEditAlarmValues, facilityservice.AlarmValueBulkEditTaskPayload{Edit: edit}, nil)
+}
+
+func toAlarmValueBulkEdit(req dto.BulkEditAlarmValuesRequest) domainFacility.AlarmValueBulkEdit {
+	assignments := make([]domainFacility.AlarmValueAssignment, len(req.Assignments))
+	for i, input := range req.Assignments {
+		assignments[i] = domainFacility.AlarmValueAssignment{
+			FieldKey: input.FieldKey,
+			Value:    input.Value,
+			Formula:  input.Formula,
+			UnitID:   input.UnitID,
+		}
+	}
+	return domainFacility.AlarmValueBulkEdit{
+		Selection: domainFacility.BacnetObjectSelection{
+			ProjectID:       req.Selection.ProjectID,
+			SPSControllerID: req.Selection.SPSControllerID,
+			SystemPartID:    req.Selection.SystemPartID,
+			ApparatID:       req.Selection.ApparatID,
+			AlarmTypeID:     req.Selection.AlarmTypeID,
+			TextFix:         req.Selection.TextFix,
+		},
+		Assignments: assignments,
+	}
+}
+
 // toAlarmValueModels converts DTO alarm value inputs to domain models,
 // setting the bacnetObjectID on each and defaulting source to "user" if empty.
 func toAlarmValueModels(bacnetObjectID uuid.UUID, inputs []dto.AlarmValueInput) []domainFacility.BacnetObjectAlarmValue {
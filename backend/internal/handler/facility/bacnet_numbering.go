@@ -0,0 +1,62 @@
+package facility
+
+import (
+	"net/http"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewBacnetNumbering godoc
+// @Summary Preview automatic BACnet instance numbers
+// @Description Proposes free instance numbers per SPS controller and object type without applying them. Free, in-range numbers are kept.
+// @Tags facility-field-devices
+// @Accept json
+// @Produce json
+// @Param request body dto.BacnetNumberingPreviewRequest true "Controller, field devices and strategy"
+// @Success 200 {object} dto.BacnetNumberingPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/field-devices/bacnet-numbering/preview [post]
+func (h *FieldDeviceHandler) PreviewBacnetNumbering(c *gin.Context) {
+	var req dto.BacnetNumberingPreviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	preview, err := h.service.PreviewBacnetNumbering(c.Request.Context(), domainFacility.BacnetNumberingRequest{
+		SPSControllerID: req.SPSControllerID,
+		FieldDeviceIDs:  req.FieldDeviceIDs,
+		Numbering: domainFacility.BacnetNumbering{
+			Strategy: domainFacility.BacnetNumberingStrategy(req.Numbering.Strategy), BlockSize: req.Numbering.BlockSize,
+		},
+	})
+	if err != nil {
+		if suppressCanceledRequestError(c, err) {
+			return
+		}
+		respondLocalizedDomainError(c, err, "fetch_failed", "facility.fetch_failed",
+			handlerutil.MapError(facilityservice.ErrBacnetNumberingUnavailable, handlerutil.LocalizedError(http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")),
+		)
+		return
+	}
+	c.JSON(http.StatusOK, toBacnetNumberingPreviewResponse(preview))
+}
+
+func toBacnetNumberingPreviewResponse(preview *domainFacility.BacnetNumberingPreview) dto.BacnetNumberingPreviewResponse {
+	proposals := make([]dto.BacnetNumberProposalResponse, len(preview.Proposals))
+	for index, proposal := range preview.Proposals {
+		proposals[index] = dto.BacnetNumberProposalResponse{
+			BacnetObjectID: proposal.BacnetObjectID, FieldDeviceID: proposal.FieldDeviceID,
+			SoftwareType:  string(proposal.SoftwareType),
+			CurrentNumber: proposal.CurrentNumber, ProposedNumber: proposal.ProposedNumber, Changed: proposal.Changed,
+		}
+	}
+	return dto.BacnetNumberingPreviewResponse{
+		SPSControllerID: preview.SPSControllerID, Strategy: string(preview.Strategy),
+		Proposals: proposals, Changed: preview.Changed,
+	}
+}
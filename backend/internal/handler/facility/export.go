@@ -7,6 +7,7 @@ import (
 	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
 	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	sharedpresenter "github.com/besart951/go_infra_link/backend/internal/handler/presenter/shared"
 	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -31,6 +32,12 @@ func (h *ExportHandler) CreateFieldDeviceExport(c *gin.Context) {
 		return
 	}
 
+	locale, ok := domainExport.ParseLocale(req.Locale)
+	if !ok {
+		respondLocalizedInvalidArgument(c, "facility.invalid_locale")
+		return
+	}
+
 	ownerID, ok := middleware.GetUserID(c)
 	if !ok {
 		respondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
@@ -50,13 +57,35 @@ func (h *ExportHandler) CreateFieldDeviceExport(c *gin.Context) {
 		Search:                     req.Search,
 		ExportAll:                  req.ExportAll,
 		ForceAsync:                 req.ForceAsync,
+		OutputType:                 domainExport.OutputType(req.OutputType),
+		CollisionPolicy:            domainExport.CollisionPolicy(req.CollisionPolicy),
+		Locale:                     locale,
 	})
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "export_creation_failed", err.Error())
 		return
 	}
 
-	c.JSON(http.StatusAccepted, toExportJobResponse(job))
+	c.JSON(http.StatusAccepted, sharedpresenter.ToExportJobResponse(job))
+}
+
+func (h *ExportHandler) AnalyzeExportCollisions(c *gin.Context) {
+	var req dto.AnalyzeExportCollisionsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if len(req.ProjectIDs) == 0 && len(req.BuildingIDs) == 0 {
+		respondLocalizedInvalidArgument(c, "facility.export_scope_required")
+		return
+	}
+	report, err := h.service.AnalyzeCollisions(c.Request.Context(), domainExport.Request{
+		ProjectIDs: req.ProjectIDs, BuildingIDs: req.BuildingIDs,
+	})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "collision_analysis_failed", err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, sharedpresenter.ToExportCollisionReportResponse(report))
 }
 
 func (h *ExportHandler) GetExportStatus(c *gin.Context) {
@@ -79,7 +108,7 @@ func (h *ExportHandler) GetExportStatus(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toExportJobResponse(job))
+	c.JSON(http.StatusOK, sharedpresenter.ToExportJobResponse(job))
 }
 
 func (h *ExportHandler) DownloadExport(c *gin.Context) {
@@ -140,22 +169,3 @@ func (h *ExportHandler) canAccessExport(c *gin.Context, ownerID uuid.UUID, job d
 	}
 	return true
 }
-
-func toExportJobResponse(job domainExport.Job) dto.FieldDeviceExportJobResponse {
-	res := dto.FieldDeviceExportJobResponse{
-		JobID:       job.ID,
-		Status:      string(job.Status),
-		Progress:    job.Progress,
-		Message:     job.Message,
-		OutputType:  string(job.OutputType),
-		FileName:    job.FileName,
-		ContentType: job.ContentType,
-		Error:       job.Error,
-		CreatedAt:   job.CreatedAt,
-		UpdatedAt:   job.UpdatedAt,
-	}
-	if job.Status == domainExport.StatusCompleted {
-		res.DownloadURL = "/api/v1/facility/exports/jobs/" + job.ID.String() + "/download"
-	}
-	return res
-}
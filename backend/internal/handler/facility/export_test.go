@@ -25,6 +25,10 @@ func (s exportServiceStub) Get(context.Context, uuid.UUID, uuid.UUID) (domainexp
 	return s.job, nil
 }
 
+func (s exportServiceStub) AnalyzeCollisions(context.Context, domainexport.Request) (domainexport.CollisionReport, error) {
+	return domainexport.CollisionReport{}, nil
+}
+
 type exportAuthorizerStub struct {
 	allowed       bool
 	authorization domainexport.DownloadAuthorization
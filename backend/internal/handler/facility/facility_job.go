@@ -18,6 +18,10 @@ import (
 )
 
 func enqueueFacilityCopy(c *gin.Context, jobs *facilityservice.FacilityJobManager, kind facilityservice.FacilityJobKind, sourceID uuid.UUID) bool {
+	return enqueueFacilityCopyPayload(c, jobs, kind, facilityservice.FacilityCopyTaskPayload{SourceID: sourceID})
+}
+
+func enqueueFacilityCopyPayload(c *gin.Context, jobs *facilityservice.FacilityJobManager, kind facilityservice.FacilityJobKind, task facilityservice.FacilityCopyTaskPayload) bool {
 	if jobs == nil || !jobs.SupportsDurableTasks() {
 		respondLocalizedError(c, http.StatusServiceUnavailable, "durable_jobs_unavailable", "errors.service_unavailable")
 		return true
@@ -31,7 +35,7 @@ func enqueueFacilityCopy(c *gin.Context, jobs *facilityservice.FacilityJobManage
 		respondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
 		return true
 	}
-	payload, err := json.Marshal(facilityservice.FacilityCopyTaskPayload{SourceID: sourceID})
+	payload, err := json.Marshal(task)
 	if err != nil {
 		respondLocalizedError(c, http.StatusInternalServerError, "copy_failed", "facility.copy_failed")
 		return true
@@ -50,6 +54,13 @@ func enqueueFacilityCopy(c *gin.Context, jobs *facilityservice.FacilityJobManage
 }
 
 func submitFieldDeviceBulkJob(c *gin.Context, jobs *facilityservice.FacilityJobManager, task string, payload any, total int) bool {
+	totalItems := int64(total)
+	return submitFacilityBulkJob(c, jobs, facilityservice.FacilityJobKindFieldDevice, task, payload, &totalItems)
+}
+
+// submitFacilityBulkJob queues a durable bulk task. total is nil when the
+// job resolves its items only once it runs.
+func submitFacilityBulkJob(c *gin.Context, jobs *facilityservice.FacilityJobManager, kind facilityservice.FacilityJobKind, task string, payload any, total *int64) bool {
 	if jobs == nil || !jobs.SupportsDurableTasks() {
 		respondLocalizedError(c, http.StatusServiceUnavailable, "durable_jobs_unavailable", "errors.service_unavailable")
 		return true
@@ -68,11 +79,10 @@ func submitFieldDeviceBulkJob(c *gin.Context, jobs *facilityservice.FacilityJobM
 		respondLocalizedError(c, http.StatusBadRequest, "invalid_bulk_payload", "validation.invalid_request")
 		return true
 	}
-	totalItems := int64(total)
 	job, err := jobs.SubmitTask(c.Request.Context(), facilityservice.FacilityJob{
-		ID: operationID, OwnerID: actorID, Kind: facilityservice.FacilityJobKindFieldDevice,
+		ID: operationID, OwnerID: actorID, Kind: kind,
 		Class: facilityservice.FacilityJobClassMutation, Type: facilityservice.FacilityJobTypeBulk,
-		Task: task, Payload: encoded, Total: &totalItems,
+		Task: task, Payload: encoded, Total: total,
 	})
 	if err != nil {
 		respondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
@@ -112,6 +112,8 @@ func (h *FieldDeviceHandler) GetFieldDevice(c *gin.Context) {
 // @Tags facility-field-devices
 // @Produce json
 // @Param id path string true "Field Device ID"
+// @Param numbering query string false "Renumber the copied BACnet objects (sequential, system_type_block, field_device_block)"
+// @Param block_size query int false "Instances per field device for field_device_block"
 // @Param Idempotency-Key header string false "Client-generated operation UUID"
 // @Success 202 {object} dto.FacilityJobResponse
 // @Failure 400 {object} dto.ErrorResponse
@@ -122,7 +124,17 @@ func (h *FieldDeviceHandler) CopyFieldDevice(c *gin.Context) {
 	if !ok {
 		return
 	}
-	enqueueFacilityCopy(c, h.facilityJobs, facilityservice.FacilityJobKindFieldDevice, id)
+	var query dto.BacnetNumberingQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+	payload := facilityservice.FacilityCopyTaskPayload{SourceID: id}
+	if query.Numbering != "" {
+		payload.Numbering = &domainFacility.BacnetNumbering{
+			Strategy: domainFacility.BacnetNumberingStrategy(query.Numbering), BlockSize: query.BlockSize,
+		}
+	}
+	enqueueFacilityCopyPayload(c, h.facilityJobs, facilityservice.FacilityJobKindFieldDevice, payload)
 }
 
 // ListFieldDevices godoc
@@ -673,6 +685,7 @@ func toBulkFieldDeviceUpdate(item dto.BulkUpdateFieldDeviceItem) domainFacility.
 		TextIndividuell: item.TextIndividuell.Value, HasTextIndividuell: item.TextIndividuell.Set,
 		ApparatNr: item.ApparatNr, ApparatID: item.ApparatID, SystemPartID: item.SystemPartID,
 		Specification: specification, BacnetObjects: bacnetObjects,
+		Numbering: fielddevice.ToBacnetNumbering(item.BacnetNumbering),
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
 	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
 	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
@@ -487,3 +488,11 @@ func (s *fakeFieldDeviceHandlerService) BulkDeleteCommands(context.Context, []do
 	s.bulkDeleteCalls++
 	return &domainFacility.BulkOperationResult{}
 }
+
+func (s *fakeFieldDeviceHandlerService) PreviewBacnetNumbering(context.Context, domainFacility.BacnetNumberingRequest) (*domainFacility.BacnetNumberingPreview, error) {
+	return &domainFacility.BacnetNumberingPreview{}, nil
+}
+
+func (s *fakeFieldDeviceHandlerService) ObjectDataDiff(context.Context, uuid.UUID) (*domainObjectData.TemplateDiff, error) {
+	return &domainObjectData.TemplateDiff{}, nil
+}
@@ -28,11 +28,24 @@ func CreateItems(requests []dto.CreateFieldDeviceRequest) []domainFacility.Field
 			},
 			ObjectDataID:  request.ObjectDataID,
 			BacnetObjects: ToBacnetObjects(request.BacnetObjects),
+			Numbering:     ToBacnetNumbering(request.BacnetNumbering),
 		}
 	}
 	return items
 }
 
+// ToBacnetNumbering maps the optional numbering input; nil keeps the
+// instance numbers the caller or the template supplied.
+func ToBacnetNumbering(input *dto.BacnetNumberingInput) *domainFacility.BacnetNumbering {
+	if input == nil {
+		return nil
+	}
+	return &domainFacility.BacnetNumbering{
+		Strategy:  domainFacility.BacnetNumberingStrategy(input.Strategy),
+		BlockSize: input.BlockSize,
+	}
+}
+
 // ToBacnetObjects maps nested field-device BACnet input consistently for all
 // creation and template flows.
 func ToBacnetObjects(inputs []dto.BacnetObjectInput) []domainFacility.BacnetObject {
@@ -22,10 +22,18 @@ type Handlers struct {
 	DeleteFieldDevice              gin.HandlerFunc
 	BulkUpdateFieldDevices         gin.HandlerFunc
 	BulkDeleteFieldDevices         gin.HandlerFunc
+	PreviewBacnetNumbering         gin.HandlerFunc
 	CreateFieldDeviceExport        gin.HandlerFunc
+	AnalyzeExportCollisions        gin.HandlerFunc
 	GetExportStatus                gin.HandlerFunc
 	DownloadExport                 gin.HandlerFunc
 	ImportFieldDevices             gin.HandlerFunc
+	ImportFieldDevicesEDE          gin.HandlerFunc
+	PreviewFieldDeviceImport       gin.HandlerFunc
+	ConfirmFieldDeviceImport       gin.HandlerFunc
+	DiscardFieldDeviceImport       gin.HandlerFunc
+	ImportFieldDeviceEdits         gin.HandlerFunc
+	GetFieldDeviceObjectDataDiff   gin.HandlerFunc
 }
 
 func Routes(handlers Handlers) []routing.Definition {
@@ -37,6 +45,7 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Get("/field-devices/:id", domainUser.PermissionFieldDeviceRead, handlers.GetFieldDevice),
 		routing.Post("/field-devices/:id/copy", domainUser.PermissionFieldDeviceCreate, handlers.CopyFieldDevice),
 		routing.Get("/field-devices/:id/bacnet-objects", domainUser.PermissionFieldDeviceRead, handlers.ListFieldDeviceBacnetObjects),
+		routing.Get("/field-devices/:id/object-data-diff", domainUser.PermissionFieldDeviceRead, handlers.GetFieldDeviceObjectDataDiff),
 		routing.Get("/field-devices/:id/specification", domainUser.PermissionSpecificationRead, handlers.GetFieldDeviceSpecification),
 		routing.Post("/field-devices/:id/specification", domainUser.PermissionSpecificationCreate, handlers.CreateFieldDeviceSpecification),
 		routing.Put("/field-devices/:id/specification", domainUser.PermissionSpecificationUpdate, handlers.UpdateFieldDeviceSpecification),
@@ -46,8 +55,15 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Delete("/field-devices/:id", domainUser.PermissionFieldDeviceDelete, handlers.DeleteFieldDevice),
 		routing.Patch("/field-devices/bulk-update", domainUser.PermissionFieldDeviceUpdate, handlers.BulkUpdateFieldDevices),
 		routing.Delete("/field-devices/bulk-delete", domainUser.PermissionFieldDeviceDelete, handlers.BulkDeleteFieldDevices),
+		routing.Post("/field-devices/bacnet-numbering/preview", domainUser.PermissionFieldDeviceRead, handlers.PreviewBacnetNumbering),
 		routing.Post("/exports/field-devices", domainUser.PermissionFieldDeviceRead, handlers.CreateFieldDeviceExport),
+		routing.Post("/exports/field-devices/collisions", domainUser.PermissionFieldDeviceRead, handlers.AnalyzeExportCollisions),
 		routing.Post("/imports/field-devices", domainUser.PermissionFieldDeviceCreate, handlers.ImportFieldDevices),
+		routing.Post("/imports/field-devices/ede", domainUser.PermissionFieldDeviceCreate, handlers.ImportFieldDevicesEDE),
+		routing.Post("/imports/field-devices/preview", domainUser.PermissionFieldDeviceCreate, handlers.PreviewFieldDeviceImport),
+		routing.Post("/imports/field-devices/sessions/:importId/confirm", domainUser.PermissionFieldDeviceCreate, handlers.ConfirmFieldDeviceImport),
+		routing.Delete("/imports/field-devices/sessions/:importId", domainUser.PermissionFieldDeviceCreate, handlers.DiscardFieldDeviceImport),
+		routing.Post("/imports/field-devices/edits", domainUser.PermissionFieldDeviceUpdate, handlers.ImportFieldDeviceEdits),
 		routing.Get("/exports/jobs/:jobId", domainUser.PermissionFieldDeviceRead, handlers.GetExportStatus),
 		routing.Get("/exports/jobs/:jobId/download", domainUser.PermissionFieldDeviceRead, handlers.DownloadExport),
 	}
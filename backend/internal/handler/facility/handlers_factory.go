@@ -60,10 +60,12 @@ type ServiceDeps struct {
 	StateText               StateTextService
 	NotificationClass       NotificationClassService
 	AlarmDefinition         AlarmDefinitionService
+	AlarmDefinitionOverride AlarmDefinitionFieldOverrideService
 	ObjectData              ObjectDataService
 	SPSControllerSystemType SPSControllerSystemTypeService
 	Export                  ExportService
 	Import                  FieldDeviceImportService
+	ReferenceCatalog        ReferenceCatalogService
 	ExportDownload          domainExport.DownloadAuthorizer
 	AlarmType               AlarmTypeService
 	Unit                    UnitService
@@ -72,9 +74,13 @@ type ServiceDeps struct {
 	BacnetAlarm             BacnetAlarmValueService
 	BacnetReferenceUsage    BacnetReferenceUsageService
 	DeleteImpact            DeleteImpactService
+	IPAM                    IPAMService
 	FacilityJobs            *facilityservice.FacilityJobManager
 	Collaboration           ProjectRefreshBroadcaster
 	ReferenceData           FacilityReferenceDataRealtime
+
+	Translation  TranslationService
+	NamingScheme NamingSchemeService
 }
 
 // Handlers groups all facility HTTP handlers.
@@ -90,10 +96,12 @@ type Handlers struct {
 	StateText               *StateTextHandler
 	NotificationClass       *NotificationClassHandler
 	AlarmDefinition         *AlarmDefinitionHandler
+	AlarmDefinitionOverride *AlarmDefinitionFieldOverrideHandler
 	ObjectData              *ObjectDataHandler
 	SPSControllerSystemType *SPSControllerSystemTypeHandler
 	Export                  *ExportHandler
 	Import                  *ImportHandler
+	ReferenceCatalog        *ReferenceCatalogHandler
 	Validation              *ValidationHandler
 	AlarmType               *AlarmTypeHandler
 	Unit                    *UnitHandler
@@ -102,10 +110,14 @@ type Handlers struct {
 	BacnetAlarm             *BacnetAlarmHandler
 	BacnetReferenceUsage    *BacnetReferenceUsageHandler
 	DeleteImpact            *DeleteImpactHandler
+	IPPool                  *IPPoolHandler
 	FacilityJob             *FacilityJobHandler
 	ReferenceData           *FacilityReferenceDataStreamHandler
 	Details                 *FacilityDetailHandler
 	Realtime                FacilityMutationBroadcaster
+
+	Translation  *TranslationHandler
+	NamingScheme *NamingSchemeHandler
 }
 
 // NewHandlers creates facility handlers using service dependencies.
@@ -116,7 +128,7 @@ func NewHandlers(deps ServiceDeps) *Handlers {
 	registerFacilityLookupHandlers(handlers, deps)
 	registerFacilityAlarmHandlers(handlers, deps)
 	handlers.Export = NewExportHandler(deps.Export, deps.ExportDownload)
-	handlers.Import = NewImportHandler(deps.Import)
+	handlers.Import = NewImportHandler(deps.Import, deps.FacilityJobs)
 	return handlers
 }
 
@@ -127,6 +139,8 @@ func registerFacilityHierarchyHandlers(handlers *Handlers, deps ServiceDeps) {
 	handlers.SPSControllerSystemType = NewSPSControllerSystemTypeHandlerWithFacilityJobs(deps.SPSControllerSystemType, deps.Collaboration, deps.FacilityJobs)
 	handlers.FieldDevice = NewFieldDeviceHandlerWithFacilityJobs(deps.FieldDevice, deps.Collaboration, deps.FacilityJobs)
 	handlers.BacnetObject = NewBacnetObjectHandler(deps.BacnetObject, deps.Collaboration)
+	handlers.IPPool = NewIPPoolHandler(deps.IPAM)
+	handlers.NamingScheme = NewNamingSchemeHandler(deps.NamingScheme)
 	handlers.ObjectData = NewObjectDataHandlerWithFacilityJobs(deps.ObjectData, deps.BacnetObject, deps.Apparat, deps.FacilityJobs)
 	handlers.Validation = NewValidationHandler(deps.Building, deps.ControlCabinet, deps.SPSController)
 	handlers.Details = NewFacilityDetailHandler(
@@ -147,6 +161,8 @@ func registerFacilityLookupHandlers(handlers *Handlers, deps ServiceDeps) {
 	handlers.ReferenceData = NewFacilityReferenceDataStreamHandler(deps.ReferenceData)
 	handlers.StateText = NewStateTextHandler(deps.StateText)
 	handlers.NotificationClass = NewNotificationClassHandler(deps.NotificationClass)
+	handlers.ReferenceCatalog = NewReferenceCatalogHandler(deps.ReferenceCatalog)
+	handlers.Translation = NewTranslationHandler(deps.Translation)
 	handlers.BacnetReferenceUsage = NewBacnetReferenceUsageHandler(deps.BacnetReferenceUsage)
 	handlers.DeleteImpact = NewDeleteImpactHandler(deps.DeleteImpact)
 	handlers.FacilityJob = NewFacilityJobHandler(deps.FacilityJobs)
@@ -154,9 +170,10 @@ func registerFacilityLookupHandlers(handlers *Handlers, deps ServiceDeps) {
 
 func registerFacilityAlarmHandlers(handlers *Handlers, deps ServiceDeps) {
 	handlers.AlarmDefinition = NewAlarmDefinitionHandler(deps.AlarmDefinition)
+	handlers.AlarmDefinitionOverride = NewAlarmDefinitionFieldOverrideHandler(deps.AlarmDefinitionOverride)
 	handlers.AlarmType = NewAlarmTypeHandler(deps.AlarmType)
 	handlers.Unit = NewUnitHandler(deps.Unit)
 	handlers.AlarmField = NewAlarmFieldHandler(deps.AlarmField)
 	handlers.AlarmTypeField = NewAlarmTypeFieldHandler(deps.AlarmTypeField)
-	handlers.BacnetAlarm = NewBacnetAlarmHandler(deps.BacnetAlarm)
+	handlers.BacnetAlarm = NewBacnetAlarmHandler(deps.BacnetAlarm, deps.FacilityJobs)
 }
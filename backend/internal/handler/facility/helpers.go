@@ -267,3 +267,24 @@ func errMessage(err error) string {
 	}
 	return err.Error()
 }
+
+// parseStringListQueryParam collects the distinct values of a repeated or
+// comma separated query parameter in request order.
+func parseStringListQueryParam(c *gin.Context, name string) []string {
+	out := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, value := range c.QueryArray(name) {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if _, exists := seen[part]; exists {
+				continue
+			}
+			seen[part] = struct{}{}
+			out = append(out, part)
+		}
+	}
+	return out
+}
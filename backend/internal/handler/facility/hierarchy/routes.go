@@ -29,6 +29,7 @@ type Handlers struct {
 	CopySPSController             gin.HandlerFunc
 	ListSPSControllers            gin.HandlerFunc
 	GetNextAvailableGADevice      gin.HandlerFunc
+	GetNextFreeSPSControllerIP    gin.HandlerFunc
 	GetSPSController              gin.HandlerFunc
 	UpdateSPSController           gin.HandlerFunc
 	DeleteSPSController           gin.HandlerFunc
@@ -38,6 +39,22 @@ type Handlers struct {
 	UpdateSPSControllerSystemType gin.HandlerFunc
 	CopySPSControllerSystemType   gin.HandlerFunc
 	DeleteSPSControllerSystemType gin.HandlerFunc
+	CreateIPPool                  gin.HandlerFunc
+	ListIPPools                   gin.HandlerFunc
+	GetIPPoolUtilizationReport    gin.HandlerFunc
+	GetIPPool                     gin.HandlerFunc
+	GetIPPoolUtilization          gin.HandlerFunc
+	GetNextFreeIP                 gin.HandlerFunc
+	UpdateIPPool                  gin.HandlerFunc
+	DeleteIPPool                  gin.HandlerFunc
+
+	CreateNamingScheme      gin.HandlerFunc
+	ListNamingSchemes       gin.HandlerFunc
+	GetResolvedNamingScheme gin.HandlerFunc
+	PreviewNamingScheme     gin.HandlerFunc
+	GetNamingScheme         gin.HandlerFunc
+	UpdateNamingScheme      gin.HandlerFunc
+	DeleteNamingScheme      gin.HandlerFunc
 }
 
 func Routes(handlers Handlers) []routing.Definition {
@@ -66,6 +83,7 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Post("/sps-controllers/:id/copy", domainUser.PermissionSPSControllerCreate, handlers.CopySPSController),
 		routing.Get("/sps-controllers", domainUser.PermissionSPSControllerRead, handlers.ListSPSControllers),
 		routing.Get("/sps-controllers/next-ga-device", domainUser.PermissionSPSControllerRead, handlers.GetNextAvailableGADevice),
+		routing.Get("/sps-controllers/next-ip", domainUser.PermissionSPSControllerRead, handlers.GetNextFreeSPSControllerIP),
 		routing.Get("/sps-controllers/:id", domainUser.PermissionSPSControllerRead, handlers.GetSPSController),
 		routing.Patch("/sps-controllers/:id", domainUser.PermissionSPSControllerUpdate, handlers.UpdateSPSController),
 		routing.Put("/sps-controllers/:id", domainUser.PermissionSPSControllerUpdate, handlers.UpdateSPSController),
@@ -77,5 +95,22 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Put("/sps-controller-system-types/:id", domainUser.PermissionSPSControllerSystemTypeUpdate, handlers.UpdateSPSControllerSystemType),
 		routing.Post("/sps-controller-system-types/:id/copy", domainUser.PermissionSPSControllerSystemTypeCreate, handlers.CopySPSControllerSystemType),
 		routing.Delete("/sps-controller-system-types/:id", domainUser.PermissionSPSControllerSystemTypeDelete, handlers.DeleteSPSControllerSystemType),
+		routing.Post("/ip-pools", domainUser.PermissionSPSControllerCreate, handlers.CreateIPPool),
+		routing.Get("/ip-pools", domainUser.PermissionSPSControllerRead, handlers.ListIPPools),
+		routing.Get("/ip-pools/utilization", domainUser.PermissionSPSControllerRead, handlers.GetIPPoolUtilizationReport),
+		routing.Get("/ip-pools/:id", domainUser.PermissionSPSControllerRead, handlers.GetIPPool),
+		routing.Get("/ip-pools/:id/utilization", domainUser.PermissionSPSControllerRead, handlers.GetIPPoolUtilization),
+		routing.Get("/ip-pools/:id/next-ip", domainUser.PermissionSPSControllerRead, handlers.GetNextFreeIP),
+		routing.Patch("/ip-pools/:id", domainUser.PermissionSPSControllerUpdate, handlers.UpdateIPPool),
+		routing.Put("/ip-pools/:id", domainUser.PermissionSPSControllerUpdate, handlers.UpdateIPPool),
+		routing.Delete("/ip-pools/:id", domainUser.PermissionSPSControllerDelete, handlers.DeleteIPPool),
+		routing.Post("/naming-schemes", domainUser.PermissionSPSControllerCreate, handlers.CreateNamingScheme),
+		routing.Get("/naming-schemes", domainUser.PermissionSPSControllerRead, handlers.ListNamingSchemes),
+		routing.Get("/naming-schemes/resolved", domainUser.PermissionSPSControllerRead, handlers.GetResolvedNamingScheme),
+		routing.Post("/naming-schemes/preview", domainUser.PermissionSPSControllerRead, handlers.PreviewNamingScheme),
+		routing.Get("/naming-schemes/:id", domainUser.PermissionSPSControllerRead, handlers.GetNamingScheme),
+		routing.Patch("/naming-schemes/:id", domainUser.PermissionSPSControllerUpdate, handlers.UpdateNamingScheme),
+		routing.Put("/naming-schemes/:id", domainUser.PermissionSPSControllerUpdate, handlers.UpdateNamingScheme),
+		routing.Delete("/naming-schemes/:id", domainUser.PermissionSPSControllerDelete, handlers.DeleteNamingScheme),
 	}
 }
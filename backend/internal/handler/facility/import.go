@@ -3,23 +3,39 @@ package facility
 import (
 	"context"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 
 	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const maxFieldDeviceImportBytes = int64(2 << 30)
 
 type FieldDeviceImportService interface {
 	Import(ctx context.Context, command fielddeviceimport.Command) (fielddeviceimport.Result, error)
+	ImportEDE(ctx context.Context, command fielddeviceimport.EDECommand) (fielddeviceimport.Result, error)
+	Preview(ctx context.Context, command fielddeviceimport.Command) (fielddeviceimport.Preview, error)
+	Confirm(ctx context.Context, ref fielddeviceimport.SessionRef) (fielddeviceimport.Result, error)
+	Discard(ctx context.Context, ref fielddeviceimport.SessionRef) error
+	PlanEdits(ctx context.Context, source io.Reader) (fielddeviceimport.EditPlan, error)
 }
 
-type ImportHandler struct{ service FieldDeviceImportService }
+type ImportHandler struct {
+	service      FieldDeviceImportService
+	facilityJobs *facilityservice.FacilityJobManager
+}
 
-func NewImportHandler(service FieldDeviceImportService) *ImportHandler {
-	return &ImportHandler{service: service}
+func NewImportHandler(service FieldDeviceImportService, facilityJobs ...*facilityservice.FacilityJobManager) *ImportHandler {
+	handler := &ImportHandler{service: service}
+	if len(facilityJobs) > 0 {
+		handler.facilityJobs = facilityJobs[0]
+	}
+	return handler
 }
 
 // ImportFieldDevices godoc
@@ -27,40 +43,193 @@ func NewImportHandler(service FieldDeviceImportService) *ImportHandler {
 // @Tags Facility - Field Devices
 // @Accept multipart/form-data
 // @Produce json
-// @Param file formData file true "Versioned XLSX export or ZIP workbook package"
+// @Param file formData file true "Versioned XLSX export, ZIP workbook package or JSON/NDJSON facility document"
 // @Success 200 {object} fielddeviceimport.Result
 // @Failure 422 {object} fielddeviceimport.Result
 // @Router /api/v1/facility/imports/field-devices [post]
 func (h *ImportHandler) ImportFieldDevices(c *gin.Context) {
+	ownerID, file, ok := h.openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+	result, err := h.service.Import(c.Request.Context(), fielddeviceimport.Command{OwnerID: ownerID, Source: file})
+	respondImportResult(c, result, err)
+}
+
+// ImportFieldDevicesEDE godoc
+// @Summary Import a BACnet EDE point list as new field devices
+// @Tags Facility - Field Devices
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "EDE object list (semicolon separated CSV)"
+// @Param sps_controller_system_type_id formData string true "Controller system type receiving the field devices"
+// @Success 200 {object} fielddeviceimport.Result
+// @Failure 422 {object} fielddeviceimport.Result
+// @Router /api/v1/facility/imports/field-devices/ede [post]
+func (h *ImportHandler) ImportFieldDevicesEDE(c *gin.Context) {
+	systemTypeID, err := uuid.Parse(c.PostForm("sps_controller_system_type_id"))
+	if err != nil {
+		respondLocalizedError(c, http.StatusBadRequest, "invalid_sps_controller_system_type_id", "validation.invalid_uuid_format")
+		return
+	}
+	ownerID, file, ok := h.openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+	result, err := h.service.ImportEDE(c.Request.Context(), fielddeviceimport.EDECommand{
+		OwnerID: ownerID, SPSControllerSystemTypeID: systemTypeID, Source: file,
+	})
+	respondImportResult(c, result, err)
+}
+
+// ImportFieldDeviceEdits godoc
+// @Summary Apply an edited field-device export as bulk updates
+// @Description Compares the controller sheets of an exported workbook with its data sheets and submits the changed cells to the bulk-update job. Devices changed since the export fail with version conflicts.
+// @Tags Facility - Field Devices
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "XLSX workbook written by the field-device export"
+// @Param Idempotency-Key header string true "Operation ID of the bulk-update job"
+// @Success 200 {object} fielddeviceimport.EditPlan
+// @Success 202 {object} dto.FacilityJobResponse
+// @Failure 422 {object} fielddeviceimport.EditPlan
+// @Router /api/v1/facility/imports/field-devices/edits [post]
+func (h *ImportHandler) ImportFieldDeviceEdits(c *gin.Context) {
+	_, file, ok := h.openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+	plan, err := h.service.PlanEdits(c.Request.Context(), file)
+	switch {
+	case errors.Is(err, fielddeviceimport.ErrInvalidWorkbook):
+		c.JSON(http.StatusUnprocessableEntity, plan)
+	case errors.Is(err, fielddeviceimport.ErrEditsUnavailable):
+		respondError(c, http.StatusServiceUnavailable, "import_unavailable", err.Error())
+	case err != nil:
+		respondError(c, http.StatusInternalServerError, "field_device_import_failed", err.Error())
+	case len(plan.Updates) == 0:
+		c.JSON(http.StatusOK, plan)
+	default:
+		submitFieldDeviceBulkJob(c, h.facilityJobs, facilityservice.FacilityJobTaskBulkUpdateFieldDevices,
+			facilityservice.FieldDeviceBulkUpdateTaskPayload{Updates: plan.Updates}, len(plan.Updates))
+	}
+}
+
+// PreviewFieldDeviceImport godoc
+// @Summary Stage a field-device workbook and preview the changes
+// @Tags Facility - Field Devices
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Versioned XLSX export, ZIP workbook package or JSON/NDJSON facility document"
+// @Success 200 {object} fielddeviceimport.Preview
+// @Failure 422 {object} fielddeviceimport.Preview
+// @Router /api/v1/facility/imports/field-devices/preview [post]
+func (h *ImportHandler) PreviewFieldDeviceImport(c *gin.Context) {
+	ownerID, file, ok := h.openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+	preview, err := h.service.Preview(c.Request.Context(), fielddeviceimport.Command{OwnerID: ownerID, Source: file})
+	switch {
+	case errors.Is(err, fielddeviceimport.ErrInvalidWorkbook):
+		c.JSON(http.StatusUnprocessableEntity, preview)
+	case err != nil:
+		respondError(c, http.StatusInternalServerError, "field_device_import_failed", err.Error())
+	default:
+		c.JSON(http.StatusOK, preview)
+	}
+}
+
+// ConfirmFieldDeviceImport godoc
+// @Summary Write a previewed field-device import
+// @Tags Facility - Field Devices
+// @Produce json
+// @Param importId path string true "Import ID returned by the preview"
+// @Success 200 {object} fielddeviceimport.Result
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} fielddeviceimport.Result
+// @Router /api/v1/facility/imports/field-devices/sessions/{importId}/confirm [post]
+func (h *ImportHandler) ConfirmFieldDeviceImport(c *gin.Context) {
+	ref, ok := h.sessionRef(c)
+	if !ok {
+		return
+	}
+	result, err := h.service.Confirm(c.Request.Context(), ref)
+	respondImportResult(c, result, err)
+}
+
+// DiscardFieldDeviceImport godoc
+// @Summary Discard a previewed field-device import
+// @Tags Facility - Field Devices
+// @Param importId path string true "Import ID returned by the preview"
+// @Success 204
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/imports/field-devices/sessions/{importId} [delete]
+func (h *ImportHandler) DiscardFieldDeviceImport(c *gin.Context) {
+	ref, ok := h.sessionRef(c)
+	if !ok {
+		return
+	}
+	if err := h.service.Discard(c.Request.Context(), ref); err != nil {
+		respondImportResult(c, fielddeviceimport.Result{}, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ImportHandler) sessionRef(c *gin.Context) (fielddeviceimport.SessionRef, bool) {
 	if h.service == nil {
 		respondError(c, http.StatusServiceUnavailable, "import_unavailable", "Field-device import is unavailable")
-		return
+		return fielddeviceimport.SessionRef{}, false
 	}
 	ownerID, ok := middleware.GetUserID(c)
 	if !ok {
 		respondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
-		return
+		return fielddeviceimport.SessionRef{}, false
+	}
+	importID, ok := parseUUIDParam(c, "importId")
+	return fielddeviceimport.SessionRef{OwnerID: ownerID, ImportID: importID}, ok
+}
+
+func (h *ImportHandler) openUpload(c *gin.Context) (uuid.UUID, multipart.File, bool) {
+	if h.service == nil {
+		respondError(c, http.StatusServiceUnavailable, "import_unavailable", "Field-device import is unavailable")
+		return uuid.Nil, nil, false
+	}
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		respondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
+		return uuid.Nil, nil, false
 	}
 	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxFieldDeviceImportBytes)
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
 		respondError(c, http.StatusBadRequest, "invalid_import_file", err.Error())
-		return
+		return uuid.Nil, nil, false
 	}
 	file, err := fileHeader.Open()
 	if err != nil {
 		respondError(c, http.StatusBadRequest, "invalid_import_file", err.Error())
-		return
+		return uuid.Nil, nil, false
 	}
-	defer file.Close()
-	result, err := h.service.Import(c.Request.Context(), fielddeviceimport.Command{OwnerID: ownerID, Source: file})
-	if errors.Is(err, fielddeviceimport.ErrInvalidWorkbook) {
+	return ownerID, file, true
+}
+
+func respondImportResult(c *gin.Context, result fielddeviceimport.Result, err error) {
+	switch {
+	case errors.Is(err, fielddeviceimport.ErrInvalidWorkbook):
 		c.JSON(http.StatusUnprocessableEntity, result)
-		return
-	}
-	if err != nil {
+	case errors.Is(err, fielddeviceimport.ErrSessionNotFound):
+		respondError(c, http.StatusNotFound, "import_session_not_found", err.Error())
+	case errors.Is(err, fielddeviceimport.ErrEDEUnavailable):
+		respondError(c, http.StatusServiceUnavailable, "import_unavailable", err.Error())
+	case err != nil:
 		respondError(c, http.StatusInternalServerError, "field_device_import_failed", err.Error())
-		return
+	default:
+		c.JSON(http.StatusOK, result)
 	}
-	c.JSON(http.StatusOK, result)
 }
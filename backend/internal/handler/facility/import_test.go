@@ -4,21 +4,25 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type importServiceStub struct {
-	result  fielddeviceimport.Result
-	err     error
-	ownerID uuid.UUID
+	result       fielddeviceimport.Result
+	err          error
+	ownerID      uuid.UUID
+	systemTypeID uuid.UUID
+	plan         fielddeviceimport.EditPlan
 }
 
 func (s *importServiceStub) Import(_ context.Context, command fielddeviceimport.Command) (fielddeviceimport.Result, error) {
@@ -26,6 +30,70 @@ func (s *importServiceStub) Import(_ context.Context, command fielddeviceimport.
 	return s.result, s.err
 }
 
+func (s *importServiceStub) ImportEDE(_ context.Context, command fielddeviceimport.EDECommand) (fielddeviceimport.Result, error) {
+	s.ownerID, s.systemTypeID = command.OwnerID, command.SPSControllerSystemTypeID
+	return s.result, s.err
+}
+
+func (s *importServiceStub) Preview(_ context.Context, command fielddeviceimport.Command) (fielddeviceimport.Preview, error) {
+	s.ownerID = command.OwnerID
+	return fielddeviceimport.Preview{ImportID: s.result.ImportID}, s.err
+}
+
+func (s *importServiceStub) Confirm(_ context.Context, ref fielddeviceimport.SessionRef) (fielddeviceimport.Result, error) {
+	s.ownerID = ref.OwnerID
+	return s.result, s.err
+}
+
+func (s *importServiceStub) Discard(_ context.Context, ref fielddeviceimport.SessionRef) error {
+	s.ownerID = ref.OwnerID
+	return s.err
+}
+
+func (s *importServiceStub) PlanEdits(context.Context, io.Reader) (fielddeviceimport.EditPlan, error) {
+	return s.plan, s.err
+}
+
+func TestImportFieldDeviceEditsNeedsDurableJobsForChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for name, tc := range map[string]struct {
+		plan fielddeviceimport.EditPlan
+		want int
+	}{
+		"unchanged": {plan: fielddeviceimport.EditPlan{Total: 1, Unchanged: 1}, want: http.StatusOK},
+		"changed":   {plan: fielddeviceimport.EditPlan{Total: 1, Updates: []domainFacility.BulkFieldDeviceUpdate{{ID: uuid.New()}}}, want: http.StatusServiceUnavailable},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = multipartImportRequest(t, nil)
+			ctx.Set(middleware.ContextUserIDKey, uuid.New())
+
+			NewImportHandler(&importServiceStub{plan: tc.plan}).ImportFieldDeviceEdits(ctx)
+
+			if recorder.Code != tc.want {
+				t.Fatalf("status=%d body=%s", recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestConfirmFieldDeviceImportReportsUnknownSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := &importServiceStub{err: fielddeviceimport.ErrSessionNotFound}
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/facility/imports/field-devices/sessions/x/confirm", nil)
+	ctx.Params = gin.Params{{Key: "importId", Value: uuid.NewString()}}
+	ctx.Set(middleware.ContextUserIDKey, uuid.New())
+
+	NewImportHandler(service).ConfirmFieldDeviceImport(ctx)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("status=%d body=%s", recorder.Code, recorder.Body.String())
+	}
+}
+
 func TestImportFieldDevicesReturnsValidationReport(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	ownerID := uuid.New()
@@ -35,7 +103,7 @@ func TestImportFieldDevicesReturnsValidationReport(t *testing.T) {
 	}
 	recorder := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(recorder)
-	ctx.Request = multipartImportRequest(t)
+	ctx.Request = multipartImportRequest(t, nil)
 	ctx.Set(middleware.ContextUserIDKey, ownerID)
 
 	NewImportHandler(service).ImportFieldDevices(ctx)
@@ -50,7 +118,7 @@ func TestImportFieldDevicesRejectsUnexpectedFailure(t *testing.T) {
 	service := &importServiceStub{err: errors.New("database unavailable")}
 	recorder := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(recorder)
-	ctx.Request = multipartImportRequest(t)
+	ctx.Request = multipartImportRequest(t, nil)
 	ctx.Set(middleware.ContextUserIDKey, uuid.New())
 
 	NewImportHandler(service).ImportFieldDevices(ctx)
@@ -60,10 +128,31 @@ func TestImportFieldDevicesRejectsUnexpectedFailure(t *testing.T) {
 	}
 }
 
-func multipartImportRequest(t *testing.T) *http.Request {
+func TestImportFieldDevicesEDEPassesChosenSystemType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	systemTypeID := uuid.New()
+	service := &importServiceStub{result: fielddeviceimport.Result{Imported: 1}}
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = multipartImportRequest(t, map[string]string{"sps_controller_system_type_id": systemTypeID.String()})
+	ctx.Set(middleware.ContextUserIDKey, uuid.New())
+
+	NewImportHandler(service).ImportFieldDevicesEDE(ctx)
+
+	if recorder.Code != http.StatusOK || service.systemTypeID != systemTypeID {
+		t.Fatalf("status=%d system type=%s body=%s", recorder.Code, service.systemTypeID, recorder.Body.String())
+	}
+}
+
+func multipartImportRequest(t *testing.T, fields map[string]string) *http.Request {
 	t.Helper()
 	var body bytes.Buffer
 	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
 	part, err := writer.CreateFormFile("file", "facility.xlsx")
 	if err != nil {
 		t.Fatal(err)
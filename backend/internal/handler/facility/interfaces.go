@@ -6,6 +6,7 @@ import (
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
 	"github.com/google/uuid"
 )
 
@@ -84,6 +85,8 @@ type FieldDeviceService interface {
 	DeleteSpecificationAtVersion(ctx context.Context, fieldDeviceID uuid.UUID, version uint64) error
 	BulkUpdate(ctx context.Context, updates []domainFacility.BulkFieldDeviceUpdate) *domainFacility.BulkOperationResult
 	BulkDeleteCommands(ctx context.Context, commands []domainFacility.FieldDeviceDeleteCommand) *domainFacility.BulkOperationResult
+	PreviewBacnetNumbering(ctx context.Context, request domainFacility.BacnetNumberingRequest) (*domainFacility.BacnetNumberingPreview, error)
+	ObjectDataDiff(ctx context.Context, fieldDeviceID uuid.UUID) (*domainObjectData.TemplateDiff, error)
 }
 
 type ControlCabinetService interface {
@@ -153,6 +156,8 @@ type ObjectDataService interface {
 	GetBacnetObjectIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
 	GetApparatIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
 	ExistsByDescription(ctx context.Context, projectID *uuid.UUID, description string, excludeID *uuid.UUID) (bool, error)
+	ListRevisions(ctx context.Context, id uuid.UUID) ([]domainObjectData.TemplateRevision, error)
+	DiffRevisions(ctx context.Context, id uuid.UUID, from, to uint64) (*domainObjectData.TemplateDiff, error)
 }
 
 type SPSControllerSystemTypeService interface {
@@ -170,6 +175,7 @@ type SPSControllerSystemTypeService interface {
 type ExportService interface {
 	Create(ctx context.Context, ownerID, operationID uuid.UUID, req domainExport.Request) (domainExport.Job, error)
 	Get(ctx context.Context, ownerID, id uuid.UUID) (domainExport.Job, error)
+	AnalyzeCollisions(ctx context.Context, req domainExport.Request) (domainExport.CollisionReport, error)
 }
 
 type AlarmTypeService interface {
@@ -204,12 +210,49 @@ type AlarmTypeFieldService interface {
 	DeleteByID(ctx context.Context, id uuid.UUID) error
 }
 
+type TranslationService interface {
+	List(ctx context.Context, resource domainFacility.TranslationResource, entityID uuid.UUID) ([]domainFacility.Translation, error)
+	Replace(ctx context.Context, resource domainFacility.TranslationResource, entityID uuid.UUID, locale string, texts map[string]string) ([]domainFacility.Translation, error)
+}
+
+type AlarmDefinitionFieldOverrideService interface {
+	ListByAlarmDefinition(ctx context.Context, alarmDefinitionID uuid.UUID) ([]domainFacility.AlarmDefinitionFieldOverride, error)
+	Create(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domainFacility.AlarmDefinitionFieldOverride, error)
+	Update(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride) error
+	DeleteAtVersion(ctx context.Context, id uuid.UUID, version uint64) error
+}
+
 type BacnetAlarmValueService interface {
 	GetSchema(ctx context.Context, bacnetObjectID uuid.UUID) (*domainFacility.AlarmType, error)
 	GetValues(ctx context.Context, bacnetObjectID uuid.UUID) (*domainFacility.BacnetAlarmValues, error)
+	RenderValues(ctx context.Context, values []domainFacility.BacnetObjectAlarmValue, unitCodes []string) error
 	PutValues(ctx context.Context, bacnetObjectID uuid.UUID, version uint64, values []domainFacility.BacnetObjectAlarmValue) (uint64, error)
+	ValidateBulkEdit(edit domainFacility.AlarmValueBulkEdit) error
 }
 
 type BacnetReferenceUsageService interface {
 	CountByResource(ctx context.Context, resource domainFacility.BacnetReferenceResource, ids []uuid.UUID) ([]domainFacility.BacnetReferenceUsage, error)
 }
+
+type IPAMService interface {
+	Create(ctx context.Context, pool *domainFacility.IPPool) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domainFacility.IPPool, error)
+	List(ctx context.Context, page, limit int, search string) (*domain.PaginatedList[domainFacility.IPPool], error)
+	Update(ctx context.Context, pool *domainFacility.IPPool) error
+	DeleteAtVersion(ctx context.Context, id uuid.UUID, version uint64) error
+	NextFreeIP(ctx context.Context, poolID uuid.UUID) (*domainFacility.IPAllocation, error)
+	NextFreeIPForControlCabinet(ctx context.Context, controlCabinetID uuid.UUID, vlan string) (*domainFacility.IPAllocation, error)
+	Utilization(ctx context.Context, poolID uuid.UUID) (*domainFacility.IPPoolUtilization, error)
+	UtilizationReport(ctx context.Context, filter domainFacility.IPPoolFilter) ([]domainFacility.IPPoolUtilization, error)
+}
+
+type NamingSchemeService interface {
+	Create(ctx context.Context, scheme *domainFacility.NamingScheme) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domainFacility.NamingScheme, error)
+	List(ctx context.Context, page, limit int, search string) (*domain.PaginatedList[domainFacility.NamingScheme], error)
+	Update(ctx context.Context, scheme *domainFacility.NamingScheme) error
+	DeleteAtVersion(ctx context.Context, id uuid.UUID, version uint64) error
+	Resolve(ctx context.Context, projectID *uuid.UUID) (domainFacility.NamingScheme, error)
+	Preview(ctx context.Context, scheme domainFacility.NamingScheme, spsControllerID uuid.UUID) (*domainFacility.NamingPreview, error)
+}
@@ -0,0 +1,307 @@
+package facility
+
+import (
+	"net/http"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	"github.com/gin-gonic/gin"
+)
+
+type IPPoolHandler struct {
+	crud    crudHandler[domainFacility.IPPool, dto.CreateIPPoolRequest, dto.UpdateIPPoolRequest]
+	service IPAMService
+}
+
+func NewIPPoolHandler(svc IPAMService) *IPPoolHandler {
+	return &IPPoolHandler{
+		crud: newCRUD(
+			svc,
+			toIPPoolModel,
+			applyIPPoolUpdate,
+			respFn(toIPPoolResponse),
+			listRespFn(toIPPoolListResponse),
+			"ip_pool",
+			"facility.ip_pool_not_found",
+		),
+		service: svc,
+	}
+}
+
+// CreateIPPool godoc
+// @Summary Create a new IP pool
+// @Tags facility-ip-pools
+// @Accept json
+// @Produce json
+// @Param ip_pool body dto.CreateIPPoolRequest true "IP pool data"
+// @Success 201 {object} dto.IPPoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools [post]
+func (h *IPPoolHandler) CreateIPPool(c *gin.Context) { h.crud.handleCreate(c) }
+
+// GetIPPool godoc
+// @Summary Get an IP pool by ID
+// @Tags facility-ip-pools
+// @Produce json
+// @Param id path string true "IP pool ID"
+// @Success 200 {object} dto.IPPoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools/{id} [get]
+func (h *IPPoolHandler) GetIPPool(c *gin.Context) { h.crud.handleGetByID(c) }
+
+// ListIPPools godoc
+// @Summary List IP pools with pagination
+// @Tags facility-ip-pools
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param search query string false "Search query"
+// @Success 200 {object} dto.IPPoolListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools [get]
+func (h *IPPoolHandler) ListIPPools(c *gin.Context) { h.crud.handleList(c) }
+
+// UpdateIPPool godoc
+// @Summary Update an IP pool
+// @Tags facility-ip-pools
+// @Accept json
+// @Produce json
+// @Param id path string true "IP pool ID"
+// @Param ip_pool body dto.UpdateIPPoolRequest true "IP pool data"
+// @Success 200 {object} dto.IPPoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools/{id} [put]
+func (h *IPPoolHandler) UpdateIPPool(c *gin.Context) { h.crud.handleUpdate(c) }
+
+// DeleteIPPool godoc
+// @Summary Delete an IP pool
+// @Tags facility-ip-pools
+// @Produce json
+// @Param id path string true "IP pool ID"
+// @Param base_version query integer true "Expected aggregate version" minimum(1)
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools/{id} [delete]
+func (h *IPPoolHandler) DeleteIPPool(c *gin.Context) { h.crud.handleDelete(c) }
+
+// GetNextFreeIP godoc
+// @Summary Suggest the next free address of an IP pool
+// @Description The address is not reserved; it is taken once a controller is saved with it.
+// @Tags facility-ip-pools
+// @Produce json
+// @Param id path string true "IP pool ID"
+// @Success 200 {object} dto.IPAllocationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools/{id}/next-ip [get]
+func (h *IPPoolHandler) GetNextFreeIP(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	allocation, err := h.service.NextFreeIP(c.Request.Context(), id)
+	if err != nil {
+		respondIPAllocationError(c, err, "facility.ip_pool_not_found")
+		return
+	}
+	c.JSON(http.StatusOK, toIPAllocationResponse(*allocation))
+}
+
+// GetNextFreeSPSControllerIP godoc
+// @Summary Suggest the next free IP address for a control cabinet
+// @Description Uses the IP pool of the cabinet's building for the given VLAN.
+// @Tags facility-sps-controllers
+// @Produce json
+// @Param control_cabinet_id query string true "Control Cabinet ID"
+// @Param vlan query string true "VLAN"
+// @Success 200 {object} dto.IPAllocationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/sps-controllers/next-ip [get]
+func (h *IPPoolHandler) GetNextFreeSPSControllerIP(c *gin.Context) {
+	controlCabinetID, ok := parseUUIDQueryParam(c, "control_cabinet_id")
+	if !ok {
+		return
+	}
+	if controlCabinetID == nil {
+		respondLocalizedInvalidArgument(c, "facility.control_cabinet_id_required")
+		return
+	}
+	var query dto.NextIPAddressQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+	allocation, err := h.service.NextFreeIPForControlCabinet(c.Request.Context(), *controlCabinetID, query.Vlan)
+	if err != nil {
+		respondIPAllocationError(c, err, "facility.ip_pool_not_found")
+		return
+	}
+	c.JSON(http.StatusOK, toIPAllocationResponse(*allocation))
+}
+
+// GetIPPoolUtilization godoc
+// @Summary Report utilization and addressing issues of an IP pool
+// @Tags facility-ip-pools
+// @Produce json
+// @Param id path string true "IP pool ID"
+// @Success 200 {object} dto.IPPoolUtilizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools/{id}/utilization [get]
+func (h *IPPoolHandler) GetIPPoolUtilization(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	utilization, err := h.service.Utilization(c.Request.Context(), id)
+	if err != nil {
+		if respondLocalizedNotFoundIf(c, err, "facility.ip_pool_not_found") {
+			return
+		}
+		respondLocalizedError(c, http.StatusInternalServerError, "fetch_failed", "facility.fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, toIPPoolUtilizationResponse(*utilization))
+}
+
+// GetIPPoolUtilizationReport godoc
+// @Summary Report utilization per subnet for a building or project
+// @Tags facility-ip-pools
+// @Produce json
+// @Param building_id query string false "Building ID"
+// @Param project_id query string false "Project ID"
+// @Param vlan query string false "VLAN"
+// @Success 200 {object} dto.IPPoolUtilizationReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/ip-pools/utilization [get]
+func (h *IPPoolHandler) GetIPPoolUtilizationReport(c *gin.Context) {
+	buildingID, ok := parseUUIDQueryParam(c, "building_id")
+	if !ok {
+		return
+	}
+	projectID, ok := parseUUIDQueryParam(c, "project_id")
+	if !ok {
+		return
+	}
+	var query dto.IPPoolUtilizationQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+	report, err := h.service.UtilizationReport(c.Request.Context(), domainFacility.IPPoolFilter{
+		BuildingID: buildingID, ProjectID: projectID, Vlan: query.Vlan,
+	})
+	if err != nil {
+		respondLocalizedError(c, http.StatusInternalServerError, "fetch_failed", "facility.fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, dto.IPPoolUtilizationReportResponse{Items: mapItems(report, toIPPoolUtilizationResponse)})
+}
+
+func respondIPAllocationError(c *gin.Context, err error, notFoundKey string) {
+	respondLocalizedDomainError(c, err, "fetch_failed", "facility.fetch_failed",
+		localizedNotFound(notFoundKey),
+		localizedConflict("facility.no_free_ip_address"),
+	)
+}
+
+func toIPPoolModel(req dto.CreateIPPoolRequest) *domainFacility.IPPool {
+	return &domainFacility.IPPool{
+		Name:        req.Name,
+		BuildingID:  req.BuildingID,
+		ProjectID:   req.ProjectID,
+		Vlan:        req.Vlan,
+		Network:     req.Network,
+		Gateway:     req.Gateway,
+		RangeStart:  req.RangeStart,
+		RangeEnd:    req.RangeEnd,
+		Description: req.Description,
+	}
+}
+
+func applyIPPoolUpdate(target *domainFacility.IPPool, req dto.UpdateIPPoolRequest) {
+	if req.Name != nil {
+		target.Name = *req.Name
+	}
+	if req.Vlan != nil {
+		target.Vlan = *req.Vlan
+	}
+	if req.Network != nil {
+		target.Network = *req.Network
+	}
+	if req.Gateway != nil {
+		target.Gateway = req.Gateway
+	}
+	if req.RangeStart != nil {
+		target.RangeStart = req.RangeStart
+	}
+	if req.RangeEnd != nil {
+		target.RangeEnd = req.RangeEnd
+	}
+	if req.Description != nil {
+		target.Description = req.Description
+	}
+}
+
+func toIPPoolResponse(pool domainFacility.IPPool) dto.IPPoolResponse {
+	return dto.IPPoolResponse{
+		ID:          pool.ID,
+		Version:     pool.Version,
+		Name:        pool.Name,
+		BuildingID:  pool.BuildingID,
+		ProjectID:   pool.ProjectID,
+		Vlan:        pool.Vlan,
+		Network:     pool.Network,
+		SubnetMask:  pool.SubnetMask(),
+		Gateway:     pool.Gateway,
+		RangeStart:  pool.RangeStart,
+		RangeEnd:    pool.RangeEnd,
+		Description: pool.Description,
+		CreatedAt:   pool.CreatedAt,
+		UpdatedAt:   pool.UpdatedAt,
+	}
+}
+
+func toIPPoolListResponse(list *domain.PaginatedList[domainFacility.IPPool]) dto.IPPoolListResponse {
+	return dto.IPPoolListResponse{Items: mapItems(list.Items, toIPPoolResponse), Total: list.Total, Page: list.Page, TotalPages: list.TotalPages}
+}
+
+func toIPAllocationResponse(allocation domainFacility.IPAllocation) dto.IPAllocationResponse {
+	return dto.IPAllocationResponse{
+		PoolID:    allocation.PoolID,
+		IPAddress: allocation.IPAddress,
+		Subnet:    allocation.Subnet,
+		Gateway:   allocation.Gateway,
+		Vlan:      allocation.Vlan,
+	}
+}
+
+func toIPPoolUtilizationResponse(utilization domainFacility.IPPoolUtilization) dto.IPPoolUtilizationResponse {
+	issues := make([]dto.IPAddressIssueResponse, len(utilization.Issues))
+	for index, issue := range utilization.Issues {
+		issues[index] = dto.IPAddressIssueResponse{
+			SPSControllerID: issue.SPSControllerID, DeviceName: issue.DeviceName, Kind: string(issue.Kind), Value: issue.Value,
+		}
+	}
+	return dto.IPPoolUtilizationResponse{
+		Pool:        toIPPoolResponse(utilization.Pool),
+		Capacity:    utilization.Capacity,
+		Used:        utilization.Used,
+		Free:        utilization.Free,
+		Utilization: utilization.Utilization,
+		Issues:      issues,
+	}
+}
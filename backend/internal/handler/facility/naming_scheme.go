@@ -0,0 +1,221 @@
+package facility
+
+import (
+	"net/http"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	"github.com/gin-gonic/gin"
+)
+
+type NamingSchemeHandler struct {
+	crud    crudHandler[domainFacility.NamingScheme, dto.CreateNamingSchemeRequest, dto.UpdateNamingSchemeRequest]
+	service NamingSchemeService
+}
+
+func NewNamingSchemeHandler(svc NamingSchemeService) *NamingSchemeHandler {
+	return &NamingSchemeHandler{
+		crud: newCRUD(
+			svc,
+			toNamingSchemeModel,
+			applyNamingSchemeUpdate,
+			respFn(toNamingSchemeResponse),
+			listRespFn(toNamingSchemeListResponse),
+			"naming_scheme",
+			"facility.naming_scheme_not_found",
+		),
+		service: svc,
+	}
+}
+
+// CreateNamingScheme godoc
+// @Summary Create a new BACnet naming scheme
+// @Tags facility-naming-schemes
+// @Accept json
+// @Produce json
+// @Param naming_scheme body dto.CreateNamingSchemeRequest true "Naming scheme data"
+// @Success 201 {object} dto.NamingSchemeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes [post]
+func (h *NamingSchemeHandler) CreateNamingScheme(c *gin.Context) { h.crud.handleCreate(c) }
+
+// GetNamingScheme godoc
+// @Summary Get a BACnet naming scheme by ID
+// @Tags facility-naming-schemes
+// @Produce json
+// @Param id path string true "Naming scheme ID"
+// @Success 200 {object} dto.NamingSchemeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes/{id} [get]
+func (h *NamingSchemeHandler) GetNamingScheme(c *gin.Context) { h.crud.handleGetByID(c) }
+
+// ListNamingSchemes godoc
+// @Summary List BACnet naming schemes with pagination
+// @Tags facility-naming-schemes
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param search query string false "Search by name or client"
+// @Success 200 {object} dto.NamingSchemeListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes [get]
+func (h *NamingSchemeHandler) ListNamingSchemes(c *gin.Context) { h.crud.handleList(c) }
+
+// UpdateNamingScheme godoc
+// @Summary Update a BACnet naming scheme
+// @Tags facility-naming-schemes
+// @Accept json
+// @Produce json
+// @Param id path string true "Naming scheme ID"
+// @Param naming_scheme body dto.UpdateNamingSchemeRequest true "Naming scheme data"
+// @Success 200 {object} dto.NamingSchemeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes/{id} [put]
+func (h *NamingSchemeHandler) UpdateNamingScheme(c *gin.Context) { h.crud.handleUpdate(c) }
+
+// DeleteNamingScheme godoc
+// @Summary Delete a BACnet naming scheme
+// @Tags facility-naming-schemes
+// @Produce json
+// @Param id path string true "Naming scheme ID"
+// @Param base_version query integer true "Expected aggregate version" minimum(1)
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes/{id} [delete]
+func (h *NamingSchemeHandler) DeleteNamingScheme(c *gin.Context) { h.crud.handleDelete(c) }
+
+// GetResolvedNamingScheme godoc
+// @Summary Get the naming scheme used for a project
+// @Description Falls back to the library default and then to the built-in scheme. Without project_id the scheme of global data is returned.
+// @Tags facility-naming-schemes
+// @Produce json
+// @Param project_id query string false "Project ID"
+// @Success 200 {object} dto.NamingSchemeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes/resolved [get]
+func (h *NamingSchemeHandler) GetResolvedNamingScheme(c *gin.Context) {
+	projectID, ok := parseUUIDQueryParam(c, "project_id")
+	if !ok {
+		return
+	}
+	scheme, err := h.service.Resolve(c.Request.Context(), projectID)
+	if err != nil {
+		respondLocalizedError(c, http.StatusInternalServerError, "fetch_failed", "facility.fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, toNamingSchemeResponse(scheme))
+}
+
+// PreviewNamingScheme godoc
+// @Summary Preview the names a naming scheme generates for an SPS controller
+// @Description Reports object names that are too long, not unique within the controller or cannot be resolved.
+// @Tags facility-naming-schemes
+// @Accept json
+// @Produce json
+// @Param preview body dto.NamingPreviewRequest true "Scheme patterns and controller"
+// @Success 200 {object} dto.NamingPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/facility/naming-schemes/preview [post]
+func (h *NamingSchemeHandler) PreviewNamingScheme(c *gin.Context) {
+	var req dto.NamingPreviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	scheme := domainFacility.NamingScheme{
+		Name:           "preview",
+		ControllerName: toNamingPattern(req.ControllerName),
+		ObjectName:     toNamingPattern(req.ObjectName),
+	}
+	preview, err := h.service.Preview(c.Request.Context(), scheme, req.SPSControllerID)
+	if err != nil {
+		respondLocalizedDomainError(c, err, "fetch_failed", "facility.fetch_failed",
+			localizedNotFound("facility.sps_controller_not_found"),
+		)
+		return
+	}
+	c.JSON(http.StatusOK, toNamingPreviewResponse(*preview))
+}
+
+func toNamingPattern(req dto.NamingPatternRequest) domainFacility.NamingPattern {
+	return domainFacility.NamingPattern{Pattern: req.Pattern, MaxLength: req.MaxLength, Uppercase: req.Uppercase}
+}
+
+func toNamingSchemeModel(req dto.CreateNamingSchemeRequest) *domainFacility.NamingScheme {
+	return &domainFacility.NamingScheme{
+		Name:           req.Name,
+		Client:         req.Client,
+		ProjectID:      req.ProjectID,
+		IsDefault:      req.IsDefault,
+		ControllerName: toNamingPattern(req.ControllerName),
+		ObjectName:     toNamingPattern(req.ObjectName),
+	}
+}
+
+func applyNamingSchemeUpdate(target *domainFacility.NamingScheme, req dto.UpdateNamingSchemeRequest) {
+	if req.Name != nil {
+		target.Name = *req.Name
+	}
+	if req.Client != nil {
+		target.Client = *req.Client
+	}
+	if req.IsDefault != nil {
+		target.IsDefault = *req.IsDefault
+	}
+	if req.ControllerName != nil {
+		target.ControllerName = toNamingPattern(*req.ControllerName)
+	}
+	if req.ObjectName != nil {
+		target.ObjectName = toNamingPattern(*req.ObjectName)
+	}
+}
+
+func toNamingPatternResponse(pattern domainFacility.NamingPattern) dto.NamingPatternResponse {
+	return dto.NamingPatternResponse{Pattern: pattern.Pattern, MaxLength: pattern.MaxLength, Uppercase: pattern.Uppercase}
+}
+
+func toNamingSchemeResponse(scheme domainFacility.NamingScheme) dto.NamingSchemeResponse {
+	return dto.NamingSchemeResponse{
+		ID:             scheme.ID,
+		Version:        scheme.Version,
+		Name:           scheme.Name,
+		Client:         scheme.Client,
+		ProjectID:      scheme.ProjectID,
+		IsDefault:      scheme.IsDefault,
+		ControllerName: toNamingPatternResponse(scheme.ControllerName),
+		ObjectName:     toNamingPatternResponse(scheme.ObjectName),
+		CreatedAt:      scheme.CreatedAt,
+		UpdatedAt:      scheme.UpdatedAt,
+	}
+}
+
+func toNamingSchemeListResponse(list *domain.PaginatedList[domainFacility.NamingScheme]) dto.NamingSchemeListResponse {
+	return dto.NamingSchemeListResponse{Items: mapItems(list.Items, toNamingSchemeResponse), Total: list.Total, Page: list.Page, TotalPages: list.TotalPages}
+}
+
+func toNamingPreviewResponse(preview domainFacility.NamingPreview) dto.NamingPreviewResponse {
+	objects := make([]dto.NamingPreviewObjectResponse, len(preview.Objects))
+	for index, object := range preview.Objects {
+		objects[index] = dto.NamingPreviewObjectResponse{BacnetObjectID: object.BacnetObjectID, FieldDeviceID: object.FieldDeviceID, Name: object.Name}
+	}
+	issues := make([]dto.NamingIssueResponse, len(preview.Issues))
+	for index, issue := range preview.Issues {
+		issues[index] = dto.NamingIssueResponse{Kind: string(issue.Kind), Name: issue.Name, ObjectID: issue.ObjectID}
+	}
+	return dto.NamingPreviewResponse{
+		SPSControllerID: preview.SPSControllerID,
+		ControllerName:  preview.ControllerName,
+		Objects:         objects,
+		Issues:          issues,
+	}
+}
@@ -0,0 +1,141 @@
+package facility
+
+import (
+	"net/http"
+
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	sharedpresenter "github.com/besart951/go_infra_link/backend/internal/handler/presenter/shared"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/gin-gonic/gin"
+)
+
+// ListObjectDataRevisions godoc
+// @Summary List the template revisions of an object data
+// @Tags facility-object-data
+// @Produce json
+// @Param id path string true "Object Data ID"
+// @Success 200 {array} dto.ObjectDataRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/object-data/{id}/revisions [get]
+func (h *ObjectDataHandler) ListObjectDataRevisions(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.service.ListRevisions(c.Request.Context(), id)
+	if respondObjectDataRevisionError(c, err, "facility.object_data_not_found") {
+		return
+	}
+
+	response := make([]dto.ObjectDataRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		response[i] = toObjectDataRevisionResponse(revision)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DiffObjectDataRevisions godoc
+// @Summary Compare two template revisions of an object data
+// @Tags facility-object-data
+// @Produce json
+// @Param id path string true "Object Data ID"
+// @Param from query int true "Base revision"
+// @Param to query int false "Target revision, defaults to the current templates"
+// @Success 200 {object} dto.ObjectDataDiffResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/object-data/{id}/revisions/diff [get]
+func (h *ObjectDataHandler) DiffObjectDataRevisions(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var query dto.ObjectDataDiffQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	diff, err := h.service.DiffRevisions(c.Request.Context(), id, query.From, query.To)
+	if respondObjectDataRevisionError(c, err, "facility.object_data_revision_not_found") {
+		return
+	}
+	c.JSON(http.StatusOK, sharedpresenter.ToObjectDataDiffResponse(diff))
+}
+
+// UpgradeObjectDataDevices godoc
+// @Summary Upgrade field devices to the current templates of an object data
+// @Tags facility-object-data
+// @Accept json
+// @Produce json
+// @Param id path string true "Object Data ID"
+// @Param request body dto.UpgradeObjectDataDevicesRequest false "Optional field device selection"
+// @Success 202 {object} dto.FacilityJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/object-data/{id}/upgrade-devices [post]
+func (h *ObjectDataHandler) UpgradeObjectDataDevices(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.UpgradeObjectDataDevicesRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	submitFacilityBulkJob(c, h.facilityJobs, facilityservice.FacilityJobKindObjectData,
+		facilityservice.FacilityJobTaskUpgradeObjectDataDevices,
+		facilityservice.ObjectDataUpgradeTaskPayload{ObjectDataID: id, FieldDeviceIDs: req.FieldDeviceIDs}, nil)
+}
+
+// GetFieldDeviceObjectDataDiff godoc
+// @Summary Compare the templates a field device was built from with the current ones
+// @Tags facility-field-devices
+// @Produce json
+// @Param id path string true "Field Device ID"
+// @Success 200 {object} dto.ObjectDataDiffResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/field-devices/{id}/object-data-diff [get]
+func (h *FieldDeviceHandler) GetFieldDeviceObjectDataDiff(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	diff, err := h.service.ObjectDataDiff(c.Request.Context(), id)
+	if respondObjectDataRevisionError(c, err, "facility.field_device_not_found") {
+		return
+	}
+	c.JSON(http.StatusOK, sharedpresenter.ToObjectDataDiffResponse(diff))
+}
+
+func respondObjectDataRevisionError(c *gin.Context, err error, notFoundKey string) bool {
+	if err == nil {
+		return false
+	}
+	if suppressCanceledRequestError(c, err) {
+		return true
+	}
+	return respondLocalizedDomainError(c, err, "fetch_failed", "facility.fetch_failed",
+		localizedNotFound(notFoundKey),
+		handlerutil.MapError(facilityservice.ErrObjectDataRevisionsUnavailable, handlerutil.LocalizedError(http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")),
+	)
+}
+
+func toObjectDataRevisionResponse(revision domainObjectData.TemplateRevision) dto.ObjectDataRevisionResponse {
+	return dto.ObjectDataRevisionResponse{
+		ObjectDataID: revision.ObjectDataID,
+		Revision:     revision.Revision,
+		Label:        revision.Label,
+		Fingerprint:  revision.Fingerprint,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
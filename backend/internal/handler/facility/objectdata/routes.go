@@ -18,6 +18,13 @@ type Handlers struct {
 	CreateObjectData           gin.HandlerFunc
 	UpdateObjectData           gin.HandlerFunc
 	DeleteObjectData           gin.HandlerFunc
+
+	ListBacnetObjectTranslations    gin.HandlerFunc
+	ReplaceBacnetObjectTranslations gin.HandlerFunc
+
+	ListObjectDataRevisions  gin.HandlerFunc
+	DiffObjectDataRevisions  gin.HandlerFunc
+	UpgradeObjectDataDevices gin.HandlerFunc
 }
 
 func Routes(handlers Handlers) []routing.Definition {
@@ -26,10 +33,15 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Get("/bacnet-objects/:id", domainUser.PermissionBacnetObjectRead, handlers.GetBacnetObject),
 		routing.Put("/bacnet-objects/:id", domainUser.PermissionBacnetObjectUpdate, handlers.UpdateBacnetObject),
 		routing.Delete("/bacnet-objects/:id", domainUser.PermissionBacnetObjectDelete, handlers.DeleteBacnetObject),
+		routing.Get("/bacnet-objects/:id/translations", domainUser.PermissionBacnetObjectRead, handlers.ListBacnetObjectTranslations),
+		routing.Put("/bacnet-objects/:id/translations/:locale", domainUser.PermissionBacnetObjectUpdate, handlers.ReplaceBacnetObjectTranslations),
 		routing.Get("/object-data", domainUser.PermissionObjectDataRead, handlers.ListObjectData),
 		routing.Get("/object-data/:id", domainUser.PermissionObjectDataRead, handlers.GetObjectData),
 		routing.Post("/object-data/:id/copy", domainUser.PermissionObjectDataCreate, handlers.CopyObjectData),
 		routing.Get("/object-data/:id/bacnet-objects", domainUser.PermissionObjectDataRead, handlers.GetObjectDataBacnetObjects),
+		routing.Get("/object-data/:id/revisions", domainUser.PermissionObjectDataRead, handlers.ListObjectDataRevisions),
+		routing.Get("/object-data/:id/revisions/diff", domainUser.PermissionObjectDataRead, handlers.DiffObjectDataRevisions),
+		routing.Post("/object-data/:id/upgrade-devices", domainUser.PermissionFieldDeviceUpdate, handlers.UpgradeObjectDataDevices),
 		routing.Post("/object-data", domainUser.PermissionObjectDataCreate, handlers.CreateObjectData),
 		routing.Put("/object-data/:id", domainUser.PermissionObjectDataUpdate, handlers.UpdateObjectData),
 		routing.Delete("/object-data/:id", domainUser.PermissionObjectDataDelete, handlers.DeleteObjectData),
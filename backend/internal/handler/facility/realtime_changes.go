@@ -34,14 +34,20 @@ func facilityMutationForRoute(route routing.Definition) (facilityMutation, bool)
 	if strings.HasSuffix(route.Path, "/copy") {
 		action = "copied"
 	}
+	// Catalog imports create and update entries in one request.
+	if strings.HasSuffix(route.Path, "/import") {
+		action = "bulk_updated"
+	}
 	if strings.Contains(route.Path, "/bulk-") || strings.Contains(route.Path, "/multi-create") || strings.HasSuffix(route.Path, "/multi") {
 		action = "bulk_" + action
 	}
 	// POST /alarm-types/:id/fields uses the alarm-type ID, not the ID of the
 	// newly created mapping. Do not publish that parent ID as an
 	// alarm_type_fields ID; consumers can still refresh the affected list from
-	// the bundled event.
-	pathIDIsTarget := !(route.Method == http.MethodPost && strings.HasPrefix(route.Path, "/alarm-types/:id/fields"))
+	// the bundled event. Field overrides are created under their definition
+	// the same way.
+	pathIDIsTarget := !(route.Method == http.MethodPost &&
+		(strings.HasPrefix(route.Path, "/alarm-types/:id/fields") || strings.HasPrefix(route.Path, "/alarm-definitions/:id/field-overrides")))
 	return facilityMutation{resource: resource, action: action, pathIDIsTarget: pathIDIsTarget}, true
 }
 
@@ -49,8 +55,13 @@ func isFacilityMutationRoute(route routing.Definition) bool {
 	if route.Method != http.MethodPost && route.Method != http.MethodPut && route.Method != http.MethodPatch && route.Method != http.MethodDelete {
 		return false
 	}
+	// Bulk edit and upgrade routes only queue a job; the job publishes the objects it
+	// changed once it has run. Import previews change nothing.
 	return !strings.HasSuffix(route.Path, "/bulk") &&
+		!strings.HasSuffix(route.Path, "/bulk-edit") &&
+		!strings.HasSuffix(route.Path, "/upgrade-devices") &&
 		!strings.HasSuffix(route.Path, "/validate") &&
+		!strings.HasSuffix(route.Path, "/preview") &&
 		!strings.Contains(route.Path, "/export")
 }
 
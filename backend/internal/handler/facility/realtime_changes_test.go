@@ -42,11 +42,17 @@ func TestFacilityMutationForRoute(t *testing.T) {
 		{name: "bulk delete", route: routing.Delete("/field-devices/bulk-delete", "", nil), want: facilityMutation{resource: "field_devices", action: "bulk_deleted", pathIDIsTarget: true}, ok: true},
 		{name: "multi create", route: routing.Post("/field-devices/multi-create", "", nil), want: facilityMutation{resource: "field_devices", action: "bulk_created", pathIDIsTarget: true}, ok: true},
 		{name: "mapping creation does not publish parent alarm type ID", route: routing.Post("/alarm-types/:id/fields", "", nil), want: facilityMutation{resource: "alarm_type_fields", action: "created"}, ok: true},
+		{name: "override creation does not publish parent definition ID", route: routing.Post("/alarm-definitions/:id/field-overrides", "", nil), want: facilityMutation{resource: "alarm_definition_field_overrides", action: "created"}, ok: true},
+		{name: "override update targets the override", route: routing.Put("/alarm-definition-field-overrides/:id", "", nil), want: facilityMutation{resource: "alarm_definition_field_overrides", action: "updated", pathIDIsTarget: true}, ok: true},
 		{name: "alarm unit route", route: routing.Put("/alarm-units/:id", "", nil), want: facilityMutation{resource: "units", action: "updated", pathIDIsTarget: true}, ok: true},
+		{name: "catalog import", route: routing.Post("/state-texts/import", "", nil), want: facilityMutation{resource: "state_texts", action: "bulk_updated", pathIDIsTarget: true}, ok: true},
+		{name: "catalog import preview is excluded", route: routing.Post("/notification-classes/import/preview", "", nil)},
 		{name: "validation is excluded", route: routing.Post("/buildings/validate", "", nil)},
 		{name: "cabinet validation is excluded", route: routing.Post("/control-cabinets/validate", "", nil)},
 		{name: "controller validation is excluded", route: routing.Post("/sps-controllers/validate", "", nil)},
 		{name: "bulk read is excluded", route: routing.Post("/apparats/bulk", "", nil)},
+		{name: "queued bulk edit is excluded", route: routing.Post("/bacnet-objects/alarm-values/bulk-edit", "", nil)},
+		{name: "queued device upgrade is excluded", route: routing.Post("/object-data/:id/upgrade-devices", "", nil)},
 		{name: "building bulk read is excluded", route: routing.Post("/buildings/bulk", "", nil)},
 		{name: "cabinet bulk read is excluded", route: routing.Post("/control-cabinets/bulk", "", nil)},
 		{name: "controller bulk read is excluded", route: routing.Post("/sps-controllers/bulk", "", nil)},
@@ -34,6 +34,21 @@ type Handlers struct {
 	UpdateNotificationClass  gin.HandlerFunc
 	DeleteNotificationClass  gin.HandlerFunc
 	GetBacnetReferenceUsages gin.HandlerFunc
+
+	ExportStateTexts               gin.HandlerFunc
+	PreviewStateTextImport         gin.HandlerFunc
+	ImportStateTexts               gin.HandlerFunc
+	CheckStateTextCoverage         gin.HandlerFunc
+	ExportNotificationClasses      gin.HandlerFunc
+	PreviewNotificationClassImport gin.HandlerFunc
+	ImportNotificationClasses      gin.HandlerFunc
+
+	ListStateTextTranslations     gin.HandlerFunc
+	ReplaceStateTextTranslations  gin.HandlerFunc
+	ListApparatTranslations       gin.HandlerFunc
+	ReplaceApparatTranslations    gin.HandlerFunc
+	ListSystemPartTranslations    gin.HandlerFunc
+	ReplaceSystemPartTranslations gin.HandlerFunc
 }
 
 func Routes(handlers Handlers) []routing.Definition {
@@ -48,18 +63,31 @@ func Routes(handlers Handlers) []routing.Definition {
 		routing.Get("/system-parts/:id", domainUser.PermissionSystemPartRead, handlers.GetSystemPart),
 		routing.Put("/system-parts/:id", domainUser.PermissionSystemPartUpdate, handlers.UpdateSystemPart),
 		routing.Delete("/system-parts/:id", domainUser.PermissionSystemPartDelete, handlers.DeleteSystemPart),
+		routing.Get("/system-parts/:id/translations", domainUser.PermissionSystemPartRead, handlers.ListSystemPartTranslations),
+		routing.Put("/system-parts/:id/translations/:locale", domainUser.PermissionSystemPartUpdate, handlers.ReplaceSystemPartTranslations),
 		routing.Post("/apparats", domainUser.PermissionApparatCreate, handlers.CreateApparat),
 		routing.Post("/apparats/bulk", domainUser.PermissionApparatRead, handlers.GetApparatsByIDs),
 		routing.Get("/apparats", domainUser.PermissionApparatRead, handlers.ListApparats),
 		routing.Get("/apparats/:id", domainUser.PermissionApparatRead, handlers.GetApparat),
 		routing.Put("/apparats/:id", domainUser.PermissionApparatUpdate, handlers.UpdateApparat),
 		routing.Delete("/apparats/:id", domainUser.PermissionApparatDelete, handlers.DeleteApparat),
+		routing.Get("/apparats/:id/translations", domainUser.PermissionApparatRead, handlers.ListApparatTranslations),
+		routing.Put("/apparats/:id/translations/:locale", domainUser.PermissionApparatUpdate, handlers.ReplaceApparatTranslations),
 		routing.Get("/state-texts", domainUser.PermissionStateTextRead, handlers.ListStateTexts),
+		routing.Get("/state-texts/export", domainUser.PermissionStateTextRead, handlers.ExportStateTexts),
+		routing.Get("/state-texts/coverage", domainUser.PermissionStateTextRead, handlers.CheckStateTextCoverage),
+		routing.Post("/state-texts/import/preview", domainUser.PermissionStateTextUpdate, handlers.PreviewStateTextImport),
+		routing.Post("/state-texts/import", domainUser.PermissionStateTextUpdate, handlers.ImportStateTexts),
 		routing.Get("/state-texts/:id", domainUser.PermissionStateTextRead, handlers.GetStateText),
 		routing.Post("/state-texts", domainUser.PermissionStateTextCreate, handlers.CreateStateText),
 		routing.Put("/state-texts/:id", domainUser.PermissionStateTextUpdate, handlers.UpdateStateText),
 		routing.Delete("/state-texts/:id", domainUser.PermissionStateTextDelete, handlers.DeleteStateText),
+		routing.Get("/state-texts/:id/translations", domainUser.PermissionStateTextRead, handlers.ListStateTextTranslations),
+		routing.Put("/state-texts/:id/translations/:locale", domainUser.PermissionStateTextUpdate, handlers.ReplaceStateTextTranslations),
 		routing.Get("/notification-classes", domainUser.PermissionNotificationClassRead, handlers.ListNotificationClasses),
+		routing.Get("/notification-classes/export", domainUser.PermissionNotificationClassRead, handlers.ExportNotificationClasses),
+		routing.Post("/notification-classes/import/preview", domainUser.PermissionNotificationClassUpdate, handlers.PreviewNotificationClassImport),
+		routing.Post("/notification-classes/import", domainUser.PermissionNotificationClassUpdate, handlers.ImportNotificationClasses),
 		routing.Get("/notification-classes/:id", domainUser.PermissionNotificationClassRead, handlers.GetNotificationClass),
 		routing.Post("/notification-classes", domainUser.PermissionNotificationClassCreate, handlers.CreateNotificationClass),
 		routing.Put("/notification-classes/:id", domainUser.PermissionNotificationClassUpdate, handlers.UpdateNotificationClass),
@@ -0,0 +1,226 @@
+package facility
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	referencecatalog "github.com/besart951/go_infra_link/backend/internal/application/referencecatalog"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+const maxReferenceCatalogImportBytes = int64(32 << 20)
+
+type ReferenceCatalogService interface {
+	Preview(ctx context.Context, command referencecatalog.Command) (referencecatalog.Plan, error)
+	Import(ctx context.Context, command referencecatalog.Command) (referencecatalog.Plan, error)
+	Export(ctx context.Context, kind referencecatalog.Kind, format referencecatalog.Format, target io.Writer) error
+	CheckStateTextCoverage(ctx context.Context, limit int) (referencecatalog.Coverage, error)
+}
+
+type ReferenceCatalogHandler struct {
+	service ReferenceCatalogService
+}
+
+func NewReferenceCatalogHandler(service ReferenceCatalogService) *ReferenceCatalogHandler {
+	return &ReferenceCatalogHandler{service: service}
+}
+
+// ExportStateTexts godoc
+// @Summary Export the state text catalog
+// @Tags Facility - State Texts
+// @Produce application/octet-stream
+// @Param format query string false "csv or xlsx" default(xlsx)
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/facility/state-texts/export [get]
+func (h *ReferenceCatalogHandler) ExportStateTexts(c *gin.Context) {
+	h.export(c, referencecatalog.KindStateTexts)
+}
+
+// PreviewStateTextImport godoc
+// @Summary Preview a state text catalog import
+// @Description Reports which state texts the file would create or update, keyed by reference number, with the number of BACnet objects using each changed entry.
+// @Tags Facility - State Texts
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX state text catalog"
+// @Param format formData string false "csv or xlsx; defaults to the file extension"
+// @Success 200 {object} referencecatalog.Plan
+// @Failure 422 {object} referencecatalog.Plan
+// @Router /api/v1/facility/state-texts/import/preview [post]
+func (h *ReferenceCatalogHandler) PreviewStateTextImport(c *gin.Context) {
+	h.importCatalog(c, referencecatalog.KindStateTexts, false)
+}
+
+// ImportStateTexts godoc
+// @Summary Import a state text catalog
+// @Description Creates and updates state texts keyed by reference number. A file with issues is rejected without changes.
+// @Tags Facility - State Texts
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX state text catalog"
+// @Param format formData string false "csv or xlsx; defaults to the file extension"
+// @Success 200 {object} referencecatalog.Plan
+// @Failure 422 {object} referencecatalog.Plan
+// @Router /api/v1/facility/state-texts/import [post]
+func (h *ReferenceCatalogHandler) ImportStateTexts(c *gin.Context) {
+	h.importCatalog(c, referencecatalog.KindStateTexts, true)
+}
+
+// CheckStateTextCoverage godoc
+// @Summary List multi-state BACnet objects with missing or short state texts
+// @Tags Facility - State Texts
+// @Produce json
+// @Param limit query int false "Maximum number of reported issues" default(1000)
+// @Success 200 {object} referencecatalog.Coverage
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/facility/state-texts/coverage [get]
+func (h *ReferenceCatalogHandler) CheckStateTextCoverage(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+	limit := referencecatalog.DefaultCoverageLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			respondError(c, http.StatusBadRequest, "invalid_limit", "limit must be a positive number")
+			return
+		}
+		limit = parsed
+	}
+	coverage, err := h.service.CheckStateTextCoverage(c.Request.Context(), limit)
+	switch {
+	case errors.Is(err, referencecatalog.ErrCoverageUnavailable):
+		respondError(c, http.StatusServiceUnavailable, "coverage_unavailable", err.Error())
+	case err != nil:
+		respondError(c, http.StatusInternalServerError, "coverage_failed", err.Error())
+	default:
+		c.JSON(http.StatusOK, coverage)
+	}
+}
+
+// ExportNotificationClasses godoc
+// @Summary Export the notification class catalog
+// @Tags Facility - Notification Classes
+// @Produce application/octet-stream
+// @Param format query string false "csv or xlsx" default(xlsx)
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/facility/notification-classes/export [get]
+func (h *ReferenceCatalogHandler) ExportNotificationClasses(c *gin.Context) {
+	h.export(c, referencecatalog.KindNotificationClasses)
+}
+
+// PreviewNotificationClassImport godoc
+// @Summary Preview a notification class catalog import
+// @Description Reports which notification classes the file would create or update, keyed by class number, with the number of BACnet objects using each changed entry.
+// @Tags Facility - Notification Classes
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX notification class catalog"
+// @Param format formData string false "csv or xlsx; defaults to the file extension"
+// @Success 200 {object} referencecatalog.Plan
+// @Failure 422 {object} referencecatalog.Plan
+// @Router /api/v1/facility/notification-classes/import/preview [post]
+func (h *ReferenceCatalogHandler) PreviewNotificationClassImport(c *gin.Context) {
+	h.importCatalog(c, referencecatalog.KindNotificationClasses, false)
+}
+
+// ImportNotificationClasses godoc
+// @Summary Import a notification class catalog
+// @Description Creates and updates notification classes keyed by class number. A file with issues is rejected without changes.
+// @Tags Facility - Notification Classes
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX notification class catalog"
+// @Param format formData string false "csv or xlsx; defaults to the file extension"
+// @Success 200 {object} referencecatalog.Plan
+// @Failure 422 {object} referencecatalog.Plan
+// @Router /api/v1/facility/notification-classes/import [post]
+func (h *ReferenceCatalogHandler) ImportNotificationClasses(c *gin.Context) {
+	h.importCatalog(c, referencecatalog.KindNotificationClasses, true)
+}
+
+func (h *ReferenceCatalogHandler) export(c *gin.Context, kind referencecatalog.Kind) {
+	if !h.available(c) {
+		return
+	}
+	format, ok := referencecatalog.ParseFormat(c.DefaultQuery("format", string(referencecatalog.FormatXLSX)))
+	if !ok {
+		respondError(c, http.StatusBadRequest, "invalid_format", "format must be csv or xlsx")
+		return
+	}
+	contentType := "text/csv; charset=utf-8"
+	if format == referencecatalog.FormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	var body bytes.Buffer
+	if err := h.service.Export(c.Request.Context(), kind, format, &body); err != nil {
+		respondError(c, http.StatusInternalServerError, "catalog_export_failed", err.Error())
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=\""+string(kind)+"."+string(format)+"\"")
+	c.Data(http.StatusOK, contentType, body.Bytes())
+}
+
+func (h *ReferenceCatalogHandler) importCatalog(c *gin.Context, kind referencecatalog.Kind, apply bool) {
+	if !h.available(c) {
+		return
+	}
+	if _, ok := middleware.GetUserID(c); !ok {
+		respondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxReferenceCatalogImportBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid_import_file", err.Error())
+		return
+	}
+	requested := c.PostForm("format")
+	if requested == "" {
+		requested = strings.TrimPrefix(filepath.Ext(fileHeader.Filename), ".")
+	}
+	format, ok := referencecatalog.ParseFormat(requested)
+	if !ok {
+		respondError(c, http.StatusBadRequest, "invalid_format", "format must be csv or xlsx")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid_import_file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	command := referencecatalog.Command{Kind: kind, Format: format, Source: file}
+	var plan referencecatalog.Plan
+	if apply {
+		plan, err = h.service.Import(c.Request.Context(), command)
+	} else {
+		plan, err = h.service.Preview(c.Request.Context(), command)
+	}
+	switch {
+	case errors.Is(err, referencecatalog.ErrInvalidCatalog):
+		c.JSON(http.StatusUnprocessableEntity, plan)
+	case err != nil:
+		respondLocalizedDomainError(c, err, "catalog_import_failed", "facility.update_failed", localizedConflict("facility.entity_conflict"))
+	default:
+		c.JSON(http.StatusOK, plan)
+	}
+}
+
+func (h *ReferenceCatalogHandler) available(c *gin.Context) bool {
+	if h.service == nil {
+		respondError(c, http.StatusServiceUnavailable, "catalog_unavailable", "Reference catalog import is unavailable")
+		return false
+	}
+	return true
+}
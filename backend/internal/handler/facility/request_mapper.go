@@ -119,6 +119,7 @@ func toSPSControllerModel(req dto.CreateSPSControllerRequest) *domainFacility.SP
 		Subnet:            req.Subnet,
 		Gateway:           req.Gateway,
 		Vlan:              req.Vlan,
+		MACAddress:        req.MACAddress,
 	}
 }
 
@@ -151,6 +152,9 @@ func applySPSControllerUpdate(target *domainFacility.SPSController, req dto.Upda
 	if req.Vlan != nil {
 		target.Vlan = req.Vlan
 	}
+	if req.MACAddress != nil {
+		target.MACAddress = req.MACAddress
+	}
 }
 
 func toSPSControllerSystemTypes(inputs []dto.SPSControllerSystemTypeInput) []domainFacility.SPSControllerSystemType {
@@ -527,10 +531,17 @@ func applyControlCabinetUpdate(target *domainFacility.ControlCabinet, req dto.Up
 }
 
 func toUnitModel(req dto.CreateUnitRequest) *domainFacility.Unit {
+	factor := 1.0
+	if req.Factor != nil {
+		factor = *req.Factor
+	}
 	return &domainFacility.Unit{
-		Code:   req.Code,
-		Symbol: req.Symbol,
-		Name:   req.Name,
+		Code:      req.Code,
+		Symbol:    req.Symbol,
+		Name:      req.Name,
+		Dimension: req.Dimension,
+		Factor:    factor,
+		Offset:    req.Offset,
 	}
 }
 
@@ -544,6 +555,15 @@ func applyUnitUpdate(target *domainFacility.Unit, req dto.UpdateUnitRequest) {
 	if req.Name != nil {
 		target.Name = *req.Name
 	}
+	if req.Dimension != nil {
+		target.Dimension = *req.Dimension
+	}
+	if req.Factor != nil {
+		target.Factor = *req.Factor
+	}
+	if req.Offset != nil {
+		target.Offset = *req.Offset
+	}
 }
 
 func toAlarmFieldModel(req dto.CreateAlarmFieldRequest) *domainFacility.AlarmField {
@@ -584,6 +604,33 @@ func toAlarmTypeFieldModel(alarmTypeID uuid.UUID, req dto.CreateAlarmTypeFieldRe
 	}
 }
 
+func toAlarmDefinitionFieldOverrideModel(alarmDefinitionID uuid.UUID, req dto.CreateAlarmDefinitionFieldOverrideRequest) *domainFacility.AlarmDefinitionFieldOverride {
+	return &domainFacility.AlarmDefinitionFieldOverride{
+		AlarmDefinitionID:        alarmDefinitionID,
+		AlarmTypeFieldID:         req.AlarmTypeFieldID,
+		IsRequiredOverride:       req.IsRequiredOverride,
+		DefaultValueOverrideJSON: req.DefaultValueOverrideJSON,
+		ValidationOverrideJSON:   req.ValidationOverrideJSON,
+		UnitOverrideID:           req.UnitOverrideID,
+	}
+}
+
+func applyAlarmDefinitionFieldOverrideUpdate(target *domainFacility.AlarmDefinitionFieldOverride, req dto.UpdateAlarmDefinitionFieldOverrideRequest) {
+	if req.IsRequiredOverride != nil {
+		target.IsRequiredOverride = req.IsRequiredOverride
+	}
+	if req.DefaultValueOverrideJSON != nil {
+		target.DefaultValueOverrideJSON = req.DefaultValueOverrideJSON
+	}
+	if req.ValidationOverrideJSON != nil {
+		target.ValidationOverrideJSON = req.ValidationOverrideJSON
+	}
+	if req.UnitOverrideID != nil {
+		target.UnitOverrideID = req.UnitOverrideID
+		target.UnitOverride = nil
+	}
+}
+
 func applyAlarmTypeFieldUpdate(target *domainFacility.AlarmTypeField, req dto.UpdateAlarmTypeFieldRequest) {
 	if req.DisplayOrder != nil {
 		target.DisplayOrder = *req.DisplayOrder
@@ -25,12 +25,15 @@ var facilityResourceCatalog = []facilityResourceDefinition{
 	{name: "control_cabinets", routeSegment: "control-cabinets", readPermission: domainUser.PermissionControlCabinetRead},
 	{name: "sps_controllers", routeSegment: "sps-controllers", readPermission: domainUser.PermissionSPSControllerRead},
 	{name: "sps_controller_system_types", routeSegment: "sps-controller-system-types", readPermission: domainUser.PermissionSPSControllerSystemTypeRead},
+	{name: "ip_pools", routeSegment: "ip-pools", readPermission: domainUser.PermissionSPSControllerRead},
+	{name: "naming_schemes", routeSegment: "naming-schemes", readPermission: domainUser.PermissionSPSControllerRead},
 	{name: "field_devices", routeSegment: "field-devices", readPermission: domainUser.PermissionFieldDeviceRead},
 	{name: "bacnet_objects", routeSegment: "bacnet-objects", readPermission: domainUser.PermissionBacnetObjectRead},
 	{name: "object_data", routeSegment: "object-data", readPermission: domainUser.PermissionObjectDataRead},
 	{name: "state_texts", routeSegment: "state-texts", readPermission: domainUser.PermissionStateTextRead},
 	{name: "notification_classes", routeSegment: "notification-classes", readPermission: domainUser.PermissionNotificationClassRead},
 	{name: "alarm_definitions", routeSegment: "alarm-definitions", readPermission: domainUser.PermissionAlarmDefinitionRead},
+	{name: "alarm_definition_field_overrides", routeSegment: "alarm-definition-field-overrides", readPermission: domainUser.PermissionAlarmDefinitionRead},
 	{name: "alarm_types", routeSegment: "alarm-types", readPermission: domainUser.PermissionAlarmTypeRead},
 	{name: "alarm_type_fields", routeSegment: "alarm-type-fields", readPermission: domainUser.PermissionAlarmFieldRead},
 	{name: "alarm_fields", routeSegment: "alarm-fields", readPermission: domainUser.PermissionAlarmFieldRead},
@@ -41,7 +44,10 @@ func facilityResourceForRoute(path string) (facilityResourceDefinition, bool) {
 	if strings.HasPrefix(path, "/alarm-types/:id/fields") {
 		return facilityResourceByName("alarm_type_fields")
 	}
-	if path == "/imports/field-devices" {
+	if strings.HasPrefix(path, "/alarm-definitions/:id/field-overrides") {
+		return facilityResourceByName("alarm_definition_field_overrides")
+	}
+	if strings.HasPrefix(path, "/imports/field-devices") {
 		return facilityResourceByName("field_devices")
 	}
 
@@ -348,6 +348,9 @@ func toObjectDataResponse(obj domainFacility.ObjectData) dto.ObjectDataResponse
 		BacnetObjects: toBacnetObjectResponses(bacnetObjects),
 		CreatedAt:     obj.CreatedAt,
 		UpdatedAt:     obj.UpdatedAt,
+
+		ForkedFromID:       obj.ForkedFromID,
+		ForkedFromRevision: obj.ForkedFromRevision,
 	}
 }
 
@@ -365,14 +368,21 @@ func toSPSControllerSystemTypeListResponse(list *domain.PaginatedList[domainFaci
 
 func toUnitResponse(unit domainFacility.Unit) dto.UnitResponse {
 	return dto.UnitResponse{
-		ID:      unit.ID,
-		Version: unit.Version,
-		Code:    unit.Code,
-		Symbol:  unit.Symbol,
-		Name:    unit.Name,
+		ID:        unit.ID,
+		Version:   unit.Version,
+		Code:      unit.Code,
+		Symbol:    unit.Symbol,
+		Name:      unit.Name,
+		Dimension: unit.Dimension,
+		Factor:    unit.Factor,
+		Offset:    unit.Offset,
 	}
 }
 
+func toUnitDimensionResponse(dimension domainFacility.UnitDimension) dto.UnitDimensionResponse {
+	return dto.UnitDimensionResponse{Code: dimension.Code, Name: dimension.Name, BaseUnitCode: dimension.BaseUnitCode}
+}
+
 func toAlarmFieldResponse(field domainFacility.AlarmField) dto.AlarmFieldResponse {
 	return dto.AlarmFieldResponse{
 		ID:              field.ID,
@@ -411,6 +421,30 @@ func toAlarmTypeFieldResponse(atf domainFacility.AlarmTypeField) dto.AlarmTypeFi
 	return r
 }
 
+func toAlarmDefinitionFieldOverrideResponse(o domainFacility.AlarmDefinitionFieldOverride) dto.AlarmDefinitionFieldOverrideResponse {
+	r := dto.AlarmDefinitionFieldOverrideResponse{
+		ID:                       o.ID,
+		Version:                  o.Version,
+		AlarmDefinitionID:        o.AlarmDefinitionID,
+		AlarmTypeFieldID:         o.AlarmTypeFieldID,
+		IsRequiredOverride:       o.IsRequiredOverride,
+		DefaultValueOverrideJSON: o.DefaultValueOverrideJSON,
+		ValidationOverrideJSON:   o.ValidationOverrideJSON,
+		UnitOverrideID:           o.UnitOverrideID,
+		CreatedAt:                o.CreatedAt,
+		UpdatedAt:                o.UpdatedAt,
+	}
+	if o.AlarmTypeField != nil {
+		field := toAlarmTypeFieldResponse(*o.AlarmTypeField)
+		r.AlarmTypeField = &field
+	}
+	if o.UnitOverride != nil {
+		u := toUnitResponse(*o.UnitOverride)
+		r.UnitOverride = &u
+	}
+	return r
+}
+
 func toAlarmTypeResponse(at domainFacility.AlarmType) dto.AlarmTypeResponse {
 	return dto.AlarmTypeResponse{
 		ID:        at.ID,
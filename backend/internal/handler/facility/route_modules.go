@@ -32,6 +32,7 @@ func hierarchyHandlers(handlers *Handlers) hierarchy.Handlers {
 		CopySPSController:             handlers.SPSController.CopySPSController,
 		ListSPSControllers:            handlers.SPSController.ListSPSControllers,
 		GetNextAvailableGADevice:      handlers.SPSController.GetNextAvailableGADevice,
+		GetNextFreeSPSControllerIP:    handlers.IPPool.GetNextFreeSPSControllerIP,
 		GetSPSController:              handlers.SPSController.GetSPSController,
 		UpdateSPSController:           handlers.SPSController.UpdateSPSController,
 		DeleteSPSController:           handlers.SPSController.DeleteSPSController,
@@ -41,6 +42,22 @@ func hierarchyHandlers(handlers *Handlers) hierarchy.Handlers {
 		UpdateSPSControllerSystemType: handlers.SPSControllerSystemType.UpdateSPSControllerSystemType,
 		CopySPSControllerSystemType:   handlers.SPSControllerSystemType.CopySPSControllerSystemType,
 		DeleteSPSControllerSystemType: handlers.SPSControllerSystemType.DeleteSPSControllerSystemType,
+		CreateIPPool:                  handlers.IPPool.CreateIPPool,
+		ListIPPools:                   handlers.IPPool.ListIPPools,
+		GetIPPoolUtilizationReport:    handlers.IPPool.GetIPPoolUtilizationReport,
+		GetIPPool:                     handlers.IPPool.GetIPPool,
+		GetIPPoolUtilization:          handlers.IPPool.GetIPPoolUtilization,
+		GetNextFreeIP:                 handlers.IPPool.GetNextFreeIP,
+		UpdateIPPool:                  handlers.IPPool.UpdateIPPool,
+		DeleteIPPool:                  handlers.IPPool.DeleteIPPool,
+
+		CreateNamingScheme:      handlers.NamingScheme.CreateNamingScheme,
+		ListNamingSchemes:       handlers.NamingScheme.ListNamingSchemes,
+		GetResolvedNamingScheme: handlers.NamingScheme.GetResolvedNamingScheme,
+		PreviewNamingScheme:     handlers.NamingScheme.PreviewNamingScheme,
+		GetNamingScheme:         handlers.NamingScheme.GetNamingScheme,
+		UpdateNamingScheme:      handlers.NamingScheme.UpdateNamingScheme,
+		DeleteNamingScheme:      handlers.NamingScheme.DeleteNamingScheme,
 	}
 }
 
@@ -73,6 +90,21 @@ func referenceHandlers(handlers *Handlers) reference.Handlers {
 		UpdateNotificationClass:  handlers.NotificationClass.UpdateNotificationClass,
 		DeleteNotificationClass:  handlers.NotificationClass.DeleteNotificationClass,
 		GetBacnetReferenceUsages: handlers.BacnetReferenceUsage.GetBacnetReferenceUsages,
+
+		ExportStateTexts:               handlers.ReferenceCatalog.ExportStateTexts,
+		PreviewStateTextImport:         handlers.ReferenceCatalog.PreviewStateTextImport,
+		ImportStateTexts:               handlers.ReferenceCatalog.ImportStateTexts,
+		CheckStateTextCoverage:         handlers.ReferenceCatalog.CheckStateTextCoverage,
+		ExportNotificationClasses:      handlers.ReferenceCatalog.ExportNotificationClasses,
+		PreviewNotificationClassImport: handlers.ReferenceCatalog.PreviewNotificationClassImport,
+		ImportNotificationClasses:      handlers.ReferenceCatalog.ImportNotificationClasses,
+
+		ListStateTextTranslations:     handlers.Translation.ListStateTextTranslations,
+		ReplaceStateTextTranslations:  handlers.Translation.ReplaceStateTextTranslations,
+		ListApparatTranslations:       handlers.Translation.ListApparatTranslations,
+		ReplaceApparatTranslations:    handlers.Translation.ReplaceApparatTranslations,
+		ListSystemPartTranslations:    handlers.Translation.ListSystemPartTranslations,
+		ReplaceSystemPartTranslations: handlers.Translation.ReplaceSystemPartTranslations,
 	}
 }
 
@@ -93,10 +125,18 @@ func fieldDeviceHandlers(handlers *Handlers) fielddevice.Handlers {
 		DeleteFieldDevice:              handlers.FieldDevice.DeleteFieldDevice,
 		BulkUpdateFieldDevices:         handlers.FieldDevice.BulkUpdateFieldDevices,
 		BulkDeleteFieldDevices:         handlers.FieldDevice.BulkDeleteFieldDevices,
+		PreviewBacnetNumbering:         handlers.FieldDevice.PreviewBacnetNumbering,
 		CreateFieldDeviceExport:        handlers.Export.CreateFieldDeviceExport,
+		AnalyzeExportCollisions:        handlers.Export.AnalyzeExportCollisions,
 		GetExportStatus:                handlers.Export.GetExportStatus,
 		DownloadExport:                 handlers.Export.DownloadExport,
 		ImportFieldDevices:             handlers.Import.ImportFieldDevices,
+		ImportFieldDevicesEDE:          handlers.Import.ImportFieldDevicesEDE,
+		PreviewFieldDeviceImport:       handlers.Import.PreviewFieldDeviceImport,
+		ConfirmFieldDeviceImport:       handlers.Import.ConfirmFieldDeviceImport,
+		DiscardFieldDeviceImport:       handlers.Import.DiscardFieldDeviceImport,
+		ImportFieldDeviceEdits:         handlers.Import.ImportFieldDeviceEdits,
+		GetFieldDeviceObjectDataDiff:   handlers.FieldDevice.GetFieldDeviceObjectDataDiff,
 	}
 }
 
@@ -113,6 +153,13 @@ func objectDataHandlers(handlers *Handlers) objectdata.Handlers {
 		CreateObjectData:           handlers.ObjectData.CreateObjectData,
 		UpdateObjectData:           handlers.ObjectData.UpdateObjectData,
 		DeleteObjectData:           handlers.ObjectData.DeleteObjectData,
+
+		ListBacnetObjectTranslations:    handlers.Translation.ListBacnetObjectTranslations,
+		ReplaceBacnetObjectTranslations: handlers.Translation.ReplaceBacnetObjectTranslations,
+
+		ListObjectDataRevisions:  handlers.ObjectData.ListObjectDataRevisions,
+		DiffObjectDataRevisions:  handlers.ObjectData.DiffObjectDataRevisions,
+		UpgradeObjectDataDevices: handlers.ObjectData.UpgradeObjectDataDevices,
 	}
 }
 
@@ -123,6 +170,10 @@ func alarmHandlers(handlers *Handlers) alarm.Handlers {
 		CreateAlarmDefinition: handlers.AlarmDefinition.CreateAlarmDefinition,
 		UpdateAlarmDefinition: handlers.AlarmDefinition.UpdateAlarmDefinition,
 		DeleteAlarmDefinition: handlers.AlarmDefinition.DeleteAlarmDefinition,
+		ListFieldOverrides:    handlers.AlarmDefinitionOverride.ListAlarmDefinitionFieldOverrides,
+		CreateFieldOverride:   handlers.AlarmDefinitionOverride.CreateAlarmDefinitionFieldOverride,
+		UpdateFieldOverride:   handlers.AlarmDefinitionOverride.UpdateAlarmDefinitionFieldOverride,
+		DeleteFieldOverride:   handlers.AlarmDefinitionOverride.DeleteAlarmDefinitionFieldOverride,
 		ListAlarmTypes:        handlers.AlarmType.ListAlarmTypes,
 		CreateAlarmType:       handlers.AlarmType.CreateAlarmType,
 		GetAlarmType:          handlers.AlarmType.GetAlarmType,
@@ -137,6 +188,7 @@ func alarmHandlers(handlers *Handlers) alarm.Handlers {
 		CreateUnit:            handlers.Unit.CreateUnit,
 		UpdateUnit:            handlers.Unit.UpdateUnit,
 		DeleteUnit:            handlers.Unit.DeleteUnit,
+		ListUnitDimensions:    handlers.Unit.ListUnitDimensions,
 		ListAlarmFields:       handlers.AlarmField.ListAlarmFields,
 		GetAlarmField:         handlers.AlarmField.GetAlarmField,
 		CreateAlarmField:      handlers.AlarmField.CreateAlarmField,
@@ -145,5 +197,6 @@ func alarmHandlers(handlers *Handlers) alarm.Handlers {
 		GetAlarmSchema:        handlers.BacnetAlarm.GetAlarmSchema,
 		GetAlarmValues:        handlers.BacnetAlarm.GetAlarmValues,
 		PutAlarmValues:        handlers.BacnetAlarm.PutAlarmValues,
+		BulkEditAlarmValues:   handlers.BacnetAlarm.BulkEditAlarmValues,
 	}
 }
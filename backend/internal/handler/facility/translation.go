@@ -0,0 +1,190 @@
+package facility
+
+import (
+	"net/http"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TranslationHandler struct {
+	service TranslationService
+}
+
+func NewTranslationHandler(service TranslationService) *TranslationHandler {
+	return &TranslationHandler{service: service}
+}
+
+// ListStateTextTranslations godoc
+// @Summary List the translations of a state text
+// @Tags facility-state-texts
+// @Produce json
+// @Param id path string true "State Text ID"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/state-texts/{id}/translations [get]
+func (h *TranslationHandler) ListStateTextTranslations(c *gin.Context) {
+	h.list(c, domainFacility.TranslationResourceStateText)
+}
+
+// ReplaceStateTextTranslations godoc
+// @Summary Replace the translations of a state text in one locale
+// @Description Fields are state_text_1 to state_text_16. States without a translation show the base text.
+// @Tags facility-state-texts
+// @Accept json
+// @Produce json
+// @Param id path string true "State Text ID"
+// @Param locale path string true "Locale, e.g. fr_CH or fr"
+// @Param request body dto.ReplaceTranslationsRequest true "Texts by field"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/state-texts/{id}/translations/{locale} [put]
+func (h *TranslationHandler) ReplaceStateTextTranslations(c *gin.Context) {
+	h.replace(c, domainFacility.TranslationResourceStateText)
+}
+
+// ListBacnetObjectTranslations godoc
+// @Summary List the translations of a BACnet object
+// @Tags facility-bacnet-objects
+// @Produce json
+// @Param id path string true "BACnet Object ID"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/bacnet-objects/{id}/translations [get]
+func (h *TranslationHandler) ListBacnetObjectTranslations(c *gin.Context) {
+	h.list(c, domainFacility.TranslationResourceBacnetObject)
+}
+
+// ReplaceBacnetObjectTranslations godoc
+// @Summary Replace the translated fixed text of a BACnet object in one locale
+// @Description The only field is text_fix.
+// @Tags facility-bacnet-objects
+// @Accept json
+// @Produce json
+// @Param id path string true "BACnet Object ID"
+// @Param locale path string true "Locale, e.g. fr_CH or fr"
+// @Param request body dto.ReplaceTranslationsRequest true "Texts by field"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/bacnet-objects/{id}/translations/{locale} [put]
+func (h *TranslationHandler) ReplaceBacnetObjectTranslations(c *gin.Context) {
+	h.replace(c, domainFacility.TranslationResourceBacnetObject)
+}
+
+// ListApparatTranslations godoc
+// @Summary List the translations of an apparat
+// @Tags facility-apparats
+// @Produce json
+// @Param id path string true "Apparat ID"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/apparats/{id}/translations [get]
+func (h *TranslationHandler) ListApparatTranslations(c *gin.Context) {
+	h.list(c, domainFacility.TranslationResourceApparat)
+}
+
+// ReplaceApparatTranslations godoc
+// @Summary Replace the translations of an apparat in one locale
+// @Description Fields are name and description.
+// @Tags facility-apparats
+// @Accept json
+// @Produce json
+// @Param id path string true "Apparat ID"
+// @Param locale path string true "Locale, e.g. fr_CH or fr"
+// @Param request body dto.ReplaceTranslationsRequest true "Texts by field"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/apparats/{id}/translations/{locale} [put]
+func (h *TranslationHandler) ReplaceApparatTranslations(c *gin.Context) {
+	h.replace(c, domainFacility.TranslationResourceApparat)
+}
+
+// ListSystemPartTranslations godoc
+// @Summary List the translations of a system part
+// @Tags facility-system-parts
+// @Produce json
+// @Param id path string true "System Part ID"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/system-parts/{id}/translations [get]
+func (h *TranslationHandler) ListSystemPartTranslations(c *gin.Context) {
+	h.list(c, domainFacility.TranslationResourceSystemPart)
+}
+
+// ReplaceSystemPartTranslations godoc
+// @Summary Replace the translations of a system part in one locale
+// @Description Fields are name and description.
+// @Tags facility-system-parts
+// @Accept json
+// @Produce json
+// @Param id path string true "System Part ID"
+// @Param locale path string true "Locale, e.g. fr_CH or fr"
+// @Param request body dto.ReplaceTranslationsRequest true "Texts by field"
+// @Success 200 {object} dto.EntityTranslationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/facility/system-parts/{id}/translations/{locale} [put]
+func (h *TranslationHandler) ReplaceSystemPartTranslations(c *gin.Context) {
+	h.replace(c, domainFacility.TranslationResourceSystemPart)
+}
+
+func (h *TranslationHandler) list(c *gin.Context, resource domainFacility.TranslationResource) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if h.service == nil {
+		respondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return
+	}
+	items, err := h.service.List(c.Request.Context(), resource, id)
+	if respondTranslationError(c, err, "fetch_failed", "facility.fetch_failed") {
+		return
+	}
+	c.JSON(http.StatusOK, toEntityTranslationsResponse(id, items))
+}
+
+func (h *TranslationHandler) replace(c *gin.Context, resource domainFacility.TranslationResource) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.ReplaceTranslationsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if h.service == nil {
+		respondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return
+	}
+	items, err := h.service.Replace(c.Request.Context(), resource, id, c.Param("locale"), req.Fields)
+	if respondTranslationError(c, err, "error", "facility.update_failed") {
+		return
+	}
+	c.JSON(http.StatusOK, toEntityTranslationsResponse(id, items))
+}
+
+func respondTranslationError(c *gin.Context, err error, fallbackCode, fallbackKey string) bool {
+	return respondLocalizedDomainError(c, err, fallbackCode, fallbackKey,
+		localizedNotFound("facility.not_found"),
+		handlerutil.MapError(facilityservice.ErrTranslationsUnavailable, handlerutil.LocalizedError(http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")),
+	)
+}
+
+func toEntityTranslationsResponse(entityID uuid.UUID, items []domainFacility.Translation) dto.EntityTranslationsResponse {
+	translations := make(map[string]map[string]string)
+	for _, item := range items {
+		if translations[item.Locale] == nil {
+			translations[item.Locale] = make(map[string]string)
+		}
+		translations[item.Locale][item.Field] = item.Text
+	}
+	return dto.EntityTranslationsResponse{EntityID: entityID, Translations: translations}
+}
@@ -106,6 +106,7 @@ func (h *ValidationHandler) ValidateSPSController(c *gin.Context) {
 		Subnet:           req.Subnet,
 		Gateway:          req.Gateway,
 		Vlan:             req.Vlan,
+		MACAddress:       req.MACAddress,
 	}
 
 	if err := h.spsControllerService.Validate(c.Request.Context(), controller, req.ID); respondValidationOrError(c, err, "validation_failed") {
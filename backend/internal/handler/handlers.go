@@ -10,6 +10,7 @@ import (
 	projecthandler "github.com/besart951/go_infra_link/backend/internal/handler/project"
 	teamhandler "github.com/besart951/go_infra_link/backend/internal/handler/team"
 	userhandler "github.com/besart951/go_infra_link/backend/internal/handler/user"
+	webhookhandler "github.com/besart951/go_infra_link/backend/internal/handler/webhook"
 )
 
 type Handlers struct {
@@ -23,4 +24,5 @@ type Handlers struct {
 	User             *userhandler.Handlers
 	Facility         *facilityhandler.Handlers
 	History          *historyhandler.Handler
+	Webhook          *webhookhandler.WebhookHandler
 }
@@ -0,0 +1,127 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	sharedpresenter "github.com/besart951/go_infra_link/backend/internal/handler/presenter/shared"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	exportservice "github.com/besart951/go_infra_link/backend/internal/service/exporting"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ChangeReportService interface {
+	CreateChangeReport(ctx context.Context, ownerID, operationID uuid.UUID, req domainExport.ChangeReportRequest) (domainExport.Job, error)
+}
+
+var changeReportFormats = map[string]domainExport.OutputType{
+	"xlsx": domainExport.OutputTypeChangeReportExcel,
+	"pdf":  domainExport.OutputTypeChangeReportPDF,
+}
+
+// SetChangeReports enables the change report endpoints.
+func (h *Handler) SetChangeReports(reports ChangeReportService) {
+	h.reports = reports
+}
+
+// CreateChangeReport godoc
+// @Summary Generate a change report
+// @Description Queues an export job that renders the filtered audit events as a change list, grouped by building, control cabinet and controller with field-level before and after values. Accepts the timeline filters; the download link is part of the completed export job.
+// @Tags history
+// @Produce json
+// @Param format query string false "xlsx or pdf" default(xlsx)
+// @Param locale query string false "Report language, e.g. de_CH; defaults to the request language"
+// @Param scope_type query string false "Scope type"
+// @Param scope_id query string false "Scope UUID"
+// @Param entity_table query string false "Entity table"
+// @Param actor_id query string false "Actor UUID"
+// @Param occurred_from query string false "Earliest ISO-8601 timestamp"
+// @Param occurred_to query string false "Latest ISO-8601 timestamp"
+// @Param action query []string false "Actions: create, update, delete, restore" collectionFormat(multi)
+// @Param field query []string false "Changed field names" collectionFormat(multi)
+// @Success 202 {object} facilitydto.FieldDeviceExportJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/history/change-reports [post]
+func (h *Handler) CreateChangeReport(c *gin.Context) {
+	filter, ok := parseTimelineFilter(c)
+	if !ok {
+		return
+	}
+	h.createChangeReport(c, domainExport.ChangeReportRequest{AccessScope: domainExport.AccessScopeGlobal, Filter: filter})
+}
+
+// CreateProjectChangeReport godoc
+// @Summary Generate a project change report
+// @Description Like the global change report, limited to the audit events of the project.
+// @Tags history
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param format query string false "xlsx or pdf" default(xlsx)
+// @Param locale query string false "Report language, e.g. de_CH; defaults to the request language"
+// @Param occurred_from query string false "Earliest ISO-8601 timestamp"
+// @Param occurred_to query string false "Latest ISO-8601 timestamp"
+// @Success 202 {object} facilitydto.FieldDeviceExportJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/history/change-reports [post]
+func (h *Handler) CreateProjectChangeReport(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	filter, ok := parseTimelineFilter(c)
+	if !ok {
+		return
+	}
+	if filter.ScopeType != "" && filter.ScopeID != uuid.Nil {
+		filter.SecondaryScopeType = filter.ScopeType
+		filter.SecondaryScopeID = filter.ScopeID
+	}
+	filter.ScopeType = "project"
+	filter.ScopeID = projectID
+	h.createChangeReport(c, domainExport.ChangeReportRequest{
+		ProjectIDs: []uuid.UUID{projectID}, AccessScope: domainExport.AccessScopeProject, Filter: filter,
+	})
+}
+
+func (h *Handler) createChangeReport(c *gin.Context, req domainExport.ChangeReportRequest) {
+	if h.reports == nil {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return
+	}
+	outputType, ok := changeReportFormats[c.DefaultQuery("format", "xlsx")]
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_format", "errors.validation_error")
+		return
+	}
+	locale, ok := domainExport.ParseLocale(c.DefaultQuery("locale", middleware.GetLocale(c)))
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_locale", "facility.invalid_locale")
+		return
+	}
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
+		return
+	}
+	operationID, ok := historyOperationID(c)
+	if !ok {
+		return
+	}
+	req.OutputType, req.Locale = outputType, locale
+	job, err := h.reports.CreateChangeReport(c.Request.Context(), ownerID, operationID, req)
+	if err != nil {
+		if errors.Is(err, exportservice.ErrChangeReportsUnavailable) {
+			handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+			return
+		}
+		handlerutil.RespondLocalizedError(c, http.StatusInternalServerError, "change_report_failed", "errors.internal_server_error")
+		return
+	}
+	c.JSON(http.StatusAccepted, sharedpresenter.ToExportJobResponse(job))
+}
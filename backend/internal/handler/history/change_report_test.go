@@ -0,0 +1,59 @@
+package history
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type changeReportServiceStub struct {
+	request domainExport.ChangeReportRequest
+}
+
+func (s *changeReportServiceStub) CreateChangeReport(_ context.Context, _, operationID uuid.UUID, req domainExport.ChangeReportRequest) (domainExport.Job, error) {
+	s.request = req
+	return domainExport.Job{ID: operationID, Status: domainExport.StatusQueued}, nil
+}
+
+func TestCreateProjectChangeReportForcesProjectScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reports := &changeReportServiceStub{}
+	handler := NewHandler(historyServiceStub{})
+	handler.SetChangeReports(reports)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, uuid.New())
+		c.Next()
+	})
+	router.POST("/projects/:id/history/change-reports", handler.CreateProjectChangeReport)
+	projectID, cabinetID := uuid.New(), uuid.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/projects/"+projectID.String()+"/history/change-reports?format=pdf&locale=fr-ch&action=update&scope_type=control_cabinet&scope_id="+cabinetID.String(), nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusAccepted {
+		t.Fatalf("expected accepted, got %d: %s", res.Code, res.Body.String())
+	}
+	got := reports.request
+	if got.OutputType != domainExport.OutputTypeChangeReportPDF || got.Locale != "fr_CH" || got.AccessScope != domainExport.AccessScopeProject ||
+		len(got.ProjectIDs) != 1 || got.ProjectIDs[0] != projectID {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	if got.Filter.ScopeType != "project" || got.Filter.ScopeID != projectID || got.Filter.SecondaryScopeID != cabinetID || len(got.Filter.Actions) != 1 {
+		t.Fatalf("unexpected filter %+v", got.Filter)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/projects/"+projectID.String()+"/history/change-reports?format=docx", nil)
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected bad request for unknown format, got %d", res.Code)
+	}
+}
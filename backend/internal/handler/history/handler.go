@@ -2,20 +2,18 @@ package history
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
 	"github.com/besart951/go_infra_link/backend/internal/cursor"
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
 	facilitydto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
 	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/history"
-	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
-	sharedpresenter "github.com/besart951/go_infra_link/backend/internal/handler/presenter/shared"
 	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
 	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
 	"github.com/gin-gonic/gin"
@@ -29,11 +27,13 @@ type Service interface {
 	RestoreEntityToEvent(ctx context.Context, eventID uuid.UUID, mode domainHistory.RestoreMode) (*domainHistory.RestoreResult, error)
 	UndoBatch(ctx context.Context, batchID uuid.UUID) (*domainHistory.RestoreResult, error)
 	RestoreControlCabinet(ctx context.Context, controlCabinetID uuid.UUID, req domainHistory.RestoreControlCabinetRequest) (*domainHistory.RestoreResult, error)
+	PreviewHierarchyRestore(ctx context.Context, root hierarchyrestore.Root, rootID uuid.UUID, req domainHistory.RestoreHierarchyRequest) (*domainHistory.RestorePreview, error)
 }
 
 type Handler struct {
 	service Service
 	jobs    *facilityservice.FacilityJobManager
+	reports ChangeReportService
 }
 
 func NewHandler(service Service, jobs ...*facilityservice.FacilityJobManager) *Handler {
@@ -152,30 +152,6 @@ func (h *Handler) restoreEntity(c *gin.Context, requiredMode domainHistory.Resto
 	c.JSON(http.StatusOK, result)
 }
 
-// RestoreControlCabinet godoc
-// @Summary Restore a control-cabinet hierarchy asynchronously
-// @Tags history
-// @Accept json
-// @Produce json
-// @Param id path string true "Control cabinet UUID"
-// @Param Idempotency-Key header string false "Stable operation UUID"
-// @Param body body domainHistory.RestoreControlCabinetRequest false "Restore checkpoint"
-// @Success 202 {object} facilitydto.FacilityJobResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 409 {object} dto.ErrorResponse
-// @Router /api/v1/history/control-cabinets/{id}/restore [post]
-func (h *Handler) RestoreControlCabinet(c *gin.Context) {
-	controlCabinetID, ok := handlerutil.ParseUUIDParam(c, "id")
-	if !ok {
-		return
-	}
-	req, ok := parseControlCabinetRestoreRequest(c)
-	if !ok {
-		return
-	}
-	h.submitControlCabinetRestore(c, controlCabinetID, req)
-}
-
 // ListProjectTimeline godoc
 // @Summary List project audit activities
 // @Description Returns authoritative audit events for one project. The optional entity, field, action, actor and date filters work like the global timeline.
@@ -246,83 +222,6 @@ func (h *Handler) respondTimelineError(c *gin.Context, err error) bool {
 	return true
 }
 
-// RestoreProjectControlCabinet godoc
-// @Summary Restore a project-scoped control-cabinet hierarchy asynchronously
-// @Tags history, projects
-// @Accept json
-// @Produce json
-// @Param id path string true "Project UUID"
-// @Param controlCabinetId path string true "Control cabinet UUID"
-// @Param Idempotency-Key header string false "Stable operation UUID"
-// @Param body body domainHistory.RestoreControlCabinetRequest false "Restore checkpoint"
-// @Success 202 {object} facilitydto.FacilityJobResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 409 {object} dto.ErrorResponse
-// @Router /api/v1/projects/{id}/history/control-cabinets/{controlCabinetId}/restore [post]
-func (h *Handler) RestoreProjectControlCabinet(c *gin.Context) {
-	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
-	if !ok {
-		return
-	}
-	controlCabinetID, ok := handlerutil.ParseUUIDParam(c, "controlCabinetId")
-	if !ok {
-		return
-	}
-	req, ok := parseControlCabinetRestoreRequest(c)
-	if !ok {
-		return
-	}
-	req.ProjectID = &projectID
-	h.submitControlCabinetRestore(c, controlCabinetID, req)
-}
-
-// submitControlCabinetRestore starts the canonical durable hierarchy restore.
-func (h *Handler) submitControlCabinetRestore(c *gin.Context, cabinetID uuid.UUID, req domainHistory.RestoreControlCabinetRequest) {
-	if h.jobs == nil || !h.jobs.SupportsDurableTasks() {
-		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "durable_jobs_unavailable", "errors.service_unavailable")
-		return
-	}
-	actorID, ok := middleware.GetUserID(c)
-	if !ok {
-		handlerutil.RespondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
-		return
-	}
-	jobID, ok := historyOperationID(c)
-	if !ok {
-		return
-	}
-	asOf := req.AsOf
-	if asOf == nil && (req.EventID == nil || *req.EventID == uuid.Nil) {
-		now := time.Now().UTC()
-		asOf = &now
-	}
-	payload, err := json.Marshal(domainHistory.RestoreControlCabinetJobPayload{
-		ControlCabinetID: cabinetID, ProjectID: req.ProjectID, AsOf: asOf, EventID: req.EventID,
-	})
-	if err != nil {
-		handlerutil.RespondLocalizedError(c, http.StatusInternalServerError, "restore_failed", "facility.update_failed")
-		return
-	}
-	job, err := h.jobs.SubmitTask(c.Request.Context(), facilityservice.FacilityJob{
-		ID: jobID, OwnerID: actorID, Kind: facilityservice.FacilityJobKindControlCabinet,
-		Class: facilityservice.FacilityJobClassMutation, Type: facilityservice.FacilityJobTypeRestore,
-		Task: domainHistory.TaskRestoreControlCabinet, Payload: payload,
-		Admission: &facilityservice.FacilityAggregateAdmission{
-			ResourceID: cabinetID, State: facilityservice.FacilityAggregateStateRestoreStaging, AllowMissing: true,
-		},
-	})
-	if err != nil {
-		status := http.StatusServiceUnavailable
-		code := "service_unavailable"
-		if errors.Is(err, facilityservice.ErrAggregateLocked) || errors.Is(err, facilityservice.ErrFacilityJobLimit) {
-			status, code = http.StatusConflict, "aggregate_locked"
-		}
-		handlerutil.RespondLocalizedError(c, status, code, "facility.aggregate_locked")
-		return
-	}
-	c.JSON(http.StatusAccepted, sharedpresenter.ToFacilityJobResponse(job))
-}
-
 func historyOperationID(c *gin.Context) (uuid.UUID, bool) {
 	raw := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
 	if raw == "" {
@@ -421,32 +320,6 @@ func parseTimelineActions(c *gin.Context) ([]domainHistory.Action, bool) {
 	return actions, true
 }
 
-func parseControlCabinetRestoreRequest(c *gin.Context) (domainHistory.RestoreControlCabinetRequest, bool) {
-	var req domainHistory.RestoreControlCabinetRequest
-	if c.Request.ContentLength > 0 {
-		if !handlerutil.BindJSON(c, &req) {
-			return req, false
-		}
-	}
-	if asOf := c.Query("as_of"); asOf != "" {
-		parsed, err := time.Parse(time.RFC3339, asOf)
-		if err != nil {
-			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_as_of", "validation.invalid_request")
-			return req, false
-		}
-		req.AsOf = &parsed
-	}
-	if eventID := c.Query("event_id"); eventID != "" {
-		id, err := uuid.Parse(eventID)
-		if err != nil {
-			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_event_id", "validation.invalid_uuid_format")
-			return req, false
-		}
-		req.EventID = &id
-	}
-	return req, true
-}
-
 func parseOptionalIntQuery(c *gin.Context, key string) (*int, bool) {
 	raw := c.Query(key)
 	if raw == "" {
@@ -0,0 +1,322 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/history"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	sharedpresenter "github.com/besart951/go_infra_link/backend/internal/handler/presenter/shared"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type restoreTarget struct {
+	kind facilityservice.FacilityJobKind
+	task string
+}
+
+var restoreTargets = map[hierarchyrestore.Root]restoreTarget{
+	hierarchyrestore.RootBuilding: {
+		kind: facilityservice.FacilityJobKindBuilding, task: domainHistory.TaskRestoreBuilding,
+	},
+	hierarchyrestore.RootControlCabinet: {
+		kind: facilityservice.FacilityJobKindControlCabinet, task: domainHistory.TaskRestoreControlCabinet,
+	},
+	hierarchyrestore.RootSPSController: {
+		kind: facilityservice.FacilityJobKindSPSController, task: domainHistory.TaskRestoreSPSController,
+	},
+	hierarchyrestore.RootSPSControllerSystemType: {
+		kind: facilityservice.FacilityJobKindSPSControllerSystemType, task: domainHistory.TaskRestoreSPSControllerSystemType,
+	},
+	hierarchyrestore.RootObjectData: {
+		kind: facilityservice.FacilityJobKindObjectData, task: domainHistory.TaskRestoreObjectData,
+	},
+}
+
+// RestoreControlCabinet godoc
+// @Summary Restore a control-cabinet hierarchy asynchronously
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param id path string true "Control cabinet UUID"
+// @Param Idempotency-Key header string false "Stable operation UUID"
+// @Param body body domainHistory.RestoreControlCabinetRequest false "Restore checkpoint"
+// @Success 202 {object} facilitydto.FacilityJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.UndoConflictResponse
+// @Router /api/v1/history/control-cabinets/{id}/restore [post]
+func (h *Handler) RestoreControlCabinet(c *gin.Context) {
+	h.RestoreHierarchy(hierarchyrestore.RootControlCabinet)(c)
+}
+
+// RestoreProjectControlCabinet godoc
+// @Summary Restore a project-scoped control-cabinet hierarchy asynchronously
+// @Tags history, projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project UUID"
+// @Param controlCabinetId path string true "Control cabinet UUID"
+// @Param Idempotency-Key header string false "Stable operation UUID"
+// @Param body body domainHistory.RestoreControlCabinetRequest false "Restore checkpoint"
+// @Success 202 {object} facilitydto.FacilityJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.UndoConflictResponse
+// @Router /api/v1/projects/{id}/history/control-cabinets/{controlCabinetId}/restore [post]
+func (h *Handler) RestoreProjectControlCabinet(c *gin.Context) {
+	h.RestoreProjectHierarchy(hierarchyrestore.RootControlCabinet, "controlCabinetId")(c)
+}
+
+// RestoreHierarchy godoc
+// @Summary Restore an aggregate subtree to a point in time asynchronously
+// @Description Runs the undo-conflict preflight first and rejects the restore when current rows diverge from their recorded history, unless force is set.
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param id path string true "Root UUID"
+// @Param Idempotency-Key header string false "Stable operation UUID"
+// @Param body body domainHistory.RestoreHierarchyRequest false "Restore checkpoint"
+// @Success 202 {object} facilitydto.FacilityJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.UndoConflictResponse
+// @Router /api/v1/history/buildings/{id}/restore [post]
+// @Router /api/v1/history/sps-controllers/{id}/restore [post]
+// @Router /api/v1/history/sps-controller-system-types/{id}/restore [post]
+// @Router /api/v1/history/object-data/{id}/restore [post]
+func (h *Handler) RestoreHierarchy(root hierarchyrestore.Root) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rootID, ok := handlerutil.ParseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		req, ok := parseHierarchyRestoreRequest(c)
+		if !ok {
+			return
+		}
+		h.submitHierarchyRestore(c, root, rootID, req)
+	}
+}
+
+// RestoreProjectHierarchy godoc
+// @Summary Restore a project-scoped aggregate subtree asynchronously
+// @Tags history, projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project UUID"
+// @Param resourceId path string true "Root UUID"
+// @Param Idempotency-Key header string false "Stable operation UUID"
+// @Param body body domainHistory.RestoreHierarchyRequest false "Restore checkpoint"
+// @Success 202 {object} facilitydto.FacilityJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.UndoConflictResponse
+// @Router /api/v1/projects/{id}/history/buildings/{resourceId}/restore [post]
+// @Router /api/v1/projects/{id}/history/sps-controllers/{resourceId}/restore [post]
+// @Router /api/v1/projects/{id}/history/sps-controller-system-types/{resourceId}/restore [post]
+// @Router /api/v1/projects/{id}/history/object-data/{resourceId}/restore [post]
+func (h *Handler) RestoreProjectHierarchy(root hierarchyrestore.Root, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, rootID, ok := parseProjectRestoreParams(c, param)
+		if !ok {
+			return
+		}
+		req, ok := parseHierarchyRestoreRequest(c)
+		if !ok {
+			return
+		}
+		req.ProjectID = &projectID
+		h.submitHierarchyRestore(c, root, rootID, req)
+	}
+}
+
+// PreviewHierarchyRestore godoc
+// @Summary Preview an aggregate subtree restore
+// @Description Lists the entities that a restore would re-create, update, delete or skip, plus undo conflicts.
+// @Tags history
+// @Produce json
+// @Param id path string true "Root UUID"
+// @Param as_of query string false "Restore checkpoint (RFC3339)"
+// @Param event_id query string false "Restore to the time of this event"
+// @Success 200 {object} dto.RestorePreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/history/buildings/{id}/restore/preview [get]
+// @Router /api/v1/history/control-cabinets/{id}/restore/preview [get]
+// @Router /api/v1/history/sps-controllers/{id}/restore/preview [get]
+// @Router /api/v1/history/sps-controller-system-types/{id}/restore/preview [get]
+// @Router /api/v1/history/object-data/{id}/restore/preview [get]
+func (h *Handler) PreviewHierarchyRestore(root hierarchyrestore.Root) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rootID, ok := handlerutil.ParseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		req, ok := parseHierarchyRestoreRequest(c)
+		if !ok {
+			return
+		}
+		h.previewHierarchyRestore(c, root, rootID, req)
+	}
+}
+
+// PreviewProjectHierarchyRestore godoc
+// @Summary Preview a project-scoped aggregate subtree restore
+// @Tags history, projects
+// @Produce json
+// @Param id path string true "Project UUID"
+// @Param resourceId path string true "Root UUID"
+// @Param as_of query string false "Restore checkpoint (RFC3339)"
+// @Param event_id query string false "Restore to the time of this event"
+// @Success 200 {object} dto.RestorePreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/history/buildings/{resourceId}/restore/preview [get]
+// @Router /api/v1/projects/{id}/history/control-cabinets/{resourceId}/restore/preview [get]
+// @Router /api/v1/projects/{id}/history/sps-controllers/{resourceId}/restore/preview [get]
+// @Router /api/v1/projects/{id}/history/sps-controller-system-types/{resourceId}/restore/preview [get]
+// @Router /api/v1/projects/{id}/history/object-data/{resourceId}/restore/preview [get]
+func (h *Handler) PreviewProjectHierarchyRestore(root hierarchyrestore.Root, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, rootID, ok := parseProjectRestoreParams(c, param)
+		if !ok {
+			return
+		}
+		req, ok := parseHierarchyRestoreRequest(c)
+		if !ok {
+			return
+		}
+		req.ProjectID = &projectID
+		h.previewHierarchyRestore(c, root, rootID, req)
+	}
+}
+
+func (h *Handler) previewHierarchyRestore(c *gin.Context, root hierarchyrestore.Root, rootID uuid.UUID, req domainHistory.RestoreHierarchyRequest) {
+	preview, err := h.service.PreviewHierarchyRestore(c.Request.Context(), root, rootID, req)
+	if err != nil {
+		respondRestorePreviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.RestorePreviewResponseFrom(preview))
+}
+
+// submitHierarchyRestore runs the undo-conflict preflight and then starts the
+// canonical durable subtree restore. The job repeats the preflight in its
+// first step unless the request forces the restore.
+func (h *Handler) submitHierarchyRestore(c *gin.Context, root hierarchyrestore.Root, rootID uuid.UUID, req domainHistory.RestoreHierarchyRequest) {
+	if h.jobs == nil || !h.jobs.SupportsDurableTasks() {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "durable_jobs_unavailable", "errors.service_unavailable")
+		return
+	}
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
+		return
+	}
+	jobID, ok := historyOperationID(c)
+	if !ok {
+		return
+	}
+	if !req.Force {
+		preview, err := h.service.PreviewHierarchyRestore(c.Request.Context(), root, rootID, req)
+		if err != nil {
+			respondRestorePreviewError(c, err)
+			return
+		}
+		if len(preview.Conflicts) > 0 {
+			c.JSON(http.StatusConflict, dto.UndoConflictResponseFrom(preview.Conflicts[0]))
+			return
+		}
+	}
+	payload, err := encodeHierarchyRestorePayload(root, rootID, req)
+	if err != nil {
+		handlerutil.RespondLocalizedError(c, http.StatusInternalServerError, "restore_failed", "facility.update_failed")
+		return
+	}
+	target := restoreTargets[root]
+	job, err := h.jobs.SubmitTask(c.Request.Context(), facilityservice.FacilityJob{
+		ID: jobID, OwnerID: actorID, Kind: target.kind,
+		Class: facilityservice.FacilityJobClassMutation, Type: facilityservice.FacilityJobTypeRestore,
+		Task: target.task, Payload: payload,
+		Admission: &facilityservice.FacilityAggregateAdmission{
+			ResourceID: rootID, State: facilityservice.FacilityAggregateStateRestoreStaging, AllowMissing: true,
+		},
+	})
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		code := "service_unavailable"
+		if errors.Is(err, facilityservice.ErrAggregateLocked) || errors.Is(err, facilityservice.ErrFacilityJobLimit) {
+			status, code = http.StatusConflict, "aggregate_locked"
+		}
+		handlerutil.RespondLocalizedError(c, status, code, "facility.aggregate_locked")
+		return
+	}
+	c.JSON(http.StatusAccepted, sharedpresenter.ToFacilityJobResponse(job))
+}
+
+// encodeHierarchyRestorePayload pins the checkpoint at submit time so a
+// retried job restores the same state. Control cabinets keep their original
+// payload shape for jobs that were queued before the generic restore existed.
+func encodeHierarchyRestorePayload(root hierarchyrestore.Root, rootID uuid.UUID, req domainHistory.RestoreHierarchyRequest) (json.RawMessage, error) {
+	asOf := req.AsOf
+	if asOf == nil && (req.EventID == nil || *req.EventID == uuid.Nil) {
+		now := time.Now().UTC()
+		asOf = &now
+	}
+	if root == hierarchyrestore.RootControlCabinet {
+		return json.Marshal(domainHistory.RestoreControlCabinetJobPayload{
+			ControlCabinetID: rootID, ProjectID: req.ProjectID, AsOf: asOf, EventID: req.EventID, Force: req.Force,
+		})
+	}
+	return json.Marshal(domainHistory.RestoreHierarchyJobPayload{
+		RootID: rootID, ProjectID: req.ProjectID, AsOf: asOf, EventID: req.EventID, Force: req.Force,
+	})
+}
+
+func respondRestorePreviewError(c *gin.Context, err error) {
+	handlerutil.RespondDomainError(c, err,
+		handlerutil.LocalizedError(http.StatusInternalServerError, "restore_preview_failed", "facility.fetch_failed"),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.LocalizedError(http.StatusNotFound, "not_found", "errors.not_found")),
+		handlerutil.MapError(domain.ErrInvalidArgument, handlerutil.LocalizedError(http.StatusBadRequest, "invalid_restore_root", "validation.invalid_request")),
+	)
+}
+
+func parseProjectRestoreParams(c *gin.Context, param string) (uuid.UUID, uuid.UUID, bool) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	rootID, ok := handlerutil.ParseUUIDParam(c, param)
+	return projectID, rootID, ok
+}
+
+func parseHierarchyRestoreRequest(c *gin.Context) (domainHistory.RestoreHierarchyRequest, bool) {
+	var req domainHistory.RestoreHierarchyRequest
+	if c.Request.ContentLength > 0 {
+		if !handlerutil.BindJSON(c, &req) {
+			return req, false
+		}
+	}
+	if asOf := c.Query("as_of"); asOf != "" {
+		parsed, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_as_of", "validation.invalid_request")
+			return req, false
+		}
+		req.AsOf = &parsed
+	}
+	if eventID := c.Query("event_id"); eventID != "" {
+		id, err := uuid.Parse(eventID)
+		if err != nil {
+			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_event_id", "validation.invalid_uuid_format")
+			return req, false
+		}
+		req.EventID = &id
+	}
+	return req, true
+}
@@ -1,6 +1,7 @@
 package history
 
 import (
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
 	"github.com/besart951/go_infra_link/backend/internal/domain/user"
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
 	"github.com/gin-gonic/gin"
@@ -17,8 +18,32 @@ func RegisterRoutes(protectedV1 *gin.RouterGroup, handler *Handler, authChecker
 	history.POST("/events/:id/undo", middleware.RequirePermission(authChecker, user.PermissionTimelineRestore), handler.UndoEntity)
 	history.POST("/batches/:id/undo", middleware.RequirePermission(authChecker, user.PermissionTimelineRestore), handler.UndoBatch)
 	history.POST("/control-cabinets/:id/restore", middleware.RequirePermission(authChecker, user.PermissionTimelineRestore), handler.RestoreControlCabinet)
+	history.POST("/change-reports", middleware.RequirePermission(authChecker, user.PermissionTimelineRead), handler.CreateChangeReport)
 
 	projects := protectedV1.Group("/projects/:id/history")
 	projects.GET("/timeline", middleware.RequirePermission(authChecker, user.PermissionTimelineRead), handler.ListProjectTimeline)
+	projects.POST("/change-reports", middleware.RequirePermission(authChecker, user.PermissionTimelineRead), handler.CreateProjectChangeReport)
 	projects.POST("/control-cabinets/:controlCabinetId/restore", middleware.RequirePermission(authChecker, user.PermissionTimelineRestore), handler.RestoreProjectControlCabinet)
+
+	registerHierarchyRestoreRoutes(history, projects, handler, authChecker)
+}
+
+var hierarchyRestoreSegments = map[string]hierarchyrestore.Root{
+	"buildings":                   hierarchyrestore.RootBuilding,
+	"sps-controllers":             hierarchyrestore.RootSPSController,
+	"sps-controller-system-types": hierarchyrestore.RootSPSControllerSystemType,
+	"object-data":                 hierarchyrestore.RootObjectData,
+}
+
+func registerHierarchyRestoreRoutes(history, projects *gin.RouterGroup, handler *Handler, authChecker middleware.AuthorizationChecker) {
+	read := middleware.RequirePermission(authChecker, user.PermissionTimelineRead)
+	restore := middleware.RequirePermission(authChecker, user.PermissionTimelineRestore)
+	history.GET("/control-cabinets/:id/restore/preview", read, handler.PreviewHierarchyRestore(hierarchyrestore.RootControlCabinet))
+	projects.GET("/control-cabinets/:controlCabinetId/restore/preview", read, handler.PreviewProjectHierarchyRestore(hierarchyrestore.RootControlCabinet, "controlCabinetId"))
+	for segment, root := range hierarchyRestoreSegments {
+		history.POST("/"+segment+"/:id/restore", restore, handler.RestoreHierarchy(root))
+		history.GET("/"+segment+"/:id/restore/preview", read, handler.PreviewHierarchyRestore(root))
+		projects.POST("/"+segment+"/:resourceId/restore", restore, handler.RestoreProjectHierarchy(root, "resourceId"))
+		projects.GET("/"+segment+"/:resourceId/restore/preview", read, handler.PreviewProjectHierarchyRestore(root, "resourceId"))
+	}
 }
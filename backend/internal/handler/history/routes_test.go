@@ -2,15 +2,18 @@ package history
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"slices"
 	"testing"
 
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
 	domainTeam "github.com/besart951/go_infra_link/backend/internal/domain/team"
 	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/history"
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -55,6 +58,42 @@ func TestRegisterRoutes_RequiresTimelineRestorePermission(t *testing.T) {
 	}
 }
 
+func TestRegisterRoutes_PreviewsBuildingRestore(t *testing.T) {
+	router, authz := setupHistoryRouter(t)
+	authz.granted[domainUser.PermissionTimelineRead] = true
+	buildingID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/history/buildings/"+buildingID.String()+"/restore/preview", nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected preview OK, got %d", res.Code)
+	}
+	var body dto.RestorePreviewResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if body.Root != string(hierarchyrestore.RootBuilding) || body.RootID != buildingID {
+		t.Fatalf("unexpected preview root: %+v", body.RestorePreview)
+	}
+}
+
+func TestRegisterRoutes_SPSControllerRestoreRequiresTimelineRestorePermission(t *testing.T) {
+	router, authz := setupHistoryRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/"+uuid.NewString()+"/history/sps-controllers/"+uuid.NewString()+"/restore", nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("expected forbidden without timeline.restore, got %d", res.Code)
+	}
+	if authz.lastPermission != domainUser.PermissionTimelineRestore {
+		t.Fatalf("expected %q check, got %q", domainUser.PermissionTimelineRestore, authz.lastPermission)
+	}
+}
+
 func TestListTimelineParsesActionFilter(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	service := &timelineFilterService{}
@@ -153,6 +192,10 @@ func (s *timelineFilterService) RestoreControlCabinet(context.Context, uuid.UUID
 	return &domainHistory.RestoreResult{}, nil
 }
 
+func (s *timelineFilterService) PreviewHierarchyRestore(context.Context, hierarchyrestore.Root, uuid.UUID, domainHistory.RestoreHierarchyRequest) (*domainHistory.RestorePreview, error) {
+	return &domainHistory.RestorePreview{}, nil
+}
+
 func (historyServiceStub) ListTimeline(context.Context, domainHistory.TimelineFilter) (*domain.PaginatedList[domainHistory.ChangeEvent], error) {
 	return &domain.PaginatedList[domainHistory.ChangeEvent]{Items: []domainHistory.ChangeEvent{}}, nil
 }
@@ -176,3 +219,7 @@ func (historyServiceStub) UndoBatch(context.Context, uuid.UUID) (*domainHistory.
 func (historyServiceStub) RestoreControlCabinet(context.Context, uuid.UUID, domainHistory.RestoreControlCabinetRequest) (*domainHistory.RestoreResult, error) {
 	return &domainHistory.RestoreResult{}, nil
 }
+
+func (historyServiceStub) PreviewHierarchyRestore(_ context.Context, root hierarchyrestore.Root, rootID uuid.UUID, _ domainHistory.RestoreHierarchyRequest) (*domainHistory.RestorePreview, error) {
+	return &domainHistory.RestorePreview{Root: string(root), RootID: rootID}, nil
+}
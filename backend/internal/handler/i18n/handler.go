@@ -1,6 +1,7 @@
 package i18n
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -23,7 +24,7 @@ func NewI18nHandler(loader *i18n.Loader) *I18nHandler {
 // @Summary Get translations for a specific locale
 // @Tags i18n
 // @Produce json
-// @Param locale path string true "Locale code (e.g., de_CH, en_US)"
+// @Param locale path string true "Locale code (e.g., de_CH, fr-CH, en)"
 // @Success 200 {object} map[string]interface{} "Translation data"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -38,18 +39,15 @@ func (h *I18nHandler) GetTranslations(c *gin.Context) {
 		return
 	}
 
-	// Sanitize locale (allow only alphanumeric and underscore)
-	if !isValidLocale(locale) {
+	if _, ok := i18n.NormalizeLocale(locale); !ok {
 		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_locale", "errors.invalid_locale")
 		return
 	}
 
-	// Load translation file
-	filename := locale + ".json"
-	translations, err := h.loader.Load(filename)
+	// Resolve the catalog that serves the locale, e.g. "fr" -> "fr_CH"
+	translations, resolved, err := h.loader.LoadLocale(locale)
 	if err != nil {
-		// Check if file not found
-		if strings.Contains(err.Error(), "no such file") {
+		if errors.Is(err, i18n.ErrLocaleNotFound) {
 			handlerutil.RespondLocalizedError(c, http.StatusNotFound, "locale_not_found", "errors.locale_not_found")
 			return
 		}
@@ -58,15 +56,6 @@ func (h *I18nHandler) GetTranslations(c *gin.Context) {
 		return
 	}
 
+	c.Header("Content-Language", strings.ReplaceAll(resolved, "_", "-"))
 	c.JSON(http.StatusOK, translations)
 }
-
-// isValidLocale checks if the locale string is valid (alphanumeric + underscore only)
-func isValidLocale(locale string) bool {
-	for _, r := range locale {
-		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_') {
-			return false
-		}
-	}
-	return true
-}
@@ -54,6 +54,7 @@ func AccountStatusGuard(userService UserStatusService) gin.HandlerFunc {
 		}
 
 		c.Set(ContextUserRoleKey, usr.Role)
+		applyUserLocale(c, usr.Locale)
 
 		c.Next()
 	}
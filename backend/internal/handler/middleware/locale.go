@@ -15,67 +15,67 @@ const (
 )
 
 // LocaleMiddleware creates middleware that detects and sets the locale from the request.
-// It checks the Accept-Language header and falls back to the default locale if not found.
+// It negotiates the Accept-Language header against the loaded catalogs and
+// falls back to the default locale if none matches. AccountStatusGuard later
+// replaces the result with the locale of the user profile, if one is set.
 func LocaleMiddleware(translator *i18n.Translator, defaultLocale string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		locale := extractLocale(c.GetHeader("Accept-Language"), defaultLocale)
+		var locale string
+		if translator != nil {
+			locale = negotiateLocale(translator.Locales(), c.GetHeader("Accept-Language"), defaultLocale)
+		} else {
+			locale = extractLocale(c.GetHeader("Accept-Language"), defaultLocale)
+		}
 
-		c.Set(ContextLocaleKey, locale)
+		c.Header("Vary", "Accept-Language")
+		setLocale(c, locale)
 		c.Set(ContextTranslatorKey, translator)
 
 		c.Next()
 	}
 }
 
-// extractLocale parses the Accept-Language header and returns the best matching language.
-// Falls back to defaultLocale if no match is found.
-func extractLocale(acceptLanguage, defaultLocale string) string {
-	if acceptLanguage == "" {
-		return defaultLocale
+// applyUserLocale replaces the negotiated locale with the preferred locale of
+// the signed-in user when a loaded catalog can serve it.
+func applyUserLocale(c *gin.Context, preferred string) {
+	if strings.TrimSpace(preferred) == "" {
+		return
 	}
-
-	// Parse Accept-Language header (e.g., "de-CH,de;q=0.9,en;q=0.8")
-	// format: language[-region][;q=quality]
-	parts := strings.Split(acceptLanguage, ",")
-	part := strings.TrimSpace(parts[0])
-
-	if part == "" {
-		return defaultLocale
+	translator := GetTranslator(c)
+	if translator == nil {
+		return
 	}
-
-	// Extract language-region part (before quality parameter)
-	langPart := part
-	if before, _, found := strings.Cut(part, ";"); found {
-		langPart = before
+	if locale, ok := i18n.Match(translator.Locales(), preferred); ok {
+		setLocale(c, locale)
 	}
+}
 
-	langPart = strings.TrimSpace(langPart)
-
-	// Convert "de-CH" to "de_CH", "de-ch" to "de_ch"
-	locale := normalizeLanguageTag(langPart)
-
-	// For now, we support all locales and let translator handle fallback
-	if locale == "" {
-		return defaultLocale
-	}
-	return locale
+func setLocale(c *gin.Context, locale string) {
+	c.Set(ContextLocaleKey, locale)
+	c.Header("Content-Language", strings.ReplaceAll(locale, "_", "-"))
 }
 
-// normalizeLanguageTag converts language tags from RFC format to our internal format.
-// Example: "de-CH" -> "de_CH", "de-ch" -> "de_CH"
-func normalizeLanguageTag(tag string) string {
-	parts := strings.Split(tag, "-")
-	if len(parts) < 1 {
-		return ""
+// negotiateLocale picks the loaded catalog that best serves the
+// Accept-Language header, honouring quality values.
+func negotiateLocale(available []string, acceptLanguage, defaultLocale string) string {
+	for _, tag := range i18n.ParseAcceptLanguage(acceptLanguage) {
+		if locale, ok := i18n.Match(available, tag); ok {
+			return locale
+		}
 	}
+	return defaultLocale
+}
 
-	lang := strings.ToLower(parts[0])
-	if len(parts) > 1 {
-		region := strings.ToUpper(parts[1])
-		return lang + "_" + region
+// extractLocale parses the Accept-Language header and returns its first
+// language. It is used when no translator is configured.
+// Falls back to defaultLocale if no match is found.
+func extractLocale(acceptLanguage, defaultLocale string) string {
+	for _, tag := range i18n.ParseAcceptLanguage(acceptLanguage) {
+		if locale, ok := i18n.NormalizeLocale(tag); ok {
+			return locale
+		}
 	}
-
-	return lang
+	return defaultLocale
 }
 
 // GetLocale retrieves the locale from the request context.
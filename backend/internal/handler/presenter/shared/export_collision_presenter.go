@@ -0,0 +1,26 @@
+package shared
+
+import (
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+)
+
+// ToExportCollisionReportResponse is shared by the facility and project
+// collision endpoints. Object instances are set for every collision on an
+// object type, where 0 is a valid instance.
+func ToExportCollisionReportResponse(report domainExport.CollisionReport) dto.ExportCollisionReportResponse {
+	collisions := make([]dto.ExportCollisionResponse, len(report.Collisions))
+	for index, collision := range report.Collisions {
+		collisions[index] = dto.ExportCollisionResponse{
+			Kind: string(collision.Kind), DeviceInstance: collision.DeviceInstance, ControllerIDs: collision.ControllerIDs,
+			ObjectType: string(collision.ObjectType), ObjectIDs: collision.ObjectIDs, Message: collision.Message,
+		}
+		if collision.ObjectType != "" {
+			instance := collision.ObjectInstance
+			collisions[index].ObjectInstance = &instance
+		}
+	}
+	return dto.ExportCollisionReportResponse{
+		ControllerCount: report.Controllers, ObjectCount: report.Objects, Collisions: collisions,
+	}
+}
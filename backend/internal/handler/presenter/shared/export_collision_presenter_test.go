@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"testing"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+)
+
+func TestToExportCollisionReportResponseSetsObjectInstanceForObjectCollisions(t *testing.T) {
+	response := ToExportCollisionReportResponse(domainExport.CollisionReport{Collisions: []domainExport.Collision{
+		{Kind: domainExport.CollisionDeviceInstanceOutOfRange, DeviceInstance: "CD021"},
+		{Kind: domainExport.CollisionDuplicateObjectInstance, DeviceInstance: "12011", ObjectType: domainFacility.BacnetSoftwareTypeAI, ObjectInstance: 0},
+	}})
+
+	if device := response.Collisions[0]; device.ObjectInstance != nil {
+		t.Fatalf("device collision carries an object instance: %#v", device)
+	}
+	if object := response.Collisions[1]; object.ObjectInstance == nil || *object.ObjectInstance != 0 || object.ObjectType != "ai" {
+		t.Fatalf("object collision = %#v, want instance 0 of ai", object)
+	}
+}
@@ -0,0 +1,27 @@
+package shared
+
+import (
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+)
+
+// ToExportJobResponse is shared by the facility export and history change
+// report endpoints. The download URL is only set once the job completed.
+func ToExportJobResponse(job domainExport.Job) dto.FieldDeviceExportJobResponse {
+	res := dto.FieldDeviceExportJobResponse{
+		JobID:       job.ID,
+		Status:      string(job.Status),
+		Progress:    job.Progress,
+		Message:     job.Message,
+		OutputType:  string(job.OutputType),
+		FileName:    job.FileName,
+		ContentType: job.ContentType,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+	if job.Status == domainExport.StatusCompleted {
+		res.DownloadURL = "/api/v1/facility/exports/jobs/" + job.ID.String() + "/download"
+	}
+	return res
+}
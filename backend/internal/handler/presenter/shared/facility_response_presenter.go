@@ -30,6 +30,7 @@ func ToSPSControllerResponse(controller domainFacility.SPSController) dto.SPSCon
 		Subnet:            controller.Subnet,
 		Gateway:           controller.Gateway,
 		Vlan:              controller.Vlan,
+		MACAddress:        controller.MACAddress,
 		CreatedAt:         controller.CreatedAt,
 		UpdatedAt:         controller.UpdatedAt,
 	}
@@ -74,6 +75,8 @@ func ToFieldDeviceResponse(fieldDevice domainFacility.FieldDevice) dto.FieldDevi
 		ApparatID:                 fieldDevice.ApparatID,
 		CreatedAt:                 fieldDevice.CreatedAt,
 		UpdatedAt:                 fieldDevice.UpdatedAt,
+		ObjectDataID:              fieldDevice.ObjectDataID,
+		ObjectDataRevision:        fieldDevice.ObjectDataRevision,
 	}
 }
 
@@ -178,6 +181,9 @@ func toObjectDataResponse(objectData domainFacility.ObjectData) dto.ObjectDataRe
 		BacnetObjects: toBacnetObjectResponses(bacnetObjects),
 		CreatedAt:     objectData.CreatedAt,
 		UpdatedAt:     objectData.UpdatedAt,
+
+		ForkedFromID:       objectData.ForkedFromID,
+		ForkedFromRevision: objectData.ForkedFromRevision,
 	}
 }
 
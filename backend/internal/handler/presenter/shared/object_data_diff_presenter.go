@@ -0,0 +1,77 @@
+package shared
+
+import (
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	"github.com/google/uuid"
+)
+
+func ToObjectDataDiffResponse(diff *domainObjectData.TemplateDiff) dto.ObjectDataDiffResponse {
+	changed := make([]dto.ObjectDataTemplateChangeResponse, len(diff.Changed))
+	for i, change := range diff.Changed {
+		changed[i] = dto.ObjectDataTemplateChangeResponse{
+			Before: toObjectDataTemplateResponse(change.Before),
+			After:  toObjectDataTemplateResponse(change.After),
+			Fields: change.Fields,
+		}
+	}
+	return dto.ObjectDataDiffResponse{
+		ObjectDataID: diff.ObjectDataID,
+		FromRevision: diff.FromRevision,
+		ToRevision:   diff.ToRevision,
+		Added:        toObjectDataTemplateResponses(diff.Added),
+		Removed:      toObjectDataTemplateResponses(diff.Removed),
+		Changed:      changed,
+	}
+}
+
+func ToObjectDataForkDiffResponse(diff *domainObjectData.TemplateThreeWayDiff) dto.ObjectDataForkDiffResponse {
+	conflicts := make([]dto.ObjectDataTemplateConflictResponse, len(diff.Conflicts))
+	for i, conflict := range diff.Conflicts {
+		conflicts[i] = dto.ObjectDataTemplateConflictResponse{
+			Ours:   optionalObjectDataTemplateResponse(conflict.Ours),
+			Theirs: optionalObjectDataTemplateResponse(conflict.Theirs),
+			Fields: conflict.Fields,
+		}
+		if conflict.Base.ID != uuid.Nil {
+			conflicts[i].Base = optionalObjectDataTemplateResponse(&conflict.Base)
+		}
+	}
+	return dto.ObjectDataForkDiffResponse{
+		ObjectDataID:   diff.ObjectDataID,
+		OriginID:       diff.OriginID,
+		BaseRevision:   diff.BaseRevision,
+		OriginRevision: diff.OriginRevision,
+		OriginMoved:    diff.OriginMoved(),
+		Ours:           ToObjectDataDiffResponse(&diff.Ours),
+		Theirs:         ToObjectDataDiffResponse(&diff.Theirs),
+		Conflicts:      conflicts,
+	}
+}
+
+func toObjectDataTemplateResponses(templates []domainObjectData.BacnetObjectTemplate) []dto.ObjectDataTemplateResponse {
+	response := make([]dto.ObjectDataTemplateResponse, len(templates))
+	for i, template := range templates {
+		response[i] = toObjectDataTemplateResponse(template)
+	}
+	return response
+}
+
+func optionalObjectDataTemplateResponse(template *domainObjectData.BacnetObjectTemplate) *dto.ObjectDataTemplateResponse {
+	if template == nil {
+		return nil
+	}
+	response := toObjectDataTemplateResponse(*template)
+	return &response
+}
+
+func toObjectDataTemplateResponse(template domainObjectData.BacnetObjectTemplate) dto.ObjectDataTemplateResponse {
+	return dto.ObjectDataTemplateResponse{
+		ID:             template.ID,
+		TextFix:        template.TextFix,
+		Description:    template.Description,
+		SoftwareType:   string(template.SoftwareType),
+		SoftwareNumber: template.SoftwareNumber,
+		HardwareType:   string(template.HardwareType),
+	}
+}
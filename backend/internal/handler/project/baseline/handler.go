@@ -0,0 +1,190 @@
+package baseline
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	projectshared "github.com/besart951/go_infra_link/backend/internal/handler/project/shared"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	againstCurrent   = "now"
+	defaultDiffLimit = 1000
+	xlsxContentType  = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+type Service interface {
+	Create(ctx context.Context, command projectbaseline.CreateCommand) (projectbaseline.Baseline, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]projectbaseline.Baseline, error)
+	Compare(ctx context.Context, projectID, baselineID uuid.UUID, target projectbaseline.Target) (projectbaseline.Diff, error)
+	ExportDiff(ctx context.Context, diff projectbaseline.Diff, target io.Writer) error
+}
+
+type Handler struct {
+	access  projectshared.AccessPolicyService
+	service Service
+}
+
+func NewHandler(access projectshared.AccessPolicyService, service Service) *Handler {
+	return &Handler{access: access, service: service}
+}
+
+type CreateBaselineRequest struct {
+	Name        string  `json:"name" binding:"required,max=120"`
+	Description *string `json:"description"`
+}
+
+// CreateBaseline godoc
+// @Summary Freeze the project's facility data as a named baseline
+// @Description Records the project's cabinets, controllers, field devices, BACnet objects and alarm values. Rows point at their recorded history version where one matches.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param request body CreateBaselineRequest true "Baseline name"
+// @Success 201 {object} projectbaseline.Baseline
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/baselines [post]
+func (h *Handler) CreateBaseline(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectUpdate) || !h.available(c) {
+		return
+	}
+	var request CreateBaselineRequest
+	if !handlerutil.BindJSON(c, &request) {
+		return
+	}
+	command := projectbaseline.CreateCommand{ProjectID: projectID, Name: request.Name, Description: request.Description}
+	if userID, ok := middleware.GetUserID(c); ok {
+		command.CreatedBy = &userID
+	}
+	baseline, err := h.service.Create(c.Request.Context(), command)
+	if err != nil {
+		respondBaselineError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, baseline)
+}
+
+// ListBaselines godoc
+// @Summary List the project's baselines
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {array} projectbaseline.Baseline
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/baselines [get]
+func (h *Handler) ListBaselines(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectFieldDeviceRead) || !h.available(c) {
+		return
+	}
+	baselines, err := h.service.List(c.Request.Context(), projectID)
+	if err != nil {
+		respondBaselineError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, baselines)
+}
+
+// CompareBaseline godoc
+// @Summary Compare a baseline with another baseline or the current state
+// @Description Lists added, removed and changed cabinets, controllers, field devices, BACnet objects and alarm values with their field-level differences. format=xlsx downloads the full comparison as a workbook.
+// @Tags projects
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "Project ID"
+// @Param baselineId path string true "Baseline ID"
+// @Param against query string false "Baseline ID to compare with, or now" default(now)
+// @Param format query string false "json or xlsx" default(json)
+// @Param limit query int false "Maximum number of changes in the JSON response" default(1000)
+// @Success 200 {object} projectbaseline.Diff
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/baselines/{baselineId}/diff [get]
+func (h *Handler) CompareBaseline(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectFieldDeviceRead) || !h.available(c) {
+		return
+	}
+	baselineID, ok := handlerutil.ParseUUIDParam(c, "baselineId")
+	if !ok {
+		return
+	}
+	target, limit, ok := parseCompareQuery(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "xlsx" {
+		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_format", "errors.validation_error")
+		return
+	}
+	diff, err := h.service.Compare(c.Request.Context(), projectID, baselineID, target)
+	if err != nil {
+		respondBaselineError(c, err)
+		return
+	}
+	if format == "json" {
+		c.JSON(http.StatusOK, diff.Limit(limit))
+		return
+	}
+	var body bytes.Buffer
+	if err := h.service.ExportDiff(c.Request.Context(), diff, &body); err != nil {
+		respondBaselineError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=\"baseline-diff-"+baselineID.String()+".xlsx\"")
+	c.Data(http.StatusOK, xlsxContentType, body.Bytes())
+}
+
+func parseCompareQuery(c *gin.Context) (projectbaseline.Target, int, bool) {
+	var target projectbaseline.Target
+	if against := c.DefaultQuery("against", againstCurrent); against != againstCurrent {
+		id, err := uuid.Parse(against)
+		if err != nil {
+			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_against", "errors.validation_error")
+			return target, 0, false
+		}
+		target.BaselineID = &id
+	}
+	limit := defaultDiffLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_limit", "errors.validation_error")
+			return target, 0, false
+		}
+		limit = parsed
+	}
+	return target, limit, true
+}
+
+func (h *Handler) available(c *gin.Context) bool {
+	if h.service == nil {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return false
+	}
+	return true
+}
+
+func respondBaselineError(c *gin.Context, err error) {
+	handlerutil.RespondDomainError(c, err,
+		handlerutil.LocalizedError(http.StatusInternalServerError, "baseline_failed", "errors.internal_server_error"),
+		handlerutil.MapError(projectbaseline.ErrInvalidBaseline, handlerutil.LocalizedError(http.StatusBadRequest, "invalid_baseline", "errors.validation_error")),
+		handlerutil.MapError(domain.ErrConflict, handlerutil.LocalizedError(http.StatusConflict, "baseline_exists", "errors.conflict")),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.LocalizedError(http.StatusNotFound, "not_found", "errors.not_found")),
+	)
+}
@@ -0,0 +1,128 @@
+package baseline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type serviceFake struct {
+	createErr error
+	command   projectbaseline.CreateCommand
+	target    projectbaseline.Target
+	diff      projectbaseline.Diff
+	exported  bool
+}
+
+func (f *serviceFake) Create(_ context.Context, command projectbaseline.CreateCommand) (projectbaseline.Baseline, error) {
+	f.command = command
+	return projectbaseline.Baseline{ID: uuid.New(), ProjectID: command.ProjectID, Name: command.Name}, f.createErr
+}
+
+func (f *serviceFake) List(context.Context, uuid.UUID) ([]projectbaseline.Baseline, error) {
+	return []projectbaseline.Baseline{}, nil
+}
+
+func (f *serviceFake) Compare(_ context.Context, _, _ uuid.UUID, target projectbaseline.Target) (projectbaseline.Diff, error) {
+	f.target = target
+	return f.diff, nil
+}
+
+func (f *serviceFake) ExportDiff(_ context.Context, _ projectbaseline.Diff, target io.Writer) error {
+	f.exported = true
+	_, err := target.Write([]byte("xlsx"))
+	return err
+}
+
+type accessFake struct{ denied string }
+
+func (accessFake) CanAccessProject(context.Context, uuid.UUID, uuid.UUID, *domainUser.Role) (bool, error) {
+	return true, nil
+}
+func (f accessFake) CanUseProjectPermission(_ context.Context, _ uuid.UUID, _ *domainUser.Role, permission string) (bool, error) {
+	return permission != f.denied, nil
+}
+func (f accessFake) CanUseProjectPermissionForProject(_ context.Context, _, _ uuid.UUID, _ *domainUser.Role, permission string) (bool, error) {
+	return permission != f.denied, nil
+}
+
+func TestCreateBaselineRecordsCreatorAndMapsDuplicateName(t *testing.T) {
+	service := &serviceFake{}
+	recorder := serveBaseline(t, accessFake{}, service, http.MethodPost, "", CreateBaselineRequest{Name: "Baseline v3"})
+	if recorder.Code != http.StatusCreated || service.command.Name != "Baseline v3" || service.command.CreatedBy == nil {
+		t.Fatalf("status = %d, command = %+v", recorder.Code, service.command)
+	}
+
+	service.createErr = fmt.Errorf("%w: taken", domain.ErrConflict)
+	recorder = serveBaseline(t, accessFake{}, service, http.MethodPost, "", CreateBaselineRequest{Name: "Baseline v3"})
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("duplicate status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestCreateBaselineRequiresProjectUpdate(t *testing.T) {
+	service := &serviceFake{}
+	recorder := serveBaseline(t, accessFake{denied: domainUser.PermissionProjectUpdate}, service, http.MethodPost, "", CreateBaselineRequest{Name: "Freeze"})
+	if recorder.Code != http.StatusForbidden || service.command.Name != "" {
+		t.Fatalf("status = %d, service called = %v", recorder.Code, service.command.Name != "")
+	}
+}
+
+func TestCompareBaselineLimitsJSONAndExportsWorkbook(t *testing.T) {
+	other := uuid.New()
+	service := &serviceFake{diff: projectbaseline.Diff{Changes: []projectbaseline.Change{{Label: "a"}, {Label: "b"}}}}
+
+	recorder := serveBaseline(t, accessFake{}, service, http.MethodGet, "?against="+other.String()+"&limit=1", nil)
+	var diff projectbaseline.Diff
+	if err := json.Unmarshal(recorder.Body.Bytes(), &diff); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Code != http.StatusOK || len(diff.Changes) != 1 || !diff.Truncated || service.target.BaselineID == nil || *service.target.BaselineID != other {
+		t.Fatalf("status = %d, diff = %+v, target = %+v", recorder.Code, diff, service.target)
+	}
+
+	recorder = serveBaseline(t, accessFake{}, service, http.MethodGet, "?format=xlsx", nil)
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != xlsxContentType || !service.exported || service.target.BaselineID != nil {
+		t.Fatalf("status = %d, content type = %q, exported = %v", recorder.Code, recorder.Header().Get("Content-Type"), service.exported)
+	}
+
+	recorder = serveBaseline(t, accessFake{}, service, http.MethodGet, "?against=yesterday", nil)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("invalid against status = %d", recorder.Code)
+	}
+}
+
+func serveBaseline(t *testing.T, access accessFake, service Service, method, query string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectID, baselineID := uuid.New(), uuid.New()
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Params = gin.Params{{Key: "id", Value: projectID.String()}, {Key: "baselineId", Value: baselineID.String()}}
+	c.Request = httptest.NewRequest(method, "/projects/"+projectID.String()+"/baselines"+query, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set(middleware.ContextUserIDKey, uuid.New())
+	handler := NewHandler(access, service)
+	if method == http.MethodPost {
+		handler.CreateBaseline(c)
+	} else {
+		handler.CompareBaseline(c)
+	}
+	return recorder
+}
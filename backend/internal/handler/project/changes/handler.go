@@ -4,10 +4,13 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
 	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/project"
 	projectshared "github.com/besart951/go_infra_link/backend/internal/handler/project/shared"
 	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	projectservice "github.com/besart951/go_infra_link/backend/internal/service/project"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -16,6 +19,14 @@ type Service interface {
 	ListAfter(ctx context.Context, projectID uuid.UUID, afterRevision uint64, limit int) (*domainProject.ChangePage, error)
 }
 
+// FeedService is the optional part of the service behind the retention and
+// snapshot endpoints.
+type FeedService interface {
+	Retention(ctx context.Context, projectID uuid.UUID) (*domainProject.ChangeRetention, error)
+	SetRetention(ctx context.Context, projectID uuid.UUID, days *int) (*domainProject.ChangeRetention, error)
+	Snapshot(ctx context.Context, projectID uuid.UUID, aggregateTypes []string) (*domainProject.ChangeSnapshot, error)
+}
+
 type Handler struct {
 	access  projectshared.AccessPolicyService
 	service Service
@@ -27,7 +38,7 @@ func NewHandler(access projectshared.AccessPolicyService, service Service) *Hand
 
 // List godoc
 // @Summary List project changes after a revision
-// @Description Returns durable project changes for HTTP recovery after a missed collaboration event.
+// @Description Returns durable project changes for HTTP recovery after a missed collaboration event. Events up to compacted_through keep only the latest event per aggregate. reset_required means the cursor cannot be resumed; load the snapshot instead.
 // @Tags projects
 // @Produce json
 // @Param id path string true "Project ID"
@@ -72,7 +83,126 @@ func toResponse(page *domainProject.ChangePage) dto.ProjectChangesResponse {
 		}
 	}
 	return dto.ProjectChangesResponse{
-		ProjectID: page.ProjectID, CurrentRevision: page.CurrentRevision,
+		ProjectID: page.ProjectID, CurrentRevision: page.CurrentRevision, CompactedThrough: page.CompactedThrough,
 		Events: events, HasMore: page.HasMore, ResetRequired: page.ResetRequired,
 	}
 }
+
+// GetRetention godoc
+// @Summary Get the change feed retention of a project
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} dto.ProjectChangeRetentionResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/changes/retention [get]
+func (h *Handler) GetRetention(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccess(c, h.access, projectID) {
+		return
+	}
+	feed, ok := h.feed(c)
+	if !ok {
+		return
+	}
+	retention, err := feed.Retention(c.Request.Context(), projectID)
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toRetentionResponse(retention))
+}
+
+// UpdateRetention godoc
+// @Summary Set the change feed retention of a project
+// @Description Events older than the retention are compacted to the latest event per aggregate. Shortening the retention compacts right away; null restores the default of 30 days.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param request body dto.UpdateProjectChangeRetentionRequest true "Retention in days"
+// @Success 200 {object} dto.ProjectChangeRetentionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/changes/retention [put]
+func (h *Handler) UpdateRetention(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectUpdate) {
+		return
+	}
+	feed, ok := h.feed(c)
+	if !ok {
+		return
+	}
+	var request dto.UpdateProjectChangeRetentionRequest
+	if !handlerutil.BindJSON(c, &request) {
+		return
+	}
+	retention, err := feed.SetRetention(c.Request.Context(), projectID, request.RetentionDays)
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toRetentionResponse(retention))
+}
+
+// Snapshot godoc
+// @Summary Get the current project state for resynchronization
+// @Description Returns every project aggregate with the revision the state matches. Resume the feed with after_revision set to that revision; events after it may already be contained in the state and can be applied again.
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param type query []string false "Aggregate types: project, user, object_data, control_cabinet, sps_controller, sps_controller_system_type, field_device, bacnet_object, bacnet_object_alarm_value" collectionFormat(multi)
+// @Success 200 {object} dto.ProjectChangeSnapshotResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/changes/snapshot [get]
+func (h *Handler) Snapshot(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccess(c, h.access, projectID) {
+		return
+	}
+	feed, ok := h.feed(c)
+	if !ok {
+		return
+	}
+	snapshot, err := feed.Snapshot(c.Request.Context(), projectID, c.QueryArray("type"))
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+	aggregates := make([]dto.ProjectAggregateStateResponse, len(snapshot.Aggregates))
+	for i, aggregate := range snapshot.Aggregates {
+		aggregates[i] = dto.ProjectAggregateStateResponse{AggregateType: aggregate.AggregateType, AggregateID: aggregate.AggregateID, State: aggregate.State}
+	}
+	c.JSON(http.StatusOK, dto.ProjectChangeSnapshotResponse{
+		ProjectID: snapshot.ProjectID, Revision: snapshot.Revision, TakenAt: snapshot.TakenAt, Aggregates: aggregates,
+	})
+}
+
+func (h *Handler) feed(c *gin.Context) (FeedService, bool) {
+	feed, ok := h.service.(FeedService)
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+	}
+	return feed, ok
+}
+
+func toRetentionResponse(retention *domainProject.ChangeRetention) dto.ProjectChangeRetentionResponse {
+	return dto.ProjectChangeRetentionResponse{
+		ProjectID: retention.ProjectID, RetentionDays: retention.Days,
+		Custom: retention.Custom, CompactedThrough: retention.CompactedThrough,
+	}
+}
+
+func respondFeedError(c *gin.Context, err error) {
+	handlerutil.RespondDomainError(c, err,
+		handlerutil.LocalizedError(http.StatusInternalServerError, "fetch_failed", "project.fetch_failed"),
+		handlerutil.MapError(projectservice.ErrChangeFeedUnsupported, handlerutil.LocalizedError(http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")),
+		handlerutil.MapError(domain.ErrInvalidArgument, handlerutil.LocalizedError(http.StatusBadRequest, "validation_error", "errors.validation_error")),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.LocalizedError(http.StatusNotFound, "not_found", "errors.not_found")),
+	)
+}
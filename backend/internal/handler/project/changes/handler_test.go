@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +29,28 @@ func (f *serviceFake) ListAfter(_ context.Context, projectID uuid.UUID, after ui
 	return f.page, nil
 }
 
+type feedServiceFake struct {
+	serviceFake
+	types     []string
+	days      *int
+	snapshot  *domainProject.ChangeSnapshot
+	retention *domainProject.ChangeRetention
+}
+
+func (f *feedServiceFake) Retention(context.Context, uuid.UUID) (*domainProject.ChangeRetention, error) {
+	return f.retention, nil
+}
+
+func (f *feedServiceFake) SetRetention(_ context.Context, _ uuid.UUID, days *int) (*domainProject.ChangeRetention, error) {
+	f.days = days
+	return f.retention, nil
+}
+
+func (f *feedServiceFake) Snapshot(_ context.Context, _ uuid.UUID, types []string) (*domainProject.ChangeSnapshot, error) {
+	f.types = types
+	return f.snapshot, nil
+}
+
 type accessFake struct{}
 
 func (accessFake) CanAccessProject(context.Context, uuid.UUID, uuid.UUID, *domainUser.Role) (bool, error) {
@@ -74,3 +97,46 @@ func TestListReturnsV2ProjectChangeContract(t *testing.T) {
 		t.Fatalf("unexpected event: %+v", response.Events[0])
 	}
 }
+
+func TestSnapshotAndRetentionEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	projectID, cabinetID := uuid.New(), uuid.New()
+	service := &feedServiceFake{
+		snapshot: &domainProject.ChangeSnapshot{ProjectID: projectID, Revision: 42, Aggregates: []domainProject.AggregateState{
+			{AggregateType: "control_cabinet", AggregateID: cabinetID, State: json.RawMessage(`{"control_cabinet_nr":"SG01"}`)},
+		}},
+		retention: &domainProject.ChangeRetention{ProjectID: projectID, Days: 90, Custom: true, CompactedThrough: 12},
+	}
+	serve := func(method, target, body string, handle func(*Handler, *gin.Context)) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Params = gin.Params{{Key: "id", Value: projectID.String()}}
+		c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set(middleware.ContextUserIDKey, uuid.New())
+		handle(NewHandler(accessFake{}, service), c)
+		return recorder
+	}
+
+	recorder := serve(http.MethodGet, "/changes/snapshot?type=control_cabinet&type=field_device", "", (*Handler).Snapshot)
+	var snapshot dto.ProjectChangeSnapshotResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if snapshot.Revision != 42 || len(snapshot.Aggregates) != 1 || string(snapshot.Aggregates[0].State) != `{"control_cabinet_nr":"SG01"}` || len(service.types) != 2 {
+		t.Fatalf("snapshot = %+v, types = %v", snapshot, service.types)
+	}
+
+	recorder = serve(http.MethodPut, "/changes/retention", `{"retention_days":90}`, (*Handler).UpdateRetention)
+	if recorder.Code != http.StatusOK || service.days == nil || *service.days != 90 || !strings.Contains(recorder.Body.String(), `"compacted_through":12`) {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder = serve(http.MethodPut, "/changes/retention", `{"retention_days":0}`, (*Handler).UpdateRetention); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("zero days must be rejected, got %d", recorder.Code)
+	}
+	if recorder = serve(http.MethodGet, "/changes/snapshot", "", func(_ *Handler, c *gin.Context) {
+		NewHandler(accessFake{}, &serviceFake{}).Snapshot(c)
+	}); recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("services without snapshots must answer 503, got %d", recorder.Code)
+	}
+}
@@ -576,6 +576,9 @@ func (h *FacilityDetailHandler) UpdateSPSController(c *gin.Context) {
 	if req.Vlan != nil {
 		controller.Vlan = req.Vlan
 	}
+	if req.MACAddress != nil {
+		controller.MACAddress = req.MACAddress
+	}
 	if err := h.services.SPSController.Update(c.Request.Context(), controller); err != nil {
 		h.projectWriteError(c, err, "sps_controller", controllerID, baseVersion, req.ChangedFields(), func() (uint64, any, bool) {
 			current, getErr := h.services.SPSController.GetByID(c.Request.Context(), controllerID)
@@ -886,7 +889,7 @@ func projectControlCabinetResponse(item domainFacility.ControlCabinet) facilityd
 	return facilitydto.ControlCabinetResponse{ID: item.ID, Version: item.Version, BuildingID: item.BuildingID, ControlCabinetNr: item.ControlCabinetNr, CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt}
 }
 func projectSPSControllerResponse(item domainFacility.SPSController) facilitydto.SPSControllerResponse {
-	return facilitydto.SPSControllerResponse{ID: item.ID, Version: item.Version, ControlCabinetID: item.ControlCabinetID, GADevice: item.GADevice, DeviceName: item.DeviceName, DeviceDescription: item.DeviceDescription, DeviceLocation: item.DeviceLocation, IPAddress: item.IPAddress, Subnet: item.Subnet, Gateway: item.Gateway, Vlan: item.Vlan, CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt}
+	return facilitydto.SPSControllerResponse{ID: item.ID, Version: item.Version, ControlCabinetID: item.ControlCabinetID, GADevice: item.GADevice, DeviceName: item.DeviceName, DeviceDescription: item.DeviceDescription, DeviceLocation: item.DeviceLocation, IPAddress: item.IPAddress, Subnet: item.Subnet, Gateway: item.Gateway, Vlan: item.Vlan, MACAddress: item.MACAddress, CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt}
 }
 func projectSPSControllerSystemTypeResponse(item domainFacility.SPSControllerSystemType) facilitydto.SPSControllerSystemTypeResponse {
 	return facilitydto.SPSControllerSystemTypeResponse{ID: item.ID, Version: item.Version, AggregateVersion: item.Version, SPSControllerID: item.SPSControllerID, SystemTypeID: item.SystemTypeID, SPSControllerName: item.SPSController.DeviceName, SystemTypeName: item.SystemType.Name, Number: item.Number, DocumentName: item.DocumentName, FieldDevicesCount: item.FieldDevicesCount, CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt}
@@ -0,0 +1,150 @@
+package facilitysync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	appfacilitysync "github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	projectshared "github.com/besart951/go_infra_link/backend/internal/handler/project/shared"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Export(ctx context.Context, projectID uuid.UUID) (appfacilitysync.Bundle, error)
+	Plan(ctx context.Context, projectID uuid.UUID, bundle appfacilitysync.Bundle) (appfacilitysync.Plan, error)
+	Apply(ctx context.Context, projectID uuid.UUID, bundle appfacilitysync.Bundle) (appfacilitysync.Plan, error)
+}
+
+type Handler struct {
+	access  projectshared.AccessPolicyService
+	service Service
+}
+
+func NewHandler(access projectshared.AccessPolicyService, service Service) *Handler {
+	return &Handler{access: access, service: service}
+}
+
+// ExportBundle godoc
+// @Summary Export a project's facility subtree for another instance
+// @Description Returns the project's buildings, cabinets, controllers and field devices with stable IDs and fingerprints of the reference data they use.
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} appfacilitysync.Bundle
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/facility-sync/bundle [get]
+func (h *Handler) ExportBundle(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok || !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectFieldDeviceRead) || !h.available(c) {
+		return
+	}
+	bundle, err := h.service.Export(c.Request.Context(), projectID)
+	if err != nil {
+		respondSyncError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, bundle)
+}
+
+// PlanBundle godoc
+// @Summary Preview importing a facility sync bundle
+// @Description Maps the bundle's reference data by natural key and reports which rows would be created or updated.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param request body appfacilitysync.Bundle true "Bundle exported by another instance"
+// @Success 200 {object} appfacilitysync.Plan
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/facility-sync/plan [post]
+func (h *Handler) PlanBundle(c *gin.Context) {
+	projectID, bundle, ok := h.bind(c, domainUser.PermissionProjectFieldDeviceRead)
+	if !ok {
+		return
+	}
+	plan, err := h.service.Plan(c.Request.Context(), projectID, bundle)
+	if err != nil {
+		respondSyncError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// ApplyBundle godoc
+// @Summary Import a facility sync bundle into the project
+// @Description Writes the planned changes in one transaction. Bundles with unresolved reference data are refused with the plan; rows changed locally since the plan are refused as a conflict.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param request body appfacilitysync.Bundle true "Bundle exported by another instance"
+// @Success 200 {object} appfacilitysync.Plan
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} appfacilitysync.Plan
+// @Router /api/v1/projects/{id}/facility-sync/apply [post]
+func (h *Handler) ApplyBundle(c *gin.Context) {
+	projectID, bundle, ok := h.bind(c,
+		domainUser.PermissionProjectControlCabinetCreate,
+		domainUser.PermissionProjectSPSControllerCreate,
+		domainUser.PermissionProjectFieldDeviceCreate,
+		domainUser.PermissionProjectFieldDeviceUpdate,
+	)
+	if !ok {
+		return
+	}
+	plan, err := h.service.Apply(c.Request.Context(), projectID, bundle)
+	if errors.Is(err, appfacilitysync.ErrUnresolvedReferences) {
+		c.JSON(http.StatusConflict, plan)
+		return
+	}
+	if err != nil {
+		respondSyncError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// bind checks every permission, since applying a bundle creates cabinets,
+// controllers and field devices at once.
+func (h *Handler) bind(c *gin.Context, permissions ...string) (uuid.UUID, appfacilitysync.Bundle, bool) {
+	var bundle appfacilitysync.Bundle
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return projectID, bundle, false
+	}
+	for _, permission := range permissions {
+		if !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, permission) {
+			return projectID, bundle, false
+		}
+	}
+	if !h.available(c) || !handlerutil.BindJSON(c, &bundle) {
+		return projectID, bundle, false
+	}
+	return projectID, bundle, true
+}
+
+func (h *Handler) available(c *gin.Context) bool {
+	if h.service == nil {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return false
+	}
+	return true
+}
+
+func respondSyncError(c *gin.Context, err error) {
+	handlerutil.RespondDomainError(c, err,
+		handlerutil.LocalizedError(http.StatusInternalServerError, "facility_sync_failed", "errors.internal_server_error"),
+		handlerutil.MapError(appfacilitysync.ErrInvalidBundle, handlerutil.LocalizedError(http.StatusBadRequest, "invalid_bundle", "errors.validation_error")),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.LocalizedError(http.StatusNotFound, "not_found", "errors.not_found")),
+		handlerutil.MapError(domain.ErrConflict, handlerutil.LocalizedError(http.StatusConflict, "conflict", "errors.conflict")),
+	)
+}
@@ -0,0 +1,93 @@
+package facilitysync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appfacilitysync "github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type serviceFake struct {
+	plan     appfacilitysync.Plan
+	applyErr error
+	bundle   appfacilitysync.Bundle
+}
+
+func (f *serviceFake) Export(_ context.Context, projectID uuid.UUID) (appfacilitysync.Bundle, error) {
+	return appfacilitysync.Bundle{ProjectID: projectID}, nil
+}
+func (f *serviceFake) Plan(_ context.Context, _ uuid.UUID, bundle appfacilitysync.Bundle) (appfacilitysync.Plan, error) {
+	f.bundle = bundle
+	return f.plan, nil
+}
+func (f *serviceFake) Apply(_ context.Context, _ uuid.UUID, bundle appfacilitysync.Bundle) (appfacilitysync.Plan, error) {
+	f.bundle = bundle
+	return f.plan, f.applyErr
+}
+
+type accessFake struct{ denied string }
+
+func (accessFake) CanAccessProject(context.Context, uuid.UUID, uuid.UUID, *domainUser.Role) (bool, error) {
+	return true, nil
+}
+func (f accessFake) CanUseProjectPermission(_ context.Context, _ uuid.UUID, _ *domainUser.Role, permission string) (bool, error) {
+	return permission != f.denied, nil
+}
+func (f accessFake) CanUseProjectPermissionForProject(_ context.Context, _, _ uuid.UUID, _ *domainUser.Role, permission string) (bool, error) {
+	return permission != f.denied, nil
+}
+
+func TestApplyBundleReturnsPlanWhenReferencesAreUnresolved(t *testing.T) {
+	plan := appfacilitysync.Plan{References: []appfacilitysync.ReferenceMatch{{Kind: appfacilitysync.ReferenceApparat, NaturalKey: "VEN", Status: appfacilitysync.ReferenceMissing}}}
+	service := &serviceFake{plan: plan, applyErr: fmt.Errorf("%w: 1 missing", appfacilitysync.ErrUnresolvedReferences)}
+
+	recorder := serveSync(t, accessFake{}, service, http.MethodPost, "apply", appfacilitysync.Bundle{ProjectName: "Sync"})
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	var response appfacilitysync.Plan
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Missing()) != 1 || service.bundle.ProjectName != "Sync" {
+		t.Fatalf("response = %+v, bundle = %+v", response, service.bundle)
+	}
+}
+
+func TestApplyBundleRequiresCreatePermissions(t *testing.T) {
+	service := &serviceFake{}
+
+	recorder := serveSync(t, accessFake{denied: domainUser.PermissionProjectSPSControllerCreate}, service, http.MethodPost, "apply", appfacilitysync.Bundle{ProjectName: "Sync"})
+
+	if recorder.Code != http.StatusForbidden || service.bundle.ProjectName != "" {
+		t.Fatalf("status = %d, service called = %v", recorder.Code, service.bundle.ProjectName != "")
+	}
+}
+
+func serveSync(t *testing.T, access accessFake, service Service, method, action string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectID := uuid.New()
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Params = gin.Params{{Key: "id", Value: projectID.String()}}
+	c.Request = httptest.NewRequest(method, "/projects/"+projectID.String()+"/facility-sync/"+action, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set(middleware.ContextUserIDKey, uuid.New())
+	NewHandler(access, service).ApplyBundle(c)
+	return recorder
+}
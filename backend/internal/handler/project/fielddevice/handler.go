@@ -43,6 +43,7 @@ type Handler struct {
 
 type ExportService interface {
 	Create(context.Context, uuid.UUID, uuid.UUID, domainExport.Request) (domainExport.Job, error)
+	AnalyzeCollisions(context.Context, domainExport.Request) (domainExport.CollisionReport, error)
 }
 
 type OptionsHandler struct {
@@ -81,6 +82,11 @@ func (h *Handler) CreateProjectFieldDeviceExport(c *gin.Context) {
 		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_request", "errors.invalid_request")
 		return
 	}
+	locale, ok := domainExport.ParseLocale(request.Locale)
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_locale", "errors.invalid_locale")
+		return
+	}
 	ownerID, ok := middleware.GetUserID(c)
 	if !ok {
 		handlerutil.RespondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
@@ -95,7 +101,8 @@ func (h *Handler) CreateProjectFieldDeviceExport(c *gin.Context) {
 		ProjectIDs: []uuid.UUID{projectID}, BuildingIDs: request.BuildingIDs,
 		ControlCabinetIDs: request.ControlCabinetIDs, SPSControllerIDs: request.SPSControllerIDs,
 		SPSControllerSystemTypeIDs: request.SPSControllerSystemTypeIDs, Search: request.Search,
-		AccessScope: domainExport.AccessScopeProject,
+		AccessScope: domainExport.AccessScopeProject, OutputType: domainExport.OutputType(request.OutputType),
+		CollisionPolicy: domainExport.CollisionPolicy(request.CollisionPolicy), Locale: locale,
 	})
 	if err != nil {
 		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "export_creation_failed", "errors.service_unavailable")
@@ -109,6 +116,33 @@ func (h *Handler) CreateProjectFieldDeviceExport(c *gin.Context) {
 	c.JSON(http.StatusAccepted, response)
 }
 
+func (h *Handler) AnalyzeProjectExportCollisions(c *gin.Context) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectFieldDeviceRead) {
+		return
+	}
+	if h.export == nil {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return
+	}
+	var request facilitydto.AnalyzeExportCollisionsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "invalid_request", "errors.invalid_request")
+		return
+	}
+	report, err := h.export.AnalyzeCollisions(c.Request.Context(), domainExport.Request{
+		ProjectIDs: []uuid.UUID{projectID}, BuildingIDs: request.BuildingIDs, AccessScope: domainExport.AccessScopeProject,
+	})
+	if err != nil {
+		handlerutil.RespondLocalizedError(c, http.StatusInternalServerError, "collision_analysis_failed", "errors.internal_server_error")
+		return
+	}
+	c.JSON(http.StatusOK, sharedpresenter.ToExportCollisionReportResponse(report))
+}
+
 func NewOptionsHandler(access projectshared.AccessPolicyService, service OptionsService) *OptionsHandler {
 	return &OptionsHandler{access: access, service: service}
 }
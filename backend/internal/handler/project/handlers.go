@@ -2,8 +2,10 @@ package project
 
 import (
 	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	baselinehandler "github.com/besart951/go_infra_link/backend/internal/handler/project/baseline"
 	changeshandler "github.com/besart951/go_infra_link/backend/internal/handler/project/changes"
 	controlcabinethandler "github.com/besart951/go_infra_link/backend/internal/handler/project/controlcabinet"
+	facilitysynchandler "github.com/besart951/go_infra_link/backend/internal/handler/project/facilitysync"
 	fielddevicehandler "github.com/besart951/go_infra_link/backend/internal/handler/project/fielddevice"
 	membershiphandler "github.com/besart951/go_infra_link/backend/internal/handler/project/membership"
 	objectdatahandler "github.com/besart951/go_infra_link/backend/internal/handler/project/objectdata"
@@ -20,6 +22,8 @@ type Handlers struct {
 	ControlCabinet     *controlcabinethandler.Handler
 	SPSController      *spscontrollerhandler.Handler
 	FieldDevice        *fielddevicehandler.Handler
+	FacilitySync       *facilitysynchandler.Handler
+	Baseline           *baselinehandler.Handler
 	ObjectData         *objectdatahandler.Handler
 	Phase              *phasehandler.Handler
 	PhasePermission    *phasepermissionhandler.Handler
@@ -44,6 +48,9 @@ type ServiceDeps struct {
 	Collaboration      *ProjectCollaborationHub
 	FacilityJobs       *facilityservice.FacilityJobManager
 	Export             fielddevicehandler.ExportService
+	FacilitySync       facilitysynchandler.Service
+	Baseline           baselinehandler.Service
+	ObjectDataLineage  objectdatahandler.LineageService
 }
 
 func NewHandlers(deps ServiceDeps) *Handlers {
@@ -63,6 +70,10 @@ func NewHandlers(deps ServiceDeps) *Handlers {
 
 	fieldDeviceHandler := fielddevicehandler.NewHandler(deps.AccessPolicy, deps.FacilityLink, projectHandler.notifyProjectChange, projectHandler.notifyProjectFieldDeviceDelta)
 	fieldDeviceHandler.ConfigureExport(deps.Export)
+	objectDataHandler := objectdatahandler.NewHandler(deps.AccessPolicy, deps.FacilityLink, projectHandler.notifyProjectChange)
+	if deps.ObjectDataLineage != nil {
+		objectDataHandler.ConfigureLineage(deps.ObjectDataLineage)
+	}
 	return &Handlers{
 		Project:            projectHandler,
 		Changes:            changeshandler.NewHandler(deps.AccessPolicy, deps.Changes),
@@ -70,7 +81,9 @@ func NewHandlers(deps ServiceDeps) *Handlers {
 		ControlCabinet:     controlCabinetHandler,
 		SPSController:      spsControllerHandler,
 		FieldDevice:        fieldDeviceHandler,
-		ObjectData:         objectdatahandler.NewHandler(deps.AccessPolicy, deps.FacilityLink, projectHandler.notifyProjectChange),
+		FacilitySync:       facilitysynchandler.NewHandler(deps.AccessPolicy, deps.FacilitySync),
+		Baseline:           baselinehandler.NewHandler(deps.AccessPolicy, deps.Baseline),
+		ObjectData:         objectDataHandler,
 		Phase:              phasehandler.NewHandler(deps.Phase),
 		PhasePermission:    phasepermissionhandler.NewHandler(deps.PhasePermission),
 		FieldDeviceOptions: fielddevicehandler.NewOptionsHandler(deps.AccessPolicy, deps.FieldDeviceOptions),
@@ -24,6 +24,8 @@ type Handler struct {
 	access       projectshared.AccessPolicyService
 	facilityLink FacilityLinkService
 	notify       projectshared.ProjectChangeNotifier
+
+	lineage LineageService
 }
 
 func NewHandler(access projectshared.AccessPolicyService, facilityLink FacilityLinkService, notify projectshared.ProjectChangeNotifier) *Handler {
@@ -204,6 +206,7 @@ func toObjectDataResponse(item domainFacility.ObjectData) dto.ObjectDataResponse
 
 	return dto.ObjectDataResponse{
 		ID:            item.ID,
+		Revision:      item.Base.Version,
 		Description:   item.Description,
 		Version:       item.Version,
 		IsActive:      item.IsActive,
@@ -211,6 +214,9 @@ func toObjectDataResponse(item domainFacility.ObjectData) dto.ObjectDataResponse
 		BacnetObjects: mapBacnetObjectResponses(bacnetObjects),
 		CreatedAt:     item.CreatedAt,
 		UpdatedAt:     item.UpdatedAt,
+
+		ForkedFromID:       item.ForkedFromID,
+		ForkedFromRevision: item.ForkedFromRevision,
 	}
 }
 
@@ -0,0 +1,162 @@
+package objectdata
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	facilitydto "github.com/besart951/go_infra_link/backend/internal/handler/dto/facility"
+	sharedpresenter "github.com/besart951/go_infra_link/backend/internal/handler/presenter/shared"
+	projectshared "github.com/besart951/go_infra_link/backend/internal/handler/project/shared"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LineageService forks global ObjectData into projects and promotes project
+// templates back to the global library.
+type LineageService interface {
+	ForkToProject(ctx context.Context, projectID, objectDataID uuid.UUID) (*domainFacility.ObjectData, error)
+	ForkDiff(ctx context.Context, projectID, objectDataID uuid.UUID) (*domainObjectData.TemplateThreeWayDiff, error)
+	PromoteToGlobal(ctx context.Context, projectID, objectDataID uuid.UUID, baseVersion uint64) (*domainFacility.ObjectData, error)
+}
+
+func (h *Handler) ConfigureLineage(service LineageService) {
+	h.lineage = service
+}
+
+// ForkProjectObjectData godoc
+// @Summary Fork global object data into the project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param payload body facilitydto.ForkObjectDataRequest true "Global object data"
+// @Success 201 {object} dto.ObjectDataResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/object-data/fork [post]
+func (h *Handler) ForkProjectObjectData(c *gin.Context) {
+	projectID, ok := h.lineageProject(c)
+	if !ok {
+		return
+	}
+	var req facilitydto.ForkObjectDataRequest
+	if !handlerutil.BindJSON(c, &req) {
+		return
+	}
+
+	obj, err := h.lineage.ForkToProject(c.Request.Context(), projectID, req.ObjectDataID)
+	if err != nil {
+		respondLineageError(c, err, "update_failed", "project.update_failed")
+		return
+	}
+
+	if h.notify != nil {
+		h.notify(c, projectID, "project.object_data.created")
+	}
+	c.JSON(http.StatusCreated, toObjectDataResponse(*obj))
+}
+
+// GetProjectObjectDataForkDiff godoc
+// @Summary Compare a project fork with its global origin
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param objectDataId path string true "Object Data ID"
+// @Success 200 {object} facilitydto.ObjectDataForkDiffResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/object-data/{objectDataId}/diff [get]
+func (h *Handler) GetProjectObjectDataForkDiff(c *gin.Context) {
+	projectID, ok := h.lineageProject(c)
+	if !ok {
+		return
+	}
+	objectDataID, ok := handlerutil.ParseUUIDParam(c, "objectDataId")
+	if !ok {
+		return
+	}
+
+	diff, err := h.lineage.ForkDiff(c.Request.Context(), projectID, objectDataID)
+	if err != nil {
+		respondLineageError(c, err, "fetch_failed", "project.fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, sharedpresenter.ToObjectDataForkDiffResponse(diff))
+}
+
+// PromoteProjectObjectData godoc
+// @Summary Promote project object data to the global library
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param objectDataId path string true "Object Data ID"
+// @Param payload body facilitydto.PromoteObjectDataRequest true "Version of the project object data"
+// @Success 200 {object} dto.ObjectDataResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/projects/{id}/object-data/{objectDataId}/promote [post]
+func (h *Handler) PromoteProjectObjectData(c *gin.Context) {
+	projectID, ok := h.lineageProject(c)
+	if !ok {
+		return
+	}
+	if !projectshared.EnsureProjectPermission(c, h.access, domainUser.PermissionObjectDataUpdate) {
+		return
+	}
+	objectDataID, ok := handlerutil.ParseUUIDParam(c, "objectDataId")
+	if !ok {
+		return
+	}
+	var req facilitydto.PromoteObjectDataRequest
+	if !handlerutil.BindJSON(c, &req) {
+		return
+	}
+
+	obj, err := h.lineage.PromoteToGlobal(c.Request.Context(), projectID, objectDataID, req.BaseVersion)
+	if err != nil {
+		respondLineageError(c, err, "update_failed", "project.update_failed")
+		return
+	}
+
+	if h.notify != nil {
+		h.notify(c, projectID, "project.object_data.updated")
+	}
+	c.JSON(http.StatusOK, toObjectDataResponse(*obj))
+}
+
+func (h *Handler) lineageProject(c *gin.Context) (uuid.UUID, bool) {
+	projectID, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return uuid.Nil, false
+	}
+	if !projectshared.EnsureProjectAccessAndPermission(c, h.access, projectID, domainUser.PermissionProjectUpdate) {
+		return uuid.Nil, false
+	}
+	if h.lineage == nil {
+		handlerutil.RespondLocalizedError(c, http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")
+		return uuid.Nil, false
+	}
+	return projectID, true
+}
+
+func respondLineageError(c *gin.Context, err error, fallbackCode, fallbackKey string) {
+	handlerutil.RespondDomainError(c, err,
+		handlerutil.LocalizedError(http.StatusInternalServerError, fallbackCode, fallbackKey),
+		handlerutil.MapError(facilityservice.ErrObjectDataPromoteConflict, handlerutil.LocalizedError(http.StatusConflict, "promote_conflict", "project.object_data_promote_conflict")),
+		handlerutil.MapError(facilityservice.ErrObjectDataRevisionsUnavailable, handlerutil.LocalizedError(http.StatusServiceUnavailable, "service_unavailable", "errors.service_unavailable")),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.LocalizedError(http.StatusNotFound, "not_found", "project.project_or_object_data_not_found")),
+		handlerutil.MapError(domain.ErrConflict, handlerutil.LocalizedError(http.StatusConflict, "conflict", "errors.conflict")),
+	)
+}
@@ -14,6 +14,9 @@ func RegisterProjectRoutes(protectedV1 *gin.RouterGroup, handlers *Handlers) {
 		projects.GET("/:id/capabilities", handlers.Project.GetProjectCapabilities)
 		projects.GET("/:id", handlers.Project.GetProject)
 		projects.GET("/:id/changes", handlers.Changes.List)
+		projects.GET("/:id/changes/snapshot", handlers.Changes.Snapshot)
+		projects.GET("/:id/changes/retention", handlers.Changes.GetRetention)
+		projects.PUT("/:id/changes/retention", handlers.Changes.UpdateRetention)
 		projects.GET("/:id/collaboration", handlers.Project.StreamProjectCollaboration)
 		projects.GET("/:id/field-device-options", handlers.FieldDeviceOptions.GetFieldDeviceOptionsForProject)
 		projects.GET("/:id/facility/buildings/:buildingId", handlers.FacilityDetail.GetBuilding)
@@ -41,6 +44,13 @@ func RegisterProjectRoutes(protectedV1 *gin.RouterGroup, handlers *Handlers) {
 		projects.POST("/:id/field-devices/multi-create", handlers.FieldDevice.MultiCreateProjectFieldDevices)
 		projects.GET("/:id/field-devices", handlers.FieldDevice.ListProjectFieldDevices)
 		projects.POST("/:id/exports/field-devices", handlers.FieldDevice.CreateProjectFieldDeviceExport)
+		projects.POST("/:id/exports/field-devices/collisions", handlers.FieldDevice.AnalyzeProjectExportCollisions)
+		projects.GET("/:id/facility-sync/bundle", handlers.FacilitySync.ExportBundle)
+		projects.POST("/:id/facility-sync/plan", handlers.FacilitySync.PlanBundle)
+		projects.POST("/:id/facility-sync/apply", handlers.FacilitySync.ApplyBundle)
+		projects.POST("/:id/baselines", handlers.Baseline.CreateBaseline)
+		projects.GET("/:id/baselines", handlers.Baseline.ListBaselines)
+		projects.GET("/:id/baselines/:baselineId/diff", handlers.Baseline.CompareBaseline)
 		projects.PUT("/:id/field-devices/:linkId", handlers.FieldDevice.UpdateProjectFieldDevice)
 		projects.DELETE("/:id/field-devices/:linkId", handlers.FieldDevice.DeleteProjectFieldDevice)
 		projects.GET("/:id/users", handlers.Membership.ListProjectUsers)
@@ -48,6 +58,9 @@ func RegisterProjectRoutes(protectedV1 *gin.RouterGroup, handlers *Handlers) {
 		projects.GET("/:id/object-data", handlers.ObjectData.ListProjectObjectData)
 		projects.POST("/:id/object-data", handlers.ObjectData.AddProjectObjectData)
 		projects.DELETE("/:id/object-data/:objectDataId", handlers.ObjectData.RemoveProjectObjectData)
+		projects.POST("/:id/object-data/fork", handlers.ObjectData.ForkProjectObjectData)
+		projects.GET("/:id/object-data/:objectDataId/diff", handlers.ObjectData.GetProjectObjectDataForkDiff)
+		projects.POST("/:id/object-data/:objectDataId/promote", handlers.ObjectData.PromoteProjectObjectData)
 		projects.PATCH("/:id", handlers.Project.UpdateProject)
 		projects.PUT("/:id", handlers.Project.UpdateProject)
 		projects.DELETE("/:id", handlers.Project.DeleteProject)
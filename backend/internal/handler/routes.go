@@ -12,6 +12,7 @@ import (
 	projecthandler "github.com/besart951/go_infra_link/backend/internal/handler/project"
 	teamhandler "github.com/besart951/go_infra_link/backend/internal/handler/team"
 	userhandler "github.com/besart951/go_infra_link/backend/internal/handler/user"
+	webhookhandler "github.com/besart951/go_infra_link/backend/internal/handler/webhook"
 	"github.com/gin-gonic/gin"
 )
 
@@ -34,6 +35,7 @@ func RegisterRoutes(r *gin.Engine, handlers *Handlers, tokenValidator domainAuth
 	teamhandler.RegisterRoutes(protectedV1, handlers.Team, authChecker)
 	userhandler.RegisterAdminRoutes(protectedV1, handlers.User, authChecker)
 	notificationhandler.RegisterRoutes(protectedV1, handlers.Notification, authChecker)
+	webhookhandler.RegisterRoutes(protectedV1, handlers.Webhook, authChecker)
 	authhandler.RegisterProtectedRoutes(protectedV1, handlers.Auth)
 	facilityhandler.RegisterRoutes(protectedV1, handlers.Facility, authChecker)
 	historyhandler.RegisterRoutes(protectedV1, handlers.History, authChecker)
@@ -63,6 +63,7 @@ func ToUserResponse(usr *user.User) dto.UserResponse {
 		FailedLoginAttempts: usr.FailedLoginAttempts,
 		IsDeleted:           usr.IsDeleted(),
 		IsAnonymized:        usr.IsAnonymized(),
+		Locale:              usr.Locale,
 		DeletedAt:           usr.DeletedAt,
 		RestoreUntil:        usr.RestoreUntil,
 	}
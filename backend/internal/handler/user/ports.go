@@ -15,6 +15,7 @@ type UserService interface {
 	CreateWithPasswordForActor(ctx context.Context, actorID uuid.UUID, user *domainUser.User, password string) error
 	UpdateProfileForActor(ctx context.Context, actorID uuid.UUID, user *domainUser.User) error
 	UpdatePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) (*domainUser.User, error)
+	UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) (*domainUser.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domainUser.User, error)
 	List(ctx context.Context, page, limit int, search, orderBy, order string, includeDeleted bool) (*domain.PaginatedList[domainUser.User], error)
 	DeleteByID(ctx context.Context, id uuid.UUID) error
@@ -16,6 +16,7 @@ func RegisterUserRoutes(protectedV1 *gin.RouterGroup, handlers *Handlers, authCh
 			handlers.User.ListDirectory,
 		)
 		users.PUT("/me/password", handlers.User.UpdateOwnPassword)
+		users.PUT("/me/locale", handlers.User.UpdateOwnLocale)
 	}
 
 	usersAdmin := protectedV1.Group("/users")
@@ -2,6 +2,7 @@ package user
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainAuth "github.com/besart951/go_infra_link/backend/internal/domain/auth"
@@ -11,6 +12,7 @@ import (
 	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
 	userdirectory "github.com/besart951/go_infra_link/backend/internal/service/userdirectory"
 	userregistration "github.com/besart951/go_infra_link/backend/internal/service/userregistration"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -289,6 +291,45 @@ func (h *UserHandler) UpdateOwnPassword(c *gin.Context) {
 	c.JSON(http.StatusOK, ToUserResponse(usr))
 }
 
+// UpdateOwnLocale godoc
+// @Summary Update own preferred locale
+// @Description The locale must have a translation catalog, e.g. de_CH, fr_CH, it_CH or en. It overrides Accept-Language; an empty locale clears it.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param payload body dto.UpdateOwnLocaleRequest true "Locale"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/locale [put]
+func (h *UserHandler) UpdateOwnLocale(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		handlerutil.RespondLocalizedError(c, http.StatusUnauthorized, "unauthorized", "errors.unauthorized")
+		return
+	}
+	var req dto.UpdateOwnLocaleRequest
+	if !handlerutil.BindJSON(c, &req) {
+		return
+	}
+	locale := strings.TrimSpace(req.Locale)
+	if translator := middleware.GetTranslator(c); locale != "" && translator != nil {
+		resolved, ok := i18n.Match(translator.Locales(), locale)
+		if !ok {
+			handlerutil.RespondLocalizedError(c, http.StatusBadRequest, "locale_not_found", "errors.locale_not_found")
+			return
+		}
+		locale = resolved
+	}
+	usr, err := h.service.UpdateLocale(c.Request.Context(), userID, locale)
+	if err != nil {
+		handlerutil.RespondDomainError(c, err, handlerutil.LocalizedError(http.StatusInternalServerError, "update_failed", "user.update_failed"))
+		return
+	}
+	c.JSON(http.StatusOK, ToUserResponse(usr))
+}
+
 // DeleteUser godoc
 // @Summary Delete a user
 // @Tags users
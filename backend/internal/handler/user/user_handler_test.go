@@ -193,6 +193,10 @@ func (s *fakeUserService) UpdatePassword(_ context.Context, userID uuid.UUID, _,
 	return &domainUser.User{Base: domain.Base{ID: userID}, Email: domainUser.EmailPtr("ada@example.com"), Password: newPassword, Role: domainUser.RolePlaner}, nil
 }
 
+func (s *fakeUserService) UpdateLocale(_ context.Context, userID uuid.UUID, locale string) (*domainUser.User, error) {
+	return &domainUser.User{Base: domain.Base{ID: userID}, Locale: locale, Role: domainUser.RolePlaner}, nil
+}
+
 func (s *fakeUserService) GetByID(context.Context, uuid.UUID) (*domainUser.User, error) {
 	s.getByIDCalled = true
 	return &domainUser.User{Role: domainUser.RolePlaner}, nil
@@ -0,0 +1,380 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/webhook"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/besart951/go_infra_link/backend/internal/handlerutil"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	service WebhookService
+}
+
+func NewWebhookHandler(service WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// ListSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Param project_id query string false "Only subscriptions of this project"
+// @Success 200 {object} dto.SubscriptionListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/subscriptions [get]
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	var query struct {
+		ProjectID string `form:"project_id" binding:"omitempty,uuid"`
+	}
+	if !handlerutil.BindQuery(c, &query) {
+		return
+	}
+
+	subscriptions, err := h.service.ListSubscriptions(c.Request.Context(), optionalUUID(query.ProjectID))
+	if err != nil {
+		handlerutil.RespondDomainError(
+			c,
+			err,
+			handlerutil.PlainError(http.StatusInternalServerError, "fetch_failed", "Failed to load webhook subscriptions"),
+		)
+		return
+	}
+
+	items := make([]dto.SubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		items[i] = mapSubscriptionResponse(&subscriptions[i], "")
+	}
+	c.JSON(http.StatusOK, dto.SubscriptionListResponse{Items: items})
+}
+
+// GetSubscription godoc
+// @Summary Get a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} dto.SubscriptionResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/subscriptions/{id} [get]
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	id, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.service.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		respondSubscriptionError(c, err, "fetch_failed", "Failed to load webhook subscription")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapSubscriptionResponse(subscription, ""))
+}
+
+// CreateSubscription godoc
+// @Summary Create a webhook subscription
+// @Description The signing secret is only returned in this response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param payload body dto.UpsertSubscriptionRequest true "Subscription"
+// @Success 201 {object} dto.SubscriptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/subscriptions [post]
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	h.upsertSubscription(c, false)
+}
+
+// UpdateSubscription godoc
+// @Summary Update a webhook subscription
+// @Description An empty secret keeps the current one.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param payload body dto.UpsertSubscriptionRequest true "Subscription"
+// @Success 200 {object} dto.SubscriptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/subscriptions/{id} [put]
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	h.upsertSubscription(c, true)
+}
+
+func (h *WebhookHandler) upsertSubscription(c *gin.Context, existing bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		handlerutil.RespondError(c, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+
+	var req dto.UpsertSubscriptionRequest
+	if !handlerutil.BindJSON(c, &req) {
+		return
+	}
+
+	var id uuid.UUID
+	if existing {
+		parsed, ok := handlerutil.ParseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		id = parsed
+	}
+
+	subscription, secret, err := h.service.UpsertSubscription(c.Request.Context(), domainWebhook.UpsertSubscriptionInput{
+		ID:             id,
+		ActorID:        userID,
+		Name:           req.Name,
+		URL:            req.URL,
+		ProjectID:      req.ProjectID,
+		Active:         *req.Active,
+		Secret:         req.Secret,
+		EventTypes:     req.EventTypes,
+		AggregateTypes: req.AggregateTypes,
+		Actions:        req.Actions,
+		JobStatuses:    req.JobStatuses,
+	})
+	if err != nil {
+		respondSubscriptionError(c, err, "update_failed", "Failed to save webhook subscription")
+		return
+	}
+
+	status := http.StatusOK
+	if !existing {
+		status = http.StatusCreated
+	}
+	c.JSON(status, mapSubscriptionResponse(subscription, secret))
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook subscription and its deliveries
+// @Tags webhooks
+// @Param id path string true "Subscription ID"
+// @Success 204
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/subscriptions/{id} [delete]
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSubscription(c.Request.Context(), id); err != nil {
+		respondSubscriptionError(c, err, "deletion_failed", "Failed to delete webhook subscription")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Tags webhooks
+// @Produce json
+// @Param status query string false "pending, delivered or failed"
+// @Param subscription_id query string false "Subscription ID"
+// @Param page query int false "Page"
+// @Param limit query int false "Page size"
+// @Success 200 {object} dto.DeliveryListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	var query struct {
+		Status         string `form:"status" binding:"omitempty,oneof=pending delivered failed"`
+		SubscriptionID string `form:"subscription_id" binding:"omitempty,uuid"`
+		Page           int    `form:"page"`
+		Limit          int    `form:"limit"`
+	}
+	if !handlerutil.BindQuery(c, &query) {
+		return
+	}
+
+	result, err := h.service.ListDeliveries(c.Request.Context(), domainWebhook.DeliveryFilter{
+		SubscriptionID:   optionalUUID(query.SubscriptionID),
+		Status:           domainWebhook.DeliveryStatus(query.Status),
+		PaginationParams: domain.PaginationParams{Page: query.Page, Limit: query.Limit},
+	})
+	if err != nil {
+		handlerutil.RespondDomainError(
+			c,
+			err,
+			handlerutil.PlainError(http.StatusInternalServerError, "fetch_failed", "Failed to load webhook deliveries"),
+		)
+		return
+	}
+
+	items := make([]dto.DeliveryResponse, len(result.Items))
+	for i := range result.Items {
+		items[i] = mapDeliveryResponse(&result.Items[i])
+	}
+	c.JSON(http.StatusOK, dto.DeliveryListResponse{
+		Items:      items,
+		Total:      result.Total,
+		Page:       result.Page,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// GetDelivery godoc
+// @Summary Get a webhook delivery with its payload
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 200 {object} dto.DeliveryResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/deliveries/{id} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondDeliveryError(c, err, "fetch_failed", "Failed to load webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapDeliveryResponse(delivery))
+}
+
+// ReplayDelivery godoc
+// @Summary Requeue a failed webhook delivery
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 200 {object} dto.DeliveryResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/deliveries/{id}/replay [post]
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	id, ok := handlerutil.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.ReplayDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondDeliveryError(c, err, "replay_failed", "Failed to replay webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapDeliveryResponse(delivery))
+}
+
+// ReplayFailedDeliveries godoc
+// @Summary Requeue all failed webhook deliveries
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param payload body dto.ReplayDeliveriesRequest false "Limit the replay to one subscription"
+// @Success 200 {object} dto.ReplayDeliveriesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/admin/webhooks/deliveries/replay [post]
+func (h *WebhookHandler) ReplayFailedDeliveries(c *gin.Context) {
+	var req dto.ReplayDeliveriesRequest
+	if c.Request.ContentLength != 0 && !handlerutil.BindJSON(c, &req) {
+		return
+	}
+
+	replayed, err := h.service.ReplayFailed(c.Request.Context(), req.SubscriptionID)
+	if err != nil {
+		handlerutil.RespondDomainError(
+			c,
+			err,
+			handlerutil.PlainError(http.StatusInternalServerError, "replay_failed", "Failed to replay webhook deliveries"),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReplayDeliveriesResponse{Replayed: replayed})
+}
+
+func respondSubscriptionError(c *gin.Context, err error, code, message string) {
+	handlerutil.RespondDomainError(
+		c,
+		err,
+		handlerutil.PlainError(http.StatusInternalServerError, code, message),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.PlainError(http.StatusNotFound, "not_found", "Webhook subscription not found")),
+	)
+}
+
+func respondDeliveryError(c *gin.Context, err error, code, message string) {
+	handlerutil.RespondDomainError(
+		c,
+		err,
+		handlerutil.PlainError(http.StatusInternalServerError, code, message),
+		handlerutil.MapError(domain.ErrNotFound, handlerutil.PlainError(http.StatusNotFound, "not_found", "Webhook delivery not found")),
+		handlerutil.MapError(domain.ErrConflict, handlerutil.PlainError(http.StatusConflict, "not_failed", "Only failed deliveries can be replayed")),
+	)
+}
+
+func optionalUUID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+func mapSubscriptionResponse(subscription *domainWebhook.Subscription, secret string) dto.SubscriptionResponse {
+	return dto.SubscriptionResponse{
+		ID:             subscription.ID,
+		Name:           subscription.Name,
+		URL:            subscription.URL,
+		ProjectID:      subscription.ProjectID,
+		Active:         subscription.Active,
+		EventTypes:     nonNil(subscription.EventTypes),
+		AggregateTypes: nonNil(subscription.AggregateTypes),
+		Actions:        nonNil(subscription.Actions),
+		JobStatuses:    nonNil(subscription.JobStatuses),
+		CreatedByID:    subscription.CreatedByID,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+		Secret:         secret,
+	}
+}
+
+func mapDeliveryResponse(delivery *domainWebhook.Delivery) dto.DeliveryResponse {
+	return dto.DeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventType:      string(delivery.EventType),
+		EventID:        delivery.EventID,
+		ProjectID:      delivery.ProjectID,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	ListSubscriptions(ctx context.Context, projectID *uuid.UUID) ([]domainWebhook.Subscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*domainWebhook.Subscription, error)
+	UpsertSubscription(ctx context.Context, input domainWebhook.UpsertSubscriptionInput) (*domainWebhook.Subscription, string, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) (*domain.PaginatedList[domainWebhook.Delivery], error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*domainWebhook.Delivery, error)
+	ReplayDelivery(ctx context.Context, id uuid.UUID) (*domainWebhook.Delivery, error)
+	ReplayFailed(ctx context.Context, subscriptionID *uuid.UUID) (int64, error)
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(protectedV1 *gin.RouterGroup, handler *WebhookHandler, authChecker middleware.AuthorizationChecker) {
+	webhooksAdmin := protectedV1.Group("/admin/webhooks")
+	webhooksAdmin.Use(middleware.RequirePermission(authChecker, domainUser.PermissionWebhookManage))
+	{
+		webhooksAdmin.GET("/subscriptions", handler.ListSubscriptions)
+		webhooksAdmin.POST("/subscriptions", handler.CreateSubscription)
+		webhooksAdmin.GET("/subscriptions/:id", handler.GetSubscription)
+		webhooksAdmin.PUT("/subscriptions/:id", handler.UpdateSubscription)
+		webhooksAdmin.DELETE("/subscriptions/:id", handler.DeleteSubscription)
+		webhooksAdmin.GET("/deliveries", handler.ListDeliveries)
+		webhooksAdmin.POST("/deliveries/replay", handler.ReplayFailedDeliveries)
+		webhooksAdmin.GET("/deliveries/:id", handler.GetDelivery)
+		webhooksAdmin.POST("/deliveries/:id/replay", handler.ReplayDelivery)
+	}
+}
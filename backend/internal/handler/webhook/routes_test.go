@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainTeam "github.com/besart951/go_infra_link/backend/internal/domain/team"
+	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	dto "github.com/besart951/go_infra_link/backend/internal/handler/dto/webhook"
+	"github.com/besart951/go_infra_link/backend/internal/handler/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestRegisterRoutes_RequiresWebhookManagePermission(t *testing.T) {
+	router, authz, _ := setupWebhookRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks/deliveries?status=failed", nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("expected forbidden without webhook.manage, got %d", res.Code)
+	}
+	if authz.lastPermission != domainUser.PermissionWebhookManage {
+		t.Fatalf("expected %q check, got %q", domainUser.PermissionWebhookManage, authz.lastPermission)
+	}
+}
+
+func TestCreateSubscriptionReturnsSecretOnce(t *testing.T) {
+	router, authz, service := setupWebhookRouter(t)
+	authz.granted[domainUser.PermissionWebhookManage] = true
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/subscriptions", strings.NewReader(`{"name":"GMS","url":"https://example.com/hook","active":true,"event_types":["project.change"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected created, got %d: %s", res.Code, res.Body.String())
+	}
+	var body dto.SubscriptionResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Secret != "whsec_test" || service.input.ActorID == uuid.Nil || service.input.EventTypes[0] != "project.change" {
+		t.Fatalf("unexpected create: %+v from %+v", body, service.input)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks/subscriptions", nil)
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	if res.Code != http.StatusOK || strings.Contains(res.Body.String(), "whsec_test") {
+		t.Fatalf("listing must not expose secrets, got %d: %s", res.Code, res.Body.String())
+	}
+}
+
+func TestReplayDeliveryMapsConflict(t *testing.T) {
+	router, authz, _ := setupWebhookRouter(t)
+	authz.granted[domainUser.PermissionWebhookManage] = true
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/deliveries/"+uuid.NewString()+"/replay", nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected conflict for a delivery that did not fail, got %d", res.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/deliveries/replay", nil)
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	if res.Code != http.StatusOK || !strings.Contains(res.Body.String(), `"replayed":3`) {
+		t.Fatalf("bulk replay = %d: %s", res.Code, res.Body.String())
+	}
+}
+
+func setupWebhookRouter(t *testing.T) (*gin.Engine, *webhookAuthzStub, *webhookServiceStub) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	authz := &webhookAuthzStub{granted: map[string]bool{}}
+	service := &webhookServiceStub{}
+	router := gin.New()
+	group := router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, uuid.New())
+		c.Next()
+	})
+	RegisterRoutes(group, NewWebhookHandler(service), authz)
+	return router, authz, service
+}
+
+type webhookAuthzStub struct {
+	granted        map[string]bool
+	lastPermission string
+}
+
+func (a *webhookAuthzStub) GetGlobalRole(context.Context, uuid.UUID) (domainUser.Role, error) {
+	return domainUser.RolePlaner, nil
+}
+
+func (a *webhookAuthzStub) GetTeamRole(context.Context, uuid.UUID, uuid.UUID) (*domainTeam.MemberRole, error) {
+	return nil, nil
+}
+
+func (a *webhookAuthzStub) HasPermission(_ context.Context, _ domainUser.Role, permission string) (bool, error) {
+	a.lastPermission = permission
+	return a.granted[permission], nil
+}
+
+type webhookServiceStub struct {
+	input domainWebhook.UpsertSubscriptionInput
+}
+
+func (s *webhookServiceStub) ListSubscriptions(context.Context, *uuid.UUID) ([]domainWebhook.Subscription, error) {
+	return []domainWebhook.Subscription{{Base: domain.Base{ID: uuid.New()}, Name: "GMS", SecretCipher: "cipher"}}, nil
+}
+
+func (s *webhookServiceStub) GetSubscription(context.Context, uuid.UUID) (*domainWebhook.Subscription, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (s *webhookServiceStub) UpsertSubscription(_ context.Context, input domainWebhook.UpsertSubscriptionInput) (*domainWebhook.Subscription, string, error) {
+	s.input = input
+	return &domainWebhook.Subscription{Base: domain.Base{ID: uuid.New()}, Name: input.Name, URL: input.URL, EventTypes: input.EventTypes}, "whsec_test", nil
+}
+
+func (s *webhookServiceStub) DeleteSubscription(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (s *webhookServiceStub) ListDeliveries(context.Context, domainWebhook.DeliveryFilter) (*domain.PaginatedList[domainWebhook.Delivery], error) {
+	return &domain.PaginatedList[domainWebhook.Delivery]{}, nil
+}
+
+func (s *webhookServiceStub) GetDelivery(context.Context, uuid.UUID) (*domainWebhook.Delivery, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (s *webhookServiceStub) ReplayDelivery(context.Context, uuid.UUID) (*domainWebhook.Delivery, error) {
+	return nil, domain.ErrConflict
+}
+
+func (s *webhookServiceStub) ReplayFailed(context.Context, *uuid.UUID) (int64, error) {
+	return 3, nil
+}
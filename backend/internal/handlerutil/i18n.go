@@ -17,8 +17,7 @@ func RespondLocalizedError(c *gin.Context, status int, code string, keyOrMessage
 			if key == "" {
 				continue
 			}
-			translated := translator.Get(locale, key)
-			if translated != key {
+			if translated, ok := translator.Lookup(locale, key); ok {
 				message = translated
 				break
 			}
@@ -0,0 +1,98 @@
+package exporting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	"github.com/xuri/excelize/v2"
+)
+
+// BaselineDiffWriter writes a baseline comparison as a workbook with a
+// summary sheet and one row per changed field.
+type BaselineDiffWriter struct{}
+
+func NewBaselineDiffWriter() BaselineDiffWriter { return BaselineDiffWriter{} }
+
+var (
+	baselineSummaryHeadings = []string{"category", "added", "removed", "changed", "unchanged"}
+	baselineChangeHeadings  = []string{"category", "action", "label", "entity_id", "field", "before", "after"}
+)
+
+func (BaselineDiffWriter) WriteDiff(ctx context.Context, target io.Writer, diff projectbaseline.Diff) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+	if err := workbook.SetSheetName(workbook.GetSheetName(0), "Summary"); err != nil {
+		return err
+	}
+	if err := setSheetRows(workbook, "Summary", baselineSummaryRows(diff)); err != nil {
+		return err
+	}
+	if _, err := workbook.NewSheet("Changes"); err != nil {
+		return err
+	}
+	if err := setSheetRows(workbook, "Changes", baselineChangeRows(diff)); err != nil {
+		return err
+	}
+	_, err := workbook.WriteTo(target)
+	return err
+}
+
+func baselineSummaryRows(diff projectbaseline.Diff) [][]string {
+	rows := [][]string{
+		{"from", diff.From.Name, diff.From.CapturedAt.Format(time.RFC3339)},
+		{"to", diff.To.Name, diff.To.CapturedAt.Format(time.RFC3339)},
+		{},
+		baselineSummaryHeadings,
+	}
+	for _, category := range projectbaseline.Categories() {
+		summary := diff.Summary[category]
+		rows = append(rows, []string{
+			string(category), strconv.Itoa(summary.Added), strconv.Itoa(summary.Removed),
+			strconv.Itoa(summary.Changed), strconv.Itoa(summary.Unchanged),
+		})
+	}
+	return rows
+}
+
+func baselineChangeRows(diff projectbaseline.Diff) [][]string {
+	rows := [][]string{baselineChangeHeadings}
+	for _, change := range diff.Changes {
+		prefix := []string{string(change.Category), string(change.Action), change.Label, change.EntityID.String()}
+		if len(change.Fields) == 0 {
+			rows = append(rows, append(prefix, "", "", ""))
+			continue
+		}
+		for _, field := range change.Fields {
+			row := append([]string{}, prefix...)
+			rows = append(rows, append(row, field.Field, baselineCell(field.Before), baselineCell(field.After)))
+		}
+	}
+	return rows
+}
+
+func baselineCell(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typed)
+	default:
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprint(typed)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,109 @@
+package exporting
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	referencecatalog "github.com/besart951/go_infra_link/backend/internal/application/referencecatalog"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/xuri/excelize/v2"
+)
+
+// CatalogWriter writes state text and notification class catalogs with the
+// headings the catalog import reads back.
+type CatalogWriter struct{}
+
+func NewCatalogWriter() CatalogWriter { return CatalogWriter{} }
+
+var notificationClassHeadings = []string{
+	"nc", "event_category", "object_description", "internal_description", "meaning",
+	"ack_required_not_normal", "ack_required_error", "ack_required_normal",
+	"norm_not_normal", "norm_error", "norm_normal",
+}
+
+func (CatalogWriter) WriteCatalog(ctx context.Context, target io.Writer, format referencecatalog.Format, catalog referencecatalog.Catalog) error {
+	rows := catalogRows(catalog)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	switch format {
+	case referencecatalog.FormatCSV:
+		w := csv.NewWriter(target)
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		return w.Error()
+	case referencecatalog.FormatXLSX:
+		return writeCatalogWorkbook(target, catalogSheetName(catalog.Kind), rows)
+	default:
+		return fmt.Errorf("unsupported catalog format %q", format)
+	}
+}
+
+func catalogRows(catalog referencecatalog.Catalog) [][]string {
+	if catalog.Kind == referencecatalog.KindNotificationClasses {
+		rows := [][]string{notificationClassHeadings}
+		for _, item := range catalog.NotificationClasses {
+			rows = append(rows, []string{
+				strconv.Itoa(item.Nc), item.EventCategory, item.ObjectDescription, item.InternalDescription, item.Meaning,
+				strconv.FormatBool(item.AckRequiredNotNormal), strconv.FormatBool(item.AckRequiredError), strconv.FormatBool(item.AckRequiredNormal),
+				strconv.Itoa(item.NormNotNormal), strconv.Itoa(item.NormError), strconv.Itoa(item.NormNormal),
+			})
+		}
+		return rows
+	}
+
+	heading := []string{"ref_number"}
+	for state := 1; state <= domainFacility.StateTextSlots; state++ {
+		heading = append(heading, domainFacility.StateTextField(state))
+	}
+	rows := [][]string{heading}
+	for _, item := range catalog.StateTexts {
+		row := []string{strconv.Itoa(item.RefNumber)}
+		for _, state := range item.States() {
+			row = append(row, strPtr(state))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func catalogSheetName(kind referencecatalog.Kind) string {
+	if kind == referencecatalog.KindNotificationClasses {
+		return "NotificationClasses"
+	}
+	return "StateTexts"
+}
+
+func writeCatalogWorkbook(target io.Writer, sheet string, rows [][]string) error {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+	if err := workbook.SetSheetName(workbook.GetSheetName(0), sheet); err != nil {
+		return err
+	}
+	if err := setSheetRows(workbook, sheet, rows); err != nil {
+		return err
+	}
+	_, err := workbook.WriteTo(target)
+	return err
+}
+
+func setSheetRows(workbook *excelize.File, sheet string, rows [][]string) error {
+	for index, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, index+1)
+		if err != nil {
+			return err
+		}
+		values := make([]any, len(row))
+		for column, value := range row {
+			values[column] = value
+		}
+		if err := workbook.SetSheetRow(sheet, cell, &values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
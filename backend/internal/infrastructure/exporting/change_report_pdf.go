@@ -0,0 +1,271 @@
+package exporting
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+)
+
+// The PDF change list uses the standard Helvetica fonts with WinAnsi
+// encoding on A4 landscape pages, which every viewer renders without
+// embedded fonts.
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 36.0
+	pdfFontSize   = 8.0
+	pdfLineHeight = 11.0
+)
+
+type pdfText struct {
+	x        float64
+	maxRunes int
+	bold     bool
+	text     string
+}
+
+type pdfLine []pdfText
+
+// Column offsets and widths in characters of the entry and field lines.
+// Longer texts wrap onto the following lines.
+var (
+	pdfEntryColumns = []pdfText{{x: 0, maxRunes: 18}, {x: 80, maxRunes: 26}, {x: 200, maxRunes: 14}, {x: 270, maxRunes: 120}}
+	pdfFieldColumns = []pdfText{{x: 80, maxRunes: 40}, {x: 270, maxRunes: 62}, {x: 520, maxRunes: 62}}
+)
+
+func writeChangeReportPDF(target io.Writer, report domainExport.ChangeReport) error {
+	pages := paginatePDFLines(changeReportPDFLines(report))
+	document := pdfDocument{}
+	document.write(pages, report.Title)
+	_, err := target.Write(document.buffer.Bytes())
+	return err
+}
+
+// changeReportPDFLines lays out the report and wraps every line whose texts
+// exceed their column.
+func changeReportPDFLines(report domainExport.ChangeReport) []pdfLine {
+	var wrapped []pdfLine
+	for _, line := range changeReportPDFRows(report) {
+		wrapped = append(wrapped, wrapPDFLine(line)...)
+	}
+	return wrapped
+}
+
+func changeReportPDFRows(report domainExport.ChangeReport) []pdfLine {
+	headings := report.Headings
+	lines := []pdfLine{
+		{{maxRunes: 120, bold: true, text: report.Title}},
+		{{maxRunes: 30, text: headings.Period}, {x: 120, maxRunes: 60, text: changeReportPeriod(report)}},
+		{{maxRunes: 30, text: headings.GeneratedAt}, {x: 120, maxRunes: 60, text: formatChangeReportTime(report.GeneratedAt)}},
+	}
+	if report.Truncated {
+		lines = append(lines, pdfLine{{maxRunes: 160, text: headings.Truncated}})
+	}
+	for _, group := range report.Groups {
+		lines = append(lines, nil,
+			pdfLine{{maxRunes: 160, bold: true, text: changeReportGroupTitle(group, headings.Unassigned)}},
+			pdfColumns(pdfEntryColumns, true, headings.OccurredAt, headings.Actor, headings.Action, headings.Entity),
+		)
+		for _, entry := range group.Entries {
+			lines = append(lines, pdfColumns(pdfEntryColumns, false, formatChangeReportTime(entry.OccurredAt), entry.Actor, entry.Action, entry.Entity))
+			for _, field := range entry.Fields {
+				lines = append(lines, pdfColumns(pdfFieldColumns, false, field.Label, field.Before, field.After))
+			}
+		}
+	}
+	return lines
+}
+
+func pdfColumns(columns []pdfText, bold bool, values ...string) pdfLine {
+	line := make(pdfLine, len(columns))
+	for index, column := range columns {
+		column.bold, column.text = bold, values[index]
+		line[index] = column
+	}
+	return line
+}
+
+// wrapPDFLine splits the texts of line into chunks that fit their columns
+// and spreads them over as many lines as the longest text needs.
+func wrapPDFLine(line pdfLine) []pdfLine {
+	chunks := make([][]string, len(line))
+	height := 1
+	for index, text := range line {
+		chunks[index] = wrapPDFText(text.text, text.maxRunes)
+		height = max(height, len(chunks[index]))
+	}
+	lines := make([]pdfLine, height)
+	for row := range lines {
+		lines[row] = make(pdfLine, len(line))
+		for index, text := range line {
+			text.text = ""
+			if row < len(chunks[index]) {
+				text.text = chunks[index][row]
+			}
+			lines[row][index] = text
+		}
+	}
+	return lines
+}
+
+// wrapPDFText breaks text at spaces into chunks of at most maxRunes. Words
+// longer than a chunk are split.
+func wrapPDFText(text string, maxRunes int) []string {
+	words := strings.Fields(text)
+	if maxRunes <= 0 || len(words) == 0 {
+		return []string{strings.Join(words, " ")}
+	}
+	var chunks []string
+	var current []rune
+	for _, word := range words {
+		runes := []rune(word)
+		if len(current) > 0 && len(current)+1+len(runes) > maxRunes {
+			chunks, current = append(chunks, string(current)), nil
+		}
+		if len(current) > 0 {
+			current = append(current, ' ')
+		}
+		for len(current)+len(runes) > maxRunes {
+			split := maxRunes - len(current)
+			chunks, current = append(chunks, string(append(current, runes[:split]...))), nil
+			runes = runes[split:]
+		}
+		current = append(current, runes...)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, string(current))
+	}
+	return chunks
+}
+
+func paginatePDFLines(lines []pdfLine) [][]pdfLine {
+	usable := pdfPageHeight - 2*pdfMargin - pdfLineHeight
+	perPage := int(usable / pdfLineHeight)
+	pages := make([][]pdfLine, 0, len(lines)/perPage+1)
+	for start := 0; start < len(lines); start += perPage {
+		pages = append(pages, lines[start:min(start+perPage, len(lines))])
+	}
+	if len(pages) == 0 {
+		pages = append(pages, nil)
+	}
+	return pages
+}
+
+// pdfDocument writes the objects of a PDF file and records their offsets
+// for the cross-reference table.
+type pdfDocument struct {
+	buffer  bytes.Buffer
+	offsets []int
+}
+
+func (d *pdfDocument) write(pages [][]pdfLine, title string) {
+	d.buffer.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	kids := make([]string, len(pages))
+	for index := range pages {
+		kids[index] = fmt.Sprintf("%d 0 R", 5+2*index)
+	}
+	d.object("<< /Type /Catalog /Pages 2 0 R >>")
+	d.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	d.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	d.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for index, lines := range pages {
+		d.object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*index,
+		))
+		footer := wrapPDFLine(pdfLine{{x: 0, maxRunes: 120, text: fmt.Sprintf("%s - %d/%d", title, index+1, len(pages))}})[0]
+		content := pdfPageContent(lines, footer)
+		d.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+	xref := d.buffer.Len()
+	fmt.Fprintf(&d.buffer, "xref\n0 %d\n0000000000 65535 f \n", len(d.offsets)+1)
+	for _, offset := range d.offsets {
+		fmt.Fprintf(&d.buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&d.buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(d.offsets)+1, xref)
+}
+
+func (d *pdfDocument) object(body string) {
+	d.offsets = append(d.offsets, d.buffer.Len())
+	fmt.Fprintf(&d.buffer, "%d 0 obj\n%s\nendobj\n", len(d.offsets), body)
+}
+
+func pdfPageContent(lines []pdfLine, footer pdfLine) string {
+	var content strings.Builder
+	y := pdfPageHeight - pdfMargin - pdfFontSize
+	for _, line := range lines {
+		writePDFLine(&content, line, y)
+		y -= pdfLineHeight
+	}
+	writePDFLine(&content, footer, pdfMargin-pdfFontSize)
+	return content.String()
+}
+
+func writePDFLine(content *strings.Builder, line pdfLine, y float64) {
+	for _, text := range line {
+		if text.text == "" {
+			continue
+		}
+		font := "F1"
+		if text.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(content, "BT /%s %g Tf 1 0 0 1 %g %g Tm (%s) Tj ET\n", font, pdfFontSize, pdfMargin+text.x, y, pdfString(text.text))
+	}
+}
+
+// pdfWinAnsi maps the typographic characters WinAnsi keeps below 0xA0.
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// pdfWinAnsiByte returns the WinAnsi code of r.
+func pdfWinAnsiByte(r rune) (byte, bool) {
+	switch {
+	case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+		return byte(r), true
+	case pdfWinAnsi[r] != 0:
+		return pdfWinAnsi[r], true
+	default:
+		return 0, false
+	}
+}
+
+// pdfString encodes text as an escaped WinAnsi string literal. Reports with
+// characters outside WinAnsi are written as workbooks instead (see
+// CanRenderPDF), so the "?" replacement only guards against misuse.
+func pdfString(text string) string {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		code, ok := pdfWinAnsiByte(r)
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			encoded = append(encoded, '\\', byte(r))
+		case ok:
+			encoded = append(encoded, code)
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return string(encoded)
+}
+
+// CanRenderPDF reports whether the standard PDF fonts can show every text
+// of report. Whitespace is normalized when the lines are laid out.
+func (ChangeReportWriter) CanRenderPDF(report domainExport.ChangeReport) bool {
+	for _, line := range changeReportPDFRows(report) {
+		for _, text := range line {
+			for _, r := range text.text {
+				if _, ok := pdfWinAnsiByte(r); !ok && !unicode.IsSpace(r) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
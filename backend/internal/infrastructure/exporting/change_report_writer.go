@@ -0,0 +1,162 @@
+package exporting
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/xuri/excelize/v2"
+)
+
+const changeReportTimeLayout = "2006-01-02 15:04"
+
+// ChangeReportWriter renders change reports as a workbook with one row per
+// changed field, or as a printable PDF list.
+type ChangeReportWriter struct{}
+
+func NewChangeReportWriter() ChangeReportWriter { return ChangeReportWriter{} }
+
+func (ChangeReportWriter) GenerateChangeReport(ctx context.Context, outputPath string, report domainExport.ChangeReport, outputType domainExport.OutputType) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	if outputType == domainExport.OutputTypeChangeReportPDF {
+		err = writeChangeReportPDF(f, report)
+	} else {
+		err = writeChangeReportWorkbook(f, report)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(report.EventCount), f.Close()
+}
+
+func writeChangeReportWorkbook(target io.Writer, report domainExport.ChangeReport) error {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+	sheet := changeReportSheetName(report.Title)
+	if err := workbook.SetSheetName(workbook.GetSheetName(0), sheet); err != nil {
+		return err
+	}
+	rows := changeReportCaptionRows(report)
+	headingRow := len(rows) + 2
+	rows = append(rows, []string{}, changeReportHeadingRow(report.Headings))
+	rows = append(rows, changeReportRows(report)...)
+	if err := setSheetRows(workbook, sheet, rows); err != nil {
+		return err
+	}
+	if err := workbook.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: headingRow, TopLeftCell: "A" + strconv.Itoa(headingRow+1), ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	if err := workbook.SetColWidth(sheet, "A", "G", 18); err != nil {
+		return err
+	}
+	if err := workbook.SetColWidth(sheet, "H", "J", 30); err != nil {
+		return err
+	}
+	_, err := workbook.WriteTo(target)
+	return err
+}
+
+func changeReportCaptionRows(report domainExport.ChangeReport) [][]string {
+	rows := [][]string{
+		{report.Title},
+		{report.Headings.Period, changeReportPeriod(report)},
+		{report.Headings.GeneratedAt, formatChangeReportTime(report.GeneratedAt)},
+	}
+	if report.Truncated {
+		rows = append(rows, []string{report.Headings.Truncated})
+	}
+	return rows
+}
+
+func changeReportHeadingRow(headings domainExport.ChangeReportHeadings) []string {
+	return []string{
+		headings.Building, headings.ControlCabinet, headings.SPSController, headings.OccurredAt,
+		headings.Actor, headings.Action, headings.Entity, headings.Field, headings.Before, headings.After,
+	}
+}
+
+func changeReportRows(report domainExport.ChangeReport) [][]string {
+	var rows [][]string
+	for _, group := range report.Groups {
+		building := group.Building
+		if building == "" && group.ControlCabinet == "" && group.SPSController == "" {
+			building = report.Headings.Unassigned
+		}
+		for _, entry := range group.Entries {
+			prefix := []string{
+				building, group.ControlCabinet, group.SPSController, formatChangeReportTime(entry.OccurredAt),
+				entry.Actor, entry.Action, entry.Entity,
+			}
+			if len(entry.Fields) == 0 {
+				rows = append(rows, append(prefix, entry.Summary, "", ""))
+				continue
+			}
+			for _, field := range entry.Fields {
+				row := append([]string{}, prefix...)
+				rows = append(rows, append(row, field.Label, field.Before, field.After))
+			}
+		}
+	}
+	return rows
+}
+
+func changeReportPeriod(report domainExport.ChangeReport) string {
+	from, to := "...", "..."
+	if report.OccurredFrom != nil {
+		from = formatChangeReportTime(*report.OccurredFrom)
+	}
+	if report.OccurredTo != nil {
+		to = formatChangeReportTime(*report.OccurredTo)
+	}
+	return from + " - " + to
+}
+
+func changeReportGroupTitle(group domainExport.ChangeReportGroup, unassigned string) string {
+	parts := make([]string, 0, 3)
+	for _, label := range []string{group.Building, group.ControlCabinet, group.SPSController} {
+		if label != "" {
+			parts = append(parts, label)
+		}
+	}
+	if len(parts) == 0 {
+		return unassigned
+	}
+	return strings.Join(parts, " / ")
+}
+
+// changeReportSheetName keeps the localized title within the 31 characters
+// and the character set Excel accepts for sheet names.
+func changeReportSheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		return "Changes"
+	}
+	return name
+}
+
+func formatChangeReportTime(value time.Time) string {
+	return value.UTC().Format(changeReportTimeLayout)
+}
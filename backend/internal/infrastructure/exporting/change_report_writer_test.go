@@ -0,0 +1,114 @@
+package exporting
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/xuri/excelize/v2"
+)
+
+func changeReportFixture(entries int) domainExport.ChangeReport {
+	group := domainExport.ChangeReportGroup{Building: "1234-1", ControlCabinet: "SG01"}
+	for index := range entries {
+		group.Entries = append(group.Entries, domainExport.ChangeReportEntry{
+			OccurredAt: time.Date(2026, 10, 1, 8, index%60, 0, 0, time.UTC), Actor: "Anna (QA)", Action: "Geändert", Entity: "Feldgerät",
+			Fields: []domainExport.ChangeReportField{{Label: "Apparat-Nr.", Before: "1", After: strconv.Itoa(index)}},
+		})
+	}
+	return domainExport.ChangeReport{
+		Title: "Änderungsliste", GeneratedAt: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), EventCount: entries + 1,
+		Headings: domainExport.ChangeReportHeadings{Field: "Feld", Before: "Vorher", After: "Nachher", Unassigned: "Ohne Zuordnung"},
+		Groups:   []domainExport.ChangeReportGroup{group, {Entries: []domainExport.ChangeReportEntry{{Action: "Erstellt", Entity: "Projekt"}}}},
+	}
+}
+
+func TestChangeReportWorkbookListsOneRowPerField(t *testing.T) {
+	path := t.TempDir() + "/report.xlsx.partial"
+	count, err := NewChangeReportWriter().GenerateChangeReport(t.Context(), path, changeReportFixture(2), domainExport.OutputTypeChangeReportExcel)
+	if err != nil || count != 3 {
+		t.Fatalf("GenerateChangeReport() = %d, %v", count, err)
+	}
+	workbook, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+	rows, err := workbook.GetRows("Änderungsliste")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 8 || rows[4][7] != "Feld" || rows[6][9] != "1" || rows[7][0] != "Ohne Zuordnung" {
+		t.Fatalf("rows = %q", rows)
+	}
+}
+
+func TestChangeReportPDFPaginatesWithValidCrossReferences(t *testing.T) {
+	path := t.TempDir() + "/report.pdf.partial"
+	if _, err := NewChangeReportWriter().GenerateChangeReport(t.Context(), path, changeReportFixture(80), domainExport.OutputTypeChangeReportPDF); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4")) || !bytes.Contains(content, []byte("/Count 4")) {
+		t.Fatalf("want a four page PDF, got %q", content[:min(len(content), 400)])
+	}
+	if !bytes.Contains(content, []byte("(\xc4nderungsliste)")) || !bytes.Contains(content, []byte(`(Anna \(QA\))`)) {
+		t.Fatal("titles must be WinAnsi encoded and parentheses escaped")
+	}
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	if match == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content[xref:], -1)
+	for index, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(content[offset:], []byte(fmt.Sprintf("%d 0 obj", index+1))) {
+			t.Fatalf("xref entry %d points at %q", index+1, content[offset:offset+12])
+		}
+	}
+}
+
+func TestChangeReportPDFWrapsLongValues(t *testing.T) {
+	report := changeReportFixture(1)
+	long := strings.Repeat("Zuluft Temperatur ", 10) + "Ende"
+	report.Groups[0].Entries[0].Fields[0].After = long
+	lines := changeReportPDFLines(report)
+	var after []string
+	for _, line := range lines {
+		if len(line) == 3 && line[2].x == pdfFieldColumns[2].x && line[2].text != "" {
+			if runes := len([]rune(line[2].text)); runes > pdfFieldColumns[2].maxRunes {
+				t.Fatalf("wrapped chunk %q exceeds %d characters", line[2].text, pdfFieldColumns[2].maxRunes)
+			}
+			after = append(after, line[2].text)
+		}
+	}
+	if got := strings.Join(after, " "); got != strings.Join(strings.Fields(long), " ") {
+		t.Fatalf("wrapped value = %q, want the whole value", got)
+	}
+	if !slices.Equal(wrapPDFText("abcdefgh ij", 4), []string{"abcd", "efgh", "ij"}) {
+		t.Fatalf("wrapPDFText() = %q", wrapPDFText("abcdefgh ij", 4))
+	}
+}
+
+func TestChangeReportPDFRejectsCharactersOutsideWinAnsi(t *testing.T) {
+	writer := NewChangeReportWriter()
+	report := changeReportFixture(1)
+	if !writer.CanRenderPDF(report) {
+		t.Fatal("German umlauts must be renderable")
+	}
+	report.Groups[0].Entries[0].Actor = "Łukasz Dvořák"
+	if writer.CanRenderPDF(report) {
+		t.Fatal("characters outside WinAnsi must not be renderable")
+	}
+}
@@ -198,6 +198,7 @@ func buildExportController(c domainFacility.SPSController) domainExport.Controll
 	return domainExport.Controller{
 		ID:               c.ID,
 		ControlCabinetID: c.ControlCabinetID,
+		BuildingID:       c.ControlCabinet.BuildingID,
 		GADevice:         ga,
 
 		IWSCode:             building.IWSCode,
@@ -212,9 +213,29 @@ func buildExportController(c domainFacility.SPSController) domainExport.Controll
 		Subnet:              derefStr(c.Subnet),
 		Gateway:             derefStr(c.Gateway),
 		VLAN:                derefStr(c.Vlan),
+		MACAddress:          exportMACAddress(c.MACAddress),
+		SystemTypes:         exportControllerSystemTypes(c.SPSControllerSystemTypes),
 	}
 }
 
+func exportMACAddress(value *string) string {
+	if value == nil {
+		return ""
+	}
+	mac, _ := domainFacility.NormalizeMACAddress(*value)
+	return mac
+}
+
+func exportControllerSystemTypes(systemTypes []domainFacility.SPSControllerSystemType) []domainExport.ControllerSystemType {
+	out := make([]domainExport.ControllerSystemType, 0, len(systemTypes))
+	for _, st := range systemTypes {
+		out = append(out, domainExport.ControllerSystemType{
+			ID: st.ID, SystemTypeID: st.SystemTypeID, Number: st.Number, DocumentName: st.DocumentName,
+		})
+	}
+	return out
+}
+
 func minSystemPartNumber(systemTypes []domainFacility.SPSControllerSystemType) string {
 	lowest := math.MaxInt
 	for _, st := range systemTypes {
@@ -0,0 +1,288 @@
+package exporting
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// DocumentGenerator writes the facility hierarchy as JSON or NDJSON. Records
+// follow a fixed order (buildings, cabinets, controllers, their system types,
+// then every field device with its children) and are sorted by ID within each level, so the
+// same snapshot always yields byte-identical output.
+type DocumentGenerator struct{}
+
+func NewDocumentGenerator() *DocumentGenerator {
+	return &DocumentGenerator{}
+}
+
+type documentRecordWriter struct {
+	out     *bufio.Writer
+	ndjson  bool
+	records int
+}
+
+func (g *DocumentGenerator) GenerateDocument(ctx context.Context, outputPath string, controllers []domainExport.Controller, source domainExport.DataProvider, req domainExport.Request, pageSize int) (int64, error) {
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 500
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	writer := &documentRecordWriter{out: bufio.NewWriter(f), ndjson: req.OutputType == domainExport.OutputTypeNDJSON}
+	sorted := sortedControllers(controllers)
+	if err := writer.begin(documentManifest(sorted, req)); err != nil {
+		return 0, err
+	}
+	if err := writeDocumentHierarchy(writer, sorted, req); err != nil {
+		return 0, err
+	}
+	var written int64
+	for _, controller := range sorted {
+		count, err := writeDocumentControllerDevices(ctx, writer, controller, source, req, pageSize)
+		if err != nil {
+			return 0, err
+		}
+		written += count
+	}
+	if err := writer.finish(); err != nil {
+		return 0, err
+	}
+	return written, f.Close()
+}
+
+func documentManifest(controllers []domainExport.Controller, req domainExport.Request) domainExport.DocumentManifest {
+	counts := req.Manifest.Counts
+	warnings := append([]string{}, req.Manifest.Warnings...)
+	checksums := req.Manifest.SnapshotChecksums
+	if checksums == nil {
+		checksums = map[string]string{}
+	}
+	return domainExport.DocumentManifest{
+		Format: domainExport.DocumentFormat, FormatVersion: domainExport.DocumentVersion,
+		SchemaVersion: req.SchemaVersion, SnapshotAt: req.SnapshotAt.UTC(), Scope: req.AccessScope,
+		DeviceCount: req.DeviceCount,
+		Counts: domainExport.DocumentCounts{
+			Buildings: int64(len(documentBuildings(controllers))), ControlCabinets: int64(len(documentControlCabinets(controllers))),
+			SPSControllers: int64(len(controllers)), SystemTypes: int64(len(documentSystemTypes(controllers, req))), FieldDevices: counts.FieldDevices, Specifications: counts.Specifications,
+			BacnetObjects: counts.BacnetObjects, SoftwareReferences: counts.SoftwareReferences, AlarmValues: counts.AlarmValues,
+		},
+		Filters: domainExport.DocumentFilters{
+			ProjectIDs: req.ProjectIDs, BuildingIDs: req.BuildingIDs, ControlCabinetIDs: req.ControlCabinetIDs,
+			SPSControllerIDs: req.SPSControllerIDs, SPSControllerSystemTypeIDs: req.SPSControllerSystemTypeIDs, Search: req.Search,
+		},
+		Warnings: warnings, SnapshotChecksums: checksums,
+	}
+}
+
+func writeDocumentHierarchy(writer *documentRecordWriter, controllers []domainExport.Controller, req domainExport.Request) error {
+	for _, building := range documentBuildings(controllers) {
+		if err := writer.write(domainExport.DocumentRecordBuilding, building); err != nil {
+			return err
+		}
+	}
+	for _, cabinet := range documentControlCabinets(controllers) {
+		if err := writer.write(domainExport.DocumentRecordControlCabinet, cabinet); err != nil {
+			return err
+		}
+	}
+	for _, controller := range controllers {
+		if err := writer.write(domainExport.DocumentRecordSPSController, domainExport.DocumentSPSController{
+			ID: controller.ID, ControlCabinetID: controller.ControlCabinetID, GADevice: controller.GADevice,
+			DeviceName: controller.DeviceName, DeviceInstance: controller.DeviceInstance,
+			DeviceDescription: controller.DeviceDescription, DeviceLocation: controller.DeviceLocation,
+			IPAddress: controller.IPAddress, Subnet: controller.Subnet, Gateway: controller.Gateway, VLAN: controller.VLAN,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, systemType := range documentSystemTypes(controllers, req) {
+		if err := writer.write(domainExport.DocumentRecordSystemType, systemType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// documentSystemTypes lists the system types of the controllers, narrowed to
+// the requested ones when the export filters by system type.
+func documentSystemTypes(controllers []domainExport.Controller, req domainExport.Request) []domainExport.DocumentSystemType {
+	var systemTypes []domainExport.DocumentSystemType
+	for _, controller := range controllers {
+		for _, systemType := range controller.SystemTypes {
+			if len(req.SPSControllerSystemTypeIDs) > 0 && !slices.Contains(req.SPSControllerSystemTypeIDs, systemType.ID) {
+				continue
+			}
+			systemTypes = append(systemTypes, domainExport.DocumentSystemType{
+				ID: systemType.ID, SPSControllerID: controller.ID, SystemTypeID: systemType.SystemTypeID,
+				Number: systemType.Number, DocumentName: systemType.DocumentName,
+			})
+		}
+	}
+	sort.Slice(systemTypes, func(i, j int) bool { return systemTypes[i].ID.String() < systemTypes[j].ID.String() })
+	return systemTypes
+}
+
+func documentBuildings(controllers []domainExport.Controller) []domainExport.DocumentBuilding {
+	unique := make(map[uuid.UUID]domainExport.DocumentBuilding)
+	for _, controller := range controllers {
+		unique[controller.BuildingID] = domainExport.DocumentBuilding{
+			ID: controller.BuildingID, IWSCode: controller.IWSCode, BuildingGroup: controller.BuildingGroup,
+		}
+	}
+	buildings := make([]domainExport.DocumentBuilding, 0, len(unique))
+	for _, building := range unique {
+		buildings = append(buildings, building)
+	}
+	sort.Slice(buildings, func(i, j int) bool { return buildings[i].ID.String() < buildings[j].ID.String() })
+	return buildings
+}
+
+func documentControlCabinets(controllers []domainExport.Controller) []domainExport.DocumentControlCabinet {
+	unique := make(map[uuid.UUID]domainExport.DocumentControlCabinet)
+	for _, controller := range controllers {
+		unique[controller.ControlCabinetID] = domainExport.DocumentControlCabinet{
+			ID: controller.ControlCabinetID, BuildingID: controller.BuildingID, ControlCabinetNr: controller.ControlCabinetNr,
+		}
+	}
+	cabinets := make([]domainExport.DocumentControlCabinet, 0, len(unique))
+	for _, cabinet := range unique {
+		cabinets = append(cabinets, cabinet)
+	}
+	sort.Slice(cabinets, func(i, j int) bool { return cabinets[i].ID.String() < cabinets[j].ID.String() })
+	return cabinets
+}
+
+func writeDocumentControllerDevices(ctx context.Context, writer *documentRecordWriter, controller domainExport.Controller, source domainExport.DataProvider, req domainExport.Request, pageSize int) (int64, error) {
+	var written int64
+	afterID := uuid.Nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		devices, err := source.ListFieldDevicesByControllerAfter(ctx, controller.ID, req, afterID, pageSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(devices) == 0 {
+			return written, nil
+		}
+		for _, device := range devices {
+			if err := writeDocumentFieldDevice(writer, controller.ID, device); err != nil {
+				return 0, err
+			}
+			written++
+		}
+		afterID = devices[len(devices)-1].ID
+		if len(devices) < pageSize {
+			return written, nil
+		}
+	}
+}
+
+func writeDocumentFieldDevice(writer *documentRecordWriter, controllerID uuid.UUID, device domainFacility.FieldDevice) error {
+	if err := writer.write(domainExport.DocumentRecordFieldDevice, domainExport.NewDocumentFieldDevice(controllerID, device)); err != nil {
+		return err
+	}
+	if spec := device.Specification; spec != nil {
+		if err := writer.write(domainExport.DocumentRecordSpecification, domainExport.NewDocumentSpecification(device.ID, *spec)); err != nil {
+			return err
+		}
+	}
+	objects := append([]domainFacility.BacnetObject{}, device.BacnetObjects...)
+	sort.Slice(objects, func(i, j int) bool { return objects[i].ID.String() < objects[j].ID.String() })
+	for _, object := range objects {
+		if err := writeDocumentBacnetObject(writer, device.ID, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDocumentBacnetObject(writer *documentRecordWriter, fieldDeviceID uuid.UUID, object domainFacility.BacnetObject) error {
+	if err := writer.write(domainExport.DocumentRecordBacnetObject, domainExport.NewDocumentBacnetObject(fieldDeviceID, object)); err != nil {
+		return err
+	}
+	if object.SoftwareReferenceID != nil {
+		if err := writer.write(domainExport.DocumentRecordSoftwareReference, domainExport.DocumentSoftwareReference{
+			SourceObjectID: object.ID, TargetObjectID: *object.SoftwareReferenceID, FieldDeviceID: fieldDeviceID,
+		}); err != nil {
+			return err
+		}
+	}
+	values := append([]domainFacility.BacnetObjectAlarmValue{}, object.AlarmValues...)
+	sort.Slice(values, func(i, j int) bool { return values[i].ID.String() < values[j].ID.String() })
+	for _, value := range values {
+		if err := writer.write(domainExport.DocumentRecordAlarmValue, domainExport.NewDocumentAlarmValue(object.ID, value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// begin writes the manifest: as the first NDJSON record, or as the
+// "manifest" member that opens a JSON document.
+func (w *documentRecordWriter) begin(manifest domainExport.DocumentManifest) error {
+	if w.ndjson {
+		return w.write(domainExport.DocumentRecordManifest, manifest)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if _, err := w.out.WriteString(`{"manifest":`); err != nil {
+		return err
+	}
+	if _, err := w.out.Write(data); err != nil {
+		return err
+	}
+	_, err = w.out.WriteString(`,"records":[`)
+	return err
+}
+
+func (w *documentRecordWriter) write(recordType domainExport.DocumentRecordType, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(domainExport.DocumentRecord{Type: recordType, Data: data})
+	if err != nil {
+		return err
+	}
+	if !w.ndjson && w.records > 0 {
+		if err := w.out.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	w.records++
+	if _, err := w.out.Write(line); err != nil {
+		return err
+	}
+	if w.ndjson {
+		return w.out.WriteByte('\n')
+	}
+	return nil
+}
+
+func (w *documentRecordWriter) finish() error {
+	if !w.ndjson {
+		if _, err := w.out.WriteString("]}\n"); err != nil {
+			return err
+		}
+	}
+	return w.out.Flush()
+}
@@ -0,0 +1,147 @@
+package exporting
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func TestGenerateDocumentWritesDeterministicNDJSON(t *testing.T) {
+	controllers, source, req := documentFixture()
+	req.OutputType = domainExport.OutputTypeNDJSON
+	first, second := t.TempDir()+"/first.ndjson", t.TempDir()+"/second.ndjson"
+
+	count, err := NewDocumentGenerator().GenerateDocument(t.Context(), first, controllers, source, req, 500)
+	if err != nil || count != 1 {
+		t.Fatalf("GenerateDocument() count=%d error=%v", count, err)
+	}
+	if _, err := NewDocumentGenerator().GenerateDocument(t.Context(), second, controllers, source, req, 500); err != nil {
+		t.Fatal(err)
+	}
+
+	firstBytes, secondBytes := readFile(t, first), readFile(t, second)
+	if !bytes.Equal(firstBytes, secondBytes) {
+		t.Fatal("repeated exports of the same snapshot differ")
+	}
+	var types []domainExport.DocumentRecordType
+	scanner := bufio.NewScanner(bytes.NewReader(firstBytes))
+	for scanner.Scan() {
+		var record domainExport.DocumentRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid record %q: %v", scanner.Text(), err)
+		}
+		types = append(types, record.Type)
+	}
+	want := []domainExport.DocumentRecordType{
+		domainExport.DocumentRecordManifest, domainExport.DocumentRecordBuilding, domainExport.DocumentRecordControlCabinet,
+		domainExport.DocumentRecordSPSController, domainExport.DocumentRecordSystemType, domainExport.DocumentRecordFieldDevice, domainExport.DocumentRecordSpecification,
+		domainExport.DocumentRecordBacnetObject, domainExport.DocumentRecordSoftwareReference, domainExport.DocumentRecordAlarmValue,
+		domainExport.DocumentRecordBacnetObject,
+	}
+	if len(types) != len(want) {
+		t.Fatalf("record types = %v, want %v", types, want)
+	}
+	for index := range want {
+		if types[index] != want[index] {
+			t.Fatalf("record %d type = %q, want %q", index, types[index], want[index])
+		}
+	}
+}
+
+func TestGenerateDocumentWritesJSONManifestWithSnapshotCounts(t *testing.T) {
+	controllers, source, req := documentFixture()
+	req.OutputType = domainExport.OutputTypeJSON
+	path := t.TempDir() + "/export.json"
+
+	if _, err := NewDocumentGenerator().GenerateDocument(t.Context(), path, controllers, source, req, 500); err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Manifest domainExport.DocumentManifest `json:"manifest"`
+		Records  []domainExport.DocumentRecord `json:"records"`
+	}
+	if err := json.Unmarshal(readFile(t, path), &document); err != nil {
+		t.Fatal(err)
+	}
+	manifest := document.Manifest
+	if manifest.Format != domainExport.DocumentFormat || manifest.FormatVersion != domainExport.DocumentVersion || manifest.SchemaVersion != 2 {
+		t.Fatalf("manifest header = %+v", manifest)
+	}
+	if manifest.Counts.Buildings != 1 || manifest.Counts.SPSControllers != 1 || manifest.Counts.SystemTypes != 1 || manifest.Counts.BacnetObjects != 2 ||
+		manifest.SnapshotChecksums["controllers.json"] != "abc" {
+		t.Fatalf("manifest counts = %+v checksums = %v", manifest.Counts, manifest.SnapshotChecksums)
+	}
+	if len(document.Records) != 10 {
+		t.Fatalf("records = %d, want 10", len(document.Records))
+	}
+	var systemType domainExport.DocumentSystemType
+	if err := json.Unmarshal(document.Records[3].Data, &systemType); err != nil {
+		t.Fatal(err)
+	}
+	if systemType.ID != controllers[0].SystemTypes[0].ID || systemType.SPSControllerID != controllers[0].ID ||
+		systemType.Number == nil || *systemType.Number != 100 {
+		t.Fatalf("system type record = %+v", systemType)
+	}
+}
+
+func TestDocumentSystemTypesFollowSystemTypeFilter(t *testing.T) {
+	controllers, _, req := documentFixture()
+	controllers[0].SystemTypes = append(controllers[0].SystemTypes, domainExport.ControllerSystemType{ID: uuid.New(), SystemTypeID: uuid.New()})
+
+	if got := documentSystemTypes(controllers, req); len(got) != 2 {
+		t.Fatalf("unfiltered system types = %d, want 2", len(got))
+	}
+	req.SPSControllerSystemTypeIDs = []uuid.UUID{controllers[0].SystemTypes[1].ID}
+	got := documentSystemTypes(controllers, req)
+	if len(got) != 1 || got[0].ID != controllers[0].SystemTypes[1].ID {
+		t.Fatalf("filtered system types = %+v", got)
+	}
+}
+
+func documentFixture() ([]domainExport.Controller, *generatorPageSource, domainExport.Request) {
+	limit := 24.5
+	device := domainFacility.FieldDevice{ApparatNr: 1, SPSControllerSystemTypeID: uuid.New()}
+	device.ID = uuid.New()
+	device.CreatedAt = time.Unix(1_700_000_000, 0)
+	device.UpdatedAt = device.CreatedAt
+	device.Specification = &domainFacility.Specification{}
+	device.Specification.ID = uuid.New()
+	targetID := uuid.New()
+	device.BacnetObjects = []domainFacility.BacnetObject{
+		{TextFix: "Source", SoftwareReferenceID: &targetID, AlarmValues: []domainFacility.BacnetObjectAlarmValue{{ValueNumber: &limit}}},
+		{TextFix: "Target"},
+	}
+	device.BacnetObjects[0].ID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	device.BacnetObjects[1].ID = targetID
+	device.BacnetObjects[0].AlarmValues[0].ID = uuid.New()
+	number := 100
+	controllers := []domainExport.Controller{{
+		ID: uuid.New(), ControlCabinetID: uuid.New(), BuildingID: uuid.New(), GADevice: "A", IWSCode: "ABCD", BuildingGroup: 1,
+		SystemTypes: []domainExport.ControllerSystemType{{ID: device.SPSControllerSystemTypeID, SystemTypeID: uuid.New(), Number: &number}},
+	}}
+	req := domainExport.Request{
+		SchemaVersion: 2, SnapshotAt: time.Unix(1_700_000_100, 0), DeviceCount: 1, AccessScope: domainExport.AccessScopeGlobal,
+		Manifest: domainExport.Manifest{
+			Counts:            domainExport.Counts{FieldDevices: 1, Specifications: 1, BacnetObjects: 2, SoftwareReferences: 1, AlarmValues: 1},
+			SnapshotChecksums: map[string]string{"controllers.json": "abc"},
+		},
+	}
+	return controllers, &generatorPageSource{items: []domainFacility.FieldDevice{device}}, req
+}
+
+func readFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
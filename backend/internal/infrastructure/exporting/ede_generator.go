@@ -0,0 +1,347 @@
+package exporting
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+const (
+	edeLayoutVersion   = "2.3"
+	edeAuthor          = "go_infra_link"
+	edeDeviceType      = 8
+	edeTimestampLayout = "2006-01-02 15:04:05"
+)
+
+var edeColumns = []string{
+	"# keyname", "device obj.-instance", "object-name", "object-type", "object-instance", "description",
+	"present-value-default", "min-present-value", "max-present-value", "settable", "supports COV",
+	"hi-limit", "low-limit", "state-text-reference", "unit-code", "vendor-specific-address", "notification-class",
+}
+
+// edeObjectTypes maps software types to the BACnet object type enumeration.
+var edeObjectTypes = map[domainFacility.BacnetSoftwareType]int{
+	domainFacility.BacnetSoftwareTypeAI: 0,
+	domainFacility.BacnetSoftwareTypeAO: 1,
+	domainFacility.BacnetSoftwareTypeAV: 2,
+	domainFacility.BacnetSoftwareTypeBI: 3,
+	domainFacility.BacnetSoftwareTypeBO: 4,
+	domainFacility.BacnetSoftwareTypeBV: 5,
+	domainFacility.BacnetSoftwareTypeCA: 6,
+	domainFacility.BacnetSoftwareTypeEE: 9,
+	domainFacility.BacnetSoftwareTypeLP: 12,
+	domainFacility.BacnetSoftwareTypeMI: 13,
+	domainFacility.BacnetSoftwareTypeMO: 14,
+	domainFacility.BacnetSoftwareTypeNC: 15,
+	domainFacility.BacnetSoftwareTypeSC: 17,
+	domainFacility.BacnetSoftwareTypeMV: 19,
+	domainFacility.BacnetSoftwareTypeTL: 20,
+}
+
+var edeSettableTypes = map[domainFacility.BacnetSoftwareType]bool{
+	domainFacility.BacnetSoftwareTypeAO: true, domainFacility.BacnetSoftwareTypeAV: true,
+	domainFacility.BacnetSoftwareTypeBO: true, domainFacility.BacnetSoftwareTypeBV: true,
+	domainFacility.BacnetSoftwareTypeMO: true, domainFacility.BacnetSoftwareTypeMV: true,
+}
+
+// edeEngineeringUnits maps unit catalog codes to BACnet engineering units.
+var edeEngineeringUnits = map[string]int{
+	"A": 3, "V": 5, "J": 16, "KWH": 19, "HZ": 27, "G_KG": 28, "RH": 29, "M": 31, "LUX": 37,
+	"W": 47, "KW": 48, "PA": 53, "KPA": 54, "BAR": 55, "C": 62, "K": 63, "F": 64, "H": 71,
+	"MIN": 72, "S": 73, "M_S": 74, "L_S": 87, "PPM": 96, "PERCENT": 98, "RPM": 104,
+	"HPA": 133, "M3_H": 135,
+}
+
+type EDEGenerator struct{}
+
+func NewEDEGenerator() *EDEGenerator {
+	return &EDEGenerator{}
+}
+
+type edeControllerFiles struct {
+	stateTexts map[int]*domainFacility.StateText
+	units      map[int]string
+}
+
+func (g *EDEGenerator) GenerateEDEArchive(ctx context.Context, outputPath string, controllers []domainExport.Controller, source domainExport.DataProvider, req domainExport.Request, pageSize int) (int64, error) {
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 500
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	zw := zip.NewWriter(f)
+	defer func() { _ = zw.Close() }()
+
+	usedBaseNames := map[string]struct{}{}
+	var written int64
+	for _, controller := range sortedControllers(controllers) {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+		baseName := ensureUniqueZipEntryName(safeEDEBaseName(controller), usedBaseNames)
+		entry, err := zw.Create(baseName + "_EDE.csv")
+		if err != nil {
+			return 0, err
+		}
+		count, files, err := writeEDEObjectList(ctx, entry, controller, source, req, pageSize)
+		if err != nil {
+			return 0, err
+		}
+		written += count
+		if err := writeEDEStateTexts(zw, baseName, files.stateTexts); err != nil {
+			return 0, err
+		}
+		if err := writeEDEUnits(zw, baseName, files.units); err != nil {
+			return 0, err
+		}
+	}
+	return written, zw.Close()
+}
+
+func writeEDEObjectList(ctx context.Context, out io.Writer, controller domainExport.Controller, source domainExport.DataProvider, req domainExport.Request, pageSize int) (int64, edeControllerFiles, error) {
+	files := edeControllerFiles{stateTexts: map[int]*domainFacility.StateText{}, units: map[int]string{}}
+	naming := req.ObjectNamingPattern()
+	w := newEDEWriter(out)
+	for _, record := range edeHeaderRecords(controller, req) {
+		if err := w.Write(record); err != nil {
+			return 0, files, err
+		}
+	}
+	if err := w.Write(edeDeviceRecord(controller)); err != nil {
+		return 0, files, err
+	}
+
+	var written int64
+	afterID := uuid.Nil
+	for {
+		devices, err := source.ListFieldDevicesByControllerAfter(ctx, controller.ID, req, afterID, pageSize)
+		if err != nil {
+			return 0, files, err
+		}
+		if len(devices) == 0 {
+			break
+		}
+		for _, device := range devices {
+			for _, bo := range device.BacnetObjects {
+				if err := w.Write(edeObjectRecord(naming, controller, device, bo, files)); err != nil {
+					return 0, files, err
+				}
+			}
+			written++
+		}
+		afterID = devices[len(devices)-1].ID
+		if len(devices) < pageSize {
+			break
+		}
+	}
+	w.Flush()
+	return written, files, w.Error()
+}
+
+func newEDEWriter(out io.Writer) *csv.Writer {
+	w := csv.NewWriter(out)
+	w.Comma = ';'
+	w.UseCRLF = true
+	return w
+}
+
+func edeHeaderRecords(controller domainExport.Controller, req domainExport.Request) [][]string {
+	timestamp := req.SnapshotAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	markers := make([]string, len(edeColumns))
+	for i := range markers {
+		markers[i] = "optional"
+		if i < 5 {
+			markers[i] = "mandatory"
+		}
+	}
+	markers[0] = "#" + markers[0]
+	return [][]string{
+		{"#Engineering-Data-Exchange - B.I.G.-EU"},
+		{"PROJECT_NAME", controller.DeviceName},
+		{"VERSION_OF_REFERENCEFILE", "1"},
+		{"TIMESTAMP_OF_LAST_CHANGE", timestamp.UTC().Format(edeTimestampLayout)},
+		{"AUTHOR_OF_LAST_CHANGE", edeAuthor},
+		{"VERSION_OF_LAYOUT", edeLayoutVersion},
+		markers,
+		edeColumns,
+	}
+}
+
+func edeDeviceRecord(controller domainExport.Controller) []string {
+	record := make([]string, len(edeColumns))
+	record[0] = controller.DeviceName
+	record[1] = controller.DeviceInstance
+	record[2] = controller.DeviceName
+	record[3] = strconv.Itoa(edeDeviceType)
+	record[4] = controller.DeviceInstance
+	record[5] = controller.DeviceDescription
+	return record
+}
+
+func edeObjectRecord(naming domainFacility.NamingPattern, controller domainExport.Controller, device domainFacility.FieldDevice, bo domainFacility.BacnetObject, files edeControllerFiles) []string {
+	address := bo.SoftwareAddress()
+	name := buildBacnetObjectName(naming, controller, device, address)
+	record := make([]string, len(edeColumns))
+	record[0] = name
+	record[1] = controller.DeviceInstance
+	record[2] = name
+	if objectType, ok := edeObjectTypes[domainFacility.BacnetSoftwareType(strings.ToLower(string(bo.SoftwareType)))]; ok {
+		record[3] = strconv.Itoa(objectType)
+	}
+	record[4] = strconv.Itoa(int(bo.SoftwareNumber))
+	record[5] = buildDescription(device, bo.TextFix)
+	record[9] = edeFlag(edeSettableTypes[domainFacility.BacnetSoftwareType(strings.ToLower(string(bo.SoftwareType)))])
+	record[11] = edeAlarmLimit(bo, "high_limit")
+	record[12] = edeAlarmLimit(bo, "low_limit")
+	if bo.StateText != nil {
+		record[13] = strconv.Itoa(bo.StateText.RefNumber)
+		files.stateTexts[bo.StateText.RefNumber] = bo.StateText
+	}
+	if code, symbol, ok := edeUnit(bo); ok {
+		record[14] = strconv.Itoa(code)
+		files.units[code] = symbol
+	}
+	record[15] = address
+	if bo.NotificationClass != nil {
+		record[16] = strconv.Itoa(bo.NotificationClass.Nc)
+	}
+	return record
+}
+
+func edeFlag(value bool) string {
+	if value {
+		return "Y"
+	}
+	return "N"
+}
+
+func edeAlarmLimit(bo domainFacility.BacnetObject, key string) string {
+	for _, value := range bo.AlarmValues {
+		if value.AlarmTypeField == nil || value.AlarmTypeField.AlarmField == nil || value.AlarmTypeField.AlarmField.Key != key {
+			continue
+		}
+		switch {
+		case value.ValueNumber != nil:
+			return strconv.FormatFloat(*value.ValueNumber, 'f', -1, 64)
+		case value.ValueInteger != nil:
+			return strconv.FormatInt(*value.ValueInteger, 10)
+		}
+	}
+	return ""
+}
+
+func edeUnit(bo domainFacility.BacnetObject) (int, string, bool) {
+	for _, value := range bo.AlarmValues {
+		if value.Unit == nil {
+			continue
+		}
+		if code, ok := edeEngineeringUnits[strings.ToUpper(value.Unit.Code)]; ok {
+			return code, value.Unit.Symbol, true
+		}
+	}
+	return 0, "", false
+}
+
+func writeEDEStateTexts(zw *zip.Writer, baseName string, stateTexts map[int]*domainFacility.StateText) error {
+	entry, err := zw.Create(baseName + "_StateTexts.csv")
+	if err != nil {
+		return err
+	}
+	w := newEDEWriter(entry)
+	header := []string{"#State-Text-File - B.I.G.-EU"}
+	columns := []string{"#Reference-Number"}
+	for i := 1; i <= 16; i++ {
+		columns = append(columns, fmt.Sprintf("Text %d", i))
+	}
+	if err := w.WriteAll([][]string{header, columns}); err != nil {
+		return err
+	}
+	for _, ref := range sortedIntKeys(stateTexts) {
+		if err := w.Write(edeStateTextRecord(stateTexts[ref])); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func edeStateTextRecord(st *domainFacility.StateText) []string {
+	values := []*string{st.StateText1, st.StateText2, st.StateText3, st.StateText4, st.StateText5, st.StateText6, st.StateText7, st.StateText8, st.StateText9, st.StateText10, st.StateText11, st.StateText12, st.StateText13, st.StateText14, st.StateText15, st.StateText16}
+	last := 0
+	for i, value := range values {
+		if value != nil && strings.TrimSpace(*value) != "" {
+			last = i + 1
+		}
+	}
+	record := []string{strconv.Itoa(st.RefNumber)}
+	for _, value := range values[:last] {
+		record = append(record, strings.TrimSpace(strPtr(value)))
+	}
+	return record
+}
+
+func writeEDEUnits(zw *zip.Writer, baseName string, units map[int]string) error {
+	entry, err := zw.Create(baseName + "_Units.csv")
+	if err != nil {
+		return err
+	}
+	w := newEDEWriter(entry)
+	if err := w.WriteAll([][]string{{"#Unit-File - B.I.G.-EU"}, {"#Unit-Code", "Unit-Text"}}); err != nil {
+		return err
+	}
+	for _, code := range sortedIntKeys(units) {
+		if err := w.Write([]string{strconv.Itoa(code), units[code]}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func sortedIntKeys[T any](items map[int]T) []int {
+	keys := make([]int, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func safeEDEBaseName(ctrl domainExport.Controller) string {
+	name := strings.TrimSpace(ctrl.DeviceName)
+	if name == "" {
+		name = "controller-" + ctrl.ID.String()[:8]
+	}
+	invalid := []string{"\\", "/", "*", "?", ":", "[", "]", "<", ">", "|", "\""}
+	for _, ch := range invalid {
+		name = strings.ReplaceAll(name, ch, "-")
+	}
+	if len(name) > 120 {
+		name = name[:120]
+	}
+	return name
+}
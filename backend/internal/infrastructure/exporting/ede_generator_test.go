@@ -0,0 +1,95 @@
+package exporting
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+	"slices"
+	"testing"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func TestGenerateEDEArchiveWritesObjectListAndCompanionFiles(t *testing.T) {
+	on, off := "On", "Off"
+	highLimit := 24.5
+	number := 3
+	device := domainFacility.FieldDevice{ApparatNr: 1}
+	device.ID = uuid.New()
+	device.SPSControllerSystemType.Number = &number
+	device.SystemPart = domainFacility.SystemPart{ShortName: "LÜ", Name: "Lüftung"}
+	device.Apparat = domainFacility.Apparat{ShortName: "VE", Name: "Ventilator"}
+	device.BacnetObjects = []domainFacility.BacnetObject{{
+		TextFix: "Betrieb", SoftwareType: domainFacility.BacnetSoftwareTypeBO, SoftwareNumber: 7,
+		StateText:         &domainFacility.StateText{RefNumber: 12, StateText1: &off, StateText2: &on},
+		NotificationClass: &domainFacility.NotificationClass{Nc: 40},
+		AlarmValues: []domainFacility.BacnetObjectAlarmValue{{
+			ValueNumber:    &highLimit,
+			AlarmTypeField: &domainFacility.AlarmTypeField{AlarmField: &domainFacility.AlarmField{Key: "high_limit"}},
+			Unit:           &domainFacility.Unit{Code: "C", Symbol: "°C"},
+		}},
+	}}
+	controller := domainExport.Controller{
+		ID: uuid.New(), ControlCabinetID: uuid.New(), GADevice: "A", IWSCode: "ABCD", BuildingGroup: 1,
+		DeviceName: "ABCD_1_0003_A", DeviceInstance: "CD001",
+	}
+	path := t.TempDir() + "/export.zip"
+
+	count, err := NewEDEGenerator().GenerateEDEArchive(t.Context(), path, []domainExport.Controller{controller},
+		&generatorPageSource{items: []domainFacility.FieldDevice{device}}, domainExport.Request{}, 500)
+	if err != nil || count != 1 {
+		t.Fatalf("GenerateEDEArchive() count=%d error=%v", count, err)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = archive.Close() }()
+	entries := map[string][][]string{}
+	for _, file := range archive.File {
+		entries[file.Name] = readEDEEntry(t, file)
+	}
+	objects := entries["ABCD_1_0003_A_EDE.csv"]
+	if len(objects) != 10 {
+		t.Fatalf("EDE rows = %d, want header, device and one object: %v", len(objects), objects)
+	}
+	want := []string{
+		"ABCD_1_0003_A_LÜVE01_BO07", "CD001", "ABCD_1_0003_A_LÜVE01_BO07", "4", "7", "Lüftung Ventilator - LÜVE Betrieb",
+		"", "", "", "Y", "", "24.5", "", "12", "62", "BO07", "40",
+	}
+	if got := objects[9]; !slices.Equal(got, want) {
+		t.Fatalf("object row = %q, want %q", got, want)
+	}
+	if states := entries["ABCD_1_0003_A_StateTexts.csv"]; len(states) != 3 || !slices.Equal(states[2], []string{"12", "Off", "On"}) {
+		t.Fatalf("unexpected state texts %q", states)
+	}
+	if units := entries["ABCD_1_0003_A_Units.csv"]; len(units) != 3 || !slices.Equal(units[2], []string{"62", "°C"}) {
+		t.Fatalf("unexpected units %q", units)
+	}
+}
+
+func readEDEEntry(t *testing.T, file *zip.File) [][]string {
+	t.Helper()
+	content, err := file.Open()
+	if err != nil {
+		t.Fatalf("open %s: %v", file.Name, err)
+	}
+	defer func() { _ = content.Close() }()
+	reader := csv.NewReader(content)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		records = append(records, record)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -51,8 +52,14 @@ var headings = []string{
 	"Stromstärke",
 	"Leistung",
 	"Drehzahl",
+	idHeading,
 }
 
+// idHeading is the hidden column holding the field device or BACnet object
+// ID of each row. The edit import matches rows by it, so planners may sort,
+// filter or move rows without editing the wrong object.
+const idHeading = "ID"
+
 // styles holds pre-created excelize style IDs for the workbook.
 type styles struct {
 	headerTitle    int // Row 1: bold, size 16
@@ -98,12 +105,82 @@ func createStyles(f *excelize.File) (styles, error) {
 	return s, nil
 }
 
-type ExcelizeGenerator struct{}
+type ExcelizeGenerator struct {
+	translations domainFacility.TranslationRepository
+}
 
 func NewExcelizeGenerator() *ExcelizeGenerator {
 	return &ExcelizeGenerator{}
 }
 
+// SetTranslations enables localized point lists for requests with a locale.
+// Translations are read live rather than from the export snapshot; they only
+// change the read-only columns of the controller sheets. The editable Text-Fix
+// column and the machine-readable Data-* sheets keep the base texts, so an
+// edited localized workbook imports without overwriting them.
+func (g *ExcelizeGenerator) SetTranslations(translations domainFacility.TranslationRepository) {
+	g.translations = translations
+}
+
+func (g *ExcelizeGenerator) loadTranslations(ctx context.Context, locale string, devices []domainFacility.FieldDevice) (domainFacility.Translations, error) {
+	if g.translations == nil || locale == "" {
+		return domainFacility.Translations{}, nil
+	}
+	ids := make([]uuid.UUID, 0, 2+len(devices)*2)
+	seen := make(map[uuid.UUID]struct{})
+	add := func(id uuid.UUID) {
+		if _, ok := seen[id]; ok || id == uuid.Nil {
+			return
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	for _, device := range devices {
+		add(device.Apparat.ID)
+		add(device.SystemPart.ID)
+		for _, bo := range device.BacnetObjects {
+			add(bo.ID)
+			if bo.StateText != nil {
+				add(bo.StateText.ID)
+			}
+		}
+	}
+	return domainFacility.LoadTranslations(ctx, g.translations, locale, ids)
+}
+
+// localizeFieldDevice returns a copy of device with translated names and
+// state texts. Fixed texts stay in the base language; see localizedTextFix.
+func localizeFieldDevice(texts domainFacility.Translations, device domainFacility.FieldDevice) domainFacility.FieldDevice {
+	if texts.Empty() {
+		return device
+	}
+	device.Apparat.Name = texts.Text(domainFacility.TranslationResourceApparat, device.Apparat.ID, domainFacility.TranslationFieldName, device.Apparat.Name)
+	device.SystemPart.Name = texts.Text(domainFacility.TranslationResourceSystemPart, device.SystemPart.ID, domainFacility.TranslationFieldName, device.SystemPart.Name)
+	objects := make([]domainFacility.BacnetObject, len(device.BacnetObjects))
+	for index, bo := range device.BacnetObjects {
+		if bo.StateText != nil {
+			stateText := texts.LocalizeStateText(*bo.StateText)
+			bo.StateText = &stateText
+		}
+		objects[index] = bo
+	}
+	device.BacnetObjects = objects
+	return device
+}
+
+func localizedTextFix(texts domainFacility.Translations, bo domainFacility.BacnetObject) string {
+	return texts.Text(domainFacility.TranslationResourceBacnetObject, bo.ID, domainFacility.TranslationFieldTextFix, bo.TextFix)
+}
+
+// controllerHeadings adds the read-only translated Text-Fix column to localized
+// point lists. The edit import reads columns by heading and ignores it.
+func controllerHeadings(locale string) []string {
+	if locale == "" {
+		return headings
+	}
+	return append(slices.Clone(headings), fmt.Sprintf("Text-Fix (%s)", locale))
+}
+
 const excelMaximumRows = 1_048_576
 
 func (g *ExcelizeGenerator) GenerateWorkbook(ctx context.Context, outputPath string, controllers []domainExport.Controller, source domainExport.DataProvider, req domainExport.Request, pageSize int) (int64, error) {
@@ -121,6 +198,7 @@ func (g *ExcelizeGenerator) GenerateWorkbook(ctx context.Context, outputPath str
 	defaultSheet := f.GetSheetName(0)
 	var written int64
 	usedSheets := make(map[string]struct{})
+	naming := req.ObjectNamingPattern()
 	if err := writeExportManifest(f, req); err != nil {
 		return 0, err
 	}
@@ -151,7 +229,7 @@ func (g *ExcelizeGenerator) GenerateWorkbook(ctx context.Context, outputPath str
 		if err != nil {
 			return 0, err
 		}
-		rowIdx, err := writeControllerHeading(stream, controller, st)
+		rowIdx, err := writeControllerHeading(stream, controller, controllerHeadings(req.Locale), st)
 		if err != nil {
 			return 0, err
 		}
@@ -165,6 +243,10 @@ func (g *ExcelizeGenerator) GenerateWorkbook(ctx context.Context, outputPath str
 			if len(devices) == 0 {
 				break
 			}
+			texts, err := g.loadTranslations(ctx, req.Locale, devices)
+			if err != nil {
+				return 0, err
+			}
 			for _, device := range devices {
 				rowsNeeded := 1 + len(device.BacnetObjects)
 				if rowIdx+rowsNeeded-1 > excelMaximumRows {
@@ -180,18 +262,24 @@ func (g *ExcelizeGenerator) GenerateWorkbook(ctx context.Context, outputPath str
 					if err != nil {
 						return 0, err
 					}
-					rowIdx, err = writeControllerHeading(stream, controller, st)
+					rowIdx, err = writeControllerHeading(stream, controller, controllerHeadings(req.Locale), st)
 					if err != nil {
 						return 0, err
 					}
 				}
-				if err := stream.SetRow(cell("A", rowIdx), styledAnyRow(firstLine(controller, device), st.firstLineStyle)); err != nil {
+				shown := localizeFieldDevice(texts, device)
+				if err := stream.SetRow(cell("A", rowIdx), styledAnyRow(firstLine(naming, controller, shown), st.firstLineStyle)); err != nil {
 					return 0, err
 				}
 				rowIdx++
 
-				for _, bo := range device.BacnetObjects {
-					if err := stream.SetRow(cell("A", rowIdx), anyToCells(bacnetLine(controller, device, bo))); err != nil {
+				for _, bo := range shown.BacnetObjects {
+					textFix := localizedTextFix(texts, bo)
+					line := bacnetLine(naming, controller, shown, bo, textFix)
+					if req.Locale != "" {
+						line = append(line, textFix)
+					}
+					if err := stream.SetRow(cell("A", rowIdx), anyToCells(line)); err != nil {
 						return 0, err
 					}
 					rowIdx++
@@ -412,6 +500,8 @@ func exportManifestRows(req domainExport.Request) [][]any {
 		{"sps_controller_ids", joinUUIDs(req.SPSControllerIDs)},
 		{"sps_controller_system_type_ids", joinUUIDs(req.SPSControllerSystemTypeIDs)},
 		{"search", req.Search},
+		{"locale", req.Locale},
+		{"object_naming", req.ObjectNamingPattern().Pattern},
 		{"workbook_shards", strings.Join(req.Manifest.WorkbookShards, ",")},
 		{"warnings", strings.Join(req.Manifest.Warnings, " | ")},
 		{"snapshot_checksums", string(checksums)},
@@ -462,7 +552,11 @@ func pointerValue[T any](value *T) any {
 	return *value
 }
 
-func writeControllerHeading(stream *excelize.StreamWriter, controller domainExport.Controller, st styles) (int, error) {
+func writeControllerHeading(stream *excelize.StreamWriter, controller domainExport.Controller, headings []string, st styles) (int, error) {
+	idColumn := slices.Index(headings, idHeading) + 1
+	if err := stream.SetColVisible(idColumn, idColumn, false); err != nil {
+		return 0, err
+	}
 	rowIdx := 1
 	for i, row := range controllerHeaderRows(controller) {
 		styleID := st.headerInfo
@@ -618,7 +712,7 @@ func controllerHeaderRows(ctrl domainExport.Controller) [][]string {
 // Data row builders
 // ---------------------------------------------------------------------------
 
-func firstLine(ctrl domainExport.Controller, device domainFacility.FieldDevice) []any {
+func firstLine(naming domainFacility.NamingPattern, ctrl domainExport.Controller, device domainFacility.FieldDevice) []any {
 	softwareSums := map[string]float64{}
 	hardwareSums := map[string]float64{}
 	for _, key := range softwareKeys {
@@ -645,7 +739,7 @@ func firstLine(ctrl domainExport.Controller, device domainFacility.FieldDevice)
 	}
 
 	row := []any{
-		buildBacnetObjectName(ctrl, device, ""),
+		buildBacnetObjectName(naming, ctrl, device, ""),
 		buildFieldDeviceDescription(device),
 		"",
 		"",
@@ -681,19 +775,22 @@ func firstLine(ctrl domainExport.Controller, device domainFacility.FieldDevice)
 		specFloat(device.Specification, func(s *domainFacility.Specification) *float64 { return s.ElectricalConnectionAmperage }),
 		specFloat(device.Specification, func(s *domainFacility.Specification) *float64 { return s.ElectricalConnectionPower }),
 		specInt(device.Specification, func(s *domainFacility.Specification) *int { return s.ElectricalConnectionRotation }),
+		device.ID.String(),
 	)
 
 	return row
 }
 
-func bacnetLine(ctrl domainExport.Controller, device domainFacility.FieldDevice, bo domainFacility.BacnetObject) []any {
+// bacnetLine writes the base Text-Fix into its editable column and uses
+// shownTextFix, which may be translated, only for the description.
+func bacnetLine(naming domainFacility.NamingPattern, ctrl domainExport.Controller, device domainFacility.FieldDevice, bo domainFacility.BacnetObject, shownTextFix string) []any {
 	s := softwareMetrics(bo)
 	h := hardwareMetrics(bo)
-	address := softwareAddress(bo)
+	address := bo.SoftwareAddress()
 
 	row := []any{
-		buildBacnetObjectName(ctrl, device, address),
-		buildDescription(device, bo.TextFix),
+		buildBacnetObjectName(naming, ctrl, device, address),
+		buildDescription(device, shownTextFix),
 		aggregateStateTexts(bo.StateText),
 		notificationNC(bo.NotificationClass),
 		strPtr(device.BMK),
@@ -719,7 +816,7 @@ func bacnetLine(ctrl domainExport.Controller, device domainFacility.FieldDevice,
 		row = append(row, h[key])
 	}
 
-	row = append(row, "", "", "", "", "", "", "", "", "", "", "", "")
+	row = append(row, "", "", "", "", "", "", "", "", "", "", "", "", bo.ID.String())
 	return row
 }
 
@@ -739,14 +836,6 @@ func softwareMetrics(bo domainFacility.BacnetObject) map[string]float64 {
 	return out
 }
 
-func softwareAddress(bo domainFacility.BacnetObject) string {
-	key := strings.ToUpper(string(bo.SoftwareType))
-	if key == "" {
-		return ""
-	}
-	return fmt.Sprintf("%s%02d", key, bo.SoftwareNumber)
-}
-
 func hardwareMetrics(bo domainFacility.BacnetObject) map[string]float64 {
 	out := map[string]float64{}
 	for _, key := range hardwareKeys {
@@ -759,33 +848,16 @@ func hardwareMetrics(bo domainFacility.BacnetObject) map[string]float64 {
 	return out
 }
 
-func buildBacnetObjectName(ctrl domainExport.Controller, device domainFacility.FieldDevice, suffix string) string {
-	sysTypeNr := ""
-	if device.SPSControllerSystemType.Number != nil {
-		sysTypeNr = fmt.Sprintf("%04d", *device.SPSControllerSystemType.Number)
-	}
-
-	devicePart := ""
-	if device.SystemPart.ShortName != "" || device.Apparat.ShortName != "" {
-		devicePart = device.SystemPart.ShortName + device.Apparat.ShortName + fmt.Sprintf("%02d", device.ApparatNr)
-	}
-
-	nameParts := filterEmpty([]string{
-		ctrl.IWSCode,
-		fmt.Sprintf("%d", ctrl.BuildingGroup),
-		sysTypeNr,
-		ctrl.GADevice,
-		devicePart,
-	})
-
-	base := strings.Join(nameParts, "_")
-	if suffix == "" {
-		return base
-	}
-	if base == "" {
-		return suffix
+// buildBacnetObjectName renders an object name with the naming pattern of the
+// export. Names the pattern cannot resolve, such as the field device line
+// without a software address, keep the parts of the pattern that have values;
+// the export warns about objects among them before generating.
+func buildBacnetObjectName(naming domainFacility.NamingPattern, ctrl domainExport.Controller, device domainFacility.FieldDevice, softwareAddress string) string {
+	values := domainFacility.ObjectNamingValues(ctrl.NamingValues(), device, softwareAddress)
+	if name, ok := naming.Render(values); ok {
+		return name
 	}
-	return base + "_" + suffix
+	return naming.RenderPartial(values)
 }
 
 func buildDescription(device domainFacility.FieldDevice, textFix string) string {
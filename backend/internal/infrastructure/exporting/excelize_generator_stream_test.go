@@ -3,6 +3,8 @@ package exporting
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
@@ -70,3 +72,94 @@ func TestGenerateWorkbookStreamsPastFormerThreeHundredRowLimit(t *testing.T) {
 		}
 	}
 }
+
+type generatorTranslationRepo struct {
+	items   []domainFacility.Translation
+	locales []string
+}
+
+func (r *generatorTranslationRepo) ListByEntities(_ context.Context, _ []uuid.UUID, locales []string) ([]domainFacility.Translation, error) {
+	r.locales = locales
+	return r.items, nil
+}
+
+func (r *generatorTranslationRepo) ReplaceLocale(context.Context, domainFacility.TranslationResource, uuid.UUID, string, []domainFacility.Translation) error {
+	return nil
+}
+
+func TestGenerateWorkbookLocalizesControllerSheetOnly(t *testing.T) {
+	device := domainFacility.FieldDevice{ApparatNr: 1}
+	device.ID = uuid.New()
+	device.Apparat.ID, device.Apparat.Name = uuid.New(), "Ventilator"
+	device.SystemPart.ID, device.SystemPart.Name = uuid.New(), "Zuluft"
+	object := domainFacility.BacnetObject{TextFix: "Störung"}
+	object.ID = uuid.New()
+	device.BacnetObjects = []domainFacility.BacnetObject{object}
+	repo := &generatorTranslationRepo{items: []domainFacility.Translation{
+		{Resource: domainFacility.TranslationResourceApparat, EntityID: device.Apparat.ID, Locale: "fr", Field: "name", Text: "Ventilateur"},
+		{Resource: domainFacility.TranslationResourceBacnetObject, EntityID: object.ID, Locale: "fr_CH", Field: "text_fix", Text: "Dérangement"},
+	}}
+	generator := NewExcelizeGenerator()
+	generator.SetTranslations(repo)
+	path := t.TempDir() + "/export.xlsx"
+
+	_, err := generator.GenerateWorkbook(t.Context(), path, []domainExport.Controller{{
+		ID: uuid.New(), ControlCabinetID: uuid.New(), GADevice: "A",
+	}}, &generatorPageSource{items: []domainFacility.FieldDevice{device}}, domainExport.Request{SchemaVersion: 1, Locale: "fr_CH"}, 10)
+
+	if err != nil {
+		t.Fatalf("GenerateWorkbook() error = %v", err)
+	}
+	if len(repo.locales) != 2 || repo.locales[0] != "fr_CH" || repo.locales[1] != "fr" {
+		t.Fatalf("locales = %v", repo.locales)
+	}
+	workbook, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	defer func() { _ = workbook.Close() }()
+	controllerSheet := sheetText(t, workbook, workbook.GetSheetName(0))
+	for _, want := range []string{"Ventilateur", "Dérangement", "Zuluft"} {
+		if !containsCell(controllerSheet, want) {
+			t.Errorf("controller sheet lacks %q", want)
+		}
+	}
+	if containsCell(controllerSheet, "Ventilator") {
+		t.Error("controller sheet kept the base apparat name")
+	}
+	heading := slices.IndexFunc(controllerSheet, func(row []string) bool { return len(row) > 0 && row[0] == "BACnet Object Name" })
+	if heading < 0 || heading+2 >= len(controllerSheet) {
+		t.Fatalf("controller sheet lacks the object row: %v", controllerSheet)
+	}
+	columns, line := controllerSheet[heading], controllerSheet[heading+2]
+	textFix, localized := slices.Index(columns, "Text-Fix"), slices.Index(columns, "Text-Fix (fr_CH)")
+	if textFix < 0 || localized < 0 || localized >= len(line) {
+		t.Fatalf("headings = %v", columns)
+	}
+	if line[textFix] != "Störung" || line[localized] != "Dérangement" {
+		t.Errorf("Text-Fix = %q, localized = %q; the editable column must keep the base text", line[textFix], line[localized])
+	}
+	if objects := sheetText(t, workbook, "Data-BACnetObjects"); !containsCell(objects, "Störung") || containsCell(objects, "Dérangement") {
+		t.Error("machine sheet must keep the base texts")
+	}
+}
+
+func sheetText(t *testing.T, workbook *excelize.File, sheet string) [][]string {
+	t.Helper()
+	rows, err := workbook.GetRows(sheet)
+	if err != nil {
+		t.Fatalf("GetRows(%q) error = %v", sheet, err)
+	}
+	return rows
+}
+
+func containsCell(rows [][]string, text string) bool {
+	for _, row := range rows {
+		for _, value := range row {
+			if strings.Contains(value, text) {
+				return true
+			}
+		}
+	}
+	return false
+}
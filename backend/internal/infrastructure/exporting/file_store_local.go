@@ -69,8 +69,15 @@ func (s *LocalFileStore) removeExpired(before time.Time) error {
 
 func (s *LocalFileStore) BuildOutputPath(jobID uuid.UUID, outputType domainExport.OutputType, downloadFileName string) (string, string) {
 	ext := ".xlsx"
-	if outputType == domainExport.OutputTypeZip {
+	switch outputType {
+	case domainExport.OutputTypeZip, domainExport.OutputTypeEDE, domainExport.OutputTypeNetwork:
 		ext = ".zip"
+	case domainExport.OutputTypeJSON:
+		ext = ".json"
+	case domainExport.OutputTypeNDJSON:
+		ext = ".ndjson"
+	case domainExport.OutputTypeChangeReportPDF:
+		ext = ".pdf"
 	}
 
 	storageFileName := fmt.Sprintf("field-device-export-%s%s", jobID.String(), ext)
@@ -0,0 +1,263 @@
+package exporting
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+)
+
+var networkConfigColumns = []string{
+	"device_name", "ga_device", "ip_address", "subnet", "gateway", "vlan", "mac_address", "control_cabinet", "building", "building_group",
+}
+
+// NetworkConfigGenerator renders controller addressing for the network team.
+// DHCP servers match reservations by hardware address, so controllers without
+// a MAC address are left out of the DHCP snippets and listed as warnings.
+type NetworkConfigGenerator struct{}
+
+func NewNetworkConfigGenerator() *NetworkConfigGenerator {
+	return &NetworkConfigGenerator{}
+}
+
+type networkConfigEntry struct {
+	DeviceName     string `json:"device_name"`
+	GADevice       string `json:"ga_device"`
+	IPAddress      string `json:"ip_address"`
+	Subnet         string `json:"subnet"`
+	Gateway        string `json:"gateway"`
+	VLAN           string `json:"vlan"`
+	MACAddress     string `json:"mac_address"`
+	ControlCabinet string `json:"control_cabinet"`
+	Building       string `json:"building"`
+	BuildingGroup  int    `json:"building_group"`
+	// network is the subnet in CIDR form; invalid when the entry cannot be
+	// reserved because IP or subnet mask is missing or malformed.
+	network netip.Prefix
+}
+
+// reservable reports whether a DHCP reservation can be written for the entry.
+func (e networkConfigEntry) reservable() bool {
+	return e.network.IsValid() && e.MACAddress != ""
+}
+
+func (g *NetworkConfigGenerator) GenerateNetworkConfig(ctx context.Context, outputPath string, controllers []domainExport.Controller) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	entries := networkConfigEntries(controllers)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	zw := zip.NewWriter(f)
+	defer func() { _ = zw.Close() }()
+	writers := []struct {
+		name  string
+		write func(io.Writer, []networkConfigEntry) error
+	}{
+		{"controllers.csv", writeNetworkConfigCSV},
+		{"controllers.json", writeNetworkConfigJSON},
+		{"dhcpd-reservations.conf", writeISCDHCPReservations},
+		{"kea-dhcp4-reservations.json", writeKeaReservations},
+	}
+	for _, writer := range writers {
+		entry, err := zw.Create(writer.name)
+		if err != nil {
+			return 0, err
+		}
+		if err := writer.write(entry, entries); err != nil {
+			return 0, fmt.Errorf("write %s: %w", writer.name, err)
+		}
+	}
+	return int64(len(entries)), zw.Close()
+}
+
+func networkConfigEntries(controllers []domainExport.Controller) []networkConfigEntry {
+	sorted := sortedControllers(controllers)
+	entries := make([]networkConfigEntry, len(sorted))
+	for index, controller := range sorted {
+		entries[index] = networkConfigEntry{
+			DeviceName:     controller.DeviceName,
+			GADevice:       controller.GADevice,
+			IPAddress:      strings.TrimSpace(controller.IPAddress),
+			Subnet:         strings.TrimSpace(controller.Subnet),
+			Gateway:        strings.TrimSpace(controller.Gateway),
+			VLAN:           strings.TrimSpace(controller.VLAN),
+			MACAddress:     controller.MACAddress,
+			ControlCabinet: controller.ControlCabinetNr,
+			Building:       controller.IWSCode,
+			BuildingGroup:  controller.BuildingGroup,
+		}
+		entries[index].network, _ = controllerNetwork(entries[index].IPAddress, entries[index].Subnet)
+	}
+	return entries
+}
+
+func controllerNetwork(ip, subnet string) (netip.Prefix, bool) {
+	addr, err := netip.ParseAddr(ip)
+	mask := net.ParseIP(subnet).To4()
+	if err != nil || !addr.Is4() || mask == nil {
+		return netip.Prefix{}, false
+	}
+	ones, bits := net.IPMask(mask).Size()
+	if bits == 0 {
+		return netip.Prefix{}, false
+	}
+	prefix, err := addr.Prefix(ones)
+	return prefix, err == nil
+}
+
+func writeNetworkConfigCSV(w io.Writer, entries []networkConfigEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(networkConfigColumns); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := writer.Write([]string{
+			entry.DeviceName, entry.GADevice, entry.IPAddress, entry.Subnet, entry.Gateway, entry.VLAN, entry.MACAddress,
+			entry.ControlCabinet, entry.Building, fmt.Sprintf("%d", entry.BuildingGroup),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeNetworkConfigJSON(w io.Writer, entries []networkConfigEntry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]any{"controllers": entries, "warnings": networkConfigWarnings(entries)})
+}
+
+// networkConfigWarnings names the addressed controllers left out of the DHCP
+// reservations because their MAC address is unknown.
+func networkConfigWarnings(entries []networkConfigEntry) []string {
+	warnings := []string{}
+	for _, entry := range entries {
+		if entry.network.IsValid() && entry.MACAddress == "" {
+			warnings = append(warnings, fmt.Sprintf("%s (%s): no MAC address, DHCP reservation skipped", entry.DeviceName, entry.IPAddress))
+		}
+	}
+	return warnings
+}
+
+func writeISCDHCPReservations(w io.Writer, entries []networkConfigEntry) error {
+	var b strings.Builder
+	b.WriteString("# SPS controller reservations exported by go_infra_link.\n")
+	for _, warning := range networkConfigWarnings(entries) {
+		fmt.Fprintf(&b, "# Skipped %s\n", warning)
+	}
+	for _, entry := range entries {
+		if !entry.reservable() {
+			continue
+		}
+		hostName := dhcpHostName(entry.DeviceName)
+		fmt.Fprintf(&b, "\n# VLAN %s, %s, cabinet %s\n", entry.VLAN, entry.network, entry.ControlCabinet)
+		fmt.Fprintf(&b, "host %s {\n", hostName)
+		fmt.Fprintf(&b, "  hardware ethernet %s;\n", entry.MACAddress)
+		fmt.Fprintf(&b, "  fixed-address %s;\n", entry.IPAddress)
+		fmt.Fprintf(&b, "  option subnet-mask %s;\n", entry.Subnet)
+		if entry.Gateway != "" {
+			fmt.Fprintf(&b, "  option routers %s;\n", entry.Gateway)
+		}
+		fmt.Fprintf(&b, "  option host-name \"%s\";\n}\n", hostName)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type keaSubnet struct {
+	ID           int              `json:"id"`
+	Subnet       string           `json:"subnet"`
+	OptionData   []keaOptionData  `json:"option-data,omitempty"`
+	Reservations []keaReservation `json:"reservations"`
+}
+
+type keaOptionData struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+type keaReservation struct {
+	HWAddress   string            `json:"hw-address"`
+	Hostname    string            `json:"hostname"`
+	IPAddress   string            `json:"ip-address"`
+	UserContext map[string]string `json:"user-context"`
+}
+
+// writeKeaReservations groups reservations by subnet; subnet ids follow the
+// sorted subnet order so repeated exports produce identical files.
+func writeKeaReservations(w io.Writer, entries []networkConfigEntry) error {
+	subnets := map[netip.Prefix]*keaSubnet{}
+	for _, entry := range entries {
+		if !entry.reservable() {
+			continue
+		}
+		network := entry.network.Masked()
+		subnet, ok := subnets[network]
+		if !ok {
+			subnet = &keaSubnet{Subnet: network.String(), Reservations: []keaReservation{}}
+			subnets[network] = subnet
+		}
+		if len(subnet.OptionData) == 0 && entry.Gateway != "" {
+			subnet.OptionData = []keaOptionData{{Name: "routers", Data: entry.Gateway}}
+		}
+		subnet.Reservations = append(subnet.Reservations, keaReservation{
+			HWAddress: entry.MACAddress, Hostname: dhcpHostName(entry.DeviceName), IPAddress: entry.IPAddress,
+			UserContext: map[string]string{
+				"ga_device": entry.GADevice, "vlan": entry.VLAN,
+				"control_cabinet": entry.ControlCabinet, "building": entry.Building,
+			},
+		})
+	}
+	ordered := make([]keaSubnet, 0, len(subnets))
+	for _, subnet := range subnets {
+		ordered = append(ordered, *subnet)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		left, right := netip.MustParsePrefix(ordered[i].Subnet), netip.MustParsePrefix(ordered[j].Subnet)
+		if left.Addr() != right.Addr() {
+			return left.Addr().Less(right.Addr())
+		}
+		return left.Bits() < right.Bits()
+	})
+	for index := range ordered {
+		ordered[index].ID = index + 1
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]any{"Dhcp4": map[string]any{"subnet4": ordered}})
+}
+
+// dhcpHostName turns a device name into a DNS label; underscores and other
+// characters outside [A-Za-z0-9-] become hyphens.
+func dhcpHostName(deviceName string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(deviceName))
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "sps-controller"
+	}
+	return name
+}
@@ -0,0 +1,92 @@
+package exporting
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/google/uuid"
+)
+
+func TestGenerateNetworkConfigWritesAllFormats(t *testing.T) {
+	cabinetID := uuid.New()
+	controllers := []domainExport.Controller{
+		{
+			ID: uuid.New(), ControlCabinetID: cabinetID, GADevice: "A", IWSCode: "ABCD", BuildingGroup: 1,
+			ControlCabinetNr: "SG01", DeviceName: "ABCD_1_0003_A",
+			IPAddress: "10.1.0.10", Subnet: "255.255.255.0", Gateway: "10.1.0.1", VLAN: "10", MACAddress: "00:1a:2b:3c:4d:5e",
+		},
+		{ID: uuid.New(), ControlCabinetID: cabinetID, GADevice: "B", IWSCode: "ABCD", BuildingGroup: 1, DeviceName: "ABCD_1_0003_B"},
+		{
+			ID: uuid.New(), ControlCabinetID: cabinetID, GADevice: "C", IWSCode: "ABCD", BuildingGroup: 1, DeviceName: "ABCD_1_0003_C",
+			IPAddress: "10.1.0.11", Subnet: "255.255.255.0", Gateway: "10.1.0.1", VLAN: "10",
+		},
+	}
+	path := t.TempDir() + "/network.zip"
+
+	count, err := NewNetworkConfigGenerator().GenerateNetworkConfig(t.Context(), path, controllers)
+	if err != nil || count != 3 {
+		t.Fatalf("GenerateNetworkConfig() count=%d error=%v", count, err)
+	}
+
+	entries := readNetworkConfigArchive(t, path)
+	if lines := strings.Split(strings.TrimSpace(entries["controllers.csv"]), "\n"); len(lines) != 4 ||
+		lines[1] != "ABCD_1_0003_A,A,10.1.0.10,255.255.255.0,10.1.0.1,10,00:1a:2b:3c:4d:5e,SG01,ABCD,1" {
+		t.Fatalf("unexpected csv %q", entries["controllers.csv"])
+	}
+	var document struct {
+		Controllers []networkConfigEntry `json:"controllers"`
+		Warnings    []string             `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(entries["controllers.json"]), &document); err != nil || len(document.Controllers) != 3 {
+		t.Fatalf("unexpected json %q: %v", entries["controllers.json"], err)
+	}
+	if len(document.Warnings) != 1 || !strings.HasPrefix(document.Warnings[0], "ABCD_1_0003_C (10.1.0.11)") {
+		t.Fatalf("expected a warning for the controller without MAC address, got %v", document.Warnings)
+	}
+	dhcpd := entries["dhcpd-reservations.conf"]
+	if !strings.Contains(dhcpd, "host ABCD-1-0003-A {") || !strings.Contains(dhcpd, "hardware ethernet 00:1a:2b:3c:4d:5e;") ||
+		!strings.Contains(dhcpd, "fixed-address 10.1.0.10;") || strings.Contains(dhcpd, "ABCD-1-0003-B") ||
+		strings.Contains(dhcpd, "host ABCD-1-0003-C") || !strings.Contains(dhcpd, "# Skipped ABCD_1_0003_C") {
+		t.Fatalf("unexpected ISC-DHCP snippet %q", dhcpd)
+	}
+	var kea struct {
+		Dhcp4 struct {
+			Subnet4 []keaSubnet `json:"subnet4"`
+		}
+	}
+	if err := json.Unmarshal([]byte(entries["kea-dhcp4-reservations.json"]), &kea); err != nil {
+		t.Fatal(err)
+	}
+	if len(kea.Dhcp4.Subnet4) != 1 || kea.Dhcp4.Subnet4[0].Subnet != "10.1.0.0/24" ||
+		len(kea.Dhcp4.Subnet4[0].Reservations) != 1 || kea.Dhcp4.Subnet4[0].Reservations[0].HWAddress != "00:1a:2b:3c:4d:5e" ||
+		kea.Dhcp4.Subnet4[0].OptionData[0].Data != "10.1.0.1" {
+		t.Fatalf("unexpected kea snippet %+v", kea.Dhcp4.Subnet4)
+	}
+}
+
+func readNetworkConfigArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = archive.Close() }()
+	entries := map[string]string{}
+	for _, file := range archive.File {
+		content, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		data, err := io.ReadAll(content)
+		_ = content.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		entries[file.Name] = string(data)
+	}
+	return entries
+}
@@ -0,0 +1,302 @@
+package importing
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	referencecatalog "github.com/besart951/go_infra_link/backend/internal/application/referencecatalog"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/xuri/excelize/v2"
+)
+
+// CatalogReader reads state text and notification class catalogs from CSV or
+// XLSX files. It accepts the columns written by the catalog export as well as
+// common vendor headings, and the headerless B.I.G.-EU state text layout
+// (reference number followed by up to 16 texts).
+type CatalogReader struct{}
+
+func NewCatalogReader() CatalogReader { return CatalogReader{} }
+
+var catalogStateColumn = regexp.MustCompile(`^(?:statetext|state|text)(\d{1,2})$`)
+
+// catalogColumns maps normalized headings (lower case, letters and digits
+// only) to catalog fields.
+var catalogColumns = map[referencecatalog.Kind]map[string]string{
+	referencecatalog.KindStateTexts: {
+		"refnumber": "key", "referencenumber": "key", "reference": "key", "ref": "key",
+	},
+	referencecatalog.KindNotificationClasses: {
+		"nc": "key", "notificationclass": "key", "ncnumber": "key",
+		"eventcategory": "event_category", "category": "event_category",
+		"objectdescription": "object_description", "description": "object_description",
+		"internaldescription": "internal_description", "meaning": "meaning",
+		"ackrequirednotnormal": "ack_required_not_normal", "ackrequiredtooffnormal": "ack_required_not_normal", "acktooffnormal": "ack_required_not_normal",
+		"ackrequirederror": "ack_required_error", "ackrequiredtofault": "ack_required_error", "acktofault": "ack_required_error",
+		"ackrequirednormal": "ack_required_normal", "ackrequiredtonormal": "ack_required_normal", "acktonormal": "ack_required_normal",
+		"normnotnormal": "norm_not_normal", "prioritytooffnormal": "norm_not_normal",
+		"normerror": "norm_error", "prioritytofault": "norm_error",
+		"normnormal": "norm_normal", "prioritytonormal": "norm_normal",
+	},
+}
+
+func (CatalogReader) ReadCatalog(ctx context.Context, kind referencecatalog.Kind, format referencecatalog.Format, source io.Reader) (referencecatalog.Catalog, error) {
+	catalog := referencecatalog.Catalog{Kind: kind}
+	var rows [][]string
+	var err error
+	switch format {
+	case referencecatalog.FormatCSV:
+		rows, err = readCatalogCSV(source)
+	case referencecatalog.FormatXLSX:
+		rows, err = readCatalogWorkbook(source)
+	default:
+		err = fmt.Errorf("unsupported catalog format %q", format)
+	}
+	if err != nil {
+		return catalog, fmt.Errorf("%w: %v", referencecatalog.ErrInvalidCatalog, err)
+	}
+
+	parser := catalogRowParser{kind: kind, catalog: &catalog}
+	for index, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return catalog, err
+		}
+		parser.consume(index+1, row)
+	}
+	if parser.columns == nil && len(catalog.StateTexts) == 0 && len(catalog.NotificationClasses) == 0 && len(catalog.Issues) == 0 {
+		catalog.Issues = append(catalog.Issues, referencecatalog.Issue{Code: "empty", Message: "the file contains no catalog rows"})
+	}
+	return catalog, nil
+}
+
+// readCatalogCSV detects a semicolon, comma or tab delimiter from the start
+// of the file; vendor tools commonly write semicolons below a banner line.
+func readCatalogCSV(source io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(source)
+	peeked, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	head := string(peeked)
+	reader := csv.NewReader(buffered)
+	reader.Comma = ','
+	for _, candidate := range []rune{';', '\t'} {
+		if strings.Count(head, string(candidate)) > strings.Count(head, string(reader.Comma)) {
+			reader.Comma = candidate
+		}
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	return reader.ReadAll()
+}
+
+// readCatalogWorkbook reads the first sheet of the workbook.
+func readCatalogWorkbook(source io.Reader) ([][]string, error) {
+	workbook, err := excelize.OpenReader(source)
+	if err != nil {
+		return nil, err
+	}
+	defer workbook.Close()
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no sheets")
+	}
+	return workbook.GetRows(sheets[0])
+}
+
+type catalogRowParser struct {
+	kind    referencecatalog.Kind
+	catalog *referencecatalog.Catalog
+	// columns maps field names to column positions once a heading row was
+	// found; nil means no heading yet.
+	columns map[string]int
+}
+
+func (p *catalogRowParser) consume(rowNumber int, row []string) {
+	if catalogRowBlank(row) {
+		return
+	}
+	if columns, ok := p.heading(row); ok {
+		p.columns = columns
+		return
+	}
+	if strings.HasPrefix(strings.TrimSpace(row[0]), "#") {
+		return
+	}
+	if p.columns == nil {
+		if p.kind != referencecatalog.KindStateTexts {
+			p.issue(rowNumber, nil, "", "missing_header", "the file has no heading row with an nc column")
+			return
+		}
+		p.columns = positionalStateTextColumns()
+	}
+	if p.kind == referencecatalog.KindNotificationClasses {
+		p.notificationClass(rowNumber, row)
+		return
+	}
+	p.stateText(rowNumber, row)
+}
+
+func (p *catalogRowParser) heading(row []string) (map[string]int, bool) {
+	aliases := catalogColumns[p.kind]
+	columns := make(map[string]int, len(row))
+	for position, cell := range row {
+		name := normalizeCatalogHeading(cell)
+		if field, ok := aliases[name]; ok {
+			columns[field] = position
+			continue
+		}
+		if p.kind != referencecatalog.KindStateTexts {
+			continue
+		}
+		if match := catalogStateColumn.FindStringSubmatch(name); match != nil {
+			if state, _ := strconv.Atoi(match[1]); state >= 1 && state <= domainFacility.StateTextSlots {
+				columns[domainFacility.StateTextField(state)] = position
+			}
+		}
+	}
+	_, hasKey := columns["key"]
+	return columns, hasKey
+}
+
+func (p *catalogRowParser) stateText(rowNumber int, row []string) {
+	key, ok := p.key(rowNumber, row)
+	if !ok {
+		return
+	}
+	var states [domainFacility.StateTextSlots]*string
+	for state := 1; state <= domainFacility.StateTextSlots; state++ {
+		if value := p.cell(row, domainFacility.StateTextField(state)); value != "" {
+			states[state-1] = &value
+		}
+	}
+	item := domainFacility.StateText{RefNumber: key}
+	item.SetStates(states)
+	p.catalog.StateTexts = append(p.catalog.StateTexts, item)
+}
+
+func (p *catalogRowParser) notificationClass(rowNumber int, row []string) {
+	key, ok := p.key(rowNumber, row)
+	if !ok {
+		return
+	}
+	item := domainFacility.NotificationClass{
+		Nc:                  key,
+		EventCategory:       p.cell(row, "event_category"),
+		ObjectDescription:   p.cell(row, "object_description"),
+		InternalDescription: p.cell(row, "internal_description"),
+		Meaning:             p.cell(row, "meaning"),
+	}
+	valid := true
+	for _, flag := range []struct {
+		field  string
+		target *bool
+	}{
+		{"ack_required_not_normal", &item.AckRequiredNotNormal},
+		{"ack_required_error", &item.AckRequiredError},
+		{"ack_required_normal", &item.AckRequiredNormal},
+	} {
+		value, ok := parseCatalogBool(p.cell(row, flag.field))
+		if !ok {
+			p.issue(rowNumber, &key, flag.field, "invalid_value", "expected yes or no")
+			valid = false
+		}
+		*flag.target = value
+	}
+	for _, priority := range []struct {
+		field  string
+		target *int
+	}{
+		{"norm_not_normal", &item.NormNotNormal},
+		{"norm_error", &item.NormError},
+		{"norm_normal", &item.NormNormal},
+	} {
+		value, ok := parseCatalogInt(p.cell(row, priority.field))
+		if !ok {
+			p.issue(rowNumber, &key, priority.field, "invalid_value", "expected a whole number")
+			valid = false
+		}
+		*priority.target = value
+	}
+	if valid {
+		p.catalog.NotificationClasses = append(p.catalog.NotificationClasses, item)
+	}
+}
+
+func (p *catalogRowParser) key(rowNumber int, row []string) (int, bool) {
+	raw := p.cell(row, "key")
+	if raw == "" {
+		p.issue(rowNumber, nil, "key", "missing_key", "the row has no key")
+		return 0, false
+	}
+	key, err := strconv.Atoi(raw)
+	if err != nil {
+		p.issue(rowNumber, nil, "key", "invalid_value", fmt.Sprintf("key %q is not a whole number", raw))
+		return 0, false
+	}
+	return key, true
+}
+
+func (p *catalogRowParser) cell(row []string, field string) string {
+	position, ok := p.columns[field]
+	if !ok || position >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[position])
+}
+
+func (p *catalogRowParser) issue(rowNumber int, key *int, field, code, message string) {
+	p.catalog.Issues = append(p.catalog.Issues, referencecatalog.Issue{Code: code, Row: rowNumber, Key: key, Field: field, Message: message})
+}
+
+func positionalStateTextColumns() map[string]int {
+	columns := map[string]int{"key": 0}
+	for state := 1; state <= domainFacility.StateTextSlots; state++ {
+		columns[domainFacility.StateTextField(state)] = state
+	}
+	return columns
+}
+
+func normalizeCatalogHeading(value string) string {
+	var out strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+func catalogRowBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCatalogBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "1", "yes", "y", "x", "ja", "j":
+		return true, true
+	case "false", "0", "no", "n", "nein", "":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func parseCatalogInt(value string) (int, bool) {
+	if value == "" {
+		return 0, true
+	}
+	parsed, err := strconv.Atoi(value)
+	return parsed, err == nil
+}
@@ -0,0 +1,80 @@
+package importing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	referencecatalog "github.com/besart951/go_infra_link/backend/internal/application/referencecatalog"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	exporting "github.com/besart951/go_infra_link/backend/internal/infrastructure/exporting"
+)
+
+const stateTextTemplateFixture = "#State-Text-Table - B.I.G.-EU\r\n" +
+	"#Reference-Number;Text 1;Text 2;Text 3\r\n" +
+	"1;Aus;Ein;\r\n" +
+	"2;Zu;Offen;Halb\r\n" +
+	"x;Low;High\r\n"
+
+func TestCatalogReaderReadsVendorStateTextTemplate(t *testing.T) {
+	catalog, err := NewCatalogReader().ReadCatalog(t.Context(), referencecatalog.KindStateTexts, referencecatalog.FormatCSV, strings.NewReader(stateTextTemplateFixture))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.StateTexts) != 2 {
+		t.Fatalf("state texts = %+v", catalog.StateTexts)
+	}
+	second := catalog.StateTexts[1]
+	if second.RefNumber != 2 || second.DefinedStates() != 3 || *second.StateText3 != "Halb" || catalog.StateTexts[0].StateText3 != nil {
+		t.Fatalf("state texts = %+v", catalog.StateTexts)
+	}
+	if len(catalog.Issues) != 1 || catalog.Issues[0].Code != "invalid_value" || catalog.Issues[0].Row != 5 {
+		t.Fatalf("issues = %+v", catalog.Issues)
+	}
+}
+
+func TestCatalogReaderReadsNotificationClassHeadings(t *testing.T) {
+	source := "NC,Event Category,Meaning,Ack Required To Offnormal,Priority To Offnormal\n" +
+		"40,Alarm,Fire,yes,12\n" +
+		"41,Fault,Maintenance,maybe,x\n"
+
+	catalog, err := NewCatalogReader().ReadCatalog(t.Context(), referencecatalog.KindNotificationClasses, referencecatalog.FormatCSV, strings.NewReader(source))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.NotificationClasses) != 1 {
+		t.Fatalf("notification classes = %+v", catalog.NotificationClasses)
+	}
+	item := catalog.NotificationClasses[0]
+	if item.Nc != 40 || item.EventCategory != "Alarm" || item.Meaning != "Fire" || !item.AckRequiredNotNormal || item.NormNotNormal != 12 {
+		t.Fatalf("notification class = %+v", item)
+	}
+	if len(catalog.Issues) != 2 || catalog.Issues[0].Field != "ack_required_not_normal" || catalog.Issues[1].Field != "norm_not_normal" {
+		t.Fatalf("issues = %+v", catalog.Issues)
+	}
+}
+
+func TestCatalogReaderReadsExportedWorkbook(t *testing.T) {
+	off, on := "Off", "On"
+	exported := referencecatalog.Catalog{Kind: referencecatalog.KindStateTexts, StateTexts: []domainFacility.StateText{
+		{RefNumber: 7, StateText1: &off, StateText2: &on},
+	}}
+	var workbook bytes.Buffer
+	if err := exporting.NewCatalogWriter().WriteCatalog(t.Context(), &workbook, referencecatalog.FormatXLSX, exported); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := NewCatalogReader().ReadCatalog(t.Context(), referencecatalog.KindStateTexts, referencecatalog.FormatXLSX, &workbook)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.Issues) != 0 || len(catalog.StateTexts) != 1 {
+		t.Fatalf("catalog = %+v", catalog)
+	}
+	if item := catalog.StateTexts[0]; item.RefNumber != 7 || *item.StateText2 != "On" || item.DefinedStates() != 2 {
+		t.Fatalf("state text = %+v", item)
+	}
+}
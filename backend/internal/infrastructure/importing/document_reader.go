@@ -0,0 +1,287 @@
+package importing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+)
+
+// DocumentReader ingests the JSON and NDJSON facility exports. Both forms are
+// streamed: a JSON document is an object with "manifest" and "records"
+// members, an NDJSON file a sequence of records opened by the manifest.
+// Building, cabinet, controller and system type records only give the
+// document context and are not staged.
+type DocumentReader struct{}
+
+func NewDocumentReader() DocumentReader { return DocumentReader{} }
+
+func (DocumentReader) Read(ctx context.Context, source io.Reader, sink fielddeviceimport.Sink) (fielddeviceimport.Manifest, error) {
+	decoder := json.NewDecoder(source)
+	batches := &documentBatches{sink: sink}
+	state := &documentState{batches: batches}
+	if err := expectDelim(decoder, '{'); err != nil {
+		return fielddeviceimport.Manifest{}, err
+	}
+	if err := state.readObject(ctx, decoder); err != nil {
+		return state.result(), err
+	}
+	if state.ndjson {
+		if err := state.readRecordStream(ctx, decoder); err != nil {
+			return state.result(), err
+		}
+	}
+	if state.manifest == nil {
+		return fielddeviceimport.Manifest{}, errors.New("document has no manifest")
+	}
+	return state.result(), batches.flush(ctx)
+}
+
+type documentState struct {
+	batches  *documentBatches
+	manifest *domainExport.DocumentManifest
+	first    domainExport.DocumentRecord
+	ndjson   bool
+}
+
+// readObject walks the members of the first top-level object. Members "type"
+// and "data" mean the object is the first NDJSON record.
+func (s *documentState) readObject(ctx context.Context, decoder *json.Decoder) error {
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if err := s.readMember(ctx, decoder, key); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	if !s.ndjson {
+		return nil
+	}
+	return s.consume(ctx, s.first)
+}
+
+func (s *documentState) readMember(ctx context.Context, decoder *json.Decoder, key json.Token) error {
+	switch key {
+	case "manifest":
+		var manifest domainExport.DocumentManifest
+		if err := decoder.Decode(&manifest); err != nil {
+			return fmt.Errorf("decode manifest: %w", err)
+		}
+		return s.setManifest(manifest)
+	case "records":
+		return s.readRecordArray(ctx, decoder)
+	case "type":
+		s.ndjson = true
+		return decoder.Decode(&s.first.Type)
+	case "data":
+		s.ndjson = true
+		return decoder.Decode(&s.first.Data)
+	default:
+		var skipped json.RawMessage
+		return decoder.Decode(&skipped)
+	}
+}
+
+func (s *documentState) readRecordArray(ctx context.Context, decoder *json.Decoder) error {
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+	for decoder.More() {
+		var record domainExport.DocumentRecord
+		if err := decoder.Decode(&record); err != nil {
+			return fmt.Errorf("decode record: %w", err)
+		}
+		if err := s.consume(ctx, record); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+func (s *documentState) readRecordStream(ctx context.Context, decoder *json.Decoder) error {
+	for {
+		var record domainExport.DocumentRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decode record: %w", err)
+		}
+		if err := s.consume(ctx, record); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *documentState) consume(ctx context.Context, record domainExport.DocumentRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if record.Type == domainExport.DocumentRecordManifest {
+		var manifest domainExport.DocumentManifest
+		if err := json.Unmarshal(record.Data, &manifest); err != nil {
+			return fmt.Errorf("decode manifest: %w", err)
+		}
+		return s.setManifest(manifest)
+	}
+	if s.manifest == nil {
+		return errors.New("document records must follow the manifest")
+	}
+	if err := s.batches.add(record); err != nil {
+		return err
+	}
+	return s.batches.flushFull(ctx)
+}
+
+func (s *documentState) setManifest(manifest domainExport.DocumentManifest) error {
+	if s.manifest != nil {
+		return errors.New("document has more than one manifest")
+	}
+	if manifest.Format != domainExport.DocumentFormat {
+		return fmt.Errorf("unsupported document format %q", manifest.Format)
+	}
+	if manifest.FormatVersion != domainExport.DocumentVersion {
+		return fmt.Errorf("unsupported document format version %d", manifest.FormatVersion)
+	}
+	s.manifest = &manifest
+	return nil
+}
+
+func (s *documentState) result() fielddeviceimport.Manifest {
+	if s.manifest == nil {
+		return fielddeviceimport.Manifest{}
+	}
+	counts := s.manifest.Counts
+	return fielddeviceimport.Manifest{
+		SchemaVersion: s.manifest.SchemaVersion, SnapshotAt: s.manifest.SnapshotAt,
+		Scope: string(s.manifest.Scope), DeviceCount: s.manifest.DeviceCount,
+		Counts: fielddeviceimport.Counts{
+			Specifications: counts.Specifications, BacnetObjects: counts.BacnetObjects,
+			SoftwareReferences: counts.SoftwareReferences, AlarmValues: counts.AlarmValues,
+		},
+	}
+}
+
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != want {
+		return fmt.Errorf("expected %q in document, found %v", want, token)
+	}
+	return nil
+}
+
+// documentBatches collects decoded rows per kind and hands them to the sink
+// in batches of importBatchSize, like the workbook scanners do.
+type documentBatches struct {
+	sink       fielddeviceimport.Sink
+	devices    []domainFacility.FieldDevice
+	specs      []domainFacility.Specification
+	objects    []domainFacility.BacnetObject
+	references []fielddeviceimport.SoftwareReference
+	alarms     []domainFacility.BacnetObjectAlarmValue
+}
+
+func (b *documentBatches) add(record domainExport.DocumentRecord) error {
+	switch record.Type {
+	case domainExport.DocumentRecordBuilding, domainExport.DocumentRecordControlCabinet, domainExport.DocumentRecordSPSController,
+		domainExport.DocumentRecordSystemType:
+		return nil
+	case domainExport.DocumentRecordFieldDevice:
+		return decodeDocumentRow(record, func(row domainExport.DocumentFieldDevice) { b.devices = append(b.devices, row.FieldDevice()) })
+	case domainExport.DocumentRecordSpecification:
+		return decodeDocumentRow(record, func(row domainExport.DocumentSpecification) { b.specs = append(b.specs, row.Specification()) })
+	case domainExport.DocumentRecordBacnetObject:
+		return decodeDocumentRow(record, func(row domainExport.DocumentBacnetObject) { b.objects = append(b.objects, row.BacnetObject()) })
+	case domainExport.DocumentRecordSoftwareReference:
+		return decodeDocumentRow(record, func(row domainExport.DocumentSoftwareReference) {
+			b.references = append(b.references, fielddeviceimport.SoftwareReference(row))
+		})
+	case domainExport.DocumentRecordAlarmValue:
+		return decodeDocumentRow(record, func(row domainExport.DocumentAlarmValue) { b.alarms = append(b.alarms, row.AlarmValue()) })
+	default:
+		return fmt.Errorf("unsupported document record type %q", record.Type)
+	}
+}
+
+func decodeDocumentRow[T any](record domainExport.DocumentRecord, collect func(T)) error {
+	var row T
+	if err := json.Unmarshal(record.Data, &row); err != nil {
+		return fmt.Errorf("decode %s record: %w", record.Type, err)
+	}
+	collect(row)
+	return nil
+}
+
+// flushFull hands over every batch that reached importBatchSize; flush hands
+// over everything left at the end of the document.
+func (b *documentBatches) flushFull(ctx context.Context) error {
+	return b.flushAbove(ctx, importBatchSize)
+}
+
+func (b *documentBatches) flush(ctx context.Context) error {
+	return b.flushAbove(ctx, 1)
+}
+
+func (b *documentBatches) flushAbove(ctx context.Context, minimum int) error {
+	return errors.Join(
+		flushDocumentBatch(ctx, &b.devices, minimum, b.sink.FieldDevices),
+		flushDocumentBatch(ctx, &b.specs, minimum, b.sink.Specifications),
+		flushDocumentBatch(ctx, &b.objects, minimum, b.sink.BacnetObjects),
+		flushDocumentBatch(ctx, &b.references, minimum, b.sink.SoftwareReferences),
+		flushDocumentBatch(ctx, &b.alarms, minimum, b.sink.AlarmValues),
+	)
+}
+
+func flushDocumentBatch[T any](ctx context.Context, batch *[]T, minimum int, write func(context.Context, []T) error) error {
+	if len(*batch) < minimum {
+		return nil
+	}
+	err := write(ctx, *batch)
+	*batch = nil
+	return err
+}
+
+// FormatReader dispatches an upload to the document reader when it starts
+// with a JSON object and to the workbook reader otherwise.
+type FormatReader struct {
+	workbook fielddeviceimport.WorkbookReader
+	document fielddeviceimport.WorkbookReader
+}
+
+func NewFormatReader(workbook, document fielddeviceimport.WorkbookReader) FormatReader {
+	return FormatReader{workbook: workbook, document: document}
+}
+
+func (r FormatReader) Read(ctx context.Context, source io.Reader, sink fielddeviceimport.Sink) (fielddeviceimport.Manifest, error) {
+	buffered := bufio.NewReader(source)
+	for {
+		next, err := buffered.Peek(1)
+		if err != nil || !isJSONWhitespace(next[0]) {
+			break
+		}
+		_, _ = buffered.ReadByte()
+	}
+	if next, err := buffered.Peek(1); err == nil && next[0] == '{' {
+		return r.document.Read(ctx, buffered, sink)
+	}
+	return r.workbook.Read(ctx, buffered, sink)
+}
+
+func isJSONWhitespace(value byte) bool {
+	return value == ' ' || value == '\t' || value == '\n' || value == '\r'
+}
@@ -0,0 +1,104 @@
+package importing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	"github.com/google/uuid"
+)
+
+func TestFormatReaderStagesJSONAndNDJSONDocuments(t *testing.T) {
+	manifest, records := newDocumentFixture(t, domainExport.DocumentVersion)
+	for name, document := range map[string][]byte{
+		"json":   encodeJSONDocument(t, manifest, records),
+		"ndjson": encodeNDJSONDocument(t, manifest, records),
+	} {
+		t.Run(name, func(t *testing.T) {
+			sink := &captureSink{}
+			reader := NewFormatReader(NewArchiveReader(NewExcelizeReader()), NewDocumentReader())
+
+			result, err := reader.Read(context.Background(), bytes.NewReader(document), sink)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.SchemaVersion != 2 || result.DeviceCount != 1 || result.Counts.BacnetObjects != 2 || result.Scope != "global" {
+				t.Fatalf("manifest = %+v", result)
+			}
+			if len(sink.devices) != 1 || len(sink.specs) != 1 || len(sink.objects) != 2 || len(sink.references) != 1 || len(sink.alarms) != 1 {
+				t.Fatalf("unexpected rows: devices=%d specs=%d objects=%d refs=%d alarms=%d", len(sink.devices), len(sink.specs), len(sink.objects), len(sink.references), len(sink.alarms))
+			}
+			if sink.objects[0].SoftwareReferenceID == nil || *sink.objects[0].SoftwareReferenceID != sink.objects[1].ID {
+				t.Fatalf("software reference was not preserved: %+v", sink.objects[0])
+			}
+		})
+	}
+}
+
+func TestDocumentReaderRejectsUnknownFormatVersion(t *testing.T) {
+	manifest, records := newDocumentFixture(t, domainExport.DocumentVersion+1)
+
+	_, err := NewDocumentReader().Read(context.Background(), bytes.NewReader(encodeNDJSONDocument(t, manifest, records)), &captureSink{})
+
+	if err == nil || !strings.Contains(err.Error(), "format version") {
+		t.Fatalf("error = %v, want unsupported format version", err)
+	}
+}
+
+func newDocumentFixture(t *testing.T, formatVersion int) (domainExport.DocumentManifest, []domainExport.DocumentRecord) {
+	t.Helper()
+	deviceID, objectID, targetID := uuid.New(), uuid.New(), uuid.New()
+	manifest := domainExport.DocumentManifest{
+		Format: domainExport.DocumentFormat, FormatVersion: formatVersion, SchemaVersion: 2,
+		SnapshotAt: time.Unix(1_700_000_000, 0).UTC(), Scope: domainExport.AccessScopeGlobal, DeviceCount: 1,
+		Counts: domainExport.DocumentCounts{FieldDevices: 1, Specifications: 1, BacnetObjects: 2, SoftwareReferences: 1, AlarmValues: 1},
+	}
+	limit := 12.5
+	payloads := []struct {
+		recordType domainExport.DocumentRecordType
+		data       any
+	}{
+		{domainExport.DocumentRecordBuilding, domainExport.DocumentBuilding{ID: uuid.New(), IWSCode: "ABCD", BuildingGroup: 1}},
+		{domainExport.DocumentRecordFieldDevice, domainExport.DocumentFieldDevice{ID: deviceID, Version: 3, ApparatNr: 7, SPSControllerSystemTypeID: uuid.New()}},
+		{domainExport.DocumentRecordSpecification, domainExport.DocumentSpecification{ID: uuid.New(), FieldDeviceID: deviceID, Version: 2}},
+		{domainExport.DocumentRecordBacnetObject, domainExport.DocumentBacnetObject{ID: objectID, FieldDeviceID: deviceID, TextFix: "Object", SoftwareReferenceID: &targetID}},
+		{domainExport.DocumentRecordBacnetObject, domainExport.DocumentBacnetObject{ID: targetID, FieldDeviceID: deviceID, TextFix: "Target"}},
+		{domainExport.DocumentRecordSoftwareReference, domainExport.DocumentSoftwareReference{SourceObjectID: objectID, TargetObjectID: targetID, FieldDeviceID: deviceID}},
+		{domainExport.DocumentRecordAlarmValue, domainExport.DocumentAlarmValue{ID: uuid.New(), BacnetObjectID: objectID, AlarmTypeFieldID: uuid.New(), ValueNumber: &limit, Source: "import"}},
+	}
+	records := make([]domainExport.DocumentRecord, len(payloads))
+	for index, payload := range payloads {
+		records[index] = domainExport.DocumentRecord{Type: payload.recordType, Data: mustMarshal(t, payload.data)}
+	}
+	return manifest, records
+}
+
+func encodeJSONDocument(t *testing.T, manifest domainExport.DocumentManifest, records []domainExport.DocumentRecord) []byte {
+	t.Helper()
+	return mustMarshal(t, map[string]any{"manifest": manifest, "records": records})
+}
+
+func encodeNDJSONDocument(t *testing.T, manifest domainExport.DocumentManifest, records []domainExport.DocumentRecord) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	lines := append([]domainExport.DocumentRecord{{Type: domainExport.DocumentRecordManifest, Data: mustMarshal(t, manifest)}}, records...)
+	for _, line := range lines {
+		buffer.Write(mustMarshal(t, line))
+		buffer.WriteByte('\n')
+	}
+	return buffer.Bytes()
+}
+
+func mustMarshal(t *testing.T, value any) []byte {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,394 @@
+package importing
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+const (
+	edeCatalogPageSize = 1000
+	edeDeviceType      = 8
+	edeScope           = "ede"
+	edeTimestampLayout = "2006-01-02 15:04:05"
+)
+
+// edeSoftwareTypes maps BACnet object type enumerations back to software types.
+var edeSoftwareTypes = map[int]domainFacility.BacnetSoftwareType{
+	0: domainFacility.BacnetSoftwareTypeAI, 1: domainFacility.BacnetSoftwareTypeAO,
+	2: domainFacility.BacnetSoftwareTypeAV, 3: domainFacility.BacnetSoftwareTypeBI,
+	4: domainFacility.BacnetSoftwareTypeBO, 5: domainFacility.BacnetSoftwareTypeBV,
+	6: domainFacility.BacnetSoftwareTypeCA, 9: domainFacility.BacnetSoftwareTypeEE,
+	12: domainFacility.BacnetSoftwareTypeLP, 13: domainFacility.BacnetSoftwareTypeMI,
+	14: domainFacility.BacnetSoftwareTypeMO, 15: domainFacility.BacnetSoftwareTypeNC,
+	17: domainFacility.BacnetSoftwareTypeSC, 19: domainFacility.BacnetSoftwareTypeMV,
+	20: domainFacility.BacnetSoftwareTypeTL,
+}
+
+// EDE object list columns, in the order of the B.I.G.-EU layout.
+const (
+	edeColumnKeyName = iota
+	edeColumnDeviceInstance
+	edeColumnObjectName
+	edeColumnObjectType
+	edeColumnObjectInstance
+	edeColumnDescription
+	edeColumnStateText = 13
+	edeColumnNC        = 16
+)
+
+type CatalogLister[T any] interface {
+	List(ctx context.Context, page, limit int, search string) (*domain.PaginatedList[T], error)
+}
+
+// EDECatalog resolves the short names and reference numbers used in EDE point
+// lists against the shared reference data.
+type EDECatalog struct {
+	SystemParts         CatalogLister[domainFacility.SystemPart]
+	Apparats            CatalogLister[domainFacility.Apparat]
+	StateTexts          CatalogLister[domainFacility.StateText]
+	NotificationClasses CatalogLister[domainFacility.NotificationClass]
+}
+
+func (c EDECatalog) EDEReader(ctx context.Context, spsControllerSystemTypeID uuid.UUID) (fielddeviceimport.WorkbookReader, error) {
+	if spsControllerSystemTypeID == uuid.Nil {
+		return nil, domain.ErrInvalidArgument
+	}
+	references, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return EDEReader{systemTypeID: spsControllerSystemTypeID, references: references}, nil
+}
+
+func (c EDECatalog) load(ctx context.Context) (edeReferences, error) {
+	var references edeReferences
+	var err error
+	if references.systemParts, err = listKeyed(ctx, c.SystemParts, func(item domainFacility.SystemPart) (string, uuid.UUID) {
+		return strings.ToUpper(item.ShortName), item.ID
+	}); err != nil {
+		return references, err
+	}
+	if references.apparats, err = listKeyed(ctx, c.Apparats, func(item domainFacility.Apparat) (string, uuid.UUID) {
+		return strings.ToUpper(item.ShortName), item.ID
+	}); err != nil {
+		return references, err
+	}
+	if references.stateTexts, err = listKeyed(ctx, c.StateTexts, func(item domainFacility.StateText) (int, uuid.UUID) {
+		return item.RefNumber, item.ID
+	}); err != nil {
+		return references, err
+	}
+	references.notificationClasses, err = listKeyed(ctx, c.NotificationClasses, func(item domainFacility.NotificationClass) (int, uuid.UUID) {
+		return item.Nc, item.ID
+	})
+	return references, err
+}
+
+func listKeyed[T any, K comparable](ctx context.Context, source CatalogLister[T], key func(T) (K, uuid.UUID)) (map[K]uuid.UUID, error) {
+	items := make(map[K]uuid.UUID)
+	for page := 1; ; page++ {
+		result, err := source.List(ctx, page, edeCatalogPageSize, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			value, id := key(item)
+			items[value] = id
+		}
+		if page >= result.TotalPages || len(result.Items) < edeCatalogPageSize {
+			return items, nil
+		}
+	}
+}
+
+type edeReferences struct {
+	systemParts         map[string]uuid.UUID
+	apparats            map[string]uuid.UUID
+	stateTexts          map[int]uuid.UUID
+	notificationClasses map[int]uuid.UUID
+}
+
+// edeDesignation is the system part and apparat encoded in an object name
+// segment such as "LÜVE01".
+type edeDesignation struct {
+	shortName    string
+	systemPartID uuid.UUID
+	apparatID    uuid.UUID
+	apparatNr    int
+}
+
+// resolve tries every split of the concatenated short names, since neither
+// catalog uses a fixed width.
+func (r edeReferences) resolve(shortName string) ([]edeDesignation, bool) {
+	matches := make([]edeDesignation, 0, 1)
+	upper := strings.ToUpper(shortName)
+	for index := range upper {
+		if index == 0 {
+			continue
+		}
+		systemPartID, systemPartOK := r.systemParts[upper[:index]]
+		apparatID, apparatOK := r.apparats[upper[index:]]
+		if systemPartOK && apparatOK {
+			matches = append(matches, edeDesignation{shortName: shortName, systemPartID: systemPartID, apparatID: apparatID})
+		}
+	}
+	return matches, len(matches) == 1
+}
+
+// EDEReader stages the object rows of one EDE point list as new field devices
+// under a single controller system type.
+type EDEReader struct {
+	systemTypeID uuid.UUID
+	references   edeReferences
+}
+
+func (r EDEReader) Read(ctx context.Context, source io.Reader, sink fielddeviceimport.Sink) (fielddeviceimport.Manifest, error) {
+	records := csv.NewReader(source)
+	records.Comma = ';'
+	records.FieldsPerRecord = -1
+	records.LazyQuotes = true
+	state := newEDEImport(r)
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fielddeviceimport.Manifest{}, err
+		}
+		line, _ := records.FieldPos(0)
+		state.consume(record, line)
+	}
+	if err := state.flush(ctx, sink); err != nil {
+		return fielddeviceimport.Manifest{}, err
+	}
+	return state.manifest(), nil
+}
+
+type edeDeviceKey struct {
+	systemPartID uuid.UUID
+	apparatID    uuid.UUID
+	apparatNr    int
+}
+
+type edeObjectKey struct {
+	deviceID     uuid.UUID
+	softwareType domainFacility.BacnetSoftwareType
+	number       uint16
+}
+
+type edeImport struct {
+	reader    EDEReader
+	now       time.Time
+	timestamp time.Time
+	devices   []domainFacility.FieldDevice
+	deviceIDs map[edeDeviceKey]uuid.UUID
+	objects   []domainFacility.BacnetObject
+	objectIDs map[edeObjectKey]struct{}
+	issues    []fielddeviceimport.Issue
+}
+
+func newEDEImport(reader EDEReader) *edeImport {
+	return &edeImport{
+		reader: reader, now: time.Now().UTC(),
+		deviceIDs: make(map[edeDeviceKey]uuid.UUID), objectIDs: make(map[edeObjectKey]struct{}),
+	}
+}
+
+func (s *edeImport) consume(record []string, line int) {
+	first := strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff"))
+	if first == "" || strings.HasPrefix(first, "#") {
+		return
+	}
+	if first == "TIMESTAMP_OF_LAST_CHANGE" && len(record) > 1 {
+		s.timestamp, _ = time.Parse(edeTimestampLayout, strings.TrimSpace(record[1]))
+		return
+	}
+	if len(record) <= edeColumnDescription {
+		return
+	}
+	objectType, err := strconv.Atoi(strings.TrimSpace(record[edeColumnObjectType]))
+	if err != nil {
+		s.issue(line, "invalid_object_type", "object-type", "object type is not a number")
+		return
+	}
+	if objectType != edeDeviceType {
+		s.consumeObject(record, line, objectType)
+	}
+}
+
+func (s *edeImport) consumeObject(record []string, line int, objectType int) {
+	softwareType, ok := edeSoftwareTypes[objectType]
+	if !ok {
+		s.issue(line, "unsupported_object_type", "object-type", fmt.Sprintf("object type %d is not supported", objectType))
+		return
+	}
+	number, err := parseUint16(strings.TrimSpace(record[edeColumnObjectInstance]))
+	if err != nil {
+		s.issue(line, "invalid_object_instance", "object-instance", "object instance must fit a software number")
+		return
+	}
+	designation, ok := s.designation(record, line)
+	if !ok {
+		return
+	}
+	object, ok := s.bacnetObject(record, line, designation)
+	if !ok {
+		return
+	}
+	object.SoftwareType, object.SoftwareNumber = softwareType, number
+	deviceID := s.deviceID(designation)
+	key := edeObjectKey{deviceID: deviceID, softwareType: softwareType, number: number}
+	if _, exists := s.objectIDs[key]; exists {
+		s.issue(line, "duplicate_object", "object-instance", "object occurs more than once for the same field device")
+		return
+	}
+	s.objectIDs[key] = struct{}{}
+	object.FieldDeviceID = &deviceID
+	s.objects = append(s.objects, object)
+}
+
+func (s *edeImport) designation(record []string, line int) (edeDesignation, bool) {
+	name := strings.TrimSpace(record[edeColumnObjectName])
+	if name == "" {
+		name = strings.TrimSpace(record[edeColumnKeyName])
+	}
+	shortName, apparatNr, ok := splitEDEObjectName(name)
+	if !ok {
+		s.issue(line, "invalid_object_name", "object-name", fmt.Sprintf("object name %q has no system part and apparat segment", name))
+		return edeDesignation{}, false
+	}
+	matches, ok := s.reader.references.resolve(shortName)
+	switch {
+	case len(matches) == 0:
+		s.issue(line, "unresolved_designation", "object-name", fmt.Sprintf("%q matches no system part and apparat short names", shortName))
+	case !ok:
+		s.issue(line, "ambiguous_designation", "object-name", fmt.Sprintf("%q matches more than one system part and apparat", shortName))
+	}
+	if !ok {
+		return edeDesignation{}, false
+	}
+	matches[0].apparatNr = apparatNr
+	return matches[0], true
+}
+
+// splitEDEObjectName extracts the short-name segment and apparat number from
+// names built as <controller>_<system part><apparat><nr>_<address>.
+func splitEDEObjectName(name string) (string, int, bool) {
+	segments := strings.Split(name, "_")
+	if len(segments) < 2 {
+		return "", 0, false
+	}
+	segment := segments[len(segments)-2]
+	shortName := strings.TrimRightFunc(segment, unicode.IsDigit)
+	if shortName == "" || shortName == segment {
+		return "", 0, false
+	}
+	apparatNr, err := strconv.Atoi(segment[len(shortName):])
+	return shortName, apparatNr, err == nil
+}
+
+func (s *edeImport) bacnetObject(record []string, line int, designation edeDesignation) (domainFacility.BacnetObject, bool) {
+	var object domainFacility.BacnetObject
+	object.TextFix = edeTextFix(record[edeColumnDescription], designation.shortName)
+	if object.TextFix == "" {
+		s.issue(line, "missing_text_fix", "description", "description has no text after the designation")
+		return object, false
+	}
+	var ok bool
+	if object.StateTextID, ok = s.lookup(record, edeColumnStateText, s.reader.references.stateTexts); !ok {
+		s.issue(line, "unresolved_state_text", "state-text-reference", "state text reference does not exist")
+		return object, false
+	}
+	if object.NotificationClassID, ok = s.lookup(record, edeColumnNC, s.reader.references.notificationClasses); !ok {
+		s.issue(line, "unresolved_notification_class", "notification-class", "notification class does not exist")
+		return object, false
+	}
+	if err := object.InitForCreate(s.now); err != nil {
+		s.issue(line, "invalid_row", "object-name", err.Error())
+		return object, false
+	}
+	return object, true
+}
+
+// edeTextFix reverses the export description "<names> - <short> <text fix>".
+func edeTextFix(description, shortName string) string {
+	description = strings.TrimSpace(description)
+	marker := " - " + shortName
+	if index := strings.Index(description, marker); index >= 0 {
+		return strings.TrimSpace(description[index+len(marker):])
+	}
+	return description
+}
+
+func (s *edeImport) lookup(record []string, column int, references map[int]uuid.UUID) (*uuid.UUID, bool) {
+	if column >= len(record) || strings.TrimSpace(record[column]) == "" {
+		return nil, true
+	}
+	number, err := strconv.Atoi(strings.TrimSpace(record[column]))
+	if err != nil {
+		return nil, false
+	}
+	id, ok := references[number]
+	if !ok {
+		return nil, false
+	}
+	return &id, true
+}
+
+func (s *edeImport) deviceID(designation edeDesignation) uuid.UUID {
+	key := edeDeviceKey{systemPartID: designation.systemPartID, apparatID: designation.apparatID, apparatNr: designation.apparatNr}
+	if id, ok := s.deviceIDs[key]; ok {
+		return id
+	}
+	device := domainFacility.FieldDevice{
+		SPSControllerSystemTypeID: s.reader.systemTypeID, SystemPartID: key.systemPartID,
+		ApparatID: key.apparatID, ApparatNr: key.apparatNr,
+	}
+	_ = device.InitForCreate(s.now)
+	s.devices = append(s.devices, device)
+	s.deviceIDs[key] = device.ID
+	return device.ID
+}
+
+func (s *edeImport) issue(line int, code, field, message string) {
+	s.issues = append(s.issues, fielddeviceimport.Issue{
+		Code: code, Entity: "bacnet_object", Field: field, Message: fmt.Sprintf("line %d: %s", line, message),
+	})
+}
+
+func (s *edeImport) flush(ctx context.Context, sink fielddeviceimport.Sink) error {
+	if err := writeBatches(ctx, s.devices, sink.FieldDevices); err != nil {
+		return err
+	}
+	return writeBatches(ctx, s.objects, sink.BacnetObjects)
+}
+
+func writeBatches[T any](ctx context.Context, values []T, write func(context.Context, []T) error) error {
+	for start := 0; start < len(values); start += importBatchSize {
+		end := min(start+importBatchSize, len(values))
+		if err := write(ctx, values[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *edeImport) manifest() fielddeviceimport.Manifest {
+	return fielddeviceimport.Manifest{
+		SchemaVersion: fielddeviceimport.SchemaVersion, SnapshotAt: s.timestamp, Scope: edeScope,
+		DeviceCount: int64(len(s.devices)), Counts: fielddeviceimport.Counts{BacnetObjects: int64(len(s.objects))},
+		Issues: s.issues,
+	}
+}
@@ -0,0 +1,66 @@
+package importing
+
+import (
+	"strings"
+	"testing"
+
+	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+const edeFixture = "#Engineering-Data-Exchange - B.I.G.-EU\r\n" +
+	"PROJECT_NAME;ABCD_1_0003_A\r\n" +
+	"TIMESTAMP_OF_LAST_CHANGE;2026-03-01 08:00:00\r\n" +
+	"#mandatory;mandatory;mandatory;mandatory;mandatory;optional\r\n" +
+	"# keyname;device obj.-instance;object-name;object-type;object-instance;description;;;;;;;;state-text-reference;;;notification-class\r\n" +
+	"ABCD_1_0003_A;CD001;ABCD_1_0003_A;8;CD001;;;;;;;;;;;;\r\n" +
+	"ABCD_1_0003_A_LÜVE01_BO07;CD001;ABCD_1_0003_A_LÜVE01_BO07;4;7;Lüftung Ventilator - LÜVE Betrieb;;;;Y;;;;12;;BO07;40\r\n" +
+	"ABCD_1_0003_A_LÜVE01_BI01;CD001;ABCD_1_0003_A_LÜVE01_BI01;3;1;Lüftung Ventilator - LÜVE Störung;;;;N;;;;;;BI01;\r\n" +
+	"ABCD_1_0003_A_XXYY02_AI01;CD001;ABCD_1_0003_A_XXYY02_AI01;0;1;Unknown - XXYY Temperatur;;;;N;;;;;;AI01;\r\n"
+
+func TestEDEReaderStagesObjectsUnderChosenSystemType(t *testing.T) {
+	systemTypeID, systemPartID, apparatID, stateTextID, ncID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	reader := EDEReader{systemTypeID: systemTypeID, references: edeReferences{
+		systemParts:         map[string]uuid.UUID{"LÜ": systemPartID},
+		apparats:            map[string]uuid.UUID{"VE": apparatID},
+		stateTexts:          map[int]uuid.UUID{12: stateTextID},
+		notificationClasses: map[int]uuid.UUID{40: ncID},
+	}}
+	sink := &captureSink{}
+
+	manifest, err := reader.Read(t.Context(), strings.NewReader(edeFixture), sink)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.SchemaVersion != fielddeviceimport.SchemaVersion || manifest.DeviceCount != 1 || manifest.Counts.BacnetObjects != 2 {
+		t.Fatalf("manifest = %+v", manifest)
+	}
+	if len(sink.devices) != 1 || len(sink.objects) != 2 {
+		t.Fatalf("unexpected rows: devices=%d objects=%d", len(sink.devices), len(sink.objects))
+	}
+	device := sink.devices[0]
+	if device.SPSControllerSystemTypeID != systemTypeID || device.SystemPartID != systemPartID || device.ApparatID != apparatID || device.ApparatNr != 1 {
+		t.Fatalf("device = %+v", device)
+	}
+	object := sink.objects[0]
+	if object.TextFix != "Betrieb" || object.SoftwareType != domainFacility.BacnetSoftwareTypeBO || object.SoftwareNumber != 7 {
+		t.Fatalf("object = %+v", object)
+	}
+	if object.FieldDeviceID == nil || *object.FieldDeviceID != device.ID || *object.StateTextID != stateTextID || *object.NotificationClassID != ncID {
+		t.Fatalf("object references = %+v", object)
+	}
+	if len(manifest.Issues) != 1 || manifest.Issues[0].Code != "unresolved_designation" {
+		t.Fatalf("issues = %+v", manifest.Issues)
+	}
+}
+
+func TestSplitEDEObjectNameRequiresApparatNumber(t *testing.T) {
+	if shortName, nr, ok := splitEDEObjectName("ABCD_1_0003_A_LÜVE12_BO07"); !ok || shortName != "LÜVE" || nr != 12 {
+		t.Fatalf("split = %q %d %v", shortName, nr, ok)
+	}
+	if _, _, ok := splitEDEObjectName("ABCD_1_0003_A_LÜVE_BO07"); ok {
+		t.Fatal("expected name without apparat number to be rejected")
+	}
+}
@@ -0,0 +1,345 @@
+package importing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+const controllerHeadingMarker = "BACnet Object Name"
+
+// idColumn is the hidden controller sheet column with the ID of the field
+// device or BACnet object a row shows.
+const idColumn = "ID"
+
+// specificationColumns maps the controller sheet headings to the specification
+// fields they edit, in the order the generator writes them.
+var specificationColumns = []string{
+	"Lieferant", "Fabrikat", "Typ", "Motor,Ventil, etc.", "Grösse", "Montageort",
+	"Ph", "AC/DC", "Stromstärke", "Leistung", "Drehzahl",
+}
+
+// EditReader reads a workbook written by ExcelizeGenerator.GenerateWorkbook
+// after it was edited offline. The Data-* sheets record the exported state;
+// the controller sheets carry the planner's edits and name the device or
+// object of each row in the hidden ID column.
+type EditReader struct{}
+
+func NewEditReader() EditReader { return EditReader{} }
+
+func (EditReader) ReadEdits(ctx context.Context, source io.Reader) (fielddeviceimport.EditSet, error) {
+	workbook, err := excelize.OpenReader(source)
+	if err != nil {
+		return fielddeviceimport.EditSet{}, err
+	}
+	defer workbook.Close()
+	manifest, err := readManifest(workbook)
+	if err != nil {
+		return fielddeviceimport.EditSet{}, err
+	}
+	exported, err := readExportedAggregates(workbook)
+	if err != nil {
+		return fielddeviceimport.EditSet{Manifest: manifest}, err
+	}
+	cursor := newEditCursor(exported)
+	for _, sheet := range controllerSheets(workbook) {
+		if err := ctx.Err(); err != nil {
+			return fielddeviceimport.EditSet{Manifest: manifest}, err
+		}
+		if err := scanControllerSheet(workbook, sheet, cursor.row); err != nil {
+			return fielddeviceimport.EditSet{Manifest: manifest}, err
+		}
+	}
+	if err := cursor.finish(); err != nil {
+		return fielddeviceimport.EditSet{Manifest: manifest}, err
+	}
+	return fielddeviceimport.EditSet{Manifest: manifest, Aggregates: cursor.aggregates, Issues: cursor.issues}, nil
+}
+
+func readExportedAggregates(file *excelize.File) ([]fielddeviceimport.Aggregate, error) {
+	aggregates := make([]fielddeviceimport.Aggregate, 0)
+	positions := make(map[uuid.UUID]int)
+	err := scanDataSheets(file, "Data-FieldDevices", func(row rowValues) error {
+		device, err := parseFieldDevice(row)
+		positions[device.ID] = len(aggregates)
+		aggregates = append(aggregates, fielddeviceimport.Aggregate{FieldDevice: device})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	owner := func(id *uuid.UUID) (*fielddeviceimport.Aggregate, error) {
+		position, ok := positions[*id]
+		if !ok {
+			return nil, fmt.Errorf("field device %s is not listed in Data-FieldDevices", id)
+		}
+		return &aggregates[position], nil
+	}
+	err = scanDataSheets(file, "Data-Specifications", func(row rowValues) error {
+		specification, err := parseSpecification(row)
+		if err != nil {
+			return err
+		}
+		aggregate, err := owner(specification.FieldDeviceID)
+		if err == nil {
+			aggregate.Specification = &specification
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aggregates, scanDataSheets(file, "Data-BACnetObjects", func(row rowValues) error {
+		object, err := parseBacnetObject(row)
+		if err != nil {
+			return err
+		}
+		aggregate, err := owner(object.FieldDeviceID)
+		if err == nil {
+			aggregate.BacnetObjects = append(aggregate.BacnetObjects, object)
+		}
+		return err
+	})
+}
+
+// scanDataSheets reads a machine sheet including the numbered continuation
+// sheets the generator adds once a sheet reaches the Excel row limit.
+func scanDataSheets(file *excelize.File, base string, consume func(rowValues) error) error {
+	for part := 1; ; part++ {
+		name := base
+		if part > 1 {
+			name = fmt.Sprintf("%s-%d", base, part)
+			if index, err := file.GetSheetIndex(name); err != nil || index < 0 {
+				return err
+			}
+		}
+		if err := scanRows(file, name, consume); err != nil {
+			return err
+		}
+	}
+}
+
+func controllerSheets(file *excelize.File) []string {
+	sheets := make([]string, 0)
+	for _, name := range file.GetSheetList() {
+		if name != "Export-Manifest" && !strings.HasPrefix(name, "Data-") {
+			sheets = append(sheets, name)
+		}
+	}
+	return sheets
+}
+
+type editRow struct {
+	rowValues
+	sheet  string
+	number int
+}
+
+func (r editRow) location() string {
+	return fmt.Sprintf("sheet %s row %d", r.sheet, r.number)
+}
+
+// scanControllerSheet skips the controller heading block and passes every
+// non-empty row below the column headings to consume.
+func scanControllerSheet(file *excelize.File, sheet string, consume func(editRow) error) error {
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		return fmt.Errorf("open sheet %s: %w", sheet, err)
+	}
+	defer rows.Close()
+	var index map[string]int
+	for number := 1; rows.Next(); number++ {
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		row := editRow{rowValues: rowValues{columns: columns, index: index}, sheet: sheet, number: number}
+		switch {
+		case index == nil && row.at(0) == controllerHeadingMarker:
+			index = headingIndex(columns)
+		case index != nil && strings.TrimSpace(strings.Join(columns, "")) != "":
+			if err := consume(row); err != nil {
+				return err
+			}
+		}
+	}
+	return rows.Error()
+}
+
+// editCursor pairs controller rows with the exported aggregates by the hidden
+// ID column, so rows may be sorted or moved. Every exported field device and
+// BACnet object must still be listed exactly once.
+type editCursor struct {
+	aggregates []fielddeviceimport.EditedAggregate
+	devices    map[uuid.UUID]int
+	objects    map[uuid.UUID]objectPosition
+	seen       map[uuid.UUID]struct{}
+	issues     []fielddeviceimport.Issue
+}
+
+type objectPosition struct {
+	device int
+	object int
+}
+
+func newEditCursor(exported []fielddeviceimport.Aggregate) *editCursor {
+	c := &editCursor{
+		aggregates: make([]fielddeviceimport.EditedAggregate, len(exported)),
+		devices:    make(map[uuid.UUID]int, len(exported)),
+		objects:    make(map[uuid.UUID]objectPosition),
+		seen:       make(map[uuid.UUID]struct{}),
+	}
+	for position, aggregate := range exported {
+		edited := aggregate
+		edited.BacnetObjects = slices.Clone(aggregate.BacnetObjects)
+		c.aggregates[position] = fielddeviceimport.EditedAggregate{Exported: aggregate, Edited: edited}
+		c.devices[aggregate.FieldDevice.ID] = position
+		for index, object := range aggregate.BacnetObjects {
+			c.objects[object.ID] = objectPosition{device: position, object: index}
+		}
+	}
+	return c
+}
+
+func (c *editCursor) row(row editRow) error {
+	value := row.get(idColumn)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.reject(row, "missing_id", fmt.Sprintf("%s: the %s column is empty or invalid", row.location(), idColumn))
+		return nil
+	}
+	if _, ok := c.seen[id]; ok {
+		c.reject(row, "duplicate_id", fmt.Sprintf("%s: %s is listed more than once", row.location(), id))
+		return nil
+	}
+	if position, ok := c.devices[id]; ok {
+		c.seen[id] = struct{}{}
+		c.deviceRow(row, &c.aggregates[position])
+		return nil
+	}
+	if position, ok := c.objects[id]; ok {
+		c.seen[id] = struct{}{}
+		c.objectRow(row, &c.aggregates[position.device].Edited.BacnetObjects[position.object])
+		return nil
+	}
+	c.reject(row, "unknown_id", fmt.Sprintf("%s: %s was not exported with this workbook", row.location(), id))
+	return nil
+}
+
+func (c *editCursor) deviceRow(row editRow, pair *fielddeviceimport.EditedAggregate) {
+	if row.get("Adresse") != "" {
+		c.reject(row, "row_mismatch", fmt.Sprintf("%s: expected the row of field device %s", row.location(), pair.Exported.FieldDevice.ID))
+		return
+	}
+	pair.Edited.FieldDevice.BMK = optionalString(row.get("BMK"))
+	pair.Edited.FieldDevice.Description = optionalString(row.get("Bemerkung"))
+	pair.Edited.Specification = c.specification(row, pair.Exported)
+}
+
+func (c *editCursor) objectRow(row editRow, object *domainFacility.BacnetObject) {
+	if row.get("Adresse") != objectAddress(*object) {
+		c.reject(row, "row_mismatch", fmt.Sprintf("%s: expected BACnet object %s", row.location(), objectAddress(*object)))
+		return
+	}
+	object.TextFix = row.get("Text-Fix")
+	visible, err := strconv.ParseBool(row.get("GMS Sichtbar"))
+	if err != nil {
+		c.invalid(row, "bacnet_object", object.ID, "gms_visible")
+		return
+	}
+	object.GMSVisible = visible
+}
+
+func (c *editCursor) specification(row editRow, exported fielddeviceimport.Aggregate) *domainFacility.Specification {
+	if exported.Specification == nil && !slices.ContainsFunc(specificationColumns, func(name string) bool { return row.get(name) != "" }) {
+		return nil
+	}
+	specification := domainFacility.Specification{}
+	if exported.Specification != nil {
+		specification = *exported.Specification
+	}
+	number := specificationNumbers{cursor: c, row: row, id: exported.FieldDevice.ID}
+	specification.SpecificationSupplier = optionalString(row.get("Lieferant"))
+	specification.SpecificationBrand = optionalString(row.get("Fabrikat"))
+	specification.SpecificationType = optionalString(row.get("Typ"))
+	specification.AdditionalInfoMotorValve = optionalString(row.get("Motor,Ventil, etc."))
+	specification.AdditionalInfoSize = number.integer("Grösse", "size")
+	specification.AdditionalInformationInstallationLocation = optionalString(row.get("Montageort"))
+	specification.ElectricalConnectionPH = number.integer("Ph", "ph")
+	specification.ElectricalConnectionACDC = optionalString(row.get("AC/DC"))
+	specification.ElectricalConnectionAmperage = number.decimal("Stromstärke", "amperage")
+	specification.ElectricalConnectionPower = number.decimal("Leistung", "power")
+	specification.ElectricalConnectionRotation = number.integer("Drehzahl", "rotation")
+	return &specification
+}
+
+func (c *editCursor) invalid(row editRow, entity string, id uuid.UUID, field string) {
+	c.issues = append(c.issues, fielddeviceimport.Issue{
+		Code: "invalid_value", Entity: entity, SourceID: id, Field: field,
+		Message: fmt.Sprintf("%s: invalid %s value", row.location(), field),
+	})
+}
+
+func (c *editCursor) reject(row editRow, code, message string) {
+	c.issues = append(c.issues, fielddeviceimport.Issue{Code: code, Entity: "controller_row", Message: message})
+}
+
+// finish refuses workbooks that dropped rows. Rejected rows already explain
+// a missing match, so it only fails when every listed row was accepted.
+func (c *editCursor) finish() error {
+	if len(c.issues) > 0 {
+		return nil
+	}
+	for _, pair := range c.aggregates {
+		if _, ok := c.seen[pair.Exported.FieldDevice.ID]; !ok {
+			return fmt.Errorf("controller sheets do not list field device %s", pair.Exported.FieldDevice.ID)
+		}
+		for _, object := range pair.Exported.BacnetObjects {
+			if _, ok := c.seen[object.ID]; !ok {
+				return fmt.Errorf("controller sheets do not list BACnet object %s", object.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// specificationNumbers parses numeric specification cells and records an
+// issue instead of silently dropping values Excel users typed by hand.
+type specificationNumbers struct {
+	cursor *editCursor
+	row    editRow
+	id     uuid.UUID
+}
+
+func (n specificationNumbers) integer(column, field string) *int {
+	value := n.row.get(column)
+	parsed := optionalInt(value)
+	if parsed == nil && value != "" {
+		n.cursor.invalid(n.row, "specification", n.id, field)
+	}
+	return parsed
+}
+
+func (n specificationNumbers) decimal(column, field string) *float64 {
+	value := n.row.get(column)
+	parsed := optionalFloat(value)
+	if parsed == nil && value != "" {
+		n.cursor.invalid(n.row, "specification", n.id, field)
+	}
+	return parsed
+}
+
+func objectAddress(object domainFacility.BacnetObject) string {
+	if object.SoftwareType == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%02d", strings.ToUpper(string(object.SoftwareType)), object.SoftwareNumber)
+}
@@ -0,0 +1,191 @@
+package importing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	exporting "github.com/besart951/go_infra_link/backend/internal/infrastructure/exporting"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+type editedDeviceSource struct{ devices []domainFacility.FieldDevice }
+
+func (s editedDeviceSource) ResolveControllers(context.Context, domainExport.Request) ([]domainExport.Controller, error) {
+	return nil, nil
+}
+
+func (s editedDeviceSource) ListFieldDevicesByControllerAfter(_ context.Context, _ uuid.UUID, _ domainExport.Request, afterID uuid.UUID, _ int) ([]domainFacility.FieldDevice, error) {
+	if afterID != uuid.Nil {
+		return nil, nil
+	}
+	return s.devices, nil
+}
+
+func TestEditReaderPairsControllerRowsWithExportedState(t *testing.T) {
+	bmk, supplier := "B-1", "Belimo"
+	device := domainFacility.FieldDevice{ApparatNr: 1, BMK: &bmk}
+	device.ID, device.Version = uuid.New(), 4
+	device.Specification = &domainFacility.Specification{SpecificationSupplier: &supplier}
+	device.Specification.ID, device.Specification.Version = uuid.New(), 2
+	device.BacnetObjects = []domainFacility.BacnetObject{
+		{TextFix: "Betrieb", SoftwareType: domainFacility.BacnetSoftwareTypeBO, SoftwareNumber: 1, FieldDeviceID: &device.ID},
+		{TextFix: "Störung", SoftwareType: domainFacility.BacnetSoftwareTypeBI, SoftwareNumber: 2, FieldDeviceID: &device.ID},
+	}
+	device.BacnetObjects[0].ID, device.BacnetObjects[1].ID = uuid.New(), uuid.New()
+	workbook := exportedWorkbook(t, device)
+	setCells(t, workbook, map[string]any{"E14": "B-2", "AI14": "", "AM14": "12", "K16": "Alarm"})
+
+	set, err := NewEditReader().ReadEdits(context.Background(), workbookReader(t, workbook))
+
+	if err != nil || len(set.Issues) != 0 || len(set.Aggregates) != 1 {
+		t.Fatalf("unexpected set=%+v err=%v", set, err)
+	}
+	if set.Manifest.SchemaVersion != fielddeviceimport.SchemaVersion {
+		t.Fatalf("manifest = %+v", set.Manifest)
+	}
+	pair := set.Aggregates[0]
+	if pair.Exported.FieldDevice.Version != 4 || *pair.Exported.FieldDevice.BMK != "B-1" || *pair.Exported.Specification.SpecificationSupplier != supplier {
+		t.Fatalf("exported state = %+v", pair.Exported)
+	}
+	edited := pair.Edited
+	if *edited.FieldDevice.BMK != "B-2" || edited.Specification.SpecificationSupplier != nil || *edited.Specification.AdditionalInfoSize != 12 {
+		t.Fatalf("edited state = %+v spec=%+v", edited.FieldDevice, edited.Specification)
+	}
+	if edited.BacnetObjects[0].TextFix != "Betrieb" || edited.BacnetObjects[1].TextFix != "Alarm" || pair.Exported.BacnetObjects[1].TextFix != "Störung" {
+		t.Fatalf("edited objects = %+v", edited.BacnetObjects)
+	}
+}
+
+func TestEditReaderRejectsRemovedObjectRows(t *testing.T) {
+	device := domainFacility.FieldDevice{ApparatNr: 1}
+	device.ID = uuid.New()
+	device.BacnetObjects = []domainFacility.BacnetObject{{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1, FieldDeviceID: &device.ID}}
+	device.BacnetObjects[0].ID = uuid.New()
+	workbook := exportedWorkbook(t, device)
+	sheet := workbook.GetSheetName(0)
+	if err := workbook.RemoveRow(sheet, 15); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewEditReader().ReadEdits(context.Background(), workbookReader(t, workbook)); err == nil {
+		t.Fatal("expected missing object row to be rejected")
+	}
+}
+
+func TestEditReaderMatchesRowsByID(t *testing.T) {
+	device := domainFacility.FieldDevice{ApparatNr: 1}
+	device.ID = uuid.New()
+	device.BacnetObjects = []domainFacility.BacnetObject{
+		{TextFix: "Betrieb", SoftwareType: domainFacility.BacnetSoftwareTypeBO, SoftwareNumber: 1, FieldDeviceID: &device.ID},
+		{TextFix: "Störung", SoftwareType: domainFacility.BacnetSoftwareTypeBI, SoftwareNumber: 2, FieldDeviceID: &device.ID},
+	}
+	device.BacnetObjects[0].ID, device.BacnetObjects[1].ID = uuid.New(), uuid.New()
+	workbook := exportedWorkbook(t, device)
+	sheet := workbook.GetSheetName(0)
+	rows, err := workbook.GetRows(sheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := rows[14], rows[15]
+	first[10] = "Betrieb neu"
+	for cell, row := range map[string][]string{"A15": second, "A16": first} {
+		values := make([]any, len(row))
+		for index, value := range row {
+			values[index] = value
+		}
+		if err := workbook.SetSheetRow(sheet, cell, &values); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	set, err := NewEditReader().ReadEdits(context.Background(), workbookReader(t, workbook))
+
+	if err != nil || len(set.Issues) != 0 || len(set.Aggregates) != 1 {
+		t.Fatalf("unexpected set=%+v err=%v", set, err)
+	}
+	if objects := set.Aggregates[0].Edited.BacnetObjects; objects[0].TextFix != "Betrieb neu" || objects[1].TextFix != "Störung" {
+		t.Fatalf("swapped rows were not matched by ID: %+v", objects)
+	}
+}
+
+func TestEditReaderRejectsRowsWithoutKnownID(t *testing.T) {
+	device := domainFacility.FieldDevice{ApparatNr: 1}
+	device.ID = uuid.New()
+	device.BacnetObjects = []domainFacility.BacnetObject{{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1, FieldDeviceID: &device.ID}}
+	device.BacnetObjects[0].ID = uuid.New()
+	workbook := exportedWorkbook(t, device)
+	idCell := fmt.Sprintf("%s15", idColumnName(t, workbook))
+	setCells(t, workbook, map[string]any{idCell: uuid.NewString()})
+
+	set, err := NewEditReader().ReadEdits(context.Background(), workbookReader(t, workbook))
+	if err != nil || len(set.Issues) != 1 || set.Issues[0].Code != "unknown_id" {
+		t.Fatalf("unknown ID: issues=%+v err=%v", set.Issues, err)
+	}
+
+	setCells(t, workbook, map[string]any{idCell: ""})
+	set, err = NewEditReader().ReadEdits(context.Background(), workbookReader(t, workbook))
+	if err != nil || len(set.Issues) != 1 || set.Issues[0].Code != "missing_id" {
+		t.Fatalf("missing ID: issues=%+v err=%v", set.Issues, err)
+	}
+}
+
+func idColumnName(t *testing.T, workbook *excelize.File) string {
+	t.Helper()
+	rows, err := workbook.GetRows(workbook.GetSheetName(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	position := slices.Index(rows[12], idColumn)
+	if position < 0 {
+		t.Fatalf("headings lack %q: %v", idColumn, rows[12])
+	}
+	name, err := excelize.ColumnNumberToName(position + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func exportedWorkbook(t *testing.T, device domainFacility.FieldDevice) *excelize.File {
+	t.Helper()
+	path := t.TempDir() + "/export.xlsx"
+	controller := domainExport.Controller{ID: uuid.New(), GADevice: "A", IWSCode: "ABCD", BuildingGroup: 1}
+	request := domainExport.Request{SchemaVersion: fielddeviceimport.SchemaVersion, SnapshotAt: time.Now(), DeviceCount: 1}
+	if _, err := exporting.NewExcelizeGenerator().GenerateWorkbook(context.Background(), path, []domainExport.Controller{controller},
+		editedDeviceSource{devices: []domainFacility.FieldDevice{device}}, request, 500); err != nil {
+		t.Fatal(err)
+	}
+	workbook, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = workbook.Close() })
+	return workbook
+}
+
+func setCells(t *testing.T, workbook *excelize.File, values map[string]any) {
+	t.Helper()
+	sheet := workbook.GetSheetName(0)
+	for cell, value := range values {
+		if err := workbook.SetCellValue(sheet, cell, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func workbookReader(t *testing.T, workbook *excelize.File) *bytes.Reader {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := workbook.Write(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buffer.Bytes())
+}
@@ -427,7 +427,7 @@ func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
 
 func isFacilityRealtimeResource(resource string) bool {
 	switch resource {
-	case "buildings", "system_types", "system_parts", "apparats", "control_cabinets", "sps_controllers", "sps_controller_system_types", "field_devices", "bacnet_objects", "object_data", "state_texts", "notification_classes", "alarm_definitions", "alarm_types", "alarm_type_fields", "alarm_fields", "units":
+	case "buildings", "system_types", "system_parts", "apparats", "control_cabinets", "sps_controllers", "sps_controller_system_types", "ip_pools", "naming_schemes", "field_devices", "bacnet_objects", "object_data", "state_texts", "notification_classes", "alarm_definitions", "alarm_types", "alarm_type_fields", "alarm_fields", "units":
 		return true
 	default:
 		return false
@@ -655,7 +655,7 @@ func (h *ProjectCollaborationHub) BroadcastControlCabinetDelta(projectID uuid.UU
 }
 
 func (h *ProjectCollaborationHub) BroadcastSPSControllerDelta(projectID uuid.UUID, actorID *uuid.UUID, controller domainFacility.SPSController) {
-	h.broadcastLegacyProjectChange(projectID, actorID, "sps_controller", controller.ID, "updated", []string{"control_cabinet_id", "ga_device", "device_name", "device_description", "device_location", "ip_address", "subnet", "gateway", "vlan"})
+	h.broadcastLegacyProjectChange(projectID, actorID, "sps_controller", controller.ID, "updated", []string{"control_cabinet_id", "ga_device", "device_name", "device_description", "device_location", "ip_address", "subnet", "gateway", "vlan", "mac_address"})
 }
 
 func (h *ProjectCollaborationHub) BroadcastFieldDeviceDelta(projectID uuid.UUID, actorID *uuid.UUID, devices []map[string]any) {
@@ -30,7 +30,7 @@ var (
 		"control_cabinet": fieldSet("building_id", "control_cabinet_nr"),
 		"sps_controller": fieldSet(
 			"control_cabinet_id", "ga_device", "device_name", "device_description", "device_location",
-			"ip_address", "subnet", "gateway", "vlan",
+			"ip_address", "subnet", "gateway", "vlan", "mac_address",
 		),
 		"sps_controller_system_type": fieldSet("sps_controller_id", "system_type_id", "number", "document_name"),
 		"field_device": fieldSet(
@@ -0,0 +1,280 @@
+package baselinesql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/projectsql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	idChunkSize    = 1000
+	entryBatchSize = 500
+)
+
+type baselineRecord struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_project_baselines_name,priority:1"`
+	Name        string     `gorm:"size:120;not null;uniqueIndex:idx_project_baselines_name,priority:2"`
+	Description *string    `gorm:"type:text"`
+	CapturedAt  time.Time  `gorm:"not null"`
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	EntityCount int        `gorm:"not null;default:0"`
+	CreatedAt   time.Time  `gorm:"not null"`
+}
+
+func (baselineRecord) TableName() string { return "project_baselines" }
+
+// entryRecord points at the entity version that matched the row when the
+// baseline was captured. Rows whose state was never recorded keep their own
+// snapshot instead.
+type entryRecord struct {
+	BaselineID   uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	EntityTable  string              `gorm:"type:varchar(96);primaryKey"`
+	EntityID     uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	VersionID    *uuid.UUID          `gorm:"type:uuid"`
+	SnapshotJSON domainHistory.JSONB `gorm:"type:jsonb"`
+}
+
+func (entryRecord) TableName() string { return "project_baseline_entries" }
+
+type Store struct{ db *gorm.DB }
+
+func NewStore(db *gorm.DB) *Store { return &Store{db: db} }
+
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&baselineRecord{}, &entryRecord{})
+}
+
+func (s *Store) Capture(ctx context.Context, baseline projectbaseline.Baseline) (projectbaseline.Baseline, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureUniqueName(tx, baseline); err != nil {
+			return err
+		}
+		entities, err := loadCurrent(ctx, tx, baseline.ProjectID)
+		if err != nil {
+			return err
+		}
+		entries, err := pinEntries(ctx, tx, baseline, entities)
+		if err != nil {
+			return err
+		}
+		baseline.ID, baseline.EntityCount = entries.baselineID, len(entities)
+		record := toRecord(baseline)
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		if len(entries.rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(entries.rows, entryBatchSize).Error
+	})
+	return baseline, err
+}
+
+func (s *Store) List(ctx context.Context, projectID uuid.UUID) ([]projectbaseline.Baseline, error) {
+	var records []baselineRecord
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Order("captured_at DESC, id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	baselines := make([]projectbaseline.Baseline, 0, len(records))
+	for _, record := range records {
+		baselines = append(baselines, fromRecord(record))
+	}
+	return baselines, nil
+}
+
+func (s *Store) Get(ctx context.Context, projectID, baselineID uuid.UUID) (projectbaseline.Baseline, error) {
+	var record baselineRecord
+	err := s.db.WithContext(ctx).Where("id = ? AND project_id = ?", baselineID, projectID).Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return projectbaseline.Baseline{}, domain.ErrNotFound
+	}
+	return fromRecord(record), err
+}
+
+func (s *Store) LoadBaseline(ctx context.Context, baselineID uuid.UUID) ([]projectbaseline.Entity, error) {
+	var rows []struct {
+		EntityTable  string
+		EntityID     uuid.UUID
+		SnapshotJSON domainHistory.JSONB
+	}
+	err := s.db.WithContext(ctx).Raw(`
+		SELECT e.entity_table, e.entity_id, COALESCE(e.snapshot_json, v.snapshot_json) AS snapshot_json
+		FROM project_baseline_entries e
+		LEFT JOIN entity_versions v ON v.id = e.version_id
+		WHERE e.baseline_id = ?
+		ORDER BY e.entity_table, e.entity_id
+	`, baselineID).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]projectbaseline.Entity, 0, len(rows))
+	for _, row := range rows {
+		if len(row.SnapshotJSON) == 0 {
+			return nil, fmt.Errorf("baseline %s: version of %s %s is no longer available", baselineID, row.EntityTable, row.EntityID)
+		}
+		entities = append(entities, projectbaseline.Entity{Category: projectbaseline.Category(row.EntityTable), ID: row.EntityID, Snapshot: json.RawMessage(row.SnapshotJSON)})
+	}
+	return entities, nil
+}
+
+func (s *Store) LoadCurrent(ctx context.Context, projectID uuid.UUID) ([]projectbaseline.Entity, error) {
+	return loadCurrent(ctx, s.db.WithContext(ctx), projectID)
+}
+
+func ensureUniqueName(tx *gorm.DB, baseline projectbaseline.Baseline) error {
+	var projects int64
+	if err := tx.Table("projects").Where("id = ?", baseline.ProjectID).Count(&projects).Error; err != nil {
+		return err
+	}
+	if projects == 0 {
+		return domain.ErrNotFound
+	}
+	var taken int64
+	if err := tx.Model(&baselineRecord{}).Where("project_id = ? AND name = ?", baseline.ProjectID, baseline.Name).Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return fmt.Errorf("%w: baseline %q already exists", domain.ErrConflict, baseline.Name)
+	}
+	return nil
+}
+
+type pinnedEntries struct {
+	baselineID uuid.UUID
+	rows       []entryRecord
+}
+
+// pinEntries reuses the newest recorded version of every row whose snapshot
+// still matches it, so most baselines cost one pointer per row.
+func pinEntries(ctx context.Context, tx *gorm.DB, baseline projectbaseline.Baseline, entities []projectbaseline.Entity) (pinnedEntries, error) {
+	pinned := pinnedEntries{baselineID: uuid.New(), rows: make([]entryRecord, 0, len(entities))}
+	history := historysql.NewStore(tx)
+	for category, group := range groupByCategory(entities) {
+		ids := make([]uuid.UUID, 0, len(group))
+		for _, entity := range group {
+			ids = append(ids, entity.ID)
+		}
+		versions, err := history.LatestVersions(ctx, string(category), ids, baseline.CapturedAt)
+		if err != nil {
+			return pinned, err
+		}
+		for _, entity := range group {
+			row := entryRecord{BaselineID: pinned.baselineID, EntityTable: string(category), EntityID: entity.ID}
+			if version, ok := versions[entity.ID]; ok && projectbaseline.SameSnapshot(json.RawMessage(version.SnapshotJSON), entity.Snapshot) {
+				versionID := version.ID
+				row.VersionID = &versionID
+			} else {
+				row.SnapshotJSON = domainHistory.JSONB(entity.Snapshot)
+			}
+			pinned.rows = append(pinned.rows, row)
+		}
+	}
+	return pinned, nil
+}
+
+func groupByCategory(entities []projectbaseline.Entity) map[projectbaseline.Category][]projectbaseline.Entity {
+	groups := make(map[projectbaseline.Category][]projectbaseline.Entity)
+	for _, entity := range entities {
+		groups[entity.Category] = append(groups[entity.Category], entity)
+	}
+	return groups
+}
+
+// loadCurrent collects the cabinets, controllers and field devices linked to
+// the project, with the BACnet objects and alarm values of those devices.
+func loadCurrent(ctx context.Context, db *gorm.DB, projectID uuid.UUID) ([]projectbaseline.Entity, error) {
+	members, err := loadMembers(db, projectID)
+	if err != nil {
+		return nil, err
+	}
+	history := historysql.NewStore(db)
+	var entities []projectbaseline.Entity
+	for _, category := range projectbaseline.Categories() {
+		rows, err := history.LoadRows(ctx, string(category), members[category])
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range members[category] {
+			if snapshot, ok := rows[id]; ok {
+				entities = append(entities, projectbaseline.Entity{Category: category, ID: id, Snapshot: json.RawMessage(snapshot)})
+			}
+		}
+	}
+	return entities, nil
+}
+
+func loadMembers(db *gorm.DB, projectID uuid.UUID) (map[projectbaseline.Category][]uuid.UUID, error) {
+	members := make(map[projectbaseline.Category][]uuid.UUID, len(projectbaseline.Categories()))
+	links := []struct {
+		model    any
+		column   string
+		category projectbaseline.Category
+	}{
+		{&projectsql.ProjectControlCabinetRecord{}, "control_cabinet_id", projectbaseline.CategoryControlCabinets},
+		{&projectsql.ProjectSPSControllerRecord{}, "sps_controller_id", projectbaseline.CategorySPSControllers},
+		{&projectsql.ProjectFieldDeviceRecord{}, "field_device_id", projectbaseline.CategoryFieldDevices},
+	}
+	for _, link := range links {
+		var ids []uuid.UUID
+		if err := db.Model(link.model).Where("project_id = ?", projectID).Distinct().Order(link.column).Pluck(link.column, &ids).Error; err != nil {
+			return nil, err
+		}
+		members[link.category] = ids
+	}
+	objects, err := childIDs(db, "bacnet_objects", "field_device_id", members[projectbaseline.CategoryFieldDevices])
+	if err != nil {
+		return nil, err
+	}
+	members[projectbaseline.CategoryBacnetObjects] = objects
+	members[projectbaseline.CategoryAlarmValues], err = childIDs(db, "bacnet_object_alarm_values", "bacnet_object_id", objects)
+	return members, err
+}
+
+func childIDs(db *gorm.DB, table, parentColumn string, parents []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for start := 0; start < len(parents); start += idChunkSize {
+		end := min(start+idChunkSize, len(parents))
+		var chunk []uuid.UUID
+		if err := db.Table(table).Where(parentColumn+" IN ?", parents[start:end]).Order("id").Pluck("id", &chunk).Error; err != nil {
+			return nil, err
+		}
+		ids = append(ids, chunk...)
+	}
+	return ids, nil
+}
+
+func toRecord(baseline projectbaseline.Baseline) baselineRecord {
+	return baselineRecord{
+		ID:          baseline.ID,
+		ProjectID:   baseline.ProjectID,
+		Name:        baseline.Name,
+		Description: baseline.Description,
+		CapturedAt:  baseline.CapturedAt,
+		CreatedBy:   baseline.CreatedBy,
+		EntityCount: baseline.EntityCount,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+func fromRecord(record baselineRecord) projectbaseline.Baseline {
+	return projectbaseline.Baseline{
+		ID:          record.ID,
+		ProjectID:   record.ProjectID,
+		Name:        record.Name,
+		Description: record.Description,
+		CapturedAt:  record.CapturedAt.UTC(),
+		CreatedBy:   record.CreatedBy,
+		EntityCount: record.EntityCount,
+	}
+}
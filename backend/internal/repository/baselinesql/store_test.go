@@ -0,0 +1,159 @@
+package baselinesql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	projectrepo "github.com/besart951/go_infra_link/backend/internal/repository/project"
+	"github.com/besart951/go_infra_link/backend/internal/repository/projectsql"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCapturePinsRecordedVersionsAndDiffsAgainstCurrentState(t *testing.T) {
+	ctx := context.Background()
+	db := newBaselineTestDB(t)
+	projectID := seedBaselineProject(t, db)
+	nr := "SG01"
+	cabinet := domainFacility.ControlCabinet{BuildingID: uuid.New(), ControlCabinetNr: &nr}
+	seedBaselineRecord(t, db, &cabinet)
+	device := facilitysql.FieldDeviceRecord{SPSControllerSystemTypeID: uuid.New(), SystemPartID: uuid.New(), ApparatID: uuid.New(), ApparatNr: 1}
+	seedBaselineRecord(t, db, &device)
+	seedBaselineRecord(t, db, &projectsql.ProjectControlCabinetRecord{ProjectID: projectID, ControlCabinetID: cabinet.ID})
+	seedBaselineRecord(t, db, &projectsql.ProjectFieldDeviceRecord{ProjectID: projectID, FieldDeviceID: device.ID})
+	seedCurrentVersion(t, db, "control_cabinets", cabinet.ID)
+
+	service := projectbaseline.NewService(NewStore(db), nil)
+	baseline, err := service.Create(ctx, projectbaseline.CreateCommand{ProjectID: projectID, Name: "Baseline v3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if baseline.EntityCount != 2 {
+		t.Fatalf("entity count = %d, want 2", baseline.EntityCount)
+	}
+	var entries []entryRecord
+	if err := db.Order("entity_table").Find(&entries).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].VersionID == nil || len(entries[0].SnapshotJSON) != 0 || entries[1].VersionID != nil || len(entries[1].SnapshotJSON) == 0 {
+		t.Fatalf("entries = %+v, want a version pointer for the cabinet and a snapshot for the device", entries)
+	}
+
+	if err := db.Model(&facilitysql.FieldDeviceRecord{}).Where("id = ?", device.ID).Update("apparat_nr", 7).Error; err != nil {
+		t.Fatal(err)
+	}
+	object := domainFacility.BacnetObject{TextFix: "AI01", SoftwareType: "ai", SoftwareNumber: 1, FieldDeviceID: &device.ID}
+	seedBaselineRecord(t, db, &object)
+
+	diff, err := service.Compare(ctx, projectID, baseline.ID, projectbaseline.Target{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := diff.Summary[projectbaseline.CategoryControlCabinets]; got != (projectbaseline.Summary{Unchanged: 1}) {
+		t.Fatalf("cabinet summary = %+v", got)
+	}
+	if got := diff.Summary[projectbaseline.CategoryFieldDevices]; got != (projectbaseline.Summary{Changed: 1}) {
+		t.Fatalf("field device summary = %+v", got)
+	}
+	if got := diff.Summary[projectbaseline.CategoryBacnetObjects]; got != (projectbaseline.Summary{Added: 1}) {
+		t.Fatalf("bacnet object summary = %+v", got)
+	}
+}
+
+func TestCaptureRejectsDuplicateNameAndUnknownProject(t *testing.T) {
+	ctx := context.Background()
+	db := newBaselineTestDB(t)
+	projectID := seedBaselineProject(t, db)
+	service := projectbaseline.NewService(NewStore(db), nil)
+	if _, err := service.Create(ctx, projectbaseline.CreateCommand{ProjectID: projectID, Name: "Freeze"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Create(ctx, projectbaseline.CreateCommand{ProjectID: projectID, Name: "Freeze"}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("duplicate Create() error = %v, want ErrConflict", err)
+	}
+	if _, err := service.Create(ctx, projectbaseline.CreateCommand{ProjectID: uuid.New(), Name: "Freeze"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("unknown project Create() error = %v, want ErrNotFound", err)
+	}
+	baselines, err := service.List(ctx, projectID)
+	if err != nil || len(baselines) != 1 || baselines[0].EntityCount != 0 {
+		t.Fatalf("List() = %+v, %v", baselines, err)
+	}
+}
+
+func newBaselineTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(
+		&projectrepo.ProjectRecord{},
+		&projectsql.ProjectControlCabinetRecord{},
+		&projectsql.ProjectSPSControllerRecord{},
+		&projectsql.ProjectFieldDeviceRecord{},
+		&domainFacility.ControlCabinet{},
+		&domainFacility.SPSController{},
+		&facilitysql.FieldDeviceRecord{},
+		&domainFacility.BacnetObject{},
+		&domainFacility.BacnetObjectAlarmValue{},
+	); err != nil {
+		t.Fatalf("migrate facility tables: %v", err)
+	}
+	if err := historysql.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate history: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("migrate baselines: %v", err)
+	}
+	return db
+}
+
+func seedBaselineProject(t *testing.T, db *gorm.DB) uuid.UUID {
+	t.Helper()
+	project := projectrepo.ProjectRecord{Name: "Baseline", Status: "planned", PhaseID: uuid.New(), CreatorID: uuid.New()}
+	seedBaselineRecord(t, db, &project)
+	return project.ID
+}
+
+func seedBaselineRecord(t *testing.T, db *gorm.DB, entity interface{ GetBase() *domain.Base }) {
+	t.Helper()
+	if err := entity.GetBase().InitForCreate(time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Omit("*.*").Create(entity).Error; err != nil {
+		t.Fatalf("seed record: %v", err)
+	}
+}
+
+func seedCurrentVersion(t *testing.T, db *gorm.DB, table string, id uuid.UUID) {
+	t.Helper()
+	snapshot, _, err := historysql.NewStore(db).LoadRow(t.Context(), table, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versionAt := time.Now().UTC().Add(-time.Minute)
+	if err := db.Create(&domainHistory.EntityVersion{
+		ID: uuid.New(), ChangeEventID: uuid.New(), EntityTable: table, EntityID: id,
+		VersionAt: versionAt, Action: domainHistory.ActionCreate, SnapshotJSON: snapshot,
+	}).Error; err != nil {
+		t.Fatalf("seed version: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package facilitysql
+
+import (
+	"context"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/besart951/go_infra_link/backend/internal/repository/gormbase"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type alarmDefinitionFieldOverrideRepo struct {
+	*gormbase.BaseRepository[*domainFacility.AlarmDefinitionFieldOverride]
+	db *gorm.DB
+}
+
+func NewAlarmDefinitionFieldOverrideRepository(db *gorm.DB) domainFacility.AlarmDefinitionFieldOverrideRepository {
+	return &alarmDefinitionFieldOverrideRepo{
+		BaseRepository: gormbase.NewBaseRepository[*domainFacility.AlarmDefinitionFieldOverride](db, nil),
+		db:             db,
+	}
+}
+
+func (r *alarmDefinitionFieldOverrideRepo) GetPaginatedList(ctx context.Context, params domain.PaginationParams) (*domain.PaginatedList[domainFacility.AlarmDefinitionFieldOverride], error) {
+	result, err := r.BaseRepository.GetPaginatedList(ctx, params, 50)
+	if err != nil {
+		return nil, err
+	}
+	return gormbase.DerefPaginatedList(result), nil
+}
+
+func (r *alarmDefinitionFieldOverrideRepo) ListByAlarmDefinitionIDs(ctx context.Context, alarmDefinitionIDs []uuid.UUID) ([]domainFacility.AlarmDefinitionFieldOverride, error) {
+	if len(alarmDefinitionIDs) == 0 {
+		return nil, nil
+	}
+	var items []domainFacility.AlarmDefinitionFieldOverride
+	err := r.db.WithContext(ctx).
+		Preload("UnitOverride").
+		Where("alarm_definition_id IN ?", alarmDefinitionIDs).
+		Order("alarm_definition_id ASC, created_at ASC").
+		Find(&items).Error
+	return items, err
+}
@@ -31,6 +31,15 @@ func (r *unitRepo) GetPaginatedList(ctx context.Context, params domain.Paginatio
 	return gormbase.DerefPaginatedList(result), nil
 }
 
+func (r *unitRepo) GetByCodes(ctx context.Context, codes []string) ([]domainFacility.Unit, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	var items []domainFacility.Unit
+	err := r.DB().WithContext(ctx).Where("code IN ?", codes).Order("code ASC").Find(&items).Error
+	return items, err
+}
+
 // --- AlarmField ---
 
 type alarmFieldRepo struct {
@@ -0,0 +1,52 @@
+package facilitysql
+
+import (
+	"context"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type bacnetInstanceRepo struct {
+	db *gorm.DB
+}
+
+type bacnetInstanceRow struct {
+	BacnetObjectID uuid.UUID `gorm:"column:bacnet_object_id"`
+	FieldDeviceID  uuid.UUID `gorm:"column:field_device_id"`
+	SystemTypeID   uuid.UUID `gorm:"column:system_type_id"`
+	SoftwareType   string    `gorm:"column:software_type"`
+	SoftwareNumber uint16    `gorm:"column:software_number"`
+}
+
+func NewBacnetInstanceRepository(db *gorm.DB) domainObjectData.BacnetInstanceStore {
+	return &bacnetInstanceRepo{db: db}
+}
+
+// ListBySPSControllerID deliberately ignores lifecycle tombstones: a deleted
+// field device can still be restored, so its instances stay reserved.
+func (r *bacnetInstanceRepo) ListBySPSControllerID(ctx context.Context, spsControllerID uuid.UUID) ([]domainObjectData.BacnetInstanceSlot, error) {
+	const query = `
+		SELECT bo.id AS bacnet_object_id, fd.id AS field_device_id, scst.system_type_id,
+			bo.software_type, bo.software_number
+		FROM bacnet_objects bo
+		JOIN field_devices fd ON fd.id = bo.field_device_id
+		JOIN sps_controller_system_types scst ON scst.id = fd.sps_controller_system_type_id
+		WHERE scst.sps_controller_id = ?
+		ORDER BY bo.software_type, bo.software_number, bo.id
+	`
+	var rows []bacnetInstanceRow
+	if err := r.db.WithContext(ctx).Raw(query, spsControllerID).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	slots := make([]domainObjectData.BacnetInstanceSlot, len(rows))
+	for index, row := range rows {
+		slots[index] = domainObjectData.BacnetInstanceSlot{
+			BacnetObjectID: row.BacnetObjectID, FieldDeviceID: row.FieldDeviceID, SystemTypeID: row.SystemTypeID,
+			SoftwareType: domainFacility.BacnetSoftwareType(row.SoftwareType), SoftwareNumber: row.SoftwareNumber,
+		}
+	}
+	return slots, nil
+}
@@ -0,0 +1,46 @@
+package facilitysql
+
+import (
+	"context"
+	"testing"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func TestBacnetInstanceRepo_ListBySPSControllerID(t *testing.T) {
+	db := newBacnetReferenceUsageRepoTestDB(t)
+	systemType := seedBacnetReferenceUsageRecord(t, db, &domainFacility.SystemType{Name: "HVAC", NumberMin: 100, NumberMax: 199})
+	controllers := []*domainFacility.SPSController{
+		seedBacnetReferenceUsageRecord(t, db, &domainFacility.SPSController{ControlCabinetID: uuid.New(), DeviceName: "SPS-A"}),
+		seedBacnetReferenceUsageRecord(t, db, &domainFacility.SPSController{ControlCabinetID: uuid.New(), DeviceName: "SPS-B"}),
+	}
+	devices := make([]*FieldDeviceRecord, len(controllers))
+	for index, controller := range controllers {
+		number := 1
+		spsSystemType := seedBacnetReferenceUsageRecord(t, db, &domainFacility.SPSControllerSystemType{
+			Number: &number, SPSControllerID: controller.ID, SystemTypeID: systemType.ID,
+		})
+		devices[index] = seedBacnetReferenceUsageRecord(t, db, &FieldDeviceRecord{
+			SPSControllerSystemTypeID: spsSystemType.ID, SystemPartID: uuid.New(), ApparatID: uuid.New(), ApparatNr: 1,
+		})
+	}
+	for index, device := range devices {
+		seedBacnetReferenceUsageRecord(t, db, &domainFacility.BacnetObject{
+			TextFix: "AI", SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: uint16(index + 1), FieldDeviceID: &device.ID,
+		})
+	}
+
+	slots, err := NewBacnetInstanceRepository(db).ListBySPSControllerID(context.Background(), controllers[0].ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 1 {
+		t.Fatalf("slots = %+v", slots)
+	}
+	slot := slots[0]
+	if slot.FieldDeviceID != devices[0].ID || slot.SystemTypeID != systemType.ID || slot.SoftwareType != domainFacility.BacnetSoftwareTypeAI || slot.SoftwareNumber != 1 {
+		t.Fatalf("slot = %+v", slot)
+	}
+}
@@ -0,0 +1,68 @@
+package facilitysql
+
+import (
+	"context"
+	"strings"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"gorm.io/gorm"
+)
+
+type bacnetObjectSelectionRepo struct {
+	db *gorm.DB
+}
+
+func NewBacnetObjectSelectionRepository(db *gorm.DB) domainFacility.BacnetObjectSelectionRepository {
+	return &bacnetObjectSelectionRepo{db: db}
+}
+
+func (r *bacnetObjectSelectionRepo) Select(ctx context.Context, selection domainFacility.BacnetObjectSelection, limit int) ([]domainFacility.SelectedBacnetObject, error) {
+	query := r.db.WithContext(ctx).
+		Table("bacnet_objects").
+		Select("bacnet_objects.id, bacnet_objects.version").
+		Joins("JOIN field_devices ON field_devices.id = bacnet_objects.field_device_id")
+	query = activeFieldDevices(query)
+
+	if selection.SPSControllerID != nil {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM sps_controller_system_types selection_scts
+			WHERE selection_scts.id = field_devices.sps_controller_system_type_id AND selection_scts.sps_controller_id = ?
+		)`, *selection.SPSControllerID)
+	}
+	if selection.ProjectID != nil {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM project_field_devices pfd
+			WHERE pfd.field_device_id = field_devices.id AND pfd.project_id = ?
+		)`, *selection.ProjectID)
+	}
+	if selection.SystemPartID != nil {
+		query = query.Where("field_devices.system_part_id = ?", *selection.SystemPartID)
+	}
+	if selection.ApparatID != nil {
+		query = query.Where("field_devices.apparat_id = ?", *selection.ApparatID)
+	}
+	if selection.AlarmTypeID != nil {
+		query = query.Where("bacnet_objects.alarm_type_id = ?", *selection.AlarmTypeID)
+	}
+	if pattern := textFixPattern(selection.TextFix); pattern != "" {
+		query = query.Where("LOWER(bacnet_objects.text_fix) LIKE ? ESCAPE '\\'", pattern)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var rows []domainFacility.SelectedBacnetObject
+	err := query.Order("bacnet_objects.id ASC").Scan(&rows).Error
+	return rows, err
+}
+
+// textFixPattern turns a * wildcard filter into a lower-cased LIKE pattern
+// with the LIKE metacharacters of the input escaped.
+func textFixPattern(filter string) string {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return ""
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter)
+	return strings.ReplaceAll(escaped, "*", "%")
+}
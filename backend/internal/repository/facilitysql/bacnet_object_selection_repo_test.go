@@ -0,0 +1,17 @@
+package facilitysql
+
+import "testing"
+
+func TestTextFixPattern(t *testing.T) {
+	cases := map[string]string{
+		"":          "",
+		"  TF_01 ":  `tf\_01`,
+		"B01*_SOLL": `b01%\_soll`,
+		"100%":      `100\%`,
+	}
+	for input, want := range cases {
+		if got := textFixPattern(input); got != want {
+			t.Fatalf("textFixPattern(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -27,6 +27,7 @@ type BacnetObjectTemplateRecord struct {
 	StateTextID         *uuid.UUID `gorm:"type:uuid;index"`
 	NotificationClassID *uuid.UUID `gorm:"type:uuid;index"`
 	AlarmTypeID         *uuid.UUID `gorm:"type:uuid;index"`
+	AlarmDefinitionID   *uuid.UUID `gorm:"type:uuid;index"`
 }
 
 func (BacnetObjectTemplateRecord) TableName() string { return "bacnet_object_templates" }
@@ -60,6 +61,7 @@ func templateRecordToDomain(record BacnetObjectTemplateRecord) domainObjectData.
 		HardwareType: record.HardwareType, HardwareQuantity: record.HardwareQuantity,
 		SoftwareReferenceID: record.SoftwareReferenceID, StateTextID: record.StateTextID,
 		NotificationClassID: record.NotificationClassID, AlarmTypeID: record.AlarmTypeID,
+		AlarmDefinitionID: record.AlarmDefinitionID,
 	}
 }
 
@@ -90,5 +92,6 @@ func templateRecordFromDomain(template domainObjectData.BacnetObjectTemplate) Ba
 		HardwareType: template.HardwareType, HardwareQuantity: template.HardwareQuantity,
 		SoftwareReferenceID: template.SoftwareReferenceID, StateTextID: template.StateTextID,
 		NotificationClassID: template.NotificationClassID, AlarmTypeID: template.AlarmTypeID,
+		AlarmDefinitionID: template.AlarmDefinitionID,
 	}
 }
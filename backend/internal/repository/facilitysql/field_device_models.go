@@ -21,6 +21,9 @@ type FieldDeviceRecord struct {
 	ApparatID                 uuid.UUID                              `gorm:"type:uuid;not null;index"`
 	Apparat                   domainFacility.Apparat                 `gorm:"foreignKey:ApparatID"`
 	BacnetObjects             []domainFacility.BacnetObject          `gorm:"foreignKey:FieldDeviceID"`
+
+	ObjectDataID       *uuid.UUID `gorm:"type:uuid;index"`
+	ObjectDataRevision *uint64
 }
 
 func (FieldDeviceRecord) TableName() string {
@@ -41,6 +44,8 @@ func toFieldDeviceRecord(entity *domainFacility.FieldDevice) *FieldDeviceRecord
 		SPSControllerSystemTypeID: entity.SPSControllerSystemTypeID,
 		SystemPartID:              entity.SystemPartID,
 		ApparatID:                 entity.ApparatID,
+		ObjectDataID:              entity.ObjectDataID,
+		ObjectDataRevision:        entity.ObjectDataRevision,
 	}
 }
 
@@ -62,6 +67,8 @@ func toFieldDeviceDomain(record *FieldDeviceRecord) *domainFacility.FieldDevice
 		ApparatID:                 record.ApparatID,
 		Apparat:                   record.Apparat,
 		BacnetObjects:             record.BacnetObjects,
+		ObjectDataID:              record.ObjectDataID,
+		ObjectDataRevision:        record.ObjectDataRevision,
 	}
 }
 
@@ -86,6 +93,8 @@ type fieldDeviceListRow struct {
 	SystemPartID              uuid.UUID  `gorm:"column:system_part_id"`
 	SpecificationID           *uuid.UUID `gorm:"column:canonical_specification_id"`
 	ApparatID                 uuid.UUID  `gorm:"column:apparat_id"`
+	ObjectDataID              *uuid.UUID `gorm:"column:object_data_id"`
+	ObjectDataRevision        *uint64    `gorm:"column:object_data_revision"`
 
 	SPSSystemTypeID         *uuid.UUID `gorm:"column:sps_system_type_id"`
 	SPSSystemTypeCreatedAt  *time.Time `gorm:"column:sps_system_type_created_at"`
@@ -126,6 +135,8 @@ func (row fieldDeviceListRow) toDomain() domainFacility.FieldDevice {
 		SystemPartID:              row.SystemPartID,
 		SpecificationID:           row.SpecificationID,
 		ApparatID:                 row.ApparatID,
+		ObjectDataID:              row.ObjectDataID,
+		ObjectDataRevision:        row.ObjectDataRevision,
 	}
 
 	if row.SPSSystemTypeID != nil {
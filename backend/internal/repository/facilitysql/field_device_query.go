@@ -26,6 +26,8 @@ const (
 		field_devices.system_part_id,
 		specs_list.id AS canonical_specification_id,
 		field_devices.apparat_id,
+		field_devices.object_data_id,
+		field_devices.object_data_revision,
 		scts_list.id AS sps_system_type_id,
 		scts_list.created_at AS sps_system_type_created_at,
 		scts_list.updated_at AS sps_system_type_updated_at,
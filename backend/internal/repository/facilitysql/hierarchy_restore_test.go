@@ -14,13 +14,15 @@ import (
 
 type restoreParents struct {
 	cabinetID    uuid.UUID
+	controllerID uuid.UUID
 	assignmentID uuid.UUID
 	partID       uuid.UUID
 	apparatID    uuid.UUID
 }
 
 type restoreVersionSeed struct {
-	cabinetID uuid.UUID
+	scopeType string
+	scopeID   uuid.UUID
 	entityID  uuid.UUID
 	snapshot  domainHistory.JSONB
 	versionAt time.Time
@@ -56,14 +58,14 @@ func TestHierarchyRestoreChunksDeleteAndRestoreAgainstOneSnapshot(t *testing.T)
 		t.Fatalf("load remove snapshot: %v", err)
 	}
 	seedRestoreVersion(t, db, restoreVersionSeed{
-		cabinetID: parents.cabinetID, entityID: keep.ID, snapshot: keepSnapshot, versionAt: asOf.Add(-time.Minute),
+		scopeID: parents.cabinetID, entityID: keep.ID, snapshot: keepSnapshot, versionAt: asOf.Add(-time.Minute),
 	})
 	seedRestoreVersion(t, db, restoreVersionSeed{
-		cabinetID: parents.cabinetID, entityID: remove.ID, snapshot: removeSnapshot, versionAt: asOf.Add(time.Minute),
+		scopeID: parents.cabinetID, entityID: remove.ID, snapshot: removeSnapshot, versionAt: asOf.Add(time.Minute),
 	})
 
 	command := hierarchyrestore.Command{
-		ControlCabinetID: parents.cabinetID, AsOf: asOf, Table: "field_devices",
+		Root: hierarchyrestore.RootControlCabinet, RootID: parents.cabinetID, AsOf: asOf, Table: "field_devices",
 		Limit: 1, ActorID: uuid.New(), BatchID: uuid.New(),
 	}
 	command.Phase = hierarchyrestore.PhaseDelete
@@ -83,6 +85,57 @@ func TestHierarchyRestoreChunksDeleteAndRestoreAgainstOneSnapshot(t *testing.T)
 	}
 }
 
+func TestHierarchyRestorePreviewClassifiesControllerSubtree(t *testing.T) {
+	db := newFieldDeviceRepoTestDB(t)
+	if err := db.AutoMigrate(&domainFacility.Building{}, &domainFacility.ControlCabinet{}); err != nil {
+		t.Fatalf("migrate hierarchy: %v", err)
+	}
+	if err := historysql.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate history: %v", err)
+	}
+	parents := seedRestoreParents(t, db)
+	asOf := time.Now().UTC()
+	store := historysql.NewStore(db)
+	keep := seedFacilityRecord(t, db, &FieldDeviceRecord{
+		SPSControllerSystemTypeID: parents.assignmentID, SystemPartID: parents.partID, ApparatID: parents.apparatID, ApparatNr: 1,
+	})
+	keepSnapshot, _, err := store.LoadRow(t.Context(), "field_devices", keep.ID)
+	if err != nil {
+		t.Fatalf("load keep snapshot: %v", err)
+	}
+	remove := seedFacilityRecord(t, db, &FieldDeviceRecord{
+		SPSControllerSystemTypeID: parents.assignmentID, SystemPartID: parents.partID, ApparatID: parents.apparatID, ApparatNr: 2,
+	})
+	removeSnapshot, _, err := store.LoadRow(t.Context(), "field_devices", remove.ID)
+	if err != nil {
+		t.Fatalf("load remove snapshot: %v", err)
+	}
+	seedRestoreVersion(t, db, restoreVersionSeed{
+		scopeType: "sps_controller", scopeID: parents.controllerID, entityID: keep.ID,
+		snapshot: keepSnapshot, versionAt: asOf.Add(-time.Minute),
+	})
+	seedRestoreVersion(t, db, restoreVersionSeed{
+		scopeType: "sps_controller", scopeID: parents.controllerID, entityID: remove.ID,
+		snapshot: removeSnapshot, versionAt: asOf.Add(time.Minute),
+	})
+	if err := db.Model(&FieldDeviceRecord{}).Where("id = ?", keep.ID).Update("version", 7).Error; err != nil {
+		t.Fatalf("change field device outside history: %v", err)
+	}
+
+	preview, err := store.PreviewHierarchyRestore(t.Context(), hierarchyrestore.RootSPSController, parents.controllerID,
+		domainHistory.RestoreHierarchyRequest{AsOf: &asOf})
+	if err != nil {
+		t.Fatalf("preview restore: %v", err)
+	}
+	if preview.UpdateCount != 1 || preview.DeleteCount != 1 || preview.RecreateCount != 0 {
+		t.Fatalf("unexpected preview counts: %+v", preview)
+	}
+	if len(preview.Conflicts) != 1 || preview.Conflicts[0].EntityID != keep.ID {
+		t.Fatalf("expected one conflict for the untracked change, got %+v", preview.Conflicts)
+	}
+	assertEntityExists(t, db, remove.ID, true)
+}
+
 func seedRestoreParents(t *testing.T, db *gorm.DB) restoreParents {
 	building := seedFacilityRecord(t, db, &domainFacility.Building{IWSCode: "RST", BuildingGroup: 1})
 	cabinet := seedFacilityRecord(t, db, &domainFacility.ControlCabinet{BuildingID: building.ID})
@@ -91,7 +144,7 @@ func seedRestoreParents(t *testing.T, db *gorm.DB) restoreParents {
 	assignment := seedFacilityRecord(t, db, &domainFacility.SPSControllerSystemType{SPSControllerID: controller.ID, SystemTypeID: typeDefinition.ID})
 	part := seedFacilityRecord(t, db, &domainFacility.SystemPart{ShortName: "RST-P", Name: "Restore Part"})
 	apparat := seedFacilityRecord(t, db, &domainFacility.Apparat{ShortName: "RST-A", Name: "Restore Apparat"})
-	return restoreParents{cabinetID: cabinet.ID, assignmentID: assignment.ID, partID: part.ID, apparatID: apparat.ID}
+	return restoreParents{cabinetID: cabinet.ID, controllerID: controller.ID, assignmentID: assignment.ID, partID: part.ID, apparatID: apparat.ID}
 }
 
 func seedRestoreVersion(t *testing.T, db *gorm.DB, seed restoreVersionSeed) {
@@ -103,9 +156,13 @@ func seedRestoreVersion(t *testing.T, db *gorm.DB, seed restoreVersionSeed) {
 	if err := db.Create(&event).Error; err != nil {
 		t.Fatalf("create restore event: %v", err)
 	}
+	scopeType := seed.scopeType
+	if scopeType == "" {
+		scopeType = "control_cabinet"
+	}
 	if err := db.Create(&domainHistory.ChangeEventScope{
-		ID: uuid.New(), ChangeEventID: eventID, ScopeType: "control_cabinet",
-		ScopeID: seed.cabinetID, OccurredAt: seed.versionAt,
+		ID: uuid.New(), ChangeEventID: eventID, ScopeType: scopeType,
+		ScopeID: seed.scopeID, OccurredAt: seed.versionAt,
 	}).Error; err != nil {
 		t.Fatalf("create restore scope: %v", err)
 	}
@@ -0,0 +1,87 @@
+package facilitysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/besart951/go_infra_link/backend/internal/repository/gormbase"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ipPoolRepo struct {
+	*gormbase.BaseRepository[*domainFacility.IPPool]
+	db *gorm.DB
+}
+
+type spsControllerAddressRow struct {
+	SPSControllerID uuid.UUID `gorm:"column:sps_controller_id"`
+	BuildingID      uuid.UUID `gorm:"column:building_id"`
+	DeviceName      string    `gorm:"column:device_name"`
+	IPAddress       *string   `gorm:"column:ip_address"`
+	Subnet          *string   `gorm:"column:subnet"`
+	Gateway         *string   `gorm:"column:gateway"`
+	Vlan            *string   `gorm:"column:vlan"`
+}
+
+func NewIPPoolRepository(db *gorm.DB) domainFacility.IPPoolRepository {
+	baseRepo := gormbase.NewBaseRepository(db,
+		gormbase.TrigramSearchCallback[*domainFacility.IPPool](
+			gormbase.SearchColumn("name"), gormbase.SearchColumn("network"), gormbase.SearchColumn("vlan"),
+		),
+	)
+	return &ipPoolRepo{BaseRepository: baseRepo, db: db}
+}
+
+func (r *ipPoolRepo) GetPaginatedList(ctx context.Context, params domain.PaginationParams) (*domain.PaginatedList[domainFacility.IPPool], error) {
+	result, err := r.BaseRepository.GetPaginatedList(ctx, params, 50)
+	if err != nil {
+		return nil, err
+	}
+	return gormbase.DerefPaginatedList(result), nil
+}
+
+func (r *ipPoolRepo) List(ctx context.Context, filter domainFacility.IPPoolFilter) ([]domainFacility.IPPool, error) {
+	query := r.db.WithContext(ctx).Model(&domainFacility.IPPool{})
+	if filter.BuildingID != nil {
+		query = query.Where("building_id = ?", *filter.BuildingID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if vlan := strings.TrimSpace(filter.Vlan); vlan != "" {
+		query = query.Where("vlan = ?", vlan)
+	}
+	var pools []domainFacility.IPPool
+	if err := query.Order("vlan ASC, network ASC, id ASC").Find(&pools).Error; err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// ListControllerAddresses deliberately includes tombstoned controllers: the
+// VLAN/IP unique index still holds their rows until they are purged.
+func (r *ipPoolRepo) ListControllerAddresses(ctx context.Context, filter domainFacility.IPPoolFilter) ([]domainFacility.SPSControllerAddress, error) {
+	query := r.db.WithContext(ctx).Table("sps_controllers").
+		Select(`sps_controllers.id AS sps_controller_id, control_cabinets.building_id, sps_controllers.device_name,
+			sps_controllers.ip_address, sps_controllers.subnet, sps_controllers.gateway, sps_controllers.vlan`).
+		Joins("JOIN control_cabinets ON control_cabinets.id = sps_controllers.control_cabinet_id").
+		Where("TRIM(sps_controllers.vlan) = ?", strings.TrimSpace(filter.Vlan))
+	if filter.BuildingID != nil {
+		query = query.Where("control_cabinets.building_id = ?", *filter.BuildingID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM project_sps_controllers link WHERE link.sps_controller_id = sps_controllers.id AND link.project_id = ?)", *filter.ProjectID)
+	}
+	var rows []spsControllerAddressRow
+	if err := query.Order("sps_controllers.device_name ASC, sps_controllers.id ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	addresses := make([]domainFacility.SPSControllerAddress, len(rows))
+	for index, row := range rows {
+		addresses[index] = domainFacility.SPSControllerAddress(row)
+	}
+	return addresses, nil
+}
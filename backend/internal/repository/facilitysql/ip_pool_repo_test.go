@@ -0,0 +1,46 @@
+package facilitysql
+
+import (
+	"context"
+	"testing"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func TestIPPoolRepo_ListControllerAddressesScopesByBuildingAndVlan(t *testing.T) {
+	db := newBacnetReferenceUsageRepoTestDB(t)
+	if err := db.AutoMigrate(&domainFacility.ControlCabinet{}, &domainFacility.IPPool{}); err != nil {
+		t.Fatal(err)
+	}
+	buildingID, otherBuildingID := uuid.New(), uuid.New()
+	cabinet := seedBacnetReferenceUsageRecord(t, db, &domainFacility.ControlCabinet{BuildingID: buildingID})
+	otherCabinet := seedBacnetReferenceUsageRecord(t, db, &domainFacility.ControlCabinet{BuildingID: otherBuildingID})
+	vlan, otherVlan := "10", "20"
+	for _, controller := range []*domainFacility.SPSController{
+		{ControlCabinetID: cabinet.ID, DeviceName: "SPS-A", IPAddress: stringPtr("10.0.0.5"), Vlan: &vlan},
+		{ControlCabinetID: cabinet.ID, DeviceName: "SPS-B", IPAddress: stringPtr("10.0.0.6"), Vlan: &otherVlan},
+		{ControlCabinetID: otherCabinet.ID, DeviceName: "SPS-C", IPAddress: stringPtr("10.0.0.7"), Vlan: &vlan},
+	} {
+		seedBacnetReferenceUsageRecord(t, db, controller)
+	}
+	repo := NewIPPoolRepository(db)
+
+	scoped, err := repo.ListControllerAddresses(context.Background(), domainFacility.IPPoolFilter{BuildingID: &buildingID, Vlan: vlan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := repo.ListControllerAddresses(context.Background(), domainFacility.IPPoolFilter{Vlan: vlan})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scoped) != 1 || scoped[0].DeviceName != "SPS-A" || scoped[0].BuildingID != buildingID {
+		t.Fatalf("scoped addresses = %+v", scoped)
+	}
+	if len(all) != 2 {
+		t.Fatalf("vlan-wide addresses = %+v", all)
+	}
+}
+
+func stringPtr(value string) *string { return &value }
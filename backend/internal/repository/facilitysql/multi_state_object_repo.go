@@ -0,0 +1,70 @@
+package facilitysql
+
+import (
+	"context"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type multiStateObjectRepo struct {
+	db *gorm.DB
+}
+
+func NewMultiStateObjectRepository(db *gorm.DB) domainFacility.MultiStateObjectRepository {
+	return &multiStateObjectRepo{db: db}
+}
+
+func (r *multiStateObjectRepo) ListMultiStateObjects(ctx context.Context, after uuid.UUID, limit int) ([]domainFacility.MultiStateObject, error) {
+	query := r.db.WithContext(ctx).
+		Table("bacnet_objects").
+		Select(`bacnet_objects.id, bacnet_objects.field_device_id, bacnet_objects.text_fix,
+			bacnet_objects.software_type, bacnet_objects.software_number,
+			bacnet_objects.state_text_id`).
+		Joins("JOIN field_devices ON field_devices.id = bacnet_objects.field_device_id").
+		Where("bacnet_objects.software_type IN ?", []domainFacility.BacnetSoftwareType{
+			domainFacility.BacnetSoftwareTypeMI, domainFacility.BacnetSoftwareTypeMO, domainFacility.BacnetSoftwareTypeMV,
+		})
+	query = activeFieldDevices(query)
+	if after != uuid.Nil {
+		query = query.Where("bacnet_objects.id > ?", after)
+	}
+
+	var rows []domainFacility.MultiStateObject
+	if err := query.Order("bacnet_objects.id ASC").Limit(limit).Scan(&rows).Error; err != nil || len(rows) == 0 {
+		return rows, err
+	}
+	return rows, r.attachStateMaps(ctx, rows)
+}
+
+// attachStateMaps loads the state_map alarm values of the objects.
+func (r *multiStateObjectRepo) attachStateMaps(ctx context.Context, rows []domainFacility.MultiStateObject) error {
+	ids := make([]uuid.UUID, len(rows))
+	for index, row := range rows {
+		ids[index] = row.ID
+	}
+	var values []struct {
+		BacnetObjectID uuid.UUID
+		ValueJSON      string
+	}
+	err := r.db.WithContext(ctx).
+		Table("bacnet_object_alarm_values").
+		Select("bacnet_object_alarm_values.bacnet_object_id, bacnet_object_alarm_values.value_json").
+		Joins("JOIN alarm_type_fields ON alarm_type_fields.id = bacnet_object_alarm_values.alarm_type_field_id").
+		Joins("JOIN alarm_fields ON alarm_fields.id = alarm_type_fields.alarm_field_id").
+		Where("alarm_fields.data_type = ? AND bacnet_object_alarm_values.value_json IS NOT NULL", "state_map").
+		Where("bacnet_object_alarm_values.bacnet_object_id IN ?", ids).
+		Scan(&values).Error
+	if err != nil {
+		return err
+	}
+	byObject := make(map[uuid.UUID][]string, len(values))
+	for _, value := range values {
+		byObject[value.BacnetObjectID] = append(byObject[value.BacnetObjectID], value.ValueJSON)
+	}
+	for index := range rows {
+		rows[index].StateMaps = byObject[rows[index].ID]
+	}
+	return nil
+}
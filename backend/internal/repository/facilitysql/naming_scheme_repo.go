@@ -0,0 +1,93 @@
+package facilitysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/besart951/go_infra_link/backend/internal/repository/gormbase"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type namingSchemeRepo struct {
+	*gormbase.BaseRepository[*domainFacility.NamingScheme]
+	db *gorm.DB
+}
+
+func NewNamingSchemeRepository(db *gorm.DB) domainFacility.NamingSchemeRepository {
+	baseRepo := gormbase.NewBaseRepository(db,
+		gormbase.TrigramSearchCallback[*domainFacility.NamingScheme](
+			gormbase.SearchColumn("name"), gormbase.SearchColumn("client"),
+		),
+	)
+	return &namingSchemeRepo{BaseRepository: baseRepo, db: db}
+}
+
+func (r *namingSchemeRepo) GetPaginatedList(ctx context.Context, params domain.PaginationParams) (*domain.PaginatedList[domainFacility.NamingScheme], error) {
+	result, err := r.BaseRepository.GetPaginatedList(ctx, params, 50)
+	if err != nil {
+		return nil, err
+	}
+	return gormbase.DerefPaginatedList(result), nil
+}
+
+func (r *namingSchemeRepo) List(ctx context.Context, filter domainFacility.NamingSchemeFilter) ([]domainFacility.NamingScheme, error) {
+	query := scopeNamingSchemes(r.db.WithContext(ctx).Model(&domainFacility.NamingScheme{}), filter.ProjectID)
+	if client := strings.TrimSpace(filter.Client); client != "" {
+		query = query.Where("client = ?", client)
+	}
+	var schemes []domainFacility.NamingScheme
+	if err := query.Order("client ASC, name ASC, id ASC").Find(&schemes).Error; err != nil {
+		return nil, err
+	}
+	return schemes, nil
+}
+
+func (r *namingSchemeRepo) GetDefault(ctx context.Context, projectID *uuid.UUID) (*domainFacility.NamingScheme, error) {
+	var schemes []domainFacility.NamingScheme
+	err := scopeNamingSchemes(r.db.WithContext(ctx).Model(&domainFacility.NamingScheme{}), projectID).
+		Where("is_default = ?", true).
+		Order("updated_at DESC, id ASC").
+		Limit(1).
+		Find(&schemes).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(schemes) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &schemes[0], nil
+}
+
+func (r *namingSchemeRepo) ListDefaultsForControlCabinet(ctx context.Context, controlCabinetID uuid.UUID) ([]domainFacility.NamingScheme, error) {
+	var schemes []domainFacility.NamingScheme
+	err := r.db.WithContext(ctx).
+		Model(&domainFacility.NamingScheme{}).
+		Where("naming_schemes.is_default = ?", true).
+		Where("naming_schemes.project_id IN (SELECT link.project_id FROM project_control_cabinets link WHERE link.control_cabinet_id = ?)", controlCabinetID).
+		Order("naming_schemes.id ASC").
+		Find(&schemes).Error
+	return schemes, err
+}
+
+// ClearDefault unsets the default flag of every other scheme of the scope and
+// bumps their version so stale edits of those schemes conflict.
+func (r *namingSchemeRepo) ClearDefault(ctx context.Context, projectID *uuid.UUID, exceptID uuid.UUID) error {
+	return scopeNamingSchemes(r.db.WithContext(ctx).Model(&domainFacility.NamingScheme{}), projectID).
+		Where("is_default = ? AND id <> ?", true, exceptID).
+		Updates(map[string]any{
+			"is_default": false,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+func scopeNamingSchemes(query *gorm.DB, projectID *uuid.UUID) *gorm.DB {
+	if projectID == nil {
+		return query.Where("project_id IS NULL")
+	}
+	return query.Where("project_id = ?", *projectID)
+}
@@ -201,6 +201,9 @@ func updateObjectData(ctx context.Context, tx *gorm.DB, entity *domainFacility.O
 			"is_active":   entity.IsActive,
 			"updated_at":  entity.UpdatedAt,
 			"version":     entity.GetBase().Version,
+
+			"forked_from_id":       entity.ForkedFromID,
+			"forked_from_revision": entity.ForkedFromRevision,
 		})
 	if result.Error != nil {
 		entity.GetBase().Version = expectedVersion
@@ -301,7 +304,7 @@ func templateRecordToBacnetObject(record BacnetObjectTemplateRecord, alarms []do
 		SoftwareNumber: record.SoftwareNumber, HardwareType: record.HardwareType,
 		HardwareQuantity: record.HardwareQuantity, SoftwareReferenceID: record.SoftwareReferenceID,
 		StateTextID: record.StateTextID, NotificationClassID: record.NotificationClassID,
-		AlarmTypeID: record.AlarmTypeID, AlarmValues: alarms,
+		AlarmTypeID: record.AlarmTypeID, AlarmDefinitionID: record.AlarmDefinitionID, AlarmValues: alarms,
 	}
 }
 
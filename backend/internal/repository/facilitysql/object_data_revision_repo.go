@@ -0,0 +1,133 @@
+package facilitysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/besart951/go_infra_link/backend/internal/repository/postgreserror"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const objectDataRevisionConstraint = "idx_object_data_revision"
+
+// ObjectDataRevisionRecord stores a frozen copy of the templates as JSON, so
+// revisions stay readable after the live templates were rewritten.
+type ObjectDataRevisionRecord struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ObjectDataID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_object_data_revision"`
+	Revision     uint64    `gorm:"not null;uniqueIndex:idx_object_data_revision"`
+	Label        string    `gorm:"not null;default:''"`
+	Fingerprint  string    `gorm:"type:varchar(64);not null"`
+	Templates    string    `gorm:"type:text;not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
+
+func (ObjectDataRevisionRecord) TableName() string { return "object_data_revisions" }
+
+type objectDataRevisionRepo struct {
+	db *gorm.DB
+}
+
+func NewObjectDataRevisionRepository(db *gorm.DB) domainObjectData.TemplateRevisionStore {
+	return &objectDataRevisionRepo{db: db}
+}
+
+func (r *objectDataRevisionRepo) Create(ctx context.Context, revision *domainObjectData.TemplateRevision) error {
+	if revision == nil || revision.ObjectDataID == uuid.Nil || revision.Revision == 0 {
+		return domain.ErrInvalidArgument
+	}
+	templates, err := json.Marshal(revision.Templates)
+	if err != nil {
+		return err
+	}
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now().UTC()
+	}
+	record := ObjectDataRevisionRecord{
+		ID: uuid.New(), ObjectDataID: revision.ObjectDataID, Revision: revision.Revision,
+		Label: revision.Label, Fingerprint: revision.Fingerprint, Templates: string(templates),
+		CreatedAt: revision.CreatedAt,
+	}
+	err = r.db.WithContext(ctx).Create(&record).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) || postgreserror.IsUniqueConstraint(err, objectDataRevisionConstraint) {
+		return domain.ErrConflict
+	}
+	return err
+}
+
+func (r *objectDataRevisionRepo) Get(ctx context.Context, objectDataID uuid.UUID, revision uint64) (*domainObjectData.TemplateRevision, error) {
+	var record ObjectDataRevisionRecord
+	err := r.db.WithContext(ctx).Where("object_data_id = ? AND revision = ?", objectDataID, revision).Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revisionRecordToDomain(record, true)
+}
+
+func (r *objectDataRevisionRepo) Latest(ctx context.Context, objectDataID uuid.UUID) (*domainObjectData.TemplateRevision, error) {
+	var record ObjectDataRevisionRecord
+	err := r.db.WithContext(ctx).Where("object_data_id = ?", objectDataID).Order("revision DESC").Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revisionRecordToDomain(record, true)
+}
+
+func (r *objectDataRevisionRepo) List(ctx context.Context, objectDataID uuid.UUID) ([]domainObjectData.TemplateRevision, error) {
+	var records []ObjectDataRevisionRecord
+	err := r.db.WithContext(ctx).Omit("templates").Where("object_data_id = ?", objectDataID).
+		Order("revision DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domainObjectData.TemplateRevision, len(records))
+	for i := range records {
+		item, _ := revisionRecordToDomain(records[i], false)
+		items[i] = *item
+	}
+	return items, nil
+}
+
+func (r *objectDataRevisionRepo) ListFieldDevices(ctx context.Context, objectDataID uuid.UUID) ([]domainObjectData.TemplateFieldDevice, error) {
+	var rows []struct {
+		ID                 uuid.UUID
+		Version            uint64
+		ObjectDataRevision uint64
+	}
+	err := activeFieldDevices(r.db.WithContext(ctx).Model(&FieldDeviceRecord{})).
+		Select("field_devices.id, field_devices.version, field_devices.object_data_revision").
+		Where("field_devices.object_data_id = ? AND field_devices.object_data_revision IS NOT NULL", objectDataID).
+		Order("field_devices.id ASC").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domainObjectData.TemplateFieldDevice, len(rows))
+	for i, row := range rows {
+		items[i] = domainObjectData.TemplateFieldDevice{FieldDeviceID: row.ID, Version: row.Version, Revision: row.ObjectDataRevision}
+	}
+	return items, nil
+}
+
+func revisionRecordToDomain(record ObjectDataRevisionRecord, withTemplates bool) (*domainObjectData.TemplateRevision, error) {
+	revision := &domainObjectData.TemplateRevision{
+		ObjectDataID: record.ObjectDataID, Revision: record.Revision, Label: record.Label,
+		Fingerprint: record.Fingerprint, CreatedAt: record.CreatedAt,
+	}
+	if withTemplates {
+		if err := json.Unmarshal([]byte(record.Templates), &revision.Templates); err != nil {
+			return nil, err
+		}
+	}
+	return revision, nil
+}
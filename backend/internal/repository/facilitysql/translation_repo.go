@@ -0,0 +1,52 @@
+package facilitysql
+
+import (
+	"context"
+	"time"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type translationRepo struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepository(db *gorm.DB) domainFacility.TranslationRepository {
+	return &translationRepo{db: db}
+}
+
+func (r *translationRepo) ListByEntities(ctx context.Context, entityIDs []uuid.UUID, locales []string) ([]domainFacility.Translation, error) {
+	if len(entityIDs) == 0 {
+		return []domainFacility.Translation{}, nil
+	}
+	query := r.db.WithContext(ctx).Where("entity_id IN ?", entityIDs)
+	if len(locales) > 0 {
+		query = query.Where("locale IN ?", locales)
+	}
+	var items []domainFacility.Translation
+	err := query.Order("entity_id ASC, locale ASC, field ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *translationRepo) ReplaceLocale(ctx context.Context, resource domainFacility.TranslationResource, entityID uuid.UUID, locale string, items []domainFacility.Translation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource = ? AND entity_id = ? AND locale = ?", resource, entityID, locale).
+			Delete(&domainFacility.Translation{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		for i := range items {
+			items[i].Resource, items[i].EntityID, items[i].Locale = resource, entityID, locale
+			if err := items[i].InitForCreate(now); err != nil {
+				return err
+			}
+		}
+		return tx.Create(&items).Error
+	})
+}
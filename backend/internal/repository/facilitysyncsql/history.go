@@ -0,0 +1,169 @@
+package facilitysyncsql
+
+import (
+	"context"
+	"slices"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// versionedRows are the rows of one table the apply writes, with the version
+// each will have afterwards.
+type versionedRows struct {
+	table    string
+	model    any
+	versions map[uuid.UUID]uint64
+	ids      []uuid.UUID
+}
+
+func newVersionedRows[T any](table string, model any, rows []T, id func(T) uuid.UUID, version func(T) uint64) versionedRows {
+	set := versionedRows{table: table, model: model, versions: make(map[uuid.UUID]uint64, len(rows)), ids: make([]uuid.UUID, 0, len(rows))}
+	for _, row := range rows {
+		set.versions[id(row)] = version(row)
+		set.ids = append(set.ids, id(row))
+	}
+	return set
+}
+
+// writtenRows lists the bundle's rows in hierarchy order.
+func writtenRows(writes facilitysync.Bundle) []versionedRows {
+	return []versionedRows{
+		newVersionedRows("buildings", &domainFacility.Building{}, writes.Buildings,
+			func(row facilitysync.Building) uuid.UUID { return row.ID }, func(row facilitysync.Building) uint64 { return row.Version }),
+		newVersionedRows("control_cabinets", &domainFacility.ControlCabinet{}, writes.ControlCabinets,
+			func(row facilitysync.ControlCabinet) uuid.UUID { return row.ID }, func(row facilitysync.ControlCabinet) uint64 { return row.Version }),
+		newVersionedRows("sps_controllers", &domainFacility.SPSController{}, writes.SPSControllers,
+			func(row facilitysync.SPSController) uuid.UUID { return row.ID }, func(row facilitysync.SPSController) uint64 { return row.Version }),
+		newVersionedRows("sps_controller_system_types", &domainFacility.SPSControllerSystemType{}, writes.SystemTypes,
+			func(row facilitysync.SPSControllerSystemType) uuid.UUID { return row.ID }, func(row facilitysync.SPSControllerSystemType) uint64 { return row.Version }),
+		newVersionedRows("field_devices", &facilitysql.FieldDeviceRecord{}, writes.FieldDevices,
+			func(row domainExport.DocumentFieldDevice) uuid.UUID { return row.ID }, func(row domainExport.DocumentFieldDevice) uint64 { return row.Version }),
+		newVersionedRows("specifications", &domainFacility.Specification{}, writes.Specifications,
+			func(row domainExport.DocumentSpecification) uuid.UUID { return row.ID }, func(row domainExport.DocumentSpecification) uint64 { return row.Version }),
+		newVersionedRows("bacnet_objects", &domainFacility.BacnetObject{}, writes.BacnetObjects,
+			func(row domainExport.DocumentBacnetObject) uuid.UUID { return row.ID }, func(row domainExport.DocumentBacnetObject) uint64 { return row.Version }),
+		newVersionedRows("bacnet_object_alarm_values", &domainFacility.BacnetObjectAlarmValue{}, writes.AlarmValues,
+			func(row domainExport.DocumentAlarmValue) uuid.UUID { return row.ID }, func(row domainExport.DocumentAlarmValue) uint64 { return row.Version }),
+	}
+}
+
+// checkVersions locks the rows about to be written and refuses the apply when
+// one was created, changed or deleted locally after the plan was made.
+func checkVersions(tx *gorm.DB, rows versionedRows) error {
+	seen := make(map[uuid.UUID]struct{}, len(rows.ids))
+	for chunk := range slices.Chunk(uniqueIDs(rows.ids), idChunkSize) {
+		var current []struct {
+			ID      uuid.UUID
+			Version uint64
+		}
+		if err := tx.Model(rows.model).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "version").Where("id IN ?", chunk).Find(&current).Error; err != nil {
+			return err
+		}
+		for _, row := range current {
+			seen[row.ID] = struct{}{}
+			if rows.versions[row.ID] != row.Version+1 {
+				return domain.ErrConflict
+			}
+		}
+	}
+	for id, version := range rows.versions {
+		if _, ok := seen[id]; !ok && version != 1 {
+			return domain.ErrConflict
+		}
+	}
+	return nil
+}
+
+// syncAudit records every row an apply writes as one history batch, so the
+// import shows in the timeline and can be undone like other batch edits.
+type syncAudit struct {
+	history   *historysql.Store
+	batchID   uuid.UUID
+	projectID uuid.UUID
+	tables    []string
+	ids       map[string][]uuid.UUID
+	before    map[string]map[uuid.UUID]domainHistory.JSONB
+}
+
+func newSyncAudit(history *historysql.Store, tx *gorm.DB, projectID uuid.UUID) (*syncAudit, error) {
+	if history == nil {
+		return nil, nil
+	}
+	batchID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	return &syncAudit{
+		history: history.WithDB(tx), batchID: batchID, projectID: projectID,
+		ids: map[string][]uuid.UUID{}, before: map[string]map[uuid.UUID]domainHistory.JSONB{},
+	}, nil
+}
+
+// capture snapshots the rows before they are overwritten.
+func (a *syncAudit) capture(ctx context.Context, table string, ids []uuid.UUID) error {
+	if a == nil || len(ids) == 0 {
+		return nil
+	}
+	before, err := a.history.LoadRows(ctx, table, ids)
+	if err != nil {
+		return err
+	}
+	a.created(table, ids)
+	if a.before[table] == nil {
+		a.before[table] = map[uuid.UUID]domainHistory.JSONB{}
+	}
+	for id, row := range before {
+		a.before[table][id] = row
+	}
+	return nil
+}
+
+// created registers rows the apply inserted itself.
+func (a *syncAudit) created(table string, ids []uuid.UUID) {
+	if a == nil || len(ids) == 0 {
+		return
+	}
+	if !slices.Contains(a.tables, table) {
+		a.tables = append(a.tables, table)
+	}
+	a.ids[table] = append(a.ids[table], ids...)
+}
+
+func (a *syncAudit) record(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+	for _, table := range a.tables {
+		after, err := a.history.LoadRows(ctx, table, a.ids[table])
+		if err != nil {
+			return err
+		}
+		for _, id := range a.ids[table] {
+			if len(after[id]) == 0 {
+				continue
+			}
+			mutation := historysql.Mutation{
+				Action: domainHistory.ActionCreate, EntityTable: table, EntityID: id,
+				BeforeJSON: a.before[table][id], AfterJSON: after[id], BatchID: &a.batchID,
+				Summary:  "facility sync bundle applied",
+				Metadata: map[string]any{"project_id": a.projectID.String()},
+			}
+			if len(mutation.BeforeJSON) > 0 {
+				mutation.Action = domainHistory.ActionUpdate
+			}
+			if err := a.history.RecordMutation(ctx, mutation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package facilitysyncsql
+
+import (
+	"slices"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// findIn loads the rows whose column is one of ids, in chunks, ordered by ID.
+func findIn[T any](db *gorm.DB, column string, ids []uuid.UUID) ([]T, error) {
+	rows := make([]T, 0)
+	ids = uniqueIDs(ids)
+	for chunk := range slices.Chunk(ids, idChunkSize) {
+		var page []T
+		if err := db.Where(column+" IN ?", chunk).Order("id").Find(&page).Error; err != nil {
+			return nil, err
+		}
+		rows = append(rows, page...)
+	}
+	return rows, nil
+}
+
+func findByID[T, R any](db *gorm.DB, rows []R, id func(R) uuid.UUID) ([]T, error) {
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, id(row))
+	}
+	return findIn[T](db, "id", ids)
+}
+
+// findByIDOr loads the rows sharing an ID with rows plus those whose parent
+// column is one of parentIDs.
+func findByIDOr[T any](db *gorm.DB, ids []uuid.UUID, parentColumn string, parentIDs []uuid.UUID, id func(T) uuid.UUID) ([]T, error) {
+	byID, err := findIn[T](db, "id", ids)
+	if err != nil {
+		return nil, err
+	}
+	byParent, err := findIn[T](db, parentColumn, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueByID(append(byID, byParent...), id), nil
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := slices.Clone(ids)
+	slices.SortFunc(unique, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+	return slices.Compact(unique)
+}
+
+func uniqueByID[T any](rows []T, id func(T) uuid.UUID) []T {
+	seen := make(map[uuid.UUID]struct{}, len(rows))
+	unique := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if _, ok := seen[id(row)]; ok {
+			continue
+		}
+		seen[id(row)] = struct{}{}
+		unique = append(unique, row)
+	}
+	return unique
+}
+
+func setHierarchy(bundle *facilitysync.Bundle, buildings []domainFacility.Building, cabinets []domainFacility.ControlCabinet, controllers []domainFacility.SPSController, systemTypes []domainFacility.SPSControllerSystemType) {
+	bundle.Buildings = make([]facilitysync.Building, 0, len(buildings))
+	for _, row := range buildings {
+		bundle.Buildings = append(bundle.Buildings, facilitysync.Building{ID: row.ID, Version: row.Version, IWSCode: row.IWSCode, BuildingGroup: row.BuildingGroup})
+	}
+	bundle.ControlCabinets = make([]facilitysync.ControlCabinet, 0, len(cabinets))
+	for _, row := range cabinets {
+		bundle.ControlCabinets = append(bundle.ControlCabinets, facilitysync.ControlCabinet{
+			ID: row.ID, Version: row.Version, BuildingID: row.BuildingID, ControlCabinetNr: row.ControlCabinetNr,
+		})
+	}
+	bundle.SPSControllers = make([]facilitysync.SPSController, 0, len(controllers))
+	for _, row := range controllers {
+		bundle.SPSControllers = append(bundle.SPSControllers, facilitysync.SPSController{
+			ID: row.ID, Version: row.Version, ControlCabinetID: row.ControlCabinetID, GADevice: row.GADevice,
+			DeviceName: row.DeviceName, DeviceDescription: row.DeviceDescription, DeviceLocation: row.DeviceLocation,
+			IPAddress: row.IPAddress, Subnet: row.Subnet, Gateway: row.Gateway, Vlan: row.Vlan,
+			MACAddress: row.MACAddress,
+		})
+	}
+	bundle.SystemTypes = make([]facilitysync.SPSControllerSystemType, 0, len(systemTypes))
+	for _, row := range systemTypes {
+		bundle.SystemTypes = append(bundle.SystemTypes, facilitysync.SPSControllerSystemType{
+			ID: row.ID, Version: row.Version, SPSControllerID: row.SPSControllerID, SystemTypeID: row.SystemTypeID,
+			Number: row.Number, DocumentName: row.DocumentName,
+		})
+	}
+	sortByID(bundle.Buildings, func(row facilitysync.Building) uuid.UUID { return row.ID })
+	sortByID(bundle.ControlCabinets, func(row facilitysync.ControlCabinet) uuid.UUID { return row.ID })
+	sortByID(bundle.SPSControllers, func(row facilitysync.SPSController) uuid.UUID { return row.ID })
+	sortByID(bundle.SystemTypes, func(row facilitysync.SPSControllerSystemType) uuid.UUID { return row.ID })
+}
+
+func setDeviceRows(bundle *facilitysync.Bundle, specs []domainFacility.Specification, objects []domainFacility.BacnetObject, values []domainFacility.BacnetObjectAlarmValue) {
+	bundle.Specifications = make([]domainExport.DocumentSpecification, 0, len(specs))
+	for _, spec := range specs {
+		fieldDeviceID := uuid.Nil
+		if spec.FieldDeviceID != nil {
+			fieldDeviceID = *spec.FieldDeviceID
+		}
+		bundle.Specifications = append(bundle.Specifications, domainExport.NewDocumentSpecification(fieldDeviceID, spec))
+	}
+	bundle.BacnetObjects = make([]domainExport.DocumentBacnetObject, 0, len(objects))
+	for _, object := range objects {
+		fieldDeviceID := uuid.Nil
+		if object.FieldDeviceID != nil {
+			fieldDeviceID = *object.FieldDeviceID
+		}
+		bundle.BacnetObjects = append(bundle.BacnetObjects, domainExport.NewDocumentBacnetObject(fieldDeviceID, object))
+	}
+	bundle.AlarmValues = make([]domainExport.DocumentAlarmValue, 0, len(values))
+	for _, value := range values {
+		bundle.AlarmValues = append(bundle.AlarmValues, domainExport.NewDocumentAlarmValue(value.BacnetObjectID, value))
+	}
+	sortByID(bundle.FieldDevices, func(row domainExport.DocumentFieldDevice) uuid.UUID { return row.ID })
+}
+
+func sortByID[T any](rows []T, id func(T) uuid.UUID) {
+	slices.SortFunc(rows, func(a, b T) int {
+		left, right := id(a), id(b)
+		return slices.Compare(left[:], right[:])
+	})
+}
+
+// upsert builds an ON CONFLICT (id) clause updating every writable column of
+// model except created_at and the excluded ones.
+func upsert(tx *gorm.DB, model any, exclude ...string) (clause.OnConflict, error) {
+	statement := &gorm.Statement{DB: tx}
+	if err := statement.Parse(model); err != nil {
+		return clause.OnConflict{}, err
+	}
+	columns := make([]string, 0, len(statement.Schema.Fields))
+	for _, field := range statement.Schema.Fields {
+		if field.DBName == "" || !field.Updatable || !field.Creatable || field.DBName == "id" || field.DBName == "created_at" || slices.Contains(exclude, field.DBName) {
+			continue
+		}
+		columns = append(columns, field.DBName)
+	}
+	return clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoUpdates: clause.AssignmentColumns(columns)}, nil
+}
+
+func upsertRows[T any](tx *gorm.DB, rows []T, exclude ...string) error {
+	var model T
+	conflict, err := upsert(tx, &model, exclude...)
+	if err != nil {
+		return err
+	}
+	return save(tx, rows, conflict)
+}
+
+func save[T any](tx *gorm.DB, rows []T, conflict clause.OnConflict) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Clauses(conflict).CreateInBatches(rows, 500).Error
+}
@@ -0,0 +1,462 @@
+package facilitysyncsql
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/projectsql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// idChunkSize keeps IN lists well below the bind parameter limit.
+const idChunkSize = 1000
+
+type Store struct {
+	db      *gorm.DB
+	history *historysql.Store
+}
+
+// NewStore records applied rows in history when history is set.
+func NewStore(db *gorm.DB, history *historysql.Store) *Store {
+	return &Store{db: db, history: history}
+}
+
+func (s *Store) LoadSubtree(ctx context.Context, projectID uuid.UUID) (facilitysync.Bundle, error) {
+	db := s.db.WithContext(ctx)
+	bundle := facilitysync.Bundle{ProjectID: projectID}
+	if err := db.Table("projects").Select("name").Where("id = ?", projectID).Take(&bundle.ProjectName).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return bundle, domain.ErrNotFound
+		}
+		return bundle, err
+	}
+	links, err := loadLinks(db, projectID)
+	if err != nil {
+		return bundle, err
+	}
+	bundle.Links = links
+	devices, err := findIn[facilitysql.FieldDeviceRecord](db, "id", links.FieldDeviceIDs)
+	if err != nil {
+		return bundle, err
+	}
+	if err := loadHierarchy(db, &bundle, devices); err != nil {
+		return bundle, err
+	}
+	return bundle, loadDeviceRows(db, &bundle, devices)
+}
+
+func loadLinks(db *gorm.DB, projectID uuid.UUID) (facilitysync.ProjectLinks, error) {
+	var links facilitysync.ProjectLinks
+	plucks := []struct {
+		model  any
+		column string
+		target *[]uuid.UUID
+	}{
+		{&projectsql.ProjectControlCabinetRecord{}, "control_cabinet_id", &links.ControlCabinetIDs},
+		{&projectsql.ProjectSPSControllerRecord{}, "sps_controller_id", &links.SPSControllerIDs},
+		{&projectsql.ProjectFieldDeviceRecord{}, "field_device_id", &links.FieldDeviceIDs},
+	}
+	for _, pluck := range plucks {
+		*pluck.target = []uuid.UUID{}
+		if err := db.Model(pluck.model).Where("project_id = ?", projectID).Order(pluck.column).Pluck(pluck.column, pluck.target).Error; err != nil {
+			return links, err
+		}
+	}
+	return links, nil
+}
+
+// loadHierarchy walks upwards from the linked rows so every device keeps its
+// system type, controller, cabinet and building.
+func loadHierarchy(db *gorm.DB, bundle *facilitysync.Bundle, devices []facilitysql.FieldDeviceRecord) error {
+	systemTypeIDs := make([]uuid.UUID, 0, len(devices))
+	for _, device := range devices {
+		systemTypeIDs = append(systemTypeIDs, device.SPSControllerSystemTypeID)
+	}
+	systemTypes, err := findIn[domainFacility.SPSControllerSystemType](db, "id", systemTypeIDs)
+	if err != nil {
+		return err
+	}
+	controllerIDs := slices.Clone(bundle.Links.SPSControllerIDs)
+	for _, systemType := range systemTypes {
+		controllerIDs = append(controllerIDs, systemType.SPSControllerID)
+	}
+	controllers, err := findIn[domainFacility.SPSController](db, "id", controllerIDs)
+	if err != nil {
+		return err
+	}
+	// Controllers linked on their own bring all their system types along.
+	siblings, err := findIn[domainFacility.SPSControllerSystemType](db, "sps_controller_id", bundle.Links.SPSControllerIDs)
+	if err != nil {
+		return err
+	}
+	systemTypes = uniqueByID(append(systemTypes, siblings...), func(row domainFacility.SPSControllerSystemType) uuid.UUID { return row.ID })
+	cabinetIDs := slices.Clone(bundle.Links.ControlCabinetIDs)
+	for _, controller := range controllers {
+		cabinetIDs = append(cabinetIDs, controller.ControlCabinetID)
+	}
+	cabinets, err := findIn[domainFacility.ControlCabinet](db, "id", cabinetIDs)
+	if err != nil {
+		return err
+	}
+	buildingIDs := make([]uuid.UUID, 0, len(cabinets))
+	for _, cabinet := range cabinets {
+		buildingIDs = append(buildingIDs, cabinet.BuildingID)
+	}
+	buildings, err := findIn[domainFacility.Building](db, "id", buildingIDs)
+	if err != nil {
+		return err
+	}
+	setHierarchy(bundle, buildings, cabinets, controllers, systemTypes)
+	return nil
+}
+
+func loadDeviceRows(db *gorm.DB, bundle *facilitysync.Bundle, devices []facilitysql.FieldDeviceRecord) error {
+	controllerBySystemType := make(map[uuid.UUID]uuid.UUID, len(bundle.SystemTypes))
+	for _, systemType := range bundle.SystemTypes {
+		controllerBySystemType[systemType.ID] = systemType.SPSControllerID
+	}
+	ids := make([]uuid.UUID, 0, len(devices))
+	bundle.FieldDevices = make([]domainExport.DocumentFieldDevice, 0, len(devices))
+	for _, device := range devices {
+		ids = append(ids, device.ID)
+		bundle.FieldDevices = append(bundle.FieldDevices, domainExport.NewDocumentFieldDevice(controllerBySystemType[device.SPSControllerSystemTypeID], fieldDevice(device)))
+	}
+	specs, err := findIn[domainFacility.Specification](db, "field_device_id", ids)
+	if err != nil {
+		return err
+	}
+	objects, err := findIn[domainFacility.BacnetObject](db, "field_device_id", ids)
+	if err != nil {
+		return err
+	}
+	objectIDs := make([]uuid.UUID, 0, len(objects))
+	for _, object := range objects {
+		objectIDs = append(objectIDs, object.ID)
+	}
+	values, err := findIn[domainFacility.BacnetObjectAlarmValue](db, "bacnet_object_id", objectIDs)
+	if err != nil {
+		return err
+	}
+	setDeviceRows(bundle, specs, objects, values)
+	return nil
+}
+
+func (s *Store) LoadReferences(ctx context.Context) (facilitysync.References, error) {
+	db := s.db.WithContext(ctx)
+	var references facilitysync.References
+	targets := []any{
+		&references.Apparats, &references.SystemParts, &references.SystemTypes, &references.StateTexts,
+		&references.NotificationClasses, &references.AlarmDefinitions, &references.Units,
+	}
+	for _, target := range targets {
+		if err := db.Order("id").Find(target).Error; err != nil {
+			return references, err
+		}
+	}
+	err := db.Preload("Fields.AlarmField").Order("id").Find(&references.AlarmTypes).Error
+	return references, err
+}
+
+func (s *Store) LoadExisting(ctx context.Context, bundle facilitysync.Bundle) (facilitysync.Bundle, error) {
+	db := s.db.WithContext(ctx)
+	var existing facilitysync.Bundle
+	buildings, err := existingBuildings(db, bundle.Buildings)
+	if err != nil {
+		return existing, err
+	}
+	buildingIDs := make([]uuid.UUID, 0, len(buildings)+len(bundle.ControlCabinets))
+	for _, building := range buildings {
+		buildingIDs = append(buildingIDs, building.ID)
+	}
+	for _, cabinet := range bundle.ControlCabinets {
+		buildingIDs = append(buildingIDs, cabinet.BuildingID)
+	}
+	cabinetIDs := make([]uuid.UUID, 0, len(bundle.ControlCabinets))
+	for _, cabinet := range bundle.ControlCabinets {
+		cabinetIDs = append(cabinetIDs, cabinet.ID)
+	}
+	cabinets, err := findByIDOr(db, cabinetIDs, "building_id", buildingIDs, func(row domainFacility.ControlCabinet) uuid.UUID { return row.ID })
+	if err != nil {
+		return existing, err
+	}
+	parentIDs := make([]uuid.UUID, 0, len(cabinets)+len(bundle.SPSControllers))
+	controllerIDs := make([]uuid.UUID, 0, len(bundle.SPSControllers))
+	for _, cabinet := range cabinets {
+		parentIDs = append(parentIDs, cabinet.ID)
+	}
+	for _, controller := range bundle.SPSControllers {
+		parentIDs, controllerIDs = append(parentIDs, controller.ControlCabinetID), append(controllerIDs, controller.ID)
+	}
+	controllers, err := findByIDOr(db, controllerIDs, "control_cabinet_id", parentIDs, func(row domainFacility.SPSController) uuid.UUID { return row.ID })
+	if err != nil {
+		return existing, err
+	}
+	systemTypes, err := findByID[domainFacility.SPSControllerSystemType](db, bundle.SystemTypes, func(row facilitysync.SPSControllerSystemType) uuid.UUID { return row.ID })
+	if err != nil {
+		return existing, err
+	}
+	setHierarchy(&existing, buildings, cabinets, controllers, systemTypes)
+	return existing, loadExistingDeviceRows(db, bundle, &existing)
+}
+
+// existingBuildings returns local buildings sharing an ID or an IWS code with
+// the bundle; remapHierarchy narrows the code matches down to the group.
+func existingBuildings(db *gorm.DB, rows []facilitysync.Building) ([]domainFacility.Building, error) {
+	codes := make([]string, 0, len(rows))
+	for _, row := range rows {
+		codes = append(codes, row.IWSCode)
+	}
+	buildings, err := findByID[domainFacility.Building](db, rows, func(row facilitysync.Building) uuid.UUID { return row.ID })
+	if err != nil {
+		return nil, err
+	}
+	for chunk := range slices.Chunk(codes, idChunkSize) {
+		var page []domainFacility.Building
+		if err := db.Where("iws_code IN ?", chunk).Find(&page).Error; err != nil {
+			return nil, err
+		}
+		buildings = append(buildings, page...)
+	}
+	return uniqueByID(buildings, func(row domainFacility.Building) uuid.UUID { return row.ID }), nil
+}
+
+func loadExistingDeviceRows(db *gorm.DB, bundle facilitysync.Bundle, existing *facilitysync.Bundle) error {
+	devices, err := findByID[facilitysql.FieldDeviceRecord](db, bundle.FieldDevices, func(row domainExport.DocumentFieldDevice) uuid.UUID { return row.ID })
+	if err != nil {
+		return err
+	}
+	existing.FieldDevices = make([]domainExport.DocumentFieldDevice, 0, len(devices))
+	for _, device := range devices {
+		existing.FieldDevices = append(existing.FieldDevices, domainExport.NewDocumentFieldDevice(uuid.Nil, fieldDevice(device)))
+	}
+	specs, err := findByID[domainFacility.Specification](db, bundle.Specifications, func(row domainExport.DocumentSpecification) uuid.UUID { return row.ID })
+	if err != nil {
+		return err
+	}
+	objects, err := findByID[domainFacility.BacnetObject](db, bundle.BacnetObjects, func(row domainExport.DocumentBacnetObject) uuid.UUID { return row.ID })
+	if err != nil {
+		return err
+	}
+	values, err := findByID[domainFacility.BacnetObjectAlarmValue](db, bundle.AlarmValues, func(row domainExport.DocumentAlarmValue) uuid.UUID { return row.ID })
+	if err != nil {
+		return err
+	}
+	setDeviceRows(existing, specs, objects, values)
+	return nil
+}
+
+func (s *Store) Apply(ctx context.Context, projectID uuid.UUID, writes facilitysync.Bundle, links facilitysync.ProjectLinks) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domainFacility.ProjectRef{}).Where("id = ?", projectID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrNotFound
+		}
+		audit, err := newSyncAudit(s.history, tx, projectID)
+		if err != nil {
+			return err
+		}
+		for _, rows := range writtenRows(writes) {
+			if err := checkVersions(tx, rows); err != nil {
+				return err
+			}
+			if err := audit.capture(ctx, rows.table, rows.ids); err != nil {
+				return err
+			}
+		}
+		if err := applyHierarchy(tx, writes); err != nil {
+			return err
+		}
+		if err := applyDevices(tx, writes); err != nil {
+			return err
+		}
+		if err := linkProject(tx, audit, projectID, links); err != nil {
+			return err
+		}
+		return audit.record(ctx)
+	})
+}
+
+func applyHierarchy(tx *gorm.DB, writes facilitysync.Bundle) error {
+	now := time.Now().UTC()
+	buildings := make([]domainFacility.Building, 0, len(writes.Buildings))
+	for _, row := range writes.Buildings {
+		buildings = append(buildings, domainFacility.Building{Base: base(row.ID, row.Version, now), IWSCode: row.IWSCode, BuildingGroup: row.BuildingGroup})
+	}
+	if err := upsertRows(tx, buildings); err != nil {
+		return err
+	}
+	cabinets := make([]domainFacility.ControlCabinet, 0, len(writes.ControlCabinets))
+	for _, row := range writes.ControlCabinets {
+		cabinets = append(cabinets, domainFacility.ControlCabinet{Base: base(row.ID, row.Version, now), BuildingID: row.BuildingID, ControlCabinetNr: row.ControlCabinetNr})
+	}
+	if err := upsertRows(tx, cabinets); err != nil {
+		return err
+	}
+	controllers := make([]domainFacility.SPSController, 0, len(writes.SPSControllers))
+	for _, row := range writes.SPSControllers {
+		controllers = append(controllers, domainFacility.SPSController{
+			Base: base(row.ID, row.Version, now), ControlCabinetID: row.ControlCabinetID, GADevice: row.GADevice,
+			DeviceName: row.DeviceName, DeviceDescription: row.DeviceDescription, DeviceLocation: row.DeviceLocation,
+			IPAddress: row.IPAddress, Subnet: row.Subnet, Gateway: row.Gateway, Vlan: row.Vlan,
+			MACAddress: row.MACAddress,
+		})
+	}
+	if err := upsertRows(tx, controllers); err != nil {
+		return err
+	}
+	systemTypes := make([]domainFacility.SPSControllerSystemType, 0, len(writes.SystemTypes))
+	for _, row := range writes.SystemTypes {
+		systemTypes = append(systemTypes, domainFacility.SPSControllerSystemType{
+			Base: base(row.ID, row.Version, now), SPSControllerID: row.SPSControllerID, SystemTypeID: row.SystemTypeID,
+			Number: row.Number, DocumentName: row.DocumentName,
+		})
+	}
+	return upsertRows(tx, systemTypes)
+}
+
+func applyDevices(tx *gorm.DB, writes facilitysync.Bundle) error {
+	now := time.Now().UTC()
+	devices := make([]facilitysql.FieldDeviceRecord, 0, len(writes.FieldDevices))
+	for _, row := range writes.FieldDevices {
+		devices = append(devices, facilitysql.FieldDeviceRecord{
+			Base: base(row.ID, row.Version, now), BMK: row.BMK, Description: row.Description, ApparatNr: row.ApparatNr,
+			TextIndividuell: row.TextIndividual, SPSControllerSystemTypeID: row.SPSControllerSystemTypeID,
+			SystemPartID: row.SystemPartID, ApparatID: row.ApparatID,
+		})
+	}
+	if err := upsertRows(tx, devices); err != nil {
+		return err
+	}
+	specs := make([]domainFacility.Specification, 0, len(writes.Specifications))
+	for _, row := range writes.Specifications {
+		spec := row.Specification()
+		spec.Base = base(row.ID, row.Version, now)
+		specs = append(specs, spec)
+	}
+	if err := upsertRows(tx, specs); err != nil {
+		return err
+	}
+	return applyObjects(tx, writes, now)
+}
+
+// applyObjects writes objects before their software references, which may
+// point at objects of the same batch.
+func applyObjects(tx *gorm.DB, writes facilitysync.Bundle, now time.Time) error {
+	objects := make([]domainFacility.BacnetObject, 0, len(writes.BacnetObjects))
+	for _, row := range writes.BacnetObjects {
+		object := row.BacnetObject()
+		object.Base, object.SoftwareReferenceID = base(row.ID, row.Version, now), nil
+		objects = append(objects, object)
+	}
+	if err := upsertRows(tx, objects); err != nil {
+		return err
+	}
+	for _, row := range writes.BacnetObjects {
+		if row.SoftwareReferenceID == nil {
+			continue
+		}
+		if err := tx.Model(&domainFacility.BacnetObject{}).Where("id = ?", row.ID).Update("software_reference_id", *row.SoftwareReferenceID).Error; err != nil {
+			return err
+		}
+	}
+	values := make([]domainFacility.BacnetObjectAlarmValue, 0, len(writes.AlarmValues))
+	for _, row := range writes.AlarmValues {
+		value := row.AlarmValue()
+		value.Base = base(row.ID, row.Version, now)
+		values = append(values, value)
+	}
+	return upsertRows(tx, values)
+}
+
+func linkProject(tx *gorm.DB, audit *syncAudit, projectID uuid.UUID, links facilitysync.ProjectLinks) error {
+	existing, err := loadLinks(tx, projectID)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	cabinets := make([]projectsql.ProjectControlCabinetRecord, 0)
+	for _, id := range missingIDs(links.ControlCabinetIDs, existing.ControlCabinetIDs) {
+		cabinets = append(cabinets, projectsql.ProjectControlCabinetRecord{Base: base(uuid.Nil, 1, now), ProjectID: projectID, ControlCabinetID: id})
+	}
+	controllers := make([]projectsql.ProjectSPSControllerRecord, 0)
+	for _, id := range missingIDs(links.SPSControllerIDs, existing.SPSControllerIDs) {
+		controllers = append(controllers, projectsql.ProjectSPSControllerRecord{Base: base(uuid.Nil, 1, now), ProjectID: projectID, SPSControllerID: id})
+	}
+	devices := make([]projectsql.ProjectFieldDeviceRecord, 0)
+	for _, id := range missingIDs(links.FieldDeviceIDs, existing.FieldDeviceIDs) {
+		devices = append(devices, projectsql.ProjectFieldDeviceRecord{Base: base(uuid.Nil, 1, now), ProjectID: projectID, FieldDeviceID: id})
+	}
+	linkConflict := clause.OnConflict{DoNothing: true}
+	if err := save(tx, cabinets, linkConflict); err != nil {
+		return err
+	}
+	if err := save(tx, controllers, linkConflict); err != nil {
+		return err
+	}
+	if err := save(tx, devices, linkConflict); err != nil {
+		return err
+	}
+	created := []struct {
+		table string
+		ids   []uuid.UUID
+	}{
+		{"project_control_cabinets", recordIDs(cabinets, func(row projectsql.ProjectControlCabinetRecord) uuid.UUID { return row.ID })},
+		{"project_sps_controllers", recordIDs(controllers, func(row projectsql.ProjectSPSControllerRecord) uuid.UUID { return row.ID })},
+		{"project_field_devices", recordIDs(devices, func(row projectsql.ProjectFieldDeviceRecord) uuid.UUID { return row.ID })},
+	}
+	for _, links := range created {
+		audit.created(links.table, links.ids)
+	}
+	return nil
+}
+
+func recordIDs[T any](rows []T, id func(T) uuid.UUID) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, id(row))
+	}
+	return ids
+}
+
+func base(id uuid.UUID, version uint64, now time.Time) domain.Base {
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+	return domain.Base{ID: id, Version: version, CreatedAt: now, UpdatedAt: now}
+}
+
+func missingIDs(wanted, present []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(present)+len(wanted))
+	for _, id := range present {
+		seen[id] = struct{}{}
+	}
+	missing := make([]uuid.UUID, 0)
+	for _, id := range wanted {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func fieldDevice(record facilitysql.FieldDeviceRecord) domainFacility.FieldDevice {
+	return domainFacility.FieldDevice{
+		Base: record.Base, BMK: record.BMK, Description: record.Description, ApparatNr: record.ApparatNr,
+		TextIndividuell: record.TextIndividuell, SPSControllerSystemTypeID: record.SPSControllerSystemTypeID,
+		SystemPartID: record.SystemPartID, ApparatID: record.ApparatID,
+	}
+}
@@ -0,0 +1,244 @@
+package facilitysyncsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	projectrepo "github.com/besart951/go_infra_link/backend/internal/repository/project"
+	"github.com/besart951/go_infra_link/backend/internal/repository/projectsql"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type syncReferences struct {
+	apparat    domainFacility.Apparat
+	systemPart domainFacility.SystemPart
+	systemType domainFacility.SystemType
+	field      domainFacility.AlarmTypeField
+}
+
+func TestStoreRoundTripsProjectSubtreeBetweenInstances(t *testing.T) {
+	ctx := context.Background()
+	source, target := newFacilitySyncTestDB(t, "source"), newFacilitySyncTestDB(t, "target")
+	sourceRefs, targetRefs := seedSyncReferences(t, source), seedSyncReferences(t, target)
+	sourceProject, targetProject := seedSyncProject(t, source), seedSyncProject(t, target)
+	deviceID := seedSyncSubtree(t, source, sourceProject, sourceRefs)
+
+	bundle, err := facilitysync.NewService(NewStore(source, nil)).Export(ctx, sourceProject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle.FieldDevices) != 1 || len(bundle.BacnetObjects) != 2 || len(bundle.AlarmValues) != 1 || len(bundle.Buildings) != 1 {
+		t.Fatalf("exported subtree = devices %d objects %d values %d buildings %d", len(bundle.FieldDevices), len(bundle.BacnetObjects), len(bundle.AlarmValues), len(bundle.Buildings))
+	}
+
+	service := facilitysync.NewService(NewStore(target, nil))
+	plan, err := service.Apply(ctx, targetProject, bundle)
+	if err != nil {
+		t.Fatalf("Apply() error = %v, missing = %+v", err, plan.Missing())
+	}
+
+	var device facilitysql.FieldDeviceRecord
+	if err := target.First(&device, "id = ?", deviceID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if device.ApparatID != targetRefs.apparat.ID || device.SystemPartID != targetRefs.systemPart.ID {
+		t.Fatalf("device references = %s/%s, want target rows", device.ApparatID, device.SystemPartID)
+	}
+	var value domainFacility.BacnetObjectAlarmValue
+	if err := target.First(&value).Error; err != nil || value.AlarmTypeFieldID != targetRefs.field.ID {
+		t.Fatalf("alarm value = %+v error = %v", value, err)
+	}
+	var reference domainFacility.BacnetObject
+	if err := target.Where("software_reference_id IS NOT NULL").First(&reference).Error; err != nil {
+		t.Fatalf("software reference was not restored: %v", err)
+	}
+	var links int64
+	target.Model(&projectsql.ProjectFieldDeviceRecord{}).Where("project_id = ? AND field_device_id = ?", targetProject, deviceID).Count(&links)
+	if links != 1 {
+		t.Fatalf("project field device links = %d, want 1", links)
+	}
+
+	again, err := service.Plan(ctx, targetProject, bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Changes) != 0 || again.Summary["field_device"].Unchanged != 1 || again.Summary["alarm_value"].Unchanged != 1 {
+		t.Fatalf("second plan changes = %+v summary = %+v", again.Changes, again.Summary)
+	}
+}
+
+func TestStoreApplyChecksVersionsAndRecordsHistory(t *testing.T) {
+	db := newFacilitySyncTestDB(t, "stale")
+	projectID := seedSyncProject(t, db)
+	nr := "02"
+	building := domainFacility.Building{IWSCode: "EFGH", BuildingGroup: 1}
+	seedSyncRecord(t, db, &building)
+	cabinet := domainFacility.ControlCabinet{BuildingID: building.ID, ControlCabinetNr: &nr}
+	seedSyncRecord(t, db, &cabinet)
+	// Another user saved the cabinet after the plan saw version 1.
+	if err := db.Model(&cabinet).Update("version", 2).Error; err != nil {
+		t.Fatal(err)
+	}
+	renamed := "03"
+	writes := facilitysync.Bundle{ControlCabinets: []facilitysync.ControlCabinet{{ID: cabinet.ID, Version: 2, BuildingID: building.ID, ControlCabinetNr: &renamed}}}
+	store := NewStore(db, historysql.NewStore(db))
+
+	err := store.Apply(context.Background(), projectID, writes, facilitysync.ProjectLinks{})
+
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("Apply() error = %v, want ErrConflict", err)
+	}
+	var stored domainFacility.ControlCabinet
+	if err := db.First(&stored, "id = ?", cabinet.ID).Error; err != nil || *stored.ControlCabinetNr != nr {
+		t.Fatalf("stale write reached the row: %+v, %v", stored, err)
+	}
+
+	writes.ControlCabinets[0].Version = 3
+	links := facilitysync.ProjectLinks{ControlCabinetIDs: []uuid.UUID{cabinet.ID}}
+	if err := store.Apply(context.Background(), projectID, writes, links); err != nil {
+		t.Fatal(err)
+	}
+	var events []domainHistory.ChangeEvent
+	if err := db.Order("entity_table").Find(&events).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].EntityTable != "control_cabinets" || events[0].Action != domainHistory.ActionUpdate ||
+		events[1].EntityTable != "project_control_cabinets" || events[1].Action != domainHistory.ActionCreate ||
+		events[0].BatchID == nil || events[1].BatchID == nil || *events[0].BatchID != *events[1].BatchID {
+		t.Fatalf("history = %+v, want the cabinet update and the new link in one batch", events)
+	}
+}
+
+func TestStoreApplyRejectsUnknownProject(t *testing.T) {
+	db := newFacilitySyncTestDB(t, "unknown")
+
+	err := NewStore(db, nil).Apply(context.Background(), uuid.New(), facilitysync.Bundle{}, facilitysync.ProjectLinks{})
+
+	if err != domain.ErrNotFound {
+		t.Fatalf("Apply() error = %v, want ErrNotFound", err)
+	}
+}
+
+func newFacilitySyncTestDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", strings.NewReplacer("/", "_", " ", "_", "#", "_").Replace(t.Name()), name)
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	if err != nil {
+		t.Fatalf("expected sqlite db to open, got %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("expected sql db handle, got %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	models := []any{
+		&projectrepo.ProjectRecord{},
+		&projectsql.ProjectControlCabinetRecord{},
+		&projectsql.ProjectSPSControllerRecord{},
+		&projectsql.ProjectFieldDeviceRecord{},
+		&domainFacility.Building{},
+		&domainFacility.ControlCabinet{},
+		&domainFacility.SPSController{},
+		&domainFacility.SystemType{},
+		&domainFacility.SPSControllerSystemType{},
+		&domainFacility.SystemPart{},
+		&domainFacility.Apparat{},
+		&facilitysql.FieldDeviceRecord{},
+		&domainFacility.Specification{},
+		&domainFacility.StateText{},
+		&domainFacility.NotificationClass{},
+		&domainFacility.AlarmDefinition{},
+		&domainFacility.BacnetObject{},
+		&domainFacility.Unit{},
+		&domainFacility.AlarmField{},
+		&domainFacility.AlarmType{},
+		&domainFacility.AlarmTypeField{},
+		&domainFacility.BacnetObjectAlarmValue{},
+		&domainFacility.ObjectData{},
+		&facilitysql.BacnetObjectTemplateRecord{},
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("expected facility sync tables to migrate, got %v", err)
+	}
+	if err := historysql.AutoMigrate(db); err != nil {
+		t.Fatalf("expected history tables to migrate, got %v", err)
+	}
+	return db
+}
+
+// seedSyncReferences creates the same reference data under fresh IDs, as two
+// independently seeded instances would have it.
+func seedSyncReferences(t *testing.T, db *gorm.DB) syncReferences {
+	t.Helper()
+	refs := syncReferences{
+		apparat:    domainFacility.Apparat{ShortName: "VEN", Name: "Ventilator"},
+		systemPart: domainFacility.SystemPart{ShortName: "AB", Name: "Abluft"},
+		systemType: domainFacility.SystemType{Name: "Lüftung", NumberMin: 1, NumberMax: 9},
+	}
+	field := domainFacility.AlarmField{Key: "high_limit", Label: "High limit", DataType: "number"}
+	alarmType := domainFacility.AlarmType{Code: "LIMIT", Name: "Limit"}
+	for _, entity := range []interface{ GetBase() *domain.Base }{&refs.apparat, &refs.systemPart, &refs.systemType, &field, &alarmType} {
+		seedSyncRecord(t, db, entity)
+	}
+	refs.field = domainFacility.AlarmTypeField{AlarmTypeID: alarmType.ID, AlarmFieldID: field.ID}
+	seedSyncRecord(t, db, &refs.field)
+	return refs
+}
+
+func seedSyncProject(t *testing.T, db *gorm.DB) uuid.UUID {
+	t.Helper()
+	project := projectrepo.ProjectRecord{Name: "Sync", Status: "planned", PhaseID: uuid.New(), CreatorID: uuid.New()}
+	seedSyncRecord(t, db, &project)
+	return project.ID
+}
+
+func seedSyncSubtree(t *testing.T, db *gorm.DB, projectID uuid.UUID, refs syncReferences) uuid.UUID {
+	t.Helper()
+	nr, limit := "01", 42.0
+	building := domainFacility.Building{IWSCode: "ABCD", BuildingGroup: 1}
+	seedSyncRecord(t, db, &building)
+	cabinet := domainFacility.ControlCabinet{BuildingID: building.ID, ControlCabinetNr: &nr}
+	seedSyncRecord(t, db, &cabinet)
+	controller := domainFacility.SPSController{ControlCabinetID: cabinet.ID, DeviceName: "AS01"}
+	seedSyncRecord(t, db, &controller)
+	systemType := domainFacility.SPSControllerSystemType{SPSControllerID: controller.ID, SystemTypeID: refs.systemType.ID}
+	seedSyncRecord(t, db, &systemType)
+	device := facilitysql.FieldDeviceRecord{SPSControllerSystemTypeID: systemType.ID, SystemPartID: refs.systemPart.ID, ApparatID: refs.apparat.ID, ApparatNr: 1}
+	seedSyncRecord(t, db, &device)
+	seedSyncRecord(t, db, &domainFacility.Specification{FieldDeviceID: &device.ID})
+	target := domainFacility.BacnetObject{TextFix: "Target", SoftwareType: "ai", FieldDeviceID: &device.ID}
+	seedSyncRecord(t, db, &target)
+	object := domainFacility.BacnetObject{TextFix: "Source", SoftwareType: "ai", FieldDeviceID: &device.ID, SoftwareReferenceID: &target.ID}
+	seedSyncRecord(t, db, &object)
+	seedSyncRecord(t, db, &domainFacility.BacnetObjectAlarmValue{BacnetObjectID: object.ID, AlarmTypeFieldID: refs.field.ID, ValueNumber: &limit, Source: "user"})
+	seedSyncRecord(t, db, &projectsql.ProjectFieldDeviceRecord{ProjectID: projectID, FieldDeviceID: device.ID})
+	seedSyncRecord(t, db, &projectsql.ProjectControlCabinetRecord{ProjectID: projectID, ControlCabinetID: cabinet.ID})
+	return device.ID
+}
+
+func seedSyncRecord(t *testing.T, db *gorm.DB, entity interface{ GetBase() *domain.Base }) {
+	t.Helper()
+	if err := entity.GetBase().InitForCreate(time.Now().UTC()); err != nil {
+		t.Fatalf("expected base init to succeed, got %v", err)
+	}
+	if err := db.Omit("*.*").Create(entity).Error; err != nil {
+		t.Fatalf("expected record seed to succeed, got %v", err)
+	}
+}
@@ -438,6 +438,60 @@ func (r *AlarmTypeRepository) DeleteByIds(ctx context.Context, ids []uuid.UUID)
 	return r.audit.deleteByIds(ctx, r.AlarmTypeRepository, ids)
 }
 
+type UnitRepository struct {
+	domainFacility.UnitRepository
+	audit audit[domainFacility.Unit]
+}
+
+func WrapUnit(next domainFacility.UnitRepository, store *historysql.Store) domainFacility.UnitRepository {
+	if store == nil {
+		return next
+	}
+	return &UnitRepository{UnitRepository: next, audit: newAudit[domainFacility.Unit]("units", store)}
+}
+
+func (r *UnitRepository) Create(ctx context.Context, entity *domainFacility.Unit) error {
+	return r.audit.create(ctx, r.UnitRepository, entity)
+}
+func (r *UnitRepository) Update(ctx context.Context, entity *domainFacility.Unit) error {
+	return r.audit.update(ctx, r.UnitRepository, entity)
+}
+func (r *UnitRepository) DeleteByIds(ctx context.Context, ids []uuid.UUID) error {
+	return r.audit.deleteByIds(ctx, r.UnitRepository, ids)
+}
+
+type AlarmDefinitionFieldOverrideRepository struct {
+	domainFacility.AlarmDefinitionFieldOverrideRepository
+	audit audit[domainFacility.AlarmDefinitionFieldOverride]
+}
+
+func WrapAlarmDefinitionFieldOverride(next domainFacility.AlarmDefinitionFieldOverrideRepository, store *historysql.Store) domainFacility.AlarmDefinitionFieldOverrideRepository {
+	if store == nil {
+		return next
+	}
+	return &AlarmDefinitionFieldOverrideRepository{AlarmDefinitionFieldOverrideRepository: next, audit: newAudit[domainFacility.AlarmDefinitionFieldOverride]("alarm_definition_field_overrides", store)}
+}
+
+func (r *AlarmDefinitionFieldOverrideRepository) Create(ctx context.Context, entity *domainFacility.AlarmDefinitionFieldOverride) error {
+	return r.audit.create(ctx, r.AlarmDefinitionFieldOverrideRepository, entity)
+}
+func (r *AlarmDefinitionFieldOverrideRepository) Update(ctx context.Context, entity *domainFacility.AlarmDefinitionFieldOverride) error {
+	return r.audit.update(ctx, r.AlarmDefinitionFieldOverrideRepository, entity)
+}
+func (r *AlarmDefinitionFieldOverrideRepository) DeleteByIds(ctx context.Context, ids []uuid.UUID) error {
+	return r.audit.deleteByIds(ctx, r.AlarmDefinitionFieldOverrideRepository, ids)
+}
+func (r *AlarmDefinitionFieldOverrideRepository) DeleteAtVersion(ctx context.Context, id uuid.UUID, version uint64) error {
+	return r.audit.deleteRows(ctx,
+		func(ctx context.Context) (map[uuid.UUID]domainHistory.JSONB, error) {
+			return r.audit.store.LoadRows(ctx, "alarm_definition_field_overrides", []uuid.UUID{id})
+		},
+		func(ctx context.Context) error {
+			return r.AlarmDefinitionFieldOverrideRepository.DeleteAtVersion(ctx, id, version)
+		},
+	)
+}
+
 type BacnetObjectAlarmValueRepository struct {
 	domainFacility.BacnetObjectAlarmValueRepository
 	audit audit[domainFacility.BacnetObjectAlarmValue]
@@ -114,18 +114,11 @@ func (s *Store) checkUndoConflict(ctx context.Context, preflight undoPreflight)
 	if err != nil {
 		return err
 	}
-	expectedVersion := snapshotVersion(expected)
-	currentVersion := snapshotVersion(preflight.current)
-	if !newer && snapshotsMatchExpectedState(undoSnapshotState{
-		expected: expected, current: preflight.current,
-		expectedVersion: expectedVersion, currentVersion: currentVersion,
-	}) {
+	state := newUndoSnapshotState(expected, preflight.current)
+	if !newer && snapshotsMatchExpectedState(state) {
 		return nil
 	}
-	return &domainHistory.UndoConflictError{Conflict: domainHistory.UndoConflict{
-		Code: "undo_conflict", EntityTable: preflight.event.EntityTable, EntityID: preflight.event.EntityID,
-		ExpectedVersion: expectedVersion, CurrentVersion: currentVersion, Fields: []string{"version"},
-	}}
+	return &domainHistory.UndoConflictError{Conflict: state.conflict(preflight.event.EntityTable, preflight.event.EntityID)}
 }
 
 func (s *Store) hasNewerEvent(ctx context.Context, event *domainHistory.ChangeEvent) (bool, error) {
@@ -152,6 +145,20 @@ type undoSnapshotState struct {
 	currentVersion  *uint64
 }
 
+func newUndoSnapshotState(expected, current domainHistory.JSONB) undoSnapshotState {
+	return undoSnapshotState{
+		expected: expected, current: current,
+		expectedVersion: snapshotVersion(expected), currentVersion: snapshotVersion(current),
+	}
+}
+
+func (state undoSnapshotState) conflict(table string, id uuid.UUID) domainHistory.UndoConflict {
+	return domainHistory.UndoConflict{
+		Code: "undo_conflict", EntityTable: table, EntityID: id,
+		ExpectedVersion: state.expectedVersion, CurrentVersion: state.currentVersion, Fields: []string{"version"},
+	}
+}
+
 func snapshotsMatchExpectedState(state undoSnapshotState) bool {
 	if len(state.expected) == 0 || len(state.current) == 0 {
 		return len(state.expected) == 0 && len(state.current) == 0
@@ -182,16 +189,9 @@ func snapshotVersion(snapshot domainHistory.JSONB) *uint64 {
 }
 
 func (s *Store) RestoreControlCabinet(ctx context.Context, controlCabinetID uuid.UUID, req domainHistory.RestoreControlCabinetRequest) (*domainHistory.RestoreResult, error) {
-	asOf := time.Now().UTC()
-	if req.AsOf != nil {
-		asOf = req.AsOf.UTC()
-	}
-	if req.EventID != nil && *req.EventID != uuid.Nil {
-		event, err := s.GetEvent(ctx, *req.EventID)
-		if err != nil {
-			return nil, err
-		}
-		asOf = event.OccurredAt
+	asOf, err := s.resolveRestoreAsOf(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	batchID, err := uuid.NewV7()
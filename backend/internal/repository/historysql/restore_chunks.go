@@ -40,7 +40,7 @@ var restoreEffects = map[hierarchyrestore.Phase]restoreEffect{
 }
 
 func (s *Store) RestoreChunk(ctx context.Context, command hierarchyrestore.Command) (hierarchyrestore.Result, error) {
-	if !allowedTable(command.Table) || restoreEffects[command.Phase] == nil {
+	if !allowedTable(command.Table) || restoreEffects[command.Phase] == nil || !hierarchyrestore.IsRoot(command.Root) {
 		return hierarchyrestore.Result{}, fmt.Errorf("invalid hierarchy restore stage")
 	}
 	if command.Limit <= 0 || command.Limit > hierarchyRestoreChunkLimit {
@@ -50,7 +50,7 @@ func (s *Store) RestoreChunk(ctx context.Context, command hierarchyrestore.Comma
 	if err != nil || len(ids) == 0 {
 		return hierarchyrestore.Result{Done: len(ids) == 0}, err
 	}
-	versions, err := s.latestRestoreVersions(ctx, command, ids)
+	versions, err := s.latestRestoreVersions(ctx, restoreVersionQuery{table: command.Table, ids: ids, asOf: command.AsOf})
 	if err != nil {
 		return hierarchyrestore.Result{}, err
 	}
@@ -63,7 +63,7 @@ func (s *Store) RestoreChunk(ctx context.Context, command hierarchyrestore.Comma
 func (s *Store) restoreTargetIDs(ctx context.Context, command hierarchyrestore.Command) ([]uuid.UUID, bool, error) {
 	query := s.db.WithContext(ctx).Table("change_events ce").
 		Select("DISTINCT ce.entity_id").
-		Joins("JOIN change_event_scopes cabinet_scope ON cabinet_scope.change_event_id = ce.id AND cabinet_scope.scope_type = ? AND cabinet_scope.scope_id = ?", scopeControlCabinet, command.ControlCabinetID).
+		Joins("JOIN change_event_scopes root_scope ON root_scope.change_event_id = ce.id AND root_scope.scope_type = ? AND root_scope.scope_id = ?", string(command.Root), command.RootID).
 		Where("ce.entity_table = ? AND ce.entity_id > ?", command.Table, command.AfterID)
 	if command.ProjectID != nil {
 		query = query.Joins("JOIN change_event_scopes project_scope ON project_scope.change_event_id = ce.id AND project_scope.scope_type = ? AND project_scope.scope_id = ?", scopeProject, *command.ProjectID)
@@ -80,7 +80,13 @@ func (s *Store) restoreTargetIDs(ctx context.Context, command hierarchyrestore.C
 	return ids, hasMore, nil
 }
 
-func (s *Store) latestRestoreVersions(ctx context.Context, command hierarchyrestore.Command, ids []uuid.UUID) (map[uuid.UUID]domainHistory.EntityVersion, error) {
+type restoreVersionQuery struct {
+	table string
+	ids   []uuid.UUID
+	asOf  time.Time
+}
+
+func (s *Store) latestRestoreVersions(ctx context.Context, query restoreVersionQuery) (map[uuid.UUID]domainHistory.EntityVersion, error) {
 	var rows []domainHistory.EntityVersion
 	err := s.db.WithContext(ctx).Raw(`
 		SELECT ranked.id, ranked.change_event_id, ranked.entity_table, ranked.entity_id,
@@ -94,7 +100,7 @@ func (s *Store) latestRestoreVersions(ctx context.Context, command hierarchyrest
 			WHERE ev.entity_table = ? AND ev.entity_id IN ? AND ev.version_at <= ?
 		) ranked
 		WHERE ranked.row_number = 1
-	`, command.Table, ids, command.AsOf).Scan(&rows).Error
+	`, query.table, query.ids, query.asOf).Scan(&rows).Error
 	versions := make(map[uuid.UUID]domainHistory.EntityVersion, len(rows))
 	for _, row := range rows {
 		versions[row.EntityID] = row
@@ -161,15 +167,16 @@ func (s *Store) recordRestoreEffect(ctx context.Context, effect restoreHistoryEf
 		BeforeJSON: effect.before, AfterJSON: effect.after, BatchID: &effect.command.BatchID,
 		Summary: "hierarchy restored from history",
 		Metadata: map[string]any{
-			"control_cabinet_id": effect.command.ControlCabinetID.String(),
-			"restore_as_of":      effect.command.AsOf.UTC().Format(time.RFC3339Nano), "restore_effect": effect.effect,
+			"restore_root": string(effect.command.Root), string(effect.command.Root) + "_id": effect.command.RootID.String(),
+			"restore_as_of": effect.command.AsOf.UTC().Format(time.RFC3339Nano), "restore_effect": effect.effect,
 		},
 	})
 }
 
-func ReleaseRestoreLifecycle(ctx context.Context, db *gorm.DB, cabinetID uuid.UUID) error {
+// ReleaseRestoreLifecycle drops the restore-staging lock of the command's root.
+func ReleaseRestoreLifecycle(ctx context.Context, db *gorm.DB, command hierarchyrestore.Command) error {
 	return db.WithContext(ctx).Exec(
 		"DELETE FROM facility_aggregate_lifecycle WHERE kind = ? AND resource_id = ?",
-		"control_cabinet", cabinetID,
+		string(command.Root), command.RootID,
 	).Error
 }
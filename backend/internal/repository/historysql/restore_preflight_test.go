@@ -0,0 +1,58 @@
+package historysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/google/uuid"
+)
+
+func TestCheckHierarchyRestoreRejectsUntrackedChanges(t *testing.T) {
+	db := historyV2TestDB(t)
+	if err := db.AutoMigrate(&domainFacility.ObjectData{}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	store := NewStore(db)
+	objectData := domainFacility.ObjectData{Base: domain.Base{ID: uuid.New(), Version: 1}, Description: "AHU", Version: "1.0"}
+	if err := db.Create(&objectData).Error; err != nil {
+		t.Fatal(err)
+	}
+	current, err := store.LoadRows(ctx, "object_data", []uuid.UUID{objectData.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	occurredAt := time.Now().UTC()
+	event := domainHistory.ChangeEvent{
+		ID: uuid.New(), OccurredAt: occurredAt, Action: domainHistory.ActionCreate,
+		EntityTable: "object_data", EntityID: objectData.ID, AfterJSON: current[objectData.ID],
+	}
+	rows := []any{
+		&event,
+		&domainHistory.ChangeEventScope{ID: uuid.New(), ChangeEventID: event.ID, ScopeType: string(hierarchyrestore.RootObjectData), ScopeID: objectData.ID, OccurredAt: occurredAt},
+		&domainHistory.EntityVersion{ID: uuid.New(), ChangeEventID: event.ID, EntityTable: "object_data", EntityID: objectData.ID, VersionAt: occurredAt, Action: domainHistory.ActionCreate, SnapshotJSON: current[objectData.ID]},
+	}
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	command := hierarchyrestore.Command{Root: hierarchyrestore.RootObjectData, RootID: objectData.ID, AsOf: occurredAt}
+	if err := store.CheckHierarchyRestore(ctx, command); err != nil {
+		t.Fatalf("CheckHierarchyRestore() on tracked rows = %v", err)
+	}
+
+	if err := db.Model(&objectData).Updates(map[string]any{"description": "AHU changed", "version": 2}).Error; err != nil {
+		t.Fatal(err)
+	}
+	var conflict *domainHistory.UndoConflictError
+	if err := store.CheckHierarchyRestore(ctx, command); !errors.As(err, &conflict) || conflict.Conflict.EntityID != objectData.ID {
+		t.Fatalf("CheckHierarchyRestore() = %v, want undo conflict on %s", err, objectData.ID)
+	}
+}
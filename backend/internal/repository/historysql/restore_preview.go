@@ -0,0 +1,199 @@
+package historysql
+
+import (
+	"context"
+	"time"
+
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/google/uuid"
+)
+
+const restorePreviewItemLimit = 500
+
+// latestRecordedVersion bounds the version lookup that finds the newest
+// recorded state of an entity for the restore preflight.
+var latestRecordedVersion = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+type restorePreviewer struct {
+	store   *Store
+	preview *domainHistory.RestorePreview
+}
+
+// PreviewHierarchyRestore classifies every entity of the root's subtree the
+// way an as-of restore would treat it. The preflight flags entities whose
+// current row no longer matches their newest recorded version, since the
+// restore would silently overwrite those untracked changes.
+func (s *Store) PreviewHierarchyRestore(ctx context.Context, root hierarchyrestore.Root, rootID uuid.UUID, req domainHistory.RestoreHierarchyRequest) (*domainHistory.RestorePreview, error) {
+	if !hierarchyrestore.IsRoot(root) || rootID == uuid.Nil {
+		return nil, domain.ErrInvalidArgument
+	}
+	asOf, err := s.resolveRestoreAsOf(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	preview := &domainHistory.RestorePreview{
+		Root: string(root), RootID: rootID, ProjectID: req.ProjectID, AsOf: asOf,
+		Tables: []domainHistory.RestorePreviewTable{}, Items: []domainHistory.RestorePreviewItem{},
+	}
+	previewer := restorePreviewer{store: s, preview: preview}
+	command := hierarchyrestore.Command{
+		Root: root, RootID: rootID, ProjectID: req.ProjectID, AsOf: asOf,
+		Phase: hierarchyrestore.PhaseRestore, Limit: hierarchyRestoreChunkLimit,
+	}
+	for _, table := range hierarchyrestore.Tables(root, hierarchyrestore.PhaseRestore) {
+		command.Table, command.AfterID = table, uuid.Nil
+		if err := previewer.table(ctx, command); err != nil {
+			return nil, err
+		}
+	}
+	return preview, nil
+}
+
+func (s *Store) resolveRestoreAsOf(ctx context.Context, req domainHistory.RestoreHierarchyRequest) (time.Time, error) {
+	if req.EventID != nil && *req.EventID != uuid.Nil {
+		event, err := s.GetEvent(ctx, *req.EventID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return event.OccurredAt.UTC(), nil
+	}
+	if req.AsOf != nil {
+		return req.AsOf.UTC(), nil
+	}
+	return time.Now().UTC(), nil
+}
+
+func (p restorePreviewer) table(ctx context.Context, command hierarchyrestore.Command) error {
+	p.preview.Tables = append(p.preview.Tables, domainHistory.RestorePreviewTable{EntityTable: command.Table})
+	for {
+		ids, hasMore, err := p.store.restoreTargetIDs(ctx, command)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		if err := p.page(ctx, command.Table, ids); err != nil {
+			return err
+		}
+		if !hasMore {
+			return nil
+		}
+		command.AfterID = ids[len(ids)-1]
+	}
+}
+
+func (p restorePreviewer) page(ctx context.Context, table string, ids []uuid.UUID) error {
+	targets, err := p.store.latestRestoreVersions(ctx, restoreVersionQuery{table: table, ids: ids, asOf: p.preview.AsOf})
+	if err != nil {
+		return err
+	}
+	current, err := p.store.LoadRows(ctx, table, ids)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		p.record(table, id, classifyRestoreEffect(targets[id].SnapshotJSON, current[id]))
+	}
+	conflicts, err := p.store.restoreConflicts(ctx, table, ids, current)
+	if err != nil {
+		return err
+	}
+	p.preview.Conflicts = append(p.preview.Conflicts, conflicts...)
+	return nil
+}
+
+// CheckHierarchyRestore is the undo-conflict preflight of a restore job. It
+// walks the same targets as the preview and fails with the first conflict.
+func (s *Store) CheckHierarchyRestore(ctx context.Context, command hierarchyrestore.Command) error {
+	command.Phase, command.Limit = hierarchyrestore.PhaseRestore, hierarchyRestoreChunkLimit
+	for _, table := range hierarchyrestore.Tables(command.Root, hierarchyrestore.PhaseRestore) {
+		command.Table, command.AfterID = table, uuid.Nil
+		for {
+			ids, hasMore, err := s.restoreTargetIDs(ctx, command)
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				break
+			}
+			current, err := s.LoadRows(ctx, table, ids)
+			if err != nil {
+				return err
+			}
+			conflicts, err := s.restoreConflicts(ctx, table, ids, current)
+			if err != nil {
+				return err
+			}
+			if len(conflicts) > 0 {
+				return &domainHistory.UndoConflictError{Conflict: conflicts[0]}
+			}
+			if !hasMore {
+				break
+			}
+			command.AfterID = ids[len(ids)-1]
+		}
+	}
+	return nil
+}
+
+// restoreConflicts lists the entities whose current row no longer matches
+// their newest recorded version.
+func (s *Store) restoreConflicts(ctx context.Context, table string, ids []uuid.UUID, current map[uuid.UUID]domainHistory.JSONB) ([]domainHistory.UndoConflict, error) {
+	recorded, err := s.latestRestoreVersions(ctx, restoreVersionQuery{table: table, ids: ids, asOf: latestRecordedVersion})
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []domainHistory.UndoConflict
+	for _, id := range ids {
+		version, ok := recorded[id]
+		if !ok {
+			continue
+		}
+		state := newUndoSnapshotState(version.SnapshotJSON, current[id])
+		if !snapshotsMatchExpectedState(state) {
+			conflicts = append(conflicts, state.conflict(table, id))
+		}
+	}
+	return conflicts, nil
+}
+
+func (p restorePreviewer) record(table string, id uuid.UUID, effect domainHistory.RestoreEffect) {
+	summary := &p.preview.Tables[len(p.preview.Tables)-1]
+	switch effect {
+	case domainHistory.RestoreEffectRecreate:
+		summary.RecreateCount++
+		p.preview.RecreateCount++
+	case domainHistory.RestoreEffectUpdate:
+		summary.UpdateCount++
+		p.preview.UpdateCount++
+	case domainHistory.RestoreEffectDelete:
+		summary.DeleteCount++
+		p.preview.DeleteCount++
+	default:
+		summary.SkipCount++
+		p.preview.SkipCount++
+		return
+	}
+	if len(p.preview.Items) >= restorePreviewItemLimit {
+		p.preview.Truncated = true
+		return
+	}
+	p.preview.Items = append(p.preview.Items, domainHistory.RestorePreviewItem{EntityTable: table, EntityID: id, Effect: effect})
+}
+
+// classifyRestoreEffect mirrors the delete and restore phases of RestoreChunk:
+// a missing snapshot removes the row, a present one re-creates or updates it.
+func classifyRestoreEffect(target, current domainHistory.JSONB) domainHistory.RestoreEffect {
+	switch {
+	case len(target) == 0 && len(current) == 0:
+		return domainHistory.RestoreEffectSkip
+	case len(target) == 0:
+		return domainHistory.RestoreEffectDelete
+	case len(current) == 0:
+		return domainHistory.RestoreEffectRecreate
+	case jsonEqual(target, current):
+		return domainHistory.RestoreEffectSkip
+	default:
+		return domainHistory.RestoreEffectUpdate
+	}
+}
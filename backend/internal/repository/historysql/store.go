@@ -20,29 +20,30 @@ const defaultTimelineLimit = 50
 const maxTimelineLimit = 200
 
 var tableWhitelist = map[string]struct{}{
-	"buildings":                   {},
-	"projects":                    {},
-	"control_cabinets":            {},
-	"sps_controllers":             {},
-	"sps_controller_system_types": {},
-	"field_devices":               {},
-	"specifications":              {},
-	"bacnet_objects":              {},
-	"bacnet_object_alarm_values":  {},
-	"object_data":                 {},
-	"state_texts":                 {},
-	"notification_classes":        {},
-	"alarm_definitions":           {},
-	"alarm_fields":                {},
-	"alarm_types":                 {},
-	"alarm_type_fields":           {},
-	"units":                       {},
-	"system_types":                {},
-	"system_parts":                {},
-	"apparats":                    {},
-	"project_control_cabinets":    {},
-	"project_sps_controllers":     {},
-	"project_field_devices":       {},
+	"buildings":                        {},
+	"projects":                         {},
+	"control_cabinets":                 {},
+	"sps_controllers":                  {},
+	"sps_controller_system_types":      {},
+	"field_devices":                    {},
+	"specifications":                   {},
+	"bacnet_objects":                   {},
+	"bacnet_object_alarm_values":       {},
+	"object_data":                      {},
+	"state_texts":                      {},
+	"notification_classes":             {},
+	"alarm_definitions":                {},
+	"alarm_definition_field_overrides": {},
+	"alarm_fields":                     {},
+	"alarm_types":                      {},
+	"alarm_type_fields":                {},
+	"units":                            {},
+	"system_types":                     {},
+	"system_parts":                     {},
+	"apparats":                         {},
+	"project_control_cabinets":         {},
+	"project_sps_controllers":          {},
+	"project_field_devices":            {},
 }
 
 type Store struct {
@@ -0,0 +1,25 @@
+package historysql
+
+import (
+	"context"
+	"time"
+
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/google/uuid"
+)
+
+// LatestVersions returns the newest version of each entity recorded at or
+// before asOf. Entities without such a version are left out.
+func (s *Store) LatestVersions(ctx context.Context, table string, ids []uuid.UUID, asOf time.Time) (map[uuid.UUID]domainHistory.EntityVersion, error) {
+	out := make(map[uuid.UUID]domainHistory.EntityVersion, len(ids))
+	for _, chunk := range uuidChunks(ids, 500) {
+		versions, err := s.latestRestoreVersions(ctx, restoreVersionQuery{table: table, ids: chunk, asOf: asOf})
+		if err != nil {
+			return nil, err
+		}
+		for id, version := range versions {
+			out[id] = version
+		}
+	}
+	return out, nil
+}
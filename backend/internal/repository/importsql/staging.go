@@ -3,6 +3,7 @@ package importsql
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -60,6 +61,18 @@ func (s *Store) Start(ctx context.Context, ownerID uuid.UUID) (uuid.UUID, fieldd
 	return record.ID, &session{db: s.db, id: record.ID}, nil
 }
 
+func (s *Store) Open(ctx context.Context, ref fielddeviceimport.SessionRef) (fielddeviceimport.Session, error) {
+	var record sessionRecord
+	err := s.db.WithContext(ctx).Where("id = ? AND owner_id = ? AND status = ?", ref.ImportID, ref.OwnerID, "validating").Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fielddeviceimport.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session{db: s.db, id: record.ID}, nil
+}
+
 func (s *Store) Cleanup(ctx context.Context, cutoff time.Time) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var ids []uuid.UUID
@@ -315,23 +328,30 @@ func (s *session) missingSoftwareReferenceRows(ctx context.Context) ([]fielddevi
 	return mapIssues(rows, "missing_software_reference_row", "BACnet reference is missing from Data-SoftwareReferences"), err
 }
 
+// existingIDIssues accepts field devices that already exist, because those are
+// planned as updates. Owned rows may reuse an ID only under the same owner.
 func (s *session) existingIDIssues(ctx context.Context) ([]fielddeviceimport.Issue, error) {
-	tables := map[string]string{
-		"field_device": "field_devices", "specification": "specifications",
-		"bacnet_object": "bacnet_objects", "alarm_value": "bacnet_object_alarm_values",
+	checks := []existingIDCheck{
+		{kind: "specification", table: "specifications", ownerColumn: "field_device_id"},
+		{kind: "bacnet_object", table: "bacnet_objects", ownerColumn: "field_device_id"},
+		{kind: "alarm_value", table: "bacnet_object_alarm_values", ownerColumn: "bacnet_object_id"},
 	}
 	issues := make([]fielddeviceimport.Issue, 0)
-	for kind, table := range tables {
+	for _, check := range checks {
 		var rows []issueRow
-		query := fmt.Sprintf(`SELECT r.kind, r.source_id, 'source_id' AS field FROM facility_import_rows r JOIN %s target ON target.id = r.source_id WHERE r.import_id = ? AND r.kind = ?`, table)
-		if err := s.db.WithContext(ctx).Raw(query, s.id, kind).Scan(&rows).Error; err != nil {
+		query := fmt.Sprintf(`SELECT r.kind, r.source_id, 'source_id' AS field FROM facility_import_rows r JOIN %s target ON target.id = r.source_id WHERE r.import_id = ? AND r.kind = ? AND (target.%s IS NULL OR target.%s <> r.owner_id)`, check.table, check.ownerColumn, check.ownerColumn)
+		if err := s.db.WithContext(ctx).Raw(query, s.id, check.kind).Scan(&rows).Error; err != nil {
 			return nil, err
 		}
-		issues = append(issues, mapIssues(rows, "source_id_conflict", "source ID already exists")...)
+		issues = append(issues, mapIssues(rows, "source_id_conflict", "source ID already exists under another owner")...)
 	}
 	return issues, nil
 }
 
+type existingIDCheck struct {
+	kind, table, ownerColumn string
+}
+
 func (s *session) referenceDataIssues(ctx context.Context) ([]fielddeviceimport.Issue, error) {
 	checks := []referenceCheck{
 		{kind: "field_device", table: "sps_controller_system_types", field: "SPSControllerSystemTypeID", required: true},
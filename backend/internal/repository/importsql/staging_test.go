@@ -2,6 +2,7 @@ package importsql
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -114,6 +115,36 @@ func TestSessionRejectsCrossAggregateSoftwareReference(t *testing.T) {
 	}
 }
 
+func TestSessionAcceptsExistingDevicesButRejectsForeignOwnedRows(t *testing.T) {
+	db := newStagingTestDB(t)
+	fixture := newStagingFixture(t, db)
+	ownerID := uuid.New()
+	store := NewStore(db)
+	importID, session, err := store.Start(context.Background(), ownerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stageFixture(t, session, fixture)
+	if err := session.Seal(context.Background(), fixture.manifest(1)); err != nil {
+		t.Fatal(err)
+	}
+	insertReference(t, db, "field_devices", fixture.devices[0].ID)
+	db.Exec("INSERT INTO bacnet_objects (id, field_device_id) VALUES (?, ?)", fixture.objects[1].ID, uuid.New())
+
+	resumed, err := store.Open(context.Background(), fielddeviceimport.SessionRef{OwnerID: ownerID, ImportID: importID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues, err := resumed.Validate(context.Background())
+
+	if err != nil || len(issues) != 1 || issues[0].Code != "source_id_conflict" || issues[0].SourceID != fixture.objects[1].ID {
+		t.Fatalf("issues=%+v err=%v", issues, err)
+	}
+	if _, err := store.Open(context.Background(), fielddeviceimport.SessionRef{OwnerID: uuid.New(), ImportID: importID}); !errors.Is(err, fielddeviceimport.ErrSessionNotFound) {
+		t.Fatalf("foreign owner opened session: %v", err)
+	}
+}
+
 type stagingFixture struct {
 	devices      []domainFacility.FieldDevice
 	specs        []domainFacility.Specification
@@ -179,11 +210,16 @@ func newStagingTestDB(t *testing.T) *gorm.DB {
 	if err := Migrate(db); err != nil {
 		t.Fatal(err)
 	}
-	for _, table := range []string{"field_devices", "specifications", "bacnet_objects", "bacnet_object_alarm_values", "sps_controller_system_types", "system_parts", "apparats", "alarm_type_fields", "state_texts", "notification_classes", "alarm_types", "units"} {
+	for _, table := range []string{"field_devices", "sps_controller_system_types", "system_parts", "apparats", "alarm_type_fields", "state_texts", "notification_classes", "alarm_types", "units"} {
 		if err := db.Exec("CREATE TABLE " + table + " (id text primary key)").Error; err != nil {
 			t.Fatal(err)
 		}
 	}
+	for table, owner := range map[string]string{"specifications": "field_device_id", "bacnet_objects": "field_device_id", "bacnet_object_alarm_values": "bacnet_object_id"} {
+		if err := db.Exec("CREATE TABLE " + table + " (id text primary key, " + owner + " text)").Error; err != nil {
+			t.Fatal(err)
+		}
+	}
 	return db
 }
 
@@ -0,0 +1,286 @@
+package projectchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const compactionSweepBatch = 100
+
+func (s *Store) Retention(ctx context.Context, projectID uuid.UUID) (*domainProject.ChangeRetention, error) {
+	var cursors []cursorRecord
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Limit(1).Find(&cursors).Error; err != nil {
+		return nil, fmt.Errorf("load project change cursor: %w", err)
+	}
+	cursor := cursorRecord{ProjectID: projectID}
+	if len(cursors) > 0 {
+		cursor = cursors[0]
+	}
+	return s.retentionOf(cursor), nil
+}
+
+// SetRetention stores the project's retention and compacts the events that
+// fall outside the new window right away.
+func (s *Store) SetRetention(ctx context.Context, projectID uuid.UUID, days *int) (*domainProject.ChangeRetention, error) {
+	if days != nil && (*days < 1 || *days > domainProject.MaxChangeRetentionDays) {
+		return nil, fmt.Errorf("%w: retention must be between 1 and %d days", domain.ErrInvalidArgument, domainProject.MaxChangeRetentionDays)
+	}
+	now := s.now()
+	var cursor cursorRecord
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"retention_days", "updated_at"}),
+		}).Create(&cursorRecord{ProjectID: projectID, RetentionDays: days, UpdatedAt: now}).Error; err != nil {
+			return fmt.Errorf("store project change retention: %w", err)
+		}
+		if err := s.compact(tx, projectID, now, nil); err != nil {
+			return err
+		}
+		return tx.First(&cursor, "project_id = ?", projectID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.retentionOf(cursor), nil
+}
+
+// CompactExpired compacts every project whose retention window has moved past
+// its compacted revision. Appends and retention changes compact their own
+// project; the sweep covers the projects that went quiet.
+func (s *Store) CompactExpired(ctx context.Context) error {
+	now := s.now()
+	afterID := uuid.Nil
+	for {
+		var cursors []cursorRecord
+		if err := s.db.WithContext(ctx).Where("project_id > ?", afterID).
+			Order("project_id ASC").Limit(compactionSweepBatch).Find(&cursors).Error; err != nil {
+			return fmt.Errorf("list project change cursors: %w", err)
+		}
+		for _, cursor := range cursors {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var expired []uint64
+			if err := s.db.WithContext(ctx).Model(&changeRecord{}).
+				Where("project_id = ? AND revision > ? AND occurred_at < ?", cursor.ProjectID, cursor.CompactedThrough, now.AddDate(0, 0, -s.retentionOf(cursor).Days)).
+				Limit(1).Pluck("revision", &expired).Error; err != nil {
+				return fmt.Errorf("find expired project changes: %w", err)
+			}
+			if len(expired) == 0 {
+				continue
+			}
+			err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				var locked cursorRecord
+				if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, "project_id = ?", cursor.ProjectID).Error; err != nil {
+					return fmt.Errorf("lock project change cursor: %w", err)
+				}
+				return s.compact(tx, cursor.ProjectID, now, nil)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(cursors) < compactionSweepBatch {
+			return nil
+		}
+		afterID = cursors[len(cursors)-1].ProjectID
+	}
+}
+
+func (s *Store) retentionOf(cursor cursorRecord) *domainProject.ChangeRetention {
+	retention := &domainProject.ChangeRetention{
+		ProjectID: cursor.ProjectID, Days: int(s.retention / (24 * time.Hour)),
+		CompactedThrough: cursor.CompactedThrough,
+	}
+	if cursor.RetentionDays != nil {
+		retention.Days, retention.Custom = *cursor.RetentionDays, true
+	}
+	return retention
+}
+
+type aggregateKey struct {
+	aggregateType string
+	aggregateID   uuid.UUID
+	hasID         bool
+}
+
+func keyOf(record changeRecord) aggregateKey {
+	if record.AggregateID == nil {
+		return aggregateKey{aggregateType: record.AggregateType}
+	}
+	return aggregateKey{aggregateType: record.AggregateType, aggregateID: *record.AggregateID, hasID: true}
+}
+
+// compact replaces the events that left the project's retention window with
+// the latest event of each aggregate, so a consumer resuming from any
+// revision still learns about every aggregate that changed since. The
+// surviving event carries the changed fields of the events it replaced.
+// Aggregates in appended that still have a compacted event get it folded
+// into their newer events.
+func (s *Store) compact(tx *gorm.DB, projectID uuid.UUID, now time.Time, appended []changeRecord) error {
+	var cursor cursorRecord
+	if err := tx.First(&cursor, "project_id = ?", projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("load project change cursor: %w", err)
+	}
+	days := s.retentionOf(cursor).Days
+	var through uint64
+	if err := tx.Model(&changeRecord{}).
+		Where("project_id = ? AND occurred_at < ?", projectID, now.AddDate(0, 0, -days)).
+		Select("COALESCE(MAX(revision), 0)").Scan(&through).Error; err != nil {
+		return fmt.Errorf("find expired project changes: %w", err)
+	}
+	if through <= cursor.CompactedThrough {
+		return foldCompacted(tx, projectID, cursor.CompactedThrough, appended)
+	}
+
+	var expired []changeRecord
+	if err := tx.Where("project_id = ? AND revision > ? AND revision <= ?", projectID, cursor.CompactedThrough, through).
+		Order("revision ASC").Find(&expired).Error; err != nil {
+		return fmt.Errorf("load expired project changes: %w", err)
+	}
+	seen := make(map[aggregateKey]bool)
+	for _, record := range expired {
+		key := keyOf(record)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if err := compactAggregate(tx, projectID, key, through); err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&cursorRecord{}).Where("project_id = ?", projectID).
+		Update("compacted_through", through).Error; err != nil {
+		return fmt.Errorf("advance project change compaction: %w", err)
+	}
+	return foldCompacted(tx, projectID, through, appended)
+}
+
+func foldCompacted(tx *gorm.DB, projectID uuid.UUID, through uint64, appended []changeRecord) error {
+	if through == 0 || len(appended) == 0 {
+		return nil
+	}
+	touched := make(map[aggregateKey]bool, len(appended))
+	var ids []uuid.UUID
+	var untyped []string
+	for _, record := range appended {
+		key := keyOf(record)
+		if touched[key] {
+			continue
+		}
+		touched[key] = true
+		if key.hasID {
+			ids = append(ids, key.aggregateID)
+		} else {
+			untyped = append(untyped, key.aggregateType)
+		}
+	}
+	var compacted []changeRecord
+	for start := 0; start < len(ids); start += 500 {
+		var chunk []changeRecord
+		if err := tx.Where("project_id = ? AND revision <= ? AND aggregate_id IN ?", projectID, through, ids[start:min(start+500, len(ids))]).
+			Find(&chunk).Error; err != nil {
+			return fmt.Errorf("load compacted project changes: %w", err)
+		}
+		compacted = append(compacted, chunk...)
+	}
+	if len(untyped) > 0 {
+		var chunk []changeRecord
+		if err := tx.Where("project_id = ? AND revision <= ? AND aggregate_id IS NULL AND aggregate_type IN ?", projectID, through, untyped).
+			Find(&chunk).Error; err != nil {
+			return fmt.Errorf("load compacted project changes: %w", err)
+		}
+		compacted = append(compacted, chunk...)
+	}
+	for _, record := range compacted {
+		if key := keyOf(record); touched[key] {
+			if err := compactAggregate(tx, projectID, key, through); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compactAggregate keeps one event of the aggregate up to through, or none
+// if a later event supersedes them. Earlier compactions may have left a
+// survivor below the window, which is folded in as well.
+func compactAggregate(tx *gorm.DB, projectID uuid.UUID, key aggregateKey, through uint64) error {
+	query := func() *gorm.DB {
+		query := tx.Where("project_id = ? AND aggregate_type = ?", projectID, key.aggregateType)
+		if key.hasID {
+			return query.Where("aggregate_id = ?", key.aggregateID)
+		}
+		return query.Where("aggregate_id IS NULL")
+	}
+	var superseded []changeRecord
+	if err := query().Where("revision <= ?", through).Order("revision ASC").Find(&superseded).Error; err != nil {
+		return fmt.Errorf("load compacted project changes: %w", err)
+	}
+	var later []changeRecord
+	if err := query().Where("revision > ?", through).Order("revision ASC").Limit(1).Find(&later).Error; err != nil {
+		return fmt.Errorf("load surviving project change: %w", err)
+	}
+	if len(superseded) == 0 {
+		return nil
+	}
+	var survivor changeRecord
+	if len(later) > 0 {
+		survivor = later[0]
+	} else {
+		survivor, superseded = superseded[len(superseded)-1], superseded[:len(superseded)-1]
+	}
+	if len(superseded) == 0 {
+		return nil
+	}
+
+	fields, err := mergedChangedFields(append(append([]changeRecord{}, superseded...), survivor))
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, len(superseded))
+	for i, record := range superseded {
+		ids[i] = record.EventID
+	}
+	if err := tx.Where("event_id IN ?", ids).Delete(&changeRecord{}).Error; err != nil {
+		return fmt.Errorf("delete compacted project changes: %w", err)
+	}
+	if err := tx.Model(&changeRecord{}).Where("event_id = ?", survivor.EventID).
+		Update("changed_fields", fields).Error; err != nil {
+		return fmt.Errorf("merge compacted changed fields: %w", err)
+	}
+	return nil
+}
+
+// mergedChangedFields unions the changed fields of the records in the order
+// they first changed.
+func mergedChangedFields(records []changeRecord) ([]byte, error) {
+	merged := []string{}
+	seen := map[string]bool{}
+	for _, record := range records {
+		var fields []string
+		if err := json.Unmarshal(record.ChangedFields, &fields); err != nil {
+			return nil, fmt.Errorf("decode project change %s changed fields: %w", record.EventID, err)
+		}
+		for _, field := range fields {
+			if !seen[field] {
+				seen[field] = true
+				merged = append(merged, field)
+			}
+		}
+	}
+	return json.Marshal(merged)
+}
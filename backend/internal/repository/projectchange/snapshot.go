@@ -0,0 +1,27 @@
+package projectchange
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReadAtRevision reads the project's current revision and runs read in the
+// same repeatable-read transaction, so the state read alongside matches it.
+func (s *Store) ReadAtRevision(ctx context.Context, projectID uuid.UUID, read func(ctx context.Context, revision uint64, unit transaction.UnitOfWork) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var revisions []uint64
+		if err := tx.Model(&cursorRecord{}).Where("project_id = ?", projectID).Limit(1).Pluck("current_revision", &revisions).Error; err != nil {
+			return fmt.Errorf("load project change cursor: %w", err)
+		}
+		var revision uint64
+		if len(revisions) > 0 {
+			revision = revisions[0]
+		}
+		return read(ctx, revision, tx)
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
@@ -0,0 +1,41 @@
+package projectchange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestReadAtRevisionPinsTheCurrentRevision(t *testing.T) {
+	store := openStore(t)
+	projectID := uuid.New()
+	for _, aggregateType := range []string{"control_cabinet", "sps_controller"} {
+		if _, err := store.Append(context.Background(), domainProject.NewChange{
+			ProjectID: projectID, AggregateType: aggregateType, Action: domainProject.ChangeCreated,
+		}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	var revision uint64
+	var events int64
+	err := store.ReadAtRevision(context.Background(), projectID, func(ctx context.Context, current uint64, unit transaction.UnitOfWork) error {
+		revision = current
+		return unit.(*gorm.DB).Model(&changeRecord{}).Where("project_id = ?", projectID).Count(&events).Error
+	})
+	if err != nil || revision != 2 || events != 2 {
+		t.Fatalf("ReadAtRevision() = revision %d, %d events, %v; want revision 2 with 2 events", revision, events, err)
+	}
+
+	err = store.ReadAtRevision(context.Background(), uuid.New(), func(_ context.Context, current uint64, _ transaction.UnitOfWork) error {
+		revision = current
+		return nil
+	})
+	if err != nil || revision != 0 {
+		t.Fatalf("ReadAtRevision() for a project without changes = %d, %v; want 0", revision, err)
+	}
+}
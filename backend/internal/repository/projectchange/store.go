@@ -18,10 +18,16 @@ const (
 	Retention    = 30 * 24 * time.Hour
 )
 
+// cursorRecord holds the project revision and feed retention. Events up to
+// CompactedThrough keep only the latest event per aggregate; PrunedThrough
+// marks events that older releases deleted outright.
 type cursorRecord struct {
-	ProjectID       uuid.UUID `gorm:"type:uuid;primaryKey"`
-	CurrentRevision uint64    `gorm:"not null;default:0"`
-	UpdatedAt       time.Time `gorm:"not null"`
+	ProjectID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CurrentRevision  uint64    `gorm:"not null;default:0"`
+	RetentionDays    *int
+	CompactedThrough uint64    `gorm:"not null;default:0"`
+	PrunedThrough    uint64    `gorm:"not null;default:0"`
+	UpdatedAt        time.Time `gorm:"not null"`
 }
 
 func (cursorRecord) TableName() string { return "project_change_cursors" }
@@ -41,16 +47,28 @@ type changeRecord struct {
 
 func (changeRecord) TableName() string { return "project_changes" }
 
+// ChangeOutbox queues the deliveries of a batch inside the transaction that
+// stores it.
+type ChangeOutbox interface {
+	QueueChanges(tx *gorm.DB, changes []domainProject.Change) error
+}
+
 type Store struct {
 	db        *gorm.DB
 	now       func() time.Time
 	retention time.Duration
+	outbox    ChangeOutbox
 }
 
 func NewStore(db *gorm.DB) *Store {
 	return &Store{db: db, now: func() time.Time { return time.Now().UTC() }, retention: Retention}
 }
 
+// SetOutbox must be called before changes are appended.
+func (s *Store) SetOutbox(outbox ChangeOutbox) {
+	s.outbox = outbox
+}
+
 func AutoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(&cursorRecord{}, &changeRecord{})
 }
@@ -115,10 +133,14 @@ func (s *Store) AppendBatch(ctx context.Context, inputs []domainProject.NewChang
 		if err := tx.CreateInBatches(records, 500).Error; err != nil {
 			return fmt.Errorf("insert project change batch: %w", err)
 		}
-		cutoff := now.Add(-s.retention)
-		if err := tx.Where("occurred_at < ?", cutoff).
-			Delete(&changeRecord{}).Error; err != nil {
-			return fmt.Errorf("prune project changes: %w", err)
+		if err := s.compact(tx, projectID, now, records); err != nil {
+			return err
+		}
+		if s.outbox == nil {
+			return nil
+		}
+		if err := s.outbox.QueueChanges(tx, changes); err != nil {
+			return fmt.Errorf("queue project change deliveries: %w", err)
 		}
 		return nil
 	})
@@ -141,18 +163,8 @@ func (s *Store) ListAfter(ctx context.Context, projectID uuid.UUID, afterRevisio
 		return page, nil
 	}
 	page.CurrentRevision = cursor.CurrentRevision
-	if afterRevision > page.CurrentRevision {
-		page.ResetRequired = true
-		return page, nil
-	}
-
-	var earliest uint64
-	if err := s.db.WithContext(ctx).Model(&changeRecord{}).
-		Where("project_id = ?", projectID).
-		Select("COALESCE(MIN(revision), 0)").Scan(&earliest).Error; err != nil {
-		return nil, fmt.Errorf("load earliest project revision: %w", err)
-	}
-	if (earliest == 0 && afterRevision < page.CurrentRevision) || (earliest > 0 && afterRevision < earliest-1) {
+	page.CompactedThrough = cursor.CompactedThrough
+	if afterRevision > page.CurrentRevision || afterRevision < cursor.PrunedThrough {
 		page.ResetRequired = true
 		return page, nil
 	}
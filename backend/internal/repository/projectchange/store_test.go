@@ -2,10 +2,12 @@ package projectchange
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
 	"github.com/google/uuid"
 	"gorm.io/driver/sqlite"
@@ -47,31 +49,137 @@ func TestStoreAllocatesMonotonicProjectRevisionsAndPages(t *testing.T) {
 	}
 }
 
-func TestStoreRequiresResetWhenRetentionCreatesRevisionGap(t *testing.T) {
+func TestStoreCompactsExpiredEventsToLatestPerAggregate(t *testing.T) {
 	store := openStore(t)
 	now := time.Date(2026, 8, 13, 12, 0, 0, 0, time.UTC)
 	store.now = func() time.Time { return now }
-	projectID := uuid.New()
+	projectID, deviceID, cabinetID := uuid.New(), uuid.New(), uuid.New()
+	old := now.Add(-Retention - time.Hour)
 
-	if _, err := store.Append(context.Background(), domainProject.NewChange{
-		ProjectID: projectID, AggregateType: "project", Action: domainProject.ChangeCreated,
-		OccurredAt: now.Add(-Retention - time.Hour),
-	}); err != nil {
-		t.Fatalf("append old event: %v", err)
-	}
-	if _, err := store.Append(context.Background(), domainProject.NewChange{
-		ProjectID: projectID, AggregateType: "project", Action: domainProject.ChangeUpdated,
-		OccurredAt: now,
-	}); err != nil {
-		t.Fatalf("append current event: %v", err)
+	for _, input := range []domainProject.NewChange{
+		{AggregateType: "field_device", AggregateID: &deviceID, Action: domainProject.ChangeCreated, OccurredAt: old},
+		{AggregateType: "field_device", AggregateID: &deviceID, Action: domainProject.ChangeUpdated, ChangedFields: []string{"bmk"}, OccurredAt: old},
+		{AggregateType: "control_cabinet", AggregateID: &cabinetID, Action: domainProject.ChangeCreated, OccurredAt: old},
+		{AggregateType: "field_device", AggregateID: &deviceID, Action: domainProject.ChangeUpdated, ChangedFields: []string{"description"}, OccurredAt: now},
+	} {
+		input.ProjectID = projectID
+		if _, err := store.Append(context.Background(), input); err != nil {
+			t.Fatalf("append: %v", err)
+		}
 	}
 
 	page, err := store.ListAfter(context.Background(), projectID, 0, 100)
 	if err != nil {
-		t.Fatalf("list retained events: %v", err)
+		t.Fatalf("list compacted events: %v", err)
+	}
+	if page.ResetRequired || page.CurrentRevision != 4 || page.CompactedThrough != 3 || len(page.Events) != 2 {
+		t.Fatalf("expected the cabinet and the latest device event, got %+v", page)
+	}
+	if page.Events[0].Revision != 3 || page.Events[1].Revision != 4 {
+		t.Fatalf("unexpected surviving revisions: %+v", page.Events)
 	}
-	if !page.ResetRequired || len(page.Events) != 0 || page.CurrentRevision != 2 {
-		t.Fatalf("expected reset at revision 2, got %+v", page)
+	if fields := page.Events[1].ChangedFields; len(fields) != 2 || fields[0] != "bmk" || fields[1] != "description" {
+		t.Fatalf("surviving event must carry the compacted fields, got %v", fields)
+	}
+}
+
+func TestStoreRetentionIsConfigurablePerProject(t *testing.T) {
+	store := openStore(t)
+	now := time.Date(2026, 8, 13, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	longLived, defaulted := uuid.New(), uuid.New()
+	days := 90
+	if _, err := store.SetRetention(context.Background(), longLived, &days); err != nil {
+		t.Fatalf("set retention: %v", err)
+	}
+	for _, projectID := range []uuid.UUID{longLived, defaulted} {
+		for _, action := range []domainProject.ChangeAction{domainProject.ChangeCreated, domainProject.ChangeUpdated} {
+			if _, err := store.Append(context.Background(), domainProject.NewChange{
+				ProjectID: projectID, AggregateType: "project", Action: action, OccurredAt: now.AddDate(0, 0, -40),
+			}); err != nil {
+				t.Fatalf("append: %v", err)
+			}
+		}
+	}
+
+	for projectID, want := range map[uuid.UUID]int{longLived: 2, defaulted: 1} {
+		page, err := store.ListAfter(context.Background(), projectID, 0, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Events) != want {
+			t.Fatalf("project kept %d events, want %d", len(page.Events), want)
+		}
+	}
+
+	retention, err := store.SetRetention(context.Background(), longLived, ptr(7))
+	if err != nil || retention.Days != 7 || !retention.Custom || retention.CompactedThrough != 2 {
+		t.Fatalf("shortening the retention must compact right away, got %+v, %v", retention, err)
+	}
+	retention, err = store.Retention(context.Background(), defaulted)
+	if err != nil || retention.Days != 30 || retention.Custom {
+		t.Fatalf("default retention = %+v, %v", retention, err)
+	}
+	if _, err := store.SetRetention(context.Background(), defaulted, ptr(0)); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("zero days must be rejected, got %v", err)
+	}
+}
+
+func TestStoreCompactExpiredSweepsQuietProjects(t *testing.T) {
+	store := openStore(t)
+	now := time.Date(2026, 8, 13, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	quiet, fresh := uuid.New(), uuid.New()
+	for projectID, occurredAt := range map[uuid.UUID]time.Time{quiet: now.AddDate(0, 0, -20), fresh: now} {
+		for _, action := range []domainProject.ChangeAction{domainProject.ChangeCreated, domainProject.ChangeUpdated} {
+			if _, err := store.Append(context.Background(), domainProject.NewChange{
+				ProjectID: projectID, AggregateType: "project", Action: action, OccurredAt: occurredAt,
+			}); err != nil {
+				t.Fatalf("append: %v", err)
+			}
+		}
+	}
+
+	now = now.AddDate(0, 0, 15)
+	if err := store.CompactExpired(context.Background()); err != nil {
+		t.Fatalf("compact expired: %v", err)
+	}
+
+	for projectID, want := range map[uuid.UUID]struct {
+		compactedThrough uint64
+		events           int
+	}{quiet: {2, 1}, fresh: {0, 2}} {
+		page, err := store.ListAfter(context.Background(), projectID, 0, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.CompactedThrough != want.compactedThrough || len(page.Events) != want.events {
+			t.Fatalf("page = %+v, want %+v", page, want)
+		}
+	}
+}
+
+func TestStoreRequiresResetBehindLegacyPrunedEvents(t *testing.T) {
+	store := openStore(t)
+	projectID := uuid.New()
+	for range 3 {
+		if _, err := store.Append(context.Background(), domainProject.NewChange{
+			ProjectID: projectID, AggregateType: "project", Action: domainProject.ChangeUpdated,
+		}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := store.db.Model(&cursorRecord{}).Where("project_id = ?", projectID).Update("pruned_through", 2).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := store.ListAfter(context.Background(), projectID, 1, 100)
+	if err != nil || !page.ResetRequired || len(page.Events) != 0 {
+		t.Fatalf("expected reset behind pruned events, got %+v, %v", page, err)
+	}
+	page, err = store.ListAfter(context.Background(), projectID, 2, 100)
+	if err != nil || page.ResetRequired || len(page.Events) != 1 {
+		t.Fatalf("expected the event after the pruned range, got %+v, %v", page, err)
 	}
 }
 
@@ -115,6 +223,49 @@ func TestStoreAppendBatchAllocatesConsecutiveRevisions(t *testing.T) {
 	}
 }
 
+func TestStoreQueuesDeliveriesInTheAppendTransaction(t *testing.T) {
+	store := openStore(t)
+	projectID := uuid.New()
+	outbox := &stubOutbox{}
+	store.SetOutbox(outbox)
+	if _, err := store.AppendBatch(context.Background(), []domainProject.NewChange{
+		{ProjectID: projectID, AggregateType: "field_device", AggregateID: ptr(uuid.New()), Action: domainProject.ChangeCreated},
+	}); err != nil {
+		t.Fatalf("append batch: %v", err)
+	}
+	if len(outbox.queued) != 1 || outbox.queued[0].Revision != 1 || outbox.visible != 1 {
+		t.Fatalf("outbox saw %+v with %d stored rows, want the revision inside the transaction", outbox.queued, outbox.visible)
+	}
+
+	outbox.err = errors.New("deliveries not written")
+	if _, err := store.AppendBatch(context.Background(), []domainProject.NewChange{
+		{ProjectID: projectID, AggregateType: "field_device", AggregateID: ptr(uuid.New()), Action: domainProject.ChangeUpdated},
+	}); !errors.Is(err, outbox.err) {
+		t.Fatalf("append batch = %v, want the outbox failure", err)
+	}
+	page, err := store.ListAfter(context.Background(), projectID, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.CurrentRevision != 1 || len(page.Events) != 1 {
+		t.Fatalf("change stored without its deliveries: %+v", page)
+	}
+}
+
+type stubOutbox struct {
+	queued  []domainProject.Change
+	visible int64
+	err     error
+}
+
+func (o *stubOutbox) QueueChanges(tx *gorm.DB, changes []domainProject.Change) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.queued = append(o.queued, changes...)
+	return tx.Model(&changeRecord{}).Count(&o.visible).Error
+}
+
 func ptr[T any](value T) *T { return &value }
 
 func openStore(t *testing.T) *Store {
@@ -0,0 +1,136 @@
+package projectstatesql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	"github.com/besart951/go_infra_link/backend/internal/repository/baselinesql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const idChunkSize = 1000
+
+// Store reads the stored rows of a project for change feed snapshots. It
+// reuses the baseline view of the project's facility rows and adds the
+// system types, object data and members the baseline leaves out.
+type Store struct {
+	db *gorm.DB
+}
+
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+func (s *Store) ProjectRow(ctx context.Context, projectID uuid.UUID) (json.RawMessage, error) {
+	row, found, err := historysql.NewStore(s.db).LoadRow(ctx, "projects", projectID)
+	if err != nil {
+		return nil, fmt.Errorf("load project: %w", err)
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	return json.RawMessage(row), nil
+}
+
+func (s *Store) Rows(ctx context.Context, projectID uuid.UUID, tables []string) (map[string][]domainProject.ChangeStateRow, error) {
+	rows := make(map[string][]domainProject.ChangeStateRow, len(tables))
+	wanted := func(table string) bool { return slices.Contains(tables, table) }
+	var controllerIDs []uuid.UUID
+	fromBaseline := slices.ContainsFunc(projectbaseline.Categories(), func(category projectbaseline.Category) bool { return wanted(string(category)) })
+	if fromBaseline || wanted("sps_controller_system_types") {
+		entities, err := baselinesql.NewStore(s.db).LoadCurrent(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("load project facility state: %w", err)
+		}
+		for _, entity := range entities {
+			table := string(entity.Category)
+			if table == "sps_controllers" {
+				controllerIDs = append(controllerIDs, entity.ID)
+			}
+			if wanted(table) {
+				rows[table] = append(rows[table], domainProject.ChangeStateRow{ID: entity.ID, State: entity.Snapshot})
+			}
+		}
+	}
+	if wanted("sps_controller_system_types") {
+		var ids []uuid.UUID
+		for chunk := range slices.Chunk(controllerIDs, idChunkSize) {
+			found, err := s.ids(ctx, "sps_controller_system_types", "sps_controller_id IN ?", chunk)
+			if err != nil {
+				return nil, fmt.Errorf("load project system types: %w", err)
+			}
+			ids = append(ids, found...)
+		}
+		systemTypes, err := s.load(ctx, "sps_controller_system_types", ids)
+		if err != nil {
+			return nil, fmt.Errorf("load project system types: %w", err)
+		}
+		rows["sps_controller_system_types"] = systemTypes
+	}
+	if wanted("object_data") {
+		ids, err := s.ids(ctx, "object_data", "project_id = ?", projectID)
+		if err != nil {
+			return nil, fmt.Errorf("load project object data: %w", err)
+		}
+		objectData, err := s.load(ctx, "object_data", ids)
+		if err != nil {
+			return nil, fmt.Errorf("load project object data: %w", err)
+		}
+		rows["object_data"] = objectData
+	}
+	if wanted("project_users") {
+		members, err := s.members(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		rows["project_users"] = members
+	}
+	return rows, nil
+}
+
+func (s *Store) ids(ctx context.Context, table, where string, args ...any) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := s.db.WithContext(ctx).Table(table).Where(where, args...).Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// load returns the rows of ids in the given order.
+func (s *Store) load(ctx context.Context, table string, ids []uuid.UUID) ([]domainProject.ChangeStateRow, error) {
+	loaded, err := historysql.NewStore(s.db).LoadRows(ctx, table, ids)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]domainProject.ChangeStateRow, 0, len(ids))
+	for _, id := range ids {
+		if row, ok := loaded[id]; ok {
+			rows = append(rows, domainProject.ChangeStateRow{ID: id, State: json.RawMessage(row)})
+		}
+	}
+	return rows, nil
+}
+
+// members lists the project's users keyed by user ID; the membership row has
+// no ID of its own.
+func (s *Store) members(ctx context.Context, projectID uuid.UUID) ([]domainProject.ChangeStateRow, error) {
+	var userIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).Table("project_users").Where("project_id = ?", projectID).
+		Order("user_id").Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("load project users: %w", err)
+	}
+	rows := make([]domainProject.ChangeStateRow, 0, len(userIDs))
+	for _, userID := range userIDs {
+		state, err := json.Marshal(map[string]uuid.UUID{"project_id": projectID, "user_id": userID})
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, domainProject.ChangeStateRow{ID: userID, State: state})
+	}
+	return rows, nil
+}
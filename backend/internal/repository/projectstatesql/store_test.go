@@ -0,0 +1,103 @@
+package projectstatesql
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	projectrepo "github.com/besart951/go_infra_link/backend/internal/repository/project"
+	"github.com/besart951/go_infra_link/backend/internal/repository/projectsql"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRowsCoverTheProjectAggregates(t *testing.T) {
+	db := openDB(t)
+	project := projectrepo.ProjectRecord{Name: "Snapshot", Status: "planned", PhaseID: uuid.New(), CreatorID: uuid.New()}
+	seed(t, db, &project)
+	nr := "SG01"
+	cabinet := domainFacility.ControlCabinet{BuildingID: uuid.New(), ControlCabinetNr: &nr}
+	seed(t, db, &cabinet)
+	controller := domainFacility.SPSController{ControlCabinetID: cabinet.ID, DeviceName: "AS01"}
+	seed(t, db, &controller)
+	systemType := domainFacility.SPSControllerSystemType{SPSControllerID: controller.ID, SystemTypeID: uuid.New()}
+	seed(t, db, &systemType)
+	objectData := domainFacility.ObjectData{Description: "AHU", Version: "1.0", ProjectID: &project.ID}
+	seed(t, db, &objectData)
+	seed(t, db, &domainFacility.ObjectData{Description: "Library", Version: "1.0"})
+	seed(t, db, &projectsql.ProjectControlCabinetRecord{ProjectID: project.ID, ControlCabinetID: cabinet.ID})
+	seed(t, db, &projectsql.ProjectSPSControllerRecord{ProjectID: project.ID, SPSControllerID: controller.ID})
+	member := uuid.New()
+	if err := db.Create(&projectrepo.ProjectUserRecord{ProjectID: project.ID, UserID: member}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(db)
+	ctx := context.Background()
+	if row, err := store.ProjectRow(ctx, project.ID); err != nil || len(row) == 0 {
+		t.Fatalf("ProjectRow() = %s, %v", row, err)
+	}
+	if _, err := store.ProjectRow(ctx, uuid.New()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("unknown project must not be found, got %v", err)
+	}
+	rows, err := store.Rows(ctx, project.ID, []string{"project_users", "object_data", "control_cabinets", "sps_controllers", "sps_controller_system_types"})
+	if err != nil {
+		t.Fatalf("Rows() error = %v", err)
+	}
+	want := map[string]uuid.UUID{
+		"project_users": member, "object_data": objectData.ID, "control_cabinets": cabinet.ID,
+		"sps_controllers": controller.ID, "sps_controller_system_types": systemType.ID,
+	}
+	for table, id := range want {
+		if len(rows[table]) != 1 || rows[table][0].ID != id || len(rows[table][0].State) == 0 {
+			t.Fatalf("%s rows = %+v, want only %s", table, rows[table], id)
+		}
+	}
+
+	filtered, err := store.Rows(ctx, project.ID, []string{"sps_controllers"})
+	if err != nil || len(filtered) != 1 || len(filtered["sps_controllers"]) != 1 {
+		t.Fatalf("filtered rows = %+v, %v", filtered, err)
+	}
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "states.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&projectrepo.ProjectRecord{},
+		&projectrepo.ProjectUserRecord{},
+		&projectsql.ProjectControlCabinetRecord{},
+		&projectsql.ProjectSPSControllerRecord{},
+		&projectsql.ProjectFieldDeviceRecord{},
+		&domainFacility.ControlCabinet{},
+		&domainFacility.SPSController{},
+		&domainFacility.SPSControllerSystemType{},
+		&domainFacility.ObjectData{},
+	); err != nil {
+		t.Fatalf("migrate project tables: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sqlite handle: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
+
+func seed(t *testing.T, db *gorm.DB, entity interface{ GetBase() *domain.Base }) {
+	t.Helper()
+	if err := entity.GetBase().InitForCreate(time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Omit("*.*").Create(entity).Error; err != nil {
+		t.Fatalf("seed record: %v", err)
+	}
+}
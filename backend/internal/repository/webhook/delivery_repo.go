@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"github.com/besart951/go_infra_link/backend/internal/repository/gormbase"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type deliveryRepo struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) domainWebhook.DeliveryRepository {
+	return &deliveryRepo{db: db}
+}
+
+func (r *deliveryRepo) CreateBatch(ctx context.Context, deliveries []domainWebhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	for i := range deliveries {
+		if deliveries[i].Status == "" {
+			deliveries[i].Status = domainWebhook.DeliveryStatusPending
+		}
+		if deliveries[i].NextAttemptAt.IsZero() {
+			deliveries[i].NextAttemptAt = now
+		}
+		if err := deliveries[i].InitForCreate(now); err != nil {
+			return err
+		}
+	}
+	return r.db.WithContext(ctx).CreateInBatches(deliveries, 200).Error
+}
+
+// Claim moves next_attempt_at of each due delivery to leaseUntil with a
+// conditional update; only the worker whose update hits the row owns it. A
+// worker that dies mid-send leaves the delivery due again once the lease ends.
+func (r *deliveryRepo) Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domainWebhook.Delivery, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var due []domainWebhook.Delivery
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", domainWebhook.DeliveryStatusPending, now).
+		Order("next_attempt_at ASC, created_at ASC").
+		Limit(limit).
+		Find(&due).Error; err != nil {
+		return nil, err
+	}
+	claimed := due[:0]
+	for _, delivery := range due {
+		result := r.db.WithContext(ctx).Model(&domainWebhook.Delivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at <= ?", delivery.ID, domainWebhook.DeliveryStatusPending, now).
+			Update("next_attempt_at", leaseUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			delivery.NextAttemptAt = leaseUntil
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *deliveryRepo) RecordAttempt(ctx context.Context, id uuid.UUID, attempt domainWebhook.DeliveryAttempt) error {
+	updates := map[string]any{
+		"updated_at":      time.Now().UTC(),
+		"status":          attempt.Status,
+		"attempts":        attempt.Attempts,
+		"next_attempt_at": attempt.NextAttemptAt,
+		"response_status": attempt.ResponseStatus,
+		"last_error":      attempt.Error,
+	}
+	if attempt.Status == domainWebhook.DeliveryStatusDelivered {
+		updates["delivered_at"] = attempt.At
+	}
+	return r.db.WithContext(ctx).Model(&domainWebhook.Delivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *deliveryRepo) GetByID(ctx context.Context, id uuid.UUID) (*domainWebhook.Delivery, error) {
+	var delivery domainWebhook.Delivery
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *deliveryRepo) List(ctx context.Context, filter domainWebhook.DeliveryFilter) (*domain.PaginatedList[domainWebhook.Delivery], error) {
+	query := r.db.WithContext(ctx).Model(&domainWebhook.Delivery{})
+	if filter.SubscriptionID != nil {
+		query = query.Where("subscription_id = ?", *filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return gormbase.ExactOffsetPage[domainWebhook.Delivery](query, gormbase.NormalizeOffsetPage(filter.PaginationParams, 50), "created_at DESC")
+}
+
+func (r *deliveryRepo) Replay(ctx context.Context, filter domainWebhook.ReplayFilter, now time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&domainWebhook.Delivery{}).Where("status = ?", domainWebhook.DeliveryStatusFailed)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.SubscriptionID != nil {
+		query = query.Where("subscription_id = ?", *filter.SubscriptionID)
+	}
+	result := query.Updates(map[string]any{
+		"updated_at":      now,
+		"status":          domainWebhook.DeliveryStatusPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+		"last_error":      "",
+	})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDeliveryClaimLeasesAndReplayRequeuesFailures(t *testing.T) {
+	db := openDB(t)
+	subscriptions, deliveries := NewSubscriptionRepository(db), NewDeliveryRepository(db)
+	subscription := &domainWebhook.Subscription{Name: "GMS", URL: "https://example.com/hook", Active: true, SecretCipher: "x", Actions: []string{"created"}}
+	if err := subscriptions.Create(t.Context(), subscription); err != nil {
+		t.Fatal(err)
+	}
+	subscription.Actions = []string{"updated", "deleted"}
+	subscription.Active = false
+	if err := subscriptions.Update(t.Context(), subscription); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := subscriptions.GetByID(t.Context(), subscription.ID)
+	if err != nil || stored.Active || !slices.Equal(stored.Actions, []string{"updated", "deleted"}) {
+		t.Fatalf("stored = %+v, %v", stored, err)
+	}
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	batch := []domainWebhook.Delivery{
+		{SubscriptionID: subscription.ID, EventType: domainWebhook.EventProjectChange, EventID: uuid.New(), Payload: []byte(`{}`), NextAttemptAt: now},
+		{SubscriptionID: subscription.ID, EventType: domainWebhook.EventProjectChange, EventID: uuid.New(), Payload: []byte(`{}`), NextAttemptAt: now.Add(time.Hour)},
+	}
+	if err := deliveries.CreateBatch(t.Context(), batch); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err := deliveries.Claim(t.Context(), now, now.Add(time.Minute), 10)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != batch[0].ID {
+		t.Fatalf("Claim() = %+v, %v", claimed, err)
+	}
+	if again, err := deliveries.Claim(t.Context(), now, now.Add(time.Minute), 10); err != nil || len(again) != 0 {
+		t.Fatalf("leased delivery claimed twice: %+v, %v", again, err)
+	}
+
+	status := 500
+	if err := deliveries.RecordAttempt(t.Context(), claimed[0].ID, domainWebhook.DeliveryAttempt{
+		Status: domainWebhook.DeliveryStatusFailed, Attempts: domainWebhook.MaxDeliveryAttempts, At: now, NextAttemptAt: now, ResponseStatus: &status, Error: "endpoint answered 500",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	failed, err := deliveries.List(t.Context(), domainWebhook.DeliveryFilter{Status: domainWebhook.DeliveryStatusFailed})
+	if err != nil || failed.Total != 1 || *failed.Items[0].ResponseStatus != 500 {
+		t.Fatalf("List(failed) = %+v, %v", failed, err)
+	}
+
+	other := uuid.New()
+	if replayed, err := deliveries.Replay(t.Context(), domainWebhook.ReplayFilter{SubscriptionID: &other}, now); err != nil || replayed != 0 {
+		t.Fatalf("replay of another subscription = %d, %v", replayed, err)
+	}
+	later := now.Add(2 * time.Minute)
+	if replayed, err := deliveries.Replay(t.Context(), domainWebhook.ReplayFilter{}, later); err != nil || replayed != 1 {
+		t.Fatalf("Replay() = %d, %v", replayed, err)
+	}
+	requeued, err := deliveries.GetByID(t.Context(), claimed[0].ID)
+	if err != nil || requeued.Status != domainWebhook.DeliveryStatusPending || requeued.Attempts != 0 || !requeued.NextAttemptAt.Equal(later) {
+		t.Fatalf("requeued = %+v, %v", requeued, err)
+	}
+
+	if err := subscriptions.DeleteByID(t.Context(), subscription.ID); err != nil {
+		t.Fatal(err)
+	}
+	if remaining, err := deliveries.List(t.Context(), domainWebhook.DeliveryFilter{}); err != nil || remaining.Total != 0 {
+		t.Fatalf("deliveries left after deleting the subscription: %+v, %v", remaining, err)
+	}
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webhooks.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&domainWebhook.Subscription{}, &domainWebhook.Delivery{}); err != nil {
+		t.Fatalf("migrate webhooks: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sqlite handle: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"time"
+
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"gorm.io/gorm"
+)
+
+// Outbox writes the deliveries of an event inside the transaction that
+// records the event, so both commit or roll back together. The delivery
+// worker picks the rows up from the table.
+type Outbox struct {
+	now func() time.Time
+}
+
+func NewOutbox() *Outbox {
+	return &Outbox{now: func() time.Time { return time.Now().UTC() }}
+}
+
+func (o *Outbox) QueueChanges(tx *gorm.DB, changes []domainProject.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	subscriptions, err := activeSubscriptions(tx)
+	if err != nil {
+		return err
+	}
+	now := o.now()
+	deliveries, err := domainWebhook.ChangeDeliveries(subscriptions, changes, now)
+	if err != nil {
+		return err
+	}
+	return insertDeliveries(tx, deliveries, now)
+}
+
+func (o *Outbox) QueueJobStatus(tx *gorm.DB, job domainWebhook.JobStatus) error {
+	subscriptions, err := activeSubscriptions(tx)
+	if err != nil {
+		return err
+	}
+	now := o.now()
+	deliveries, err := domainWebhook.JobDeliveries(subscriptions, job, now)
+	if err != nil {
+		return err
+	}
+	return insertDeliveries(tx, deliveries, now)
+}
+
+func activeSubscriptions(tx *gorm.DB) ([]domainWebhook.Subscription, error) {
+	var subscriptions []domainWebhook.Subscription
+	err := tx.Where("active = ?", true).Order("created_at ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func insertDeliveries(tx *gorm.DB, deliveries []domainWebhook.Delivery, now time.Time) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	for i := range deliveries {
+		if err := deliveries[i].InitForCreate(now); err != nil {
+			return err
+		}
+	}
+	return tx.CreateInBatches(deliveries, 200).Error
+}
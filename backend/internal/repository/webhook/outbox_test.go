@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestOutboxQueuesDeliveriesInTheCallersTransaction(t *testing.T) {
+	db := openDB(t)
+	subscriptions, deliveries := NewSubscriptionRepository(db), NewDeliveryRepository(db)
+	projectID := uuid.New()
+	project := &domainWebhook.Subscription{Name: "GMS", URL: "https://example.com/gms", ProjectID: &projectID, Active: true, SecretCipher: "x", Actions: []string{"updated"}}
+	global := &domainWebhook.Subscription{Name: "Ticketing", URL: "https://example.com/jobs", Active: true, SecretCipher: "x", EventTypes: []string{string(domainWebhook.EventFacilityJobStatus)}, JobStatuses: []string{"failed"}}
+	for _, subscription := range []*domainWebhook.Subscription{project, global} {
+		if err := subscriptions.Create(t.Context(), subscription); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	outbox := NewOutbox()
+	outbox.now = func() time.Time { return now }
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := outbox.QueueChanges(tx, []domainProject.Change{
+			{EventID: uuid.New(), ProjectID: projectID, Revision: 4, AggregateType: "field_device", Action: domainProject.ChangeUpdated, OccurredAt: now},
+			{EventID: uuid.New(), ProjectID: projectID, Revision: 5, AggregateType: "field_device", Action: domainProject.ChangeCreated, OccurredAt: now},
+		}); err != nil {
+			return err
+		}
+		if err := outbox.QueueJobStatus(tx, domainWebhook.JobStatus{JobID: uuid.New(), Status: "running", UpdatedAt: now}); err != nil {
+			return err
+		}
+		return outbox.QueueJobStatus(tx, domainWebhook.JobStatus{JobID: uuid.New(), Status: "failed", UpdatedAt: now})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := deliveries.List(t.Context(), domainWebhook.DeliveryFilter{})
+	if err != nil || queued.Total != 2 {
+		t.Fatalf("deliveries = %+v, %v; want the updated change and the failed job", queued, err)
+	}
+	for _, delivery := range queued.Items {
+		if delivery.Status != domainWebhook.DeliveryStatusPending || !delivery.NextAttemptAt.Equal(now) {
+			t.Fatalf("delivery = %+v, want pending and due now", delivery)
+		}
+		var envelope struct {
+			Data map[string]any `json:"data"`
+		}
+		if err := json.Unmarshal(delivery.Payload, &envelope); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := envelope.Data["owner_id"]; ok {
+			t.Fatalf("payload exposes the owner: %s", delivery.Payload)
+		}
+	}
+
+	rollback := db.Transaction(func(tx *gorm.DB) error {
+		if err := outbox.QueueJobStatus(tx, domainWebhook.JobStatus{JobID: uuid.New(), Status: "failed", UpdatedAt: now}); err != nil {
+			return err
+		}
+		return gorm.ErrInvalidTransaction
+	})
+	if rollback == nil {
+		t.Fatal("transaction did not fail")
+	}
+	if after, err := deliveries.List(t.Context(), domainWebhook.DeliveryFilter{}); err != nil || after.Total != 2 {
+		t.Fatalf("deliveries after rollback = %+v, %v; want the rolled back event gone", after, err)
+	}
+}
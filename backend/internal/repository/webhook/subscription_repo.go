@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type subscriptionRepo struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) domainWebhook.SubscriptionRepository {
+	return &subscriptionRepo{db: db}
+}
+
+func (r *subscriptionRepo) Create(ctx context.Context, subscription *domainWebhook.Subscription) error {
+	if err := subscription.InitForCreate(time.Now().UTC()); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *subscriptionRepo) Update(ctx context.Context, subscription *domainWebhook.Subscription) error {
+	subscription.TouchForUpdate(time.Now().UTC())
+	// Select writes zero values too and keeps the JSON serializer of the
+	// filter lists, which map updates would bypass.
+	result := r.db.WithContext(ctx).Model(subscription).
+		Select("updated_at", "name", "url", "project_id", "active", "secret_cipher",
+			"event_types", "aggregate_types", "actions", "job_statuses").
+		Updates(subscription)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByID removes the subscription together with its delivery log.
+func (r *subscriptionRepo) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return domain.ErrInvalidArgument
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&domainWebhook.Delivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&domainWebhook.Subscription{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *subscriptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*domainWebhook.Subscription, error) {
+	var subscription domainWebhook.Subscription
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *subscriptionRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domainWebhook.Subscription, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var subscriptions []domainWebhook.Subscription
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *subscriptionRepo) List(ctx context.Context, projectID *uuid.UUID) ([]domainWebhook.Subscription, error) {
+	query := r.db.WithContext(ctx).Model(&domainWebhook.Subscription{})
+	if projectID != nil {
+		query = query.Where("project_id = ?", *projectID)
+	}
+	var subscriptions []domainWebhook.Subscription
+	err := query.Order("created_at DESC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *subscriptionRepo) ListActive(ctx context.Context) ([]domainWebhook.Subscription, error) {
+	return activeSubscriptions(r.db.WithContext(ctx))
+}
@@ -0,0 +1,337 @@
+package exporting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainHistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/google/uuid"
+)
+
+const (
+	changeReportTask     = "history.changereport.v1"
+	changeReportFileName = "change-report"
+	changeReportPageSize = 200
+
+	// maxChangeReportEvents bounds the events held in memory while a report
+	// is grouped. Larger ranges are cut off and flagged as truncated.
+	maxChangeReportEvents = 20000
+)
+
+var ErrChangeReportsUnavailable = errors.New("change reports unavailable")
+
+// changeReportVolatileFields change with every update and carry no meaning
+// for the reader of a change list.
+var changeReportVolatileFields = map[string]struct{}{
+	"id": {}, "created_at": {}, "updated_at": {}, "deleted_at": {}, "version": {},
+}
+
+type changeReports struct {
+	events    domainExport.ChangeEventSource
+	generator domainExport.ChangeReportGenerator
+	labels    domainExport.LabelCatalog
+}
+
+// SetChangeReports enables change report jobs, which render the history
+// timeline as a localized change list.
+func (s *Service) SetChangeReports(events domainExport.ChangeEventSource, generator domainExport.ChangeReportGenerator, labels domainExport.LabelCatalog) {
+	s.reports = &changeReports{events: events, generator: generator, labels: labels}
+	if s.jobs != nil {
+		s.jobs.RegisterTask(changeReportTask, facilityservice.FacilityJobHandlerFunc(s.runChangeReport))
+	}
+}
+
+func (s *Service) CreateChangeReport(ctx context.Context, ownerID, operationID uuid.UUID, req domainExport.ChangeReportRequest) (domainExport.Job, error) {
+	if s.jobs == nil || s.reports == nil {
+		return domainExport.Job{}, ErrChangeReportsUnavailable
+	}
+	if req.OutputType == "" {
+		req.OutputType = domainExport.OutputTypeChangeReportExcel
+	}
+	if !req.OutputType.IsChangeReport() {
+		return domainExport.Job{}, fmt.Errorf("%w: %s", ErrUnsupportedOutputType, req.OutputType)
+	}
+	if req.AccessScope == "" {
+		req.AccessScope = domainExport.AccessScopeGlobal
+	}
+	req.Filter.Page, req.Filter.Limit, req.Filter.Cursor = 0, 0, ""
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return domainExport.Job{}, fmt.Errorf("encode change report request: %w", err)
+	}
+	job, err := s.jobs.SubmitTask(ctx, facilityservice.FacilityJob{
+		ID: operationID, OwnerID: ownerID,
+		Kind:  facilityservice.FacilityJobKindChangeReport,
+		Class: facilityservice.FacilityJobClassExport,
+		Type:  facilityservice.FacilityJobTypeExport,
+		Task:  changeReportTask, Payload: payload,
+	})
+	if err != nil {
+		return domainExport.Job{}, err
+	}
+	return s.toExportJob(job)
+}
+
+func (s *Service) runChangeReport(ctx context.Context, execution facilityservice.FacilityJobExecution) (facilityservice.FacilityJobTaskResult, error) {
+	job, report := execution.Job, execution.Reporter.Report
+	if s.reports == nil {
+		return facilityservice.FacilityJobTaskResult{}, ErrChangeReportsUnavailable
+	}
+	var req domainExport.ChangeReportRequest
+	if err := json.Unmarshal(job.Payload, &req); err != nil {
+		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("decode change report request: %w", err)
+	}
+	report(facilityservice.FacilityJobProgress{Progress: 5, Stage: "collecting"})
+	events, truncated, err := s.reports.collect(ctx, req.Filter)
+	if err != nil {
+		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("collect change events: %w", err)
+	}
+	changeReport := buildChangeReport(events, req, s.reports.labels, time.Now().UTC())
+	changeReport.Truncated = truncated
+	outputType := s.reports.outputType(changeReport, req.OutputType)
+	return s.publish(job.ID, outputType, nil, report, func(stagingPath string) (int64, error) {
+		return s.reports.generator.GenerateChangeReport(ctx, stagingPath, changeReport, outputType)
+	})
+}
+
+// outputType falls back to the workbook when the PDF fonts cannot show every
+// text of the report, so no value is lost.
+func (r *changeReports) outputType(report domainExport.ChangeReport, requested domainExport.OutputType) domainExport.OutputType {
+	if requested != domainExport.OutputTypeChangeReportPDF {
+		return requested
+	}
+	if checker, ok := r.generator.(domainExport.ChangeReportPDFChecker); ok && !checker.CanRenderPDF(report) {
+		return domainExport.OutputTypeChangeReportExcel
+	}
+	return requested
+}
+
+func (r *changeReports) collect(ctx context.Context, filter domainHistory.TimelineFilter) ([]domainHistory.ChangeEvent, bool, error) {
+	filter.Page, filter.Limit, filter.Cursor = 0, changeReportPageSize, ""
+	var events []domainHistory.ChangeEvent
+	for {
+		page, err := r.events.ListTimelineCursor(ctx, filter)
+		if err != nil {
+			return nil, false, err
+		}
+		events = append(events, page.Items...)
+		if len(events) >= maxChangeReportEvents {
+			return events[:maxChangeReportEvents], true, nil
+		}
+		if page.NextCursor == "" || len(page.Items) == 0 {
+			return events, false, nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+// changeReportTexts localizes report texts. Missing keys fall back to the
+// given default, so a report never shows raw catalog keys.
+type changeReportTexts struct {
+	labels domainExport.LabelCatalog
+	locale string
+}
+
+func (t changeReportTexts) text(fallback string, keys ...string) string {
+	if t.labels == nil {
+		return fallback
+	}
+	for _, key := range keys {
+		if value, ok := t.labels.Lookup(t.locale, key); ok {
+			return value
+		}
+	}
+	return fallback
+}
+
+func (t changeReportTexts) heading(name, fallback string) string {
+	return t.text(fallback, "change_report.headings."+name)
+}
+
+func buildChangeReport(events []domainHistory.ChangeEvent, req domainExport.ChangeReportRequest, labels domainExport.LabelCatalog, now time.Time) domainExport.ChangeReport {
+	texts := changeReportTexts{labels: labels, locale: req.Locale}
+	report := domainExport.ChangeReport{
+		Title:        texts.text("Change report", "change_report.title"),
+		Locale:       req.Locale,
+		GeneratedAt:  now,
+		OccurredFrom: req.Filter.OccurredFrom,
+		OccurredTo:   req.Filter.OccurredTo,
+		Headings:     changeReportHeadings(texts),
+		EventCount:   len(events),
+	}
+	groups := make(map[changeReportGroupKey]*domainExport.ChangeReportGroup)
+	for _, event := range events {
+		key := groupKeyOf(event)
+		group, ok := groups[key]
+		if !ok {
+			group = &domainExport.ChangeReportGroup{Building: key.building, ControlCabinet: key.controlCabinet, SPSController: key.spsController}
+			groups[key] = group
+		}
+		group.Entries = append(group.Entries, changeReportEntry(event, texts))
+	}
+	report.Groups = sortedChangeReportGroups(groups)
+	return report
+}
+
+func changeReportHeadings(texts changeReportTexts) domainExport.ChangeReportHeadings {
+	return domainExport.ChangeReportHeadings{
+		Period:         texts.heading("period", "Period"),
+		GeneratedAt:    texts.heading("generated_at", "Generated at"),
+		Building:       texts.heading("building", "Building"),
+		ControlCabinet: texts.heading("control_cabinet", "Control cabinet"),
+		SPSController:  texts.heading("sps_controller", "Controller"),
+		OccurredAt:     texts.heading("occurred_at", "Date"),
+		Actor:          texts.heading("actor", "User"),
+		Action:         texts.heading("action", "Action"),
+		Entity:         texts.heading("entity", "Object"),
+		Field:          texts.heading("field", "Field"),
+		Before:         texts.heading("before", "Before"),
+		After:          texts.heading("after", "After"),
+		Unassigned:     texts.heading("unassigned", "Unassigned"),
+		Truncated:      texts.heading("truncated", "The report was cut off at the maximum number of changes."),
+	}
+}
+
+type changeReportGroupKey struct {
+	building       string
+	controlCabinet string
+	spsController  string
+}
+
+func (k changeReportGroupKey) unassigned() bool {
+	return k == changeReportGroupKey{}
+}
+
+func groupKeyOf(event domainHistory.ChangeEvent) changeReportGroupKey {
+	var key changeReportGroupKey
+	for _, scope := range event.Scopes {
+		switch scope.ScopeType {
+		case "building":
+			key.building = scopeLabel(scope)
+		case "control_cabinet":
+			key.controlCabinet = scopeLabel(scope)
+		case "sps_controller":
+			key.spsController = scopeLabel(scope)
+		}
+	}
+	return key
+}
+
+func scopeLabel(scope domainHistory.Scope) string {
+	if scope.Label != nil && strings.TrimSpace(*scope.Label) != "" {
+		return *scope.Label
+	}
+	return scope.ScopeID.String()
+}
+
+// sortedChangeReportGroups orders groups by their labels and keeps events
+// without a building, cabinet or controller at the end. Entries are listed
+// oldest first.
+func sortedChangeReportGroups(groups map[changeReportGroupKey]*domainExport.ChangeReportGroup) []domainExport.ChangeReportGroup {
+	keys := make([]changeReportGroupKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].unassigned() != keys[j].unassigned() {
+			return keys[j].unassigned()
+		}
+		if keys[i].building != keys[j].building {
+			return keys[i].building < keys[j].building
+		}
+		if keys[i].controlCabinet != keys[j].controlCabinet {
+			return keys[i].controlCabinet < keys[j].controlCabinet
+		}
+		return keys[i].spsController < keys[j].spsController
+	})
+	sorted := make([]domainExport.ChangeReportGroup, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		sort.SliceStable(group.Entries, func(i, j int) bool {
+			return group.Entries[i].OccurredAt.Before(group.Entries[j].OccurredAt)
+		})
+		sorted = append(sorted, *group)
+	}
+	return sorted
+}
+
+func changeReportEntry(event domainHistory.ChangeEvent, texts changeReportTexts) domainExport.ChangeReportEntry {
+	entry := domainExport.ChangeReportEntry{
+		OccurredAt: event.OccurredAt.UTC(),
+		Action:     texts.text(string(event.Action), "change_report.actions."+string(event.Action)),
+		Entity:     texts.text(event.EntityTable, "change_report.entities."+event.EntityTable),
+		Fields:     changeReportFields(event.DiffJSON, texts),
+	}
+	if event.ActorName != nil {
+		entry.Actor = *event.ActorName
+	}
+	if event.Summary != nil {
+		entry.Summary = *event.Summary
+	}
+	for _, scope := range event.Scopes {
+		if scope.ScopeID == event.EntityID && scope.Label != nil && *scope.Label != "" {
+			entry.Entity += " " + *scope.Label
+			break
+		}
+	}
+	return entry
+}
+
+// changeReportFields decodes the {"field": {"before": ..., "after": ...}}
+// diff of an event. Creates and deletes carry no diff and list no fields.
+func changeReportFields(diff domainHistory.JSONB, texts changeReportTexts) []domainExport.ChangeReportField {
+	if len(diff) == 0 {
+		return nil
+	}
+	var changes map[string]struct {
+		Before any `json:"before"`
+		After  any `json:"after"`
+	}
+	if err := json.Unmarshal(diff, &changes); err != nil {
+		return nil
+	}
+	fields := make([]domainExport.ChangeReportField, 0, len(changes))
+	for name, change := range changes {
+		if _, volatile := changeReportVolatileFields[name]; volatile {
+			continue
+		}
+		fields = append(fields, domainExport.ChangeReportField{
+			Name:   name,
+			Label:  texts.text(name, "change_report.fields."+name, "facility."+name),
+			Before: texts.value(change.Before),
+			After:  texts.value(change.After),
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Label < fields[j].Label })
+	return fields
+}
+
+func (t changeReportTexts) value(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		if typed {
+			return t.text("true", "common.yes")
+		}
+		return t.text("false", "common.no")
+	default:
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprint(typed)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,132 @@
+package exporting
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	domainexport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainhistory "github.com/besart951/go_infra_link/backend/internal/domain/history"
+	"github.com/google/uuid"
+)
+
+type changeEventPages struct {
+	pages   []domainhistory.TimelineCursorPage
+	filters []domainhistory.TimelineFilter
+}
+
+func (s *changeEventPages) ListTimelineCursor(_ context.Context, filter domainhistory.TimelineFilter) (*domainhistory.TimelineCursorPage, error) {
+	s.filters = append(s.filters, filter)
+	page := s.pages[len(s.filters)-1]
+	return &page, nil
+}
+
+type labelCatalogStub map[string]string
+
+func (s labelCatalogStub) Lookup(_, key string) (string, bool) {
+	value, ok := s[key]
+	return value, ok
+}
+
+func TestChangeReportGroupsEventsAndLocalizesFields(t *testing.T) {
+	buildingID, cabinetID, deviceID := uuid.New(), uuid.New(), uuid.New()
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	actor := "Anna Muster"
+	device := []domainhistory.Scope{
+		{ScopeType: "building", ScopeID: buildingID, Label: stringRef("1234-1")},
+		{ScopeType: "control_cabinet", ScopeID: cabinetID, Label: stringRef("SG01")},
+		{ScopeType: "field_device", ScopeID: deviceID, Label: stringRef("BMK 7")},
+	}
+	source := &changeEventPages{pages: []domainhistory.TimelineCursorPage{
+		{Items: []domainhistory.ChangeEvent{
+			{OccurredAt: at.Add(time.Hour), ActorName: &actor, Action: domainhistory.ActionUpdate, EntityTable: "field_devices", EntityID: deviceID, Scopes: device,
+				DiffJSON: domainhistory.JSONB(`{"apparat_nr":{"before":1,"after":7},"updated_at":{"before":"a","after":"b"},"ga_device":{"before":"AS01","after":null}}`)},
+			{OccurredAt: at.Add(2 * time.Hour), Action: domainhistory.ActionCreate, EntityTable: "projects", EntityID: uuid.New()},
+		}, NextCursor: "next"},
+		{Items: []domainhistory.ChangeEvent{
+			{OccurredAt: at, Action: domainhistory.ActionCreate, EntityTable: "field_devices", EntityID: deviceID, Scopes: device},
+		}},
+	}}
+	labels := labelCatalogStub{
+		"change_report.title":                  "Änderungsliste",
+		"change_report.actions.update":         "Geändert",
+		"change_report.entities.field_devices": "Feldgerät",
+		"change_report.fields.apparat_nr":      "Apparat-Nr.",
+		"facility.ga_device":                   "GA-Gerät",
+		"change_report.headings.unassigned":    "Ohne Zuordnung",
+	}
+
+	events, truncated, err := (&changeReports{events: source}).collect(t.Context(), domainhistory.TimelineFilter{Limit: 5, Cursor: "stale"})
+	if err != nil || truncated || len(events) != 3 {
+		t.Fatalf("collect() = %d events, truncated %v, %v", len(events), truncated, err)
+	}
+	if source.filters[0].Limit != changeReportPageSize || source.filters[0].Cursor != "" || source.filters[1].Cursor != "next" {
+		t.Fatalf("filters = %+v", source.filters)
+	}
+
+	report := buildChangeReport(events, domainexport.ChangeReportRequest{Locale: "de_CH"}, labels, at)
+	if report.Title != "Änderungsliste" || report.EventCount != 3 || report.Headings.Unassigned != "Ohne Zuordnung" || report.Headings.Before != "Before" {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(report.Groups) != 2 || report.Groups[0].Building != "1234-1" || report.Groups[0].ControlCabinet != "SG01" || report.Groups[1].Building != "" {
+		t.Fatalf("groups = %+v, want the cabinet group before the unassigned one", report.Groups)
+	}
+	entries := report.Groups[0].Entries
+	if len(entries) != 2 || !entries[0].OccurredAt.Equal(at) || entries[1].Action != "Geändert" || entries[1].Entity != "Feldgerät BMK 7" || entries[1].Actor != actor {
+		t.Fatalf("entries = %+v", entries)
+	}
+	want := []domainexport.ChangeReportField{
+		{Name: "apparat_nr", Label: "Apparat-Nr.", Before: "1", After: "7"},
+		{Name: "ga_device", Label: "GA-Gerät", Before: "AS01", After: ""},
+	}
+	if fields := entries[1].Fields; len(fields) != len(want) || fields[0] != want[0] || fields[1] != want[1] {
+		t.Fatalf("fields = %+v, want %+v", fields, want)
+	}
+}
+
+func TestChangeReportPayloadKeepsProjectDownloadScope(t *testing.T) {
+	projectID := uuid.New()
+	payload, err := json.Marshal(domainexport.ChangeReportRequest{
+		ProjectIDs: []uuid.UUID{projectID}, AccessScope: domainexport.AccessScopeProject,
+		OutputType: domainexport.OutputTypeChangeReportPDF,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope, err := exportScopeFromPayload(payload)
+	if err != nil || scope.Kind != domainexport.AccessScopeProject || len(scope.ProjectIDs) != 1 || scope.ProjectIDs[0] != projectID {
+		t.Fatalf("scope = %+v, %v", scope, err)
+	}
+	if outputContentType(domainexport.OutputTypeChangeReportPDF) != "application/pdf" || exportDownloadFileName(domainexport.OutputTypeChangeReportPDF, nil) != changeReportFileName {
+		t.Fatal("change report PDFs need their own content type and file name")
+	}
+}
+
+func stringRef(value string) *string { return &value }
+
+type pdfCheckingGenerator struct {
+	domainexport.ChangeReportGenerator
+	renderable bool
+}
+
+func (g pdfCheckingGenerator) CanRenderPDF(domainexport.ChangeReport) bool { return g.renderable }
+
+func TestChangeReportFallsBackToWorkbookWhenPDFCannotRenderIt(t *testing.T) {
+	report := domainexport.ChangeReport{Title: "Changes"}
+	cases := []struct {
+		generator domainexport.ChangeReportGenerator
+		requested domainexport.OutputType
+		want      domainexport.OutputType
+	}{
+		{pdfCheckingGenerator{renderable: true}, domainexport.OutputTypeChangeReportPDF, domainexport.OutputTypeChangeReportPDF},
+		{pdfCheckingGenerator{renderable: false}, domainexport.OutputTypeChangeReportPDF, domainexport.OutputTypeChangeReportExcel},
+		{pdfCheckingGenerator{renderable: false}, domainexport.OutputTypeChangeReportExcel, domainexport.OutputTypeChangeReportExcel},
+	}
+	for _, tt := range cases {
+		reports := &changeReports{generator: tt.generator}
+		if got := reports.outputType(report, tt.requested); got != tt.want {
+			t.Fatalf("outputType(%s) = %s, want %s", tt.requested, got, tt.want)
+		}
+	}
+}
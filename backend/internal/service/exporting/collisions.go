@@ -0,0 +1,184 @@
+package exporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// maxBACnetInstance is the largest assignable device instance; 4194303 is
+// reserved as "uninitialized" by the BACnet standard. Object instances are
+// stored as uint16 and always fit.
+const maxBACnetInstance = 4194302
+
+var ErrInstanceCollisions = errors.New("BACnet instance collisions found")
+
+type objectInstanceKey struct {
+	objectType domainFacility.BacnetSoftwareType
+	instance   uint16
+}
+
+// AnalyzeCollisions reports duplicate and invalid BACnet instances for
+// the controllers an export with the same request would contain.
+func (s *Service) AnalyzeCollisions(ctx context.Context, req domainExport.Request) (domainExport.CollisionReport, error) {
+	controllers, err := s.data.ResolveControllers(ctx, req)
+	if err != nil {
+		return domainExport.CollisionReport{}, err
+	}
+	return analyzeCollisions(ctx, s.data, controllers, req, s.cfg.PageSize)
+}
+
+// checkCollisions applies the request's collision policy before generating.
+// The snapshot readers are sequential, so the analysis opens its own.
+func (s *Service) checkCollisions(ctx context.Context, directory string, controllers []domainExport.Controller, req *domainExport.Request) error {
+	if req.CollisionPolicy == domainExport.CollisionPolicyIgnore {
+		return nil
+	}
+	source, err := openSnapshot(directory)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Close() }()
+	report, err := analyzeCollisions(ctx, source, controllers, *req, s.cfg.PageSize)
+	if err != nil {
+		return fmt.Errorf("analyze BACnet instances: %w", err)
+	}
+	if len(report.Collisions) == 0 {
+		return nil
+	}
+	if req.CollisionPolicy == domainExport.CollisionPolicyRefuse {
+		return fmt.Errorf("%w: %s", ErrInstanceCollisions, strings.Join(report.Warnings(), "; "))
+	}
+	req.Manifest.Warnings = append(req.Manifest.Warnings, report.Warnings()...)
+	return nil
+}
+
+func analyzeCollisions(ctx context.Context, source domainExport.DataProvider, controllers []domainExport.Controller, req domainExport.Request, pageSize int) (domainExport.CollisionReport, error) {
+	controllers = sortedByDeviceName(controllers)
+	report := domainExport.CollisionReport{Controllers: len(controllers), Collisions: deviceInstanceCollisions(controllers)}
+	for _, controller := range controllers {
+		objects, err := controllerObjectInstances(ctx, source, controller, req, pageSize)
+		if err != nil {
+			return domainExport.CollisionReport{}, err
+		}
+		for _, ids := range objects {
+			report.Objects += int64(len(ids))
+		}
+		report.Collisions = append(report.Collisions, objectInstanceCollisions(controller, objects)...)
+	}
+	return report, nil
+}
+
+func deviceInstanceCollisions(controllers []domainExport.Controller) []domainExport.Collision {
+	byInstance := make(map[string][]domainExport.Controller)
+	instances := make([]string, 0)
+	for _, controller := range controllers {
+		if _, seen := byInstance[controller.DeviceInstance]; !seen {
+			instances = append(instances, controller.DeviceInstance)
+		}
+		byInstance[controller.DeviceInstance] = append(byInstance[controller.DeviceInstance], controller)
+	}
+	sort.Strings(instances)
+	collisions := make([]domainExport.Collision, 0)
+	for _, instance := range instances {
+		shared := byInstance[instance]
+		if !validDeviceInstance(shared[0]) {
+			collisions = append(collisions, deviceCollision(domainExport.CollisionDeviceInstanceOutOfRange, instance, shared,
+				fmt.Sprintf("device instance %q of %s is not a BACnet instance between 0 and %d", instance, deviceNames(shared), maxBACnetInstance)))
+		}
+		if len(shared) > 1 {
+			collisions = append(collisions, deviceCollision(domainExport.CollisionDuplicateDeviceInstance, instance, shared,
+				fmt.Sprintf("device instance %q is used by %s", instance, deviceNames(shared))))
+		}
+	}
+	return collisions
+}
+
+func deviceCollision(kind domainExport.CollisionKind, instance string, controllers []domainExport.Controller, message string) domainExport.Collision {
+	ids := make([]uuid.UUID, len(controllers))
+	for index, controller := range controllers {
+		ids[index] = controller.ID
+	}
+	return domainExport.Collision{Kind: kind, DeviceInstance: instance, ControllerIDs: ids, Message: message}
+}
+
+// validDeviceInstance range-checks the number the exporter encodes as
+// {lastTwoIws}{gaDeviceIndex}{buildingGroup}. An IWS code with letters yields
+// an instance that is not a number and is reported as invalid.
+func validDeviceInstance(controller domainExport.Controller) bool {
+	value, err := strconv.Atoi(controller.DeviceInstance)
+	return err == nil && value >= 0 && value <= maxBACnetInstance
+}
+
+func controllerObjectInstances(ctx context.Context, source domainExport.DataProvider, controller domainExport.Controller, req domainExport.Request, pageSize int) (map[objectInstanceKey][]uuid.UUID, error) {
+	objects := make(map[objectInstanceKey][]uuid.UUID)
+	afterID := uuid.Nil
+	for {
+		devices, err := source.ListFieldDevicesByControllerAfter(ctx, controller.ID, req, afterID, pageSize)
+		if err != nil || len(devices) == 0 {
+			return objects, err
+		}
+		for _, device := range devices {
+			for _, object := range device.BacnetObjects {
+				if object.SoftwareType == "" {
+					continue
+				}
+				key := objectInstanceKey{objectType: object.SoftwareType, instance: object.SoftwareNumber}
+				objects[key] = append(objects[key], object.ID)
+			}
+		}
+		afterID = devices[len(devices)-1].ID
+		if len(devices) < pageSize {
+			return objects, nil
+		}
+	}
+}
+
+func objectInstanceCollisions(controller domainExport.Controller, objects map[objectInstanceKey][]uuid.UUID) []domainExport.Collision {
+	collisions := make([]domainExport.Collision, 0)
+	for key, ids := range objects {
+		if len(ids) < 2 {
+			continue
+		}
+		collisions = append(collisions, domainExport.Collision{
+			Kind: domainExport.CollisionDuplicateObjectInstance, DeviceInstance: controller.DeviceInstance,
+			ControllerIDs: []uuid.UUID{controller.ID}, ObjectType: key.objectType, ObjectInstance: key.instance, ObjectIDs: ids,
+			Message: fmt.Sprintf("%s: %s %d is used by %d BACnet objects", deviceName(controller), strings.ToUpper(string(key.objectType)), key.instance, len(ids)),
+		})
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		if collisions[i].ObjectType != collisions[j].ObjectType {
+			return collisions[i].ObjectType < collisions[j].ObjectType
+		}
+		return collisions[i].ObjectInstance < collisions[j].ObjectInstance
+	})
+	return collisions
+}
+
+func sortedByDeviceName(controllers []domainExport.Controller) []domainExport.Controller {
+	out := append([]domainExport.Controller(nil), controllers...)
+	sort.SliceStable(out, func(i, j int) bool { return deviceName(out[i]) < deviceName(out[j]) })
+	return out
+}
+
+func deviceNames(controllers []domainExport.Controller) string {
+	names := make([]string, len(controllers))
+	for index, controller := range controllers {
+		names[index] = deviceName(controller)
+	}
+	return strings.Join(names, ", ")
+}
+
+func deviceName(controller domainExport.Controller) string {
+	if controller.DeviceName != "" {
+		return controller.DeviceName
+	}
+	return controller.ID.String()
+}
@@ -0,0 +1,92 @@
+package exporting
+
+import (
+	"errors"
+	"testing"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func TestAnalyzeCollisionsReportsDeviceAndObjectInstances(t *testing.T) {
+	first := domainExport.Controller{ID: uuid.New(), DeviceName: "A", DeviceInstance: "12011"}
+	second := domainExport.Controller{ID: uuid.New(), DeviceName: "B", DeviceInstance: "12011"}
+	third := domainExport.Controller{ID: uuid.New(), DeviceName: "C", IWSCode: "ABCD", DeviceInstance: "CD021"}
+	fourth := domainExport.Controller{ID: uuid.New(), DeviceName: "D", IWSCode: "0099", DeviceInstance: "9925123"}
+	device := domainFacility.FieldDevice{BacnetObjects: []domainFacility.BacnetObject{
+		{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1},
+		{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1},
+		{SoftwareType: domainFacility.BacnetSoftwareTypeAO, SoftwareNumber: 1},
+	}}
+	device.ID = uuid.New()
+	source := snapshotSourceStub{controller: first, devices: []domainFacility.FieldDevice{device}}
+
+	report, err := analyzeCollisions(t.Context(), source, []domainExport.Controller{fourth, third, second, first}, domainExport.Request{}, 500)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make([]domainExport.CollisionKind, len(report.Collisions))
+	for index, collision := range report.Collisions {
+		kinds[index] = collision.Kind
+	}
+	want := []domainExport.CollisionKind{
+		domainExport.CollisionDuplicateDeviceInstance, domainExport.CollisionDeviceInstanceOutOfRange,
+		domainExport.CollisionDeviceInstanceOutOfRange, domainExport.CollisionDuplicateObjectInstance,
+		domainExport.CollisionDuplicateObjectInstance, domainExport.CollisionDuplicateObjectInstance,
+		domainExport.CollisionDuplicateObjectInstance,
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("collisions = %+v", report.Collisions)
+	}
+	for index := range want {
+		if kinds[index] != want[index] {
+			t.Fatalf("collision %d = %s, want %s (%+v)", index, kinds[index], want[index], report.Collisions)
+		}
+	}
+	if duplicate := report.Collisions[0]; len(duplicate.ControllerIDs) != 2 || duplicate.ControllerIDs[0] != first.ID {
+		t.Fatalf("device collision = %+v", duplicate)
+	}
+	if outOfRange := report.Collisions[1]; len(outOfRange.ControllerIDs) != 1 || outOfRange.ControllerIDs[0] != fourth.ID {
+		t.Fatalf("out-of-range collision = %+v", outOfRange)
+	}
+	if alphanumeric := report.Collisions[2]; len(alphanumeric.ControllerIDs) != 1 || alphanumeric.ControllerIDs[0] != third.ID {
+		t.Fatalf("alphanumeric collision = %+v; a device instance with letters is not a BACnet instance", alphanumeric)
+	}
+	if object := report.Collisions[3]; object.ObjectType != domainFacility.BacnetSoftwareTypeAI || object.ObjectInstance != 1 || len(object.ObjectIDs) != 2 {
+		t.Fatalf("object collision = %+v", object)
+	}
+}
+
+func TestCheckCollisionsAppliesPolicy(t *testing.T) {
+	controller := domainExport.Controller{ID: uuid.New(), ControlCabinetID: uuid.New(), DeviceName: "A", IWSCode: "0012", DeviceInstance: "12001"}
+	device := domainFacility.FieldDevice{BacnetObjects: []domainFacility.BacnetObject{
+		{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1},
+		{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1},
+	}}
+	device.ID = uuid.New()
+	directory := t.TempDir()
+	snapshot, err := createOrOpenSnapshot(t.Context(), directory, domainExport.Request{}, snapshotSourceStub{
+		controller: controller, devices: []domainFacility.FieldDevice{device},
+	}, 500, nil)
+	if err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+	_ = snapshot.Close()
+	service := &Service{cfg: Config{PageSize: 500}}
+	controllers := []domainExport.Controller{controller}
+
+	warned := domainExport.Request{}
+	if err := service.checkCollisions(t.Context(), directory, controllers, &warned); err != nil || len(warned.Manifest.Warnings) != 1 {
+		t.Fatalf("warn policy: err=%v warnings=%v", err, warned.Manifest.Warnings)
+	}
+	refused := domainExport.Request{CollisionPolicy: domainExport.CollisionPolicyRefuse}
+	if err := service.checkCollisions(t.Context(), directory, controllers, &refused); !errors.Is(err, ErrInstanceCollisions) {
+		t.Fatalf("refuse policy: err=%v", err)
+	}
+	ignored := domainExport.Request{CollisionPolicy: domainExport.CollisionPolicyIgnore}
+	if err := service.checkCollisions(t.Context(), directory, controllers, &ignored); err != nil || len(ignored.Manifest.Warnings) != 0 {
+		t.Fatalf("ignore policy: err=%v warnings=%v", err, ignored.Manifest.Warnings)
+	}
+}
@@ -0,0 +1,61 @@
+package exporting
+
+import (
+	"context"
+	"fmt"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// checkObjectNames warns about the controllers whose BACnet objects the naming
+// scheme of the export cannot name completely. The generators still write
+// those names from the tokens that have values.
+func (s *Service) checkObjectNames(ctx context.Context, directory string, controllers []domainExport.Controller, req *domainExport.Request) error {
+	if req.ObjectNaming.Pattern == "" {
+		return nil
+	}
+	source, err := openSnapshot(directory)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Close() }()
+	warnings, err := objectNamingWarnings(ctx, source, controllers, *req, s.cfg.PageSize)
+	if err != nil {
+		return fmt.Errorf("render BACnet object names: %w", err)
+	}
+	req.Manifest.Warnings = append(req.Manifest.Warnings, warnings...)
+	return nil
+}
+
+func objectNamingWarnings(ctx context.Context, source domainExport.DataProvider, controllers []domainExport.Controller, req domainExport.Request, pageSize int) ([]string, error) {
+	naming := req.ObjectNamingPattern()
+	warnings := make([]string, 0)
+	for _, controller := range sortedByDeviceName(controllers) {
+		unresolved := 0
+		controllerValues := controller.NamingValues()
+		afterID := uuid.Nil
+		for {
+			devices, err := source.ListFieldDevicesByControllerAfter(ctx, controller.ID, req, afterID, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, device := range devices {
+				for _, object := range device.BacnetObjects {
+					if _, ok := naming.Render(domainFacility.ObjectNamingValues(controllerValues, device, object.SoftwareAddress())); !ok {
+						unresolved++
+					}
+				}
+			}
+			if len(devices) < pageSize {
+				break
+			}
+			afterID = devices[len(devices)-1].ID
+		}
+		if unresolved > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: %d BACnet object names miss tokens of the naming pattern %q and were written without them", deviceName(controller), unresolved, naming.Pattern))
+		}
+	}
+	return warnings, nil
+}
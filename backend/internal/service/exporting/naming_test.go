@@ -0,0 +1,37 @@
+package exporting
+
+import (
+	"strings"
+	"testing"
+
+	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+func TestObjectNamingWarningsCountObjectsThePatternCannotName(t *testing.T) {
+	controller := domainExport.Controller{ID: uuid.New(), DeviceName: "A", GADevice: "AAB"}
+	device := domainFacility.FieldDevice{
+		Apparat: domainFacility.Apparat{ShortName: "TF"},
+		BacnetObjects: []domainFacility.BacnetObject{
+			{SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: 1},
+			{SoftwareType: domainFacility.BacnetSoftwareTypeAO, SoftwareNumber: 1},
+		},
+	}
+	device.ID = uuid.New()
+	source := snapshotSourceStub{controller: controller, devices: []domainFacility.FieldDevice{device}}
+
+	resolved, err := objectNamingWarnings(t.Context(), source, []domainExport.Controller{controller},
+		domainExport.Request{ObjectNaming: domainFacility.NamingPattern{Pattern: "{ga_device}_{apparat}_{software_address}"}}, 500)
+	if err != nil || len(resolved) != 0 {
+		t.Fatalf("warnings = %v, %v; want none", resolved, err)
+	}
+	warnings, err := objectNamingWarnings(t.Context(), source, []domainExport.Controller{controller},
+		domainExport.Request{ObjectNaming: domainFacility.NamingPattern{Pattern: "{iws_code}_{ga_device}_{software_address}"}}, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "A: 2 BACnet object names") {
+		t.Fatalf("warnings = %v; want the two objects without IWS code", warnings)
+	}
+}
@@ -11,11 +11,15 @@ import (
 	"time"
 
 	domainExport "github.com/besart951/go_infra_link/backend/internal/domain/exporting"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
 	"github.com/google/uuid"
 )
 
-var ErrJobNotFound = errors.New("export job not found")
+var (
+	ErrJobNotFound           = errors.New("export job not found")
+	ErrUnsupportedOutputType = errors.New("unsupported export output type")
+)
 
 const fieldDeviceExportTask = "fielddevice.export.v1"
 
@@ -39,15 +43,29 @@ type Service struct {
 	data     domainExport.DataProvider
 	workbook domainExport.WorkbookGenerator
 	zip      domainExport.ZipGenerator
+	ede      domainExport.EDEGenerator
+	network  domainExport.NetworkConfigGenerator
+	document domainExport.DocumentGenerator
 	files    domainExport.FileStore
 	jobs     *facilityservice.FacilityJobManager
 	cfg      Config
+
+	naming  NamingResolver
+	reports *changeReports
+}
+
+// NamingResolver selects the naming scheme of the projects an export covers.
+type NamingResolver interface {
+	ResolveForProjects(ctx context.Context, projectIDs []uuid.UUID) (domainFacility.NamingScheme, error)
 }
 
 func NewService(
 	data domainExport.DataProvider,
 	workbook domainExport.WorkbookGenerator,
 	zip domainExport.ZipGenerator,
+	ede domainExport.EDEGenerator,
+	network domainExport.NetworkConfigGenerator,
+	document domainExport.DocumentGenerator,
 	files domainExport.FileStore,
 	jobs *facilityservice.FacilityJobManager,
 	cfg Config,
@@ -55,13 +73,19 @@ func NewService(
 	if cfg.PageSize <= 0 || cfg.PageSize > 500 {
 		cfg.PageSize = 500
 	}
-	service := &Service{data: data, workbook: workbook, zip: zip, files: files, jobs: jobs, cfg: cfg}
+	service := &Service{data: data, workbook: workbook, zip: zip, ede: ede, network: network, document: document, files: files, jobs: jobs, cfg: cfg}
 	if jobs != nil {
 		jobs.RegisterTask(fieldDeviceExportTask, facilityservice.FacilityJobHandlerFunc(service.run))
 	}
 	return service
 }
 
+// SetNaming renders object names with the naming scheme of the exported
+// projects instead of the built-in names.
+func (s *Service) SetNaming(naming NamingResolver) {
+	s.naming = naming
+}
+
 func (s *Service) Create(ctx context.Context, ownerID, operationID uuid.UUID, req domainExport.Request) (domainExport.Job, error) {
 	if s.jobs == nil {
 		return domainExport.Job{}, errors.New("facility jobs unavailable")
@@ -69,6 +93,16 @@ func (s *Service) Create(ctx context.Context, ownerID, operationID uuid.UUID, re
 	if req.AccessScope == "" {
 		req.AccessScope = domainExport.AccessScopeGlobal
 	}
+	if !isSupportedOutputType(req.OutputType) {
+		return domainExport.Job{}, fmt.Errorf("%w: %s", ErrUnsupportedOutputType, req.OutputType)
+	}
+	if s.naming != nil {
+		scheme, err := s.naming.ResolveForProjects(ctx, req.ProjectIDs)
+		if err != nil {
+			return domainExport.Job{}, fmt.Errorf("resolve naming scheme: %w", err)
+		}
+		req.ObjectNaming = scheme.ObjectName
+	}
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return domainExport.Job{}, fmt.Errorf("encode export request: %w", err)
@@ -103,6 +137,9 @@ func (s *Service) run(ctx context.Context, execution facilityservice.FacilityJob
 	if err := json.Unmarshal(job.Payload, &req); err != nil {
 		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("decode export request: %w", err)
 	}
+	if req.OutputType == domainExport.OutputTypeNetwork {
+		return s.runNetworkConfig(ctx, job, report, req)
+	}
 	report(facilityservice.FacilityJobProgress{Progress: 5, Stage: "snapshotting"})
 	live, ok := s.data.(domainExport.SnapshotDataProvider)
 	if !ok {
@@ -128,23 +165,52 @@ func (s *Service) run(ctx context.Context, execution facilityservice.FacilityJob
 	req.SchemaVersion = exportSnapshotSchemaVersion
 	req.DeviceCount = snapshotTotal
 	req.Manifest = exportManifest(snapshot.manifest)
+	if err := s.checkCollisions(ctx, s.files.SnapshotDirectory(job.ID), controllers, &req); err != nil {
+		return facilityservice.FacilityJobTaskResult{}, err
+	}
+	if err := s.checkObjectNames(ctx, s.files.SnapshotDirectory(job.ID), controllers, &req); err != nil {
+		return facilityservice.FacilityJobTaskResult{}, err
+	}
 
-	outputType := domainExport.OutputTypeExcel
-	if uniqueControlCabinetCount(controllers) > 1 {
-		outputType = domainExport.OutputTypeZip
+	outputType := resolveOutputType(req.OutputType, controllers)
+	return s.publish(job.ID, outputType, controllers, report, func(stagingPath string) (int64, error) {
+		return s.generate(ctx, outputType, stagingPath, controllers, snapshot, req)
+	})
+}
+
+// runNetworkConfig exports controller addressing only. It resolves
+// controllers with the same filters as field-device exports and skips the
+// field-device snapshot, which the network files never read.
+func (s *Service) runNetworkConfig(ctx context.Context, job facilityservice.FacilityJob, report func(facilityservice.FacilityJobProgress), req domainExport.Request) (facilityservice.FacilityJobTaskResult, error) {
+	if s.network == nil {
+		return facilityservice.FacilityJobTaskResult{}, errors.New("network config generator unavailable")
 	}
-	outputPath, fileName := s.files.BuildOutputPath(job.ID, outputType, exportDownloadFileName(outputType, controllers))
-	stagingPath := s.files.BuildStagingPath(job.ID, outputType)
+	report(facilityservice.FacilityJobProgress{Progress: 10, Stage: "resolving"})
+	controllers, err := s.data.ResolveControllers(ctx, req)
+	if err != nil {
+		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("resolve controllers: %w", err)
+	}
+	return s.publish(job.ID, domainExport.OutputTypeNetwork, controllers, report, func(stagingPath string) (int64, error) {
+		return s.network.GenerateNetworkConfig(ctx, stagingPath, controllers)
+	})
+}
+
+// publish generates the output into a staging file, moves it to its final
+// location and records the download metadata as the job result.
+func (s *Service) publish(
+	jobID uuid.UUID,
+	outputType domainExport.OutputType,
+	controllers []domainExport.Controller,
+	report func(facilityservice.FacilityJobProgress),
+	generate func(stagingPath string) (int64, error),
+) (facilityservice.FacilityJobTaskResult, error) {
+	outputPath, fileName := s.files.BuildOutputPath(jobID, outputType, exportDownloadFileName(outputType, controllers))
+	stagingPath := s.files.BuildStagingPath(jobID, outputType)
 	_ = s.files.Remove(stagingPath)
 	defer func() { _ = s.files.Remove(stagingPath) }()
 
 	report(facilityservice.FacilityJobProgress{Progress: 30, Stage: "generating"})
-	var processed int64
-	if outputType == domainExport.OutputTypeZip {
-		processed, err = s.zip.GenerateZipByCabinet(ctx, stagingPath, controllers, snapshot, req, s.cfg.PageSize)
-	} else {
-		processed, err = s.workbook.GenerateWorkbook(ctx, stagingPath, controllers, snapshot, req, s.cfg.PageSize)
-	}
+	processed, err := generate(stagingPath)
 	if err != nil {
 		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("generate export: %w", err)
 	}
@@ -157,13 +223,9 @@ func (s *Service) run(ctx context.Context, execution facilityservice.FacilityJob
 	if err != nil {
 		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("stat export: %w", err)
 	}
-	contentType := "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-	if outputType == domainExport.OutputTypeZip {
-		contentType = "application/zip"
-	}
 	result, err := json.Marshal(exportResult{
-		OutputType: outputType, FileName: fileName, ContentType: contentType,
-		DownloadURL: "/api/v1/facility/jobs/" + job.ID.String() + "/download",
+		OutputType: outputType, FileName: fileName, ContentType: outputContentType(outputType),
+		DownloadURL: "/api/v1/facility/jobs/" + jobID.String() + "/download",
 		Size:        info.Size(), ExpiresAt: time.Now().UTC().Add(90 * 24 * time.Hour),
 	})
 	if err != nil {
@@ -172,6 +234,63 @@ func (s *Service) run(ctx context.Context, execution facilityservice.FacilityJob
 	return facilityservice.FacilityJobTaskResult{Result: result}, nil
 }
 
+func (s *Service) generate(ctx context.Context, outputType domainExport.OutputType, stagingPath string, controllers []domainExport.Controller, source domainExport.DataProvider, req domainExport.Request) (int64, error) {
+	switch outputType {
+	case domainExport.OutputTypeZip:
+		return s.zip.GenerateZipByCabinet(ctx, stagingPath, controllers, source, req, s.cfg.PageSize)
+	case domainExport.OutputTypeEDE:
+		if s.ede == nil {
+			return 0, errors.New("EDE generator unavailable")
+		}
+		return s.ede.GenerateEDEArchive(ctx, stagingPath, controllers, source, req, s.cfg.PageSize)
+	case domainExport.OutputTypeJSON, domainExport.OutputTypeNDJSON:
+		if s.document == nil {
+			return 0, errors.New("document generator unavailable")
+		}
+		req.OutputType = outputType
+		return s.document.GenerateDocument(ctx, stagingPath, controllers, source, req, s.cfg.PageSize)
+	default:
+		return s.workbook.GenerateWorkbook(ctx, stagingPath, controllers, source, req, s.cfg.PageSize)
+	}
+}
+
+func isSupportedOutputType(outputType domainExport.OutputType) bool {
+	switch outputType {
+	case "", domainExport.OutputTypeExcel, domainExport.OutputTypeZip, domainExport.OutputTypeEDE, domainExport.OutputTypeNetwork,
+		domainExport.OutputTypeJSON, domainExport.OutputTypeNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolveOutputType honours an explicit caller choice and otherwise falls back
+// to one workbook per export, or one workbook per cabinet in a ZIP.
+func resolveOutputType(requested domainExport.OutputType, controllers []domainExport.Controller) domainExport.OutputType {
+	if requested != "" {
+		return requested
+	}
+	if uniqueControlCabinetCount(controllers) > 1 {
+		return domainExport.OutputTypeZip
+	}
+	return domainExport.OutputTypeExcel
+}
+
+func outputContentType(outputType domainExport.OutputType) string {
+	switch outputType {
+	case domainExport.OutputTypeExcel, domainExport.OutputTypeChangeReportExcel:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case domainExport.OutputTypeChangeReportPDF:
+		return "application/pdf"
+	case domainExport.OutputTypeJSON:
+		return "application/json"
+	case domainExport.OutputTypeNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/zip"
+	}
+}
+
 func exportManifest(snapshot exportSnapshotManifest) domainExport.Manifest {
 	checksums := make(map[string]string, len(snapshot.Artifacts))
 	for _, artifact := range snapshot.Artifacts {
@@ -235,6 +354,9 @@ func exportScopeFromPayload(payload json.RawMessage) (domainExport.Scope, error)
 }
 
 func exportDownloadFileName(outputType domainExport.OutputType, controllers []domainExport.Controller) string {
+	if outputType.IsChangeReport() {
+		return changeReportFileName
+	}
 	if outputType != domainExport.OutputTypeExcel {
 		return ""
 	}
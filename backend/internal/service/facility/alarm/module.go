@@ -6,11 +6,12 @@ import (
 )
 
 type Repositories struct {
-	AlarmDefinitions        domainFacility.AlarmDefinitionRepository
-	Units                   domainFacility.UnitRepository
-	AlarmFields             domainFacility.AlarmFieldRepository
-	AlarmTypes              domainFacility.AlarmTypeRepository
-	AlarmTypeFields         domainFacility.AlarmTypeFieldRepository
-	BacnetObjectAlarmValues domainFacility.BacnetObjectAlarmValueRepository
-	BacnetObjects           domainObjectData.BacnetObjectStore
+	AlarmDefinitions              domainFacility.AlarmDefinitionRepository
+	AlarmDefinitionFieldOverrides domainFacility.AlarmDefinitionFieldOverrideRepository
+	Units                         domainFacility.UnitRepository
+	AlarmFields                   domainFacility.AlarmFieldRepository
+	AlarmTypes                    domainFacility.AlarmTypeRepository
+	AlarmTypeFields               domainFacility.AlarmTypeFieldRepository
+	BacnetObjectAlarmValues       domainFacility.BacnetObjectAlarmValueRepository
+	BacnetObjects                 domainObjectData.BacnetObjectStore
 }
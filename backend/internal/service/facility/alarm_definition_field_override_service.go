@@ -0,0 +1,208 @@
+package facility
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// AlarmDefinitionFieldOverrideService manages the per-definition tuning of
+// alarm type fields. Overrides are checked against the definition's alarm
+// type so they can never point at a field the bound objects do not have.
+type AlarmDefinitionFieldOverrideService struct {
+	baseService[domainFacility.AlarmDefinitionFieldOverride]
+	overrideRepo        domainFacility.AlarmDefinitionFieldOverrideRepository
+	alarmDefinitionRepo domainFacility.AlarmDefinitionRepository
+	alarmTypeRepo       domainFacility.AlarmTypeRepository
+	unitRepo            domainFacility.UnitRepository
+}
+
+func NewAlarmDefinitionFieldOverrideService(
+	repo domainFacility.AlarmDefinitionFieldOverrideRepository,
+	alarmDefinitionRepo domainFacility.AlarmDefinitionRepository,
+	alarmTypeRepo domainFacility.AlarmTypeRepository,
+	unitRepo domainFacility.UnitRepository,
+) *AlarmDefinitionFieldOverrideService {
+	return &AlarmDefinitionFieldOverrideService{
+		baseService:         newBase[domainFacility.AlarmDefinitionFieldOverride](repo, 50),
+		overrideRepo:        repo,
+		alarmDefinitionRepo: alarmDefinitionRepo,
+		alarmTypeRepo:       alarmTypeRepo,
+		unitRepo:            unitRepo,
+	}
+}
+
+// ListByAlarmDefinition returns the overrides of one definition ordered by
+// the display order of the fields they tune.
+func (s *AlarmDefinitionFieldOverrideService) ListByAlarmDefinition(ctx context.Context, alarmDefinitionID uuid.UUID) ([]domainFacility.AlarmDefinitionFieldOverride, error) {
+	definition, err := domain.GetByID(ctx, s.alarmDefinitionRepo, alarmDefinitionID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.overrideRepo.ListByAlarmDefinitionIDs(ctx, []uuid.UUID{alarmDefinitionID})
+	if err != nil || definition.AlarmTypeID == nil {
+		return items, err
+	}
+	alarmType, err := s.alarmTypeRepo.GetWithFields(ctx, *definition.AlarmTypeID)
+	if err != nil || alarmType == nil {
+		return items, err
+	}
+	order := make(map[uuid.UUID]int, len(alarmType.Fields))
+	for index := range alarmType.Fields {
+		field := &alarmType.Fields[index]
+		order[field.ID] = field.DisplayOrder
+		for itemIndex := range items {
+			if items[itemIndex].AlarmTypeFieldID == field.ID {
+				items[itemIndex].AlarmTypeField = field
+			}
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return order[items[i].AlarmTypeFieldID] < order[items[j].AlarmTypeFieldID]
+	})
+	return items, nil
+}
+
+func (s *AlarmDefinitionFieldOverrideService) Create(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride) error {
+	if err := s.validate(ctx, item); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, item)
+}
+
+func (s *AlarmDefinitionFieldOverrideService) Update(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride) error {
+	if err := s.validate(ctx, item); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, item)
+}
+
+func (s *AlarmDefinitionFieldOverrideService) validate(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride) error {
+	if item == nil {
+		return domain.ErrInvalidArgument
+	}
+	ve := domain.NewValidationError()
+	if item.AlarmDefinitionID == uuid.Nil {
+		ve = ve.Add("alarm_definition_id", "required")
+	}
+	if item.AlarmTypeFieldID == uuid.Nil {
+		ve = ve.Add("alarm_type_field_id", "required")
+	}
+	if item.DefaultValueOverrideJSON != nil && !json.Valid([]byte(*item.DefaultValueOverrideJSON)) {
+		ve = ve.AddCode("default_value_override_json", "json", "default_value_override_json must be valid JSON")
+	}
+	if item.ValidationOverrideJSON != nil && strings.TrimSpace(*item.ValidationOverrideJSON) != "" {
+		rules, err := domainFacility.ParseAlarmFieldRules(item.ValidationOverrideJSON)
+		if err != nil {
+			ve = ve.AddCode("validation_override_json", "invalid_rules", "validation_override_json is not a valid rule set")
+		} else if rules.Regex != nil {
+			if _, err := regexp.Compile(*rules.Regex); err != nil {
+				ve = ve.AddCode("validation_override_json", "invalid_regex", "validation_override_json regex does not compile")
+			}
+		}
+	}
+	if len(ve.Fields) > 0 {
+		return ve
+	}
+
+	alarmType, err := s.definitionAlarmType(ctx, item.AlarmDefinitionID)
+	if err != nil {
+		return err
+	}
+	if alarmType == nil {
+		return domain.NewValidationError().Add("alarm_definition_id", "alarm definition has no alarm type")
+	}
+	var field *domainFacility.AlarmTypeField
+	for index := range alarmType.Fields {
+		if alarmType.Fields[index].ID == item.AlarmTypeFieldID {
+			field = &alarmType.Fields[index]
+		}
+	}
+	if field == nil {
+		return domain.NewValidationError().AddCode("alarm_type_field_id", "unknown_field", "field does not belong to the alarm type of the definition")
+	}
+	if err := s.ensureUnique(ctx, item); err != nil {
+		return err
+	}
+	if err := s.validateUnitOverride(ctx, item, field); err != nil {
+		return err
+	}
+	return validateOverriddenDefault(alarmType, *item, *field)
+}
+
+func (s *AlarmDefinitionFieldOverrideService) definitionAlarmType(ctx context.Context, alarmDefinitionID uuid.UUID) (*domainFacility.AlarmType, error) {
+	definition, err := domain.GetByID(ctx, s.alarmDefinitionRepo, alarmDefinitionID)
+	if err != nil || definition.AlarmTypeID == nil {
+		return nil, err
+	}
+	alarmType, err := s.alarmTypeRepo.GetWithFields(ctx, *definition.AlarmTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if alarmType == nil {
+		return nil, domain.ErrNotFound
+	}
+	return alarmType, nil
+}
+
+func (s *AlarmDefinitionFieldOverrideService) ensureUnique(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride) error {
+	existing, err := s.overrideRepo.ListByAlarmDefinitionIDs(ctx, []uuid.UUID{item.AlarmDefinitionID})
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.AlarmTypeFieldID == item.AlarmTypeFieldID && other.ID != item.ID {
+			return domain.NewValidationError().AddCode("alarm_type_field_id", "unique", "the field already has an override for this definition")
+		}
+	}
+	return nil
+}
+
+// validateUnitOverride rejects a unit override that values stored in the
+// field's default unit could not be converted into. Without a unit
+// repository the check is skipped.
+func (s *AlarmDefinitionFieldOverrideService) validateUnitOverride(ctx context.Context, item *domainFacility.AlarmDefinitionFieldOverride, field *domainFacility.AlarmTypeField) error {
+	if s.unitRepo == nil || item.UnitOverrideID == nil || field.DefaultUnitID == nil || *item.UnitOverrideID == *field.DefaultUnitID {
+		return nil
+	}
+	units, err := s.unitRepo.GetByIds(ctx, []uuid.UUID{*item.UnitOverrideID, *field.DefaultUnitID})
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]*domainFacility.Unit, len(units))
+	for _, unit := range units {
+		byID[unit.ID] = unit
+	}
+	override, defaultUnit := byID[*item.UnitOverrideID], byID[*field.DefaultUnitID]
+	if override == nil {
+		return domain.NewValidationError().AddCode("unit_override_id", "unit", "unit does not exist")
+	}
+	if defaultUnit != nil && !override.ConvertibleTo(*defaultUnit) {
+		return domain.NewValidationError().AddCode("unit_override_id", "unit", override.Code+" cannot be converted to "+defaultUnit.Code)
+	}
+	return nil
+}
+
+// validateOverriddenDefault materializes the effective default the same way
+// field devices receive it and checks it against the effective rules, so a
+// definition cannot seed values that PutValues would reject.
+func validateOverriddenDefault(alarmType *domainFacility.AlarmType, override domainFacility.AlarmDefinitionFieldOverride, field domainFacility.AlarmTypeField) error {
+	effective := domainFacility.EffectiveAlarmType(alarmType, []domainFacility.AlarmDefinitionFieldOverride{override})
+	for _, effectiveField := range effective.Fields {
+		if effectiveField.ID != field.ID || effectiveField.DefaultValueJSON == nil || effectiveField.AlarmField == nil {
+			continue
+		}
+		value := domainFacility.BacnetObjectAlarmValue{AlarmTypeFieldID: field.ID}
+		applyAlarmDefaultValue(&value, effectiveField.AlarmField.DataType, *effectiveField.DefaultValueJSON)
+		// Siblings are absent, so cross-field rules are skipped here.
+		single := &domainFacility.AlarmType{Fields: []domainFacility.AlarmTypeField{effectiveField}}
+		return domainFacility.ValidateAlarmValues("default_value_override_json", single, []domainFacility.BacnetObjectAlarmValue{value})
+	}
+	return nil
+}
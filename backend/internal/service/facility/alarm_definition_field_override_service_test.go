@@ -0,0 +1,182 @@
+package facility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type stubAlarmTypeRepo struct {
+	domainFacility.AlarmTypeRepository
+	items map[uuid.UUID]*domainFacility.AlarmType
+}
+
+func (r *stubAlarmTypeRepo) GetWithFields(_ context.Context, id uuid.UUID) (*domainFacility.AlarmType, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, nil
+	}
+	clone := *item
+	clone.Fields = append([]domainFacility.AlarmTypeField(nil), item.Fields...)
+	return &clone, nil
+}
+
+type fakeAlarmDefinitionRepo struct {
+	domainFacility.AlarmDefinitionRepository
+	items map[uuid.UUID]*domainFacility.AlarmDefinition
+}
+
+func (r *fakeAlarmDefinitionRepo) GetByIds(_ context.Context, ids []uuid.UUID) ([]*domainFacility.AlarmDefinition, error) {
+	out := make([]*domainFacility.AlarmDefinition, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := r.items[id]; ok {
+			clone := *item
+			out = append(out, &clone)
+		}
+	}
+	return out, nil
+}
+
+type fakeAlarmDefinitionFieldOverrideRepo struct {
+	domain.Repository[domainFacility.AlarmDefinitionFieldOverride]
+	items []domainFacility.AlarmDefinitionFieldOverride
+}
+
+func (r *fakeAlarmDefinitionFieldOverrideRepo) Create(_ context.Context, entity *domainFacility.AlarmDefinitionFieldOverride) error {
+	entity.ID = uuid.New()
+	r.items = append(r.items, *entity)
+	return nil
+}
+
+func (r *fakeAlarmDefinitionFieldOverrideRepo) ListByAlarmDefinitionIDs(_ context.Context, ids []uuid.UUID) ([]domainFacility.AlarmDefinitionFieldOverride, error) {
+	out := make([]domainFacility.AlarmDefinitionFieldOverride, 0, len(r.items))
+	for _, item := range r.items {
+		for _, id := range ids {
+			if item.AlarmDefinitionID == id {
+				out = append(out, item)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeAlarmDefinitionFieldOverrideRepo) DeleteAtVersion(context.Context, uuid.UUID, uint64) error {
+	return nil
+}
+
+func overrideFixture() (*stubAlarmTypeRepo, *fakeAlarmDefinitionRepo, uuid.UUID, uuid.UUID, uuid.UUID) {
+	alarmTypeID, definitionID, fieldID := uuid.New(), uuid.New(), uuid.New()
+	rules := `{"min": 0, "max": 120}`
+	defaultValue := `80`
+	alarmTypes := &stubAlarmTypeRepo{items: map[uuid.UUID]*domainFacility.AlarmType{
+		alarmTypeID: {Base: domain.Base{ID: alarmTypeID}, Code: "limit_high", Fields: []domainFacility.AlarmTypeField{{
+			Base:             domain.Base{ID: fieldID},
+			AlarmTypeID:      alarmTypeID,
+			AlarmField:       &domainFacility.AlarmField{Key: "high_limit", DataType: "number"},
+			DefaultValueJSON: &defaultValue,
+			ValidationJSON:   &rules,
+		}}},
+	}}
+	definitions := &fakeAlarmDefinitionRepo{items: map[uuid.UUID]*domainFacility.AlarmDefinition{
+		definitionID: {Base: domain.Base{ID: definitionID}, Name: "Supply air high", AlarmTypeID: &alarmTypeID},
+	}}
+	return alarmTypes, definitions, alarmTypeID, definitionID, fieldID
+}
+
+func TestAlarmDefinitionFieldOverrideServiceValidatesAgainstDefinitionType(t *testing.T) {
+	alarmTypes, definitions, _, definitionID, fieldID := overrideFixture()
+	overrides := &fakeAlarmDefinitionFieldOverrideRepo{}
+	service := NewAlarmDefinitionFieldOverrideService(overrides, definitions, alarmTypes, nil)
+	ctx := context.Background()
+
+	tighter := `{"max": 60}`
+	brokenPattern := `{"regex": "([a-z"}`
+	cases := []struct {
+		name     string
+		override domainFacility.AlarmDefinitionFieldOverride
+		field    string
+		code     string
+	}{
+		{"foreign field", domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: uuid.New()}, "alarm_type_field_id", "unknown_field"},
+		{"broken regex", domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID, ValidationOverrideJSON: &brokenPattern}, "validation_override_json", "invalid_regex"},
+		{"default breaks rules", domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID, ValidationOverrideJSON: &tighter}, "default_value_override_json.high_limit", "max"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.Create(ctx, &tt.override)
+			validationErr, ok := domain.AsValidationError(err)
+			if !ok || validationErr.Codes[tt.field] != tt.code {
+				t.Fatalf("error = %v, want %s on %s", err, tt.code, tt.field)
+			}
+		})
+	}
+
+	lowered := `50`
+	valid := &domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID, ValidationOverrideJSON: &tighter, DefaultValueOverrideJSON: &lowered}
+	if err := service.Create(ctx, valid); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	duplicate := &domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID}
+	validationErr, ok := domain.AsValidationError(service.Create(ctx, duplicate))
+	if !ok || validationErr.Codes["alarm_type_field_id"] != "unique" {
+		t.Fatalf("duplicate override error = %v, want unique", validationErr)
+	}
+}
+
+func TestAlarmValueMaterializerUsesDefinitionOverrides(t *testing.T) {
+	alarmTypes, _, alarmTypeID, definitionID, fieldID := overrideFixture()
+	defaultValue := `42`
+	unitID := uuid.New()
+	overrides := &fakeAlarmDefinitionFieldOverrideRepo{items: []domainFacility.AlarmDefinitionFieldOverride{{
+		AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID,
+		DefaultValueOverrideJSON: &defaultValue, UnitOverrideID: &unitID,
+	}}}
+	objects := []*domainFacility.BacnetObject{
+		{Base: domain.Base{ID: uuid.New()}, AlarmTypeID: &alarmTypeID, AlarmDefinitionID: &definitionID},
+		{Base: domain.Base{ID: uuid.New()}, AlarmTypeID: &alarmTypeID},
+	}
+
+	values, err := newAlarmValueMaterializer(alarmTypes, overrides).buildDefaultValues(context.Background(), objects)
+	if err != nil {
+		t.Fatalf("buildDefaultValues() error = %v", err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("values = %d, want 2", len(values))
+	}
+	overridden, plain := values[0], values[1]
+	if overridden.ValueNumber == nil || *overridden.ValueNumber != 42 || overridden.UnitID == nil || *overridden.UnitID != unitID {
+		t.Fatalf("overridden default = %+v, want 42 with unit override", overridden)
+	}
+	if plain.ValueNumber == nil || *plain.ValueNumber != 80 || plain.UnitID != nil {
+		t.Fatalf("type default = %+v, want 80 without unit", plain)
+	}
+}
+
+func TestBindPatchedAlarmDefinitionRejectsForeignAlarmType(t *testing.T) {
+	_, definitions, alarmTypeID, definitionID, _ := overrideFixture()
+	service := &FieldDeviceService{}
+	service.bindAlarmDefinitions(definitions)
+	ctx := context.Background()
+
+	otherType := uuid.New()
+	mismatched := &domainFacility.BacnetObject{Base: domain.Base{ID: uuid.New()}, AlarmTypeID: &otherType, AlarmDefinitionID: &definitionID}
+	ve := domain.NewValidationError()
+	if err := service.bindPatchedAlarmDefinition(ctx, mismatched, ve); err != nil {
+		t.Fatalf("bindPatchedAlarmDefinition() error = %v", err)
+	}
+	if _, ok := ve.Fields["bacnet_objects."+mismatched.ID.String()+".alarm_definition_id"]; !ok {
+		t.Fatalf("fields = %v, want alarm_definition_id conflict", ve.Fields)
+	}
+
+	unbound := &domainFacility.BacnetObject{Base: domain.Base{ID: uuid.New()}, AlarmDefinitionID: &definitionID}
+	ve = domain.NewValidationError()
+	if err := service.bindPatchedAlarmDefinition(ctx, unbound, ve); err != nil || len(ve.Fields) > 0 {
+		t.Fatalf("bindPatchedAlarmDefinition() = %v, %v", err, ve.Fields)
+	}
+	if unbound.AlarmTypeID == nil || *unbound.AlarmTypeID != alarmTypeID {
+		t.Fatalf("AlarmTypeID = %v, want %s", unbound.AlarmTypeID, alarmTypeID)
+	}
+}
@@ -0,0 +1,254 @@
+package facility
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// AlarmValueBulkSelectionLimit caps how many BACnet objects one bulk alarm
+// value edit may touch.
+const AlarmValueBulkSelectionLimit = 20000
+
+// ValidateBulkEdit checks the shape of a bulk edit before it is queued, so a
+// typo in a formula fails the request instead of every object of the job.
+func (s *BacnetAlarmValueService) ValidateBulkEdit(edit domainFacility.AlarmValueBulkEdit) error {
+	ve := domain.NewValidationError()
+	if edit.Selection.IsEmpty() {
+		ve = ve.AddCode("selection", "required", "at least one selection criterion is required")
+	}
+	if len(edit.Assignments) == 0 {
+		ve = ve.AddCode("assignments", "required", "at least one assignment is required")
+	}
+	seen := make(map[string]struct{}, len(edit.Assignments))
+	for index, assignment := range edit.Assignments {
+		path := fmt.Sprintf("assignments.%d", index)
+		compiled, err := compileAlarmValueAssignment(assignment)
+		if err != nil {
+			ve = ve.AddCode(path, "formula", err.Error())
+			continue
+		}
+		if _, ok := seen[compiled.key]; ok {
+			ve = ve.AddCode(path, "duplicate", compiled.key+" is assigned more than once")
+		}
+		seen[compiled.key] = struct{}{}
+	}
+	if len(ve.Fields) > 0 {
+		return ve
+	}
+	return nil
+}
+
+// SelectObjects resolves a bulk edit selection to BACnet object instances
+// and the versions the edit will be written against.
+func (s *BacnetAlarmValueService) SelectObjects(ctx context.Context, selection domainFacility.BacnetObjectSelection) ([]domainFacility.SelectedBacnetObject, error) {
+	if s.selectionRepo == nil {
+		return nil, errors.New("bacnet object selection is unavailable")
+	}
+	objects, err := s.selectionRepo.Select(ctx, selection, AlarmValueBulkSelectionLimit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) > AlarmValueBulkSelectionLimit {
+		return nil, domain.NewValidationError().AddCode("selection", "too_many", fmt.Sprintf("selection matches more than %d BACnet objects", AlarmValueBulkSelectionLimit))
+	}
+	return objects, nil
+}
+
+// ApplyAssignments computes the assignments against the values currently
+// stored on one BACnet object and replaces them at baseVersion. A changed
+// object fails with domain.ErrConflict instead of being overwritten.
+func (s *BacnetAlarmValueService) ApplyAssignments(ctx context.Context, bacnetObjectID uuid.UUID, baseVersion uint64, assignments []domainFacility.AlarmValueAssignment) (uint64, error) {
+	objects, err := s.bacnetRepo.GetByIds(ctx, []uuid.UUID{bacnetObjectID})
+	if err != nil {
+		return 0, err
+	}
+	if len(objects) == 0 {
+		return 0, domain.ErrNotFound
+	}
+	object := objects[0]
+	if object.Version != baseVersion {
+		return 0, domain.ErrConflict
+	}
+	schema, err := newAlarmSchemaResolver(s.alarmTypeRepo, s.overrideRepo).schema(ctx, object.AlarmTypeID, object.AlarmDefinitionID)
+	if err != nil {
+		return 0, err
+	}
+	if schema == nil {
+		return 0, domain.NewValidationError().AddCode("alarm_type_id", "required", "BACnet object has no alarm type")
+	}
+	current, err := s.valueRepo.GetByBacnetObjectID(ctx, bacnetObjectID)
+	if err != nil {
+		return 0, err
+	}
+	values, err := applyAlarmValueAssignments(bacnetObjectID, schema, current, assignments)
+	if err != nil {
+		return 0, err
+	}
+	return s.PutValues(ctx, bacnetObjectID, baseVersion, values)
+}
+
+type compiledAlarmValueAssignment struct {
+	key     string
+	value   json.RawMessage
+	formula *domainFacility.AlarmFormula
+	unitID  *uuid.UUID
+}
+
+func compileAlarmValueAssignment(assignment domainFacility.AlarmValueAssignment) (compiledAlarmValueAssignment, error) {
+	compiled := compiledAlarmValueAssignment{key: strings.TrimSpace(assignment.FieldKey), value: assignment.Value, unitID: assignment.UnitID}
+	formula := strings.TrimSpace(assignment.Formula)
+	if formula != "" && len(assignment.Value) > 0 {
+		return compiled, errors.New("set either value or formula")
+	}
+	if formula == "" {
+		if compiled.key == "" || len(assignment.Value) == 0 {
+			return compiled, errors.New("field_key and value are required")
+		}
+		return compiled, nil
+	}
+	var parsed domainFacility.AlarmFormula
+	var err error
+	if strings.Contains(formula, "=") {
+		var key string
+		key, parsed, err = domainFacility.ParseAlarmAssignment(formula)
+		if err == nil && compiled.key != "" && compiled.key != key {
+			err = fmt.Errorf("formula assigns %s but field_key is %s", key, compiled.key)
+		}
+		compiled.key = key
+	} else {
+		parsed, err = domainFacility.ParseAlarmFormula(formula)
+		if err == nil && compiled.key == "" {
+			err = errors.New("field_key is required for a bare expression")
+		}
+	}
+	compiled.formula = &parsed
+	return compiled, err
+}
+
+// applyAlarmValueAssignments merges the assignments into the current values.
+// Formulas read the numeric values as stored before the edit.
+func applyAlarmValueAssignments(bacnetObjectID uuid.UUID, schema *domainFacility.AlarmType, current []domainFacility.BacnetObjectAlarmValue, assignments []domainFacility.AlarmValueAssignment) ([]domainFacility.BacnetObjectAlarmValue, error) {
+	fields := make(map[string]*domainFacility.AlarmTypeField, len(schema.Fields))
+	keys := make(map[uuid.UUID]string, len(schema.Fields))
+	for index := range schema.Fields {
+		field := &schema.Fields[index]
+		if field.AlarmField != nil {
+			fields[field.AlarmField.Key] = field
+			keys[field.ID] = field.AlarmField.Key
+		}
+	}
+	variables := make(map[string]float64, len(current))
+	byField := make(map[uuid.UUID]domainFacility.BacnetObjectAlarmValue, len(current))
+	order := make([]uuid.UUID, 0, len(current)+len(assignments))
+	for _, value := range current {
+		byField[value.AlarmTypeFieldID] = detachedAlarmValue(value)
+		order = append(order, value.AlarmTypeFieldID)
+		if number, ok := alarmValueNumber(value); ok {
+			variables[keys[value.AlarmTypeFieldID]] = number
+		}
+	}
+
+	ve := domain.NewValidationError()
+	for index, assignment := range assignments {
+		path := fmt.Sprintf("assignments.%d", index)
+		compiled, err := compileAlarmValueAssignment(assignment)
+		if err != nil {
+			ve = ve.AddCode(path, "formula", err.Error())
+			continue
+		}
+		field := fields[compiled.key]
+		if field == nil {
+			ve = ve.AddCode(path, "unknown_field", compiled.key+" is not a field of the alarm type")
+			continue
+		}
+		value, clear, err := assignedAlarmValue(compiled, field, variables)
+		if err != nil {
+			ve = ve.AddCode(path, "formula", err.Error())
+			continue
+		}
+		if _, exists := byField[field.ID]; !exists {
+			order = append(order, field.ID)
+		}
+		if clear {
+			delete(byField, field.ID)
+			continue
+		}
+		value.BacnetObjectID = bacnetObjectID
+		byField[field.ID] = value
+	}
+	if len(ve.Fields) > 0 {
+		return nil, ve
+	}
+
+	values := make([]domainFacility.BacnetObjectAlarmValue, 0, len(byField))
+	for _, fieldID := range order {
+		if value, ok := byField[fieldID]; ok {
+			values = append(values, value)
+			delete(byField, fieldID)
+		}
+	}
+	return values, nil
+}
+
+// assignedAlarmValue builds the new value of one field. clear is true when a
+// literal null removes the value.
+func assignedAlarmValue(compiled compiledAlarmValueAssignment, field *domainFacility.AlarmTypeField, variables map[string]float64) (domainFacility.BacnetObjectAlarmValue, bool, error) {
+	value := domainFacility.BacnetObjectAlarmValue{
+		AlarmTypeFieldID: field.ID, UnitID: compiled.unitID, Source: domainFacility.AlarmValueSourceUser,
+	}
+	dataType := strings.ToLower(strings.TrimSpace(field.AlarmField.DataType))
+	if compiled.formula == nil {
+		if strings.TrimSpace(string(compiled.value)) == "null" {
+			return value, true, nil
+		}
+		applyAlarmDefaultValue(&value, dataType, string(compiled.value))
+		return value, false, nil
+	}
+	result, err := compiled.formula.Evaluate(variables)
+	if err != nil {
+		return value, false, err
+	}
+	switch dataType {
+	case "number", "duration":
+		value.ValueNumber = &result
+	case "integer":
+		rounded := math.Round(result)
+		if math.Abs(result-rounded) > 1e-9 {
+			return value, false, fmt.Errorf("%s must be a whole number, formula gives %v", compiled.key, result)
+		}
+		integer := int64(rounded)
+		value.ValueInteger = &integer
+	default:
+		return value, false, fmt.Errorf("%s holds %s values and cannot take a formula", compiled.key, dataType)
+	}
+	return value, false, nil
+}
+
+// detachedAlarmValue copies a stored value without its preloaded relations,
+// so replacing values does not write the associations back.
+func detachedAlarmValue(value domainFacility.BacnetObjectAlarmValue) domainFacility.BacnetObjectAlarmValue {
+	value.Base = domain.Base{}
+	value.BacnetObject = nil
+	value.AlarmTypeField = nil
+	value.Unit = nil
+	return value
+}
+
+func alarmValueNumber(value domainFacility.BacnetObjectAlarmValue) (float64, bool) {
+	switch {
+	case value.ValueNumber != nil:
+		return *value.ValueNumber, true
+	case value.ValueInteger != nil:
+		return float64(*value.ValueInteger), true
+	default:
+		return 0, false
+	}
+}
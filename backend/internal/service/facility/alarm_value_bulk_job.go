@@ -0,0 +1,40 @@
+package facility
+
+import (
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+const FacilityJobTaskBulkEditAlarmValues = "bacnetobject.alarm_values.bulk_edit.v1"
+
+type AlarmValueBulkEditTaskPayload struct {
+	Edit domainFacility.AlarmValueBulkEdit `json:"edit"`
+}
+
+// AlarmValueBulkEditStep is the persisted input of one object of the job.
+// The version is captured when the selection is resolved, so an object that
+// changes while the job runs is reported as a conflict, not overwritten.
+type AlarmValueBulkEditStep struct {
+	BacnetObjectID uuid.UUID                             `json:"bacnet_object_id"`
+	BaseVersion    uint64                                `json:"base_version"`
+	Assignments    []domainFacility.AlarmValueAssignment `json:"assignments"`
+}
+
+type AlarmValueBulkJobResult struct {
+	TotalCount    int                           `json:"total_count"`
+	SuccessCount  int                           `json:"success_count"`
+	FailureCount  int                           `json:"failure_count"`
+	ConflictCount int                           `json:"conflict_count"`
+	Results       []AlarmValueBulkJobResultItem `json:"results"`
+}
+
+// AlarmValueBulkJobResultItem mirrors domainFacility.BulkOperationResultItem
+// for one BACnet object.
+type AlarmValueBulkJobResultItem struct {
+	ID       uuid.UUID         `json:"id"`
+	Success  bool              `json:"success"`
+	Version  uint64            `json:"version,omitempty"`
+	Conflict bool              `json:"conflict,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Fields   map[string]string `json:"fields,omitempty"`
+}
@@ -0,0 +1,122 @@
+package facility
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type stubBulkBacnetObjectRepo struct {
+	domainFacility.BacnetObjectRepository
+	object domainFacility.BacnetObject
+}
+
+func (r *stubBulkBacnetObjectRepo) GetByIds(_ context.Context, ids []uuid.UUID) ([]*domainFacility.BacnetObject, error) {
+	for _, id := range ids {
+		if id == r.object.ID {
+			clone := r.object
+			return []*domainFacility.BacnetObject{&clone}, nil
+		}
+	}
+	return nil, nil
+}
+
+func bulkAlarmSchema() (*domainFacility.AlarmType, uuid.UUID, uuid.UUID, uuid.UUID) {
+	setpointID, highLimitID, messageID := uuid.New(), uuid.New(), uuid.New()
+	return &domainFacility.AlarmType{Fields: []domainFacility.AlarmTypeField{
+		{Base: domain.Base{ID: setpointID}, AlarmField: &domainFacility.AlarmField{Key: "setpoint", DataType: "number"}},
+		{Base: domain.Base{ID: highLimitID}, AlarmField: &domainFacility.AlarmField{Key: "high_limit", DataType: "number"}},
+		{Base: domain.Base{ID: messageID}, AlarmField: &domainFacility.AlarmField{Key: "message", DataType: "string"}},
+	}}, setpointID, highLimitID, messageID
+}
+
+func TestApplyAlarmValueAssignments(t *testing.T) {
+	schema, setpointID, highLimitID, messageID := bulkAlarmSchema()
+	objectID := uuid.New()
+	setpoint, message := 21.5, "old"
+	current := []domainFacility.BacnetObjectAlarmValue{
+		{Base: domain.Base{ID: uuid.New()}, BacnetObjectID: objectID, AlarmTypeFieldID: setpointID, ValueNumber: &setpoint},
+		{Base: domain.Base{ID: uuid.New()}, BacnetObjectID: objectID, AlarmTypeFieldID: messageID, ValueString: &message},
+	}
+
+	values, err := applyAlarmValueAssignments(objectID, schema, current, []domainFacility.AlarmValueAssignment{
+		{Formula: "high_limit = setpoint + 2"},
+		{FieldKey: "setpoint", Value: json.RawMessage("19")},
+		{FieldKey: "message", Value: json.RawMessage("null")},
+	})
+	if err != nil {
+		t.Fatalf("applyAlarmValueAssignments() error = %v", err)
+	}
+	byField := make(map[uuid.UUID]domainFacility.BacnetObjectAlarmValue, len(values))
+	for _, value := range values {
+		if value.ID != uuid.Nil {
+			t.Fatalf("value for %s keeps stored ID %s", value.AlarmTypeFieldID, value.ID)
+		}
+		byField[value.AlarmTypeFieldID] = value
+	}
+	if len(values) != 2 {
+		t.Fatalf("values = %d, want setpoint and high_limit only", len(values))
+	}
+	if got := byField[highLimitID].ValueNumber; got == nil || *got != 23.5 {
+		t.Fatalf("high_limit = %v, want 23.5 computed from the stored setpoint", got)
+	}
+	if got := byField[setpointID].ValueNumber; got == nil || *got != 19 {
+		t.Fatalf("setpoint = %v, want 19", got)
+	}
+
+	_, err = applyAlarmValueAssignments(objectID, schema, current, []domainFacility.AlarmValueAssignment{
+		{Formula: "low_limit = setpoint - 2"},
+		{Formula: "message = setpoint"},
+		{Formula: "high_limit = dead_band * 2"},
+	})
+	validationErr, ok := domain.AsValidationError(err)
+	if !ok {
+		t.Fatalf("error = %v, want validation error", err)
+	}
+	want := map[string]string{"assignments.0": "unknown_field", "assignments.1": "formula", "assignments.2": "formula"}
+	for path, code := range want {
+		if validationErr.Codes[path] != code {
+			t.Fatalf("code for %s = %q, want %q (codes %v)", path, validationErr.Codes[path], code, validationErr.Codes)
+		}
+	}
+}
+
+func TestBacnetAlarmValueServiceValidateBulkEdit(t *testing.T) {
+	service := &BacnetAlarmValueService{}
+	projectID := uuid.New()
+
+	validationErr, ok := domain.AsValidationError(service.ValidateBulkEdit(domainFacility.AlarmValueBulkEdit{
+		Selection: domainFacility.BacnetObjectSelection{ProjectID: &projectID},
+		Assignments: []domainFacility.AlarmValueAssignment{
+			{Formula: "high_limit = setpoint +"},
+			{FieldKey: "high_limit", Value: json.RawMessage("30")},
+			{Formula: "high_limit = setpoint + 2"},
+		},
+	}))
+	if !ok || validationErr.Codes["assignments.0"] != "formula" || validationErr.Codes["assignments.2"] != "duplicate" {
+		t.Fatalf("error = %v, want formula and duplicate codes", validationErr)
+	}
+
+	validationErr, ok = domain.AsValidationError(service.ValidateBulkEdit(domainFacility.AlarmValueBulkEdit{}))
+	if !ok || validationErr.Codes["selection"] != "required" || validationErr.Codes["assignments"] != "required" {
+		t.Fatalf("empty edit error = %v, want required codes", validationErr)
+	}
+}
+
+func TestBacnetAlarmValueServiceApplyAssignmentsRejectsChangedObject(t *testing.T) {
+	object := domainFacility.BacnetObject{Base: domain.Base{ID: uuid.New(), Version: 4}}
+	service := &BacnetAlarmValueService{bacnetRepo: &stubBulkBacnetObjectRepo{object: object}}
+
+	_, err := service.ApplyAssignments(context.Background(), object.ID, 3, []domainFacility.AlarmValueAssignment{{Formula: "high_limit = setpoint + 2"}})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("ApplyAssignments() error = %v, want conflict", err)
+	}
+	if _, err := service.ApplyAssignments(context.Background(), uuid.New(), 1, nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("ApplyAssignments() missing object error = %v, want not found", err)
+	}
+}
@@ -7,15 +7,14 @@ import (
 
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
-	"github.com/google/uuid"
 )
 
 type alarmValueMaterializer struct {
-	alarmTypeRepo domainFacility.AlarmTypeRepository
+	schemas *alarmSchemaResolver
 }
 
-func newAlarmValueMaterializer(alarmTypeRepo domainFacility.AlarmTypeRepository) alarmValueMaterializer {
-	return alarmValueMaterializer{alarmTypeRepo: alarmTypeRepo}
+func newAlarmValueMaterializer(alarmTypeRepo domainFacility.AlarmTypeRepository, overrideRepo domainFacility.AlarmDefinitionFieldOverrideRepository) alarmValueMaterializer {
+	return alarmValueMaterializer{schemas: newAlarmSchemaResolver(alarmTypeRepo, overrideRepo)}
 }
 
 func (m alarmValueMaterializer) buildDefaultValues(ctx context.Context, bacnetObjects []*domainFacility.BacnetObject) ([]*domainFacility.BacnetObjectAlarmValue, error) {
@@ -23,7 +22,6 @@ func (m alarmValueMaterializer) buildDefaultValues(ctx context.Context, bacnetOb
 		return nil, nil
 	}
 
-	alarmTypeCache := make(map[uuid.UUID]*domainFacility.AlarmType)
 	values := make([]*domainFacility.BacnetObjectAlarmValue, 0)
 
 	for _, obj := range bacnetObjects {
@@ -31,17 +29,12 @@ func (m alarmValueMaterializer) buildDefaultValues(ctx context.Context, bacnetOb
 			continue
 		}
 
-		alarmType, ok := alarmTypeCache[*obj.AlarmTypeID]
-		if !ok {
-			loaded, err := m.alarmTypeRepo.GetWithFields(ctx, *obj.AlarmTypeID)
-			if err != nil {
-				return nil, err
-			}
-			if loaded == nil {
-				return nil, domain.ErrNotFound
-			}
-			alarmType = loaded
-			alarmTypeCache[*obj.AlarmTypeID] = loaded
+		alarmType, err := m.schemas.schema(ctx, obj.AlarmTypeID, obj.AlarmDefinitionID)
+		if err != nil {
+			return nil, err
+		}
+		if alarmType == nil {
+			return nil, domain.ErrNotFound
 		}
 
 		for _, field := range alarmType.Fields {
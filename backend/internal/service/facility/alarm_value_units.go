@@ -0,0 +1,189 @@
+package facility
+
+import (
+	"context"
+	"errors"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// alarmValueUnitConverter moves alarm values between units of one dimension.
+// Values are stored in the default unit of their field, so thresholds stay
+// comparable across objects no matter which unit they were entered in.
+type alarmValueUnitConverter struct {
+	unitRepo domainFacility.UnitRepository
+	units    map[uuid.UUID]*domainFacility.Unit
+}
+
+func newAlarmValueUnitConverter(unitRepo domainFacility.UnitRepository) *alarmValueUnitConverter {
+	return &alarmValueUnitConverter{unitRepo: unitRepo, units: make(map[uuid.UUID]*domainFacility.Unit)}
+}
+
+// normalize converts values in place to the default unit of their field.
+// Values without a unit are taken to be in the default unit already; fields
+// without a default unit keep whatever unit was given.
+func (c *alarmValueUnitConverter) normalize(ctx context.Context, path string, schema *domainFacility.AlarmType, values []domainFacility.BacnetObjectAlarmValue) error {
+	if c == nil || c.unitRepo == nil || schema == nil || len(values) == 0 {
+		return nil
+	}
+	if path == "" {
+		path = "alarm_values"
+	}
+	fields := make(map[uuid.UUID]*domainFacility.AlarmTypeField, len(schema.Fields))
+	for index := range schema.Fields {
+		fields[schema.Fields[index].ID] = &schema.Fields[index]
+	}
+	if err := c.load(ctx, alarmValueUnitIDs(fields, values)); err != nil {
+		return err
+	}
+
+	ve := domain.NewValidationError()
+	for index := range values {
+		value := &values[index]
+		field := fields[value.AlarmTypeFieldID]
+		if field == nil || field.DefaultUnitID == nil {
+			continue
+		}
+		if value.UnitID == nil {
+			defaultUnitID := *field.DefaultUnitID
+			value.UnitID = &defaultUnitID
+			continue
+		}
+		if *value.UnitID == *field.DefaultUnitID {
+			continue
+		}
+		fieldPath := path + "." + alarmTypeFieldKey(field) + ".unit_id"
+		from, to := c.units[*value.UnitID], c.units[*field.DefaultUnitID]
+		if from == nil || to == nil {
+			ve = ve.AddCode(fieldPath, "unit", "unit does not exist")
+			continue
+		}
+		if err := domainFacility.ConvertAlarmValue(value, *from, *to, domainFacility.AlarmFieldValueKind(field)); err != nil {
+			code, message := alarmUnitConversionFailure(err, *from, *to)
+			ve = ve.AddCode(fieldPath, code, message)
+		}
+	}
+	if len(ve.Fields) > 0 {
+		return ve
+	}
+	return nil
+}
+
+// render converts stored values into the requested units. A target applies
+// to every value whose unit shares its dimension; other values are left in
+// their stored unit. Values convert as spans when their loaded field is one.
+func (c *alarmValueUnitConverter) render(ctx context.Context, values []domainFacility.BacnetObjectAlarmValue, codes []string) error {
+	targets, err := c.targets(ctx, codes)
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+	unitIDs := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		if value.UnitID != nil {
+			unitIDs = append(unitIDs, *value.UnitID)
+		}
+	}
+	if err := c.load(ctx, unitIDs); err != nil {
+		return err
+	}
+	for index := range values {
+		value := &values[index]
+		if value.UnitID == nil {
+			continue
+		}
+		from := c.units[*value.UnitID]
+		if from == nil {
+			continue
+		}
+		to, ok := targets[from.Dimension]
+		if !ok || to.ID == from.ID {
+			continue
+		}
+		// Integers that do not land on a whole number in the target unit
+		// stay in their stored unit rather than being rounded.
+		kind := domainFacility.AlarmFieldValueKind(value.AlarmTypeField)
+		if err := domainFacility.ConvertAlarmValue(value, *from, to, kind); err != nil && !errors.Is(err, domainFacility.ErrUnitValueNotWhole) {
+			return err
+		}
+	}
+	return nil
+}
+
+// targets resolves requested unit codes to one unit per dimension.
+func (c *alarmValueUnitConverter) targets(ctx context.Context, codes []string) (map[string]domainFacility.Unit, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	units, err := c.unitRepo.GetByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]domainFacility.Unit, len(units))
+	for _, unit := range units {
+		byCode[unit.Code] = unit
+	}
+	targets := make(map[string]domainFacility.Unit, len(codes))
+	for _, code := range codes {
+		unit, ok := byCode[code]
+		if !ok {
+			return nil, domain.NewValidationError().AddCode("units", "unknown_unit", "unknown unit "+code)
+		}
+		if unit.Dimension == "" {
+			return nil, domain.NewValidationError().AddCode("units", "no_dimension", "unit "+code+" has no dimension to convert within")
+		}
+		if other, ok := targets[unit.Dimension]; ok && other.ID != unit.ID {
+			return nil, domain.NewValidationError().AddCode("units", "duplicate_dimension", "units "+other.Code+" and "+code+" share the "+unit.Dimension+" dimension")
+		}
+		targets[unit.Dimension] = unit
+	}
+	return targets, nil
+}
+
+func (c *alarmValueUnitConverter) load(ctx context.Context, ids []uuid.UUID) error {
+	missing := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := c.units[id]; !ok {
+			c.units[id] = nil
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	units, err := c.unitRepo.GetByIds(ctx, missing)
+	if err != nil {
+		return err
+	}
+	for _, unit := range units {
+		c.units[unit.ID] = unit
+	}
+	return nil
+}
+
+func alarmValueUnitIDs(fields map[uuid.UUID]*domainFacility.AlarmTypeField, values []domainFacility.BacnetObjectAlarmValue) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values)*2)
+	for _, value := range values {
+		field := fields[value.AlarmTypeFieldID]
+		if field == nil || field.DefaultUnitID == nil || value.UnitID == nil || *value.UnitID == *field.DefaultUnitID {
+			continue
+		}
+		ids = append(ids, *value.UnitID, *field.DefaultUnitID)
+	}
+	return ids
+}
+
+func alarmTypeFieldKey(field *domainFacility.AlarmTypeField) string {
+	if field.AlarmField != nil && field.AlarmField.Key != "" {
+		return field.AlarmField.Key
+	}
+	return field.ID.String()
+}
+
+func alarmUnitConversionFailure(err error, from, to domainFacility.Unit) (string, string) {
+	if errors.Is(err, domainFacility.ErrUnitValueNotWhole) {
+		return "unit_precision", "value in " + from.Code + " is not a whole number of " + to.Code
+	}
+	return "unit", from.Code + " cannot be converted to " + to.Code
+}
@@ -0,0 +1,133 @@
+package facility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type stubUnitRepo struct {
+	domainFacility.UnitRepository
+	items []domainFacility.Unit
+}
+
+func (r *stubUnitRepo) GetByIds(_ context.Context, ids []uuid.UUID) ([]*domainFacility.Unit, error) {
+	out := make([]*domainFacility.Unit, 0, len(ids))
+	for index := range r.items {
+		for _, id := range ids {
+			if r.items[index].ID == id {
+				clone := r.items[index]
+				out = append(out, &clone)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *stubUnitRepo) GetByCodes(_ context.Context, codes []string) ([]domainFacility.Unit, error) {
+	out := make([]domainFacility.Unit, 0, len(codes))
+	for _, item := range r.items {
+		for _, code := range codes {
+			if item.Code == code {
+				out = append(out, item)
+			}
+		}
+	}
+	return out, nil
+}
+
+func unitFixture() (*stubUnitRepo, domainFacility.Unit, domainFacility.Unit, domainFacility.Unit) {
+	celsius := domainFacility.Unit{Base: domain.Base{ID: uuid.New()}, Code: "degC", Dimension: "temperature", Factor: 1, Offset: 273.15}
+	fahrenheit := domainFacility.Unit{Base: domain.Base{ID: uuid.New()}, Code: "degF", Dimension: "temperature", Factor: 5.0 / 9.0, Offset: 273.15 - 32*5.0/9.0}
+	pascal := domainFacility.Unit{Base: domain.Base{ID: uuid.New()}, Code: "Pa", Dimension: "pressure", Factor: 1}
+	return &stubUnitRepo{items: []domainFacility.Unit{celsius, fahrenheit, pascal}}, celsius, fahrenheit, pascal
+}
+
+func TestAlarmValueUnitConverterNormalizesToFieldUnit(t *testing.T) {
+	units, celsius, fahrenheit, pascal := unitFixture()
+	fieldID := uuid.New()
+	schema := &domainFacility.AlarmType{Fields: []domainFacility.AlarmTypeField{{
+		Base:          domain.Base{ID: fieldID},
+		AlarmField:    &domainFacility.AlarmField{Key: "high_limit", DataType: "number"},
+		DefaultUnitID: &celsius.ID,
+	}}}
+	ctx := context.Background()
+
+	number := 68.0
+	values := []domainFacility.BacnetObjectAlarmValue{{AlarmTypeFieldID: fieldID, ValueNumber: &number, UnitID: &fahrenheit.ID}}
+	if err := newAlarmValueUnitConverter(units).normalize(ctx, "alarm_values", schema, values); err != nil {
+		t.Fatalf("normalize() error = %v", err)
+	}
+	if *values[0].ValueNumber != 20 || *values[0].UnitID != celsius.ID {
+		t.Fatalf("normalized = %v in %s, want 20 degC", *values[0].ValueNumber, values[0].UnitID)
+	}
+
+	if err := newAlarmValueUnitConverter(units).render(ctx, values, []string{"degF"}); err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if *values[0].ValueNumber != 68 || *values[0].UnitID != fahrenheit.ID {
+		t.Fatalf("rendered = %v in %s, want 68 degF", *values[0].ValueNumber, values[0].UnitID)
+	}
+
+	pressure := 100.0
+	values = []domainFacility.BacnetObjectAlarmValue{{AlarmTypeFieldID: fieldID, ValueNumber: &pressure, UnitID: &pascal.ID}}
+	validationErr, ok := domain.AsValidationError(newAlarmValueUnitConverter(units).normalize(ctx, "alarm_values", schema, values))
+	if !ok || validationErr.Codes["alarm_values.high_limit.unit_id"] != "unit" {
+		t.Fatalf("incompatible unit error = %v, want unit code", validationErr)
+	}
+
+	validationErr, ok = domain.AsValidationError(newAlarmValueUnitConverter(units).render(ctx, values, []string{"degC", "degF"}))
+	if !ok || validationErr.Codes["units"] != "duplicate_dimension" {
+		t.Fatalf("duplicate dimension error = %v, want duplicate_dimension", validationErr)
+	}
+}
+
+func TestAlarmValueUnitConverterConvertsDeadbandsAsDifferences(t *testing.T) {
+	units, celsius, fahrenheit, _ := unitFixture()
+	fieldID := uuid.New()
+	deadband := domainFacility.AlarmTypeField{
+		Base:          domain.Base{ID: fieldID},
+		AlarmField:    &domainFacility.AlarmField{Key: "deadband", DataType: "number"},
+		DefaultUnitID: &celsius.ID,
+	}
+	schema := &domainFacility.AlarmType{Fields: []domainFacility.AlarmTypeField{deadband}}
+	ctx := context.Background()
+
+	number := 3.6
+	values := []domainFacility.BacnetObjectAlarmValue{{AlarmTypeFieldID: fieldID, ValueNumber: &number, UnitID: &fahrenheit.ID}}
+	if err := newAlarmValueUnitConverter(units).normalize(ctx, "alarm_values", schema, values); err != nil {
+		t.Fatalf("normalize() error = %v", err)
+	}
+	if *values[0].ValueNumber != 2 || *values[0].UnitID != celsius.ID {
+		t.Fatalf("normalized deadband = %v in %s, want 2 degC", *values[0].ValueNumber, values[0].UnitID)
+	}
+
+	values[0].AlarmTypeField = &deadband
+	if err := newAlarmValueUnitConverter(units).render(ctx, values, []string{"degF"}); err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if *values[0].ValueNumber != 3.6 || *values[0].UnitID != fahrenheit.ID {
+		t.Fatalf("rendered deadband = %v in %s, want 3.6 degF", *values[0].ValueNumber, values[0].UnitID)
+	}
+}
+
+func TestAlarmDefinitionFieldOverrideServiceRejectsIncompatibleUnit(t *testing.T) {
+	alarmTypes, definitions, alarmTypeID, definitionID, fieldID := overrideFixture()
+	units, celsius, fahrenheit, pascal := unitFixture()
+	alarmTypes.items[alarmTypeID].Fields[0].DefaultUnitID = &celsius.ID
+	service := NewAlarmDefinitionFieldOverrideService(&fakeAlarmDefinitionFieldOverrideRepo{}, definitions, alarmTypes, units)
+	ctx := context.Background()
+
+	incompatible := &domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID, UnitOverrideID: &pascal.ID}
+	validationErr, ok := domain.AsValidationError(service.Create(ctx, incompatible))
+	if !ok || validationErr.Codes["unit_override_id"] != "unit" {
+		t.Fatalf("incompatible override error = %v, want unit code", validationErr)
+	}
+	compatible := &domainFacility.AlarmDefinitionFieldOverride{AlarmDefinitionID: definitionID, AlarmTypeFieldID: fieldID, UnitOverrideID: &fahrenheit.ID}
+	if err := service.Create(ctx, compatible); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package facility
+
+import (
+	"context"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+// alarmSchemaResolver builds the effective alarm schema of a BACnet object:
+// the alarm type's fields with the overrides of the bound alarm definition
+// applied. Types and overrides are loaded once per resolver, so imports and
+// copies of many objects stay cheap.
+type alarmSchemaResolver struct {
+	alarmTypeRepo domainFacility.AlarmTypeRepository
+	overrideRepo  domainFacility.AlarmDefinitionFieldOverrideRepository
+	types         map[uuid.UUID]*domainFacility.AlarmType
+	overrides     map[uuid.UUID][]domainFacility.AlarmDefinitionFieldOverride
+}
+
+func newAlarmSchemaResolver(alarmTypeRepo domainFacility.AlarmTypeRepository, overrideRepo domainFacility.AlarmDefinitionFieldOverrideRepository) *alarmSchemaResolver {
+	return &alarmSchemaResolver{
+		alarmTypeRepo: alarmTypeRepo,
+		overrideRepo:  overrideRepo,
+		types:         make(map[uuid.UUID]*domainFacility.AlarmType),
+		overrides:     make(map[uuid.UUID][]domainFacility.AlarmDefinitionFieldOverride),
+	}
+}
+
+// schema returns nil when the object has no alarm type. A missing override
+// repository or definition yields the plain type schema.
+func (r *alarmSchemaResolver) schema(ctx context.Context, alarmTypeID, alarmDefinitionID *uuid.UUID) (*domainFacility.AlarmType, error) {
+	alarmType, err := r.alarmType(ctx, alarmTypeID)
+	if err != nil || alarmType == nil {
+		return alarmType, err
+	}
+	if alarmDefinitionID == nil || r.overrideRepo == nil {
+		return alarmType, nil
+	}
+	overrides, ok := r.overrides[*alarmDefinitionID]
+	if !ok {
+		overrides, err = r.overrideRepo.ListByAlarmDefinitionIDs(ctx, []uuid.UUID{*alarmDefinitionID})
+		if err != nil {
+			return nil, err
+		}
+		r.overrides[*alarmDefinitionID] = overrides
+	}
+	return domainFacility.EffectiveAlarmType(alarmType, overrides), nil
+}
+
+func (r *alarmSchemaResolver) alarmType(ctx context.Context, id *uuid.UUID) (*domainFacility.AlarmType, error) {
+	if id == nil || r.alarmTypeRepo == nil {
+		return nil, nil
+	}
+	if alarmType, ok := r.types[*id]; ok {
+		return alarmType, nil
+	}
+	alarmType, err := r.alarmTypeRepo.GetWithFields(ctx, *id)
+	if err != nil {
+		return nil, err
+	}
+	if alarmType == nil {
+		return nil, domain.ErrNotFound
+	}
+	r.types[*id] = alarmType
+	return alarmType, nil
+}
+
+// alarmValueValidator checks alarm values against the effective schema of
+// the object they belong to. With units bound it first converts the values
+// to the default units of their fields.
+type alarmValueValidator struct {
+	schemas *alarmSchemaResolver
+	units   *alarmValueUnitConverter
+}
+
+func newAlarmValueValidator(alarmTypeRepo domainFacility.AlarmTypeRepository, overrideRepo domainFacility.AlarmDefinitionFieldOverrideRepository) *alarmValueValidator {
+	return &alarmValueValidator{schemas: newAlarmSchemaResolver(alarmTypeRepo, overrideRepo)}
+}
+
+func (v *alarmValueValidator) withUnits(unitRepo domainFacility.UnitRepository) *alarmValueValidator {
+	if unitRepo != nil {
+		v.units = newAlarmValueUnitConverter(unitRepo)
+	}
+	return v
+}
+
+func (v *alarmValueValidator) validate(ctx context.Context, path string, object *domainFacility.BacnetObject, values []domainFacility.BacnetObjectAlarmValue) error {
+	if len(values) == 0 || object == nil || v.schemas.alarmTypeRepo == nil {
+		return nil
+	}
+	alarmType, err := v.schemas.schema(ctx, object.AlarmTypeID, object.AlarmDefinitionID)
+	if err != nil {
+		return err
+	}
+	if err := v.units.normalize(ctx, path, alarmType, values); err != nil {
+		return err
+	}
+	return domainFacility.ValidateAlarmValues(path, alarmType, values)
+}
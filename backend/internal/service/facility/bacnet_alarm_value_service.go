@@ -12,6 +12,9 @@ import (
 type BacnetAlarmValueService struct {
 	valueRepo     domainFacility.BacnetObjectAlarmValueRepository
 	alarmTypeRepo domainFacility.AlarmTypeRepository
+	overrideRepo  domainFacility.AlarmDefinitionFieldOverrideRepository
+	unitRepo      domainFacility.UnitRepository
+	selectionRepo domainFacility.BacnetObjectSelectionRepository
 	bacnetRepo    domainFacility.BacnetObjectRepository
 	templateStore domainObjectData.BacnetObjectTemplateStore
 	writer        *BacnetObjectService
@@ -20,6 +23,9 @@ type BacnetAlarmValueService struct {
 type BacnetAlarmValueDependencies struct {
 	Values     domainFacility.BacnetObjectAlarmValueRepository
 	AlarmTypes domainFacility.AlarmTypeRepository
+	Overrides  domainFacility.AlarmDefinitionFieldOverrideRepository
+	Units      domainFacility.UnitRepository
+	Selection  domainFacility.BacnetObjectSelectionRepository
 	Objects    domainFacility.BacnetObjectRepository
 	Templates  domainObjectData.BacnetObjectTemplateStore
 	Writer     *BacnetObjectService
@@ -29,34 +35,38 @@ func NewBacnetAlarmValueService(deps BacnetAlarmValueDependencies) *BacnetAlarmV
 	return &BacnetAlarmValueService{
 		valueRepo:     deps.Values,
 		alarmTypeRepo: deps.AlarmTypes,
+		overrideRepo:  deps.Overrides,
+		unitRepo:      deps.Units,
+		selectionRepo: deps.Selection,
 		bacnetRepo:    deps.Objects,
 		templateStore: deps.Templates,
 		writer:        deps.Writer,
 	}
 }
 
-// GetSchema returns the alarm field schema for a BacnetObject
+// GetSchema returns the alarm field schema for a BacnetObject with the
+// overrides of its alarm definition applied.
 func (s *BacnetAlarmValueService) GetSchema(ctx context.Context, bacnetObjectID uuid.UUID) (*domainFacility.AlarmType, error) {
 	bacnetObjs, err := s.bacnetRepo.GetByIds(ctx, []uuid.UUID{bacnetObjectID})
 	if err != nil {
 		return nil, err
 	}
-	alarmTypeID, err := s.alarmTypeID(ctx, bacnetObjectID, bacnetObjs)
+	alarmTypeID, alarmDefinitionID, err := s.alarmBinding(ctx, bacnetObjectID, bacnetObjs)
 	if err != nil || alarmTypeID == nil {
 		return nil, err
 	}
-	return s.alarmTypeRepo.GetWithFields(ctx, *alarmTypeID)
+	return newAlarmSchemaResolver(s.alarmTypeRepo, s.overrideRepo).schema(ctx, alarmTypeID, alarmDefinitionID)
 }
 
-func (s *BacnetAlarmValueService) alarmTypeID(ctx context.Context, id uuid.UUID, instances []*domainFacility.BacnetObject) (*uuid.UUID, error) {
+func (s *BacnetAlarmValueService) alarmBinding(ctx context.Context, id uuid.UUID, instances []*domainFacility.BacnetObject) (*uuid.UUID, *uuid.UUID, error) {
 	if len(instances) > 0 {
-		return instances[0].AlarmTypeID, nil
+		return instances[0].AlarmTypeID, instances[0].AlarmDefinitionID, nil
 	}
 	template, err := s.templateStore.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return template.AlarmTypeID, nil
+	return template.AlarmTypeID, template.AlarmDefinitionID, nil
 }
 
 // GetValues returns the stored alarm values for a BacnetObject
@@ -72,8 +82,30 @@ func (s *BacnetAlarmValueService) GetValues(ctx context.Context, bacnetObjectID
 	return s.templateValues(ctx, bacnetObjectID)
 }
 
-// PutValues replaces all alarm values for a BacnetObject
+// RenderValues converts values in place into the requested units, one unit
+// per dimension. Values of other dimensions keep their stored unit.
+func (s *BacnetAlarmValueService) RenderValues(ctx context.Context, values []domainFacility.BacnetObjectAlarmValue, unitCodes []string) error {
+	if len(unitCodes) == 0 || s.unitRepo == nil {
+		return nil
+	}
+	return newAlarmValueUnitConverter(s.unitRepo).render(ctx, values, unitCodes)
+}
+
+// PutValues replaces all alarm values for a BacnetObject after converting
+// them to the default units of their fields and checking them against its
+// effective alarm schema. An empty list is checked too, so required values
+// cannot be cleared.
 func (s *BacnetAlarmValueService) PutValues(ctx context.Context, bacnetObjectID uuid.UUID, version uint64, values []domainFacility.BacnetObjectAlarmValue) (uint64, error) {
+	schema, err := s.GetSchema(ctx, bacnetObjectID)
+	if err != nil {
+		return 0, err
+	}
+	if err := newAlarmValueUnitConverter(s.unitRepo).normalize(ctx, "alarm_values", schema, values); err != nil {
+		return 0, err
+	}
+	if err := domainFacility.ValidateAlarmValues("alarm_values", schema, values); err != nil {
+		return 0, err
+	}
 	return s.writer.ReplaceAlarmValues(ctx, bacnetObjectID, version, values)
 }
 
@@ -0,0 +1,367 @@
+package facility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainFieldDevice "github.com/besart951/go_infra_link/backend/internal/domain/facility/fielddevice"
+	domainHierarchy "github.com/besart951/go_infra_link/backend/internal/domain/facility/hierarchy"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+const bacnetNumberingField = "bacnet_numbering"
+
+var ErrBacnetNumberingUnavailable = errors.New("BACnet numbering is unavailable")
+
+// BacnetNumberingService assigns free BACnet object instances per SPS
+// controller and object type. Free numbers are computed from the current
+// controller state, so callers run it inside the transaction that wrote the
+// objects it renumbers.
+type BacnetNumberingService struct {
+	instances             domainObjectData.BacnetInstanceStore
+	objects               domainObjectData.BacnetObjectStore
+	fieldDevices          domainFieldDevice.FieldDeviceStore
+	controllerSystemTypes domainHierarchy.SPSControllerSystemTypeStore
+	systemTypes           domainFacility.SystemTypeRepository
+}
+
+type BacnetNumberingDependencies struct {
+	Instances             domainObjectData.BacnetInstanceStore
+	Objects               domainObjectData.BacnetObjectStore
+	FieldDevices          domainFieldDevice.FieldDeviceStore
+	ControllerSystemTypes domainHierarchy.SPSControllerSystemTypeStore
+	SystemTypes           domainFacility.SystemTypeRepository
+}
+
+func NewBacnetNumberingService(deps BacnetNumberingDependencies) *BacnetNumberingService {
+	return &BacnetNumberingService{
+		instances:             deps.Instances,
+		objects:               deps.Objects,
+		fieldDevices:          deps.FieldDevices,
+		controllerSystemTypes: deps.ControllerSystemTypes,
+		systemTypes:           deps.SystemTypes,
+	}
+}
+
+// Preview proposes instance numbers without writing them. Field devices that
+// have no BACnet objects on the controller yield no proposals.
+func (s *BacnetNumberingService) Preview(ctx context.Context, request domainFacility.BacnetNumberingRequest) (*domainFacility.BacnetNumberingPreview, error) {
+	if request.SPSControllerID == uuid.Nil {
+		return nil, domain.ErrInvalidArgument
+	}
+	if err := validateBacnetNumbering(request.Numbering); err != nil {
+		return nil, err
+	}
+	slots, err := s.instances.ListBySPSControllerID(ctx, request.SPSControllerID)
+	if err != nil {
+		return nil, err
+	}
+	targets := request.FieldDeviceIDs
+	if len(targets) == 0 {
+		targets = slotFieldDeviceIDs(slots)
+	}
+	proposals, err := s.plan(ctx, slots, targets, request.Numbering)
+	if err != nil {
+		return nil, err
+	}
+	preview := &domainFacility.BacnetNumberingPreview{
+		SPSControllerID: request.SPSControllerID, Strategy: request.Numbering.Strategy, Proposals: proposals,
+	}
+	for _, proposal := range proposals {
+		if proposal.Changed {
+			preview.Changed++
+		}
+	}
+	return preview, nil
+}
+
+// assign renumbers the BACnet objects of one field device in the caller's
+// transaction. Numbers that are free and inside the strategy's range are kept.
+func (s *BacnetNumberingService) assign(ctx context.Context, fieldDeviceID uuid.UUID, numbering domainFacility.BacnetNumbering) error {
+	if err := validateBacnetNumbering(numbering); err != nil {
+		return err
+	}
+	device, err := domain.GetByID(ctx, s.fieldDevices, fieldDeviceID)
+	if err != nil {
+		return err
+	}
+	systemType, err := domain.GetByID(ctx, s.controllerSystemTypes, device.SPSControllerSystemTypeID)
+	if err != nil {
+		return err
+	}
+	slots, err := s.instances.ListBySPSControllerID(ctx, systemType.SPSControllerID)
+	if err != nil {
+		return err
+	}
+	proposals, err := s.plan(ctx, slots, []uuid.UUID{fieldDeviceID}, numbering)
+	if err != nil {
+		return err
+	}
+	return s.write(ctx, fieldDeviceID, proposals)
+}
+
+func (s *BacnetNumberingService) write(ctx context.Context, fieldDeviceID uuid.UUID, proposals []domainFacility.BacnetNumberProposal) error {
+	numbers := make(map[uuid.UUID]uint16)
+	for _, proposal := range proposals {
+		if proposal.Changed {
+			numbers[proposal.BacnetObjectID] = proposal.ProposedNumber
+		}
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+	objects, err := s.objects.GetByFieldDeviceIDs(ctx, []uuid.UUID{fieldDeviceID})
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		number, ok := numbers[object.ID]
+		if !ok {
+			continue
+		}
+		object.SoftwareNumber = number
+		if err := s.objects.Update(ctx, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BacnetNumberingService) plan(ctx context.Context, slots []domainObjectData.BacnetInstanceSlot, targets []uuid.UUID, numbering domainFacility.BacnetNumbering) ([]domainFacility.BacnetNumberProposal, error) {
+	var ranges map[uuid.UUID]bacnetNumberRange
+	if numbering.Strategy == domainFacility.BacnetNumberingSystemTypeBlock {
+		var err error
+		if ranges, err = s.systemTypeRanges(ctx, slots); err != nil {
+			return nil, err
+		}
+	}
+	return planBacnetNumbers(slots, targets, numbering, ranges)
+}
+
+func (s *BacnetNumberingService) systemTypeRanges(ctx context.Context, slots []domainObjectData.BacnetInstanceSlot) (map[uuid.UUID]bacnetNumberRange, error) {
+	seen := make(map[uuid.UUID]struct{})
+	ids := make([]uuid.UUID, 0)
+	for _, slot := range slots {
+		if _, ok := seen[slot.SystemTypeID]; !ok {
+			seen[slot.SystemTypeID] = struct{}{}
+			ids = append(ids, slot.SystemTypeID)
+		}
+	}
+	systemTypes, err := s.systemTypes.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	ranges := make(map[uuid.UUID]bacnetNumberRange, len(systemTypes))
+	for _, systemType := range systemTypes {
+		ranges[systemType.ID] = bacnetNumberRange{min: max(systemType.NumberMin, 0), max: min(systemType.NumberMax, math.MaxUint16)}
+	}
+	return ranges, nil
+}
+
+func validateBacnetNumbering(numbering domainFacility.BacnetNumbering) error {
+	switch numbering.Strategy {
+	case domainFacility.BacnetNumberingSequential, domainFacility.BacnetNumberingSystemTypeBlock, domainFacility.BacnetNumberingFieldDeviceBlock:
+	default:
+		return domain.NewValidationError().Add(bacnetNumberingField+".strategy", "unknown numbering strategy")
+	}
+	if numbering.BlockSize < 0 || numbering.BlockSize > math.MaxUint16 {
+		return domain.NewValidationError().Add(bacnetNumberingField+".block_size", "block_size must be between 1 and 65535")
+	}
+	return nil
+}
+
+func slotFieldDeviceIDs(slots []domainObjectData.BacnetInstanceSlot) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{})
+	ids := make([]uuid.UUID, 0)
+	for _, slot := range slots {
+		if _, ok := seen[slot.FieldDeviceID]; !ok {
+			seen[slot.FieldDeviceID] = struct{}{}
+			ids = append(ids, slot.FieldDeviceID)
+		}
+	}
+	return ids
+}
+
+type bacnetNumberRange struct {
+	min int
+	max int
+}
+
+// bacnetNumberPlanner reserves every instance of objects it does not
+// renumber, then hands out numbers to the targets in request order.
+type bacnetNumberPlanner struct {
+	numbering   domainFacility.BacnetNumbering
+	systemTypes map[uuid.UUID]bacnetNumberRange
+	used        map[domainFacility.BacnetSoftwareType]map[int]struct{}
+	blocks      map[int]uuid.UUID
+}
+
+func planBacnetNumbers(slots []domainObjectData.BacnetInstanceSlot, targets []uuid.UUID, numbering domainFacility.BacnetNumbering, systemTypes map[uuid.UUID]bacnetNumberRange) ([]domainFacility.BacnetNumberProposal, error) {
+	if numbering.BlockSize == 0 {
+		numbering.BlockSize = domainFacility.DefaultBacnetNumberingBlockSize
+	}
+	planner := &bacnetNumberPlanner{
+		numbering: numbering, systemTypes: systemTypes,
+		used: make(map[domainFacility.BacnetSoftwareType]map[int]struct{}), blocks: make(map[int]uuid.UUID),
+	}
+	byDevice := planner.reserve(slots, targets)
+	proposals := make([]domainFacility.BacnetNumberProposal, 0)
+	for _, id := range targets {
+		deviceSlots, ok := byDevice[id]
+		if !ok {
+			continue
+		}
+		delete(byDevice, id)
+		numbers, err := planner.rangeFor(id, deviceSlots)
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range deviceSlots {
+			number, err := planner.take(slot, numbers)
+			if err != nil {
+				return nil, err
+			}
+			proposals = append(proposals, domainFacility.BacnetNumberProposal{
+				BacnetObjectID: slot.BacnetObjectID, FieldDeviceID: id, SoftwareType: slot.SoftwareType,
+				CurrentNumber: slot.SoftwareNumber, ProposedNumber: number, Changed: number != slot.SoftwareNumber,
+			})
+		}
+	}
+	return proposals, nil
+}
+
+func (p *bacnetNumberPlanner) reserve(slots []domainObjectData.BacnetInstanceSlot, targets []uuid.UUID) map[uuid.UUID][]domainObjectData.BacnetInstanceSlot {
+	targeted := make(map[uuid.UUID]struct{}, len(targets))
+	for _, id := range targets {
+		targeted[id] = struct{}{}
+	}
+	byDevice := make(map[uuid.UUID][]domainObjectData.BacnetInstanceSlot)
+	for _, slot := range slots {
+		if slot.SoftwareType == "" {
+			continue
+		}
+		if _, ok := targeted[slot.FieldDeviceID]; ok {
+			byDevice[slot.FieldDeviceID] = append(byDevice[slot.FieldDeviceID], slot)
+			continue
+		}
+		p.usedNumbers(slot.SoftwareType)[int(slot.SoftwareNumber)] = struct{}{}
+		if block, ok := p.blockOf(int(slot.SoftwareNumber)); ok {
+			if _, owned := p.blocks[block]; !owned {
+				p.blocks[block] = slot.FieldDeviceID
+			}
+		}
+	}
+	for _, deviceSlots := range byDevice {
+		sort.SliceStable(deviceSlots, func(i, j int) bool {
+			if deviceSlots[i].SoftwareType != deviceSlots[j].SoftwareType {
+				return deviceSlots[i].SoftwareType < deviceSlots[j].SoftwareType
+			}
+			return deviceSlots[i].SoftwareNumber < deviceSlots[j].SoftwareNumber
+		})
+	}
+	return byDevice
+}
+
+func (p *bacnetNumberPlanner) rangeFor(fieldDeviceID uuid.UUID, slots []domainObjectData.BacnetInstanceSlot) (bacnetNumberRange, error) {
+	switch p.numbering.Strategy {
+	case domainFacility.BacnetNumberingSystemTypeBlock:
+		numbers, ok := p.systemTypes[slots[0].SystemTypeID]
+		if !ok {
+			return bacnetNumberRange{}, domain.ErrNotFound
+		}
+		return numbers, nil
+	case domainFacility.BacnetNumberingFieldDeviceBlock:
+		return p.claimBlock(fieldDeviceID, slots)
+	default:
+		return bacnetNumberRange{min: 1, max: math.MaxUint16}, nil
+	}
+}
+
+// claimBlock keeps a device in the block its objects already share when no
+// other device uses it; otherwise it moves the device to the lowest free block.
+func (p *bacnetNumberPlanner) claimBlock(fieldDeviceID uuid.UUID, slots []domainObjectData.BacnetInstanceSlot) (bacnetNumberRange, error) {
+	current, shared := p.blockOf(int(slots[0].SoftwareNumber))
+	for _, slot := range slots[1:] {
+		block, ok := p.blockOf(int(slot.SoftwareNumber))
+		shared = shared && ok && block == current
+	}
+	if owner, owned := p.blocks[current]; shared && (!owned || owner == fieldDeviceID) {
+		return p.claim(current, fieldDeviceID), nil
+	}
+	size := p.numbering.BlockSize
+	for block := 0; (block+1)*size <= math.MaxUint16; block++ {
+		if _, owned := p.blocks[block]; !owned {
+			return p.claim(block, fieldDeviceID), nil
+		}
+	}
+	return bacnetNumberRange{}, domain.NewValidationError().Add(bacnetNumberingField, fmt.Sprintf("no free block of %d instances is left on the controller", size))
+}
+
+func (p *bacnetNumberPlanner) claim(block int, fieldDeviceID uuid.UUID) bacnetNumberRange {
+	p.blocks[block] = fieldDeviceID
+	size := p.numbering.BlockSize
+	return bacnetNumberRange{min: block*size + 1, max: (block + 1) * size}
+}
+
+// blockOf maps instance n to block (n-1)/size; block zero holds 1..size.
+func (p *bacnetNumberPlanner) blockOf(number int) (int, bool) {
+	if p.numbering.Strategy != domainFacility.BacnetNumberingFieldDeviceBlock || number < 1 {
+		return 0, false
+	}
+	return (number - 1) / p.numbering.BlockSize, true
+}
+
+func (p *bacnetNumberPlanner) take(slot domainObjectData.BacnetInstanceSlot, numbers bacnetNumberRange) (uint16, error) {
+	used := p.usedNumbers(slot.SoftwareType)
+	candidate := int(slot.SoftwareNumber)
+	if _, taken := used[candidate]; !taken && candidate >= numbers.min && candidate <= numbers.max {
+		used[candidate] = struct{}{}
+		return slot.SoftwareNumber, nil
+	}
+	for candidate = numbers.min; candidate <= numbers.max; candidate++ {
+		if _, taken := used[candidate]; !taken {
+			used[candidate] = struct{}{}
+			return uint16(candidate), nil
+		}
+	}
+	return 0, domain.NewValidationError().Add(bacnetNumberingField, fmt.Sprintf(
+		"no free %s instance between %d and %d", strings.ToUpper(string(slot.SoftwareType)), numbers.min, numbers.max,
+	))
+}
+
+func (p *bacnetNumberPlanner) usedNumbers(softwareType domainFacility.BacnetSoftwareType) map[int]struct{} {
+	used, ok := p.used[softwareType]
+	if !ok {
+		used = make(map[int]struct{})
+		p.used[softwareType] = used
+	}
+	return used
+}
+
+// assignBacnetNumbers applies the optional numbering of a create, copy or
+// bulk update after its BACnet objects were written.
+func (s *FieldDeviceService) assignBacnetNumbers(ctx context.Context, fieldDeviceID uuid.UUID, numbering *domainFacility.BacnetNumbering) error {
+	if numbering == nil {
+		return nil
+	}
+	if s.numbering == nil {
+		return ErrBacnetNumberingUnavailable
+	}
+	return s.numbering.assign(ctx, fieldDeviceID, *numbering)
+}
+
+// PreviewBacnetNumbering shows the instance numbers a numbering request would
+// assign without changing any BACnet object.
+func (s *FieldDeviceService) PreviewBacnetNumbering(ctx context.Context, request domainFacility.BacnetNumberingRequest) (*domainFacility.BacnetNumberingPreview, error) {
+	if s.numbering == nil {
+		return nil, ErrBacnetNumberingUnavailable
+	}
+	return s.numbering.Preview(ctx, request)
+}
@@ -0,0 +1,85 @@
+package facility
+
+import (
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+func TestPlanBacnetNumbersSequentialKeepsFreeNumbersAndMovesCopies(t *testing.T) {
+	source, copied := uuid.New(), uuid.New()
+	slots := []domainObjectData.BacnetInstanceSlot{
+		instanceSlot(source, domainFacility.BacnetSoftwareTypeAI, 1),
+		instanceSlot(source, domainFacility.BacnetSoftwareTypeAI, 2),
+		instanceSlot(copied, domainFacility.BacnetSoftwareTypeAI, 1),
+		instanceSlot(copied, domainFacility.BacnetSoftwareTypeAI, 2),
+		instanceSlot(copied, domainFacility.BacnetSoftwareTypeBI, 1),
+	}
+
+	proposals, err := planBacnetNumbers(slots, []uuid.UUID{copied}, domainFacility.BacnetNumbering{Strategy: domainFacility.BacnetNumberingSequential}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := proposedNumbers(proposals); len(got) != 3 || got[0] != 3 || got[1] != 4 || got[2] != 1 {
+		t.Fatalf("proposed numbers = %v", got)
+	}
+	if proposals[2].Changed {
+		t.Fatalf("free BI instance must be kept: %+v", proposals[2])
+	}
+}
+
+func TestPlanBacnetNumbersFieldDeviceBlockMovesSharedBlock(t *testing.T) {
+	owner, copied := uuid.New(), uuid.New()
+	slots := []domainObjectData.BacnetInstanceSlot{
+		instanceSlot(owner, domainFacility.BacnetSoftwareTypeAI, 3),
+		instanceSlot(copied, domainFacility.BacnetSoftwareTypeAI, 3),
+		instanceSlot(copied, domainFacility.BacnetSoftwareTypeAI, 4),
+	}
+	numbering := domainFacility.BacnetNumbering{Strategy: domainFacility.BacnetNumberingFieldDeviceBlock, BlockSize: 10}
+
+	proposals, err := planBacnetNumbers(slots, []uuid.UUID{copied}, numbering, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := proposedNumbers(proposals); len(got) != 2 || got[0] != 11 || got[1] != 12 {
+		t.Fatalf("copy must move to the next block, got %v", got)
+	}
+}
+
+func TestPlanBacnetNumbersSystemTypeBlockReportsExhaustedRange(t *testing.T) {
+	device := uuid.New()
+	slots := []domainObjectData.BacnetInstanceSlot{
+		instanceSlot(device, domainFacility.BacnetSoftwareTypeAI, 1),
+		instanceSlot(device, domainFacility.BacnetSoftwareTypeAI, 2),
+	}
+	ranges := map[uuid.UUID]bacnetNumberRange{slots[0].SystemTypeID: {min: 100, max: 100}}
+
+	_, err := planBacnetNumbers(slots, []uuid.UUID{device}, domainFacility.BacnetNumbering{Strategy: domainFacility.BacnetNumberingSystemTypeBlock}, ranges)
+
+	validationErr, ok := domain.AsValidationError(err)
+	if !ok || validationErr.Fields[bacnetNumberingField] == "" {
+		t.Fatalf("expected numbering validation error, got %v", err)
+	}
+}
+
+var instanceSlotSystemTypeID = uuid.New()
+
+func instanceSlot(fieldDeviceID uuid.UUID, softwareType domainFacility.BacnetSoftwareType, number uint16) domainObjectData.BacnetInstanceSlot {
+	return domainObjectData.BacnetInstanceSlot{
+		BacnetObjectID: uuid.New(), FieldDeviceID: fieldDeviceID, SystemTypeID: instanceSlotSystemTypeID,
+		SoftwareType: softwareType, SoftwareNumber: number,
+	}
+}
+
+func proposedNumbers(proposals []domainFacility.BacnetNumberProposal) []uint16 {
+	numbers := make([]uint16, len(proposals))
+	for index, proposal := range proposals {
+		numbers[index] = proposal.ProposedNumber
+	}
+	return numbers
+}
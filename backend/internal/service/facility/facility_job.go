@@ -12,6 +12,8 @@ import (
 	apprealtime "github.com/besart951/go_infra_link/backend/internal/application/realtime"
 	apptransaction "github.com/besart951/go_infra_link/backend/internal/application/transaction"
 	cursorcodec "github.com/besart951/go_infra_link/backend/internal/cursor"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -19,11 +21,14 @@ import (
 type FacilityJobKind string
 
 const (
+	FacilityJobKindBuilding                FacilityJobKind = "building"
 	FacilityJobKindControlCabinet          FacilityJobKind = "control_cabinet"
 	FacilityJobKindSPSController           FacilityJobKind = "sps_controller"
 	FacilityJobKindSPSControllerSystemType FacilityJobKind = "sps_controller_system_type"
 	FacilityJobKindFieldDevice             FacilityJobKind = "field_device"
 	FacilityJobKindObjectData              FacilityJobKind = "object_data"
+	FacilityJobKindBacnetObject            FacilityJobKind = "bacnet_object"
+	FacilityJobKindChangeReport            FacilityJobKind = "change_report"
 )
 
 type FacilityJobStatus string
@@ -129,6 +134,13 @@ type FacilityJobReporter interface {
 	Report(FacilityJobProgress)
 }
 
+// FacilityJobStatusOutbox queues a status event inside the transaction that
+// stores the status: when a job is queued, starts running or finishes.
+// Progress reports within a status are not queued.
+type FacilityJobStatusOutbox interface {
+	QueueJobStatus(tx *gorm.DB, job domainWebhook.JobStatus) error
+}
+
 type FacilityJobExecution struct {
 	Job        FacilityJob
 	Reporter   FacilityJobReporter
@@ -163,6 +175,8 @@ const (
 
 type FacilityCopyTaskPayload struct {
 	SourceID uuid.UUID `json:"source_id"`
+	// Numbering is only honoured by field-device copies.
+	Numbering *domainFacility.BacnetNumbering `json:"numbering,omitempty"`
 }
 
 type FacilityJobPage struct {
@@ -198,7 +212,13 @@ func NewFacilityJobManager(publisher apprealtime.FacilityJobProgressPublisher) *
 }
 
 func NewFacilityJobManagerWithDB(publisher apprealtime.FacilityJobProgressPublisher, db *gorm.DB) *FacilityJobManager {
-	return newFacilityJobManager(publisher, newSQLFacilityJobStore(db))
+	return newFacilityJobManager(publisher, newSQLFacilityJobStore(db, nil))
+}
+
+// NewFacilityJobManagerWithOutbox queues the status events of the jobs in
+// the outbox.
+func NewFacilityJobManagerWithOutbox(publisher apprealtime.FacilityJobProgressPublisher, db *gorm.DB, outbox FacilityJobStatusOutbox) *FacilityJobManager {
+	return newFacilityJobManager(publisher, newSQLFacilityJobStore(db, outbox))
 }
 
 func newFacilityJobManager(publisher apprealtime.FacilityJobProgressPublisher, store facilityJobStore) *FacilityJobManager {
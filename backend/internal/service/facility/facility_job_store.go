@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
 	"github.com/besart951/go_infra_link/backend/internal/postgresjson"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -131,14 +132,28 @@ type facilityJobStore interface {
 }
 
 type sqlFacilityJobStore struct {
-	db *gorm.DB
+	db     *gorm.DB
+	outbox FacilityJobStatusOutbox
 }
 
-func newSQLFacilityJobStore(db *gorm.DB) facilityJobStore {
+func newSQLFacilityJobStore(db *gorm.DB, outbox FacilityJobStatusOutbox) facilityJobStore {
 	if db == nil {
 		return nil
 	}
-	return &sqlFacilityJobStore{db: db}
+	return &sqlFacilityJobStore{db: db, outbox: outbox}
+}
+
+// queueStatus writes the status event of the job in the transaction that
+// stores the status.
+func (s *sqlFacilityJobStore) queueStatus(tx *gorm.DB, job FacilityJob) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.QueueJobStatus(tx, domainWebhook.JobStatus{
+		JobID: job.ID, Kind: string(job.Kind), Type: string(job.Type), Class: string(job.Class),
+		Status: string(job.Status), Stage: job.Stage, Processed: job.Processed, Total: job.Total,
+		Succeeded: job.Succeeded, Failed: job.Failed, CompletedAt: job.CompletedAt, UpdatedAt: job.UpdatedAt,
+	})
 }
 
 func (s *sqlFacilityJobStore) CreateOrGetActive(ctx context.Context, candidate FacilityJob) (FacilityJob, bool, error) {
@@ -200,7 +215,7 @@ func (s *sqlFacilityJobStore) CreateOrGetActive(ctx context.Context, candidate F
 		}
 		selected = candidate
 		created = true
-		return nil
+		return s.queueStatus(tx, candidate)
 	})
 	if err == nil {
 		return selected, created, nil
@@ -229,6 +244,7 @@ func (s *sqlFacilityJobStore) CreateOrGetActive(ctx context.Context, candidate F
 }
 
 var facilityAggregateTables = map[FacilityJobKind]string{
+	FacilityJobKindBuilding:                "buildings",
 	FacilityJobKindControlCabinet:          "control_cabinets",
 	FacilityJobKindSPSController:           "sps_controllers",
 	FacilityJobKindSPSControllerSystemType: "sps_controller_system_types",
@@ -244,6 +260,10 @@ func admitFacilityAggregate(tx *gorm.DB, job FacilityJob) error {
 	if !ok || job.Admission.ResourceID == uuid.Nil || job.Admission.State == "" {
 		return ErrAggregateNotFound
 	}
+	if job.Admission.AllowMissing && job.Admission.BaseVersion == 0 {
+		// Restores overwrite or re-create the root, so no version is checked.
+		return createFacilityAggregateLock(tx, job)
+	}
 	if err := lockFacilityAggregateRow(tx, table, job.Admission.ResourceID, job.Admission.BaseVersion); err != nil {
 		if !job.Admission.AllowMissing || !errors.Is(err, ErrAggregateNotFound) {
 			return err
@@ -386,21 +406,25 @@ func (s *sqlFacilityJobStore) ClaimNext(
 			}
 			return err
 		}
-		return tx.Model(&facilityJobRecord{}).
+		if err := tx.Model(&facilityJobRecord{}).
 			Where("owner_id = ? AND id = ?", claimed.OwnerID, claimed.ID).
 			Updates(map[string]any{
-				"status": string(FacilityJobStatusRunning), "worker_id": workerID,
+				"status": string(FacilityJobStatusRunning), "stage": facilityJobStagePreparing, "worker_id": workerID,
 				"lease_until": leaseUntil, "attempts": gorm.Expr("attempts + 1"), "updated_at": now,
-			}).Error
+			}).Error; err != nil {
+			return err
+		}
+		claimed.Status = string(FacilityJobStatusRunning)
+		claimed.Stage = facilityJobStagePreparing
+		claimed.WorkerID = &workerID
+		claimed.LeaseUntil = &leaseUntil
+		claimed.Attempts++
+		claimed.UpdatedAt = now
+		return s.queueStatus(tx, claimed.toDomain())
 	})
 	if err != nil || claimed.ID == uuid.Nil {
 		return FacilityJob{}, false, err
 	}
-	claimed.Status = string(FacilityJobStatusRunning)
-	claimed.WorkerID = &workerID
-	claimed.LeaseUntil = &leaseUntil
-	claimed.Attempts++
-	claimed.UpdatedAt = now
 	return claimed.toDomain(), true, nil
 }
 
@@ -430,17 +454,29 @@ func (s *sqlFacilityJobStore) Save(ctx context.Context, job FacilityJob, workerI
 		"failed":        job.Failed,
 		"retryable":     job.Retryable,
 	}
-	if job.IsTerminal() {
-		updates["completed_at"] = job.UpdatedAt
-		updates["lease_until"] = nil
-		updates["worker_id"] = nil
-	}
-	query := s.db.WithContext(ctx).Model(&facilityJobRecord{}).
-		Where("owner_id = ? AND id = ?", job.OwnerID, job.ID)
+	if !job.IsTerminal() {
+		return s.saveQuery(s.db.WithContext(ctx), job, workerID).Updates(updates).Error
+	}
+	updates["completed_at"] = job.UpdatedAt
+	updates["lease_until"] = nil
+	updates["worker_id"] = nil
+	completedAt := job.UpdatedAt
+	job.CompletedAt = &completedAt
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := s.saveQuery(tx, job, workerID).Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return s.queueStatus(tx, job)
+	})
+}
+
+func (s *sqlFacilityJobStore) saveQuery(db *gorm.DB, job FacilityJob, workerID string) *gorm.DB {
+	query := db.Model(&facilityJobRecord{}).Where("owner_id = ? AND id = ?", job.OwnerID, job.ID)
 	if workerID != "" {
 		query = query.Where("worker_id = ?", workerID)
 	}
-	return query.Updates(updates).Error
+	return query
 }
 
 func (s *sqlFacilityJobStore) Retry(ctx context.Context, ownerID, jobID uuid.UUID, now time.Time) (FacilityJob, error) {
@@ -468,11 +504,16 @@ func (s *sqlFacilityJobStore) Retry(ctx context.Context, ownerID, jobID uuid.UUI
 		if active >= limit {
 			return ErrFacilityJobLimit
 		}
-		return tx.Model(&facilityJobRecord{}).Where("owner_id = ? AND id = ?", ownerID, jobID).Updates(map[string]any{
+		if err := tx.Model(&facilityJobRecord{}).Where("owner_id = ? AND id = ?", ownerID, jobID).Updates(map[string]any{
 			"status": string(FacilityJobStatusQueued), "stage": facilityJobStageQueued,
 			"progress": 0, "error_message": "", "worker_id": nil,
 			"lease_until": nil, "completed_at": nil, "updated_at": now,
-		}).Error
+		}).Error; err != nil {
+			return err
+		}
+		job := record.toDomain()
+		job.Status, job.Stage, job.CompletedAt, job.UpdatedAt = FacilityJobStatusQueued, facilityJobStageQueued, nil, now
+		return s.queueStatus(tx, job)
 	})
 	if err != nil {
 		return FacilityJob{}, err
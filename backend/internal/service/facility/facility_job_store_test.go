@@ -6,9 +6,12 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
 	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -61,6 +64,54 @@ func TestPersistedTaskRunsAfterManagerRestartWithoutResubmission(t *testing.T) {
 	}
 }
 
+func TestJobStatusEventsAreQueuedWithTheStatus(t *testing.T) {
+	db := openFacilityJobTestDB(t)
+	outbox := &statusOutbox{}
+	manager := NewFacilityJobManagerWithOutbox(nil, db, outbox)
+	t.Cleanup(manager.Close)
+	ownerID, jobID := uuid.New(), uuid.New()
+	manager.RegisterTask("test.export.v1", FacilityJobHandlerFunc(func(context.Context, FacilityJobExecution) (FacilityJobTaskResult, error) {
+		return FacilityJobTaskResult{}, errors.New("export failed")
+	}))
+	if _, err := manager.SubmitTask(t.Context(), FacilityJob{
+		ID: jobID, OwnerID: ownerID, Kind: FacilityJobKindFieldDevice,
+		Class: FacilityJobClassExport, Type: FacilityJobTypeExport, Task: "test.export.v1",
+	}); err != nil {
+		t.Fatalf("SubmitTask() error = %v", err)
+	}
+	waitForFacilityJobStatus(t, manager, ownerID, jobID, FacilityJobStatusFailed)
+	if _, err := manager.Retry(t.Context(), ownerID, jobID); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+
+	statuses := outbox.statuses()
+	if len(statuses) < 4 || !slices.Equal(statuses[:4], []string{"queued", "running", "failed", "queued"}) {
+		t.Fatalf("queued statuses = %v, want queued running failed queued", statuses)
+	}
+}
+
+type statusOutbox struct {
+	mu     sync.Mutex
+	events []domainWebhook.JobStatus
+}
+
+func (o *statusOutbox) QueueJobStatus(_ *gorm.DB, job domainWebhook.JobStatus) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, job)
+	return nil
+}
+
+func (o *statusOutbox) statuses() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	statuses := make([]string, len(o.events))
+	for i, event := range o.events {
+		statuses[i] = event.Status
+	}
+	return statuses
+}
+
 func TestExportConcurrencyLimitAndRetry(t *testing.T) {
 	db := openFacilityJobTestDB(t)
 	manager := NewFacilityJobManagerWithDB(nil, db)
@@ -174,6 +225,30 @@ func TestDeleteJobAdmissionRejectsMissingAggregateWithoutCreatingJob(t *testing.
 	}
 }
 
+func TestRestoreJobAdmissionLocksMissingAggregateWithoutBaseVersion(t *testing.T) {
+	db := openFacilityJobTestDB(t)
+	if err := db.Exec("CREATE TABLE buildings (id text PRIMARY KEY, version integer NOT NULL)").Error; err != nil {
+		t.Fatalf("create aggregate table: %v", err)
+	}
+	manager := NewFacilityJobManagerWithDB(nil, db)
+	t.Cleanup(manager.Close)
+
+	job := FacilityJob{
+		ID: uuid.New(), OwnerID: uuid.New(), Kind: FacilityJobKindBuilding,
+		Class: FacilityJobClassMutation, Type: FacilityJobTypeRestore, Task: "building.restore.v1",
+		Admission: &FacilityAggregateAdmission{
+			ResourceID: uuid.New(), State: FacilityAggregateStateRestoreStaging, AllowMissing: true,
+		},
+	}
+	if _, err := manager.SubmitTask(t.Context(), job); err != nil {
+		t.Fatalf("submit restore job: %v", err)
+	}
+	var count int64
+	if err := db.Model(&facilityAggregateLifecycleRecord{}).Where("kind = ?", "building").Count(&count).Error; err != nil || count != 1 {
+		t.Fatalf("lifecycle locks = %d, error = %v; want one", count, err)
+	}
+}
+
 func admittedDeleteJob(ownerID, jobID, resourceID uuid.UUID) FacilityJob {
 	return FacilityJob{
 		ID: jobID, OwnerID: ownerID, Kind: FacilityJobKindControlCabinet,
@@ -297,9 +297,9 @@ func runBulkUpdatePhases(ctx context.Context, service *FieldDeviceService, execu
 			return errBulkUpdateItem
 		}
 	}
-	if execution.update.BacnetObjects != nil {
+	if execution.update.BacnetObjects != nil || execution.update.Numbering != nil {
 		phases++
-		if err := service.patchBacnetObjects(ctx, execution.proposed.ID, *execution.update.BacnetObjects); err != nil {
+		if err := applyBulkBacnetChanges(ctx, service, execution); err != nil {
 			addBulkUpdateError(execution.report.errors, "bacnet_objects", "failed to update BACnet objects: ", err)
 			return errBulkUpdateItem
 		}
@@ -307,6 +307,15 @@ func runBulkUpdatePhases(ctx context.Context, service *FieldDeviceService, execu
 	return persistBulkUpdate(ctx, service, bulkUpdatePersistence{execution: execution, phases: phases})
 }
 
+func applyBulkBacnetChanges(ctx context.Context, service *FieldDeviceService, execution bulkUpdateExecution) error {
+	if execution.update.BacnetObjects != nil {
+		if err := service.patchBacnetObjects(ctx, execution.proposed.ID, *execution.update.BacnetObjects); err != nil {
+			return err
+		}
+	}
+	return service.assignBacnetNumbers(ctx, execution.proposed.ID, execution.update.Numbering)
+}
+
 func persistBulkUpdate(ctx context.Context, service *FieldDeviceService, persistence bulkUpdatePersistence) error {
 	if persistence.phases == 0 {
 		persistence.execution.report.errors["fielddevice"] = "no changes provided"
@@ -13,6 +13,12 @@ import (
 // references to shared master data. Every owned child is copied in the same
 // transaction and internal BACnet references are remapped to the new IDs.
 func (s *FieldDeviceService) CopyByID(ctx context.Context, id uuid.UUID) (*domainFacility.FieldDevice, error) {
+	return s.CopyWithNumbering(ctx, id, nil)
+}
+
+// CopyWithNumbering copies like CopyByID. A non-nil numbering moves the
+// copied BACnet objects off the source's instances, which CopyByID keeps.
+func (s *FieldDeviceService) CopyWithNumbering(ctx context.Context, id uuid.UUID, numbering *domainFacility.BacnetNumbering) (*domainFacility.FieldDevice, error) {
 	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *FieldDeviceService) (*domainFacility.FieldDevice, error) {
 		original, err := domain.GetByID(txCtx, txService.repo, id)
 		if err != nil {
@@ -47,6 +53,9 @@ func (s *FieldDeviceService) CopyByID(ctx context.Context, id uuid.UUID) (*domai
 		if err := copyFieldDeviceBacnetObjects(txCtx, txService, original.ID, copyDevice.ID); err != nil {
 			return nil, err
 		}
+		if err := txService.assignBacnetNumbers(txCtx, copyDevice.ID, numbering); err != nil {
+			return nil, err
+		}
 		if err := txService.recordFieldDeviceChange(txCtx, changecapture.ActionCreated, copyDevice.ID); err != nil {
 			return nil, err
 		}
@@ -90,6 +99,7 @@ func copyFieldDeviceBacnetObjects(ctx context.Context, service *FieldDeviceServi
 		return err
 	}
 
+	validator := newAlarmValueValidator(service.alarmTypeRepo, service.alarmOverrideRepo)
 	for sourceID, copyObject := range copyBySourceID {
 		original := originalByID[sourceID]
 		if original.SoftwareReferenceID != nil {
@@ -101,14 +111,16 @@ func copyFieldDeviceBacnetObjects(ctx context.Context, service *FieldDeviceServi
 				}
 			}
 		}
-		if err := copyBacnetAlarmValues(ctx, service, sourceID, copyObject.ID); err != nil {
+		if err := copyBacnetAlarmValues(ctx, service, validator, sourceID, copyObject); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func copyBacnetAlarmValues(ctx context.Context, service *FieldDeviceService, sourceID, targetID uuid.UUID) error {
+// copyBacnetAlarmValues revalidates the source values, since the alarm
+// type's rules may have tightened after they were stored.
+func copyBacnetAlarmValues(ctx context.Context, service *FieldDeviceService, validator *alarmValueValidator, sourceID uuid.UUID, target *domainFacility.BacnetObject) error {
 	if service.bacnetAlarmValueRepo == nil {
 		return nil
 	}
@@ -116,6 +128,10 @@ func copyBacnetAlarmValues(ctx context.Context, service *FieldDeviceService, sou
 	if err != nil {
 		return err
 	}
+	if err := validator.validate(ctx, "bacnet_objects."+sourceID.String()+".alarm_values", target, values); err != nil {
+		return err
+	}
+	targetID := target.ID
 	copies := make([]*domainFacility.BacnetObjectAlarmValue, 0, len(values))
 	for i := range values {
 		copyValue := values[i]
@@ -44,7 +44,17 @@ func (s *FieldDeviceService) validateImportAggregate(ctx context.Context, aggreg
 			return err
 		}
 	}
-	return validateImportedBacnetObjects(aggregate.FieldDevice.ID, aggregate.BacnetObjects)
+	if err := validateImportedBacnetObjects(aggregate.FieldDevice.ID, aggregate.BacnetObjects); err != nil {
+		return err
+	}
+	validator := newAlarmValueValidator(s.alarmTypeRepo, s.alarmOverrideRepo).withUnits(s.unitRepo)
+	for index := range aggregate.BacnetObjects {
+		object := &aggregate.BacnetObjects[index]
+		if err := validator.validate(ctx, fmt.Sprintf("bacnet_objects.%d.alarm_values", index), object, object.AlarmValues); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func validateImportedBacnetObjects(fieldDeviceID uuid.UUID, objects []domainFacility.BacnetObject) error {
@@ -117,3 +117,31 @@ func (f *importServiceFixture) aggregate() domainFacility.FieldDeviceImportAggre
 		BacnetObjects: objects,
 	}
 }
+
+func TestFieldDeviceImportRejectsAlarmValuesBreakingFieldRules(t *testing.T) {
+	fixture := newImportServiceFixture()
+	alarmTypeID, limit, rules := uuid.New(), 150.0, `{"max": 120}`
+	alarmTypes := &fakeAlarmTypeRepo{items: map[uuid.UUID]*domainFacility.AlarmType{
+		alarmTypeID: {Base: domain.Base{ID: alarmTypeID}, Fields: []domainFacility.AlarmTypeField{{
+			Base: domain.Base{ID: fixture.alarmFieldID}, ValidationJSON: &rules,
+			AlarmField: &domainFacility.AlarmField{Key: "high_limit", DataType: "number"},
+		}}},
+	}}
+	repos := fixture.txRepositories()
+	repos.AlarmTypes = alarmTypes
+	runnerCalls := 0
+	services := newTxServices(fixture.baseRepositories(), repos, &runnerCalls)
+	aggregate := fixture.aggregate()
+	aggregate.BacnetObjects[0].AlarmTypeID = &alarmTypeID
+	aggregate.BacnetObjects[0].AlarmValues[0].ValueNumber = &limit
+
+	err := services.FieldDevice.ImportAggregate(context.Background(), aggregate)
+
+	validationErr, ok := domain.AsValidationError(err)
+	if !ok || validationErr.Codes["bacnet_objects.0.alarm_values.high_limit"] != "max" {
+		t.Fatalf("error = %v, want max on the imported alarm value", err)
+	}
+	if len(fixture.txDevices.items) != 0 || len(fixture.txValues.items) != 0 {
+		t.Fatalf("rejected aggregate was written: devices=%d values=%d", len(fixture.txDevices.items), len(fixture.txValues.items))
+	}
+}
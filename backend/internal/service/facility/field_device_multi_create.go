@@ -190,7 +190,7 @@ func executeFieldDeviceCreateWork(execution fieldDeviceCreateExecution) {
 		resultItem := &execution.result.Results[candidate.index]
 		selection := fieldDeviceBacnetSelection{
 			objectDataID: candidate.item.ObjectDataID, objects: candidate.item.BacnetObjects,
-			objectsSet: len(candidate.item.BacnetObjects) > 0,
+			objectsSet: len(candidate.item.BacnetObjects) > 0, numbering: candidate.item.Numbering,
 		}
 		if err := execution.writer.create(execution.ctx, candidate.item.FieldDevice, selection); err != nil {
 			setFieldDeviceCreateError(resultItem, err, "fielddevice")
@@ -0,0 +1,373 @@
+package facility
+
+import (
+	"context"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/besart951/go_infra_link/backend/internal/service/changecapture"
+	"github.com/google/uuid"
+)
+
+func errFieldDeviceWithoutObjectData() error {
+	return domain.NewValidationError().Add("fielddevice.object_data_id", "field device was not created from an object data template")
+}
+
+// ObjectDataDiff compares the revision a field device was built from with
+// the current templates of its ObjectData.
+func (s *FieldDeviceService) ObjectDataDiff(ctx context.Context, fieldDeviceID uuid.UUID) (*domainObjectData.TemplateDiff, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *FieldDeviceService) (*domainObjectData.TemplateDiff, error) {
+		revisions := txService.templateRevisions()
+		if !revisions.available() {
+			return nil, ErrObjectDataRevisionsUnavailable
+		}
+		device, err := txService.GetByID(txCtx, fieldDeviceID)
+		if err != nil {
+			return nil, err
+		}
+		if device.ObjectDataID == nil || device.ObjectDataRevision == nil {
+			return nil, errFieldDeviceWithoutObjectData()
+		}
+		return revisions.diff(txCtx, *device.ObjectDataID, *device.ObjectDataRevision, 0)
+	})
+}
+
+// UpgradeObjectData moves a field device to another revision of its
+// ObjectData with a three-way merge: a field takes the target value only
+// while the device still has the value of its current revision, so per-device
+// edits survive. TextIndividual is never propagated. baseVersion 0 skips the
+// optimistic version check; targetRevision 0 selects the current templates.
+func (s *FieldDeviceService) UpgradeObjectData(ctx context.Context, fieldDeviceID uuid.UUID, baseVersion, targetRevision uint64) (*domainObjectData.TemplateUpgrade, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *FieldDeviceService) (*domainObjectData.TemplateUpgrade, error) {
+		return txService.upgradeObjectDataInTx(txCtx, fieldDeviceID, baseVersion, targetRevision)
+	})
+}
+
+func (s *FieldDeviceService) upgradeObjectDataInTx(ctx context.Context, fieldDeviceID uuid.UUID, baseVersion, targetRevision uint64) (*domainObjectData.TemplateUpgrade, error) {
+	revisions := s.templateRevisions()
+	if !revisions.available() {
+		return nil, ErrObjectDataRevisionsUnavailable
+	}
+	device, err := s.GetByID(ctx, fieldDeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if baseVersion != 0 && device.Version != baseVersion {
+		return nil, domain.ErrConflict
+	}
+	if device.ObjectDataID == nil || device.ObjectDataRevision == nil {
+		return nil, errFieldDeviceWithoutObjectData()
+	}
+	base, err := revisions.get(ctx, *device.ObjectDataID, *device.ObjectDataRevision)
+	if err != nil {
+		return nil, err
+	}
+	var target *domainObjectData.TemplateRevision
+	if targetRevision == 0 {
+		target, err = revisions.freeze(ctx, *device.ObjectDataID)
+	} else {
+		target, err = revisions.get(ctx, *device.ObjectDataID, targetRevision)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &domainObjectData.TemplateUpgrade{
+		FieldDeviceID: device.ID, Version: device.Version,
+		FromRevision: base.Revision, ToRevision: target.Revision,
+	}
+	if base.Revision == target.Revision {
+		return result, nil
+	}
+
+	objects, err := s.bacnetObjectRepo.GetByFieldDeviceIDs(ctx, []uuid.UUID{device.ID})
+	if err != nil {
+		return nil, err
+	}
+	merge := newTemplateMerge(s.projectFacilityCopy(), device.ID, base.Templates, target.Templates, objects)
+	if err := merge.apply(ctx, result); err != nil {
+		return nil, err
+	}
+
+	device.ObjectDataRevision = &target.Revision
+	if err := s.repo.Update(ctx, device); err != nil {
+		return nil, err
+	}
+	result.Version = device.Version
+	return result, s.recordFieldDeviceChange(ctx, changecapture.ActionUpdated, device.ID)
+}
+
+// templateMerge applies the difference between two revisions to the BACnet
+// objects of one field device.
+type templateMerge struct {
+	copier        projectFacilityCopy
+	fieldDeviceID uuid.UUID
+	base          map[uuid.UUID]domainObjectData.BacnetObjectTemplate
+	target        []domainObjectData.BacnetObjectTemplate
+	matches       map[uuid.UUID]uuid.UUID
+	objects       []*domainFacility.BacnetObject
+	// byTemplate holds the device objects by the base template they link to.
+	byTemplate map[uuid.UUID]*domainFacility.BacnetObject
+}
+
+func newTemplateMerge(copier projectFacilityCopy, fieldDeviceID uuid.UUID, base, target []domainObjectData.BacnetObjectTemplate, objects []*domainFacility.BacnetObject) templateMerge {
+	merge := templateMerge{
+		copier: copier, fieldDeviceID: fieldDeviceID, target: target, objects: objects,
+		base:       make(map[uuid.UUID]domainObjectData.BacnetObjectTemplate, len(base)),
+		matches:    domainObjectData.MatchTemplates(base, target),
+		byTemplate: make(map[uuid.UUID]*domainFacility.BacnetObject, len(objects)),
+	}
+	for _, template := range base {
+		merge.base[template.ID] = template
+	}
+	for _, object := range objects {
+		if object != nil && object.TemplateID != nil {
+			merge.byTemplate[*object.TemplateID] = object
+		}
+	}
+	return merge
+}
+
+func (m templateMerge) apply(ctx context.Context, result *domainObjectData.TemplateUpgrade) error {
+	// linked maps target template IDs to the device object that ends up
+	// linked to them, for remapping software references afterwards.
+	linked := make(map[uuid.UUID]*domainFacility.BacnetObject, len(m.target))
+	added := make(map[uuid.UUID]*domainFacility.BacnetObject)
+	var clones []*domainFacility.BacnetObject
+	for _, template := range m.target {
+		baseID, ok := m.matches[template.ID]
+		if !ok {
+			clone := cloneBacnetObjectFromTemplate(template, m.fieldDeviceID)
+			linked[template.ID], added[template.ID] = clone, clone
+			clones = append(clones, clone)
+			continue
+		}
+		object := m.byTemplate[baseID]
+		if object == nil {
+			// The object was deleted on the device, which counts as an override.
+			result.Conflicts++
+			continue
+		}
+		linked[template.ID] = object
+		updated, err := m.updateObject(ctx, object, m.base[baseID], template, result)
+		if err != nil {
+			return err
+		}
+		if updated {
+			result.Updated++
+		}
+	}
+
+	removed, err := m.removeObjects(ctx)
+	if err != nil {
+		return err
+	}
+	result.Removed = len(removed)
+
+	if len(clones) > 0 {
+		if err := m.copier.bacnetObjectRepo.BulkCreate(ctx, clones, 200); err != nil {
+			return err
+		}
+		if err := m.copier.createAlarmValuesForBacnetObjects(ctx, clones); err != nil {
+			return err
+		}
+		result.Added = len(clones)
+	}
+	return m.remapReferences(ctx, linked, added, removed)
+}
+
+// updateObject merges one template change into a device object and reports
+// whether the object was written.
+func (m templateMerge) updateObject(ctx context.Context, object *domainFacility.BacnetObject, base, target domainObjectData.BacnetObjectTemplate, result *domainObjectData.TemplateUpgrade) (bool, error) {
+	view := bacnetObjectToTemplate(uuid.Nil, *object)
+	changed := object.TemplateID == nil || *object.TemplateID != target.ID
+	for _, field := range domainObjectData.TemplateFields {
+		if field.Name == domainObjectData.TemplateFieldTextIndividual || field.Equal(base, target) || field.Equal(view, target) {
+			continue
+		}
+		if !field.Equal(view, base) {
+			result.Conflicts++
+			continue
+		}
+		field.Copy(&view, target)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	alarmChanged := !sameUUIDPtr(object.AlarmTypeID, view.AlarmTypeID) || !sameUUIDPtr(object.AlarmDefinitionID, view.AlarmDefinitionID)
+	applyTemplateFields(object, view)
+	templateID := target.ID
+	object.TemplateID = &templateID
+	if err := m.copier.bacnetObjectRepo.Update(ctx, object); err != nil {
+		return false, err
+	}
+	if !alarmChanged {
+		return true, nil
+	}
+	return true, m.replaceAlarmValues(ctx, object)
+}
+
+// replaceAlarmValues rebuilds the alarm values of an object whose alarm type
+// or definition changed. Values entered for fields the new schema still has
+// are carried over; the other fields get their defaults.
+func (m templateMerge) replaceAlarmValues(ctx context.Context, object *domainFacility.BacnetObject) error {
+	previous, err := m.copier.bacnetAlarmValueRepo.GetByBacnetObjectID(ctx, object.ID)
+	if err != nil {
+		return err
+	}
+	defaults, err := newAlarmValueMaterializer(m.copier.alarmTypeRepo, m.copier.alarmOverrideRepo).buildDefaultValues(ctx, []*domainFacility.BacnetObject{object})
+	if err != nil {
+		return err
+	}
+	entered := make(map[uuid.UUID]domainFacility.BacnetObjectAlarmValue, len(previous))
+	for _, value := range previous {
+		if value.Source != domainFacility.AlarmValueSourceDefault {
+			entered[value.AlarmTypeFieldID] = value
+		}
+	}
+	values := make([]domainFacility.BacnetObjectAlarmValue, len(defaults))
+	for index, value := range defaults {
+		values[index] = *value
+		if kept, ok := entered[value.AlarmTypeFieldID]; ok {
+			kept.Base, kept.BacnetObject, kept.AlarmTypeField, kept.Unit = domain.Base{}, nil, nil, nil
+			values[index] = kept
+		}
+	}
+	return m.copier.bacnetAlarmValueRepo.ReplaceForBacnetObject(ctx, object.ID, values)
+}
+
+// removeObjects deletes the device objects whose template was removed.
+func (m templateMerge) removeObjects(ctx context.Context) (map[uuid.UUID]struct{}, error) {
+	kept := make(map[uuid.UUID]struct{}, len(m.matches))
+	for _, baseID := range m.matches {
+		kept[baseID] = struct{}{}
+	}
+	removed := make(map[uuid.UUID]struct{})
+	var ids []uuid.UUID
+	for baseID, object := range m.byTemplate {
+		if _, ok := kept[baseID]; ok {
+			continue
+		}
+		if _, ok := m.base[baseID]; !ok {
+			continue
+		}
+		removed[object.ID] = struct{}{}
+		ids = append(ids, object.ID)
+	}
+	if len(ids) == 0 {
+		return removed, nil
+	}
+	return removed, m.copier.bacnetObjectRepo.DeleteByIds(ctx, ids)
+}
+
+// remapReferences points software references at the device objects of the
+// referenced templates. Existing objects only follow a changed template
+// reference while they still reference what the base revision did.
+func (m templateMerge) remapReferences(ctx context.Context, linked, added map[uuid.UUID]*domainFacility.BacnetObject, removed map[uuid.UUID]struct{}) error {
+	baseLinked := make(map[uuid.UUID]*domainFacility.BacnetObject, len(m.byTemplate))
+	for baseID, object := range m.byTemplate {
+		baseLinked[baseID] = object
+	}
+	for _, template := range m.target {
+		object := linked[template.ID]
+		if object == nil {
+			continue
+		}
+		want := referencedObjectID(template.SoftwareReferenceID, linked)
+		if _, isNew := added[template.ID]; !isNew {
+			base := m.base[m.matches[template.ID]]
+			if sameUUIDPtr(want, referencedObjectID(base.SoftwareReferenceID, baseLinked)) ||
+				!sameUUIDPtr(object.SoftwareReferenceID, referencedObjectID(base.SoftwareReferenceID, baseLinked)) {
+				continue
+			}
+		}
+		if sameUUIDPtr(object.SoftwareReferenceID, want) {
+			continue
+		}
+		object.SoftwareReferenceID = want
+		if err := m.copier.bacnetObjectRepo.Update(ctx, object); err != nil {
+			return err
+		}
+	}
+	for _, object := range m.objects {
+		if object == nil || object.SoftwareReferenceID == nil {
+			continue
+		}
+		if _, gone := removed[object.ID]; gone {
+			continue
+		}
+		if _, dangling := removed[*object.SoftwareReferenceID]; !dangling {
+			continue
+		}
+		object.SoftwareReferenceID = nil
+		if err := m.copier.bacnetObjectRepo.Update(ctx, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func referencedObjectID(templateID *uuid.UUID, linked map[uuid.UUID]*domainFacility.BacnetObject) *uuid.UUID {
+	if templateID == nil || linked[*templateID] == nil {
+		return nil
+	}
+	id := linked[*templateID].ID
+	return &id
+}
+
+// replaceFieldDeviceBacnetObjectsFromRevision rebuilds the BACnet objects of
+// a field device from a frozen revision and links each clone to its template.
+func (c projectFacilityCopy) replaceFieldDeviceBacnetObjectsFromRevision(ctx context.Context, fieldDeviceID uuid.UUID, revision *domainObjectData.TemplateRevision) error {
+	if err := c.bacnetObjectRepo.DeleteByFieldDeviceIDs(ctx, []uuid.UUID{fieldDeviceID}); err != nil {
+		return err
+	}
+	if len(revision.Templates) == 0 {
+		return nil
+	}
+
+	templateToClone := make(map[uuid.UUID]*domainFacility.BacnetObject, len(revision.Templates))
+	templateRef := make(map[uuid.UUID]*uuid.UUID, len(revision.Templates))
+	clones := make([]*domainFacility.BacnetObject, 0, len(revision.Templates))
+	for _, template := range revision.Templates {
+		if normalizeBacnetTextFix(template.TextFix) == "" {
+			return domain.NewValidationError().Add("bacnet_objects.text_fix", "text_fix is required")
+		}
+		clone := cloneBacnetObjectFromTemplate(template, fieldDeviceID)
+		templateToClone[template.ID] = clone
+		templateRef[template.ID] = template.SoftwareReferenceID
+		clones = append(clones, clone)
+	}
+
+	if err := c.bacnetObjectRepo.BulkCreate(ctx, clones, 200); err != nil {
+		return err
+	}
+	if err := c.createAlarmValuesForBacnetObjects(ctx, clones); err != nil {
+		return err
+	}
+	return c.remapSoftwareReferences(ctx, templateToClone, templateRef)
+}
+
+func cloneBacnetObjectFromTemplate(template domainObjectData.BacnetObjectTemplate, fieldDeviceID uuid.UUID) *domainFacility.BacnetObject {
+	clone := cloneBacnetObjectForFieldDeviceTemplate(*templateToBacnetObject(template), fieldDeviceID, normalizeBacnetTextFix(template.TextFix))
+	templateID := template.ID
+	clone.TemplateID = &templateID
+	return clone
+}
+
+func applyTemplateFields(object *domainFacility.BacnetObject, template domainObjectData.BacnetObjectTemplate) {
+	object.TextFix, object.Description = template.TextFix, template.Description
+	object.GMSVisible, object.Optional = template.GMSVisible, template.Optional
+	object.SoftwareType, object.SoftwareNumber = template.SoftwareType, template.SoftwareNumber
+	object.HardwareType, object.HardwareQuantity = template.HardwareType, template.HardwareQuantity
+	object.StateTextID, object.NotificationClassID = template.StateTextID, template.NotificationClassID
+	object.AlarmTypeID, object.AlarmDefinitionID = template.AlarmTypeID, template.AlarmDefinitionID
+}
+
+func sameUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,191 @@
+package facility
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+type stubUpgradeBacnetObjectStore struct {
+	domainObjectData.BacnetObjectStore
+	created []*domainFacility.BacnetObject
+	updated map[uuid.UUID]int
+	deleted []uuid.UUID
+}
+
+func (s *stubUpgradeBacnetObjectStore) BulkCreate(_ context.Context, entities []*domainFacility.BacnetObject, _ int) error {
+	s.created = append(s.created, entities...)
+	return nil
+}
+
+func (s *stubUpgradeBacnetObjectStore) Update(_ context.Context, entity *domainFacility.BacnetObject) error {
+	if s.updated == nil {
+		s.updated = make(map[uuid.UUID]int)
+	}
+	s.updated[entity.ID]++
+	return nil
+}
+
+func (s *stubUpgradeBacnetObjectStore) DeleteByIds(_ context.Context, ids []uuid.UUID) error {
+	s.deleted = append(s.deleted, ids...)
+	return nil
+}
+
+type stubUpgradeAlarmValueStore struct {
+	domainFacility.BacnetObjectAlarmValueRepository
+	values map[uuid.UUID][]domainFacility.BacnetObjectAlarmValue
+}
+
+func (s *stubUpgradeAlarmValueStore) GetByBacnetObjectID(_ context.Context, bacnetObjectID uuid.UUID) ([]domainFacility.BacnetObjectAlarmValue, error) {
+	return s.values[bacnetObjectID], nil
+}
+
+func (s *stubUpgradeAlarmValueStore) ReplaceForBacnetObject(_ context.Context, bacnetObjectID uuid.UUID, values []domainFacility.BacnetObjectAlarmValue) error {
+	s.values[bacnetObjectID] = values
+	return nil
+}
+
+func upgradeTemplate(textFix string, number uint16) domainObjectData.BacnetObjectTemplate {
+	return domainObjectData.BacnetObjectTemplate{
+		ID: uuid.New(), TextFix: textFix, SoftwareType: domainFacility.BacnetSoftwareTypeAI, SoftwareNumber: number,
+	}
+}
+
+func linkedObject(template domainObjectData.BacnetObjectTemplate, fieldDeviceID uuid.UUID) *domainFacility.BacnetObject {
+	object := templateToBacnetObject(template)
+	object.ID = uuid.New()
+	object.FieldDeviceID = &fieldDeviceID
+	templateID := template.ID
+	object.TemplateID = &templateID
+	return object
+}
+
+func TestTemplateMergeKeepsDeviceOverrides(t *testing.T) {
+	fieldDeviceID := uuid.New()
+	description, custom, individual := "supply air", "customer label", "device text"
+	kept, removed := upgradeTemplate("Temperature", 1), upgradeTemplate("Alarm", 2)
+	kept.Description = &description
+	base := []domainObjectData.BacnetObjectTemplate{kept, removed}
+
+	changed := kept
+	newDescription, newIndividual := "supply air temperature", "template text"
+	changed.TextFix, changed.Description, changed.TextIndividual = "Temperature actual", &newDescription, &newIndividual
+	added := upgradeTemplate("Setpoint", 3)
+	target := []domainObjectData.BacnetObjectTemplate{changed, added}
+
+	keptObject, removedObject := linkedObject(kept, fieldDeviceID), linkedObject(removed, fieldDeviceID)
+	keptObject.Description, keptObject.TextIndividual = &custom, &individual
+
+	store := &stubUpgradeBacnetObjectStore{}
+	merge := newTemplateMerge(projectFacilityCopy{bacnetObjectRepo: store}, fieldDeviceID, base, target,
+		[]*domainFacility.BacnetObject{keptObject, removedObject})
+	result := &domainObjectData.TemplateUpgrade{}
+	if err := merge.apply(context.Background(), result); err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+
+	if result.Added != 1 || result.Updated != 1 || result.Removed != 1 || result.Conflicts != 1 {
+		t.Fatalf("result = %+v, want one added, updated, removed and conflict", result)
+	}
+	if keptObject.TextFix != "Temperature actual" {
+		t.Fatalf("text_fix = %q, want the template change", keptObject.TextFix)
+	}
+	if keptObject.Description == nil || *keptObject.Description != custom {
+		t.Fatalf("description = %v, want the device override", keptObject.Description)
+	}
+	if keptObject.TextIndividual == nil || *keptObject.TextIndividual != individual {
+		t.Fatalf("text_individual = %v, want the device value", keptObject.TextIndividual)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != removedObject.ID {
+		t.Fatalf("deleted = %v, want the object of the removed template", store.deleted)
+	}
+	if len(store.created) != 1 || store.created[0].TemplateID == nil || *store.created[0].TemplateID != added.ID {
+		t.Fatalf("created = %v, want a clone linked to the added template", store.created)
+	}
+	if *store.created[0].FieldDeviceID != fieldDeviceID {
+		t.Fatalf("clone field device = %v, want %v", store.created[0].FieldDeviceID, fieldDeviceID)
+	}
+}
+
+func TestTemplateMergeFollowsRenumberedTemplate(t *testing.T) {
+	fieldDeviceID := uuid.New()
+	template := upgradeTemplate("Temperature", 1)
+	rewritten := template
+	rewritten.ID = uuid.New()
+	rewritten.HardwareQuantity = 2
+	object := linkedObject(template, fieldDeviceID)
+
+	store := &stubUpgradeBacnetObjectStore{}
+	merge := newTemplateMerge(projectFacilityCopy{bacnetObjectRepo: store}, fieldDeviceID,
+		[]domainObjectData.BacnetObjectTemplate{template}, []domainObjectData.BacnetObjectTemplate{rewritten},
+		[]*domainFacility.BacnetObject{object})
+	result := &domainObjectData.TemplateUpgrade{}
+	if err := merge.apply(context.Background(), result); err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+
+	if result.Updated != 1 || result.Added != 0 || result.Removed != 0 {
+		t.Fatalf("result = %+v, want the object updated in place", result)
+	}
+	if object.TemplateID == nil || *object.TemplateID != rewritten.ID || object.HardwareQuantity != 2 {
+		t.Fatalf("object = %+v, want it linked to the rewritten template", object)
+	}
+}
+
+func TestUpgradeObjectDataRequiresRevisions(t *testing.T) {
+	service := &FieldDeviceService{}
+	if _, err := service.upgradeObjectDataInTx(context.Background(), uuid.New(), 0, 0); !errors.Is(err, ErrObjectDataRevisionsUnavailable) {
+		t.Fatalf("upgradeObjectDataInTx() error = %v, want %v", err, ErrObjectDataRevisionsUnavailable)
+	}
+}
+
+func TestTemplateMergeCarriesAlarmValuesToTheNewDefinition(t *testing.T) {
+	fieldDeviceID := uuid.New()
+	typeID, oldDefinitionID, newDefinitionID := uuid.New(), uuid.New(), uuid.New()
+	limitField, delayField := uuid.New(), uuid.New()
+	alarmTypes := &stubAlarmTypeRepo{items: map[uuid.UUID]*domainFacility.AlarmType{typeID: {Fields: []domainFacility.AlarmTypeField{
+		{Base: domain.Base{ID: limitField}, AlarmField: &domainFacility.AlarmField{Key: "high_limit", DataType: "number"}},
+		{Base: domain.Base{ID: delayField}, AlarmField: &domainFacility.AlarmField{Key: "alarm_delay", DataType: "duration"}},
+	}}}}
+	delay := "30"
+	overrides := &fakeAlarmDefinitionFieldOverrideRepo{items: []domainFacility.AlarmDefinitionFieldOverride{
+		{AlarmDefinitionID: newDefinitionID, AlarmTypeFieldID: delayField, DefaultValueOverrideJSON: &delay},
+	}}
+	template := upgradeTemplate("Temperature", 1)
+	template.AlarmTypeID, template.AlarmDefinitionID = &typeID, &oldDefinitionID
+	target := template
+	target.AlarmDefinitionID = &newDefinitionID
+	object := linkedObject(template, fieldDeviceID)
+
+	entered, defaulted := 80.0, 10.0
+	values := &stubUpgradeAlarmValueStore{values: map[uuid.UUID][]domainFacility.BacnetObjectAlarmValue{object.ID: {
+		{Base: domain.Base{ID: uuid.New()}, BacnetObjectID: object.ID, AlarmTypeFieldID: limitField, ValueNumber: &entered, Source: domainFacility.AlarmValueSourceUser},
+		{Base: domain.Base{ID: uuid.New()}, BacnetObjectID: object.ID, AlarmTypeFieldID: delayField, ValueNumber: &defaulted, Source: domainFacility.AlarmValueSourceDefault},
+	}}}
+
+	merge := newTemplateMerge(projectFacilityCopy{
+		bacnetObjectRepo: &stubUpgradeBacnetObjectStore{}, alarmTypeRepo: alarmTypes,
+		alarmOverrideRepo: overrides, bacnetAlarmValueRepo: values,
+	}, fieldDeviceID, []domainObjectData.BacnetObjectTemplate{template}, []domainObjectData.BacnetObjectTemplate{target},
+		[]*domainFacility.BacnetObject{object})
+	if err := merge.apply(context.Background(), &domainObjectData.TemplateUpgrade{}); err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+
+	stored := values.values[object.ID]
+	byField := make(map[uuid.UUID]domainFacility.BacnetObjectAlarmValue, len(stored))
+	for _, value := range stored {
+		byField[value.AlarmTypeFieldID] = value
+	}
+	if limit := byField[limitField]; len(stored) != 2 || limit.ID != uuid.Nil || limit.ValueNumber == nil || *limit.ValueNumber != entered {
+		t.Fatalf("values = %+v, want the entered high limit carried over", stored)
+	}
+	if delay := byField[delayField]; delay.ValueNumber == nil || *delay.ValueNumber != 30 || delay.Source != domainFacility.AlarmValueSourceDefault {
+		t.Fatalf("alarm delay = %+v, want the default of the new definition", delay)
+	}
+}
@@ -32,9 +32,16 @@ type FieldDeviceService struct {
 	objectDataRepo              domainObjectData.ObjectDataStore
 	alarmTypeRepo               domainFacility.AlarmTypeRepository
 	bacnetAlarmValueRepo        domainFacility.BacnetObjectAlarmValueRepository
+	alarmOverrideRepo           domainFacility.AlarmDefinitionFieldOverrideRepository
+	alarmDefinitionRepo         domainFacility.AlarmDefinitionRepository
+	unitRepo                    domainFacility.UnitRepository
 	fieldDeviceOptionsCache     *fieldDeviceOptionsCache
 	changeRecorder              changecapture.Recorder
+	numbering                   *BacnetNumberingService
 	tx                          txCoordinator
+
+	templateStore domainObjectData.BacnetObjectTemplateStore
+	revisionStore domainObjectData.TemplateRevisionStore
 }
 
 func normalizeFieldDeviceListPagination(page, limit int) (int, int) {
@@ -77,6 +84,39 @@ func (s *FieldDeviceService) bindTransactions(tx txCoordinator) {
 	s.tx = tx
 }
 
+func (s *FieldDeviceService) bindBacnetNumbering(numbering *BacnetNumberingService) {
+	s.numbering = numbering
+}
+
+// bindAlarmDefinitionOverrides lets alarm defaults and validation follow the
+// overrides of the alarm definition bound to each BACnet object.
+func (s *FieldDeviceService) bindAlarmDefinitionOverrides(repo domainFacility.AlarmDefinitionFieldOverrideRepository) {
+	s.alarmOverrideRepo = repo
+}
+
+// bindAlarmDefinitions lets BACnet object patches check that a newly bound
+// alarm definition belongs to the object's alarm type.
+func (s *FieldDeviceService) bindAlarmDefinitions(repo domainFacility.AlarmDefinitionRepository) {
+	s.alarmDefinitionRepo = repo
+}
+
+// bindUnits lets imported alarm values be converted to the default units of
+// their fields.
+func (s *FieldDeviceService) bindUnits(repo domainFacility.UnitRepository) {
+	s.unitRepo = repo
+}
+
+// bindTemplateRevisions makes devices built from an ObjectData record the
+// template revision they were cloned from, so they can be upgraded later.
+func (s *FieldDeviceService) bindTemplateRevisions(templates domainObjectData.BacnetObjectTemplateStore, store domainObjectData.TemplateRevisionStore) {
+	s.templateStore = templates
+	s.revisionStore = store
+}
+
+func (s *FieldDeviceService) templateRevisions() objectDataRevisions {
+	return objectDataRevisions{objectDataRepo: s.objectDataRepo, templateStore: s.templateStore, store: s.revisionStore}
+}
+
 func (s *FieldDeviceService) bindChangeRecorder(recorder changecapture.Recorder) {
 	s.changeRecorder = changecapture.DefaultRecorder(recorder)
 }
@@ -681,8 +721,14 @@ func applyBacnetObjectPatch(target *domainFacility.BacnetObject, patch domainFac
 		target.NotificationClassID = patch.NotificationClassID
 	}
 	if patch.AlarmTypeID != nil {
+		if target.AlarmTypeID == nil || *target.AlarmTypeID != *patch.AlarmTypeID {
+			target.AlarmDefinitionID = nil
+		}
 		target.AlarmTypeID = patch.AlarmTypeID
 	}
+	if patch.AlarmDefinitionID != nil {
+		target.AlarmDefinitionID = patch.AlarmDefinitionID
+	}
 }
 
 func (s *FieldDeviceService) patchBacnetObjects(ctx context.Context, fieldDeviceID uuid.UUID, patches []domainFacility.BacnetObjectPatch) error {
@@ -714,6 +760,11 @@ func (s *FieldDeviceService) patchBacnetObjects(ctx context.Context, fieldDevice
 
 		clone := *existing
 		applyBacnetObjectPatch(&clone, patch)
+		if patch.AlarmDefinitionID != nil {
+			if err := s.bindPatchedAlarmDefinition(ctx, &clone, ve); err != nil {
+				return err
+			}
+		}
 
 		if strings.TrimSpace(clone.TextFix) == "" {
 			ve = ve.Add(fmt.Sprintf("bacnet_objects.%s.text_fix", patch.ID), "text_fix is required")
@@ -738,6 +789,29 @@ func (s *FieldDeviceService) patchBacnetObjects(ctx context.Context, fieldDevice
 	return nil
 }
 
+// bindPatchedAlarmDefinition checks a patched alarm definition the way
+// resolveAlarmBinding checks template objects: it must exist and belong to
+// the object's alarm type, which it sets when the object has none.
+func (s *FieldDeviceService) bindPatchedAlarmDefinition(ctx context.Context, object *domainFacility.BacnetObject, ve *domain.ValidationError) error {
+	if s.alarmDefinitionRepo == nil {
+		return nil
+	}
+	field := fmt.Sprintf("bacnet_objects.%s.alarm_definition_id", object.ID)
+	definitions, err := s.alarmDefinitionRepo.GetByIds(ctx, []uuid.UUID{*object.AlarmDefinitionID})
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(definitions) == 0 || definitions[0].AlarmTypeID == nil:
+		ve.Add(field, "alarm definition not found")
+	case object.AlarmTypeID != nil && *object.AlarmTypeID != *definitions[0].AlarmTypeID:
+		ve.Add(field, "alarm_definition_id conflicts with alarm_type_id")
+	default:
+		object.AlarmTypeID = definitions[0].AlarmTypeID
+	}
+	return nil
+}
+
 func (s *FieldDeviceService) replaceBacnetObjectsFromObjectData(ctx context.Context, fieldDeviceID uuid.UUID, objectDataID uuid.UUID) error {
 	return s.projectFacilityCopy().replaceFieldDeviceBacnetObjectsFromObjectData(ctx, fieldDeviceID, objectDataID)
 }
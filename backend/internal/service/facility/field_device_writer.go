@@ -6,6 +6,7 @@ import (
 
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
 	"github.com/besart951/go_infra_link/backend/internal/service/changecapture"
 	"github.com/google/uuid"
 )
@@ -18,6 +19,8 @@ type fieldDeviceBacnetSelection struct {
 	objectDataID *uuid.UUID
 	objects      []domainFacility.BacnetObject
 	objectsSet   bool
+	numbering    *domainFacility.BacnetNumbering
+	revision     *domainObjectData.TemplateRevision
 }
 
 const apparatNrAlreadyUsedMessage = "apparatnummer ist bereits vergeben"
@@ -42,6 +45,9 @@ func (w fieldDeviceWriter) createInTx(ctx context.Context, fieldDevice *domainFa
 	if err := w.service.Validate(ctx, fieldDevice, nil); err != nil {
 		return err
 	}
+	if err := w.linkObjectData(ctx, fieldDevice, &selection); err != nil {
+		return err
+	}
 	if err := w.service.repo.Create(ctx, fieldDevice); err != nil {
 		return err
 	}
@@ -74,6 +80,9 @@ func (w fieldDeviceWriter) updateInTx(ctx context.Context, fieldDevice *domainFa
 	if err := w.service.Validate(ctx, fieldDevice, &fieldDevice.ID); err != nil {
 		return err
 	}
+	if err := w.linkObjectData(ctx, fieldDevice, &selection); err != nil {
+		return err
+	}
 	if err := w.service.repo.Update(ctx, fieldDevice); err != nil {
 		return err
 	}
@@ -84,6 +93,45 @@ func (w fieldDeviceWriter) updateInTx(ctx context.Context, fieldDevice *domainFa
 }
 
 func (w fieldDeviceWriter) applyBacnetSelection(ctx context.Context, fieldDeviceID uuid.UUID, selection fieldDeviceBacnetSelection) error {
+	if err := w.replaceBacnetSelection(ctx, fieldDeviceID, selection); err != nil {
+		return err
+	}
+	return w.service.assignBacnetNumbers(ctx, fieldDeviceID, selection.numbering)
+}
+
+// linkObjectData records the template revision a device is built from.
+// Explicit BACnet objects detach the device from its ObjectData. Without a
+// revision store the legacy copy without a link is used.
+func (w fieldDeviceWriter) linkObjectData(ctx context.Context, fieldDevice *domainFacility.FieldDevice, selection *fieldDeviceBacnetSelection) error {
+	if selection.objectsSet {
+		fieldDevice.ObjectDataID, fieldDevice.ObjectDataRevision = nil, nil
+		return nil
+	}
+	revisions := w.service.templateRevisions()
+	if selection.objectDataID == nil || !revisions.available() {
+		return nil
+	}
+	objectData, err := domain.GetByID(ctx, w.service.objectDataRepo, *selection.objectDataID)
+	if err != nil {
+		return err
+	}
+	if !objectData.IsActive {
+		return domain.ErrNotFound
+	}
+	revision, err := revisions.freeze(ctx, objectData.ID)
+	if err != nil {
+		return err
+	}
+	objectDataID, number := revision.ObjectDataID, revision.Revision
+	fieldDevice.ObjectDataID, fieldDevice.ObjectDataRevision = &objectDataID, &number
+	selection.revision = revision
+	return nil
+}
+
+func (w fieldDeviceWriter) replaceBacnetSelection(ctx context.Context, fieldDeviceID uuid.UUID, selection fieldDeviceBacnetSelection) error {
+	if selection.revision != nil {
+		return w.service.projectFacilityCopy().replaceFieldDeviceBacnetObjectsFromRevision(ctx, fieldDeviceID, selection.revision)
+	}
 	if selection.objectDataID != nil {
 		return w.service.replaceBacnetObjectsFromObjectData(ctx, fieldDeviceID, *selection.objectDataID)
 	}
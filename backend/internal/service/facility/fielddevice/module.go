@@ -15,6 +15,7 @@ type Repositories struct {
 	SystemParts              domainFacility.SystemPartRepository
 	Specifications           domainFieldDevice.SpecificationStore
 	BacnetObjects            domainObjectData.BacnetObjectStore
+	BacnetInstances          domainObjectData.BacnetInstanceStore
 	ObjectData               domainObjectData.ObjectDataStore
 	AlarmTypes               domainFacility.AlarmTypeRepository
 	BacnetObjectAlarmValues  domainFacility.BacnetObjectAlarmValueRepository
@@ -16,4 +16,5 @@ type Repositories struct {
 	FieldDevices             domainFieldDevice.FieldDeviceStore
 	Specifications           domainFieldDevice.SpecificationStore
 	BacnetObjects            domainObjectData.BacnetObjectStore
+	IPPools                  domainFacility.IPPoolRepository
 }
@@ -21,7 +21,10 @@ type HierarchyCopier struct {
 	fieldDeviceRepo         domainFieldDevice.FieldDeviceStore
 	specificationRepo       domainFieldDevice.SpecificationStore
 	bacnetObjectRepo        domainObjectData.BacnetObjectStore
+	ipam                    *IPAMService
 	tx                      txCoordinator
+
+	naming *NamingSchemeService
 }
 
 func NewHierarchyCopier(
@@ -50,6 +53,16 @@ func (c *HierarchyCopier) bindTransactions(tx txCoordinator) {
 	c.tx = tx
 }
 
+// bindIPAM lets controller copies draw addresses from the IP pools.
+func (c *HierarchyCopier) bindIPAM(ipam *IPAMService) {
+	c.ipam = ipam
+}
+
+// bindNaming lets controller copies follow the naming scheme of their cabinet.
+func (c *HierarchyCopier) bindNaming(naming *NamingSchemeService) {
+	c.naming = naming
+}
+
 func (c *HierarchyCopier) transaction() facilityTx[*HierarchyCopier] {
 	return newFacilityTx(c.tx, c, func(services *Services) *HierarchyCopier {
 		return services.HierarchyCopier
@@ -66,6 +79,8 @@ func (c *HierarchyCopier) projectFacilityCopy() projectFacilityCopy {
 		fieldDeviceRepo:         c.fieldDeviceRepo,
 		specificationRepo:       c.specificationRepo,
 		bacnetObjectRepo:        c.bacnetObjectRepo,
+		ipam:                    c.ipam,
+		naming:                  c.naming,
 	}
 }
 
@@ -81,6 +96,27 @@ func (c *HierarchyCopier) CopySPSControllerByID(ctx context.Context, id uuid.UUI
 	})
 }
 
+// CopyControlCabinetForProject copies a control cabinet for projectID. Its
+// controllers get addresses from the project's IP pools before the
+// building's.
+func (c *HierarchyCopier) CopyControlCabinetForProject(ctx context.Context, projectID, id uuid.UUID) (*domainFacility.ControlCabinet, error) {
+	return runWithFacilityTxResult(ctx, c.transaction(), func(txCtx context.Context, copier *HierarchyCopier) (*domainFacility.ControlCabinet, error) {
+		facilityCopy := copier.projectFacilityCopy()
+		facilityCopy.projectID = &projectID
+		return facilityCopy.copyControlCabinetByID(txCtx, id)
+	})
+}
+
+// CopySPSControllerForProject copies a controller for projectID, preferring
+// the project's IP pools like CopyControlCabinetForProject.
+func (c *HierarchyCopier) CopySPSControllerForProject(ctx context.Context, projectID, id uuid.UUID) (*domainFacility.SPSController, error) {
+	return runWithFacilityTxResult(ctx, c.transaction(), func(txCtx context.Context, copier *HierarchyCopier) (*domainFacility.SPSController, error) {
+		facilityCopy := copier.projectFacilityCopy()
+		facilityCopy.projectID = &projectID
+		return facilityCopy.copySPSControllerByID(txCtx, id)
+	})
+}
+
 func (c *HierarchyCopier) CopySPSControllerSystemTypeByID(ctx context.Context, id uuid.UUID) (*domainFacility.SPSControllerSystemType, error) {
 	return runWithFacilityTxResult(ctx, c.transaction(), func(txCtx context.Context, copier *HierarchyCopier) (*domainFacility.SPSControllerSystemType, error) {
 		return copier.projectFacilityCopy().copySPSControllerSystemTypeByID(txCtx, id)
@@ -0,0 +1,266 @@
+package facility
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/netip"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+var ipPoolNetworkField = domain.ValidationField{Key: "ippool.network", Name: "network"}
+
+// IPAMService manages per-VLAN address pools for SPS controllers. Addresses
+// are unique per VLAN across all buildings, so allocation checks the whole
+// VLAN while reports only look at controllers inside the pool's scope.
+type IPAMService struct {
+	baseService[domainFacility.IPPool]
+	pools           domainFacility.IPPoolRepository
+	controlCabinets domainFacility.ControlCabinetRepository
+}
+
+func NewIPAMService(pools domainFacility.IPPoolRepository, controlCabinets domainFacility.ControlCabinetRepository) *IPAMService {
+	return &IPAMService{
+		baseService:     newBase(pools, 50),
+		pools:           pools,
+		controlCabinets: controlCabinets,
+	}
+}
+
+func (s *IPAMService) Create(ctx context.Context, pool *domainFacility.IPPool) error {
+	if err := s.Validate(ctx, pool, nil); err != nil {
+		return err
+	}
+	return s.pools.Create(ctx, pool)
+}
+
+func (s *IPAMService) Update(ctx context.Context, pool *domainFacility.IPPool) error {
+	if err := s.Validate(ctx, pool, &pool.ID); err != nil {
+		return err
+	}
+	return s.pools.Update(ctx, pool)
+}
+
+// Validate rejects pools whose network overlaps another pool of the same
+// VLAN and scope; pools of different buildings may reuse a VLAN.
+func (s *IPAMService) Validate(ctx context.Context, pool *domainFacility.IPPool, excludeID *uuid.UUID) error {
+	pool.Vlan = strings.TrimSpace(pool.Vlan)
+	pool.Network = strings.TrimSpace(pool.Network)
+	if err := pool.Validate(); err != nil {
+		return err
+	}
+	return validateChecks(func(builder *domain.ValidationBuilder) error {
+		existing, err := s.pools.List(ctx, domainFacility.IPPoolFilter{BuildingID: pool.BuildingID, ProjectID: pool.ProjectID, Vlan: pool.Vlan})
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if excludeID != nil && other.ID == *excludeID {
+				continue
+			}
+			if pool.Overlaps(other) {
+				ipPoolNetworkField.Add(builder, fmt.Sprintf("network overlaps pool %q (%s)", other.Name, other.Network))
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (s *IPAMService) ListPools(ctx context.Context, filter domainFacility.IPPoolFilter) ([]domainFacility.IPPool, error) {
+	return s.pools.List(ctx, filter)
+}
+
+// NextFreeIP suggests the lowest free address of a pool without reserving it.
+func (s *IPAMService) NextFreeIP(ctx context.Context, poolID uuid.UUID) (*domainFacility.IPAllocation, error) {
+	pool, err := s.GetByID(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.usedAddresses(ctx, pool.Vlan)
+	if err != nil {
+		return nil, err
+	}
+	return allocateFromPool(*pool, used)
+}
+
+// NextFreeIPForControlCabinet resolves the building pool of a VLAN the way
+// /sps-controllers/next-ga-device resolves GA devices for a cabinet.
+func (s *IPAMService) NextFreeIPForControlCabinet(ctx context.Context, controlCabinetID uuid.UUID, vlan string) (*domainFacility.IPAllocation, error) {
+	cabinet, err := domain.GetByID(ctx, s.controlCabinets, controlCabinetID)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := s.buildingPool(ctx, nil, cabinet.BuildingID, vlan, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.NextFreeIP(ctx, pool.ID)
+}
+
+func (s *IPAMService) Utilization(ctx context.Context, poolID uuid.UUID) (*domainFacility.IPPoolUtilization, error) {
+	pool, err := s.GetByID(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	return s.utilization(ctx, *pool)
+}
+
+// UtilizationReport summarizes every pool matching filter, one row per subnet.
+func (s *IPAMService) UtilizationReport(ctx context.Context, filter domainFacility.IPPoolFilter) ([]domainFacility.IPPoolUtilization, error) {
+	pools, err := s.pools.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	report := make([]domainFacility.IPPoolUtilization, 0, len(pools))
+	for _, pool := range pools {
+		item, err := s.utilization(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, *item)
+	}
+	return report, nil
+}
+
+func (s *IPAMService) utilization(ctx context.Context, pool domainFacility.IPPool) (*domainFacility.IPPoolUtilization, error) {
+	controllers, err := s.pools.ListControllerAddresses(ctx, domainFacility.IPPoolFilter{
+		BuildingID: pool.BuildingID, ProjectID: pool.ProjectID, Vlan: pool.Vlan,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &domainFacility.IPPoolUtilization{Pool: pool, Capacity: pool.Capacity(), Issues: make([]domainFacility.IPAddressIssue, 0)}
+	first, last, _ := pool.AllocatableRange()
+	counted := make(map[netip.Addr]struct{}, len(controllers))
+	for _, controller := range controllers {
+		result.Issues = append(result.Issues, pool.InspectController(controller)...)
+		addr, ok := parseStoredAddress(controller.IPAddress)
+		if !ok || addr.Compare(first) < 0 || addr.Compare(last) > 0 {
+			continue
+		}
+		counted[addr] = struct{}{}
+	}
+	result.Used = len(counted)
+	result.Free = max(result.Capacity-result.Used, 0)
+	if result.Capacity > 0 {
+		result.Utilization = math.Round(float64(result.Used)*1000/float64(result.Capacity)) / 10
+	}
+	return result, nil
+}
+
+// buildingPool resolves the pool of a VLAN. Pools of projectID, when given,
+// take precedence over the building's own pools. Among several pools it
+// prefers the one that already contains preferred, so copies stay in the
+// subnet of their source controller.
+func (s *IPAMService) buildingPool(ctx context.Context, projectID *uuid.UUID, buildingID uuid.UUID, vlan string, preferred *string) (*domainFacility.IPPool, error) {
+	vlan = strings.TrimSpace(vlan)
+	if vlan == "" {
+		return nil, nil
+	}
+	var pools []domainFacility.IPPool
+	var err error
+	if projectID != nil {
+		if pools, err = s.pools.List(ctx, domainFacility.IPPoolFilter{ProjectID: projectID, Vlan: vlan}); err != nil {
+			return nil, err
+		}
+	}
+	if len(pools) == 0 {
+		pools, err = s.pools.List(ctx, domainFacility.IPPoolFilter{BuildingID: &buildingID, Vlan: vlan})
+	}
+	if err != nil || len(pools) == 0 {
+		return nil, err
+	}
+	if address, ok := parseStoredAddress(preferred); ok {
+		for index := range pools {
+			if pools[index].Contains(address.String()) {
+				return &pools[index], nil
+			}
+		}
+	}
+	return &pools[0], nil
+}
+
+func (s *IPAMService) usedAddresses(ctx context.Context, vlan string) (map[string]struct{}, error) {
+	controllers, err := s.pools.ListControllerAddresses(ctx, domainFacility.IPPoolFilter{Vlan: vlan})
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]struct{}, len(controllers))
+	for _, controller := range controllers {
+		if addr, ok := parseStoredAddress(controller.IPAddress); ok {
+			used[addr.String()] = struct{}{}
+		}
+	}
+	return used, nil
+}
+
+func allocateFromPool(pool domainFacility.IPPool, used map[string]struct{}) (*domainFacility.IPAllocation, error) {
+	address, ok := pool.NextFree(used)
+	if !ok {
+		return nil, domain.ErrConflict
+	}
+	return &domainFacility.IPAllocation{
+		PoolID: pool.ID, IPAddress: address, Subnet: pool.SubnetMask(), Gateway: pool.Gateway, Vlan: pool.Vlan,
+	}, nil
+}
+
+func parseStoredAddress(value *string) (netip.Addr, bool) {
+	if value == nil {
+		return netip.Addr{}, false
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(*value))
+	return addr, err == nil && addr.Is4()
+}
+
+// controllerAddressAllocator hands out pool addresses to the controllers of
+// one copy operation. Copies are bulk-created after planning, so addresses
+// handed out earlier in the same operation are tracked here.
+type controllerAddressAllocator struct {
+	ipam      *IPAMService
+	projectID *uuid.UUID
+	used      map[string]map[string]struct{}
+}
+
+// newControllerAddressAllocator allocates from the pools of projectID first
+// when the copy is made for a project; nil uses building pools only.
+func (s *IPAMService) newControllerAddressAllocator(projectID *uuid.UUID) *controllerAddressAllocator {
+	return &controllerAddressAllocator{ipam: s, projectID: projectID, used: make(map[string]map[string]struct{})}
+}
+
+// assign gives target a fresh address from the project or building pool of
+// the source controller's VLAN. Without such a pool the copy keeps no
+// address, as before.
+func (a *controllerAddressAllocator) assign(ctx context.Context, buildingID uuid.UUID, original domainFacility.SPSController, target *domainFacility.SPSController) error {
+	if a.ipam == nil || original.Vlan == nil {
+		return nil
+	}
+	pool, err := a.ipam.buildingPool(ctx, a.projectID, buildingID, *original.Vlan, original.IPAddress)
+	if err != nil || pool == nil {
+		return err
+	}
+	used, ok := a.used[pool.Vlan]
+	if !ok {
+		if used, err = a.ipam.usedAddresses(ctx, pool.Vlan); err != nil {
+			return err
+		}
+		a.used[pool.Vlan] = used
+	}
+	allocation, err := allocateFromPool(*pool, used)
+	if err != nil {
+		return domain.NewValidationError().Add(spsControllerIPAddressField.Key, fmt.Sprintf("no free ip address left in pool %q", pool.Name))
+	}
+	used[allocation.IPAddress] = struct{}{}
+	target.IPAddress, target.Subnet, target.Vlan = &allocation.IPAddress, &allocation.Subnet, &allocation.Vlan
+	if allocation.Gateway != nil {
+		target.Gateway = allocation.Gateway
+	}
+	return nil
+}
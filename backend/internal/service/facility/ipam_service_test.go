@@ -0,0 +1,47 @@
+package facility
+
+import (
+	"context"
+	"testing"
+
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type ipPoolRepoStub struct {
+	domainFacility.IPPoolRepository
+	pools []domainFacility.IPPool
+}
+
+func (r ipPoolRepoStub) List(_ context.Context, filter domainFacility.IPPoolFilter) ([]domainFacility.IPPool, error) {
+	out := make([]domainFacility.IPPool, 0)
+	for _, pool := range r.pools {
+		if filter.BuildingID != nil && (pool.BuildingID == nil || *pool.BuildingID != *filter.BuildingID) {
+			continue
+		}
+		if filter.ProjectID != nil && (pool.ProjectID == nil || *pool.ProjectID != *filter.ProjectID) {
+			continue
+		}
+		if pool.Vlan == filter.Vlan {
+			out = append(out, pool)
+		}
+	}
+	return out, nil
+}
+
+func TestBuildingPoolPrefersProjectPools(t *testing.T) {
+	buildingID, projectID := uuid.New(), uuid.New()
+	buildingPool := domainFacility.IPPool{Name: "building", BuildingID: &buildingID, Vlan: "100", Network: "10.1.0.0/24"}
+	projectPool := domainFacility.IPPool{Name: "project", ProjectID: &projectID, Vlan: "100", Network: "10.2.0.0/24"}
+	service := NewIPAMService(ipPoolRepoStub{pools: []domainFacility.IPPool{buildingPool, projectPool}}, nil)
+
+	pool, err := service.buildingPool(t.Context(), &projectID, buildingID, "100", nil)
+	if err != nil || pool == nil || pool.Name != "project" {
+		t.Fatalf("project copy: pool=%+v err=%v", pool, err)
+	}
+	otherProject := uuid.New()
+	pool, err = service.buildingPool(t.Context(), &otherProject, buildingID, "100", nil)
+	if err != nil || pool == nil || pool.Name != "building" {
+		t.Fatalf("project without pools must fall back to the building: pool=%+v err=%v", pool, err)
+	}
+}
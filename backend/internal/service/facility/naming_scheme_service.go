@@ -0,0 +1,246 @@
+package facility
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainFieldDevice "github.com/besart951/go_infra_link/backend/internal/domain/facility/fielddevice"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+const namingPreviewPageLimit = 500
+
+// NamingSchemeDependencies lists the repositories a NamingSchemeService reads.
+// Everything except Schemes is only needed for previews.
+type NamingSchemeDependencies struct {
+	Schemes         domainFacility.NamingSchemeRepository
+	Buildings       domainFacility.BuildingRepository
+	ControlCabinets domainFacility.ControlCabinetRepository
+	SPSControllers  domainFacility.SPSControllerRepository
+	FieldDevices    domainFieldDevice.FieldDeviceStore
+	BacnetObjects   domainObjectData.BacnetObjectStore
+}
+
+// NamingSchemeService manages the naming schemes of the library and of
+// projects and decides which scheme names a controller or an export. A
+// project uses its default scheme, falling back to the library default and
+// finally to the built-in scheme.
+type NamingSchemeService struct {
+	baseService[domainFacility.NamingScheme]
+	deps NamingSchemeDependencies
+	tx   txCoordinator
+}
+
+func NewNamingSchemeService(deps NamingSchemeDependencies) *NamingSchemeService {
+	return &NamingSchemeService{baseService: newBase(deps.Schemes, 50), deps: deps}
+}
+
+func (s *NamingSchemeService) bindTransactions(tx txCoordinator) {
+	s.tx = tx
+}
+
+func (s *NamingSchemeService) transaction() facilityTx[*NamingSchemeService] {
+	return newFacilityTx(s.tx, s, func(services *Services) *NamingSchemeService {
+		return services.NamingScheme
+	})
+}
+
+func (s *NamingSchemeService) Create(ctx context.Context, scheme *domainFacility.NamingScheme) error {
+	if err := s.Validate(scheme); err != nil {
+		return err
+	}
+	return s.transaction().run(ctx, func(txCtx context.Context, txService *NamingSchemeService) error {
+		if err := txService.deps.Schemes.Create(txCtx, scheme); err != nil {
+			return err
+		}
+		return txService.claimDefault(txCtx, scheme)
+	})
+}
+
+func (s *NamingSchemeService) Update(ctx context.Context, scheme *domainFacility.NamingScheme) error {
+	if err := s.Validate(scheme); err != nil {
+		return err
+	}
+	return s.transaction().run(ctx, func(txCtx context.Context, txService *NamingSchemeService) error {
+		if err := txService.deps.Schemes.Update(txCtx, scheme); err != nil {
+			return err
+		}
+		return txService.claimDefault(txCtx, scheme)
+	})
+}
+
+// Validate normalizes a scheme and checks its patterns.
+func (s *NamingSchemeService) Validate(scheme *domainFacility.NamingScheme) error {
+	scheme.Name = strings.TrimSpace(scheme.Name)
+	scheme.Client = strings.TrimSpace(scheme.Client)
+	scheme.ControllerName.Pattern = strings.TrimSpace(scheme.ControllerName.Pattern)
+	scheme.ObjectName.Pattern = strings.TrimSpace(scheme.ObjectName.Pattern)
+	return scheme.Validate()
+}
+
+// claimDefault keeps a single default scheme per scope.
+func (s *NamingSchemeService) claimDefault(ctx context.Context, scheme *domainFacility.NamingScheme) error {
+	if !scheme.IsDefault {
+		return nil
+	}
+	return s.deps.Schemes.ClearDefault(ctx, scheme.ProjectID, scheme.ID)
+}
+
+func (s *NamingSchemeService) ListSchemes(ctx context.Context, filter domainFacility.NamingSchemeFilter) ([]domainFacility.NamingScheme, error) {
+	return s.deps.Schemes.List(ctx, filter)
+}
+
+// Resolve returns the scheme used by a project, or by global data when
+// projectID is nil.
+func (s *NamingSchemeService) Resolve(ctx context.Context, projectID *uuid.UUID) (domainFacility.NamingScheme, error) {
+	if s == nil || s.deps.Schemes == nil {
+		return domainFacility.DefaultNamingScheme(), nil
+	}
+	if projectID != nil {
+		scheme, err := s.deps.Schemes.GetDefault(ctx, projectID)
+		if err == nil {
+			return *scheme, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return domainFacility.NamingScheme{}, err
+		}
+	}
+	scheme, err := s.deps.Schemes.GetDefault(ctx, nil)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domainFacility.DefaultNamingScheme(), nil
+	}
+	if err != nil {
+		return domainFacility.NamingScheme{}, err
+	}
+	return *scheme, nil
+}
+
+// ResolveForProjects returns the scheme of the only project in projectIDs;
+// exports spanning several projects or none use the library scheme.
+func (s *NamingSchemeService) ResolveForProjects(ctx context.Context, projectIDs []uuid.UUID) (domainFacility.NamingScheme, error) {
+	if len(projectIDs) == 1 {
+		return s.Resolve(ctx, &projectIDs[0])
+	}
+	return s.Resolve(ctx, nil)
+}
+
+// ResolveForControlCabinet returns the scheme of the projects a cabinet is
+// linked to. Cabinets whose projects disagree use the library scheme.
+func (s *NamingSchemeService) ResolveForControlCabinet(ctx context.Context, controlCabinetID uuid.UUID) (domainFacility.NamingScheme, error) {
+	if s == nil || s.deps.Schemes == nil {
+		return domainFacility.DefaultNamingScheme(), nil
+	}
+	schemes, err := s.deps.Schemes.ListDefaultsForControlCabinet(ctx, controlCabinetID)
+	if err != nil {
+		return domainFacility.NamingScheme{}, err
+	}
+	if len(schemes) > 0 && sameNamingPatterns(schemes) {
+		return schemes[0], nil
+	}
+	return s.Resolve(ctx, nil)
+}
+
+func sameNamingPatterns(schemes []domainFacility.NamingScheme) bool {
+	for _, scheme := range schemes[1:] {
+		if scheme.ControllerName != schemes[0].ControllerName || scheme.ObjectName != schemes[0].ObjectName {
+			return false
+		}
+	}
+	return true
+}
+
+// Preview renders the names scheme generates for one SPS controller and
+// reports names that are too long or not unique within the controller.
+func (s *NamingSchemeService) Preview(ctx context.Context, scheme domainFacility.NamingScheme, spsControllerID uuid.UUID) (*domainFacility.NamingPreview, error) {
+	if err := s.Validate(&scheme); err != nil {
+		return nil, err
+	}
+	controller, err := domain.GetByID(ctx, s.deps.SPSControllers, spsControllerID)
+	if err != nil {
+		return nil, err
+	}
+	cabinet, err := domain.GetByID(ctx, s.deps.ControlCabinets, controller.ControlCabinetID)
+	if err != nil {
+		return nil, err
+	}
+	var building *domainFacility.Building
+	if cabinet.BuildingID != uuid.Nil {
+		if building, err = domain.GetByID(ctx, s.deps.Buildings, cabinet.BuildingID); err != nil {
+			return nil, err
+		}
+	}
+
+	preview := &domainFacility.NamingPreview{
+		Scheme: scheme, SPSControllerID: controller.ID,
+		Objects: []domainFacility.NamingPreviewObject{}, Issues: []domainFacility.NamingIssue{},
+	}
+	controllerValues := domainFacility.ControllerNamingValues(building, cabinet, controller.GADevice)
+	name, ok := scheme.ControllerName.Render(controllerValues)
+	switch {
+	case !ok:
+		preview.Issues = append(preview.Issues, domainFacility.NamingIssue{Kind: domainFacility.NamingIssueUnresolved})
+	case !scheme.ControllerName.Fits(name):
+		preview.Issues = append(preview.Issues, domainFacility.NamingIssue{Kind: domainFacility.NamingIssueTooLong, Name: name})
+	}
+	preview.ControllerName = name
+
+	seen := map[string]struct{}{}
+	err = s.eachControllerObject(ctx, controller.ID, func(device domainFacility.FieldDevice, object domainFacility.BacnetObject) {
+		objectID := object.ID
+		name, ok := scheme.ObjectName.Render(domainFacility.ObjectNamingValues(controllerValues, device, object.SoftwareAddress()))
+		if !ok {
+			preview.Issues = append(preview.Issues, domainFacility.NamingIssue{Kind: domainFacility.NamingIssueUnresolved, ObjectID: &objectID})
+			return
+		}
+		preview.Objects = append(preview.Objects, domainFacility.NamingPreviewObject{BacnetObjectID: object.ID, FieldDeviceID: device.ID, Name: name})
+		if !scheme.ObjectName.Fits(name) {
+			preview.Issues = append(preview.Issues, domainFacility.NamingIssue{Kind: domainFacility.NamingIssueTooLong, Name: name, ObjectID: &objectID})
+		}
+		if _, duplicate := seen[name]; duplicate {
+			preview.Issues = append(preview.Issues, domainFacility.NamingIssue{Kind: domainFacility.NamingIssueDuplicate, Name: name, ObjectID: &objectID})
+		}
+		seen[name] = struct{}{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+func (s *NamingSchemeService) eachControllerObject(ctx context.Context, controllerID uuid.UUID, visit func(domainFacility.FieldDevice, domainFacility.BacnetObject)) error {
+	for page := 1; ; page++ {
+		result, err := s.deps.FieldDevices.GetPaginatedListWithFilters(ctx,
+			domain.PaginationParams{Page: page, Limit: namingPreviewPageLimit},
+			domainFacility.FieldDeviceFilterParams{SPSControllerID: &controllerID},
+		)
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, len(result.Items))
+		devices := make(map[uuid.UUID]domainFacility.FieldDevice, len(result.Items))
+		for index, device := range result.Items {
+			ids[index] = device.ID
+			devices[device.ID] = device
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		objects, err := s.deps.BacnetObjects.GetByFieldDeviceIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, object := range objects {
+			if object == nil || object.FieldDeviceID == nil {
+				continue
+			}
+			visit(devices[*object.FieldDeviceID], *object)
+		}
+		if page >= result.TotalPages || len(result.Items) == 0 {
+			return nil
+		}
+	}
+}
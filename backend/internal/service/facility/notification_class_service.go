@@ -10,6 +10,7 @@ import (
 type NotificationClassService struct {
 	baseService[domainFacility.NotificationClass]
 	deleteGuard bacnetReferenceDeleteGuard
+	tx          txCoordinator
 }
 
 func NewNotificationClassService(repo domainFacility.NotificationClassRepository, usageRepos ...domainFacility.BacnetReferenceUsageRepository) *NotificationClassService {
@@ -19,6 +20,24 @@ func NewNotificationClassService(repo domainFacility.NotificationClassRepository
 	}
 }
 
+func (s *NotificationClassService) bindTransactions(tx txCoordinator) {
+	s.tx = tx
+}
+
+func (s *NotificationClassService) transaction() facilityTx[*NotificationClassService] {
+	return newFacilityTx(s.tx, s, func(services *Services) *NotificationClassService {
+		return services.NotificationClass
+	})
+}
+
+// SaveCatalog writes one catalog import in a single transaction, so a
+// version conflict on any entry leaves the catalog untouched.
+func (s *NotificationClassService) SaveCatalog(ctx context.Context, create, update []domainFacility.NotificationClass) error {
+	return s.transaction().run(ctx, func(txCtx context.Context, txService *NotificationClassService) error {
+		return txService.saveAll(txCtx, create, update)
+	})
+}
+
 func (s *NotificationClassService) Create(ctx context.Context, nc *domainFacility.NotificationClass) error {
 	return s.repo.Create(ctx, nc)
 }
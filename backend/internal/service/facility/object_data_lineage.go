@@ -0,0 +1,235 @@
+package facility
+
+import (
+	"context"
+	"errors"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+// ErrObjectDataPromoteConflict is returned when the origin of a fork changed
+// the same templates as the fork since it was taken.
+var ErrObjectDataPromoteConflict = errors.New("object data fork conflicts with its origin")
+
+// ForkToProject copies a global ObjectData into a project and records the
+// origin revision the copy is based on.
+func (s *ObjectDataService) ForkToProject(ctx context.Context, projectID, objectDataID uuid.UUID) (*domainFacility.ObjectData, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *ObjectDataService) (*domainFacility.ObjectData, error) {
+		origin, err := domain.GetByID(txCtx, txService.extRepo, objectDataID)
+		if err != nil {
+			return nil, err
+		}
+		if origin.ProjectID != nil {
+			return nil, domain.NewValidationError().Add("objectdata.project_id", "only global object data can be forked")
+		}
+		revision, err := txService.revisions().freeze(txCtx, origin.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := txService.template().ensureDescriptionUnique(txCtx, &domainFacility.ObjectData{Description: origin.Description, ProjectID: &projectID}, nil); err != nil {
+			return nil, err
+		}
+		apparatIDs, err := txService.GetApparatIDs(txCtx, origin.ID)
+		if err != nil {
+			return nil, err
+		}
+		apparats, err := txService.template().loadApparats(txCtx, apparatIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		fork := &domainFacility.ObjectData{
+			Description: origin.Description, Version: origin.Version, IsActive: true,
+			ProjectID: &projectID, Apparats: apparats,
+			ForkedFromID: &origin.ID, ForkedFromRevision: &revision.Revision,
+		}
+		if err := txService.extRepo.Create(txCtx, fork); err != nil {
+			return nil, err
+		}
+		if err := txService.replaceTemplateCopies(txCtx, fork.ID, revision.Templates); err != nil {
+			return nil, err
+		}
+		return domain.GetByID(txCtx, txService.extRepo, fork.ID)
+	})
+}
+
+// ForkDiff compares a project fork with its origin: what the fork changed,
+// what the origin changed since the fork was taken, and where both collide.
+func (s *ObjectDataService) ForkDiff(ctx context.Context, projectID, objectDataID uuid.UUID) (*domainObjectData.TemplateThreeWayDiff, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *ObjectDataService) (*domainObjectData.TemplateThreeWayDiff, error) {
+		fork, err := txService.projectObjectData(txCtx, projectID, objectDataID)
+		if err != nil {
+			return nil, err
+		}
+		if fork.ForkedFromID == nil || fork.ForkedFromRevision == nil {
+			return nil, errObjectDataWithoutOrigin()
+		}
+		return txService.forkDiff(txCtx, fork)
+	})
+}
+
+// PromoteToGlobal publishes a project template to the global library. A fork
+// merges its changes into its origin and fails with
+// ErrObjectDataPromoteConflict while the origin changed the same fields; a
+// template without origin is copied as a new global template. Afterwards the
+// project template mirrors the global one and is based on its new revision.
+func (s *ObjectDataService) PromoteToGlobal(ctx context.Context, projectID, objectDataID uuid.UUID, baseVersion uint64) (*domainFacility.ObjectData, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *ObjectDataService) (*domainFacility.ObjectData, error) {
+		fork, err := txService.projectObjectData(txCtx, projectID, objectDataID)
+		if err != nil {
+			return nil, err
+		}
+		if baseVersion != 0 && fork.Base.Version != baseVersion {
+			return nil, domain.ErrConflict
+		}
+		if !txService.revisions().available() {
+			return nil, ErrObjectDataRevisionsUnavailable
+		}
+
+		var origin *domainFacility.ObjectData
+		if fork.ForkedFromID == nil {
+			origin, err = txService.createPromotedOrigin(txCtx, fork)
+		} else {
+			origin, err = txService.mergeIntoOrigin(txCtx, fork)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		revision, err := txService.revisions().freeze(txCtx, origin.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := txService.replaceTemplateCopies(txCtx, fork.ID, revision.Templates); err != nil {
+			return nil, err
+		}
+		fork.Apparats = origin.Apparats
+		fork.ForkedFromID, fork.ForkedFromRevision = &origin.ID, &revision.Revision
+		if err := txService.extRepo.Update(txCtx, fork); err != nil {
+			return nil, err
+		}
+		return origin, nil
+	})
+}
+
+func (s *ObjectDataService) createPromotedOrigin(ctx context.Context, fork *domainFacility.ObjectData) (*domainFacility.ObjectData, error) {
+	origin := &domainFacility.ObjectData{
+		Description: fork.Description, Version: fork.Version, IsActive: true, Apparats: fork.Apparats,
+	}
+	if err := s.template().ensureDescriptionUnique(ctx, origin, nil); err != nil {
+		return nil, err
+	}
+	templates, err := s.templateStore.ListByObjectDataID(ctx, fork.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.extRepo.Create(ctx, origin); err != nil {
+		return nil, err
+	}
+	if err := s.replaceTemplateCopies(ctx, origin.ID, templates); err != nil {
+		return nil, err
+	}
+	return domain.GetByID(ctx, s.extRepo, origin.ID)
+}
+
+func (s *ObjectDataService) mergeIntoOrigin(ctx context.Context, fork *domainFacility.ObjectData) (*domainFacility.ObjectData, error) {
+	origin, err := domain.GetByID(ctx, s.extRepo, *fork.ForkedFromID)
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.revisions().get(ctx, origin.ID, *fork.ForkedFromRevision)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := s.templateStore.ListByObjectDataID(ctx, origin.ID)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := s.templateStore.ListByObjectDataID(ctx, fork.ID)
+	if err != nil {
+		return nil, err
+	}
+	merged, conflicts := domainObjectData.MergeTemplates(base.Templates, ours, theirs)
+	if len(conflicts) > 0 {
+		return nil, ErrObjectDataPromoteConflict
+	}
+
+	// Templates only the fork added still carry fork IDs and need fresh ones.
+	ids := make(map[uuid.UUID]uuid.UUID, len(merged))
+	for _, template := range merged {
+		ids[template.ID] = template.ID
+		if template.ObjectDataID == fork.ID {
+			ids[template.ID] = uuid.New()
+		}
+	}
+	for i := range merged {
+		merged[i] = copyBacnetTemplate(merged[i], origin.ID, ids)
+	}
+	if err := s.templateStore.Replace(ctx, origin.ID, merged); err != nil {
+		return nil, err
+	}
+
+	origin.Description, origin.Version, origin.Apparats = fork.Description, fork.Version, fork.Apparats
+	if err := s.template().ensureDescriptionUnique(ctx, origin, &origin.ID); err != nil {
+		return nil, err
+	}
+	if err := s.extRepo.Update(ctx, origin); err != nil {
+		return nil, err
+	}
+	return domain.GetByID(ctx, s.extRepo, origin.ID)
+}
+
+func (s *ObjectDataService) forkDiff(ctx context.Context, fork *domainFacility.ObjectData) (*domainObjectData.TemplateThreeWayDiff, error) {
+	base, err := s.revisions().get(ctx, *fork.ForkedFromID, *fork.ForkedFromRevision)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := s.revisions().current(ctx, *fork.ForkedFromID)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := s.templateStore.ListByObjectDataID(ctx, fork.ID)
+	if err != nil {
+		return nil, err
+	}
+	diff := domainObjectData.DiffTemplatesThreeWay(base.Templates, ours, theirs.Templates)
+	diff.ObjectDataID, diff.OriginID = fork.ID, *fork.ForkedFromID
+	diff.BaseRevision, diff.OriginRevision = base.Revision, theirs.Revision
+	diff.Ours.ObjectDataID, diff.Ours.FromRevision = fork.ID, base.Revision
+	diff.Theirs.ObjectDataID, diff.Theirs.FromRevision, diff.Theirs.ToRevision = *fork.ForkedFromID, base.Revision, theirs.Revision
+	return &diff, nil
+}
+
+// projectObjectData loads an ObjectData owned by the project; templates of
+// other scopes are reported as not found.
+func (s *ObjectDataService) projectObjectData(ctx context.Context, projectID, objectDataID uuid.UUID) (*domainFacility.ObjectData, error) {
+	objectData, err := domain.GetByID(ctx, s.extRepo, objectDataID)
+	if err != nil {
+		return nil, err
+	}
+	if objectData.ProjectID == nil || *objectData.ProjectID != projectID {
+		return nil, domain.ErrNotFound
+	}
+	return objectData, nil
+}
+
+// replaceTemplateCopies replaces the templates of target with copies of
+// templates under fresh IDs.
+func (s *ObjectDataService) replaceTemplateCopies(ctx context.Context, targetID uuid.UUID, templates []domainObjectData.BacnetObjectTemplate) error {
+	ids := make(map[uuid.UUID]uuid.UUID, len(templates))
+	for _, template := range templates {
+		ids[template.ID] = uuid.New()
+	}
+	copies := make([]domainObjectData.BacnetObjectTemplate, len(templates))
+	for i := range templates {
+		copies[i] = copyBacnetTemplate(templates[i], targetID, ids)
+	}
+	return s.templateStore.Replace(ctx, targetID, copies)
+}
+
+func errObjectDataWithoutOrigin() error {
+	return domain.NewValidationError().Add("objectdata.forked_from_id", "object data was not forked from a global template")
+}
@@ -0,0 +1,170 @@
+package facility
+
+import (
+	"context"
+	"errors"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+var ErrObjectDataRevisionsUnavailable = errors.New("object data revisions unavailable")
+
+// objectDataRevisions tracks frozen template revisions. Templates can be
+// written through several services, so a new revision is only recorded when
+// a write needs one, such as building or upgrading a field device, and the
+// live templates differ from the latest revision. Reads never freeze.
+type objectDataRevisions struct {
+	objectDataRepo domainObjectData.ObjectDataStore
+	templateStore  domainObjectData.BacnetObjectTemplateStore
+	store          domainObjectData.TemplateRevisionStore
+}
+
+func (r objectDataRevisions) available() bool {
+	return r.objectDataRepo != nil && r.templateStore != nil && r.store != nil
+}
+
+// current returns the latest revision while the live templates still match
+// it. Otherwise it returns the live templates as an unsaved revision 0.
+func (r objectDataRevisions) current(ctx context.Context, objectDataID uuid.UUID) (*domainObjectData.TemplateRevision, error) {
+	revision, _, err := r.pending(ctx, objectDataID)
+	return revision, err
+}
+
+// freeze stores the live templates as the next revision unless the latest
+// revision already matches them.
+func (r objectDataRevisions) freeze(ctx context.Context, objectDataID uuid.UUID) (*domainObjectData.TemplateRevision, error) {
+	revision, next, err := r.pending(ctx, objectDataID)
+	if err != nil || revision.Revision != 0 {
+		return revision, err
+	}
+	revision.Revision = next
+	if err := r.store.Create(ctx, revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// pending compares the live templates with the latest revision and returns
+// either that revision or an unsaved one with the number it would get.
+func (r objectDataRevisions) pending(ctx context.Context, objectDataID uuid.UUID) (*domainObjectData.TemplateRevision, uint64, error) {
+	if !r.available() {
+		return nil, 0, ErrObjectDataRevisionsUnavailable
+	}
+	objectData, err := domain.GetByID(ctx, r.objectDataRepo, objectDataID)
+	if err != nil {
+		return nil, 0, err
+	}
+	templates, err := r.templateStore.ListByObjectDataID(ctx, objectDataID)
+	if err != nil {
+		return nil, 0, err
+	}
+	fingerprint := domainObjectData.TemplateFingerprint(templates)
+
+	next := uint64(1)
+	latest, err := r.store.Latest(ctx, objectDataID)
+	switch {
+	case err == nil && latest.Fingerprint == fingerprint:
+		return latest, latest.Revision, nil
+	case err == nil:
+		next = latest.Revision + 1
+	case !errors.Is(err, domain.ErrNotFound):
+		return nil, 0, err
+	}
+	return &domainObjectData.TemplateRevision{
+		ObjectDataID: objectDataID,
+		Label:        objectData.Version,
+		Fingerprint:  fingerprint,
+		Templates:    templates,
+	}, next, nil
+}
+
+// get loads a stored revision; revision 0 selects the current templates
+// without freezing them.
+func (r objectDataRevisions) get(ctx context.Context, objectDataID uuid.UUID, revision uint64) (*domainObjectData.TemplateRevision, error) {
+	if revision == 0 {
+		return r.current(ctx, objectDataID)
+	}
+	if !r.available() {
+		return nil, ErrObjectDataRevisionsUnavailable
+	}
+	return r.store.Get(ctx, objectDataID, revision)
+}
+
+func (r objectDataRevisions) diff(ctx context.Context, objectDataID uuid.UUID, from, to uint64) (*domainObjectData.TemplateDiff, error) {
+	target, err := r.get(ctx, objectDataID, to)
+	if err != nil {
+		return nil, err
+	}
+	if from == 0 {
+		return nil, domain.NewValidationError().Add("objectdata.revision.from", "from revision is required")
+	}
+	base, err := r.get(ctx, objectDataID, from)
+	if err != nil {
+		return nil, err
+	}
+	diff := domainObjectData.DiffTemplates(base.Templates, target.Templates)
+	diff.ObjectDataID, diff.FromRevision, diff.ToRevision = objectDataID, base.Revision, target.Revision
+	return &diff, nil
+}
+
+// bindTemplateRevisions enables the revision history of ObjectData templates
+// and the upgrade of field devices built from them.
+func (s *ObjectDataService) bindTemplateRevisions(store domainObjectData.TemplateRevisionStore) {
+	s.revisionStore = store
+}
+
+func (s *ObjectDataService) revisions() objectDataRevisions {
+	return objectDataRevisions{objectDataRepo: s.extRepo, templateStore: s.templateStore, store: s.revisionStore}
+}
+
+// ListRevisions returns the stored revisions of an ObjectData newest first.
+// Template changes since the latest revision are not listed until a write
+// freezes them.
+func (s *ObjectDataService) ListRevisions(ctx context.Context, id uuid.UUID) ([]domainObjectData.TemplateRevision, error) {
+	if !s.revisions().available() {
+		return nil, ErrObjectDataRevisionsUnavailable
+	}
+	if _, err := domain.GetByID(ctx, s.extRepo, id); err != nil {
+		return nil, err
+	}
+	return s.revisionStore.List(ctx, id)
+}
+
+// DiffRevisions compares two revisions; to 0 compares with the current
+// templates, reported as revision 0 while they are not frozen.
+func (s *ObjectDataService) DiffRevisions(ctx context.Context, id uuid.UUID, from, to uint64) (*domainObjectData.TemplateDiff, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *ObjectDataService) (*domainObjectData.TemplateDiff, error) {
+		return txService.revisions().diff(txCtx, id, from, to)
+	})
+}
+
+// PrepareUpgrade freezes the current revision and lists the field devices
+// built from an older one. A non-empty fieldDeviceIDs narrows the selection.
+func (s *ObjectDataService) PrepareUpgrade(ctx context.Context, id uuid.UUID, fieldDeviceIDs []uuid.UUID) (*ObjectDataUpgradePlan, error) {
+	return runWithFacilityTxResult(ctx, s.transaction(), func(txCtx context.Context, txService *ObjectDataService) (*ObjectDataUpgradePlan, error) {
+		target, err := txService.revisions().freeze(txCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		devices, err := txService.revisionStore.ListFieldDevices(txCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		selected := make(map[uuid.UUID]struct{}, len(fieldDeviceIDs))
+		for _, fieldDeviceID := range fieldDeviceIDs {
+			selected[fieldDeviceID] = struct{}{}
+		}
+		plan := &ObjectDataUpgradePlan{ObjectDataID: id, TargetRevision: target.Revision}
+		for _, device := range devices {
+			if _, ok := selected[device.FieldDeviceID]; len(selected) > 0 && !ok {
+				continue
+			}
+			if device.Revision != target.Revision {
+				plan.FieldDevices = append(plan.FieldDevices, device)
+			}
+		}
+		return plan, nil
+	})
+}
@@ -18,6 +18,8 @@ type ObjectDataService struct {
 	alarmTypeRepo       domainFacility.AlarmTypeRepository
 	deleteGuard         bacnetReferenceDeleteGuard
 	tx                  txCoordinator
+
+	revisionStore domainObjectData.TemplateRevisionStore
 }
 
 func NewObjectDataService(
@@ -208,7 +208,6 @@ func (m objectDataTemplate) resolveAlarmBinding(ctx context.Context, bacnetObjec
 		}
 
 		bacnetObject.AlarmTypeID = defs[0].AlarmTypeID
-		bacnetObject.AlarmDefinitionID = nil
 		if _, err := domain.GetByID(ctx, m.alarmTypeRepo, *bacnetObject.AlarmTypeID); err != nil {
 			return err
 		}
@@ -250,6 +249,7 @@ func bacnetObjectToTemplate(objectDataID uuid.UUID, item domainFacility.BacnetOb
 		HardwareType: item.HardwareType, HardwareQuantity: item.HardwareQuantity,
 		SoftwareReferenceID: item.SoftwareReferenceID, StateTextID: item.StateTextID,
 		NotificationClassID: item.NotificationClassID, AlarmTypeID: item.AlarmTypeID,
+		AlarmDefinitionID: item.AlarmDefinitionID,
 	}
 	template.AlarmValues = make([]domainObjectData.BacnetObjectTemplateAlarmValue, len(item.AlarmValues))
 	for i := range item.AlarmValues {
@@ -273,6 +273,7 @@ func templateToBacnetObject(template domainObjectData.BacnetObjectTemplate) *dom
 		HardwareType: template.HardwareType, HardwareQuantity: template.HardwareQuantity,
 		SoftwareReferenceID: template.SoftwareReferenceID, StateTextID: template.StateTextID,
 		NotificationClassID: template.NotificationClassID, AlarmTypeID: template.AlarmTypeID,
+		AlarmDefinitionID: template.AlarmDefinitionID,
 	}
 	item.AlarmValues = make([]domainFacility.BacnetObjectAlarmValue, len(template.AlarmValues))
 	for i := range template.AlarmValues {
@@ -0,0 +1,55 @@
+package facility
+
+import (
+	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
+	"github.com/google/uuid"
+)
+
+const FacilityJobTaskUpgradeObjectDataDevices = "objectdata.field_devices.upgrade.v1"
+
+type ObjectDataUpgradeTaskPayload struct {
+	ObjectDataID   uuid.UUID   `json:"object_data_id"`
+	FieldDeviceIDs []uuid.UUID `json:"field_device_ids,omitempty"`
+}
+
+// ObjectDataUpgradePlan is the target revision and the field devices that
+// are still on an older one.
+type ObjectDataUpgradePlan struct {
+	ObjectDataID   uuid.UUID
+	TargetRevision uint64
+	FieldDevices   []domainObjectData.TemplateFieldDevice
+}
+
+// ObjectDataUpgradeStep is the persisted input of one field device of the
+// job. Every device moves to the revision frozen when the job started.
+type ObjectDataUpgradeStep struct {
+	FieldDeviceID  uuid.UUID `json:"field_device_id"`
+	BaseVersion    uint64    `json:"base_version"`
+	TargetRevision uint64    `json:"target_revision"`
+}
+
+type ObjectDataUpgradeJobResult struct {
+	TargetRevision uint64                           `json:"target_revision"`
+	TotalCount     int                              `json:"total_count"`
+	SuccessCount   int                              `json:"success_count"`
+	FailureCount   int                              `json:"failure_count"`
+	ConflictCount  int                              `json:"conflict_count"`
+	Results        []ObjectDataUpgradeJobResultItem `json:"results"`
+}
+
+// ObjectDataUpgradeJobResultItem reports one field device. KeptOverrides
+// counts template changes skipped because the device had overridden them.
+type ObjectDataUpgradeJobResultItem struct {
+	ID            uuid.UUID         `json:"id"`
+	Success       bool              `json:"success"`
+	Version       uint64            `json:"version,omitempty"`
+	FromRevision  uint64            `json:"from_revision,omitempty"`
+	ToRevision    uint64            `json:"to_revision,omitempty"`
+	Added         int               `json:"added,omitempty"`
+	Updated       int               `json:"updated,omitempty"`
+	Removed       int               `json:"removed,omitempty"`
+	KeptOverrides int               `json:"kept_overrides,omitempty"`
+	Conflict      bool              `json:"conflict,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	Fields        map[string]string `json:"fields,omitempty"`
+}
@@ -26,6 +26,12 @@ type projectFacilityCopy struct {
 	objectDataRepo          domainObjectData.ObjectDataStore
 	alarmTypeRepo           domainFacility.AlarmTypeRepository
 	bacnetAlarmValueRepo    domainFacility.BacnetObjectAlarmValueRepository
+	alarmOverrideRepo       domainFacility.AlarmDefinitionFieldOverrideRepository
+	ipam                    *IPAMService
+	naming                  *NamingSchemeService
+	// projectID is set for copies made for a project, so controller
+	// addresses come from the project's pools first.
+	projectID *uuid.UUID
 }
 
 type spsControllerBulkCreator interface {
@@ -51,6 +57,7 @@ func (s *FieldDeviceService) projectFacilityCopy() projectFacilityCopy {
 		objectDataRepo:          s.objectDataRepo,
 		alarmTypeRepo:           s.alarmTypeRepo,
 		bacnetAlarmValueRepo:    s.bacnetAlarmValueRepo,
+		alarmOverrideRepo:       s.alarmOverrideRepo,
 	}
 }
 
@@ -128,8 +135,12 @@ func (c projectFacilityCopy) copySPSControllerByID(ctx context.Context, id uuid.
 		return nil, domain.NewValidationError().Add("spscontroller.ga_device", "no available ga_device for control cabinet")
 	}
 
+	scheme, err := c.naming.ResolveForControlCabinet(ctx, controlCabinet.ID)
+	if err != nil {
+		return nil, err
+	}
 	deviceName := nextGADevice
-	if generatedName, ok := generatedSPSControllerDeviceName(controlCabinet, building, &nextGADevice); ok {
+	if generatedName, ok := generatedSPSControllerDeviceName(scheme, controlCabinet, building, &nextGADevice); ok && scheme.ControllerName.Fits(generatedName) {
 		deviceName = generatedName
 	}
 
@@ -144,6 +155,9 @@ func (c projectFacilityCopy) copySPSControllerByID(ctx context.Context, id uuid.
 		Gateway:           original.Gateway,
 		Vlan:              original.Vlan,
 	}
+	if err := c.ipam.newControllerAddressAllocator(c.projectID).assign(ctx, building.ID, *original, copyEntity); err != nil {
+		return nil, err
+	}
 	reportCopyProgress(ctx, 25, facilityJobStageCopyingRoot)
 	if err := c.spsControllerRepo.Create(ctx, copyEntity); err != nil {
 		return nil, err
@@ -221,6 +235,11 @@ func (c projectFacilityCopy) copySPSControllersForControlCabinet(ctx context.Con
 		return err
 	}
 
+	scheme, err := c.naming.ResolveForControlCabinet(ctx, newControlCabinet.ID)
+	if err != nil {
+		return err
+	}
+
 	originalSPSControllers, err := c.listSPSControllersByControlCabinetID(ctx, originalControlCabinetID)
 	if err != nil {
 		return err
@@ -228,6 +247,7 @@ func (c projectFacilityCopy) copySPSControllersForControlCabinet(ctx context.Con
 
 	spsCopies := make([]*domainFacility.SPSController, 0, len(originalSPSControllers))
 	originalToCopy := make(map[uuid.UUID]*domainFacility.SPSController, len(originalSPSControllers))
+	addresses := c.ipam.newControllerAddressAllocator(c.projectID)
 
 	for _, originalSPS := range originalSPSControllers {
 		gaDevice := ""
@@ -248,7 +268,7 @@ func (c projectFacilityCopy) copySPSControllersForControlCabinet(ctx context.Con
 		}
 
 		deviceName := strings.TrimSpace(originalSPS.DeviceName)
-		if generatedName, ok := generatedSPSControllerDeviceName(newControlCabinet, building, gaDevicePtr); ok {
+		if generatedName, ok := generatedSPSControllerDeviceName(scheme, newControlCabinet, building, gaDevicePtr); ok && scheme.ControllerName.Fits(generatedName) {
 			deviceName = generatedName
 		}
 
@@ -263,6 +283,9 @@ func (c projectFacilityCopy) copySPSControllersForControlCabinet(ctx context.Con
 			Gateway:           originalSPS.Gateway,
 			Vlan:              originalSPS.Vlan,
 		}
+		if err := addresses.assign(ctx, building.ID, originalSPS, spsCopy); err != nil {
+			return err
+		}
 		spsCopies = append(spsCopies, spsCopy)
 		originalToCopy[originalSPS.ID] = spsCopy
 	}
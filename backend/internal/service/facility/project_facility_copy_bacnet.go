@@ -116,6 +116,7 @@ func cloneBacnetObjectForFieldDeviceTemplate(original domainFacility.BacnetObjec
 		StateTextID:         original.StateTextID,
 		NotificationClassID: original.NotificationClassID,
 		AlarmTypeID:         original.AlarmTypeID,
+		AlarmDefinitionID:   original.AlarmDefinitionID,
 	}
 }
 
@@ -169,7 +170,7 @@ func (c projectFacilityCopy) copyObjectDataTemplateForProject(ctx context.Contex
 }
 
 func (c projectFacilityCopy) createAlarmValuesForBacnetObjects(ctx context.Context, bacnetObjects []*domainFacility.BacnetObject) error {
-	values, err := newAlarmValueMaterializer(c.alarmTypeRepo).buildDefaultValues(ctx, bacnetObjects)
+	values, err := newAlarmValueMaterializer(c.alarmTypeRepo, c.alarmOverrideRepo).buildDefaultValues(ctx, bacnetObjects)
 	if err != nil {
 		return err
 	}
@@ -166,7 +166,6 @@ func cloneBacnetObjectForCopy(originalObj domainFacility.BacnetObject, newFieldD
 	clone.StateText = nil
 	clone.NotificationClass = nil
 	clone.AlarmType = nil
-	clone.AlarmDefinitionID = nil
 	return &clone
 }
 
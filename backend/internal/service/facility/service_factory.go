@@ -19,6 +19,8 @@ import (
 // baseService provides GetByID, List, and DeleteByID for services whose
 // repository satisfies domain.Repository[T].
 // Embed this in a concrete service struct to avoid repeating these three methods.
+const catalogPageLimit = 500
+
 type baseService[T any] struct {
 	repo         domain.Repository[T]
 	defaultLimit int
@@ -41,6 +43,38 @@ func (s *baseService[T]) List(ctx context.Context, page, limit int, search strin
 	})
 }
 
+// ListAll pages through the whole table. It is meant for small catalogs that
+// are read or written as a whole.
+func (s *baseService[T]) ListAll(ctx context.Context) ([]T, error) {
+	items := make([]T, 0)
+	for page := 1; ; page++ {
+		result, err := s.repo.GetPaginatedList(ctx, domain.PaginationParams{Page: page, Limit: catalogPageLimit})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+		if page >= result.TotalPages || len(result.Items) == 0 {
+			return items, nil
+		}
+	}
+}
+
+// saveAll creates and updates catalog entries; updates must carry the
+// version they were read at.
+func (s *baseService[T]) saveAll(ctx context.Context, create, update []T) error {
+	for index := range create {
+		if err := s.repo.Create(ctx, &create[index]); err != nil {
+			return err
+		}
+	}
+	for index := range update {
+		if err := s.repo.Update(ctx, &update[index]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *baseService[T]) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	return s.repo.DeleteByIds(ctx, []uuid.UUID{id})
 }
@@ -79,28 +113,35 @@ func extractIDs[T any](items []*T, id func(*T) uuid.UUID) []uuid.UUID {
 
 // Repositories groups facility repositories for service construction.
 type Repositories struct {
-	Buildings                domainFacility.BuildingRepository
-	SystemTypes              domainFacility.SystemTypeRepository
-	SystemParts              domainFacility.SystemPartRepository
-	Specifications           domainFieldDevice.SpecificationStore
-	Apparats                 domainFacility.ApparatRepository
-	ControlCabinets          domainFacility.ControlCabinetRepository
-	FieldDevices             domainFieldDevice.FieldDeviceStore
-	SPSControllers           domainFacility.SPSControllerRepository
-	SPSControllerSystemTypes domainHierarchy.SPSControllerSystemTypeStore
-	BacnetObjects            domainObjectData.BacnetObjectStore
-	ObjectData               domainObjectData.ObjectDataStore
-	BacnetTemplates          domainObjectData.BacnetObjectTemplateStore
-	StateTexts               domainFacility.StateTextRepository
-	NotificationClasses      domainFacility.NotificationClassRepository
-	AlarmDefinitions         domainFacility.AlarmDefinitionRepository
-	Units                    domainFacility.UnitRepository
-	AlarmFields              domainFacility.AlarmFieldRepository
-	AlarmTypes               domainFacility.AlarmTypeRepository
-	AlarmTypeFields          domainFacility.AlarmTypeFieldRepository
-	BacnetObjectAlarmValues  domainFacility.BacnetObjectAlarmValueRepository
-	BacnetReferenceUsages    domainFacility.BacnetReferenceUsageRepository
-	DeleteImpacts            domainFacility.DeleteImpactRepository
+	Buildings                     domainFacility.BuildingRepository
+	SystemTypes                   domainFacility.SystemTypeRepository
+	SystemParts                   domainFacility.SystemPartRepository
+	Specifications                domainFieldDevice.SpecificationStore
+	Apparats                      domainFacility.ApparatRepository
+	ControlCabinets               domainFacility.ControlCabinetRepository
+	FieldDevices                  domainFieldDevice.FieldDeviceStore
+	SPSControllers                domainFacility.SPSControllerRepository
+	SPSControllerSystemTypes      domainHierarchy.SPSControllerSystemTypeStore
+	IPPools                       domainFacility.IPPoolRepository
+	BacnetObjects                 domainObjectData.BacnetObjectStore
+	BacnetInstances               domainObjectData.BacnetInstanceStore
+	ObjectData                    domainObjectData.ObjectDataStore
+	BacnetTemplates               domainObjectData.BacnetObjectTemplateStore
+	StateTexts                    domainFacility.StateTextRepository
+	NotificationClasses           domainFacility.NotificationClassRepository
+	AlarmDefinitions              domainFacility.AlarmDefinitionRepository
+	AlarmDefinitionFieldOverrides domainFacility.AlarmDefinitionFieldOverrideRepository
+	Units                         domainFacility.UnitRepository
+	AlarmFields                   domainFacility.AlarmFieldRepository
+	AlarmTypes                    domainFacility.AlarmTypeRepository
+	AlarmTypeFields               domainFacility.AlarmTypeFieldRepository
+	BacnetObjectAlarmValues       domainFacility.BacnetObjectAlarmValueRepository
+	BacnetReferenceUsages         domainFacility.BacnetReferenceUsageRepository
+	BacnetObjectSelections        domainFacility.BacnetObjectSelectionRepository
+	Translations                  domainFacility.TranslationRepository
+	DeleteImpacts                 domainFacility.DeleteImpactRepository
+	ObjectDataRevisions           domainObjectData.TemplateRevisionStore
+	NamingSchemes                 domainFacility.NamingSchemeRepository
 }
 
 func (r Repositories) FieldDeviceModule() serviceFieldDevice.Repositories {
@@ -112,6 +153,7 @@ func (r Repositories) FieldDeviceModule() serviceFieldDevice.Repositories {
 		SystemParts:              r.SystemParts,
 		Specifications:           r.Specifications,
 		BacnetObjects:            r.BacnetObjects,
+		BacnetInstances:          r.BacnetInstances,
 		ObjectData:               r.ObjectData,
 		AlarmTypes:               r.AlarmTypes,
 		BacnetObjectAlarmValues:  r.BacnetObjectAlarmValues,
@@ -140,6 +182,7 @@ func (r Repositories) HierarchyModule() serviceHierarchy.Repositories {
 		FieldDevices:             r.FieldDevices,
 		Specifications:           r.Specifications,
 		BacnetObjects:            r.BacnetObjects,
+		IPPools:                  r.IPPools,
 	}
 }
 
@@ -156,13 +199,14 @@ func (r Repositories) ReferenceModule() serviceReference.Repositories {
 
 func (r Repositories) AlarmModule() serviceAlarm.Repositories {
 	return serviceAlarm.Repositories{
-		AlarmDefinitions:        r.AlarmDefinitions,
-		Units:                   r.Units,
-		AlarmFields:             r.AlarmFields,
-		AlarmTypes:              r.AlarmTypes,
-		AlarmTypeFields:         r.AlarmTypeFields,
-		BacnetObjectAlarmValues: r.BacnetObjectAlarmValues,
-		BacnetObjects:           r.BacnetObjects,
+		AlarmDefinitions:              r.AlarmDefinitions,
+		AlarmDefinitionFieldOverrides: r.AlarmDefinitionFieldOverrides,
+		Units:                         r.Units,
+		AlarmFields:                   r.AlarmFields,
+		AlarmTypes:                    r.AlarmTypes,
+		AlarmTypeFields:               r.AlarmTypeFields,
+		BacnetObjectAlarmValues:       r.BacnetObjectAlarmValues,
+		BacnetObjects:                 r.BacnetObjects,
 	}
 }
 
@@ -175,11 +219,14 @@ type Services struct {
 	Apparat                 *ApparatService
 	ControlCabinet          *ControlCabinetService
 	FieldDevice             *FieldDeviceService
+	BacnetNumbering         *BacnetNumberingService
 	BacnetObject            *BacnetObjectService
 	SPSController           *SPSControllerService
+	IPAM                    *IPAMService
 	StateText               *StateTextService
 	NotificationClass       *NotificationClassService
 	AlarmDefinition         *AlarmDefinitionService
+	AlarmDefinitionOverride *AlarmDefinitionFieldOverrideService
 	ObjectData              *ObjectDataService
 	SPSControllerSystemType *SPSControllerSystemTypeService
 	AlarmType               *AlarmTypeService
@@ -189,6 +236,9 @@ type Services struct {
 	BacnetAlarmValue        *BacnetAlarmValueService
 	BacnetReferenceUsage    *BacnetReferenceUsageService
 	DeleteImpact            *DeleteImpactService
+	Translation             *TranslationService
+
+	NamingScheme *NamingSchemeService
 }
 
 // NewServices creates facility services using a factory-style constructor.
@@ -215,11 +265,24 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 		hierarchyRepos.BacnetObjects,
 	)
 	hierarchyCopier.bindTransactions(tx)
+	var ipam *IPAMService
+	if hierarchyRepos.IPPools != nil {
+		ipam = NewIPAMService(hierarchyRepos.IPPools, hierarchyRepos.ControlCabinets)
+		hierarchyCopier.bindIPAM(ipam)
+	}
 	controllerNames := NewSPSControllerNameSynchronizer(
 		hierarchyRepos.Buildings,
 		hierarchyRepos.ControlCabinets,
 		hierarchyRepos.SPSControllers,
 	)
+	namingSchemeService := NewNamingSchemeService(NamingSchemeDependencies{
+		Schemes: repos.NamingSchemes, Buildings: hierarchyRepos.Buildings,
+		ControlCabinets: hierarchyRepos.ControlCabinets, SPSControllers: hierarchyRepos.SPSControllers,
+		FieldDevices: fieldDeviceRepos.FieldDevices, BacnetObjects: objectDataRepos.BacnetObjects,
+	})
+	namingSchemeService.bindTransactions(tx)
+	hierarchyCopier.bindNaming(namingSchemeService)
+	controllerNames.bindNaming(namingSchemeService)
 
 	fieldDeviceService := NewFieldDeviceService(
 		fieldDeviceRepos.FieldDevices,
@@ -235,6 +298,19 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 	)
 	fieldDeviceService.bindTransactions(tx)
 	fieldDeviceService.bindChangeRecorder(cfg.ChangeRecorder)
+	fieldDeviceService.bindAlarmDefinitionOverrides(repos.AlarmDefinitionFieldOverrides)
+	fieldDeviceService.bindAlarmDefinitions(objectDataRepos.AlarmDefinitions)
+	fieldDeviceService.bindUnits(repos.Units)
+	fieldDeviceService.bindTemplateRevisions(repos.BacnetTemplates, repos.ObjectDataRevisions)
+	var bacnetNumbering *BacnetNumberingService
+	if fieldDeviceRepos.BacnetInstances != nil {
+		bacnetNumbering = NewBacnetNumberingService(BacnetNumberingDependencies{
+			Instances: fieldDeviceRepos.BacnetInstances, Objects: fieldDeviceRepos.BacnetObjects,
+			FieldDevices: fieldDeviceRepos.FieldDevices, ControllerSystemTypes: fieldDeviceRepos.SPSControllerSystemTypes,
+			SystemTypes: fieldDeviceRepos.SystemTypes,
+		})
+		fieldDeviceService.bindBacnetNumbering(bacnetNumbering)
+	}
 	objectDataService := NewObjectDataService(
 		objectDataRepos.ObjectData,
 		objectDataRepos.BacnetTemplates,
@@ -244,6 +320,7 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 		repos.BacnetReferenceUsages,
 	)
 	objectDataService.bindTransactions(tx)
+	objectDataService.bindTemplateRevisions(repos.ObjectDataRevisions)
 	bacnetObjectService := NewBacnetObjectService(BacnetObjectDependencies{
 		Objects: objectDataRepos.BacnetObjects, FieldDevices: objectDataRepos.FieldDevices,
 		ObjectData: objectDataRepos.ObjectData, Templates: objectDataRepos.BacnetTemplates,
@@ -261,6 +338,7 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 		hierarchyCopier,
 	)
 	spsControllerService.bindTransactions(tx)
+	spsControllerService.bindNaming(namingSchemeService)
 	controlCabinetService := NewControlCabinetService(
 		hierarchyRepos.ControlCabinets,
 		hierarchyRepos.Buildings,
@@ -278,9 +356,14 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 
 	bacnetAlarmValueService := NewBacnetAlarmValueService(BacnetAlarmValueDependencies{
 		Values: alarmRepos.BacnetObjectAlarmValues, AlarmTypes: alarmRepos.AlarmTypes,
+		Overrides: alarmRepos.AlarmDefinitionFieldOverrides, Units: alarmRepos.Units,
 		Objects: alarmRepos.BacnetObjects, Templates: objectDataRepos.BacnetTemplates,
-		Writer: bacnetObjectService,
+		Selection: repos.BacnetObjectSelections, Writer: bacnetObjectService,
 	})
+	stateTextService := NewStateTextService(referenceRepos.StateTexts, repos.BacnetReferenceUsages)
+	stateTextService.bindTransactions(tx)
+	notificationClassService := NewNotificationClassService(referenceRepos.NotificationClasses, repos.BacnetReferenceUsages)
+	notificationClassService.bindTransactions(tx)
 
 	return &Services{
 		HierarchyCopier:   hierarchyCopier,
@@ -290,15 +373,23 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 		Apparat:           NewApparatService(referenceRepos.Apparats, referenceRepos.SystemParts, referenceRepos.ObjectData, repos.BacnetReferenceUsages, repos.DeleteImpacts),
 		ControlCabinet:    controlCabinetService,
 		FieldDevice:       fieldDeviceService,
+		BacnetNumbering:   bacnetNumbering,
 		BacnetObject:      bacnetObjectService,
 		SPSController:     spsControllerService,
-		StateText:         NewStateTextService(referenceRepos.StateTexts, repos.BacnetReferenceUsages),
-		NotificationClass: NewNotificationClassService(referenceRepos.NotificationClasses, repos.BacnetReferenceUsages),
+		IPAM:              ipam,
+		StateText:         stateTextService,
+		NotificationClass: notificationClassService,
 		AlarmDefinition:   NewAlarmDefinitionService(alarmRepos.AlarmDefinitions, repos.BacnetReferenceUsages),
-		ObjectData:        objectDataService,
-		Unit:              NewUnitService(alarmRepos.Units),
-		AlarmField:        NewAlarmFieldService(alarmRepos.AlarmFields),
-		AlarmTypeField:    NewAlarmTypeFieldService(alarmRepos.AlarmTypeFields),
+		AlarmDefinitionOverride: NewAlarmDefinitionFieldOverrideService(
+			alarmRepos.AlarmDefinitionFieldOverrides,
+			alarmRepos.AlarmDefinitions,
+			alarmRepos.AlarmTypes,
+			alarmRepos.Units,
+		),
+		ObjectData:     objectDataService,
+		Unit:           NewUnitService(alarmRepos.Units),
+		AlarmField:     NewAlarmFieldService(alarmRepos.AlarmFields),
+		AlarmTypeField: NewAlarmTypeFieldService(alarmRepos.AlarmTypeFields),
 		SPSControllerSystemType: NewSPSControllerSystemTypeService(
 			hierarchyRepos.SPSControllerSystemTypes,
 			hierarchyCopier,
@@ -307,5 +398,12 @@ func NewServices(repos Repositories, cfgs ...Config) *Services {
 		BacnetAlarmValue:     bacnetAlarmValueService,
 		BacnetReferenceUsage: NewBacnetReferenceUsageService(repos.BacnetReferenceUsages),
 		DeleteImpact:         NewDeleteImpactService(repos.DeleteImpacts),
+		Translation: NewTranslationService(TranslationDependencies{
+			Translations: repos.Translations, StateTexts: referenceRepos.StateTexts,
+			BacnetObjects: objectDataRepos.BacnetObjects, Apparats: referenceRepos.Apparats,
+			SystemParts: referenceRepos.SystemParts,
+		}),
+
+		NamingScheme: namingSchemeService,
 	}
 }
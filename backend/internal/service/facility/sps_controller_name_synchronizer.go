@@ -14,6 +14,8 @@ type SPSControllerNameSynchronizer struct {
 	buildings   domainFacility.BuildingRepository
 	cabinets    domainFacility.ControlCabinetRepository
 	controllers domainFacility.SPSControllerRepository
+
+	naming *NamingSchemeService
 }
 
 const spsControllerNameSyncPageLimit = 500
@@ -28,6 +30,11 @@ func NewSPSControllerNameSynchronizer(
 	}
 }
 
+// bindNaming selects the naming scheme refreshed names follow.
+func (s *SPSControllerNameSynchronizer) bindNaming(naming *NamingSchemeService) {
+	s.naming = naming
+}
+
 func (s *SPSControllerNameSynchronizer) RefreshForBuilding(
 	ctx context.Context,
 	building *domainFacility.Building,
@@ -76,6 +83,10 @@ func (s *SPSControllerNameSynchronizer) refreshForCabinetWithBuilding(
 	cabinet *domainFacility.ControlCabinet,
 	building *domainFacility.Building,
 ) error {
+	scheme, err := s.naming.ResolveForControlCabinet(ctx, cabinet.ID)
+	if err != nil {
+		return err
+	}
 	for page := 1; ; page++ {
 		result, err := s.controllers.GetPaginatedListByControlCabinetID(ctx, cabinet.ID, domain.PaginationParams{
 			Page: page, Limit: spsControllerNameSyncPageLimit,
@@ -86,8 +97,8 @@ func (s *SPSControllerNameSynchronizer) refreshForCabinetWithBuilding(
 
 		for i := range result.Items {
 			controller := &result.Items[i]
-			name, ok := generatedSPSControllerDeviceName(cabinet, building, controller.GADevice)
-			if !ok || controller.DeviceName == name {
+			name, ok := generatedSPSControllerDeviceName(scheme, cabinet, building, controller.GADevice)
+			if !ok || !scheme.ControllerName.Fits(name) || controller.DeviceName == name {
 				continue
 			}
 			controller.DeviceName = name
@@ -1,38 +1,17 @@
 package facility
 
 import (
-	"strings"
-
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 )
 
+// generatedSPSControllerDeviceName renders the controller name of scheme. It
+// reports false when the scheme cannot name the controller, e.g. while the GA
+// device is still missing.
 func generatedSPSControllerDeviceName(
+	scheme domainFacility.NamingScheme,
 	controlCabinet *domainFacility.ControlCabinet,
 	building *domainFacility.Building,
 	gaDevice *string,
 ) (string, bool) {
-	if gaDevice == nil {
-		return "", false
-	}
-
-	ga := strings.ToUpper(strings.TrimSpace(*gaDevice))
-	if ga == "" {
-		return "", false
-	}
-
-	iwsCode := ""
-	if building != nil {
-		iwsCode = strings.TrimSpace(building.IWSCode)
-	}
-
-	cabinetNr := ""
-	if controlCabinet != nil && controlCabinet.ControlCabinetNr != nil {
-		cabinetNr = strings.TrimSpace(*controlCabinet.ControlCabinetNr)
-	}
-
-	if iwsCode == "" || cabinetNr == "" {
-		return ga, true
-	}
-
-	return strings.ToUpper(iwsCode + "_" + cabinetNr + "_" + ga), true
+	return scheme.ControllerName.Render(domainFacility.ControllerNamingValues(building, controlCabinet, gaDevice))
 }
@@ -2,6 +2,7 @@ package facility
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -21,6 +22,8 @@ type SPSControllerService struct {
 	fieldDeviceRepo          domainFieldDevice.FieldDeviceStore
 	hierarchyCopier          *HierarchyCopier
 	tx                       txCoordinator
+
+	naming *NamingSchemeService
 }
 
 func NewSPSControllerService(
@@ -47,6 +50,11 @@ func (s *SPSControllerService) bindTransactions(tx txCoordinator) {
 	s.tx = tx
 }
 
+// bindNaming selects the naming scheme generated device names follow.
+func (s *SPSControllerService) bindNaming(naming *NamingSchemeService) {
+	s.naming = naming
+}
+
 func (s *SPSControllerService) transaction() facilityTx[*SPSControllerService] {
 	return newFacilityTx(s.tx, s, func(services *Services) *SPSControllerService {
 		return services.SPSController
@@ -344,9 +352,19 @@ func (s *SPSControllerService) assignGeneratedDeviceName(ctx context.Context, sp
 		}
 	}
 
-	if deviceName, ok := generatedSPSControllerDeviceName(controlCabinet, building, spsController.GADevice); ok {
-		spsController.DeviceName = deviceName
+	scheme, err := s.naming.ResolveForControlCabinet(ctx, controlCabinet.ID)
+	if err != nil {
+		return err
+	}
+	deviceName, ok := generatedSPSControllerDeviceName(scheme, controlCabinet, building, spsController.GADevice)
+	if !ok {
+		return nil
+	}
+	if !scheme.ControllerName.Fits(deviceName) {
+		return domain.NewValidationError().AddCode("spscontroller.device_name", "too_long",
+			fmt.Sprintf("generated device name exceeds %d characters of naming scheme %q", scheme.ControllerName.MaxLength, scheme.Name))
 	}
+	spsController.DeviceName = deviceName
 	return nil
 }
 
@@ -386,6 +404,9 @@ func (s *SPSControllerService) Validate(ctx context.Context, spsController *doma
 	if err := s.validateRequiredFields(spsController); err != nil {
 		return err
 	}
+	if err := s.validateNetwork(ctx, spsController, excludeID); err != nil {
+		return err
+	}
 	if err := s.ensureControlCabinetExists(ctx, spsController.ControlCabinetID); err != nil {
 		return err
 	}
@@ -395,6 +416,20 @@ func (s *SPSControllerService) Validate(ctx context.Context, spsController *doma
 	return nil
 }
 
+// validateNetwork compares an update with the stored controller, so legacy
+// addressing only has to satisfy the gateway rule once it is edited.
+func (s *SPSControllerService) validateNetwork(ctx context.Context, spsController *domainFacility.SPSController, excludeID *uuid.UUID) error {
+	var previous *domainFacility.SPSController
+	if excludeID != nil {
+		stored, err := domain.GetByID(ctx, s.repo, *excludeID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		previous = stored
+	}
+	return spsController.ValidateNetworkChange(previous)
+}
+
 func (s *SPSControllerService) NextAvailableGADevice(ctx context.Context, controlCabinetID uuid.UUID, excludeID *uuid.UUID) (string, error) {
 	if err := s.ensureControlCabinetExists(ctx, controlCabinetID); err != nil {
 		return "", err
@@ -10,6 +10,7 @@ import (
 type StateTextService struct {
 	baseService[domainFacility.StateText]
 	deleteGuard bacnetReferenceDeleteGuard
+	tx          txCoordinator
 }
 
 func NewStateTextService(repo domainFacility.StateTextRepository, usageRepos ...domainFacility.BacnetReferenceUsageRepository) *StateTextService {
@@ -19,6 +20,24 @@ func NewStateTextService(repo domainFacility.StateTextRepository, usageRepos ...
 	}
 }
 
+func (s *StateTextService) bindTransactions(tx txCoordinator) {
+	s.tx = tx
+}
+
+func (s *StateTextService) transaction() facilityTx[*StateTextService] {
+	return newFacilityTx(s.tx, s, func(services *Services) *StateTextService {
+		return services.StateText
+	})
+}
+
+// SaveCatalog writes one catalog import in a single transaction, so a
+// version conflict on any entry leaves the catalog untouched.
+func (s *StateTextService) SaveCatalog(ctx context.Context, create, update []domainFacility.StateText) error {
+	return s.transaction().run(ctx, func(txCtx context.Context, txService *StateTextService) error {
+		return txService.saveAll(txCtx, create, update)
+	})
+}
+
 func (s *StateTextService) Create(ctx context.Context, stateText *domainFacility.StateText) error {
 	return s.repo.Create(ctx, stateText)
 }
@@ -0,0 +1,115 @@
+package facility
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+var ErrTranslationsUnavailable = errors.New("facility translations are unavailable")
+
+type TranslationDependencies struct {
+	Translations  domainFacility.TranslationRepository
+	StateTexts    domain.Reader[domainFacility.StateText]
+	BacnetObjects domain.Reader[domainFacility.BacnetObject]
+	Apparats      domain.Reader[domainFacility.Apparat]
+	SystemParts   domain.Reader[domainFacility.SystemPart]
+}
+
+// TranslationService keeps the per-locale texts of state texts, BACnet
+// objects, apparats and system parts next to their base texts.
+type TranslationService struct {
+	repo     domainFacility.TranslationRepository
+	entities map[domainFacility.TranslationResource]func(context.Context, uuid.UUID) error
+}
+
+func NewTranslationService(deps TranslationDependencies) *TranslationService {
+	return &TranslationService{
+		repo: deps.Translations,
+		entities: map[domainFacility.TranslationResource]func(context.Context, uuid.UUID) error{
+			domainFacility.TranslationResourceStateText:    entityExists(deps.StateTexts),
+			domainFacility.TranslationResourceBacnetObject: entityExists(deps.BacnetObjects),
+			domainFacility.TranslationResourceApparat:      entityExists(deps.Apparats),
+			domainFacility.TranslationResourceSystemPart:   entityExists(deps.SystemParts),
+		},
+	}
+}
+
+func entityExists[T any](reader domain.Reader[T]) func(context.Context, uuid.UUID) error {
+	return func(ctx context.Context, id uuid.UUID) error {
+		if reader == nil {
+			return ErrTranslationsUnavailable
+		}
+		_, err := domain.GetByID(ctx, reader, id)
+		return err
+	}
+}
+
+// List returns all translations of one entity ordered by locale and field.
+func (s *TranslationService) List(ctx context.Context, resource domainFacility.TranslationResource, entityID uuid.UUID) ([]domainFacility.Translation, error) {
+	if err := s.ensureEntity(ctx, resource, entityID); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListByEntities(ctx, []uuid.UUID{entityID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(items, func(item domainFacility.Translation) bool {
+		return item.Resource != resource
+	}), nil
+}
+
+// Replace sets the translations of one entity in one locale. Fields left
+// out or sent blank fall back to the base text again.
+func (s *TranslationService) Replace(ctx context.Context, resource domainFacility.TranslationResource, entityID uuid.UUID, locale string, texts map[string]string) ([]domainFacility.Translation, error) {
+	if err := s.ensureEntity(ctx, resource, entityID); err != nil {
+		return nil, err
+	}
+	normalized, ok := domainFacility.NormalizeLocale(locale)
+	ve := domain.NewValidationError()
+	if !ok {
+		ve = ve.AddCode("locale", "invalid", "locale must look like fr or fr_CH")
+	}
+	allowed := domainFacility.TranslatableFields(resource)
+	fields := make([]string, 0, len(texts))
+	for field := range texts {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	items := make([]domainFacility.Translation, 0, len(fields))
+	for _, field := range fields {
+		if !slices.Contains(allowed, field) {
+			ve = ve.AddCode("fields."+field, "unknown_field", "field cannot be translated")
+			continue
+		}
+		if text := strings.TrimSpace(texts[field]); text != "" {
+			items = append(items, domainFacility.Translation{
+				Resource: resource, EntityID: entityID, Locale: normalized, Field: field, Text: text,
+			})
+		}
+	}
+	if len(ve.Fields) > 0 {
+		return nil, ve
+	}
+	if err := s.repo.ReplaceLocale(ctx, resource, entityID, normalized, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *TranslationService) ensureEntity(ctx context.Context, resource domainFacility.TranslationResource, entityID uuid.UUID) error {
+	if s == nil || s.repo == nil {
+		return ErrTranslationsUnavailable
+	}
+	exists, ok := s.entities[resource]
+	if !ok {
+		return domain.ErrInvalidArgument
+	}
+	return exists(ctx, entityID)
+}
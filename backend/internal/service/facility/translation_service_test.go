@@ -0,0 +1,87 @@
+package facility
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	"github.com/google/uuid"
+)
+
+type fakeTranslationRepo struct {
+	replaced []domainFacility.Translation
+	calls    int
+}
+
+func (r *fakeTranslationRepo) ListByEntities(context.Context, []uuid.UUID, []string) ([]domainFacility.Translation, error) {
+	return nil, nil
+}
+
+func (r *fakeTranslationRepo) ReplaceLocale(_ context.Context, _ domainFacility.TranslationResource, _ uuid.UUID, _ string, items []domainFacility.Translation) error {
+	r.calls++
+	r.replaced = items
+	return nil
+}
+
+type fakeStateTextReader map[uuid.UUID]*domainFacility.StateText
+
+func (r fakeStateTextReader) GetByIds(_ context.Context, ids []uuid.UUID) ([]*domainFacility.StateText, error) {
+	out := make([]*domainFacility.StateText, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := r[id]; ok {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func TestTranslationServiceReplaceNormalizesLocaleAndDropsBlanks(t *testing.T) {
+	id := uuid.New()
+	repo := &fakeTranslationRepo{}
+	service := NewTranslationService(TranslationDependencies{
+		Translations: repo,
+		StateTexts:   fakeStateTextReader{id: {Base: domain.Base{ID: id}}},
+	})
+
+	items, err := service.Replace(context.Background(), domainFacility.TranslationResourceStateText, id, "fr-ch", map[string]string{
+		"state_text_1": " Arrêt ",
+		"state_text_2": "",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Locale != "fr_CH" || items[0].Field != "state_text_1" || items[0].Text != "Arrêt" {
+		t.Fatalf("items = %+v", items)
+	}
+	if repo.calls != 1 || len(repo.replaced) != 1 {
+		t.Fatalf("replace calls = %d, items = %+v", repo.calls, repo.replaced)
+	}
+}
+
+func TestTranslationServiceReplaceRejectsUnknownFieldsAndEntities(t *testing.T) {
+	id := uuid.New()
+	repo := &fakeTranslationRepo{}
+	service := NewTranslationService(TranslationDependencies{
+		Translations: repo,
+		StateTexts:   fakeStateTextReader{id: {Base: domain.Base{ID: id}}},
+	})
+	ctx := context.Background()
+
+	_, err := service.Replace(ctx, domainFacility.TranslationResourceStateText, id, "french", map[string]string{"name": "Arrêt"})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Fields["locale"] == "" || ve.Fields["fields.name"] == "" {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if _, err := service.Replace(ctx, domainFacility.TranslationResourceStateText, uuid.New(), "fr", nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Replace() unknown entity error = %v", err)
+	}
+	if _, err := service.Replace(ctx, domainFacility.TranslationResourceApparat, id, "fr", nil); !errors.Is(err, ErrTranslationsUnavailable) {
+		t.Fatalf("Replace() without apparat reader error = %v", err)
+	}
+	if repo.calls != 0 {
+		t.Fatalf("replace calls = %d", repo.calls)
+	}
+}
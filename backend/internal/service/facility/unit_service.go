@@ -41,6 +41,13 @@ func validateUnit(unit *domainFacility.Unit) error {
 	if strings.TrimSpace(unit.Name) == "" {
 		ve = ve.Add("name", "required")
 	}
+	unit.Dimension = strings.TrimSpace(unit.Dimension)
+	if _, ok := domainFacility.LookupUnitDimension(unit.Dimension); unit.Dimension != "" && !ok {
+		ve = ve.AddCode("dimension", "unknown_dimension", "dimension is not in the unit dimension catalog")
+	}
+	if unit.Factor <= 0 {
+		ve = ve.AddCode("factor", "positive", "factor must be greater than zero")
+	}
 	if len(ve.Fields) > 0 {
 		return ve
 	}
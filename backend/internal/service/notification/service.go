@@ -10,12 +10,15 @@ import (
 	"fmt"
 	"math/big"
 	"net/mail"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	domain "github.com/besart951/go_infra_link/backend/internal/domain"
 	domainNotification "github.com/besart951/go_infra_link/backend/internal/domain/notification"
 	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	"github.com/google/uuid"
 )
 
@@ -32,6 +35,8 @@ type Service struct {
 	cipher           SecretCipher
 	verificationKey  []byte
 	strategies       map[domainNotification.Provider]EmailStrategy
+
+	templates domainNotification.TemplateCatalog
 }
 
 type Dependencies struct {
@@ -50,6 +55,10 @@ type Dependencies struct {
 type Config struct {
 	VerificationSecret string
 	SystemPublisher    domainNotification.SystemNotificationPublisher
+
+	// Templates provides the catalog texts of notification events. Without
+	// it, notifications carry the event key as title.
+	Templates domainNotification.TemplateCatalog
 }
 
 func New(
@@ -104,6 +113,8 @@ func NewFromDependencies(deps Dependencies, cfg Config) *Service {
 		cipher:           deps.Cipher,
 		verificationKey:  key[:],
 		strategies:       registry,
+
+		templates: cfg.Templates,
 	}
 }
 
@@ -533,14 +544,14 @@ func (s *Service) DispatchEvent(ctx context.Context, input domainNotification.Di
 		return nil
 	}
 
-	title, body := renderNotificationTemplate(input.Locale, eventKey, input.Title, input.Body, input.Metadata)
+	// Dispatch renders the templates once per recipient locale.
 	return s.Dispatch(ctx, domainNotification.DispatchNotificationInput{
 		RecipientIDs: recipientIDs,
 		ActorID:      input.ActorID,
 		Locale:       input.Locale,
 		EventKey:     eventKey,
-		Title:        title,
-		Body:         body,
+		Title:        input.Title,
+		Body:         input.Body,
 		ResourceType: input.ResourceType,
 		ResourceID:   input.ResourceID,
 		Metadata:     input.Metadata,
@@ -555,7 +566,7 @@ func (s *Service) Dispatch(ctx context.Context, input domainNotification.Dispatc
 	if strings.TrimSpace(input.EventKey) == "" {
 		return domain.NewValidationError().Add("event_key", "is required")
 	}
-	title, body := renderNotificationTemplate(input.Locale, input.EventKey, input.Title, input.Body, input.Metadata)
+	title, body := s.renderNotificationTemplate(input.Locale, input.EventKey, input.Title, input.Body, input.Metadata)
 	if strings.TrimSpace(title) == "" {
 		return domain.NewValidationError().Add("title", "is required")
 	}
@@ -570,6 +581,9 @@ func (s *Service) Dispatch(ctx context.Context, input domainNotification.Dispatc
 		usersByID[user.ID] = user
 	}
 
+	type renderedTemplate struct{ title, body string }
+	rendered := map[string]renderedTemplate{input.Locale: {title: title, body: body}}
+
 	emailRecipients := make([]string, 0, len(users))
 	for _, recipientID := range recipientIDs {
 		user := usersByID[recipientID]
@@ -577,6 +591,17 @@ func (s *Service) Dispatch(ctx context.Context, input domainNotification.Dispatc
 			continue
 		}
 
+		// Recipients read the notification in their profile language.
+		locale := input.Locale
+		if strings.TrimSpace(user.Locale) != "" {
+			locale = user.Locale
+		}
+		text, ok := rendered[locale]
+		if !ok {
+			text.title, text.body = s.renderNotificationTemplate(locale, input.EventKey, input.Title, input.Body, input.Metadata)
+			rendered[locale] = text
+		}
+
 		preference, err := s.GetUserPreference(ctx, recipientID)
 		if err != nil {
 			return err
@@ -587,8 +612,8 @@ func (s *Service) Dispatch(ctx context.Context, input domainNotification.Dispatc
 				RecipientID:  recipientID,
 				ActorID:      input.ActorID,
 				EventKey:     strings.TrimSpace(input.EventKey),
-				Title:        title,
-				Body:         body,
+				Title:        text.title,
+				Body:         text.body,
 				ResourceType: strings.TrimSpace(input.ResourceType),
 				ResourceID:   input.ResourceID,
 				Metadata:     input.Metadata,
@@ -606,8 +631,8 @@ func (s *Service) Dispatch(ctx context.Context, input domainNotification.Dispatc
 					RecipientID:    recipientID,
 					RecipientEmail: strings.TrimSpace(preference.NotificationEmail),
 					EventKey:       strings.TrimSpace(input.EventKey),
-					Subject:        title,
-					Body:           body,
+					Subject:        text.title,
+					Body:           text.body,
 					Frequency:      preference.Frequency,
 					ResourceType:   strings.TrimSpace(input.ResourceType),
 					ResourceID:     input.ResourceID,
@@ -839,89 +864,54 @@ type notificationTemplate struct {
 	Body  string
 }
 
-const defaultNotificationLocale = "de_CH"
-
-var localizedNotificationTemplates = map[string]map[string]notificationTemplate{
-	defaultNotificationLocale: {
-		"project.updated": {
-			Title: "Projekt aktualisiert",
-			Body:  "Im Projekt {{project_name}} wurden Änderungen gespeichert.",
-		},
-		"project.deleted": {
-			Title: "Projekt gelöscht",
-			Body:  "Das Projekt {{project_name}} wurde gelöscht.",
-		},
-		"project.user.invited": {
-			Title: "Projektmitglied hinzugefügt",
-			Body:  "Ein Benutzer wurde zum Projekt {{project_name}} hinzugefügt.",
-		},
-		"project.user.removed": {
-			Title: "Projektmitglied entfernt",
-			Body:  "Ein Benutzer wurde aus dem Projekt {{project_name}} entfernt.",
-		},
-		"project.phase.changed": {
-			Title: "Projektphase geändert",
-			Body:  "Die Phase von {{project_name}} wurde von {{old}} auf {{new}} geändert.",
-		},
-		"project.control_cabinet.created": {
-			Title: "Schaltschrank hinzugefügt",
-			Body:  "Im Projekt {{project_name}} wurde ein Schaltschrank hinzugefügt.",
-		},
-		"project.control_cabinet.updated": {
-			Title: "Schaltschrank aktualisiert",
-			Body:  "Im Projekt {{project_name}} wurde ein Schaltschrank aktualisiert.",
-		},
-		"project.control_cabinet.deleted": {
-			Title: "Schaltschrank entfernt",
-			Body:  "Im Projekt {{project_name}} wurde ein Schaltschrank entfernt.",
-		},
-		"project.sps_controller.created": {
-			Title: "SPS-Regler hinzugefügt",
-			Body:  "Im Projekt {{project_name}} wurde ein SPS-Regler hinzugefügt.",
-		},
-		"project.sps_controller.updated": {
-			Title: "SPS-Regler aktualisiert",
-			Body:  "Im Projekt {{project_name}} wurde ein SPS-Regler aktualisiert.",
-		},
-		"project.sps_controller.deleted": {
-			Title: "SPS-Regler entfernt",
-			Body:  "Im Projekt {{project_name}} wurde ein SPS-Regler entfernt.",
-		},
-		"project.sps_controller.ip_address.changed": {
-			Title: "SPS-Regler {{name}}: IP-Adresse geändert",
-			Body:  "Die IP-Adresse wurde von {{old}} auf {{new}} geändert.",
-		},
-		"project.field_device.created": {
-			Title: "Feldgerät hinzugefügt",
-			Body:  "Im Projekt {{project_name}} wurde ein Feldgerät hinzugefügt.",
-		},
-		"project.field_device.updated": {
-			Title: "Feldgerät aktualisiert",
-			Body:  "Im Projekt {{project_name}} wurde ein Feldgerät aktualisiert.",
-		},
-		"project.field_device.deleted": {
-			Title: "Feldgerät entfernt",
-			Body:  "Im Projekt {{project_name}} wurde ein Feldgerät entfernt.",
-		},
-		"project.field_device.multi_created": {
-			Title: "Feldgeräte hinzugefügt",
-			Body:  "Im Projekt {{project_name}} wurden {{count}} Feldgeräte hinzugefügt.",
-		},
-		"project.object_data.created": {
-			Title: "Objektdaten verknüpft",
-			Body:  "Im Projekt {{project_name}} wurden Objektdaten verknüpft.",
-		},
-		"project.object_data.deleted": {
-			Title: "Objektdaten entfernt",
-			Body:  "Im Projekt {{project_name}} wurden Objektdaten entfernt.",
-		},
-	},
+// notificationEventKeys lists the built-in notification events. Their texts
+// live in the translation catalogs under notificationCatalogPrefix.
+var notificationEventKeys = []string{
+	"project.updated",
+	"project.deleted",
+	"project.user.invited",
+	"project.user.removed",
+	"project.phase.changed",
+	"project.control_cabinet.created",
+	"project.control_cabinet.updated",
+	"project.control_cabinet.deleted",
+	"project.sps_controller.created",
+	"project.sps_controller.updated",
+	"project.sps_controller.deleted",
+	"project.sps_controller.ip_address.changed",
+	"project.field_device.created",
+	"project.field_device.updated",
+	"project.field_device.deleted",
+	"project.field_device.multi_created",
+	"project.object_data.created",
+	"project.object_data.updated",
+	"project.object_data.deleted",
+}
+
+// notificationCatalogPrefix is the catalog namespace of notification event
+// templates: "notifications.events.<event_key>.title" and ".body".
+const notificationCatalogPrefix = "notifications.events."
+
+// TemplateCatalogKeys returns the catalog keys of the title and body of every
+// built-in notification event, in sorted order.
+func TemplateCatalogKeys() []string {
+	keys := make([]string, 0, 2*len(notificationEventKeys))
+	for _, eventKey := range notificationEventKeys {
+		title, body := templateCatalogKeys(eventKey)
+		keys = append(keys, title, body)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func templateCatalogKeys(eventKey string) (string, string) {
+	return notificationCatalogPrefix + eventKey + ".title", notificationCatalogPrefix + eventKey + ".body"
 }
 
-func renderNotificationTemplate(locale, eventKey, title, body string, metadata map[string]string) (string, string) {
+func (s *Service) renderNotificationTemplate(locale, eventKey, title, body string, metadata map[string]string) (string, string) {
 	title = strings.TrimSpace(title)
 	body = strings.TrimSpace(body)
-	if template, ok := notificationTemplateFor(strings.TrimSpace(locale), strings.TrimSpace(eventKey)); ok {
+	if template, ok := s.notificationTemplateFor(strings.TrimSpace(locale), strings.TrimSpace(eventKey), metadata); ok {
 		if title == "" {
 			title = template.Title
 		}
@@ -935,17 +925,40 @@ func renderNotificationTemplate(locale, eventKey, title, body string, metadata m
 	return applyTemplateValues(title, metadata), applyTemplateValues(body, metadata)
 }
 
-func notificationTemplateFor(locale, eventKey string) (notificationTemplate, bool) {
-	if templates, ok := localizedNotificationTemplates[locale]; ok {
-		if template, ok := templates[eventKey]; ok {
-			return template, true
-		}
+// notificationTemplateFor looks up the catalog texts of an event. The catalog
+// falls back to the default locale key by key.
+func (s *Service) notificationTemplateFor(locale, eventKey string, metadata map[string]string) (notificationTemplate, bool) {
+	if s.templates == nil {
+		return notificationTemplate{}, false
+	}
+	var template notificationTemplate
+	found := false
+	titleKey, bodyKey := templateCatalogKeys(eventKey)
+	if title, ok := s.lookupTemplate(locale, titleKey, metadata); ok {
+		template.Title = title
+		found = true
+	}
+	if body, ok := s.lookupTemplate(locale, bodyKey, metadata); ok {
+		template.Body = body
+		found = true
+	}
+	return template, found
+}
+
+// lookupTemplate resolves key, or its plural form when metadata carries a
+// numeric "count" and the catalog splits the text into "one" and "other".
+func (s *Service) lookupTemplate(locale, key string, metadata map[string]string) (string, bool) {
+	if value, ok := s.templates.Lookup(locale, key); ok {
+		return value, true
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(metadata["count"]))
+	if err != nil {
+		return "", false
 	}
-	if templates, ok := localizedNotificationTemplates[defaultNotificationLocale]; ok {
-		template, ok := templates[eventKey]
-		return template, ok
+	if value, ok := s.templates.Lookup(locale, key+"."+i18n.PluralCategory(locale, count)); ok {
+		return value, true
 	}
-	return notificationTemplate{}, false
+	return s.templates.Lookup(locale, key+".other")
 }
 
 func applyTemplateValues(value string, metadata map[string]string) string {
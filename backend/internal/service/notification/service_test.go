@@ -9,6 +9,7 @@ import (
 	domain "github.com/besart951/go_infra_link/backend/internal/domain"
 	domainNotification "github.com/besart951/go_infra_link/backend/internal/domain/notification"
 	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	"github.com/google/uuid"
 )
 
@@ -334,6 +335,28 @@ func TestUpsertUserPreferenceNormalizesAndSaves(t *testing.T) {
 	}
 }
 
+// shippedCatalogs loads the translation catalogs the server ships with.
+func shippedCatalogs(t *testing.T) *i18n.Translator {
+	t.Helper()
+	catalogs := i18n.NewTranslator("de_CH")
+	if _, err := i18n.NewLoader("../../../translations").LoadInto(catalogs); err != nil {
+		t.Fatalf("LoadInto returned error: %v", err)
+	}
+	return catalogs
+}
+
+func TestShippedCatalogCoversNotificationEvents(t *testing.T) {
+	catalogs := shippedCatalogs(t)
+	for _, key := range TemplateCatalogKeys() {
+		if _, ok := catalogs.Lookup("de_CH", key); ok {
+			continue
+		}
+		if _, ok := catalogs.Lookup("de_CH", key+".other"); !ok {
+			t.Errorf("de_CH catalog has no text for %s", key)
+		}
+	}
+}
+
 func TestDispatchQueuesSystemNotificationAndEmailOutbox(t *testing.T) {
 	userID := uuid.Must(uuid.NewV7())
 	verifiedAt := time.Now().UTC()
@@ -354,7 +377,13 @@ func TestDispatchQueuesSystemNotificationAndEmailOutbox(t *testing.T) {
 			userID: {Base: domain.Base{ID: userID}, Email: domainUser.EmailPtr("login@example.com")},
 		},
 	}
-	service := New(nil, preferenceRepo, systemRepo, outboxRepo, nil, nil, nil, userRepo, secretCipherStub{}, "test-secret")
+	service := NewFromDependencies(Dependencies{
+		Preferences: preferenceRepo,
+		SystemInbox: systemRepo,
+		EmailOutbox: outboxRepo,
+		Users:       userRepo,
+		Cipher:      secretCipherStub{},
+	}, Config{VerificationSecret: "test-secret", Templates: shippedCatalogs(t)})
 	service.SetSystemNotificationPublisher(publisher)
 
 	err := service.Dispatch(context.Background(), domainNotification.DispatchNotificationInput{
@@ -397,6 +426,67 @@ func TestDispatchQueuesSystemNotificationAndEmailOutbox(t *testing.T) {
 	}
 }
 
+func TestDispatchRendersCatalogTemplatesInRecipientLocale(t *testing.T) {
+	germanID := uuid.Must(uuid.NewV7())
+	frenchID := uuid.Must(uuid.NewV7())
+	catalogs := i18n.NewTranslator("de_CH")
+	if err := catalogs.LoadLanguage("de_CH", map[string]any{
+		"notifications": map[string]any{"events": map[string]any{"project": map[string]any{"field_device": map[string]any{"multi_created": map[string]any{
+			"title": "Feldgeräte hinzugefügt",
+			"body":  map[string]any{"one": "{{count}} Feldgerät in {{project_name}}", "other": "{{count}} Feldgeräte in {{project_name}}"},
+		}}}}},
+	}); err != nil {
+		t.Fatalf("LoadLanguage returned error: %v", err)
+	}
+	if err := catalogs.LoadLanguage("fr_CH", map[string]any{
+		"notifications": map[string]any{"events": map[string]any{"project": map[string]any{"field_device": map[string]any{"multi_created": map[string]any{
+			"body": map[string]any{"one": "{{count}} appareil dans {{project_name}}", "other": "{{count}} appareils dans {{project_name}}"},
+		}}}}},
+	}); err != nil {
+		t.Fatalf("LoadLanguage returned error: %v", err)
+	}
+	systemRepo := &systemNotificationRepoStub{}
+	userRepo := &userRepoStub{
+		users: map[uuid.UUID]*domainUser.User{
+			germanID: {Base: domain.Base{ID: germanID}},
+			frenchID: {Base: domain.Base{ID: frenchID}, Locale: "fr_CH"},
+		},
+	}
+	service := NewFromDependencies(Dependencies{
+		Preferences: &userPreferenceRepoStub{},
+		SystemInbox: systemRepo,
+		Users:       userRepo,
+		Cipher:      secretCipherStub{},
+	}, Config{VerificationSecret: "test-secret", Templates: catalogs})
+
+	err := service.Dispatch(context.Background(), domainNotification.DispatchNotificationInput{
+		RecipientIDs: []uuid.UUID{germanID, frenchID},
+		Locale:       "de_CH",
+		EventKey:     "project.field_device.multi_created",
+		Metadata:     map[string]string{"project_name": "Testprojekt", "count": "1"},
+	})
+	if err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+
+	if len(systemRepo.created) != 2 {
+		t.Fatalf("expected two system notifications, got %d", len(systemRepo.created))
+	}
+	texts := map[uuid.UUID]*domainNotification.SystemNotification{}
+	for index := range systemRepo.created {
+		texts[systemRepo.created[index].RecipientID] = &systemRepo.created[index]
+	}
+	if got := texts[germanID].Body; got != "1 Feldgerät in Testprojekt" {
+		t.Fatalf("expected German singular body, got %q", got)
+	}
+	if got := texts[frenchID].Body; got != "1 appareil dans Testprojekt" {
+		t.Fatalf("expected French body in the recipient locale, got %q", got)
+	}
+	if got := texts[frenchID].Title; got != "Feldgeräte hinzugefügt" {
+		t.Fatalf("expected title to fall back to the default catalog, got %q", got)
+	}
+}
+
 func TestProcessDueEmailOutboxGroupsDigestItems(t *testing.T) {
 	recipientID := uuid.Must(uuid.NewV7())
 	firstID := uuid.Must(uuid.NewV7())
@@ -2,14 +2,22 @@ package project
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"strings"
+	"time"
 
 	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
 	"github.com/google/uuid"
 )
 
+// ErrChangeFeedUnsupported is returned when the change store cannot keep
+// per-project retention or take snapshots.
+var ErrChangeFeedUnsupported = errors.New("project change store does not support retention or snapshots")
+
 type ChangeService struct {
-	store domainProject.ChangeStore
+	store  domainProject.ChangeStore
+	states ChangeStateFactory
 }
 
 func NewChangeService(store domainProject.ChangeStore) *ChangeService {
@@ -20,6 +28,57 @@ func (s *ChangeService) ListAfter(ctx context.Context, projectID uuid.UUID, afte
 	return s.store.ListAfter(ctx, projectID, afterRevision, limit)
 }
 
+func (s *ChangeService) Retention(ctx context.Context, projectID uuid.UUID) (*domainProject.ChangeRetention, error) {
+	store, ok := s.store.(domainProject.ChangeRetentionStore)
+	if !ok {
+		return nil, ErrChangeFeedUnsupported
+	}
+	return store.Retention(ctx, projectID)
+}
+
+// SetRetention changes how long the project keeps its full event history. A
+// nil days value restores the default.
+func (s *ChangeService) SetRetention(ctx context.Context, projectID uuid.UUID, days *int) (*domainProject.ChangeRetention, error) {
+	store, ok := s.store.(domainProject.ChangeRetentionStore)
+	if !ok {
+		return nil, ErrChangeFeedUnsupported
+	}
+	return store.SetRetention(ctx, projectID, days)
+}
+
+// StartCompactionWorker compacts the change feed of every project whose
+// retention window expired events, also when nothing new is appended.
+func (s *ChangeService) StartCompactionWorker(interval time.Duration) func() {
+	store, ok := s.store.(domainProject.ChangeRetentionStore)
+	if !ok {
+		return func() {}
+	}
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		compactExpiredWithLog(ctx, store)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				compactExpiredWithLog(ctx, store)
+			}
+		}
+	}()
+	return cancel
+}
+
+func compactExpiredWithLog(ctx context.Context, store domainProject.ChangeRetentionStore) {
+	if err := store.CompactExpired(ctx); err != nil && ctx.Err() == nil {
+		slog.Warn("project change compaction failed", "err", err)
+	}
+}
+
 // RecordEvent converts the existing semantic project event vocabulary into one
 // durable aggregate event per entity. It is deliberately independent of the
 // WebSocket collaboration payload.
@@ -91,7 +150,7 @@ func semanticChangedFields(aggregateType string, action domainProject.ChangeActi
 	case "control_cabinet":
 		return []string{"building_id", "control_cabinet_nr"}
 	case "sps_controller":
-		return []string{"control_cabinet_id", "ga_device", "device_name", "device_description", "device_location", "ip_address", "subnet", "gateway", "vlan", "system_types"}
+		return []string{"control_cabinet_id", "ga_device", "device_name", "device_description", "device_location", "ip_address", "subnet", "gateway", "vlan", "mac_address", "system_types"}
 	case "sps_controller_system_type":
 		return []string{"number", "document_name"}
 	case "field_device":
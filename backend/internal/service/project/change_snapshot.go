@@ -0,0 +1,99 @@
+package project
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	"github.com/google/uuid"
+)
+
+// ChangeRevisionReader is an optional capability of the change store. It
+// runs read inside one consistent, read-only transaction together with the
+// project's current revision. Events are recorded after the mutation they
+// describe commits, so the state read may already contain changes whose
+// events follow the revision; replaying those events is harmless.
+type ChangeRevisionReader interface {
+	ReadAtRevision(ctx context.Context, projectID uuid.UUID, read func(ctx context.Context, revision uint64, unit transaction.UnitOfWork) error) error
+}
+
+// ChangeStateFactory binds a state reader to the snapshot transaction.
+type ChangeStateFactory func(unit transaction.UnitOfWork) (domainProject.ChangeStateReader, error)
+
+// snapshotAggregateTypes maps the tables of a project to the aggregate types
+// the feed reports for them, in hierarchy order. BACnet objects and their
+// alarm values change through field_device events but are listed on their
+// own so consumers can rebuild the device details.
+var snapshotAggregateTypes = []struct {
+	table         string
+	aggregateType string
+}{
+	{"project_users", "user"},
+	{"object_data", "object_data"},
+	{"control_cabinets", "control_cabinet"},
+	{"sps_controllers", "sps_controller"},
+	{"sps_controller_system_types", "sps_controller_system_type"},
+	{"field_devices", "field_device"},
+	{"bacnet_objects", "bacnet_object"},
+	{"bacnet_object_alarm_values", "bacnet_object_alarm_value"},
+}
+
+// bindSnapshotStates lets Snapshot read project state through states.
+func (s *ChangeService) bindSnapshotStates(states ChangeStateFactory) {
+	s.states = states
+}
+
+// Snapshot returns the project's current state with the revision to resume
+// the feed from. An empty types list returns every aggregate type.
+func (s *ChangeService) Snapshot(ctx context.Context, projectID uuid.UUID, aggregateTypes []string) (*domainProject.ChangeSnapshot, error) {
+	store, ok := s.store.(ChangeRevisionReader)
+	if !ok || s.states == nil {
+		return nil, ErrChangeFeedUnsupported
+	}
+	wanted := func(aggregateType string) bool {
+		return len(aggregateTypes) == 0 || slices.Contains(aggregateTypes, aggregateType)
+	}
+	tables := make([]string, 0, len(snapshotAggregateTypes))
+	for _, entry := range snapshotAggregateTypes {
+		if wanted(entry.aggregateType) {
+			tables = append(tables, entry.table)
+		}
+	}
+	snapshot := &domainProject.ChangeSnapshot{ProjectID: projectID, TakenAt: time.Now().UTC(), Aggregates: []domainProject.AggregateState{}}
+	err := store.ReadAtRevision(ctx, projectID, func(ctx context.Context, revision uint64, unit transaction.UnitOfWork) error {
+		snapshot.Revision = revision
+		states, err := s.states(unit)
+		if err != nil {
+			return err
+		}
+		project, err := states.ProjectRow(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		if wanted("project") {
+			snapshot.Aggregates = append(snapshot.Aggregates, domainProject.AggregateState{AggregateType: "project", AggregateID: projectID, State: project})
+		}
+		if len(tables) == 0 {
+			return nil
+		}
+		rows, err := states.Rows(ctx, projectID, tables)
+		if err != nil {
+			return err
+		}
+		for _, entry := range snapshotAggregateTypes {
+			if !wanted(entry.aggregateType) {
+				continue
+			}
+			for _, row := range rows[entry.table] {
+				snapshot.Aggregates = append(snapshot.Aggregates, domainProject.AggregateState{AggregateType: entry.aggregateType, AggregateID: row.ID, State: row.State})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
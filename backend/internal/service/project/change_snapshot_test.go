@@ -0,0 +1,84 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	"github.com/google/uuid"
+)
+
+type revisionStoreFake struct {
+	changeStoreFake
+	revision uint64
+}
+
+func (f *revisionStoreFake) ReadAtRevision(ctx context.Context, _ uuid.UUID, read func(context.Context, uint64, transaction.UnitOfWork) error) error {
+	return read(ctx, f.revision, nil)
+}
+
+type changeStatesFake struct {
+	projectID uuid.UUID
+	rows      map[string][]domainProject.ChangeStateRow
+	requested []string
+}
+
+func (f *changeStatesFake) ProjectRow(_ context.Context, projectID uuid.UUID) (json.RawMessage, error) {
+	if projectID != f.projectID {
+		return nil, domain.ErrNotFound
+	}
+	return json.RawMessage(`{"name":"Snapshot"}`), nil
+}
+
+func (f *changeStatesFake) Rows(_ context.Context, _ uuid.UUID, tables []string) (map[string][]domainProject.ChangeStateRow, error) {
+	f.requested = tables
+	return f.rows, nil
+}
+
+func TestChangeServiceSnapshotCoversFeedAggregateTypes(t *testing.T) {
+	projectID := uuid.New()
+	rows := map[string][]domainProject.ChangeStateRow{}
+	for _, entry := range snapshotAggregateTypes {
+		rows[entry.table] = []domainProject.ChangeStateRow{{ID: uuid.New(), State: json.RawMessage(`{}`)}}
+	}
+	states := &changeStatesFake{projectID: projectID, rows: rows}
+	service := NewChangeService(&revisionStoreFake{revision: 7})
+	service.bindSnapshotStates(func(transaction.UnitOfWork) (domainProject.ChangeStateReader, error) { return states, nil })
+
+	snapshot, err := service.Snapshot(context.Background(), projectID, nil)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if snapshot.Revision != 7 || len(snapshot.Aggregates) != len(snapshotAggregateTypes)+1 {
+		t.Fatalf("snapshot = %+v, want every aggregate type at revision 7", snapshot)
+	}
+	types := make([]string, 0, len(snapshot.Aggregates))
+	for _, aggregate := range snapshot.Aggregates {
+		types = append(types, aggregate.AggregateType)
+	}
+	// Every aggregate type the feed reports must be part of the snapshot.
+	for _, eventType := range []string{
+		"project.updated", "project.user.invited", "project.object_data.created", "project.control_cabinet.created",
+		"project.sps_controller.updated", "project.sps_controller_system_type.updated", "project.field_device.created",
+	} {
+		if aggregateType, _ := changeSemantics(eventType); !slices.Contains(types, aggregateType) {
+			t.Fatalf("snapshot types %v miss %s of %s", types, aggregateType, eventType)
+		}
+	}
+
+	filtered, err := service.Snapshot(context.Background(), projectID, []string{"sps_controller"})
+	if err != nil || len(filtered.Aggregates) != 1 || !slices.Equal(states.requested, []string{"sps_controllers"}) {
+		t.Fatalf("filtered snapshot = %+v, requested %v, %v", filtered, states.requested, err)
+	}
+	if _, err := service.Snapshot(context.Background(), uuid.New(), nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("unknown project must not be found, got %v", err)
+	}
+	if _, err := NewChangeService(&changeStoreFake{}).Snapshot(context.Background(), projectID, nil); !errors.Is(err, ErrChangeFeedUnsupported) {
+		t.Fatalf("store without revision reads must be unsupported, got %v", err)
+	}
+}
@@ -127,7 +127,7 @@ func (s *ProjectFacilityLinkService) CopyControlCabinet(ctx context.Context, pro
 }
 
 func (s *ProjectFacilityLinkService) copyControlCabinet(ctx context.Context, projectID, controlCabinetID uuid.UUID) (*domainFacility.ControlCabinet, error) {
-	copyEntity, err := s.hierarchyCopier.CopyControlCabinetByID(ctx, controlCabinetID)
+	copyEntity, err := s.hierarchyCopier.CopyControlCabinetForProject(ctx, projectID, controlCabinetID)
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +173,7 @@ func (s *ProjectFacilityLinkService) CopySPSController(ctx context.Context, proj
 }
 
 func (s *ProjectFacilityLinkService) copySPSController(ctx context.Context, projectID, spsControllerID uuid.UUID) (*domainFacility.SPSController, error) {
-	copyEntity, err := s.hierarchyCopier.CopySPSControllerByID(ctx, spsControllerID)
+	copyEntity, err := s.hierarchyCopier.CopySPSControllerForProject(ctx, projectID, spsControllerID)
 	if err != nil {
 		return nil, err
 	}
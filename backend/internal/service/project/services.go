@@ -13,6 +13,7 @@ import (
 type Dependencies struct {
 	Projects                 domainProject.ProjectRepository
 	ProjectChanges           domainProject.ChangeStore
+	ProjectChangeStates      ChangeStateFactory
 	Phases                   domainProject.PhaseRepository
 	PhasePermissions         domainProject.PhasePermissionRepository
 	ProjectControlCabinets   domainProject.ProjectControlCabinetRepository
@@ -50,6 +51,7 @@ func NewServices(deps Dependencies, cfgs ...Config) *Services {
 
 	services := &Services{}
 	services.Changes = NewChangeService(deps.ProjectChanges)
+	services.Changes.bindSnapshotStates(deps.ProjectChangeStates)
 	services.AccessPolicy = &ProjectAccessPolicyService{
 		repo:                deps.Projects,
 		phaseRepo:           deps.Phases,
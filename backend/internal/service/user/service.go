@@ -11,6 +11,7 @@ import (
 	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainAuth "github.com/besart951/go_infra_link/backend/internal/domain/auth"
 	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	"github.com/google/uuid"
 )
 
@@ -162,6 +163,30 @@ func (s *Service) UpdatePassword(ctx context.Context, userID uuid.UUID, currentP
 	return usr, nil
 }
 
+// UpdateLocale stores the preferred locale of a user. An empty locale clears
+// the preference so the browser language applies again.
+func (s *Service) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) (*domainUser.User, error) {
+	if userID == uuid.Nil {
+		return nil, domain.ErrInvalidArgument
+	}
+	if locale != "" {
+		normalized, ok := i18n.NormalizeLocale(locale)
+		if !ok {
+			return nil, domain.NewValidationError().AddCode("locale", "invalid", "locale must look like fr or fr_CH")
+		}
+		locale = normalized
+	}
+	usr, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usr.Locale = locale
+	if err := s.repo.Update(ctx, usr); err != nil {
+		return nil, err
+	}
+	return usr, nil
+}
+
 func (s *Service) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	return s.deletionService.DeleteByID(ctx, id)
 }
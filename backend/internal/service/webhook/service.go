@@ -0,0 +1,389 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/google/uuid"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventIDHeader   = "X-Webhook-Id"
+	EventTypeHeader = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	deliveryTimeout = 10 * time.Second
+)
+
+// retrySchedule is the wait after each failed attempt. The attempt after the
+// last entry is the final one.
+var retrySchedule = []time.Duration{
+	30 * time.Second, 2 * time.Minute, 10 * time.Minute, 30 * time.Minute,
+	time.Hour, 3 * time.Hour, 6 * time.Hour,
+}
+
+// SecretCipher encrypts the signing secrets at rest. The notification AES
+// cipher satisfies it.
+type SecretCipher interface {
+	Encrypt(plain string) (string, error)
+	Decrypt(encoded string) (string, error)
+}
+
+type Service struct {
+	subscriptions domainWebhook.SubscriptionRepository
+	deliveries    domainWebhook.DeliveryRepository
+	cipher        SecretCipher
+	client        *http.Client
+	allowAddress  func(netip.Addr) bool
+	now           func() time.Time
+}
+
+func New(subscriptions domainWebhook.SubscriptionRepository, deliveries domainWebhook.DeliveryRepository, cipher SecretCipher) *Service {
+	s := &Service{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		cipher:        cipher,
+		allowAddress:  publicAddress,
+		now:           func() time.Time { return time.Now().UTC() },
+	}
+	s.client = newDeliveryClient(func(addr netip.Addr) bool { return s.allowAddress(addr) })
+	return s
+}
+
+func (s *Service) ListSubscriptions(ctx context.Context, projectID *uuid.UUID) ([]domainWebhook.Subscription, error) {
+	return s.subscriptions.List(ctx, projectID)
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id uuid.UUID) (*domainWebhook.Subscription, error) {
+	return s.subscriptions.GetByID(ctx, id)
+}
+
+// UpsertSubscription stores the subscription and returns the plain signing
+// secret when it was generated or replaced; it is never readable afterwards.
+func (s *Service) UpsertSubscription(ctx context.Context, input domainWebhook.UpsertSubscriptionInput) (*domainWebhook.Subscription, string, error) {
+	subscription := &domainWebhook.Subscription{
+		Base:           domain.Base{ID: input.ID},
+		Name:           strings.TrimSpace(input.Name),
+		URL:            strings.TrimSpace(input.URL),
+		ProjectID:      input.ProjectID,
+		Active:         input.Active,
+		EventTypes:     normalizeList(input.EventTypes),
+		AggregateTypes: normalizeList(input.AggregateTypes),
+		Actions:        normalizeList(input.Actions),
+		JobStatuses:    normalizeList(input.JobStatuses),
+	}
+	if input.ActorID != uuid.Nil {
+		subscription.CreatedByID = &input.ActorID
+	}
+	if err := validateSubscription(subscription, s.allowAddress); err != nil {
+		return nil, "", err
+	}
+
+	var existing *domainWebhook.Subscription
+	if subscription.ID != uuid.Nil {
+		var err error
+		if existing, err = s.subscriptions.GetByID(ctx, subscription.ID); err != nil {
+			return nil, "", err
+		}
+	}
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" && existing == nil {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, "", err
+		}
+		secret = generated
+	}
+	if secret != "" {
+		encrypted, err := s.cipher.Encrypt(secret)
+		if err != nil {
+			return nil, "", fmt.Errorf("encrypt webhook secret: %w", err)
+		}
+		subscription.SecretCipher = encrypted
+	}
+
+	if existing == nil {
+		if err := s.subscriptions.Create(ctx, subscription); err != nil {
+			return nil, "", err
+		}
+		return subscription, secret, nil
+	}
+	subscription.CreatedAt = existing.CreatedAt
+	subscription.CreatedByID = existing.CreatedByID
+	if subscription.SecretCipher == "" {
+		subscription.SecretCipher = existing.SecretCipher
+	}
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, "", err
+	}
+	return subscription, secret, nil
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return s.subscriptions.DeleteByID(ctx, id)
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) (*domain.PaginatedList[domainWebhook.Delivery], error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, domain.NewValidationError().Add("status", "must be one of: pending delivered failed")
+	}
+	return s.deliveries.List(ctx, filter)
+}
+
+func (s *Service) GetDelivery(ctx context.Context, id uuid.UUID) (*domainWebhook.Delivery, error) {
+	return s.deliveries.GetByID(ctx, id)
+}
+
+// ReplayDelivery requeues one failed delivery. Pending and delivered
+// deliveries are a conflict.
+func (s *Service) ReplayDelivery(ctx context.Context, id uuid.UUID) (*domainWebhook.Delivery, error) {
+	replayed, err := s.deliveries.Replay(ctx, domainWebhook.ReplayFilter{IDs: []uuid.UUID{id}}, s.now())
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if replayed == 0 {
+		return nil, domain.ErrConflict
+	}
+	return delivery, nil
+}
+
+// ReplayFailed requeues every failed delivery, or those of one subscription.
+func (s *Service) ReplayFailed(ctx context.Context, subscriptionID *uuid.UUID) (int64, error) {
+	return s.deliveries.Replay(ctx, domainWebhook.ReplayFilter{SubscriptionID: subscriptionID}, s.now())
+}
+
+// ProcessDueDeliveries sends the due deliveries one after another. The lease
+// covers the whole batch so a slow endpoint does not let another worker pick
+// up the rest of it.
+func (s *Service) ProcessDueDeliveries(ctx context.Context, now time.Time, limit int) error {
+	if limit <= 0 {
+		limit = 100
+	}
+	leaseUntil := now.Add(time.Duration(limit)*deliveryTimeout + time.Minute)
+	deliveries, err := s.deliveries.Claim(ctx, now, leaseUntil, limit)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if !slices.Contains(ids, delivery.SubscriptionID) {
+			ids = append(ids, delivery.SubscriptionID)
+		}
+	}
+	subscriptions, err := s.subscriptions.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]domainWebhook.Subscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID] = subscription
+	}
+
+	for _, delivery := range deliveries {
+		attempt := s.deliver(ctx, delivery, byID)
+		if err := s.deliveries.RecordAttempt(ctx, delivery.ID, attempt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) StartDeliveryWorker(interval time.Duration, batchSize int) func() {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		_ = s.ProcessDueDeliveries(ctx, time.Now().UTC(), batchSize)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				_ = s.ProcessDueDeliveries(ctx, now.UTC(), batchSize)
+			}
+		}
+	}()
+	return cancel
+}
+
+func (s *Service) deliver(ctx context.Context, delivery domainWebhook.Delivery, subscriptions map[uuid.UUID]domainWebhook.Subscription) domainWebhook.DeliveryAttempt {
+	at := s.now()
+	attempt := domainWebhook.DeliveryAttempt{Attempts: delivery.Attempts + 1, At: at, NextAttemptAt: at}
+	subscription, ok := subscriptions[delivery.SubscriptionID]
+	if !ok || !subscription.Active {
+		attempt.Status = domainWebhook.DeliveryStatusFailed
+		attempt.Error = "subscription is inactive"
+		return attempt
+	}
+	secret, err := s.cipher.Decrypt(subscription.SecretCipher)
+	if err != nil {
+		attempt.Status = domainWebhook.DeliveryStatusFailed
+		attempt.Error = truncateError(fmt.Errorf("decrypt webhook secret: %w", err))
+		return attempt
+	}
+
+	status, err := s.send(ctx, subscription.URL, secret, delivery, at)
+	if status != 0 {
+		attempt.ResponseStatus = &status
+	}
+	if err == nil {
+		attempt.Status = domainWebhook.DeliveryStatusDelivered
+		return attempt
+	}
+	attempt.Error = truncateError(err)
+	if attempt.Attempts >= domainWebhook.MaxDeliveryAttempts {
+		attempt.Status = domainWebhook.DeliveryStatusFailed
+		return attempt
+	}
+	attempt.Status = domainWebhook.DeliveryStatusPending
+	attempt.NextAttemptAt = at.Add(retrySchedule[min(attempt.Attempts, len(retrySchedule))-1])
+	return attempt
+}
+
+func (s *Service) send(ctx context.Context, target, secret string, delivery domainWebhook.Delivery, at time.Time) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "go-infra-link-webhooks/1")
+	request.Header.Set(EventIDHeader, delivery.EventID.String())
+	request.Header.Set(EventTypeHeader, string(delivery.EventType))
+	request.Header.Set(DeliveryHeader, delivery.ID.String())
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, delivery.Payload))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+	if response.StatusCode >= 300 && response.StatusCode <= 399 {
+		return response.StatusCode, fmt.Errorf("endpoint answered %s; redirects are not followed", response.Status)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("endpoint answered %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Receivers
+// recompute it with their secret and reject stale timestamps.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateSubscription(subscription *domainWebhook.Subscription, allow func(netip.Addr) bool) error {
+	ve := domain.NewValidationError()
+	if subscription.Name == "" {
+		ve = ve.Add("name", "is required")
+	}
+	if target, err := url.Parse(subscription.URL); err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		ve = ve.Add("url", "must be an absolute http or https URL")
+	} else if !validTargetHost(target, allow) {
+		ve = ve.Add("url", "must not point to a private or loopback address")
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !domainWebhook.EventType(eventType).Valid() {
+			ve = ve.Add("event_types", "must contain only: project.change facility_job.status")
+			break
+		}
+	}
+	if subscription.ProjectID != nil && slices.Contains(subscription.EventTypes, string(domainWebhook.EventFacilityJobStatus)) {
+		ve = ve.Add("event_types", "facility job events need a global subscription")
+	}
+	for _, action := range subscription.Actions {
+		if !validChangeAction(action) {
+			ve = ve.Add("actions", "must contain only: created updated deleted copied invited removed")
+			break
+		}
+	}
+	for _, status := range subscription.JobStatuses {
+		switch facilityservice.FacilityJobStatus(status) {
+		case facilityservice.FacilityJobStatusQueued, facilityservice.FacilityJobStatusRunning,
+			facilityservice.FacilityJobStatusCompleted, facilityservice.FacilityJobStatusFailed:
+		default:
+			ve = ve.Add("job_statuses", "must contain only: queued running completed failed")
+			return ve
+		}
+	}
+	if len(ve.Fields) == 0 {
+		return nil
+	}
+	return ve
+}
+
+func validChangeAction(action string) bool {
+	switch domainProject.ChangeAction(action) {
+	case domainProject.ChangeCreated, domainProject.ChangeUpdated, domainProject.ChangeDeleted,
+		domainProject.ChangeCopied, domainProject.ChangeInvited, domainProject.ChangeRemoved:
+		return true
+	default:
+		return false
+	}
+}
+
+func normalizeList(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func generateSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(raw), nil
+}
+
+func truncateError(err error) string {
+	message := err.Error()
+	if len(message) <= 1000 {
+		return message
+	}
+	return message[:1000]
+}
@@ -0,0 +1,405 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+func TestDeliveriesAreSignedAndRetriedWithBackoff(t *testing.T) {
+	receiver := newReceiver(http.StatusInternalServerError, http.StatusNoContent)
+	defer receiver.Close()
+	service, deliveries, clock := newTestService(t)
+	projectID, deviceID := uuid.New(), uuid.New()
+
+	subscription, secret, err := service.UpsertSubscription(t.Context(), domainWebhook.UpsertSubscriptionInput{
+		Name: "GMS import", URL: receiver.URL, ProjectID: &projectID, Active: true,
+		AggregateTypes: []string{"field_device"}, Actions: []string{"updated"},
+	})
+	if err != nil || secret == "" {
+		t.Fatalf("UpsertSubscription() = %q, %v", secret, err)
+	}
+	queueChanges(t, service, []domainProject.Change{
+		{EventID: uuid.New(), ProjectID: projectID, Revision: 7, AggregateType: "field_device", AggregateID: &deviceID, Action: domainProject.ChangeUpdated, ChangedFields: []string{"bmk"}, OccurredAt: clock.now},
+		{EventID: uuid.New(), ProjectID: projectID, Revision: 8, AggregateType: "field_device", Action: domainProject.ChangeDeleted, OccurredAt: clock.now},
+		{EventID: uuid.New(), ProjectID: uuid.New(), Revision: 1, AggregateType: "field_device", Action: domainProject.ChangeUpdated, OccurredAt: clock.now},
+	})
+	if len(deliveries.items) != 1 || deliveries.items[0].SubscriptionID != subscription.ID {
+		t.Fatalf("deliveries = %+v, want one for the matching change", deliveries.items)
+	}
+
+	start := clock.now
+	if err := service.ProcessDueDeliveries(t.Context(), start, 10); err != nil {
+		t.Fatal(err)
+	}
+	delivery := deliveries.items[0]
+	if delivery.Status != domainWebhook.DeliveryStatusPending || delivery.Attempts != 1 || !delivery.NextAttemptAt.Equal(start.Add(30*time.Second)) {
+		t.Fatalf("after a 500 the delivery must wait 30s, got %+v", delivery)
+	}
+	if *delivery.ResponseStatus != http.StatusInternalServerError || delivery.LastError == "" {
+		t.Fatalf("attempt not recorded: %+v", delivery)
+	}
+
+	if err := service.ProcessDueDeliveries(t.Context(), start.Add(10*time.Second), 10); err != nil || receiver.count() != 1 {
+		t.Fatalf("delivery sent before its backoff elapsed: %d requests, %v", receiver.count(), err)
+	}
+	clock.now = start.Add(30 * time.Second)
+	if err := service.ProcessDueDeliveries(t.Context(), clock.now, 10); err != nil {
+		t.Fatal(err)
+	}
+	delivery = deliveries.items[0]
+	if delivery.Status != domainWebhook.DeliveryStatusDelivered || delivery.Attempts != 2 || delivery.DeliveredAt == nil {
+		t.Fatalf("delivery = %+v, want delivered on the second attempt", delivery)
+	}
+
+	request := receiver.last()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(request.header.Get(TimestampHeader) + "."))
+	mac.Write(request.body)
+	if request.header.Get(SignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+		t.Fatalf("signature %q does not verify", request.header.Get(SignatureHeader))
+	}
+	var payload struct {
+		Type      string    `json:"type"`
+		ProjectID uuid.UUID `json:"project_id"`
+		Data      struct {
+			Revision      uint64   `json:"revision"`
+			ChangedFields []string `json:"changed_fields"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(request.body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Type != "project.change" || payload.ProjectID != projectID || payload.Data.Revision != 7 || !slices.Equal(payload.Data.ChangedFields, []string{"bmk"}) {
+		t.Fatalf("payload = %s", request.body)
+	}
+	if request.header.Get(EventIDHeader) != delivery.EventID.String() || request.header.Get(EventTypeHeader) != "project.change" {
+		t.Fatalf("headers = %v", request.header)
+	}
+}
+
+func TestDeliveryFailsAfterLastAttemptAndCanBeReplayed(t *testing.T) {
+	receiver := newReceiver(http.StatusServiceUnavailable)
+	defer receiver.Close()
+	service, deliveries, clock := newTestService(t)
+	subscription, _, err := service.UpsertSubscription(t.Context(), domainWebhook.UpsertSubscriptionInput{
+		Name: "Ticketing", URL: receiver.URL, Active: true, JobStatuses: []string{"completed", "failed"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := domainWebhook.JobStatus{JobID: uuid.New(), Status: "running", UpdatedAt: clock.now}
+	queueJobStatus(t, service, job)
+	job.Status = "completed"
+	queueJobStatus(t, service, job)
+	if len(deliveries.items) != 1 || deliveries.items[0].EventType != domainWebhook.EventFacilityJobStatus || deliveries.items[0].ProjectID != nil {
+		t.Fatalf("deliveries = %+v, want only the completed status", deliveries.items)
+	}
+
+	for range domainWebhook.MaxDeliveryAttempts {
+		clock.now = deliveries.items[0].NextAttemptAt
+		if err := service.ProcessDueDeliveries(t.Context(), clock.now, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	delivery := deliveries.items[0]
+	if delivery.Status != domainWebhook.DeliveryStatusFailed || delivery.Attempts != domainWebhook.MaxDeliveryAttempts || receiver.count() != domainWebhook.MaxDeliveryAttempts {
+		t.Fatalf("delivery = %+v after %d requests, want failed after the last attempt", delivery, receiver.count())
+	}
+
+	if _, err := service.ReplayDelivery(t.Context(), uuid.New()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("replay of an unknown delivery = %v", err)
+	}
+	replayed, err := service.ReplayFailed(t.Context(), &subscription.ID)
+	if err != nil || replayed != 1 {
+		t.Fatalf("ReplayFailed() = %d, %v", replayed, err)
+	}
+	if delivery = deliveries.items[0]; delivery.Status != domainWebhook.DeliveryStatusPending || delivery.Attempts != 0 || !delivery.NextAttemptAt.Equal(clock.now) {
+		t.Fatalf("replayed delivery = %+v", delivery)
+	}
+	if _, err := service.ReplayDelivery(t.Context(), delivery.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("replay of a pending delivery = %v, want conflict", err)
+	}
+}
+
+func TestSubscriptionValidationKeepsJobEventsGlobal(t *testing.T) {
+	service, _, _ := newTestService(t)
+	projectID := uuid.New()
+	_, _, err := service.UpsertSubscription(t.Context(), domainWebhook.UpsertSubscriptionInput{
+		Name: "Project", URL: "ftp://example.com/hook", ProjectID: &projectID, Active: true,
+		EventTypes: []string{string(domainWebhook.EventFacilityJobStatus)}, Actions: []string{"renamed"},
+	})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Fields["url"] == "" || ve.Fields["event_types"] == "" || ve.Fields["actions"] == "" {
+		t.Fatalf("UpsertSubscription() = %v", err)
+	}
+}
+
+func TestDeliveriesRefuseInternalTargetsAndRedirects(t *testing.T) {
+	service, deliveries, clock := newTestService(t)
+	service.allowAddress = publicAddress
+	for _, target := range []string{
+		"http://169.254.169.254/latest/meta-data", "https://localhost/hook", "http://10.0.0.5/hook", "http://[::1]/hook",
+		"http://100.64.0.1/hook", "http://198.18.0.1/hook", "http://[64:ff9b::a9fe:a9fe]/hook",
+	} {
+		_, _, err := service.UpsertSubscription(t.Context(), domainWebhook.UpsertSubscriptionInput{Name: "Internal", URL: target, Active: true})
+		var ve *domain.ValidationError
+		if !errors.As(err, &ve) || ve.Fields["url"] == "" {
+			t.Fatalf("UpsertSubscription(%s) = %v, want url rejected", target, err)
+		}
+	}
+
+	// A name that resolves to loopback passes validation but not the dialer.
+	receiver := newReceiver(http.StatusNoContent)
+	defer receiver.Close()
+	service.allowAddress = func(netip.Addr) bool { return true }
+	if _, _, err := service.UpsertSubscription(t.Context(), domainWebhook.UpsertSubscriptionInput{Name: "Rebound", URL: receiver.URL, Active: true}); err != nil {
+		t.Fatal(err)
+	}
+	service.allowAddress = publicAddress
+	queueJobStatus(t, service, domainWebhook.JobStatus{JobID: uuid.New(), Status: "completed", UpdatedAt: clock.now})
+	if err := service.ProcessDueDeliveries(t.Context(), clock.now, 10); err != nil {
+		t.Fatal(err)
+	}
+	if receiver.count() != 0 || !strings.Contains(deliveries.items[0].LastError, "private or reserved") {
+		t.Fatalf("delivery reached an internal address: %d requests, %+v", receiver.count(), deliveries.items[0])
+	}
+
+	redirect := newReceiver(http.StatusFound)
+	defer redirect.Close()
+	service.allowAddress = func(netip.Addr) bool { return true }
+	if _, _, err := service.UpsertSubscription(t.Context(), domainWebhook.UpsertSubscriptionInput{Name: "Redirect", URL: redirect.URL, Active: true}); err != nil {
+		t.Fatal(err)
+	}
+	queueJobStatus(t, service, domainWebhook.JobStatus{JobID: uuid.New(), Status: "failed", UpdatedAt: clock.now})
+	if err := service.ProcessDueDeliveries(t.Context(), clock.now, 10); err != nil {
+		t.Fatal(err)
+	}
+	last := deliveries.items[len(deliveries.items)-1]
+	if redirect.count() != 1 || last.Status != domainWebhook.DeliveryStatusPending || *last.ResponseStatus != http.StatusFound {
+		t.Fatalf("redirect must count as a failed attempt, got %+v", last)
+	}
+}
+
+type testClock struct{ now time.Time }
+
+func newTestService(t *testing.T) (*Service, *deliveryStore, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	deliveries := &deliveryStore{}
+	service := New(&subscriptionStore{}, deliveries, plainCipher{})
+	service.now = func() time.Time { return clock.now }
+	// The test receivers listen on loopback.
+	service.allowAddress = func(netip.Addr) bool { return true }
+	return service, deliveries, clock
+}
+
+// queueChanges and queueJobStatus stand in for the outbox, which writes the
+// deliveries in the transaction of the event.
+func queueChanges(t *testing.T, service *Service, changes []domainProject.Change) {
+	t.Helper()
+	subscriptions, err := service.subscriptions.ListActive(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliveries, err := domainWebhook.ChangeDeliveries(subscriptions, changes, service.now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.deliveries.CreateBatch(t.Context(), deliveries); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func queueJobStatus(t *testing.T, service *Service, job domainWebhook.JobStatus) {
+	t.Helper()
+	subscriptions, err := service.subscriptions.ListActive(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliveries, err := domainWebhook.JobDeliveries(subscriptions, job, service.now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.deliveries.CreateBatch(t.Context(), deliveries); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+// newReceiver answers with the given statuses in order and repeats the last.
+func newReceiver(statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := r.statuses[min(len(r.requests), len(r.statuses))-1]
+		r.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	return r
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func (r *receiver) last() receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests[len(r.requests)-1]
+}
+
+type plainCipher struct{}
+
+func (plainCipher) Encrypt(plain string) (string, error)   { return "enc:" + plain, nil }
+func (plainCipher) Decrypt(encoded string) (string, error) { return encoded[len("enc:"):], nil }
+
+type subscriptionStore struct {
+	items []domainWebhook.Subscription
+}
+
+func (s *subscriptionStore) Create(_ context.Context, subscription *domainWebhook.Subscription) error {
+	subscription.ID = uuid.New()
+	s.items = append(s.items, *subscription)
+	return nil
+}
+
+func (s *subscriptionStore) Update(_ context.Context, subscription *domainWebhook.Subscription) error {
+	for i := range s.items {
+		if s.items[i].ID == subscription.ID {
+			s.items[i] = *subscription
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (s *subscriptionStore) DeleteByID(_ context.Context, id uuid.UUID) error {
+	s.items = slices.DeleteFunc(s.items, func(item domainWebhook.Subscription) bool { return item.ID == id })
+	return nil
+}
+
+func (s *subscriptionStore) GetByID(_ context.Context, id uuid.UUID) (*domainWebhook.Subscription, error) {
+	for _, item := range s.items {
+		if item.ID == id {
+			return &item, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (s *subscriptionStore) GetByIDs(_ context.Context, ids []uuid.UUID) ([]domainWebhook.Subscription, error) {
+	var result []domainWebhook.Subscription
+	for _, item := range s.items {
+		if slices.Contains(ids, item.ID) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func (s *subscriptionStore) List(context.Context, *uuid.UUID) ([]domainWebhook.Subscription, error) {
+	return s.items, nil
+}
+
+func (s *subscriptionStore) ListActive(context.Context) ([]domainWebhook.Subscription, error) {
+	return s.items, nil
+}
+
+type deliveryStore struct {
+	items []domainWebhook.Delivery
+}
+
+func (s *deliveryStore) CreateBatch(_ context.Context, deliveries []domainWebhook.Delivery) error {
+	for _, delivery := range deliveries {
+		delivery.ID = uuid.New()
+		s.items = append(s.items, delivery)
+	}
+	return nil
+}
+
+func (s *deliveryStore) Claim(_ context.Context, now, leaseUntil time.Time, limit int) ([]domainWebhook.Delivery, error) {
+	var claimed []domainWebhook.Delivery
+	for i := range s.items {
+		if len(claimed) < limit && s.items[i].Status == domainWebhook.DeliveryStatusPending && !s.items[i].NextAttemptAt.After(now) {
+			s.items[i].NextAttemptAt = leaseUntil
+			claimed = append(claimed, s.items[i])
+		}
+	}
+	return claimed, nil
+}
+
+func (s *deliveryStore) RecordAttempt(_ context.Context, id uuid.UUID, attempt domainWebhook.DeliveryAttempt) error {
+	for i := range s.items {
+		if s.items[i].ID == id {
+			item := &s.items[i]
+			item.Status, item.Attempts, item.NextAttemptAt = attempt.Status, attempt.Attempts, attempt.NextAttemptAt
+			item.ResponseStatus, item.LastError = attempt.ResponseStatus, attempt.Error
+			if attempt.Status == domainWebhook.DeliveryStatusDelivered {
+				item.DeliveredAt = &attempt.At
+			}
+		}
+	}
+	return nil
+}
+
+func (s *deliveryStore) GetByID(_ context.Context, id uuid.UUID) (*domainWebhook.Delivery, error) {
+	for _, item := range s.items {
+		if item.ID == id {
+			return &item, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (s *deliveryStore) List(context.Context, domainWebhook.DeliveryFilter) (*domain.PaginatedList[domainWebhook.Delivery], error) {
+	return &domain.PaginatedList[domainWebhook.Delivery]{Items: s.items, Total: int64(len(s.items))}, nil
+}
+
+func (s *deliveryStore) Replay(_ context.Context, filter domainWebhook.ReplayFilter, now time.Time) (int64, error) {
+	var replayed int64
+	for i := range s.items {
+		item := &s.items[i]
+		if item.Status != domainWebhook.DeliveryStatusFailed ||
+			(len(filter.IDs) > 0 && !slices.Contains(filter.IDs, item.ID)) ||
+			(filter.SubscriptionID != nil && item.SubscriptionID != *filter.SubscriptionID) {
+			continue
+		}
+		item.Status, item.Attempts, item.NextAttemptAt, item.LastError = domainWebhook.DeliveryStatusPending, 0, now, ""
+		replayed++
+	}
+	return replayed, nil
+}
@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var errBlockedTarget = errors.New("webhook target resolves to a private or reserved address")
+
+// reservedRanges are the ranges netip does not count as private that still
+// reach internal hosts: carrier-grade NAT (RFC 6598), the benchmark network
+// (RFC 2544) and the NAT64 prefix (RFC 6052), which maps onto any IPv4
+// address behind the translator.
+var reservedRanges = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// publicAddress reports whether a delivery may connect to addr. Loopback,
+// private, link-local (including the 169.254.169.254 metadata endpoint),
+// multicast, unspecified and the reserved ranges are refused.
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	switch {
+	case !addr.IsValid(),
+		addr.IsLoopback(),
+		addr.IsPrivate(),
+		addr.IsLinkLocalUnicast(),
+		addr.IsLinkLocalMulticast(),
+		addr.IsInterfaceLocalMulticast(),
+		addr.IsMulticast(),
+		addr.IsUnspecified(),
+		slices.ContainsFunc(reservedRanges, func(prefix netip.Prefix) bool { return prefix.Contains(addr) }),
+		addr.Is4() && addr.As4()[0] == 0:
+		return false
+	default:
+		return true
+	}
+}
+
+// newDeliveryClient checks every address after DNS resolution, so a host that
+// later rebinds to an internal address is still refused. Redirects are not
+// followed; a 3xx answer counts as a failed attempt.
+func newDeliveryClient(allow func(netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !allow(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", errBlockedTarget, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   deliveryTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// validTargetHost rejects targets that are internal on their face. Names that
+// resolve to internal addresses are refused when the delivery dials.
+func validTargetHost(target *url.URL, allow func(netip.Addr) bool) bool {
+	host := strings.TrimSuffix(strings.ToLower(target.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return allow(netip.IPv6Loopback())
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return allow(addr)
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	facilityjobs "github.com/besart951/go_infra_link/backend/internal/application/facilityjobs"
+	apptransaction "github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/google/uuid"
+)
+
+type alarmValueBulkTaskRegistrar struct {
+	runtime  *RuntimeAdapters
+	steps    facilityjobs.StepStore
+	services *facilityservice.Services
+}
+
+func registerAlarmValueBulkTasks(jobs *facilityservice.FacilityJobManager, runtime *RuntimeAdapters, services *facilityservice.Services) {
+	registrar := alarmValueBulkTaskRegistrar{runtime: runtime, services: services}
+	if runtime != nil {
+		registrar.steps = runtime.FacilityJobSteps
+	}
+	jobs.RegisterTask(facilityservice.FacilityJobTaskBulkEditAlarmValues, facilityservice.FacilityJobHandlerFunc(registrar.bulkEdit))
+}
+
+func (r alarmValueBulkTaskRegistrar) bulkEdit(ctx context.Context, execution facilityservice.FacilityJobExecution) (facilityservice.FacilityJobTaskResult, error) {
+	job, report := execution.Job, execution.Reporter.Report
+	var payload facilityservice.AlarmValueBulkEditTaskPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("decode alarm value bulk task: %w", err)
+	}
+	if r.steps == nil {
+		return facilityservice.FacilityJobTaskResult{}, errors.New("durable facility job step store is unavailable")
+	}
+	report(facilityservice.FacilityJobProgress{Progress: 2, Stage: "preparing"})
+	steps, err := r.prepareSteps(ctx, job, payload.Edit)
+	if err != nil {
+		return facilityservice.FacilityJobTaskResult{}, err
+	}
+
+	result := facilityservice.AlarmValueBulkJobResult{TotalCount: len(steps)}
+	for ordinal, input := range steps {
+		item := r.executeStep(ctx, job, int64(ordinal), input)
+		result.Results = append(result.Results, item)
+		switch {
+		case item.Success:
+			result.SuccessCount++
+		case item.Conflict:
+			result.ConflictCount++
+			result.FailureCount++
+		default:
+			result.FailureCount++
+		}
+		reportAlarmValueBulkProgress(report, ordinal+1, result)
+	}
+	r.publish(ctx, job.OwnerID, result)
+	encoded, err := json.Marshal(result)
+	return facilityservice.FacilityJobTaskResult{Result: encoded}, err
+}
+
+func (r alarmValueBulkTaskRegistrar) publish(ctx context.Context, ownerID uuid.UUID, result facilityservice.AlarmValueBulkJobResult) {
+	if r.runtime == nil || r.runtime.FacilityReferenceData == nil || result.SuccessCount == 0 {
+		return
+	}
+	ids := make([]uuid.UUID, 0, result.SuccessCount)
+	for _, item := range result.Results {
+		if item.Success {
+			ids = append(ids, item.ID)
+		}
+	}
+	r.runtime.FacilityReferenceData.BroadcastFacilityChange(ctx, "bacnet_objects", "bulk_updated", ids, &ownerID)
+}
+
+// prepareSteps resolves the selection once and persists one step per object,
+// so a resumed job works on the same objects and versions.
+func (r alarmValueBulkTaskRegistrar) prepareSteps(ctx context.Context, job facilityservice.FacilityJob, edit domainFacility.AlarmValueBulkEdit) ([]facilityservice.AlarmValueBulkEditStep, error) {
+	items, err := r.steps.ListItems(ctx, job.OwnerID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		return decodeAlarmValueBulkSteps(items)
+	}
+	selected, err := r.services.BacnetAlarmValue.SelectObjects(ctx, edit.Selection)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]facilityservice.AlarmValueBulkEditStep, len(selected))
+	steps := make([]facilityjobs.Step, len(selected))
+	for index, object := range selected {
+		inputs[index] = facilityservice.AlarmValueBulkEditStep{
+			BacnetObjectID: object.ID, BaseVersion: object.Version, Assignments: edit.Assignments,
+		}
+		if steps[index], err = alarmValueBulkStep(job, int64(index), inputs[index]); err != nil {
+			return nil, err
+		}
+	}
+	for start := 0; start < len(steps); start += facilityBulkChunkSize {
+		if err := r.steps.Prepare(ctx, steps[start:min(start+facilityBulkChunkSize, len(steps))]); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+func (r alarmValueBulkTaskRegistrar) executeStep(ctx context.Context, job facilityservice.FacilityJob, ordinal int64, input facilityservice.AlarmValueBulkEditStep) facilityservice.AlarmValueBulkJobResultItem {
+	item := facilityservice.AlarmValueBulkJobResultItem{ID: input.BacnetObjectID}
+	step, err := alarmValueBulkStep(job, ordinal, input)
+	if err == nil {
+		var stored facilityjobs.StepResult
+		stored, _, err = r.steps.Execute(ctx, step, func(itemCtx context.Context, unit apptransaction.UnitOfWork) (facilityjobs.StepResult, error) {
+			services, buildErr := facilityServicesFromUnit(unit)
+			if buildErr != nil {
+				return facilityjobs.StepResult{}, buildErr
+			}
+			version, applyErr := services.BacnetAlarmValue.ApplyAssignments(itemCtx, input.BacnetObjectID, input.BaseVersion, input.Assignments)
+			if applyErr != nil {
+				return facilityjobs.StepResult{}, applyErr
+			}
+			encoded, encodeErr := json.Marshal(facilityservice.AlarmValueBulkJobResultItem{ID: input.BacnetObjectID, Success: true, Version: version})
+			return facilityjobs.StepResult{TargetID: input.BacnetObjectID, Result: encoded}, encodeErr
+		})
+		if err == nil {
+			err = json.Unmarshal(stored.Result, &item)
+		}
+	}
+	if err != nil {
+		item.Success = false
+		item.Error = err.Error()
+		item.Conflict = errors.Is(err, domain.ErrConflict)
+		if validationErr, ok := domain.AsValidationError(err); ok {
+			item.Fields = validationErr.Fields
+		}
+	}
+	return item
+}
+
+func alarmValueBulkStep(job facilityservice.FacilityJob, ordinal int64, input facilityservice.AlarmValueBulkEditStep) (facilityjobs.Step, error) {
+	encoded, err := json.Marshal(input)
+	return facilityjobs.Step{
+		Key:        facilityjobs.ItemKey{OwnerID: job.OwnerID, JobID: job.ID, Ordinal: ordinal},
+		EntityType: "bacnet_object_alarm_values", SourceID: input.BacnetObjectID, Input: encoded,
+	}, err
+}
+
+func decodeAlarmValueBulkSteps(items []facilityjobs.Item) ([]facilityservice.AlarmValueBulkEditStep, error) {
+	steps := make([]facilityservice.AlarmValueBulkEditStep, len(items))
+	for index, item := range items {
+		if err := json.Unmarshal(item.Input, &steps[index]); err != nil {
+			return nil, fmt.Errorf("decode persisted alarm value bulk step: %w", err)
+		}
+	}
+	return steps, nil
+}
+
+func reportAlarmValueBulkProgress(report func(facilityservice.FacilityJobProgress), processed int, result facilityservice.AlarmValueBulkJobResult) {
+	if processed%facilityBulkChunkSize != 0 && processed != result.TotalCount {
+		return
+	}
+	total := int64(result.TotalCount)
+	report(facilityservice.FacilityJobProgress{
+		Progress: 5 + int(90*int64(processed)/max(total, 1)), Stage: "processing_items",
+		Processed: int64(processed), Total: &total,
+		Succeeded: int64(result.SuccessCount), Failed: int64(result.FailureCount),
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	exportinfra "github.com/besart951/go_infra_link/backend/internal/infrastructure/exporting"
 	facilityrepo "github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	"gorm.io/gorm"
 )
 
@@ -45,16 +46,27 @@ func newExportService(db *gorm.DB, repos *Repositories, cfg ServiceConfig, jobs
 		})
 	}
 	excelGenerator := exportinfra.NewExcelizeGenerator()
+	excelGenerator.SetTranslations(repos.FacilityTranslations)
 	return exportservice.NewService(
 		dataProvider,
 		excelGenerator,
 		excelGenerator,
+		exportinfra.NewEDEGenerator(),
+		exportinfra.NewNetworkConfigGenerator(),
+		exportinfra.NewDocumentGenerator(),
 		fileStore,
 		jobs,
 		resolveExportConfig(cfg.Export),
 	), nil
 }
 
+func changeReportLabels(translator *i18n.Translator) domainExport.LabelCatalog {
+	if translator == nil {
+		return nil
+	}
+	return translator
+}
+
 func resolveExportDirectory(cfg ServiceConfig) string {
 	if strings.TrimSpace(cfg.ExportDirectory) == "" {
 		return defaultExportDirectory()
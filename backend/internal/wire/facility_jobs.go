@@ -15,7 +15,7 @@ type facilityCopyOperation struct {
 	task       string
 	resource   string
 	entityType string
-	copy       func(context.Context, *facilityservice.Services, uuid.UUID) (uuid.UUID, error)
+	copy       func(context.Context, *facilityservice.Services, facilityservice.FacilityCopyTaskPayload) (uuid.UUID, error)
 }
 
 type facilityCopyTaskRegistrar struct {
@@ -33,6 +33,8 @@ func registerFacilityCopyTasks(jobs *facilityservice.FacilityJobManager, service
 		jobs.RegisterTask(operation.task, registrar.taskHandler(operation))
 	}
 	registerFieldDeviceBulkTasks(jobs, runtime, services)
+	registerAlarmValueBulkTasks(jobs, runtime, services.Facility)
+	registerObjectDataUpgradeTasks(jobs, runtime, services.Facility)
 	registerFacilityDeleteTasks(jobs, runtime)
 }
 
@@ -101,7 +103,7 @@ func (r facilityCopyTaskRegistrar) executeStep(ctx context.Context, execution fa
 
 func runFacilityCopy(ctx context.Context, services *facilityservice.Services, execution facilityCopyExecution) (facilityjobs.StepResult, error) {
 	ctx = facilityservice.WithFacilityJobReporter(ctx, execution.reporter)
-	resultID, err := execution.operation.copy(ctx, services, execution.payload.SourceID)
+	resultID, err := execution.operation.copy(ctx, services, execution.payload)
 	if err != nil {
 		return facilityjobs.StepResult{}, err
 	}
@@ -129,40 +131,40 @@ func (r facilityCopyTaskRegistrar) publish(ctx context.Context, notification fac
 	)
 }
 
-func copyControlCabinet(ctx context.Context, services *facilityservice.Services, id uuid.UUID) (uuid.UUID, error) {
-	item, err := services.ControlCabinet.CopyByID(ctx, id)
+func copyControlCabinet(ctx context.Context, services *facilityservice.Services, payload facilityservice.FacilityCopyTaskPayload) (uuid.UUID, error) {
+	item, err := services.ControlCabinet.CopyByID(ctx, payload.SourceID)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return item.ID, nil
 }
 
-func copySPSController(ctx context.Context, services *facilityservice.Services, id uuid.UUID) (uuid.UUID, error) {
-	item, err := services.SPSController.CopyByID(ctx, id)
+func copySPSController(ctx context.Context, services *facilityservice.Services, payload facilityservice.FacilityCopyTaskPayload) (uuid.UUID, error) {
+	item, err := services.SPSController.CopyByID(ctx, payload.SourceID)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return item.ID, nil
 }
 
-func copySPSControllerSystemType(ctx context.Context, services *facilityservice.Services, id uuid.UUID) (uuid.UUID, error) {
-	item, err := services.SPSControllerSystemType.CopyByID(ctx, id)
+func copySPSControllerSystemType(ctx context.Context, services *facilityservice.Services, payload facilityservice.FacilityCopyTaskPayload) (uuid.UUID, error) {
+	item, err := services.SPSControllerSystemType.CopyByID(ctx, payload.SourceID)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return item.ID, nil
 }
 
-func copyFieldDevice(ctx context.Context, services *facilityservice.Services, id uuid.UUID) (uuid.UUID, error) {
-	item, err := services.FieldDevice.CopyByID(ctx, id)
+func copyFieldDevice(ctx context.Context, services *facilityservice.Services, payload facilityservice.FacilityCopyTaskPayload) (uuid.UUID, error) {
+	item, err := services.FieldDevice.CopyWithNumbering(ctx, payload.SourceID, payload.Numbering)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return item.ID, nil
 }
 
-func copyObjectData(ctx context.Context, services *facilityservice.Services, id uuid.UUID) (uuid.UUID, error) {
-	item, err := services.ObjectData.CopyByID(ctx, id)
+func copyObjectData(ctx context.Context, services *facilityservice.Services, payload facilityservice.FacilityCopyTaskPayload) (uuid.UUID, error) {
+	item, err := services.ObjectData.CopyByID(ctx, payload.SourceID)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -0,0 +1,60 @@
+package wire
+
+import (
+	"context"
+
+	facilitysync "github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	infrarealtime "github.com/besart951/go_infra_link/backend/internal/infrastructure/realtime"
+	"github.com/besart951/go_infra_link/backend/internal/repository/facilitysyncsql"
+	"github.com/besart951/go_infra_link/backend/internal/repository/historysql"
+	"github.com/besart951/go_infra_link/backend/internal/service/auditctx"
+	projectservice "github.com/besart951/go_infra_link/backend/internal/service/project"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func newFacilitySyncService(gormDB *gorm.DB, projects *projectservice.Services, cfg ServiceConfig) *facilitysync.Service {
+	service := facilitysync.NewService(facilitysyncsql.NewStore(gormDB, historysql.NewStore(gormDB)))
+	if projects.Changes != nil {
+		recorder := facilitySyncChangeRecorder{changes: projects.Changes}
+		if cfg.Runtime != nil {
+			recorder.collaboration = cfg.Runtime.ProjectCollaboration
+		}
+		service.SetChangeRecorder(recorder)
+	}
+	return service
+}
+
+// NewFacilitySyncService builds the service for the facility-sync command.
+// Applied bundles reach the project change feed like those applied through
+// the API; there is no realtime hub to publish them to.
+func NewFacilitySyncService(gormDB *gorm.DB) *facilitysync.Service {
+	service := facilitysync.NewService(facilitysyncsql.NewStore(gormDB, historysql.NewStore(gormDB)))
+	service.SetChangeRecorder(facilitySyncChangeRecorder{changes: projectservice.NewChangeService(newProjectChangeStore(gormDB))})
+	return service
+}
+
+// facilitySyncChangeRecorder writes applied bundles to the project change feed
+// and publishes them to the project's collaborators.
+type facilitySyncChangeRecorder struct {
+	changes       *projectservice.ChangeService
+	collaboration *infrarealtime.ProjectCollaborationHub
+}
+
+func (r facilitySyncChangeRecorder) RecordChanges(ctx context.Context, projectID uuid.UUID, aggregate, action string, ids []uuid.UUID) error {
+	entityIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		entityIDs = append(entityIDs, id.String())
+	}
+	actorID, _ := auditctx.ActorID(ctx)
+	changes, err := r.changes.RecordEvents(ctx, projectID, "project."+aggregate+"."+action, actorID, entityIDs...)
+	if err != nil || r.collaboration == nil {
+		return err
+	}
+	for _, change := range changes {
+		if realtimeChange, ok := infrarealtime.ProjectChangeFromDomain(change); ok {
+			_ = r.collaboration.BroadcastProjectChange(ctx, realtimeChange)
+		}
+	}
+	return nil
+}
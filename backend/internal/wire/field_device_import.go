@@ -2,8 +2,10 @@ package wire
 
 import (
 	"context"
+	"errors"
 
 	fielddeviceimport "github.com/besart951/go_infra_link/backend/internal/application/fielddeviceimport"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
 	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
 	importing "github.com/besart951/go_infra_link/backend/internal/infrastructure/importing"
 	importsql "github.com/besart951/go_infra_link/backend/internal/repository/importsql"
@@ -11,19 +13,26 @@ import (
 	"github.com/google/uuid"
 )
 
-func newFieldDeviceImportService(runtime *RuntimeAdapters, fieldDevices *facilityservice.FieldDeviceService) *fielddeviceimport.Service {
-	if runtime == nil || runtime.DB == nil || fieldDevices == nil {
+func newFieldDeviceImportService(runtime *RuntimeAdapters, facility *facilityservice.Services) *fielddeviceimport.Service {
+	if runtime == nil || runtime.DB == nil || facility == nil || facility.FieldDevice == nil {
 		return nil
 	}
-	return fielddeviceimport.NewService(
-		importing.NewArchiveReader(importing.NewExcelizeReader()),
+	service := fielddeviceimport.NewService(
+		importing.NewFormatReader(importing.NewArchiveReader(importing.NewExcelizeReader()), importing.NewDocumentReader()),
 		importsql.NewStore(runtime.DB),
-		fieldDeviceAggregateWriter{service: fieldDevices},
+		fieldDeviceAggregateWriter{service: facility.FieldDevice, alarms: facility.BacnetAlarmValue},
 	)
+	service.SetEditReader(importing.NewEditReader())
+	service.SetEDEReaders(importing.EDECatalog{
+		SystemParts: facility.SystemPart, Apparats: facility.Apparat,
+		StateTexts: facility.StateText, NotificationClasses: facility.NotificationClass,
+	})
+	return service
 }
 
 type fieldDeviceAggregateWriter struct {
 	service *facilityservice.FieldDeviceService
+	alarms  *facilityservice.BacnetAlarmValueService
 }
 
 func (w fieldDeviceAggregateWriter) Import(ctx context.Context, aggregate fielddeviceimport.Aggregate) error {
@@ -41,3 +50,38 @@ func (w fieldDeviceAggregateWriter) Import(ctx context.Context, aggregate fieldd
 		FieldDevice: aggregate.FieldDevice, Specification: aggregate.Specification, BacnetObjects: aggregate.BacnetObjects,
 	})
 }
+
+func (w fieldDeviceAggregateWriter) Current(ctx context.Context, fieldDeviceID uuid.UUID) (*fielddeviceimport.Aggregate, error) {
+	device, err := w.service.GetByID(ctx, fieldDeviceID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	objects, err := w.service.ListBacnetObjects(ctx, fieldDeviceID)
+	if err != nil {
+		return nil, err
+	}
+	for index := range objects {
+		values, err := w.alarms.GetValues(ctx, objects[index].ID)
+		if err != nil {
+			return nil, err
+		}
+		objects[index].AlarmValues = values.Items
+	}
+	return &fielddeviceimport.Aggregate{FieldDevice: *device, Specification: device.Specification, BacnetObjects: objects}, nil
+}
+
+func (w fieldDeviceAggregateWriter) Update(ctx context.Context, update domainFacility.BulkFieldDeviceUpdate) error {
+	result := w.service.BulkUpdate(ctx, []domainFacility.BulkFieldDeviceUpdate{update})
+	if result == nil || result.FailureCount == 0 {
+		return nil
+	}
+	for _, item := range result.Results {
+		if !item.Success {
+			return errors.New(item.Error)
+		}
+	}
+	return nil
+}
@@ -33,6 +33,10 @@ func newProjectHandlers(services *Services, runtime *RuntimeAdapters, facilityJo
 		Collaboration: runtime.ProjectCollaboration,
 		FacilityJobs:  facilityJobs,
 		Export:        services.Export,
+		FacilitySync:  services.FacilitySync,
+		Baseline:      services.Baseline,
+
+		ObjectDataLineage: services.Facility.ObjectData,
 	})
 }
 
@@ -49,10 +53,12 @@ func newFacilityHandlers(services *Services, collaboration facilityhandler.Proje
 		StateText:               services.Facility.StateText,
 		NotificationClass:       services.Facility.NotificationClass,
 		AlarmDefinition:         services.Facility.AlarmDefinition,
+		AlarmDefinitionOverride: services.Facility.AlarmDefinitionOverride,
 		ObjectData:              services.Facility.ObjectData,
 		SPSControllerSystemType: services.Facility.SPSControllerSystemType,
 		Export:                  services.Export,
 		Import:                  imports,
+		ReferenceCatalog:        referenceCatalogHandlerService(services.ReferenceCatalog),
 		ExportDownload:          exportservice.NewDownloadPolicy(services.Project.AccessPolicy, services.RBAC),
 		AlarmType:               services.Facility.AlarmType,
 		Unit:                    services.Facility.Unit,
@@ -61,9 +67,13 @@ func newFacilityHandlers(services *Services, collaboration facilityhandler.Proje
 		BacnetAlarm:             services.Facility.BacnetAlarmValue,
 		BacnetReferenceUsage:    services.Facility.BacnetReferenceUsage,
 		DeleteImpact:            services.Facility.DeleteImpact,
+		IPAM:                    services.Facility.IPAM,
 		FacilityJobs:            facilityJobs,
 		Collaboration:           collaboration,
 		ReferenceData:           referenceData,
+
+		Translation:  services.Facility.Translation,
+		NamingScheme: services.Facility.NamingScheme,
 	})
 }
 
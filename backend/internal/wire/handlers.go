@@ -10,6 +10,7 @@ import (
 	i18nhandler "github.com/besart951/go_infra_link/backend/internal/handler/i18n"
 	notificationhandler "github.com/besart951/go_infra_link/backend/internal/handler/notification"
 	teamhandler "github.com/besart951/go_infra_link/backend/internal/handler/team"
+	webhookhandler "github.com/besart951/go_infra_link/backend/internal/handler/webhook"
 	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 )
 
@@ -23,7 +24,7 @@ func NewHandlers(services *Services, runtime *RuntimeAdapters, cookieSettings au
 
 	projectHandlers := newProjectHandlers(services, runtime, facilityJobs)
 
-	imports := newFieldDeviceImportService(runtime, services.Facility.FieldDevice)
+	imports := newFieldDeviceImportService(runtime, services.Facility)
 	facilityHandlers := newFacilityHandlers(services, projectHandlers.RefreshBroadcaster, runtime.FacilityReferenceData, facilityJobs, imports)
 	userHandlers := newUserHandlers(services)
 
@@ -37,6 +38,9 @@ func NewHandlers(services *Services, runtime *RuntimeAdapters, cookieSettings au
 		cookieSettings,
 	)
 
+	historyHandler := historyhandler.NewHandler(services.History, facilityJobs)
+	historyHandler.SetChangeReports(services.Export)
+
 	return &handler.Handlers{
 		Auth:             authHandler,
 		AuthRegistration: authhandler.NewRegistrationHandler(services.UserRegistration),
@@ -47,6 +51,7 @@ func NewHandlers(services *Services, runtime *RuntimeAdapters, cookieSettings au
 		Team:             teamhandler.NewTeamHandler(services.Team),
 		User:             userHandlers,
 		Facility:         facilityHandlers,
-		History:          historyhandler.NewHandler(services.History, facilityJobs),
+		History:          historyHandler,
+		Webhook:          webhookhandler.NewWebhookHandler(services.Webhook),
 	}
 }
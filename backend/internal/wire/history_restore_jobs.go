@@ -17,8 +17,9 @@ import (
 )
 
 type historyRestoreExecution struct {
+	root    hierarchyrestore.Root
 	job     facilityservice.FacilityJob
-	payload domainHistory.RestoreControlCabinetJobPayload
+	payload domainHistory.RestoreHierarchyJobPayload
 	report  func(facilityservice.FacilityJobProgress)
 	asOf    time.Time
 }
@@ -37,6 +38,14 @@ type historyRestoreTaskRegistrar struct {
 	}
 }
 
+var historyRestoreTaskRoots = map[string]hierarchyrestore.Root{
+	domainHistory.TaskRestoreBuilding:                hierarchyrestore.RootBuilding,
+	domainHistory.TaskRestoreControlCabinet:          hierarchyrestore.RootControlCabinet,
+	domainHistory.TaskRestoreSPSController:           hierarchyrestore.RootSPSController,
+	domainHistory.TaskRestoreSPSControllerSystemType: hierarchyrestore.RootSPSControllerSystemType,
+	domainHistory.TaskRestoreObjectData:              hierarchyrestore.RootObjectData,
+}
+
 func registerHistoryRestoreTasks(jobs *facilityservice.FacilityJobManager, runtime *RuntimeAdapters, history HistoryRepository) {
 	if jobs == nil || history == nil {
 		return
@@ -45,13 +54,15 @@ func registerHistoryRestoreTasks(jobs *facilityservice.FacilityJobManager, runti
 	if runtime != nil {
 		registrar.steps = runtime.FacilityJobSteps
 	}
-	jobs.RegisterTask(domainHistory.TaskRestoreControlCabinet, registrar.handler())
+	for task, root := range historyRestoreTaskRoots {
+		jobs.RegisterTask(task, registrar.handler(root))
+	}
 }
 
-func (r historyRestoreTaskRegistrar) handler() facilityservice.FacilityJobHandler {
+func (r historyRestoreTaskRegistrar) handler(root hierarchyrestore.Root) facilityservice.FacilityJobHandler {
 	return facilityservice.FacilityJobHandlerFunc(func(ctx context.Context, task facilityservice.FacilityJobExecution) (facilityservice.FacilityJobTaskResult, error) {
 		job, report := task.Job, task.Reporter.Report
-		payload, err := decodeHistoryRestorePayload(job.Payload)
+		payload, err := decodeHistoryRestorePayload(root, job.Payload)
 		if err != nil {
 			return facilityservice.FacilityJobTaskResult{}, err
 		}
@@ -59,7 +70,7 @@ func (r historyRestoreTaskRegistrar) handler() facilityservice.FacilityJobHandle
 		if err != nil {
 			return facilityservice.FacilityJobTaskResult{}, err
 		}
-		result, err := r.execute(ctx, historyRestoreExecution{job: job, payload: payload, report: report, asOf: asOf})
+		result, err := r.execute(ctx, historyRestoreExecution{root: root, job: job, payload: payload, report: report, asOf: asOf})
 		return facilityservice.FacilityJobTaskResult{Result: result}, err
 	})
 }
@@ -75,7 +86,7 @@ func (r historyRestoreTaskRegistrar) execute(ctx context.Context, execution hist
 	phases := hierarchyrestore.Phases()
 	for position.PhaseIndex < len(phases) {
 		phase := phases[position.PhaseIndex]
-		tables := hierarchyrestore.Tables(phase)
+		tables := hierarchyrestore.Tables(execution.root, phase)
 		if position.TableIndex >= len(tables) {
 			position.PhaseIndex++
 			position.TableIndex, position.AfterID = 0, uuid.Nil
@@ -88,7 +99,7 @@ func (r historyRestoreTaskRegistrar) execute(ctx context.Context, execution hist
 			return nil, err
 		}
 		advanceRestorePosition(&position, result)
-		if err := reportHistoryRestoreCheckpoint(execution, position, phases); err != nil {
+		if err := reportHistoryRestoreCheckpoint(execution, position); err != nil {
 			return nil, err
 		}
 	}
@@ -101,7 +112,7 @@ func (r historyRestoreTaskRegistrar) execute(ctx context.Context, execution hist
 func (r historyRestoreTaskRegistrar) executeChunk(ctx context.Context, chunk historyRestoreChunk) (hierarchyrestore.Result, error) {
 	step := facilityjobs.Step{
 		Key:        facilityjobs.ItemKey{OwnerID: chunk.execution.job.OwnerID, JobID: chunk.execution.job.ID, Ordinal: chunk.position.Ordinal},
-		EntityType: "control_cabinet.restore." + string(chunk.phase) + "." + chunk.table,
+		EntityType: string(chunk.execution.root) + ".restore." + string(chunk.phase) + "." + chunk.table,
 		SourceID:   chunk.payloadID(), Input: chunk.execution.job.Payload,
 		PersistIDMapping: true,
 	}
@@ -110,7 +121,13 @@ func (r historyRestoreTaskRegistrar) executeChunk(ctx context.Context, chunk his
 		if dbErr != nil {
 			return facilityjobs.StepResult{}, dbErr
 		}
-		restored, restoreErr := historysql.NewStore(db).RestoreChunk(stepCtx, chunk.command())
+		store := historysql.NewStore(db)
+		if chunk.checksConflicts() {
+			if conflictErr := store.CheckHierarchyRestore(stepCtx, chunk.execution.command()); conflictErr != nil {
+				return facilityjobs.StepResult{}, conflictErr
+			}
+		}
+		restored, restoreErr := store.RestoreChunk(stepCtx, chunk.command())
 		if restoreErr != nil {
 			return facilityjobs.StepResult{}, restoreErr
 		}
@@ -126,21 +143,33 @@ func (r historyRestoreTaskRegistrar) executeChunk(ctx context.Context, chunk his
 }
 
 func (c historyRestoreChunk) command() hierarchyrestore.Command {
-	return hierarchyrestore.Command{
-		ControlCabinetID: c.payloadID(), ProjectID: c.execution.payload.ProjectID,
-		AsOf: c.execution.asOf, Phase: c.phase, Table: c.table, AfterID: c.position.AfterID,
-		Limit: 500, ActorID: c.execution.job.OwnerID, BatchID: c.execution.job.ID,
-	}
+	command := c.execution.command()
+	command.Phase, command.Table, command.AfterID = c.phase, c.table, c.position.AfterID
+	return command
+}
+
+// checksConflicts reports whether the chunk is the first step of the job,
+// which runs the undo-conflict preflight inside the same transaction. Rows
+// changed between submit and start are caught there unless forced.
+func (c historyRestoreChunk) checksConflicts() bool {
+	return c.position.Ordinal == 0 && !c.execution.payload.Force
 }
 
 func (c historyRestoreChunk) payloadID() uuid.UUID {
-	return c.execution.payload.ControlCabinetID
+	return c.execution.payload.RootID
+}
+
+func (e historyRestoreExecution) command() hierarchyrestore.Command {
+	return hierarchyrestore.Command{
+		Root: e.root, RootID: e.payload.RootID, ProjectID: e.payload.ProjectID,
+		AsOf: e.asOf, Limit: 500, ActorID: e.job.OwnerID, BatchID: e.job.ID,
+	}
 }
 
 func (r historyRestoreTaskRegistrar) finalize(ctx context.Context, execution historyRestoreExecution, ordinal int64) error {
 	step := facilityjobs.Step{
 		Key:        facilityjobs.ItemKey{OwnerID: execution.job.OwnerID, JobID: execution.job.ID, Ordinal: ordinal},
-		EntityType: "control_cabinet.restore.finalize", SourceID: execution.payload.ControlCabinetID,
+		EntityType: string(execution.root) + ".restore.finalize", SourceID: execution.payload.RootID,
 		Input:            execution.job.Payload,
 		PersistIDMapping: true,
 	}
@@ -149,15 +178,15 @@ func (r historyRestoreTaskRegistrar) finalize(ctx context.Context, execution his
 		if dbErr != nil {
 			return facilityjobs.StepResult{}, dbErr
 		}
-		if releaseErr := historysql.ReleaseRestoreLifecycle(stepCtx, db, execution.payload.ControlCabinetID); releaseErr != nil {
+		if releaseErr := historysql.ReleaseRestoreLifecycle(stepCtx, db, execution.command()); releaseErr != nil {
 			return facilityjobs.StepResult{}, releaseErr
 		}
-		return facilityjobs.StepResult{TargetID: execution.payload.ControlCabinetID, Result: json.RawMessage(`{"finalized":true}`)}, nil
+		return facilityjobs.StepResult{TargetID: execution.payload.RootID, Result: json.RawMessage(`{"finalized":true}`)}, nil
 	})
 	return err
 }
 
-func (r historyRestoreTaskRegistrar) resolveRestoreTime(ctx context.Context, payload domainHistory.RestoreControlCabinetJobPayload) (time.Time, error) {
+func (r historyRestoreTaskRegistrar) resolveRestoreTime(ctx context.Context, payload domainHistory.RestoreHierarchyJobPayload) (time.Time, error) {
 	if payload.EventID == nil || *payload.EventID == uuid.Nil {
 		if payload.AsOf != nil {
 			return payload.AsOf.UTC(), nil
@@ -185,15 +214,15 @@ func advanceRestorePosition(position *hierarchyrestore.Position, result hierarch
 	position.AfterID = result.NextID
 }
 
-func reportHistoryRestoreCheckpoint(execution historyRestoreExecution, position hierarchyrestore.Position, phases []hierarchyrestore.Phase) error {
+func reportHistoryRestoreCheckpoint(execution historyRestoreExecution, position hierarchyrestore.Position) error {
 	encoded, err := json.Marshal(position)
 	if err != nil {
 		return err
 	}
 	totalTables := 0
 	completedTables := position.TableIndex
-	for index, phase := range phases {
-		tables := hierarchyrestore.Tables(phase)
+	for index, phase := range hierarchyrestore.Phases() {
+		tables := hierarchyrestore.Tables(execution.root, phase)
 		totalTables += len(tables)
 		if index < position.PhaseIndex {
 			completedTables += len(tables)
@@ -207,13 +236,24 @@ func reportHistoryRestoreCheckpoint(execution historyRestoreExecution, position
 	return nil
 }
 
-func decodeHistoryRestorePayload(data json.RawMessage) (domainHistory.RestoreControlCabinetJobPayload, error) {
-	var payload domainHistory.RestoreControlCabinetJobPayload
-	if err := json.Unmarshal(data, &payload); err != nil {
+// decodeHistoryRestorePayload normalizes the control cabinet payload, which
+// predates the generic root_id shape, into RestoreHierarchyJobPayload.
+func decodeHistoryRestorePayload(root hierarchyrestore.Root, data json.RawMessage) (domainHistory.RestoreHierarchyJobPayload, error) {
+	var payload domainHistory.RestoreHierarchyJobPayload
+	if root == hierarchyrestore.RootControlCabinet {
+		var cabinet domainHistory.RestoreControlCabinetJobPayload
+		if err := json.Unmarshal(data, &cabinet); err != nil {
+			return payload, fmt.Errorf("decode restore payload: %w", err)
+		}
+		payload = domainHistory.RestoreHierarchyJobPayload{
+			RootID: cabinet.ControlCabinetID, ProjectID: cabinet.ProjectID, AsOf: cabinet.AsOf, EventID: cabinet.EventID,
+			Force: cabinet.Force,
+		}
+	} else if err := json.Unmarshal(data, &payload); err != nil {
 		return payload, fmt.Errorf("decode restore payload: %w", err)
 	}
-	if payload.ControlCabinetID == uuid.Nil {
-		return payload, fmt.Errorf("decode restore payload: control_cabinet_id is required")
+	if payload.RootID == uuid.Nil {
+		return payload, fmt.Errorf("decode restore payload: %s id is required", root)
 	}
 	return payload, nil
 }
@@ -229,8 +269,8 @@ func decodeHistoryRestorePosition(data json.RawMessage) (hierarchyrestore.Positi
 
 func encodeHistoryRestoreResult(execution historyRestoreExecution, position hierarchyrestore.Position) (json.RawMessage, error) {
 	return json.Marshal(map[string]any{
-		"resource_id": execution.payload.ControlCabinetID,
-		"batch_id":    execution.job.ID, "restored_count": position.Restored,
+		"resource_id": execution.payload.RootID, "root": execution.root,
+		"batch_id": execution.job.ID, "restored_count": position.Restored,
 		"deleted_count": position.Deleted, "skipped_count": position.Skipped,
 	})
 }
@@ -5,6 +5,7 @@ import (
 
 	domainNotification "github.com/besart951/go_infra_link/backend/internal/domain/notification"
 	notificationservice "github.com/besart951/go_infra_link/backend/internal/service/notification"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 )
 
 func newNotificationService(repos *Repositories, cfg ServiceConfig) (*notificationservice.Service, error) {
@@ -27,9 +28,17 @@ func newNotificationService(repos *Repositories, cfg ServiceConfig) (*notificati
 	}, notificationservice.Config{
 		VerificationSecret: cfg.JWTSecret,
 		SystemPublisher:    systemNotificationPublisher(runtimeOrNil(cfg.Runtime)),
+		Templates:          notificationTemplates(cfg.Translator),
 	}), nil
 }
 
+func notificationTemplates(translator *i18n.Translator) domainNotification.TemplateCatalog {
+	if translator == nil {
+		return nil
+	}
+	return translator
+}
+
 func systemNotificationPublisher(runtime *RuntimeAdapters) domainNotification.SystemNotificationPublisher {
 	if runtime == nil {
 		return nil
@@ -0,0 +1,176 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	facilityjobs "github.com/besart951/go_infra_link/backend/internal/application/facilityjobs"
+	apptransaction "github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	"github.com/besart951/go_infra_link/backend/internal/domain"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"github.com/google/uuid"
+)
+
+type objectDataUpgradeTaskRegistrar struct {
+	runtime  *RuntimeAdapters
+	steps    facilityjobs.StepStore
+	services *facilityservice.Services
+}
+
+func registerObjectDataUpgradeTasks(jobs *facilityservice.FacilityJobManager, runtime *RuntimeAdapters, services *facilityservice.Services) {
+	registrar := objectDataUpgradeTaskRegistrar{runtime: runtime, services: services}
+	if runtime != nil {
+		registrar.steps = runtime.FacilityJobSteps
+	}
+	jobs.RegisterTask(facilityservice.FacilityJobTaskUpgradeObjectDataDevices, facilityservice.FacilityJobHandlerFunc(registrar.upgrade))
+}
+
+func (r objectDataUpgradeTaskRegistrar) upgrade(ctx context.Context, execution facilityservice.FacilityJobExecution) (facilityservice.FacilityJobTaskResult, error) {
+	job, report := execution.Job, execution.Reporter.Report
+	var payload facilityservice.ObjectDataUpgradeTaskPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return facilityservice.FacilityJobTaskResult{}, fmt.Errorf("decode object data upgrade task: %w", err)
+	}
+	if r.steps == nil {
+		return facilityservice.FacilityJobTaskResult{}, errors.New("durable facility job step store is unavailable")
+	}
+	report(facilityservice.FacilityJobProgress{Progress: 2, Stage: "preparing"})
+	steps, err := r.prepareSteps(ctx, job, payload)
+	if err != nil {
+		return facilityservice.FacilityJobTaskResult{}, err
+	}
+
+	result := facilityservice.ObjectDataUpgradeJobResult{TotalCount: len(steps)}
+	for ordinal, input := range steps {
+		result.TargetRevision = input.TargetRevision
+		item := r.executeStep(ctx, job, int64(ordinal), input)
+		result.Results = append(result.Results, item)
+		switch {
+		case item.Success:
+			result.SuccessCount++
+		case item.Conflict:
+			result.ConflictCount++
+			result.FailureCount++
+		default:
+			result.FailureCount++
+		}
+		reportObjectDataUpgradeProgress(report, ordinal+1, result)
+	}
+	r.publish(ctx, job.OwnerID, result)
+	encoded, err := json.Marshal(result)
+	return facilityservice.FacilityJobTaskResult{Result: encoded}, err
+}
+
+func (r objectDataUpgradeTaskRegistrar) publish(ctx context.Context, ownerID uuid.UUID, result facilityservice.ObjectDataUpgradeJobResult) {
+	if r.runtime == nil || r.runtime.FacilityReferenceData == nil || result.SuccessCount == 0 {
+		return
+	}
+	ids := make([]uuid.UUID, 0, result.SuccessCount)
+	for _, item := range result.Results {
+		if item.Success {
+			ids = append(ids, item.ID)
+		}
+	}
+	r.runtime.FacilityReferenceData.BroadcastFacilityChange(ctx, "field_devices", "bulk_updated", ids, &ownerID)
+}
+
+// prepareSteps freezes the target revision once and persists one step per
+// field device, so a resumed job upgrades the same devices to the same
+// revision even if the templates changed in between.
+func (r objectDataUpgradeTaskRegistrar) prepareSteps(ctx context.Context, job facilityservice.FacilityJob, payload facilityservice.ObjectDataUpgradeTaskPayload) ([]facilityservice.ObjectDataUpgradeStep, error) {
+	items, err := r.steps.ListItems(ctx, job.OwnerID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		return decodeObjectDataUpgradeSteps(items)
+	}
+	plan, err := r.services.ObjectData.PrepareUpgrade(ctx, payload.ObjectDataID, payload.FieldDeviceIDs)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]facilityservice.ObjectDataUpgradeStep, len(plan.FieldDevices))
+	steps := make([]facilityjobs.Step, len(plan.FieldDevices))
+	for index, device := range plan.FieldDevices {
+		inputs[index] = facilityservice.ObjectDataUpgradeStep{
+			FieldDeviceID: device.FieldDeviceID, BaseVersion: device.Version, TargetRevision: plan.TargetRevision,
+		}
+		if steps[index], err = objectDataUpgradeStep(job, int64(index), inputs[index]); err != nil {
+			return nil, err
+		}
+	}
+	for start := 0; start < len(steps); start += facilityBulkChunkSize {
+		if err := r.steps.Prepare(ctx, steps[start:min(start+facilityBulkChunkSize, len(steps))]); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+func (r objectDataUpgradeTaskRegistrar) executeStep(ctx context.Context, job facilityservice.FacilityJob, ordinal int64, input facilityservice.ObjectDataUpgradeStep) facilityservice.ObjectDataUpgradeJobResultItem {
+	item := facilityservice.ObjectDataUpgradeJobResultItem{ID: input.FieldDeviceID}
+	step, err := objectDataUpgradeStep(job, ordinal, input)
+	if err == nil {
+		var stored facilityjobs.StepResult
+		stored, _, err = r.steps.Execute(ctx, step, func(itemCtx context.Context, unit apptransaction.UnitOfWork) (facilityjobs.StepResult, error) {
+			services, buildErr := facilityServicesFromUnit(unit)
+			if buildErr != nil {
+				return facilityjobs.StepResult{}, buildErr
+			}
+			upgrade, upgradeErr := services.FieldDevice.UpgradeObjectData(itemCtx, input.FieldDeviceID, input.BaseVersion, input.TargetRevision)
+			if upgradeErr != nil {
+				return facilityjobs.StepResult{}, upgradeErr
+			}
+			encoded, encodeErr := json.Marshal(facilityservice.ObjectDataUpgradeJobResultItem{
+				ID: input.FieldDeviceID, Success: true, Version: upgrade.Version,
+				FromRevision: upgrade.FromRevision, ToRevision: upgrade.ToRevision,
+				Added: upgrade.Added, Updated: upgrade.Updated, Removed: upgrade.Removed, KeptOverrides: upgrade.Conflicts,
+			})
+			return facilityjobs.StepResult{TargetID: input.FieldDeviceID, Result: encoded}, encodeErr
+		})
+		if err == nil {
+			err = json.Unmarshal(stored.Result, &item)
+		}
+	}
+	if err != nil {
+		item.Success = false
+		item.Error = err.Error()
+		item.Conflict = errors.Is(err, domain.ErrConflict)
+		if validationErr, ok := domain.AsValidationError(err); ok {
+			item.Fields = validationErr.Fields
+		}
+	}
+	return item
+}
+
+func objectDataUpgradeStep(job facilityservice.FacilityJob, ordinal int64, input facilityservice.ObjectDataUpgradeStep) (facilityjobs.Step, error) {
+	encoded, err := json.Marshal(input)
+	return facilityjobs.Step{
+		Key:        facilityjobs.ItemKey{OwnerID: job.OwnerID, JobID: job.ID, Ordinal: ordinal},
+		EntityType: "field_devices", SourceID: input.FieldDeviceID, Input: encoded,
+	}, err
+}
+
+func decodeObjectDataUpgradeSteps(items []facilityjobs.Item) ([]facilityservice.ObjectDataUpgradeStep, error) {
+	steps := make([]facilityservice.ObjectDataUpgradeStep, len(items))
+	for index, item := range items {
+		if err := json.Unmarshal(item.Input, &steps[index]); err != nil {
+			return nil, fmt.Errorf("decode persisted object data upgrade step: %w", err)
+		}
+	}
+	return steps, nil
+}
+
+func reportObjectDataUpgradeProgress(report func(facilityservice.FacilityJobProgress), processed int, result facilityservice.ObjectDataUpgradeJobResult) {
+	if processed%facilityBulkChunkSize != 0 && processed != result.TotalCount {
+		return
+	}
+	total := int64(result.TotalCount)
+	report(facilityservice.FacilityJobProgress{
+		Progress: 5 + int(90*int64(processed)/max(total, 1)), Stage: "processing_items",
+		Processed: int64(processed), Total: &total,
+		Succeeded: int64(result.SuccessCount), Failed: int64(result.FailureCount),
+	})
+}
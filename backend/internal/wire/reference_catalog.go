@@ -0,0 +1,58 @@
+package wire
+
+import (
+	"context"
+
+	referencecatalog "github.com/besart951/go_infra_link/backend/internal/application/referencecatalog"
+	domainFacility "github.com/besart951/go_infra_link/backend/internal/domain/facility"
+	facilityhandler "github.com/besart951/go_infra_link/backend/internal/handler/facility"
+	exporting "github.com/besart951/go_infra_link/backend/internal/infrastructure/exporting"
+	importing "github.com/besart951/go_infra_link/backend/internal/infrastructure/importing"
+	facilityrepo "github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
+	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
+	"gorm.io/gorm"
+)
+
+func newReferenceCatalogService(gormDB *gorm.DB, repos *Repositories, facility *facilityservice.Services) *referencecatalog.Service {
+	if facility == nil || facility.StateText == nil || facility.NotificationClass == nil {
+		return nil
+	}
+	service := referencecatalog.NewService(
+		importing.NewCatalogReader(),
+		exporting.NewCatalogWriter(),
+		referenceCatalogStore{stateTexts: facility.StateText, notificationClasses: facility.NotificationClass},
+		repos.FacilityBacnetReferenceUsages,
+	)
+	service.SetMultiStateObjects(facilityrepo.NewMultiStateObjectRepository(gormDB))
+	return service
+}
+
+// referenceCatalogHandlerService keeps a missing service a nil interface, so
+// the handler reports the catalog routes as unavailable.
+func referenceCatalogHandlerService(service *referencecatalog.Service) facilityhandler.ReferenceCatalogService {
+	if service == nil {
+		return nil
+	}
+	return service
+}
+
+type referenceCatalogStore struct {
+	stateTexts          *facilityservice.StateTextService
+	notificationClasses *facilityservice.NotificationClassService
+}
+
+func (s referenceCatalogStore) StateTexts(ctx context.Context) ([]domainFacility.StateText, error) {
+	return s.stateTexts.ListAll(ctx)
+}
+
+func (s referenceCatalogStore) NotificationClasses(ctx context.Context) ([]domainFacility.NotificationClass, error) {
+	return s.notificationClasses.ListAll(ctx)
+}
+
+func (s referenceCatalogStore) SaveStateTexts(ctx context.Context, create, update []domainFacility.StateText) error {
+	return s.stateTexts.SaveCatalog(ctx, create, update)
+}
+
+func (s referenceCatalogStore) SaveNotificationClasses(ctx context.Context, create, update []domainFacility.NotificationClass) error {
+	return s.notificationClasses.SaveCatalog(ctx, create, update)
+}
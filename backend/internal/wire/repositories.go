@@ -5,6 +5,7 @@ package wire
 import (
 	"context"
 	"fmt"
+	hierarchyrestore "github.com/besart951/go_infra_link/backend/internal/application/hierarchyrestore"
 	domainFieldDevice "github.com/besart951/go_infra_link/backend/internal/domain/facility/fielddevice"
 	domainHierarchy "github.com/besart951/go_infra_link/backend/internal/domain/facility/hierarchy"
 	domainObjectData "github.com/besart951/go_infra_link/backend/internal/domain/facility/objectdata"
@@ -17,6 +18,7 @@ import (
 	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
 	domainTeam "github.com/besart951/go_infra_link/backend/internal/domain/team"
 	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	domainWebhook "github.com/besart951/go_infra_link/backend/internal/domain/webhook"
 	authrepo "github.com/besart951/go_infra_link/backend/internal/repository/auth"
 	facilitycache "github.com/besart951/go_infra_link/backend/internal/repository/facilitycache"
 	facilityrepo "github.com/besart951/go_infra_link/backend/internal/repository/facilitysql"
@@ -29,6 +31,7 @@ import (
 	teamrepo "github.com/besart951/go_infra_link/backend/internal/repository/team"
 	userrepo "github.com/besart951/go_infra_link/backend/internal/repository/user"
 	userregistrationrepo "github.com/besart951/go_infra_link/backend/internal/repository/userregistration"
+	webhookrepo "github.com/besart951/go_infra_link/backend/internal/repository/webhook"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -55,6 +58,8 @@ type Repositories struct {
 	SystemNotifications      domainNotification.SystemNotificationRepository
 	NotificationEmailOutbox  domainNotification.EmailOutboxRepository
 	NotificationRules        domainNotification.NotificationRuleRepository
+	WebhookSubscriptions     domainWebhook.SubscriptionRepository
+	WebhookDeliveries        domainWebhook.DeliveryRepository
 	Team                     domainTeam.TeamRepository
 	TeamMember               domainTeam.TeamMemberRepository
 
@@ -69,11 +74,14 @@ type Repositories struct {
 	FacilitySPSControllerSystemTypes domainHierarchy.SPSControllerSystemTypeStore
 	FacilityBacnetObjects            domainObjectData.BacnetObjectStore
 	FacilityBacnetTemplates          domainObjectData.BacnetObjectTemplateStore
+	FacilityBacnetInstances          domainObjectData.BacnetInstanceStore
+	FacilityIPPools                  domainFacility.IPPoolRepository
 	FacilityObjectData               domainObjectData.ObjectDataStore
 
-	FacilityStateTexts          domainFacility.StateTextRepository
-	FacilityNotificationClasses domainFacility.NotificationClassRepository
-	FacilityAlarmDefinitions    domainFacility.AlarmDefinitionRepository
+	FacilityStateTexts                    domainFacility.StateTextRepository
+	FacilityNotificationClasses           domainFacility.NotificationClassRepository
+	FacilityAlarmDefinitions              domainFacility.AlarmDefinitionRepository
+	FacilityAlarmDefinitionFieldOverrides domainFacility.AlarmDefinitionFieldOverrideRepository
 
 	FacilityUnits                   domainFacility.UnitRepository
 	FacilityAlarmFields             domainFacility.AlarmFieldRepository
@@ -81,7 +89,11 @@ type Repositories struct {
 	FacilityAlarmTypeFields         domainFacility.AlarmTypeFieldRepository
 	FacilityBacnetObjectAlarmValues domainFacility.BacnetObjectAlarmValueRepository
 	FacilityBacnetReferenceUsages   domainFacility.BacnetReferenceUsageRepository
+	FacilityBacnetObjectSelections  domainFacility.BacnetObjectSelectionRepository
+	FacilityTranslations            domainFacility.TranslationRepository
 	FacilityDeleteImpacts           domainFacility.DeleteImpactRepository
+	FacilityObjectDataRevisions     domainObjectData.TemplateRevisionStore
+	FacilityNamingSchemes           domainFacility.NamingSchemeRepository
 }
 
 type HistoryRepository interface {
@@ -91,6 +103,7 @@ type HistoryRepository interface {
 	RestoreEntityToEvent(ctx context.Context, eventID uuid.UUID, mode domainHistory.RestoreMode) (*domainHistory.RestoreResult, error)
 	UndoBatch(ctx context.Context, batchID uuid.UUID) (*domainHistory.RestoreResult, error)
 	RestoreControlCabinet(ctx context.Context, controlCabinetID uuid.UUID, req domainHistory.RestoreControlCabinetRequest) (*domainHistory.RestoreResult, error)
+	PreviewHierarchyRestore(ctx context.Context, root hierarchyrestore.Root, rootID uuid.UUID, req domainHistory.RestoreHierarchyRequest) (*domainHistory.RestorePreview, error)
 }
 
 type (
@@ -115,28 +128,35 @@ type (
 	}
 
 	facilityRepositoryGroup struct {
-		FacilityBuildings                domainFacility.BuildingRepository
-		FacilitySystemTypes              domainFacility.SystemTypeRepository
-		FacilitySystemParts              domainFacility.SystemPartRepository
-		FacilitySpecifications           domainFieldDevice.SpecificationStore
-		FacilityApparats                 domainFacility.ApparatRepository
-		FacilityControlCabinet           domainFacility.ControlCabinetRepository
-		FacilityFieldDevices             domainFieldDevice.FieldDeviceStore
-		FacilitySPSControllers           domainFacility.SPSControllerRepository
-		FacilitySPSControllerSystemTypes domainHierarchy.SPSControllerSystemTypeStore
-		FacilityBacnetObjects            domainObjectData.BacnetObjectStore
-		FacilityBacnetTemplates          domainObjectData.BacnetObjectTemplateStore
-		FacilityObjectData               domainObjectData.ObjectDataStore
-		FacilityStateTexts               domainFacility.StateTextRepository
-		FacilityNotificationClasses      domainFacility.NotificationClassRepository
-		FacilityAlarmDefinitions         domainFacility.AlarmDefinitionRepository
-		FacilityUnits                    domainFacility.UnitRepository
-		FacilityAlarmFields              domainFacility.AlarmFieldRepository
-		FacilityAlarmTypes               domainFacility.AlarmTypeRepository
-		FacilityAlarmTypeFields          domainFacility.AlarmTypeFieldRepository
-		FacilityBacnetObjectAlarmValues  domainFacility.BacnetObjectAlarmValueRepository
-		FacilityBacnetReferenceUsages    domainFacility.BacnetReferenceUsageRepository
-		FacilityDeleteImpacts            domainFacility.DeleteImpactRepository
+		FacilityBuildings                     domainFacility.BuildingRepository
+		FacilitySystemTypes                   domainFacility.SystemTypeRepository
+		FacilitySystemParts                   domainFacility.SystemPartRepository
+		FacilitySpecifications                domainFieldDevice.SpecificationStore
+		FacilityApparats                      domainFacility.ApparatRepository
+		FacilityControlCabinet                domainFacility.ControlCabinetRepository
+		FacilityFieldDevices                  domainFieldDevice.FieldDeviceStore
+		FacilitySPSControllers                domainFacility.SPSControllerRepository
+		FacilitySPSControllerSystemTypes      domainHierarchy.SPSControllerSystemTypeStore
+		FacilityBacnetObjects                 domainObjectData.BacnetObjectStore
+		FacilityBacnetTemplates               domainObjectData.BacnetObjectTemplateStore
+		FacilityBacnetInstances               domainObjectData.BacnetInstanceStore
+		FacilityIPPools                       domainFacility.IPPoolRepository
+		FacilityObjectData                    domainObjectData.ObjectDataStore
+		FacilityStateTexts                    domainFacility.StateTextRepository
+		FacilityNotificationClasses           domainFacility.NotificationClassRepository
+		FacilityAlarmDefinitions              domainFacility.AlarmDefinitionRepository
+		FacilityAlarmDefinitionFieldOverrides domainFacility.AlarmDefinitionFieldOverrideRepository
+		FacilityUnits                         domainFacility.UnitRepository
+		FacilityAlarmFields                   domainFacility.AlarmFieldRepository
+		FacilityAlarmTypes                    domainFacility.AlarmTypeRepository
+		FacilityAlarmTypeFields               domainFacility.AlarmTypeFieldRepository
+		FacilityBacnetObjectAlarmValues       domainFacility.BacnetObjectAlarmValueRepository
+		FacilityBacnetReferenceUsages         domainFacility.BacnetReferenceUsageRepository
+		FacilityBacnetObjectSelections        domainFacility.BacnetObjectSelectionRepository
+		FacilityTranslations                  domainFacility.TranslationRepository
+		FacilityDeleteImpacts                 domainFacility.DeleteImpactRepository
+		FacilityObjectDataRevisions           domainObjectData.TemplateRevisionStore
+		FacilityNamingSchemes                 domainFacility.NamingSchemeRepository
 	}
 
 	notificationRepositoryGroup struct {
@@ -145,6 +165,8 @@ type (
 		SystemNotifications      domainNotification.SystemNotificationRepository
 		NotificationEmailOutbox  domainNotification.EmailOutboxRepository
 		NotificationRules        domainNotification.NotificationRuleRepository
+		WebhookSubscriptions     domainWebhook.SubscriptionRepository
+		WebhookDeliveries        domainWebhook.DeliveryRepository
 	}
 
 	teamRepositoryGroup struct {
@@ -201,7 +223,7 @@ func newUserRepositories(gormDB *gorm.DB) (userRepositoryGroup, error) {
 func newProjectRepositories(gormDB *gorm.DB, history *historyrepo.Store) projectRepositoryGroup {
 	return projectRepositoryGroup{
 		Project:                historycapture.WrapProject(projectrepo.NewProjectRepository(gormDB), history),
-		ProjectChanges:         projectchangerepo.NewStore(gormDB),
+		ProjectChanges:         newProjectChangeStore(gormDB),
 		Phase:                  projectrepo.NewPhaseRepository(gormDB),
 		PhasePermissions:       projectrepo.NewPhasePermissionRepository(gormDB),
 		ProjectControlCabinets: historycapture.WrapProjectControlCabinet(projectsqlrepo.NewProjectControlCabinetRepository(gormDB), history),
@@ -210,34 +232,49 @@ func newProjectRepositories(gormDB *gorm.DB, history *historyrepo.Store) project
 	}
 }
 
+// newProjectChangeStore queues the webhook deliveries of every change in the
+// transaction that stores it.
+func newProjectChangeStore(gormDB *gorm.DB) *projectchangerepo.Store {
+	store := projectchangerepo.NewStore(gormDB)
+	store.SetOutbox(webhookrepo.NewOutbox())
+	return store
+}
+
 func newFacilityRepositories(gormDB *gorm.DB, history *historyrepo.Store) facilityRepositoryGroup {
 	facilitySystemParts := historycapture.WrapSystemPart(facilityrepo.NewSystemPartRepository(gormDB), history)
 	facilityApparats := historycapture.WrapApparat(facilityrepo.NewApparatRepository(gormDB), history)
 	facilityApparats, facilitySystemParts = facilitycache.WrapReferenceData(facilityApparats, facilitySystemParts)
 
 	return facilityRepositoryGroup{
-		FacilityBuildings:                historycapture.WrapBuilding(facilityrepo.NewBuildingRepository(gormDB), history),
-		FacilitySystemTypes:              historycapture.WrapSystemType(facilityrepo.NewSystemTypeRepository(gormDB), history),
-		FacilitySystemParts:              facilitySystemParts,
-		FacilitySpecifications:           historycapture.WrapSpecification(facilityrepo.NewSpecificationRepository(gormDB), history),
-		FacilityApparats:                 facilityApparats,
-		FacilityControlCabinet:           historycapture.WrapControlCabinet(facilityrepo.NewControlCabinetRepository(gormDB), history),
-		FacilityFieldDevices:             historycapture.WrapFieldDevice(facilityrepo.NewFieldDeviceRepository(gormDB), history),
-		FacilitySPSControllers:           historycapture.WrapSPSController(facilityrepo.NewSPSControllerRepository(gormDB), history),
-		FacilitySPSControllerSystemTypes: historycapture.WrapSPSControllerSystemType(facilityrepo.NewSPSControllerSystemTypeRepository(gormDB), history),
-		FacilityBacnetObjects:            historycapture.WrapBacnetObject(facilityrepo.NewBacnetObjectRepository(gormDB), history),
-		FacilityBacnetTemplates:          facilityrepo.NewBacnetObjectTemplateRepository(gormDB),
-		FacilityObjectData:               historycapture.WrapObjectData(facilityrepo.NewObjectDataRepository(gormDB), history),
-		FacilityStateTexts:               historycapture.WrapRepository("state_texts", facilityrepo.NewStateTextRepository(gormDB), history),
-		FacilityNotificationClasses:      historycapture.WrapRepository("notification_classes", facilityrepo.NewNotificationClassRepository(gormDB), history),
-		FacilityAlarmDefinitions:         historycapture.WrapAlarmDefinition(facilityrepo.NewAlarmDefinitionRepository(gormDB), history),
-		FacilityUnits:                    historycapture.WrapRepository("units", facilityrepo.NewUnitRepository(gormDB), history),
-		FacilityAlarmFields:              historycapture.WrapRepository("alarm_fields", facilityrepo.NewAlarmFieldRepository(gormDB), history),
-		FacilityAlarmTypes:               historycapture.WrapAlarmType(facilityrepo.NewAlarmTypeRepository(gormDB), history),
-		FacilityAlarmTypeFields:          historycapture.WrapRepository("alarm_type_fields", facilityrepo.NewAlarmTypeFieldRepository(gormDB), history),
-		FacilityBacnetObjectAlarmValues:  historycapture.WrapBacnetObjectAlarmValue(facilityrepo.NewBacnetObjectAlarmValueRepository(gormDB), history),
-		FacilityBacnetReferenceUsages:    facilityrepo.NewBacnetReferenceUsageRepository(gormDB),
-		FacilityDeleteImpacts:            facilityrepo.NewDeleteImpactRepository(gormDB),
+		FacilityBuildings:                     historycapture.WrapBuilding(facilityrepo.NewBuildingRepository(gormDB), history),
+		FacilitySystemTypes:                   historycapture.WrapSystemType(facilityrepo.NewSystemTypeRepository(gormDB), history),
+		FacilitySystemParts:                   facilitySystemParts,
+		FacilitySpecifications:                historycapture.WrapSpecification(facilityrepo.NewSpecificationRepository(gormDB), history),
+		FacilityApparats:                      facilityApparats,
+		FacilityControlCabinet:                historycapture.WrapControlCabinet(facilityrepo.NewControlCabinetRepository(gormDB), history),
+		FacilityFieldDevices:                  historycapture.WrapFieldDevice(facilityrepo.NewFieldDeviceRepository(gormDB), history),
+		FacilitySPSControllers:                historycapture.WrapSPSController(facilityrepo.NewSPSControllerRepository(gormDB), history),
+		FacilitySPSControllerSystemTypes:      historycapture.WrapSPSControllerSystemType(facilityrepo.NewSPSControllerSystemTypeRepository(gormDB), history),
+		FacilityBacnetObjects:                 historycapture.WrapBacnetObject(facilityrepo.NewBacnetObjectRepository(gormDB), history),
+		FacilityBacnetTemplates:               facilityrepo.NewBacnetObjectTemplateRepository(gormDB),
+		FacilityBacnetInstances:               facilityrepo.NewBacnetInstanceRepository(gormDB),
+		FacilityIPPools:                       facilityrepo.NewIPPoolRepository(gormDB),
+		FacilityObjectData:                    historycapture.WrapObjectData(facilityrepo.NewObjectDataRepository(gormDB), history),
+		FacilityStateTexts:                    historycapture.WrapRepository("state_texts", facilityrepo.NewStateTextRepository(gormDB), history),
+		FacilityNotificationClasses:           historycapture.WrapRepository("notification_classes", facilityrepo.NewNotificationClassRepository(gormDB), history),
+		FacilityAlarmDefinitions:              historycapture.WrapAlarmDefinition(facilityrepo.NewAlarmDefinitionRepository(gormDB), history),
+		FacilityAlarmDefinitionFieldOverrides: historycapture.WrapAlarmDefinitionFieldOverride(facilityrepo.NewAlarmDefinitionFieldOverrideRepository(gormDB), history),
+		FacilityUnits:                         historycapture.WrapUnit(facilityrepo.NewUnitRepository(gormDB), history),
+		FacilityAlarmFields:                   historycapture.WrapRepository("alarm_fields", facilityrepo.NewAlarmFieldRepository(gormDB), history),
+		FacilityAlarmTypes:                    historycapture.WrapAlarmType(facilityrepo.NewAlarmTypeRepository(gormDB), history),
+		FacilityAlarmTypeFields:               historycapture.WrapRepository("alarm_type_fields", facilityrepo.NewAlarmTypeFieldRepository(gormDB), history),
+		FacilityBacnetObjectAlarmValues:       historycapture.WrapBacnetObjectAlarmValue(facilityrepo.NewBacnetObjectAlarmValueRepository(gormDB), history),
+		FacilityBacnetReferenceUsages:         facilityrepo.NewBacnetReferenceUsageRepository(gormDB),
+		FacilityBacnetObjectSelections:        facilityrepo.NewBacnetObjectSelectionRepository(gormDB),
+		FacilityTranslations:                  facilityrepo.NewTranslationRepository(gormDB),
+		FacilityDeleteImpacts:                 facilityrepo.NewDeleteImpactRepository(gormDB),
+		FacilityObjectDataRevisions:           facilityrepo.NewObjectDataRevisionRepository(gormDB),
+		FacilityNamingSchemes:                 facilityrepo.NewNamingSchemeRepository(gormDB),
 	}
 }
 
@@ -248,6 +285,8 @@ func newNotificationRepositories(gormDB *gorm.DB) notificationRepositoryGroup {
 		SystemNotifications:      notificationrepo.NewSystemNotificationRepository(gormDB),
 		NotificationEmailOutbox:  notificationrepo.NewEmailOutboxRepository(gormDB),
 		NotificationRules:        notificationrepo.NewNotificationRuleRepository(gormDB),
+		WebhookSubscriptions:     webhookrepo.NewSubscriptionRepository(gormDB),
+		WebhookDeliveries:        webhookrepo.NewDeliveryRepository(gormDB),
 	}
 }
 
@@ -267,50 +306,59 @@ func composeRepositories(
 	teams teamRepositoryGroup,
 ) *Repositories {
 	return &Repositories{
-		History:                          history,
-		Project:                          projects.Project,
-		ProjectChanges:                   projects.ProjectChanges,
-		Phase:                            projects.Phase,
-		PhasePermissions:                 projects.PhasePermissions,
-		ProjectControlCabinets:           projects.ProjectControlCabinets,
-		ProjectSPSControllers:            projects.ProjectSPSControllers,
-		ProjectFieldDevices:              projects.ProjectFieldDevices,
-		User:                             users.User,
-		UserLifecycle:                    users.UserLifecycle,
-		UserEmail:                        users.UserEmail,
-		UserRegistration:                 users.UserRegistration,
-		Permissions:                      users.Permissions,
-		RolePermissions:                  users.RolePermissions,
-		RefreshToken:                     users.RefreshToken,
-		NotificationSMTPSettings:         notifications.NotificationSMTPSettings,
-		NotificationPreferences:          notifications.NotificationPreferences,
-		SystemNotifications:              notifications.SystemNotifications,
-		NotificationEmailOutbox:          notifications.NotificationEmailOutbox,
-		NotificationRules:                notifications.NotificationRules,
-		Team:                             teams.Team,
-		TeamMember:                       teams.TeamMember,
-		FacilityBuildings:                facilities.FacilityBuildings,
-		FacilitySystemTypes:              facilities.FacilitySystemTypes,
-		FacilitySystemParts:              facilities.FacilitySystemParts,
-		FacilitySpecifications:           facilities.FacilitySpecifications,
-		FacilityApparats:                 facilities.FacilityApparats,
-		FacilityControlCabinet:           facilities.FacilityControlCabinet,
-		FacilityFieldDevices:             facilities.FacilityFieldDevices,
-		FacilitySPSControllers:           facilities.FacilitySPSControllers,
-		FacilitySPSControllerSystemTypes: facilities.FacilitySPSControllerSystemTypes,
-		FacilityBacnetObjects:            facilities.FacilityBacnetObjects,
-		FacilityBacnetTemplates:          facilities.FacilityBacnetTemplates,
-		FacilityObjectData:               facilities.FacilityObjectData,
-		FacilityStateTexts:               facilities.FacilityStateTexts,
-		FacilityNotificationClasses:      facilities.FacilityNotificationClasses,
-		FacilityAlarmDefinitions:         facilities.FacilityAlarmDefinitions,
-		FacilityUnits:                    facilities.FacilityUnits,
-		FacilityAlarmFields:              facilities.FacilityAlarmFields,
-		FacilityAlarmTypes:               facilities.FacilityAlarmTypes,
-		FacilityAlarmTypeFields:          facilities.FacilityAlarmTypeFields,
-		FacilityBacnetObjectAlarmValues:  facilities.FacilityBacnetObjectAlarmValues,
-		FacilityBacnetReferenceUsages:    facilities.FacilityBacnetReferenceUsages,
-		FacilityDeleteImpacts:            facilities.FacilityDeleteImpacts,
+		History:                               history,
+		Project:                               projects.Project,
+		ProjectChanges:                        projects.ProjectChanges,
+		Phase:                                 projects.Phase,
+		PhasePermissions:                      projects.PhasePermissions,
+		ProjectControlCabinets:                projects.ProjectControlCabinets,
+		ProjectSPSControllers:                 projects.ProjectSPSControllers,
+		ProjectFieldDevices:                   projects.ProjectFieldDevices,
+		User:                                  users.User,
+		UserLifecycle:                         users.UserLifecycle,
+		UserEmail:                             users.UserEmail,
+		UserRegistration:                      users.UserRegistration,
+		Permissions:                           users.Permissions,
+		RolePermissions:                       users.RolePermissions,
+		RefreshToken:                          users.RefreshToken,
+		NotificationSMTPSettings:              notifications.NotificationSMTPSettings,
+		NotificationPreferences:               notifications.NotificationPreferences,
+		SystemNotifications:                   notifications.SystemNotifications,
+		NotificationEmailOutbox:               notifications.NotificationEmailOutbox,
+		NotificationRules:                     notifications.NotificationRules,
+		WebhookSubscriptions:                  notifications.WebhookSubscriptions,
+		WebhookDeliveries:                     notifications.WebhookDeliveries,
+		Team:                                  teams.Team,
+		TeamMember:                            teams.TeamMember,
+		FacilityBuildings:                     facilities.FacilityBuildings,
+		FacilitySystemTypes:                   facilities.FacilitySystemTypes,
+		FacilitySystemParts:                   facilities.FacilitySystemParts,
+		FacilitySpecifications:                facilities.FacilitySpecifications,
+		FacilityApparats:                      facilities.FacilityApparats,
+		FacilityControlCabinet:                facilities.FacilityControlCabinet,
+		FacilityFieldDevices:                  facilities.FacilityFieldDevices,
+		FacilitySPSControllers:                facilities.FacilitySPSControllers,
+		FacilitySPSControllerSystemTypes:      facilities.FacilitySPSControllerSystemTypes,
+		FacilityBacnetObjects:                 facilities.FacilityBacnetObjects,
+		FacilityBacnetTemplates:               facilities.FacilityBacnetTemplates,
+		FacilityBacnetInstances:               facilities.FacilityBacnetInstances,
+		FacilityIPPools:                       facilities.FacilityIPPools,
+		FacilityObjectData:                    facilities.FacilityObjectData,
+		FacilityStateTexts:                    facilities.FacilityStateTexts,
+		FacilityNotificationClasses:           facilities.FacilityNotificationClasses,
+		FacilityAlarmDefinitions:              facilities.FacilityAlarmDefinitions,
+		FacilityAlarmDefinitionFieldOverrides: facilities.FacilityAlarmDefinitionFieldOverrides,
+		FacilityUnits:                         facilities.FacilityUnits,
+		FacilityAlarmFields:                   facilities.FacilityAlarmFields,
+		FacilityAlarmTypes:                    facilities.FacilityAlarmTypes,
+		FacilityAlarmTypeFields:               facilities.FacilityAlarmTypeFields,
+		FacilityBacnetObjectAlarmValues:       facilities.FacilityBacnetObjectAlarmValues,
+		FacilityBacnetReferenceUsages:         facilities.FacilityBacnetReferenceUsages,
+		FacilityBacnetObjectSelections:        facilities.FacilityBacnetObjectSelections,
+		FacilityTranslations:                  facilities.FacilityTranslations,
+		FacilityDeleteImpacts:                 facilities.FacilityDeleteImpacts,
+		FacilityObjectDataRevisions:           facilities.FacilityObjectDataRevisions,
+		FacilityNamingSchemes:                 facilities.FacilityNamingSchemes,
 	}
 }
 
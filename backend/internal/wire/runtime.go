@@ -11,6 +11,7 @@ import (
 	apprealtime "github.com/besart951/go_infra_link/backend/internal/application/realtime"
 	"github.com/besart951/go_infra_link/backend/internal/infrastructure/realtime"
 	"github.com/besart951/go_infra_link/backend/internal/repository/facilityjobsql"
+	webhookrepo "github.com/besart951/go_infra_link/backend/internal/repository/webhook"
 	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -90,7 +91,7 @@ func NewRuntimeAdaptersWithBusAndStore(bus apprealtime.Bus, nodeID string, ownsB
 			realtime.WithSystemNotificationBus(bus, nodeID),
 		),
 		FacilityReferenceData:  facilityReferenceData,
-		FacilityJobs:           facilityservice.NewFacilityJobManagerWithDB(facilityReferenceData, db),
+		FacilityJobs:           facilityservice.NewFacilityJobManagerWithOutbox(facilityReferenceData, db, webhookrepo.NewOutbox()),
 		FacilityJobSteps:       jobSteps,
 		FieldDeviceUpdatePlans: updatePlans,
 		DB:                     db,
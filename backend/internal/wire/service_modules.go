@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	apptransaction "github.com/besart951/go_infra_link/backend/internal/application/transaction"
+	domainProject "github.com/besart951/go_infra_link/backend/internal/domain/project"
 	infratransaction "github.com/besart951/go_infra_link/backend/internal/infrastructure/transaction"
+	"github.com/besart951/go_infra_link/backend/internal/repository/projectstatesql"
 	facilityservice "github.com/besart951/go_infra_link/backend/internal/service/facility"
 	projectservice "github.com/besart951/go_infra_link/backend/internal/service/project"
 	"gorm.io/gorm"
@@ -26,28 +28,35 @@ func newFacilityServices(gormDB *gorm.DB, repos *Repositories) *facilityservice.
 
 func buildFacilityRepositories(repos *Repositories) facilityservice.Repositories {
 	return facilityservice.Repositories{
-		Buildings:                repos.FacilityBuildings,
-		SystemTypes:              repos.FacilitySystemTypes,
-		SystemParts:              repos.FacilitySystemParts,
-		Specifications:           repos.FacilitySpecifications,
-		Apparats:                 repos.FacilityApparats,
-		ControlCabinets:          repos.FacilityControlCabinet,
-		FieldDevices:             repos.FacilityFieldDevices,
-		SPSControllers:           repos.FacilitySPSControllers,
-		SPSControllerSystemTypes: repos.FacilitySPSControllerSystemTypes,
-		BacnetObjects:            repos.FacilityBacnetObjects,
-		BacnetTemplates:          repos.FacilityBacnetTemplates,
-		ObjectData:               repos.FacilityObjectData,
-		StateTexts:               repos.FacilityStateTexts,
-		NotificationClasses:      repos.FacilityNotificationClasses,
-		AlarmDefinitions:         repos.FacilityAlarmDefinitions,
-		Units:                    repos.FacilityUnits,
-		AlarmFields:              repos.FacilityAlarmFields,
-		AlarmTypes:               repos.FacilityAlarmTypes,
-		AlarmTypeFields:          repos.FacilityAlarmTypeFields,
-		BacnetObjectAlarmValues:  repos.FacilityBacnetObjectAlarmValues,
-		BacnetReferenceUsages:    repos.FacilityBacnetReferenceUsages,
-		DeleteImpacts:            repos.FacilityDeleteImpacts,
+		Buildings:                     repos.FacilityBuildings,
+		SystemTypes:                   repos.FacilitySystemTypes,
+		SystemParts:                   repos.FacilitySystemParts,
+		Specifications:                repos.FacilitySpecifications,
+		Apparats:                      repos.FacilityApparats,
+		ControlCabinets:               repos.FacilityControlCabinet,
+		FieldDevices:                  repos.FacilityFieldDevices,
+		SPSControllers:                repos.FacilitySPSControllers,
+		SPSControllerSystemTypes:      repos.FacilitySPSControllerSystemTypes,
+		BacnetObjects:                 repos.FacilityBacnetObjects,
+		BacnetTemplates:               repos.FacilityBacnetTemplates,
+		BacnetInstances:               repos.FacilityBacnetInstances,
+		IPPools:                       repos.FacilityIPPools,
+		ObjectData:                    repos.FacilityObjectData,
+		StateTexts:                    repos.FacilityStateTexts,
+		NotificationClasses:           repos.FacilityNotificationClasses,
+		AlarmDefinitions:              repos.FacilityAlarmDefinitions,
+		AlarmDefinitionFieldOverrides: repos.FacilityAlarmDefinitionFieldOverrides,
+		Units:                         repos.FacilityUnits,
+		AlarmFields:                   repos.FacilityAlarmFields,
+		AlarmTypes:                    repos.FacilityAlarmTypes,
+		AlarmTypeFields:               repos.FacilityAlarmTypeFields,
+		BacnetObjectAlarmValues:       repos.FacilityBacnetObjectAlarmValues,
+		BacnetReferenceUsages:         repos.FacilityBacnetReferenceUsages,
+		BacnetObjectSelections:        repos.FacilityBacnetObjectSelections,
+		Translations:                  repos.FacilityTranslations,
+		DeleteImpacts:                 repos.FacilityDeleteImpacts,
+		ObjectDataRevisions:           repos.FacilityObjectDataRevisions,
+		NamingSchemes:                 repos.FacilityNamingSchemes,
 	}
 }
 
@@ -72,6 +81,7 @@ func buildProjectDependencies(repos *Repositories, facilityServices *facilityser
 	return projectservice.Dependencies{
 		Projects:                 repos.Project,
 		ProjectChanges:           repos.ProjectChanges,
+		ProjectChangeStates:      projectChangeStatesFromUnit,
 		Phases:                   repos.Phase,
 		PhasePermissions:         repos.PhasePermissions,
 		ProjectControlCabinets:   repos.ProjectControlCabinets,
@@ -92,6 +102,16 @@ func buildProjectDependencies(repos *Repositories, facilityServices *facilityser
 	}
 }
 
+// projectChangeStatesFromUnit reads change feed snapshots inside the
+// transaction the change store pinned the revision in.
+func projectChangeStatesFromUnit(unit apptransaction.UnitOfWork) (domainProject.ChangeStateReader, error) {
+	tx, err := infratransaction.GormDB(unit)
+	if err != nil {
+		return nil, fmt.Errorf("resolve snapshot unit: %w", err)
+	}
+	return projectstatesql.NewStore(tx), nil
+}
+
 func repositoriesFromUnit(unit apptransaction.UnitOfWork) (*Repositories, error) {
 	tx, err := infratransaction.GormDB(unit)
 	if err != nil {
@@ -4,8 +4,14 @@ import (
 	"fmt"
 	"time"
 
+	facilitysync "github.com/besart951/go_infra_link/backend/internal/application/facilitysync"
+	"github.com/besart951/go_infra_link/backend/internal/application/projectbaseline"
+	referencecatalog "github.com/besart951/go_infra_link/backend/internal/application/referencecatalog"
 	domainAuth "github.com/besart951/go_infra_link/backend/internal/domain/auth"
 	domainUser "github.com/besart951/go_infra_link/backend/internal/domain/user"
+	exporting "github.com/besart951/go_infra_link/backend/internal/infrastructure/exporting"
+	"github.com/besart951/go_infra_link/backend/internal/repository/baselinesql"
+	webhookrepo "github.com/besart951/go_infra_link/backend/internal/repository/webhook"
 	adminservice "github.com/besart951/go_infra_link/backend/internal/service/admin"
 	authservice "github.com/besart951/go_infra_link/backend/internal/service/auth"
 	dashboardservice "github.com/besart951/go_infra_link/backend/internal/service/dashboard"
@@ -22,6 +28,8 @@ import (
 	userdirectoryservice "github.com/besart951/go_infra_link/backend/internal/service/userdirectory"
 	usermutationpolicy "github.com/besart951/go_infra_link/backend/internal/service/usermutationpolicy"
 	userregistrationservice "github.com/besart951/go_infra_link/backend/internal/service/userregistration"
+	webhookservice "github.com/besart951/go_infra_link/backend/internal/service/webhook"
+	"github.com/besart951/go_infra_link/backend/pkg/i18n"
 	"gorm.io/gorm"
 )
 
@@ -40,8 +48,12 @@ type Services struct {
 	Admin            *adminservice.Service
 	UserDirectory    *userdirectoryservice.Service
 	Notification     *notificationservice.Service
+	Webhook          *webhookservice.Service
 	Password         domainUser.PasswordHasher
 	Export           *exportservice.Service
+	FacilitySync     *facilitysync.Service
+	Baseline         *projectbaseline.Service
+	ReferenceCatalog *referencecatalog.Service
 	History          HistoryRepository
 
 	Facility *facilityservice.Services
@@ -57,6 +69,14 @@ type ServiceConfig struct {
 	ExportDirectory string
 	Runtime         *RuntimeAdapters
 	AppPublicURL    string
+
+	// WebhookSecret keys the cipher for webhook signing secrets at rest. It
+	// is separate from JWTSecret so rotating one does not touch the other.
+	WebhookSecret string
+
+	// Translator serves the translation catalogs to services that render
+	// texts themselves, such as notification templates.
+	Translator *i18n.Translator
 }
 
 type securityServices struct {
@@ -84,19 +104,28 @@ func NewServices(gormDB *gorm.DB, repos *Repositories, cfg ServiceConfig) (*Serv
 		facilityJobs = cfg.Runtime.FacilityJobs
 	}
 	if facilityJobs == nil {
-		facilityJobs = facilityservice.NewFacilityJobManagerWithDB(nil, gormDB)
+		facilityJobs = facilityservice.NewFacilityJobManagerWithOutbox(nil, gormDB, webhookrepo.NewOutbox())
 	}
 	exportSvc, err := newExportService(gormDB, repos, cfg, facilityJobs)
 	if err != nil {
 		return nil, fmt.Errorf("new export service: %w", err)
 	}
+	exportSvc.SetNaming(facilityServices.NamingScheme)
+	if repos.History != nil {
+		exportSvc.SetChangeReports(repos.History, exporting.NewChangeReportWriter(), changeReportLabels(cfg.Translator))
+	}
 	notificationSvc, err := newNotificationService(repos, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("new notification service: %w", err)
 	}
+	webhookSvc, err := newWebhookService(repos, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("new webhook service: %w", err)
+	}
+	projectServices := newProjectServices(gormDB, repos, facilityServices)
 
 	return &Services{
-		Project:          newProjectServices(gormDB, repos, facilityServices),
+		Project:          projectServices,
 		Dashboard:        dashboardservice.New(repos.Project, repos.Phase, repos.Team, repos.TeamMember, repos.User),
 		Phase:            phaseservice.NewPhaseService(repos.Phase),
 		PhasePermission:  phasepermissionservice.New(repos.PhasePermissions, repos.Phase, repos.Permissions),
@@ -109,6 +138,8 @@ func NewServices(gormDB *gorm.DB, repos *Repositories, cfg ServiceConfig) (*Serv
 		Admin:            userSvc.admin,
 		UserDirectory:    userSvc.userDirectory,
 		Notification:     notificationSvc,
+		Webhook:          webhookSvc,
+		ReferenceCatalog: newReferenceCatalogService(gormDB, repos, facilityServices),
 		Auth: authservice.NewService(
 			security.jwt,
 			repos.User,
@@ -119,9 +150,11 @@ func NewServices(gormDB *gorm.DB, repos *Repositories, cfg ServiceConfig) (*Serv
 			cfg.RefreshTokenTTL,
 			cfg.Issuer,
 		),
-		Export:   exportSvc,
-		History:  repos.History,
-		Facility: facilityServices,
+		Export:       exportSvc,
+		FacilitySync: newFacilitySyncService(gormDB, projectServices, cfg),
+		Baseline:     projectbaseline.NewService(baselinesql.NewStore(gormDB), exporting.NewBaselineDiffWriter()),
+		History:      repos.History,
+		Facility:     facilityServices,
 	}, nil
 }
 
@@ -0,0 +1,16 @@
+package wire
+
+import (
+	"fmt"
+
+	notificationservice "github.com/besart951/go_infra_link/backend/internal/service/notification"
+	webhookservice "github.com/besart951/go_infra_link/backend/internal/service/webhook"
+)
+
+func newWebhookService(repos *Repositories, cfg ServiceConfig) (*webhookservice.Service, error) {
+	secretCipher, err := notificationservice.NewAESCipher(cfg.WebhookSecret)
+	if err != nil {
+		return nil, fmt.Errorf("webhook secret cipher: %w", err)
+	}
+	return webhookservice.New(repos.WebhookSubscriptions, repos.WebhookDeliveries, secretCipher), nil
+}
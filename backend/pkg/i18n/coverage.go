@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"sort"
+	"strings"
+)
+
+// LocaleCoverage compares one catalog with the keys the code uses.
+type LocaleCoverage struct {
+	Locale string `json:"locale"`
+	// Missing lists used keys the catalog does not define itself. Lookups
+	// still succeed through the fallback chain, but in another language.
+	Missing []string `json:"missing"`
+	// Untranslated lists keys of the reference catalog the catalog lacks.
+	Untranslated []string `json:"untranslated"`
+	// Unused lists keys nobody reads: keys the reference catalog does not
+	// know and unused keys below one of the scopes.
+	Unused []string `json:"unused"`
+}
+
+// Complete reports whether the catalog serves every used key itself.
+func (c LocaleCoverage) Complete() bool {
+	return len(c.Missing) == 0
+}
+
+// Coverage reports, per locale in sorted order, how the catalogs of
+// translator cover used. reference names the catalog the others are
+// translated from. Only keys below one of scopes count as unused when code
+// does not reference them, since the catalogs also serve the frontend.
+// Plural forms ("key.one", "key.other") count as the key itself.
+func Coverage(translator *Translator, reference string, used []string, scopes []string) []LocaleCoverage {
+	usedSet := make(map[string]struct{}, len(used))
+	for _, key := range used {
+		usedSet[key] = struct{}{}
+	}
+	referenceKeys := translator.translations[reference]
+
+	locales := translator.Locales()
+	report := make([]LocaleCoverage, 0, len(locales))
+	for _, locale := range locales {
+		catalog := translator.translations[locale]
+		coverage := LocaleCoverage{Locale: locale, Missing: []string{}, Untranslated: []string{}, Unused: []string{}}
+		for key := range usedSet {
+			if !hasKeyOrPlural(catalog, key) {
+				coverage.Missing = append(coverage.Missing, key)
+			}
+		}
+		for key := range referenceKeys {
+			if _, ok := catalog[key]; !ok {
+				coverage.Untranslated = append(coverage.Untranslated, key)
+			}
+		}
+		for key := range catalog {
+			if _, ok := usedSet[pluralBase(key)]; ok {
+				continue
+			}
+			_, known := referenceKeys[key]
+			if !known || hasScope(key, scopes) {
+				coverage.Unused = append(coverage.Unused, key)
+			}
+		}
+		sort.Strings(coverage.Missing)
+		sort.Strings(coverage.Untranslated)
+		sort.Strings(coverage.Unused)
+		report = append(report, coverage)
+	}
+	return report
+}
+
+func hasKeyOrPlural(catalog map[string]string, key string) bool {
+	if _, ok := catalog[key]; ok {
+		return true
+	}
+	_, ok := catalog[key+".other"]
+	return ok
+}
+
+func pluralBase(key string) string {
+	for _, form := range []string{".zero", ".one", ".other"} {
+		if base, ok := strings.CutSuffix(key, form); ok {
+			return base
+		}
+	}
+	return key
+}
+
+func hasScope(key string, scopes []string) bool {
+	for _, scope := range scopes {
+		if scope != "" && strings.HasPrefix(key, scope) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,17 @@ package i18n
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// ErrLocaleNotFound is returned when no catalog can serve a locale.
+var ErrLocaleNotFound = errors.New("translation catalog not found")
+
 // Loader loads translation files from the filesystem.
 type Loader struct {
 	basePath string
@@ -41,42 +47,103 @@ func (l *Loader) Load(filename string) (map[string]any, error) {
 }
 
 // LoadAll loads all translation files from the base directory.
-// Only .json files are loaded.
+// Only .json files named after a locale are loaded.
 // Returns a map of language -> translations.
 func (l *Loader) LoadAll() (map[string]map[string]any, error) {
-	result := make(map[string]map[string]any)
+	files, err := l.catalogFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]any, len(files))
+	for lang, filename := range files {
+		translations, err := l.Load(filename)
+		if err != nil {
+			return nil, err
+		}
+		result[lang] = translations
+	}
+
+	return result, nil
+}
+
+// Locales returns the locales that have a catalog file, in sorted order.
+func (l *Loader) Locales() ([]string, error) {
+	files, err := l.catalogFiles()
+	if err != nil {
+		return nil, err
+	}
+	locales := make([]string, 0, len(files))
+	for locale := range files {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales, nil
+}
 
+// LoadLocale loads the catalog that best serves locale, e.g. "en" for
+// "en-US", and returns the locale it resolved to.
+func (l *Loader) LoadLocale(locale string) (map[string]any, string, error) {
+	files, err := l.catalogFiles()
+	if err != nil {
+		return nil, "", err
+	}
+	available := make([]string, 0, len(files))
+	for candidate := range files {
+		available = append(available, candidate)
+	}
+	resolved, ok := Match(available, locale)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrLocaleNotFound, locale)
+	}
+	translations, err := l.Load(files[resolved])
+	if err != nil {
+		return nil, "", err
+	}
+	return translations, resolved, nil
+}
+
+// LoadInto loads every catalog into translator and returns the loaded
+// locales in sorted order.
+func (l *Loader) LoadInto(translator *Translator) ([]string, error) {
+	catalogs, err := l.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	for lang, translations := range catalogs {
+		if err := translator.LoadLanguage(lang, translations); err != nil {
+			return nil, fmt.Errorf("load %s: %w", lang, err)
+		}
+	}
+	return translator.Locales(), nil
+}
+
+// catalogFiles maps each locale to its file name.
+func (l *Loader) catalogFiles() (map[string]string, error) {
 	entries, err := os.ReadDir(l.basePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read locales directory: %w", err)
 	}
 
+	files := make(map[string]string, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
 			continue
 		}
-
-		if filepath.Ext(entry.Name()) != ".json" {
+		lang, ok := filenameTolanguageCode(entry.Name())
+		if !ok {
 			continue
 		}
-
-		translations, err := l.Load(entry.Name())
-		if err != nil {
-			return nil, err
+		if existing, ok := files[lang]; ok {
+			return nil, fmt.Errorf("translation files %s and %s both define %s", existing, entry.Name(), lang)
 		}
-
-		// Extract language code from filename (e.g., "de_ch.json" -> "de_CH")
-		lang := filenameTolanguageCode(entry.Name())
-		result[lang] = translations
+		files[lang] = entry.Name()
 	}
-
-	return result, nil
+	return files, nil
 }
 
 // filenameTolanguageCode converts a filename to a language code.
 // Example: "de_ch.json" -> "de_CH"
-func filenameTolanguageCode(filename string) string {
-	// Remove .json extension
-	lang := filename[:len(filename)-5]
-	return lang
+func filenameTolanguageCode(filename string) (string, bool) {
+	return NormalizeLocale(strings.TrimSuffix(filename, filepath.Ext(filename)))
 }
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NormalizeLocale converts language tags to the catalog form.
+// Example: "fr-ch" -> "fr_CH", "EN" -> "en". It reports false for values
+// that are not a two or three letter language with an optional region.
+func NormalizeLocale(tag string) (string, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "-", "_")
+	language, region, hasRegion := strings.Cut(tag, "_")
+	language = strings.ToLower(language)
+	if !isLetters(language, 2, 3) {
+		return "", false
+	}
+	if !hasRegion {
+		return language, true
+	}
+	region = strings.ToUpper(region)
+	if !isLetters(region, 2, 2) {
+		return "", false
+	}
+	return language + "_" + region, true
+}
+
+// Language returns the language part of a locale ("fr_CH" -> "fr").
+func Language(locale string) string {
+	language, _, _ := strings.Cut(locale, "_")
+	return language
+}
+
+// Match picks the available locale that best serves requested: the exact
+// locale, then the bare language, then another region of the same language.
+func Match(available []string, requested string) (string, bool) {
+	requested, ok := NormalizeLocale(requested)
+	if !ok {
+		return "", false
+	}
+	candidates := append([]string(nil), available...)
+	sort.Strings(candidates)
+	language := Language(requested)
+	for _, want := range []func(string) bool{
+		func(locale string) bool { return locale == requested },
+		func(locale string) bool { return locale == language },
+		func(locale string) bool { return Language(locale) == language },
+	} {
+		for _, locale := range candidates {
+			if want(locale) {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header
+// ordered by quality. Tags with q=0 and the "*" wildcard are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		items = append(items, weighted{tag: tag, quality: quality})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].quality > items[j].quality })
+	tags := make([]string, len(items))
+	for i, item := range items {
+		tags[i] = item.tag
+	}
+	return tags
+}
+
+func isLetters(value string, minLength, maxLength int) bool {
+	if len(value) < minLength || len(value) > maxLength {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
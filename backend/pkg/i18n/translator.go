@@ -2,6 +2,7 @@ package i18n
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -35,27 +36,63 @@ func (t *Translator) LoadLanguage(lang string, translations map[string]any) erro
 	return nil
 }
 
-// Get retrieves a translated string for the given key and language.
-// If the translation is not found, it returns the key itself as fallback.
-// Falls back to defaultLang if language-specific translation is not found.
-func (t *Translator) Get(lang, key string) string {
-	// Try the requested language
-	if msgs, ok := t.translations[lang]; ok {
-		if msg, ok := msgs[key]; ok {
-			return msg
+// DefaultLocale returns the locale every lookup finally falls back to.
+func (t *Translator) DefaultLocale() string {
+	return t.defaultLang
+}
+
+// Locales returns the loaded locales in sorted order.
+func (t *Translator) Locales() []string {
+	locales := make([]string, 0, len(t.translations))
+	for locale := range t.translations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Keys returns the flattened keys of one locale in sorted order, without
+// any fallback.
+func (t *Translator) Keys(lang string) []string {
+	keys := make([]string, 0, len(t.translations[lang]))
+	for key := range t.translations[lang] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Negotiate returns the first preference a loaded catalog can serve, see
+// Match. It falls back to the default language.
+func (t *Translator) Negotiate(preferences ...string) string {
+	available := t.Locales()
+	for _, preference := range preferences {
+		if locale, ok := Match(available, preference); ok {
+			return locale
 		}
 	}
+	return t.defaultLang
+}
 
-	// Fallback to default language
-	if lang != t.defaultLang {
-		if msgs, ok := t.translations[t.defaultLang]; ok {
-			if msg, ok := msgs[key]; ok {
-				return msg
-			}
+// Lookup retrieves a translated string and reports whether any catalog in
+// the fallback chain of lang has it. See Get for the chain.
+func (t *Translator) Lookup(lang, key string) (string, bool) {
+	for _, locale := range t.fallbackChain(lang) {
+		if msg, ok := t.translations[locale][key]; ok {
+			return msg, true
 		}
 	}
+	return "", false
+}
 
-	// Return key as fallback
+// Get retrieves a translated string for the given key and language.
+// Lookups fall back from the locale to its bare language, to other regions
+// of the language ("fr" -> "fr_CH") and finally to defaultLang. If the
+// translation is not found, it returns the key itself as fallback.
+func (t *Translator) Get(lang, key string) string {
+	if msg, ok := t.Lookup(lang, key); ok {
+		return msg
+	}
 	return key
 }
 
@@ -63,18 +100,124 @@ func (t *Translator) Get(lang, key string) string {
 // Parameters are specified as {paramName} in the translation string.
 // Example: translator.GetWithParams("de_CH", "errors.welcome", map[string]string{"name": "Max"})
 func (t *Translator) GetWithParams(lang, key string, params map[string]string) string {
-	msg := t.Get(lang, key)
+	values := make(map[string]any, len(params))
 	for name, value := range params {
-		msg = strings.ReplaceAll(msg, "{"+name+"}", value)
+		values[name] = value
 	}
-	return msg
+	return Interpolate(t.Get(lang, key), values)
 }
 
 // GetPlural retrieves a pluralized translation based on count.
-// The translation key should follow pattern: "key.zero", "key.one", "key.other"
+// The translation key should follow pattern: "key.zero", "key.one", "key.other".
+// "zero" is optional; the "one" category follows the language rules, so
+// French uses it for 0 and 1 while German, Italian and English only use it
+// for 1.
 func (t *Translator) GetPlural(lang, key string, count int) string {
-	pluralKey := getPluralKey(key, count)
-	return t.Get(lang, pluralKey)
+	if msg, ok := t.lookupPlural(lang, key, count); ok {
+		return msg
+	}
+	return t.Get(lang, key)
+}
+
+// Translate retrieves a translated string, picks the plural form when params
+// contains an integer "count", and substitutes {name} and {{name}}
+// placeholders with params.
+func (t *Translator) Translate(lang, key string, params map[string]any) string {
+	if count, ok := pluralCount(params["count"]); ok {
+		if msg, ok := t.lookupPlural(lang, key, count); ok {
+			return Interpolate(msg, params)
+		}
+	}
+	return Interpolate(t.Get(lang, key), params)
+}
+
+// Interpolate substitutes {name} and {{name}} placeholders in msg.
+func Interpolate(msg string, params map[string]any) string {
+	for name, value := range params {
+		text := fmt.Sprint(value)
+		msg = strings.ReplaceAll(msg, "{{"+name+"}}", text)
+		msg = strings.ReplaceAll(msg, "{"+name+"}", text)
+	}
+	return msg
+}
+
+// lookupPlural tries the plural forms catalog by catalog, so a translated
+// "one" wins over a "zero" that only the default language defines.
+func (t *Translator) lookupPlural(lang, key string, count int) (string, bool) {
+	forms := make([]string, 0, 3)
+	if count == 0 {
+		forms = append(forms, key+".zero")
+	}
+	forms = append(forms, key+"."+PluralCategory(lang, count), key+".other")
+	for _, locale := range t.fallbackChain(lang) {
+		for _, form := range forms {
+			if msg, ok := t.translations[locale][form]; ok {
+				return msg, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (t *Translator) fallbackChain(lang string) []string {
+	chain := make([]string, 0, 4)
+	seen := make(map[string]struct{}, 4)
+	add := func(locale string) {
+		if _, ok := seen[locale]; ok || locale == "" {
+			return
+		}
+		seen[locale] = struct{}{}
+		chain = append(chain, locale)
+	}
+	add(lang)
+	if normalized, ok := NormalizeLocale(lang); ok {
+		add(normalized)
+		language := Language(normalized)
+		add(language)
+		for _, locale := range t.Locales() {
+			if Language(locale) == language {
+				add(locale)
+			}
+		}
+	}
+	add(t.defaultLang)
+	return chain
+}
+
+// PluralCategory returns the CLDR plural category ("one" or "other") of
+// count for the language of locale.
+func PluralCategory(locale string, count int) string {
+	if count < 0 {
+		count = -count
+	}
+	switch Language(locale) {
+	case "fr":
+		if count <= 1 {
+			return "one"
+		}
+	default:
+		if count == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+func pluralCount(value any) (int, bool) {
+	switch count := value.(type) {
+	case int:
+		return count, true
+	case int64:
+		return int(count), true
+	case int32:
+		return int(count), true
+	case uint:
+		return int(count), true
+	case uint64:
+		return int(count), true
+	default:
+		return 0, false
+	}
 }
 
 // flatten converts nested maps into dot-separated keys
@@ -95,14 +238,3 @@ func flatten(data map[string]any, prefix string, result map[string]string) {
 		}
 	}
 }
-
-// getPluralKey returns the appropriate plural form key
-func getPluralKey(key string, count int) string {
-	if count == 0 {
-		return key + ".zero"
-	}
-	if count == 1 {
-		return key + ".one"
-	}
-	return key + ".other"
-}
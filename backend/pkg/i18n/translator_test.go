@@ -0,0 +1,167 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTestTranslator(t *testing.T) *Translator {
+	t.Helper()
+	translator := NewTranslator("de_CH")
+	catalogs := map[string]map[string]any{
+		"de_CH": {
+			"errors": map[string]any{"not_found": "Nicht gefunden", "only_de": "Nur Deutsch"},
+			"items":  map[string]any{"zero": "Keine Einträge", "one": "{count} Eintrag", "other": "{count} Einträge"},
+		},
+		"fr_CH": {
+			"errors": map[string]any{"not_found": "Introuvable"},
+			"items":  map[string]any{"one": "{count} entrée", "other": "{count} entrées"},
+		},
+		"en": {
+			"errors": map[string]any{"not_found": "Not found", "welcome": "Welcome {{name}}"},
+		},
+	}
+	for locale, catalog := range catalogs {
+		if err := translator.LoadLanguage(locale, catalog); err != nil {
+			t.Fatalf("LoadLanguage(%s) returned error: %v", locale, err)
+		}
+	}
+	return translator
+}
+
+func TestGetFallsBackThroughLanguageToDefault(t *testing.T) {
+	translator := newTestTranslator(t)
+
+	cases := []struct {
+		lang, key, want string
+	}{
+		{"fr_CH", "errors.not_found", "Introuvable"},
+		{"fr-FR", "errors.not_found", "Introuvable"},
+		{"en_US", "errors.not_found", "Not found"},
+		{"fr_CH", "errors.only_de", "Nur Deutsch"},
+		{"it_CH", "errors.not_found", "Nicht gefunden"},
+		{"fr_CH", "errors.unknown", "errors.unknown"},
+	}
+	for _, tc := range cases {
+		if got := translator.Get(tc.lang, tc.key); got != tc.want {
+			t.Errorf("Get(%q, %q) = %q, want %q", tc.lang, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestTranslateUsesLanguagePluralRules(t *testing.T) {
+	translator := newTestTranslator(t)
+
+	cases := []struct {
+		lang  string
+		count int
+		want  string
+	}{
+		{"de_CH", 0, "Keine Einträge"},
+		{"de_CH", 1, "1 Eintrag"},
+		{"de_CH", 2, "2 Einträge"},
+		{"fr_CH", 0, "0 entrée"},
+		{"fr_CH", 1, "1 entrée"},
+		{"fr_CH", 2, "2 entrées"},
+	}
+	for _, tc := range cases {
+		if got := translator.Translate(tc.lang, "items", map[string]any{"count": tc.count}); got != tc.want {
+			t.Errorf("Translate(%q, items, %d) = %q, want %q", tc.lang, tc.count, got, tc.want)
+		}
+	}
+	if got := translator.Translate("en", "errors.welcome", map[string]any{"name": "Max"}); got != "Welcome Max" {
+		t.Fatalf("expected interpolated message, got %q", got)
+	}
+}
+
+func TestNegotiatePrefersAcceptLanguageOrder(t *testing.T) {
+	translator := newTestTranslator(t)
+
+	tags := ParseAcceptLanguage("it-CH;q=0.5, fr;q=0.8, en-GB, *;q=0.1, es;q=0")
+	if want := []string{"en-GB", "fr", "it-CH"}; !reflect.DeepEqual(tags, want) {
+		t.Fatalf("ParseAcceptLanguage = %v, want %v", tags, want)
+	}
+	if got := translator.Negotiate(tags...); got != "en" {
+		t.Fatalf("expected en, got %q", got)
+	}
+	if got := translator.Negotiate("fr"); got != "fr_CH" {
+		t.Fatalf("expected fr to match fr_CH, got %q", got)
+	}
+	if got := translator.Negotiate("es", "not a locale"); got != "de_CH" {
+		t.Fatalf("expected the default locale, got %q", got)
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	cases := map[string]string{"fr-ch": "fr_CH", "EN": "en", " de_CH ": "de_CH"}
+	for input, want := range cases {
+		if got, ok := NormalizeLocale(input); !ok || got != want {
+			t.Errorf("NormalizeLocale(%q) = %q, %v, want %q", input, got, ok, want)
+		}
+	}
+	for _, input := range []string{"", "x", "de_CHE", "../de"} {
+		if _, ok := NormalizeLocale(input); ok {
+			t.Errorf("NormalizeLocale(%q) accepted an invalid locale", input)
+		}
+	}
+}
+
+func TestLoaderLoadLocaleResolvesCatalog(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"de_ch.json": `{"errors":{"not_found":"Nicht gefunden"}}`,
+		"en.json":    `{"errors":{"not_found":"Not found"}}`,
+		"README.md":  `not a catalog`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	loader := NewLoader(dir)
+
+	locales, err := loader.Locales()
+	if err != nil {
+		t.Fatalf("Locales returned error: %v", err)
+	}
+	if want := []string{"de_CH", "en"}; !reflect.DeepEqual(locales, want) {
+		t.Fatalf("Locales = %v, want %v", locales, want)
+	}
+	if _, resolved, err := loader.LoadLocale("en-US"); err != nil || resolved != "en" {
+		t.Fatalf("LoadLocale(en-US) = %q, %v, want en", resolved, err)
+	}
+	if _, _, err := loader.LoadLocale("it_CH"); !errors.Is(err, ErrLocaleNotFound) {
+		t.Fatalf("expected ErrLocaleNotFound, got %v", err)
+	}
+}
+
+func TestCoverageReportsMissingUntranslatedAndUnused(t *testing.T) {
+	translator := NewTranslator("de_CH")
+	_ = translator.LoadLanguage("de_CH", map[string]any{
+		"errors":        map[string]any{"not_found": "Nicht gefunden", "legacy": "Alt"},
+		"notifications": map[string]any{"events": map[string]any{"done": map[string]any{"one": "Eins", "other": "Viele"}, "stale": "Alt"}},
+	})
+	_ = translator.LoadLanguage("en", map[string]any{
+		"errors": map[string]any{"not_found": "Not found", "extra": "Extra"},
+	})
+
+	report := Coverage(translator, "de_CH", []string{"errors.not_found", "notifications.events.done"}, []string{"notifications.events."})
+	if len(report) != 2 {
+		t.Fatalf("expected two locales, got %d", len(report))
+	}
+	german, english := report[0], report[1]
+	if !german.Complete() || !reflect.DeepEqual(german.Unused, []string{"notifications.events.stale"}) {
+		t.Fatalf("unexpected de_CH coverage: %+v", german)
+	}
+	if english.Complete() || !reflect.DeepEqual(english.Missing, []string{"notifications.events.done"}) {
+		t.Fatalf("unexpected en missing keys: %+v", english)
+	}
+	if !reflect.DeepEqual(english.Unused, []string{"errors.extra"}) {
+		t.Fatalf("unexpected en unused keys: %v", english.Unused)
+	}
+	if len(english.Untranslated) != 4 {
+		t.Fatalf("expected four untranslated keys, got %v", english.Untranslated)
+	}
+}
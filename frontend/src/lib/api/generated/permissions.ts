@@ -123,6 +123,7 @@ export const PERMISSIONS = [
   'user.read',
   'user.read_deleted',
   'user.update',
+  'webhook.manage',
 ] as const;
 
 export type PermissionName = (typeof PERMISSIONS)[number];
@@ -51,7 +51,7 @@ if ($appEnv -ne 'production') {
 }
 
 # Check for unfilled CHANGE_ME placeholders in required vars
-$requiredVars = @('JWT_SECRET', 'POSTGRES_USER', 'POSTGRES_PASSWORD', 'POSTGRES_DB',
+$requiredVars = @('JWT_SECRET', 'WEBHOOK_SECRET_KEY', 'POSTGRES_USER', 'POSTGRES_PASSWORD', 'POSTGRES_DB',
                   'POSTGRES_SSLMODE', 'TRUSTED_PROXIES', 'COOKIE_SECURE',
                   'DATABASE_URL', 'SEED_USER_EMAIL', 'SEED_USER_PASSWORD')
 foreach ($var in $requiredVars) {